@@ -0,0 +1,105 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressReference references an Ingress.
+type IngressReference struct {
+	// Namespace is the namespace of Ingress.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of Ingress.
+	Name string `json:"name"`
+}
+
+// ListenerInventory describes a Listener of LoadBalancer.
+type ListenerInventory struct {
+	// Port is the port on which the Listener is listening.
+	Port int64 `json:"port"`
+
+	// Protocol is the protocol of the Listener.
+	Protocol string `json:"protocol"`
+
+	// ListenerARN is the Amazon Resource Name of the Listener.
+	ListenerARN string `json:"listenerARN"`
+
+	// CertificateARNs are the Amazon Resource Names of certificates on the Listener, the first one is the default certificate.
+	// +optional
+	CertificateARNs []string `json:"certificateARNs,omitempty"`
+}
+
+// LoadBalancerInventoryEntry describes a LoadBalancer managed for an IngressGroup.
+type LoadBalancerInventoryEntry struct {
+	// IngressGroup is the ID of IngressGroup, either the explicit group name or namespace/name of an Ingress.
+	IngressGroup string `json:"ingressGroup"`
+
+	// Ingresses are the Ingresses that own the LoadBalancer.
+	Ingresses []IngressReference `json:"ingresses"`
+
+	// LoadBalancerARN is the Amazon Resource Name of the LoadBalancer.
+	LoadBalancerARN string `json:"loadBalancerARN"`
+
+	// DNSName is the DNS name of the LoadBalancer.
+	DNSName string `json:"dnsName"`
+
+	// Listeners are the Listeners of the LoadBalancer.
+	// +optional
+	Listeners []ListenerInventory `json:"listeners,omitempty"`
+
+	// TargetGroupARNs are the Amazon Resource Names of TargetGroups behind the LoadBalancer.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// LastUpdateTime is the last time the entry is updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// LoadBalancerInventoryStatus defines the observed state of LoadBalancerInventory
+type LoadBalancerInventoryStatus struct {
+	// LoadBalancers are the LoadBalancers managed for the IngressGroup.
+	// +optional
+	LoadBalancers []LoadBalancerInventoryEntry `json:"loadBalancers,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// LoadBalancerInventory is the Schema for the LoadBalancerInventories API
+type LoadBalancerInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status LoadBalancerInventoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerInventoryList contains a list of LoadBalancerInventory
+type LoadBalancerInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerInventory{}, &LoadBalancerInventoryList{})
+}
@@ -51,6 +51,18 @@ type ServiceReference struct {
 	Port intstr.IntOrString `json:"port"`
 }
 
+// ExternalTarget defines a static IP target outside the cluster.
+type ExternalTarget struct {
+	// IP is the IP address of the target.
+	// Both IPV4 or IPV6 address are accepted.
+	IP string `json:"ip"`
+
+	// Port is the port of the target.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int64 `json:"port"`
+}
+
 // IPBlock defines source/destination IPBlock in networking rules.
 type IPBlock struct {
 	// CIDR is the network CIDR.
@@ -145,6 +157,31 @@ type TargetGroupBindingSpec struct {
 	// ipAddressType specifies whether the target group is of type IPv4 or IPv6. If unspecified, it will be automatically inferred.
 	// +optional
 	IPAddressType *TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
+	// externalTargets is a list of static targets outside the cluster that will be registered into TargetGroup alongside the Pod endpoints.
+	// Only supported when TargetType is ip.
+	// +optional
+	ExternalTargets []ExternalTarget `json:"externalTargets,omitempty"`
+
+	// multiClusterTargetGroup denotes whether the TargetGroup is shared with controllers of other clusters.
+	// When enabled, the controller tracks the targets it registered and only deregisters those, targets registered by other clusters are left alone.
+	// +optional
+	MultiClusterTargetGroup bool `json:"multiClusterTargetGroup,omitempty"`
+}
+
+// TargetsStatus summarizes the registration state of targets in TargetGroup.
+type TargetsStatus struct {
+	// Registered is the number of desired targets registered in TargetGroup.
+	Registered int32 `json:"registered"`
+
+	// Healthy is the number of desired targets that are healthy in TargetGroup.
+	Healthy int32 `json:"healthy"`
+
+	// PendingRegistration is the number of desired targets that are not registered yet or still in initial state.
+	PendingRegistration int32 `json:"pendingRegistration"`
+
+	// Draining is the number of targets that are being deregistered from TargetGroup.
+	Draining int32 `json:"draining"`
 }
 
 // TargetGroupBindingStatus defines the observed state of TargetGroupBinding
@@ -152,6 +189,16 @@ type TargetGroupBindingStatus struct {
 	// The generation observed by the TargetGroupBinding controller.
 	// +optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// targets summarizes the registration state of targets in TargetGroup,
+	// so that rollout tooling can wait for load balancer convergence.
+	// +optional
+	Targets *TargetsStatus `json:"targets,omitempty"`
+
+	// ownedTargets are the unique IDs of targets registered by the controller of this cluster, in format of id:port.
+	// They're only tracked if multiClusterTargetGroup is enabled.
+	// +optional
+	OwnedTargets []string `json:"ownedTargets,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -161,6 +208,8 @@ type TargetGroupBindingStatus struct {
 // +kubebuilder:printcolumn:name="SERVICE-PORT",type="string",JSONPath=".spec.serviceRef.port",description="The Kubernetes Service's port"
 // +kubebuilder:printcolumn:name="TARGET-TYPE",type="string",JSONPath=".spec.targetType",description="The AWS TargetGroup's TargetType"
 // +kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".spec.targetGroupARN",description="The AWS TargetGroup's Amazon Resource Name",priority=1
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.targets.pendingRegistration",description="The number of targets pending registration",priority=1
+// +kubebuilder:printcolumn:name="DRAINING",type="integer",JSONPath=".status.targets.draining",description="The number of targets draining",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // TargetGroupBinding is the Schema for the TargetGroupBinding API
 type TargetGroupBinding struct {
@@ -0,0 +1,84 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForCiliumEndpointEvent constructs new enqueueRequestsForCiliumEndpointEvent.
+// targetsSyncTracker is optional, sync states of impacted TargetGroupBindings are forgotten if specified.
+func NewEnqueueRequestsForCiliumEndpointEvent(k8sClient client.Client, targetsSyncTracker targetgroupbinding.TargetsSyncTracker, logger logr.Logger) handler.EventHandler {
+	return &enqueueRequestsForCiliumEndpointEvent{
+		k8sClient:       k8sClient,
+		podEventHandler: NewEnqueueRequestsForPodEvent(k8sClient, targetsSyncTracker, logger),
+		logger:          logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForCiliumEndpointEvent)(nil)
+
+// enqueueRequestsForCiliumEndpointEvent enqueues the TargetGroupBindings of pod when the addressing of its CiliumEndpoint changes,
+// which supplies the pod IP with the cilium endpoint resolver.
+type enqueueRequestsForCiliumEndpointEvent struct {
+	k8sClient       client.Client
+	podEventHandler *enqueueRequestsForPodEvent
+	logger          logr.Logger
+}
+
+// Create is called in response to an create event - e.g. CiliumEndpoint Creation.
+func (h *enqueueRequestsForCiliumEndpointEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedTargetGroupBindings(queue, e.Object)
+}
+
+// Update is called in response to an update event -  e.g. CiliumEndpoint Updated.
+func (h *enqueueRequestsForCiliumEndpointEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	addressingOld := extractCiliumEndpointAddressing(e.ObjectOld)
+	addressingNew := extractCiliumEndpointAddressing(e.ObjectNew)
+	if !equality.Semantic.DeepEqual(addressingOld, addressingNew) {
+		h.enqueueImpactedTargetGroupBindings(queue, e.ObjectNew)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. CiliumEndpoint Deleted.
+func (h *enqueueRequestsForCiliumEndpointEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedTargetGroupBindings(queue, e.Object)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForCiliumEndpointEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedTargetGroupBindings enqueues the TargetGroupBindings of the pod with same name as ciliumEndpoint.
+func (h *enqueueRequestsForCiliumEndpointEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, ciliumEndpoint client.Object) {
+	pod := &corev1.Pod{}
+	if err := h.k8sClient.Get(context.Background(), k8s.NamespacedName(ciliumEndpoint), pod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			h.logger.Error(err, "failed to fetch pod", "ciliumEndpoint", k8s.NamespacedName(ciliumEndpoint))
+		}
+		return
+	}
+	h.podEventHandler.enqueueImpactedTargetGroupBindings(queue, pod)
+}
+
+// extractCiliumEndpointAddressing extracts the addressing of ciliumEndpoint, which contains the pod IPs.
+func extractCiliumEndpointAddressing(ciliumEndpoint client.Object) []interface{} {
+	u, ok := ciliumEndpoint.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	addressing, _, _ := unstructured.NestedSlice(u.Object, "status", "networking", "addressing")
+	return addressing
+}
@@ -0,0 +1,105 @@
+package eventhandlers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_enqueueRequestsForCiliumEndpointEvent_Update(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "pod-1",
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{
+				{ConditionType: "target-health.elbv2.k8s.aws/tgb-1"},
+			},
+		},
+	}
+	buildCiliumEndpoint := func(name string, ipv4 string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "cilium.io/v2",
+				"kind":       "CiliumEndpoint",
+				"metadata": map[string]interface{}{
+					"namespace": "awesome-ns",
+					"name":      name,
+				},
+				"status": map[string]interface{}{
+					"networking": map[string]interface{}{
+						"addressing": []interface{}{
+							map[string]interface{}{"ipv4": ipv4},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		ciliumEndpointOld *unstructured.Unstructured
+		ciliumEndpointNew *unstructured.Unstructured
+		wantRequests      []ctrl.Request
+	}{
+		{
+			name:              "addressing changed",
+			ciliumEndpointOld: buildCiliumEndpoint("pod-1", "10.0.0.1"),
+			ciliumEndpointNew: buildCiliumEndpoint("pod-1", "10.0.0.2"),
+			wantRequests: []ctrl.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}},
+			},
+		},
+		{
+			name:              "addressing unchanged",
+			ciliumEndpointOld: buildCiliumEndpoint("pod-1", "10.0.0.1"),
+			ciliumEndpointNew: buildCiliumEndpoint("pod-1", "10.0.0.1"),
+			wantRequests:      nil,
+		},
+		{
+			name:              "pod not found",
+			ciliumEndpointOld: buildCiliumEndpoint("pod-2", "10.0.0.1"),
+			ciliumEndpointNew: buildCiliumEndpoint("pod-2", "10.0.0.2"),
+			wantRequests:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(pod.DeepCopy()).Build()
+			targetsSyncTracker := targetgroupbinding.NewMockTargetsSyncTracker(ctrl)
+			for _, req := range tt.wantRequests {
+				targetsSyncTracker.EXPECT().Forget(req.NamespacedName)
+			}
+			h := NewEnqueueRequestsForCiliumEndpointEvent(k8sClient, targetsSyncTracker, &log.NullLogger{})
+			queue := controllertest.Queue{Interface: workqueue.New()}
+			h.Update(event.UpdateEvent{ObjectOld: tt.ciliumEndpointOld, ObjectNew: tt.ciliumEndpointNew}, queue)
+			gotRequests := testutils.ExtractCTRLRequestsFromQueue(queue)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests),
+				"diff", cmp.Diff(tt.wantRequests, gotRequests))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NewEnqueueRequestsForPodEvent constructs new enqueueRequestsForPodEvent.
+// targetsSyncTracker is optional, sync states of impacted TargetGroupBindings are forgotten if specified.
+func NewEnqueueRequestsForPodEvent(k8sClient client.Client, targetsSyncTracker targetgroupbinding.TargetsSyncTracker, logger logr.Logger) *enqueueRequestsForPodEvent {
+	return &enqueueRequestsForPodEvent{
+		k8sClient:          k8sClient,
+		targetsSyncTracker: targetsSyncTracker,
+		logger:             logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForPodEvent)(nil)
+
+// enqueueRequestsForPodEvent enqueues TargetGroupBindings when pod changes aren't reflected in Endpoints or EndpointSlices,
+// e.g. the deregistration of pod's targets is requested by its eviction, or the pod IP from CNI plugins changes.
+type enqueueRequestsForPodEvent struct {
+	k8sClient          client.Client
+	targetsSyncTracker targetgroupbinding.TargetsSyncTracker
+	logger             logr.Logger
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *enqueueRequestsForPodEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *enqueueRequestsForPodEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	podOld := e.ObjectOld.(*corev1.Pod)
+	podNew := e.ObjectNew.(*corev1.Pod)
+	if podOld.Annotations[k8s.AnnotationKeyDeregistrationRequestedAt] != podNew.Annotations[k8s.AnnotationKeyDeregistrationRequestedAt] ||
+		!equality.Semantic.DeepEqual(k8s.BuildPodNetworkAnnotations(podOld), k8s.BuildPodNetworkAnnotations(podNew)) {
+		h.enqueueImpactedTargetGroupBindings(queue, podNew)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *enqueueRequestsForPodEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile AutoScaling, or a WebHook.
+func (h *enqueueRequestsForPodEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	// nothing to do here
+}
+
+// enqueueImpactedTargetGroupBindings enqueues the TargetGroupBindings that registers pod as targets, which are
+// the TargetGroupBindings denoted by targetHealth readiness gates of pod, and IP TargetGroupBindings of services selecting pod.
+func (h *enqueueRequestsForPodEvent) enqueueImpactedTargetGroupBindings(queue workqueue.RateLimitingInterface, pod *corev1.Pod) {
+	tgbNames := sets.NewString(targetgroupbinding.FindTargetGroupBindingNamesForPod(pod)...)
+	tgbNames.Insert(h.findIPTargetGroupBindingNamesForPodServices(pod)...)
+	for _, tgbName := range tgbNames.List() {
+		tgbKey := types.NamespacedName{Namespace: pod.Namespace, Name: tgbName}
+		// pod changes don't change the backend version, thus targets must be reconciled regardless of it.
+		if h.targetsSyncTracker != nil {
+			h.targetsSyncTracker.Forget(tgbKey)
+		}
+		h.logger.V(1).Info("enqueue targetGroupBinding for pod event",
+			"pod", k8s.NamespacedName(pod),
+			"targetGroupBinding", tgbKey,
+		)
+		queue.Add(reconcile.Request{NamespacedName: tgbKey})
+	}
+}
+
+// findIPTargetGroupBindingNamesForPodServices returns the names of IP TargetGroupBindings that reference services selecting pod.
+func (h *enqueueRequestsForPodEvent) findIPTargetGroupBindingNamesForPodServices(pod *corev1.Pod) []string {
+	svcList := &corev1.ServiceList{}
+	if err := h.k8sClient.List(context.Background(), svcList, client.InNamespace(pod.Namespace)); err != nil {
+		h.logger.Error(err, "failed to fetch services")
+		return nil
+	}
+	var tgbNames []string
+	for _, svc := range svcList.Items {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		tgbList := &elbv2api.TargetGroupBindingList{}
+		if err := h.k8sClient.List(context.Background(), tgbList,
+			client.InNamespace(svc.Namespace),
+			client.MatchingFields{targetgroupbinding.IndexKeyServiceRefName: svc.Name}); err != nil {
+			h.logger.Error(err, "failed to fetch targetGroupBindings")
+			return nil
+		}
+		for _, tgb := range tgbList.Items {
+			if tgb.Spec.TargetType == nil || (*tgb.Spec.TargetType) != elbv2api.TargetTypeIP {
+				continue
+			}
+			tgbNames = append(tgbNames, tgb.Name)
+		}
+	}
+	return tgbNames
+}
@@ -0,0 +1,168 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/testutils"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_enqueueRequestsForPodEvent_Update(t *testing.T) {
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	buildPod := func(annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        "pod-1",
+				Labels:      map[string]string{"app": "awesome"},
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				ReadinessGates: []corev1.PodReadinessGate{
+					{ConditionType: "target-health.elbv2.k8s.aws/tgb-1"},
+					{ConditionType: "other.k8s.aws/cond-1"},
+				},
+			},
+		}
+	}
+	buildTGB := func(name string, targetType *elbv2api.TargetType) *elbv2api.TargetGroupBinding {
+		return &elbv2api.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+			},
+			Spec: elbv2api.TargetGroupBindingSpec{
+				TargetType: targetType,
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		podOld       *corev1.Pod
+		podNew       *corev1.Pod
+		services     []*corev1.Service
+		tgbs         []*elbv2api.TargetGroupBinding
+		wantRequests []ctrl.Request
+	}{
+		{
+			name:   "deregistration requested",
+			podOld: buildPod(nil),
+			podNew: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": "2021-10-01T00:00:00Z",
+			}),
+			wantRequests: []ctrl.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}},
+			},
+		},
+		{
+			name: "deregistration requested again",
+			podOld: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": "2021-10-01T00:00:00Z",
+			}),
+			podNew: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": "2021-10-01T00:10:00Z",
+			}),
+			wantRequests: []ctrl.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}},
+			},
+		},
+		{
+			name:         "other annotations changed",
+			podOld:       buildPod(nil),
+			podNew:       buildPod(map[string]string{"some-key": "some-value"}),
+			wantRequests: nil,
+		},
+		{
+			name:   "calico pod IP changed, pod selected by service",
+			podOld: buildPod(map[string]string{"cni.projectcalico.org/podIP": "192.168.1.1/32"}),
+			podNew: buildPod(map[string]string{"cni.projectcalico.org/podIP": "192.168.1.2/32"}),
+			services: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+					Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "awesome"}},
+				},
+			},
+			tgbs: []*elbv2api.TargetGroupBinding{
+				buildTGB("tgb-1", &ipTargetType),
+				buildTGB("tgb-2", &instanceTargetType),
+				buildTGB("tgb-3", &ipTargetType),
+			},
+			wantRequests: []ctrl.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}},
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-3"}},
+			},
+		},
+		{
+			name:   "multus network status changed, pod not selected by service",
+			podOld: buildPod(nil),
+			podNew: buildPod(map[string]string{"k8s.v1.cni.cncf.io/network-status": `[{"name":"macvlan-conf","ips":["10.1.1.1"]}]`}),
+			services: []*corev1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "other-svc"},
+					Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "other"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "headless-svc"},
+				},
+			},
+			wantRequests: []ctrl.Request{
+				{NamespacedName: types.NamespacedName{Namespace: "awesome-ns", Name: "tgb-1"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			targetsSyncTracker := targetgroupbinding.NewMockTargetsSyncTracker(ctrl)
+			for _, req := range tt.wantRequests {
+				targetsSyncTracker.EXPECT().Forget(req.NamespacedName)
+			}
+			k8sClient := mock_client.NewMockClient(ctrl)
+			if tt.wantRequests != nil {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), testutils.NewListOptionEquals(client.InNamespace("awesome-ns"))).DoAndReturn(
+					func(ctx context.Context, svcList *corev1.ServiceList, opts ...client.ListOption) error {
+						for _, svc := range tt.services {
+							svcList.Items = append(svcList.Items, *(svc.DeepCopy()))
+						}
+						return nil
+					},
+				)
+			}
+			if tt.tgbs != nil {
+				k8sClient.EXPECT().List(gomock.Any(), gomock.Any(), testutils.NewListOptionEquals(client.InNamespace("awesome-ns")),
+					testutils.NewListOptionEquals(client.MatchingFields{"spec.serviceRef.name": "awesome-svc"})).DoAndReturn(
+					func(ctx context.Context, tgbList *elbv2api.TargetGroupBindingList, opts ...client.ListOption) error {
+						for _, tgb := range tt.tgbs {
+							tgbList.Items = append(tgbList.Items, *(tgb.DeepCopy()))
+						}
+						return nil
+					},
+				)
+			}
+			h := NewEnqueueRequestsForPodEvent(k8sClient, targetsSyncTracker, &log.NullLogger{})
+			queue := controllertest.Queue{Interface: workqueue.New()}
+			h.Update(event.UpdateEvent{ObjectOld: tt.podOld, ObjectNew: tt.podNew}, queue)
+			gotRequests := testutils.ExtractCTRLRequestsFromQueue(queue)
+			assert.True(t, cmp.Equal(tt.wantRequests, gotRequests),
+				"diff", cmp.Diff(tt.wantRequests, gotRequests))
+		})
+	}
+}
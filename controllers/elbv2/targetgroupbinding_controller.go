@@ -25,9 +25,11 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	discv1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/elbv2/eventhandlers"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
@@ -49,7 +51,7 @@ const (
 
 // NewTargetGroupBindingReconciler constructs new targetGroupBindingReconciler
 func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder record.EventRecorder, finalizerManager k8s.FinalizerManager,
-	tgbResourceManager targetgroupbinding.ResourceManager, config config.ControllerConfig,
+	tgbResourceManager targetgroupbinding.ResourceManager, targetsSyncTracker targetgroupbinding.TargetsSyncTracker, config config.ControllerConfig,
 	logger logr.Logger) *targetGroupBindingReconciler {
 
 	return &targetGroupBindingReconciler{
@@ -57,11 +59,14 @@ func NewTargetGroupBindingReconciler(k8sClient client.Client, eventRecorder reco
 		eventRecorder:      eventRecorder,
 		finalizerManager:   finalizerManager,
 		tgbResourceManager: tgbResourceManager,
+		targetsSyncTracker: targetsSyncTracker,
 		logger:             logger,
 
 		maxConcurrentReconciles:    config.TargetGroupBindingMaxConcurrentReconciles,
 		maxExponentialBackoffDelay: config.TargetGroupBindingMaxExponentialBackoffDelay,
 		enableEndpointSlices:       config.EnableEndpointSlices,
+		endpointResolver:           config.EndpointResolver,
+		gracefulShutdownTimeout:    config.RuntimeConfig.GracefulShutdownTimeout,
 	}
 }
 
@@ -71,11 +76,15 @@ type targetGroupBindingReconciler struct {
 	eventRecorder      record.EventRecorder
 	finalizerManager   k8s.FinalizerManager
 	tgbResourceManager targetgroupbinding.ResourceManager
+	// targetsSyncTracker is nil if skipping targets reconcile for unchanged backends is disabled.
+	targetsSyncTracker targetgroupbinding.TargetsSyncTracker
 	logger             logr.Logger
 
 	maxConcurrentReconciles    int
 	maxExponentialBackoffDelay time.Duration
 	enableEndpointSlices       bool
+	endpointResolver           string
+	gracefulShutdownTimeout    time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=targetgroupbindings,verbs=get;list;watch;update;patch;create;delete
@@ -90,8 +99,12 @@ type targetGroupBindingReconciler struct {
 // +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
 
 func (r *targetGroupBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.logger.V(1).Info("Reconcile request", "name", req.Name)
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	// in-flight target registrations are completed upon shutdown rather than aborted.
+	ctx, cancel := runtime.NewGracefulShutdownContext(ctx, r.gracefulShutdownTimeout)
+	defer cancel()
+	ctx, logger := runtime.NewReconcileContext(ctx, r.logger, "targetGroupBinding", req.NamespacedName.String())
+	logger.V(1).Info("Reconcile request")
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), logger)
 }
 
 func (r *targetGroupBindingReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
@@ -112,11 +125,21 @@ func (r *targetGroupBindingReconciler) reconcileTargetGroupBinding(ctx context.C
 		return err
 	}
 
+	tgbOld := tgb.DeepCopy()
 	if err := r.tgbResourceManager.Reconcile(ctx, tgb); err != nil {
+		// targets status is still published while waiting for targets to converge,
+		// so that rollout tooling can observe pending registration and draining progress.
+		if isRequeueNeededError(err) {
+			if statusErr := r.updateTargetGroupBindingStatus(ctx, tgb, tgbOld); statusErr != nil {
+				r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", statusErr))
+				return statusErr
+			}
+		}
 		return err
 	}
 
-	if err := r.updateTargetGroupBindingStatus(ctx, tgb); err != nil {
+	tgb.Status.ObservedGeneration = aws.Int64(tgb.Generation)
+	if err := r.updateTargetGroupBindingStatus(ctx, tgb, tgbOld); err != nil {
 		r.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 		return err
 	}
@@ -139,18 +162,23 @@ func (r *targetGroupBindingReconciler) cleanupTargetGroupBinding(ctx context.Con
 	return nil
 }
 
-func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
-	if aws.Int64Value(tgb.Status.ObservedGeneration) == tgb.Generation {
+func (r *targetGroupBindingReconciler) updateTargetGroupBindingStatus(ctx context.Context, tgb *elbv2api.TargetGroupBinding, tgbOld *elbv2api.TargetGroupBinding) error {
+	if equality.Semantic.DeepEqual(tgb.Status, tgbOld.Status) {
 		return nil
 	}
-	tgbOld := tgb.DeepCopy()
-	tgb.Status.ObservedGeneration = aws.Int64(tgb.Generation)
 	if err := r.k8sClient.Status().Patch(ctx, tgb, client.MergeFrom(tgbOld)); err != nil {
 		return errors.Wrapf(err, "failed to update targetGroupBinding status: %v", k8s.NamespacedName(tgb))
 	}
 	return nil
 }
 
+// isRequeueNeededError checks whether err merely requests requeue instead of reporting failure.
+func isRequeueNeededError(err error) bool {
+	var requeueNeededAfter *runtime.RequeueNeededAfter
+	var requeueNeeded *runtime.RequeueNeeded
+	return errors.As(err, &requeueNeededAfter) || errors.As(err, &requeueNeeded)
+}
+
 func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	if err := r.setupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 		return err
@@ -160,35 +188,36 @@ func (r *targetGroupBindingReconciler) SetupWithManager(ctx context.Context, mgr
 		r.logger.WithName("eventHandlers").WithName("service"))
 	nodeEventsHandler := eventhandlers.NewEnqueueRequestsForNodeEvent(r.k8sClient,
 		r.logger.WithName("eventHandlers").WithName("node"))
+	podEventsHandler := eventhandlers.NewEnqueueRequestsForPodEvent(r.k8sClient, r.targetsSyncTracker,
+		r.logger.WithName("eventHandlers").WithName("pod"))
 
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&elbv2api.TargetGroupBinding{}).
+		Named(controllerName).
+		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler)
 	// Use the config flag to decide whether to use and watch an Endpoints event handler or an EndpointSlices event handler
 	if r.enableEndpointSlices {
 		epSliceEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointSlicesEvent(r.k8sClient,
 			r.logger.WithName("eventHandlers").WithName("endpointslices"))
-		return ctrl.NewControllerManagedBy(mgr).
-			For(&elbv2api.TargetGroupBinding{}).
-			Named(controllerName).
-			Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler).
-			Watches(&source.Kind{Type: &discv1.EndpointSlice{}}, epSliceEventsHandler).
-			Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
-			WithOptions(controller.Options{
-				MaxConcurrentReconciles: r.maxConcurrentReconciles,
-				RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, r.maxExponentialBackoffDelay)}).
-			Complete(r)
+		bldr = bldr.Watches(&source.Kind{Type: &discv1.EndpointSlice{}}, epSliceEventsHandler)
 	} else {
 		epsEventsHandler := eventhandlers.NewEnqueueRequestsForEndpointsEvent(r.k8sClient,
 			r.logger.WithName("eventHandlers").WithName("endpoints"))
-		return ctrl.NewControllerManagedBy(mgr).
-			For(&elbv2api.TargetGroupBinding{}).
-			Named(controllerName).
-			Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler).
-			Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler).
-			Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
-			WithOptions(controller.Options{
-				MaxConcurrentReconciles: r.maxConcurrentReconciles,
-				RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, r.maxExponentialBackoffDelay)}).
-			Complete(r)
+		bldr = bldr.Watches(&source.Kind{Type: &corev1.Endpoints{}}, epsEventsHandler)
+	}
+	bldr = bldr.
+		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventsHandler).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, podEventsHandler)
+	if r.endpointResolver == backend.EndpointResolverCilium {
+		ciliumEndpointEventsHandler := eventhandlers.NewEnqueueRequestsForCiliumEndpointEvent(r.k8sClient, r.targetsSyncTracker,
+			r.logger.WithName("eventHandlers").WithName("ciliumEndpoint"))
+		bldr = bldr.Watches(&source.Kind{Type: backend.NewCiliumEndpoint()}, ciliumEndpointEventsHandler)
 	}
+	return bldr.
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.maxConcurrentReconciles,
+			RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, r.maxExponentialBackoffDelay)}).
+		Complete(r)
 }
 
 func (r *targetGroupBindingReconciler) setupIndexes(ctx context.Context, fieldIndexer client.FieldIndexer) error {
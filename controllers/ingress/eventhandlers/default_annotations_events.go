@@ -0,0 +1,80 @@
+package eventhandlers
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForDefaultAnnotationsEvent constructs new enqueueRequestsForDefaultAnnotationsEvent.
+func NewEnqueueRequestsForDefaultAnnotationsEvent(ingEventChan chan<- event.GenericEvent,
+	k8sClient client.Client, logger logr.Logger) *enqueueRequestsForDefaultAnnotationsEvent {
+	return &enqueueRequestsForDefaultAnnotationsEvent{
+		ingEventChan: ingEventChan,
+		k8sClient:    k8sClient,
+		logger:       logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForDefaultAnnotationsEvent)(nil)
+
+// enqueueRequestsForDefaultAnnotationsEvent enqueues all Ingresses upon changes of the ConfigMap with default annotations,
+// since any Ingress might rely on default values.
+type enqueueRequestsForDefaultAnnotationsEvent struct {
+	ingEventChan chan<- event.GenericEvent
+	k8sClient    client.Client
+	logger       logr.Logger
+}
+
+func (h *enqueueRequestsForDefaultAnnotationsEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	cmNew := e.Object.(*corev1.ConfigMap)
+	h.enqueueImpactedIngresses(cmNew)
+}
+
+func (h *enqueueRequestsForDefaultAnnotationsEvent) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	cmOld := e.ObjectOld.(*corev1.ConfigMap)
+	cmNew := e.ObjectNew.(*corev1.ConfigMap)
+
+	// we only care below update event:
+	//	1. ConfigMap data updates
+	if equality.Semantic.DeepEqual(cmOld.Data, cmNew.Data) {
+		return
+	}
+
+	h.enqueueImpactedIngresses(cmNew)
+}
+
+func (h *enqueueRequestsForDefaultAnnotationsEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	cmOld := e.Object.(*corev1.ConfigMap)
+	h.enqueueImpactedIngresses(cmOld)
+}
+
+func (h *enqueueRequestsForDefaultAnnotationsEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for configMaps.
+}
+
+func (h *enqueueRequestsForDefaultAnnotationsEvent) enqueueImpactedIngresses(cm *corev1.ConfigMap) {
+	ingList := &networking.IngressList{}
+	if err := h.k8sClient.List(context.Background(), ingList); err != nil {
+		h.logger.Error(err, "failed to fetch ingresses")
+		return
+	}
+
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+
+		h.logger.V(1).Info("enqueue ingress for default annotations event",
+			"configMap", k8s.NamespacedName(cm),
+			"ingress", k8s.NamespacedName(ing))
+		h.ingEventChan <- event.GenericEvent{
+			Object: ing,
+		}
+	}
+}
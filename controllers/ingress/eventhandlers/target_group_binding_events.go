@@ -0,0 +1,66 @@
+package eventhandlers
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NewEnqueueRequestsForTargetGroupBindingEvent constructs new enqueueRequestsForTargetGroupBindingEvent.
+func NewEnqueueRequestsForTargetGroupBindingEvent(tagPrefix string, logger logr.Logger) *enqueueRequestsForTargetGroupBindingEvent {
+	return &enqueueRequestsForTargetGroupBindingEvent{
+		tagPrefix: tagPrefix,
+		logger:    logger,
+	}
+}
+
+var _ handler.EventHandler = (*enqueueRequestsForTargetGroupBindingEvent)(nil)
+
+// enqueueRequestsForTargetGroupBindingEvent enqueues the IngressGroup that manages a TargetGroupBinding
+// when the TargetGroupBinding controller requests to relax or restore the health check of its targetGroup.
+type enqueueRequestsForTargetGroupBindingEvent struct {
+	tagPrefix string
+	logger    logr.Logger
+}
+
+func (h *enqueueRequestsForTargetGroupBindingEvent) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	// TargetGroupBindings are created by IngressGroup reconcile.
+}
+
+func (h *enqueueRequestsForTargetGroupBindingEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	tgbOld := e.ObjectOld.(*elbv2api.TargetGroupBinding)
+	tgbNew := e.ObjectNew.(*elbv2api.TargetGroupBinding)
+
+	// we only care below update event:
+	//	1. health check relaxation changes
+	if targetgroupbinding.IsHealthCheckRelaxed(tgbOld) == targetgroupbinding.IsHealthCheckRelaxed(tgbNew) {
+		return
+	}
+	h.enqueueManagingGroup(queue, tgbNew)
+}
+
+func (h *enqueueRequestsForTargetGroupBindingEvent) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	// TargetGroupBindings are deleted by IngressGroup reconcile.
+}
+
+func (h *enqueueRequestsForTargetGroupBindingEvent) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// we don't have any generic event for TargetGroupBindings.
+}
+
+func (h *enqueueRequestsForTargetGroupBindingEvent) enqueueManagingGroup(queue workqueue.RateLimitingInterface, tgb *elbv2api.TargetGroupBinding) {
+	stackID, managed := tracking.StackIDFromLabels(h.tagPrefix, tgb.Labels)
+	if !managed {
+		return
+	}
+	groupID := ingress.GroupID(stackID)
+	h.logger.V(1).Info("enqueue ingressGroup for targetGroupBinding event",
+		"targetGroupBinding", k8s.NamespacedName(tgb),
+		"ingressGroup", groupID)
+	queue.Add(ingress.EncodeGroupIDToReconcileRequest(groupID))
+}
@@ -3,19 +3,26 @@ package ingress
 import (
 	"context"
 	"fmt"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/ingress/eventhandlers"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations/schema"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
@@ -29,6 +36,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
 )
 
 const (
@@ -41,31 +50,56 @@ const (
 )
 
 // NewGroupReconciler constructs new GroupReconciler
-func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder record.EventRecorder,
+func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, apiReader client.Reader, eventRecorder record.EventRecorder,
 	finalizerManager k8s.FinalizerManager, networkingSGManager networkingpkg.SecurityGroupManager,
 	networkingSGReconciler networkingpkg.SecurityGroupReconciler, subnetsResolver networkingpkg.SubnetsResolver,
-	config config.ControllerConfig, backendSGProvider networkingpkg.BackendSGProvider, logger logr.Logger) *groupReconciler {
+	config config.ControllerConfig, backendSGProvider networkingpkg.BackendSGProvider, groupClaimer ingress.GroupClaimer,
+	defaultAnnotationsRepo ingress.DefaultAnnotationsRepo, logger logr.Logger) *groupReconciler {
 
-	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	var annotationParser annotations.Parser = annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	if defaultAnnotationsRepo != nil {
+		annotationParser = annotations.NewDefaultsAnnotationParser(annotationParser, annotations.AnnotationPrefixIngress, defaultAnnotationsRepo)
+	}
+	// annotations of other Ingress controllers on Ingresses take precedence over default values.
+	if config.IngressConfig.EnableCompatibilityAnnotations {
+		annotationParser = annotations.NewCompatibilityAnnotationParser(annotationParser, annotations.AnnotationPrefixIngress)
+	}
 	authConfigBuilder := ingress.NewDefaultAuthConfigBuilder(annotationParser)
-	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder)
+	awsRequestConfigBuilder := ingress.NewDefaultAWSRequestConfigBuilder(annotationParser)
+	enhancedBackendBuilder := ingress.NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder, config.IngressConfig.Profile)
 	referenceIndexer := ingress.NewDefaultReferenceIndexer(enhancedBackendBuilder, authConfigBuilder, logger)
 	trackingProvider := tracking.NewDefaultProvider(ingressTagPrefix, config.ClusterName)
 	elbv2TaggingManager := elbv2deploy.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), config.FeatureGates, logger)
+	tgMetricsCollector := cloudwatchdeploy.NewDefaultTargetGroupMetricsCollector(cloud.ELBV2(), cloud.CloudWatch())
 	modelBuilder := ingress.NewDefaultModelBuilder(k8sClient, eventRecorder,
 		cloud.EC2(), cloud.ACM(),
 		annotationParser, subnetsResolver,
-		authConfigBuilder, enhancedBackendBuilder, trackingProvider, elbv2TaggingManager,
+		authConfigBuilder, enhancedBackendBuilder, trackingProvider, elbv2TaggingManager, tgMetricsCollector,
 		cloud.VpcID(), config.ClusterName, config.DefaultTags, config.ExternalManagedTags,
-		config.DefaultSSLPolicy, backendSGProvider, config.EnableBackendSecurityGroup, config.DisableRestrictedSGRules, logger)
+		config.DefaultSSLPolicy, backendSGProvider, config.EnableBackendSecurityGroup, config.DisableRestrictedSGRules, config.IngressConfig.EnableFargateTargetTypeFallback,
+		config.EnableEndpointSlices, config.IngressConfig.ResourceNameTemplate, logger)
 	stackMarshaller := deploy.NewDefaultStackMarshaller()
 	stackDeployer := deploy.NewDefaultStackDeployer(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
 		config, ingressTagPrefix, logger)
 	classLoader := ingress.NewDefaultClassLoader(k8sClient)
 	classAnnotationMatcher := ingress.NewDefaultClassAnnotationMatcher(config.IngressConfig.IngressClass)
 	manageIngressesWithoutIngressClass := config.IngressConfig.IngressClass == ""
-	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, classLoader, classAnnotationMatcher, manageIngressesWithoutIngressClass)
+	// the label selector has been validated along with the controller configuration.
+	ingressSelector, _ := labels.Parse(config.IngressConfig.LabelSelector)
+	groupLoader := ingress.NewDefaultGroupLoader(k8sClient, eventRecorder, annotationParser, classLoader, classAnnotationMatcher, manageIngressesWithoutIngressClass, ingressSelector)
 	groupFinalizerManager := ingress.NewDefaultFinalizerManager(finalizerManager)
+	var driftDetector ingress.DriftDetector
+	if config.IngressConfig.EnableDriftDetection {
+		driftDetector = ingress.NewDefaultDriftDetector(cloud.ELBV2(), logger)
+	}
+	inventoryManager := ingress.NewDefaultLoadBalancerInventoryManager(k8sClient, trackingProvider)
+	var metricsDimensionsPublisher ingress.MetricsDimensionsPublisher
+	if config.IngressConfig.EnableMetricsDimensions {
+		metricsDimensionsPublisher = ingress.NewDefaultMetricsDimensionsPublisher(k8sClient, apiReader, eventRecorder, enhancedBackendBuilder)
+	}
+	stackIDsLoader := newIngressStackIDsLoader(k8sClient, annotationParser, groupLoader)
+	orphanedResourceCollector := deploy.NewDefaultOrphanedResourceCollector(cloud, k8sClient, networkingSGManager, networkingSGReconciler,
+		stackIDsLoader, config, ingressTagPrefix, config.IngressConfig.GCDryRun, logger.WithName("orphaned-resource-collector"))
 
 	return &groupReconciler{
 		k8sClient:         k8sClient,
@@ -76,11 +110,27 @@ func NewGroupReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorder
 		stackDeployer:     stackDeployer,
 		backendSGProvider: backendSGProvider,
 
-		groupLoader:           groupLoader,
-		groupFinalizerManager: groupFinalizerManager,
-		logger:                logger,
+		annotationParser:        annotationParser,
+		awsRequestConfigBuilder: awsRequestConfigBuilder,
+		groupLoader:             groupLoader,
+		groupClaimer:            groupClaimer,
+		defaultAnnotationsRepo:  defaultAnnotationsRepo,
+		groupFinalizerManager:   groupFinalizerManager,
+		driftDetector:           driftDetector,
+		inventoryManager:        inventoryManager,
+		metricsDimensionsPub:    metricsDimensionsPublisher,
+		orphanedResourceGC:      newOrphanedResourceGC(orphanedResourceCollector, config.IngressConfig.GCInterval, logger.WithName("orphaned-resource-gc")),
+		logger:                  logger,
 
-		maxConcurrentReconciles: config.IngressConfig.MaxConcurrentReconciles,
+		ingressLocker:              runtime.NewKeyedMutex(),
+		enableInventory:            config.IngressConfig.EnableLoadBalancerInventory,
+		maxConcurrentReconciles:    config.IngressConfig.MaxConcurrentReconciles,
+		maxExponentialBackoffDelay: config.IngressConfig.MaxExponentialBackoffDelay,
+		strictIngressAnnotations:   config.IngressConfig.StrictIngressAnnotations,
+		route53RecordsEnabled:      config.AddonsConfig.Route53RecordsEnabled,
+		resyncPeriod:               config.IngressConfig.ResyncPeriod,
+		gcInterval:                 config.IngressConfig.GCInterval,
+		gracefulShutdownTimeout:    config.RuntimeConfig.GracefulShutdownTimeout,
 	}
 }
 
@@ -94,14 +144,34 @@ type groupReconciler struct {
 	stackDeployer     deploy.StackDeployer
 	backendSGProvider networkingpkg.BackendSGProvider
 
-	groupLoader           ingress.GroupLoader
-	groupFinalizerManager ingress.FinalizerManager
-	logger                logr.Logger
+	annotationParser        annotations.Parser
+	awsRequestConfigBuilder ingress.AWSRequestConfigBuilder
+	groupLoader             ingress.GroupLoader
+	groupClaimer            ingress.GroupClaimer
+	defaultAnnotationsRepo  ingress.DefaultAnnotationsRepo
+	groupFinalizerManager   ingress.FinalizerManager
+	driftDetector           ingress.DriftDetector
+	inventoryManager        ingress.LoadBalancerInventoryManager
+	metricsDimensionsPub    ingress.MetricsDimensionsPublisher
+	orphanedResourceGC      *orphanedResourceGC
+	logger                  logr.Logger
 
-	maxConcurrentReconciles int
+	// ingressLocker serializes reconciles touching the same Ingress across IngressGroups,
+	// e.g. when an Ingress moves between IngressGroups, while independent IngressGroups reconcile concurrently.
+	ingressLocker              *runtime.KeyedMutex
+	enableInventory            bool
+	maxConcurrentReconciles    int
+	maxExponentialBackoffDelay time.Duration
+	strictIngressAnnotations   bool
+	route53RecordsEnabled      bool
+	resyncPeriod               time.Duration
+	gcInterval                 time.Duration
+	gracefulShutdownTimeout    time.Duration
 }
 
 // +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=ingressclassparams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=loadbalancerinventories,verbs=get;create;delete
+// +kubebuilder:rbac:groups=elbv2.k8s.aws,resources=loadbalancerinventories/status,verbs=update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
@@ -110,23 +180,57 @@ type groupReconciler struct {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 
 func (r *groupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
+	// in-flight AWS API calls are completed upon shutdown rather than aborted, so that ALBs aren't left half configured.
+	ctx, cancel := runtime.NewGracefulShutdownContext(ctx, r.gracefulShutdownTimeout)
+	defer cancel()
+	ctx, logger := runtime.NewReconcileContext(ctx, r.logger, "ingressGroup", ingGroupID.String())
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), logger)
 }
 
 func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	ingGroupID := ingress.DecodeGroupIDFromReconcileRequest(req)
-	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
+	ingGroup, unlock, err := r.loadAndLockIngressGroup(ctx, ingGroupID)
 	if err != nil {
 		return err
 	}
+	defer unlock()
+	runtime.LoggerFromContext(ctx, r.logger).V(1).Info("reconciling ingressGroup",
+		"ingresses", buildIngressGroupMemberKeys(ingGroup))
+
+	waitDuration, err := r.groupClaimer.Claim(ctx, ingGroupID)
+	if err != nil {
+		return err
+	}
+	if waitDuration > 0 {
+		return runtime.NewRequeueNeededAfter("ingressGroup is claimed by another controller instance", waitDuration)
+	}
 
 	if err := r.groupFinalizerManager.AddGroupFinalizer(ctx, ingGroupID, ingGroup.Members); err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
-	_, lb, err := r.buildAndDeployModel(ctx, ingGroup)
+	if r.strictIngressAnnotations {
+		if err := r.checkUnknownAnnotations(ctx, ingGroup); err != nil {
+			return err
+		}
+	}
+	requestConfig, err := r.awsRequestConfigBuilder.Build(ctx, ingGroup)
+	if err != nil {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
+		return err
+	}
+	// AWS API calls made when reconciling IngressGroup honor its retry and timeout overrides,
+	// including the cleanup of AWS resources and backend security group once all its Ingresses are deleted.
+	ctx = aws.ContextWithRequestConfig(ctx, requestConfig)
+	if r.driftDetector != nil {
+		r.detectDrift(ctx, ingGroup)
+	}
+	stack, lb, requeueAfter, err := r.buildAndDeployModel(ctx, ingGroup)
 	if err != nil {
 		return err
 	}
@@ -140,6 +244,29 @@ func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
 			return err
 		}
+		if r.driftDetector != nil {
+			r.recordDriftSnapshot(ctx, ingGroup, lb)
+		}
+	} else if r.driftDetector != nil {
+		r.driftDetector.Forget(ingGroup.ID)
+	}
+	if err := r.updateIngressGroupLoadBalancerARNs(ctx, ingGroup, lb); err != nil {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+		return err
+	}
+	if r.route53RecordsEnabled {
+		if err := r.updateIngressGroupRoute53Records(ctx, ingGroup); err != nil {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateStatus, fmt.Sprintf("Failed update status due to %v", err))
+			return err
+		}
+	}
+	if r.enableInventory {
+		r.updateInventory(ctx, ingGroup, stack, lb)
+	}
+	if r.metricsDimensionsPub != nil {
+		if err := r.metricsDimensionsPub.Publish(ctx, ingGroup, stack, lb); err != nil {
+			return err
+		}
 	}
 
 	if len(ingGroup.Members) == 0 {
@@ -154,30 +281,143 @@ func (r *groupReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 			return err
 		}
 	}
+	if len(ingGroup.Members) == 0 {
+		if err := r.groupClaimer.Release(ctx, ingGroupID); err != nil {
+			return err
+		}
+	}
 
 	r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeNormal, k8s.IngressEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if requeueAfter > 0 && (r.resyncPeriod <= 0 || requeueAfter < r.resyncPeriod) {
+		return runtime.NewRequeueNeededAfter("target group weight ramp, canary analysis or listener certificate replacement in progress", requeueAfter)
+	}
+	if r.resyncPeriod > 0 && len(ingGroup.Members) > 0 {
+		return runtime.NewRequeueNeededAfter("periodic resync", r.resyncPeriod)
+	}
 	return nil
 }
 
-func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, error) {
-	stack, lb, err := r.modelBuilder.Build(ctx, ingGroup)
+// detectDrift emits an event describing out-of-band changes to the AWS resources of IngressGroup since last successful reconcile,
+// which will be reverted back by the deployment afterwards.
+func (r *groupReconciler) detectDrift(ctx context.Context, ingGroup ingress.Group) {
+	drifts, err := r.driftDetector.Detect(ctx, ingGroup.ID)
+	if err != nil {
+		runtime.LoggerFromContext(ctx, r.logger).Error(err, "failed to detect drift")
+		return
+	}
+	if len(drifts) != 0 {
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonDriftDetected,
+			fmt.Sprintf("Detected out-of-band changes, reverting: %v", strings.Join(drifts, ", ")))
+	}
+}
+
+// recordDriftSnapshot records the live state of the deployed LoadBalancer to detect drift in later reconciles.
+func (r *groupReconciler) recordDriftSnapshot(ctx context.Context, ingGroup ingress.Group, lb *elbv2model.LoadBalancer) {
+	lbARN, err := lb.LoadBalancerARN().Resolve(ctx)
+	if err != nil {
+		runtime.LoggerFromContext(ctx, r.logger).Error(err, "failed to resolve LoadBalancer ARN")
+		return
+	}
+	if err := r.driftDetector.Record(ctx, ingGroup.ID, lbARN); err != nil {
+		runtime.LoggerFromContext(ctx, r.logger).Error(err, "failed to record drift snapshot")
+		r.driftDetector.Forget(ingGroup.ID)
+	}
+}
+
+// updateInventory records the deployed LoadBalancer of IngressGroup into its LoadBalancerInventory,
+// or removes the LoadBalancerInventory once the IngressGroup no longer has a LoadBalancer.
+// the inventory is informational only, so failures are reported without failing the reconcile.
+func (r *groupReconciler) updateInventory(ctx context.Context, ingGroup ingress.Group, stack core.Stack, lb *elbv2model.LoadBalancer) {
+	var err error
+	if len(ingGroup.Members) > 0 && lb != nil {
+		err = r.inventoryManager.Update(ctx, ingGroup, stack, lb)
+	} else {
+		err = r.inventoryManager.Remove(ctx, ingGroup.ID)
+	}
+	if err != nil {
+		runtime.LoggerFromContext(ctx, r.logger).Error(err, "failed to update loadBalancerInventory")
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedUpdateInventory, fmt.Sprintf("Failed update inventory due to %v", err))
+	}
+}
+
+func (r *groupReconciler) buildAndDeployModel(ctx context.Context, ingGroup ingress.Group) (core.Stack, *elbv2model.LoadBalancer, time.Duration, error) {
+	stack, lb, requeueAfter, err := r.modelBuilder.Build(ctx, ingGroup)
 	if err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 	stackJSON, err := r.stackMarshaller.Marshal(stack)
 	if err != nil {
 		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
-	r.logger.Info("successfully built model", "model", stackJSON)
+	logger := runtime.LoggerFromContext(ctx, r.logger)
+	logger.Info("successfully built model", "model", stackJSON)
 
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
-		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
-		return nil, nil, err
+		var requeueNeededAfter *runtime.RequeueNeededAfter
+		if !errors.As(err, &requeueNeededAfter) {
+			r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
+			return nil, nil, 0, err
+		}
+		// the model is deployed, except for steps that are completed by a later reconcile, e.g. removal of replaced certificates.
+		if requeueAfter == 0 || requeueNeededAfter.Duration() < requeueAfter {
+			requeueAfter = requeueNeededAfter.Duration()
+		}
+	}
+	logger.Info("successfully deployed model")
+	return stack, lb, requeueAfter, err
+}
+
+// checkUnknownAnnotations fails the reconcile if any member Ingress contains annotations unknown to this controller.
+func (r *groupReconciler) checkUnknownAnnotations(ctx context.Context, ingGroup ingress.Group) error {
+	for _, member := range ingGroup.Members {
+		unknownAnnotations := schema.FindUnknownIngressAnnotations(member.Ing.Annotations)
+		if len(unknownAnnotations) == 0 {
+			continue
+		}
+		err := errors.Errorf("ingress %v contains unknown annotations: %v", k8s.NamespacedName(member.Ing), strings.Join(unknownAnnotations, ","))
+		r.recordIngressGroupEvent(ctx, ingGroup, corev1.EventTypeWarning, k8s.IngressEventReasonUnknownAnnotations, err.Error())
+		return err
 	}
-	r.logger.Info("successfully deployed model", "ingressGroup", ingGroup.ID)
-	return stack, lb, err
+	return nil
+}
+
+// loadAndLockIngressGroup loads the IngressGroup and acquires the locks of its members, and returns a function to release them.
+// the IngressGroup is reloaded once the locks are held, as its members may have changed while waiting for them,
+// and the locks are re-acquired until they cover all members of the reloaded IngressGroup.
+func (r *groupReconciler) loadAndLockIngressGroup(ctx context.Context, ingGroupID ingress.GroupID) (ingress.Group, func(), error) {
+	ingGroup, err := r.groupLoader.Load(ctx, ingGroupID)
+	if err != nil {
+		return ingress.Group{}, nil, err
+	}
+	lockedKeys := sets.NewString(buildIngressGroupMemberKeys(ingGroup)...)
+	for {
+		unlock := r.ingressLocker.LockAll(lockedKeys.List())
+		ingGroup, err = r.groupLoader.Load(ctx, ingGroupID)
+		if err != nil {
+			unlock()
+			return ingress.Group{}, nil, err
+		}
+		memberKeys := sets.NewString(buildIngressGroupMemberKeys(ingGroup)...)
+		if lockedKeys.IsSuperset(memberKeys) {
+			return ingGroup, unlock, nil
+		}
+		unlock()
+		lockedKeys = lockedKeys.Union(memberKeys)
+	}
+}
+
+// buildIngressGroupMemberKeys returns the keys of all active and inactive members of IngressGroup.
+func buildIngressGroupMemberKeys(ingGroup ingress.Group) []string {
+	keys := make([]string, 0, len(ingGroup.Members)+len(ingGroup.InactiveMembers))
+	for _, member := range ingGroup.Members {
+		keys = append(keys, k8s.NamespacedName(member.Ing).String())
+	}
+	for _, inactiveMember := range ingGroup.InactiveMembers {
+		keys = append(keys, k8s.NamespacedName(inactiveMember).String())
+	}
+	return keys
 }
 
 func (r *groupReconciler) recordIngressGroupEvent(_ context.Context, ingGroup ingress.Group, eventType string, reason string, message string) {
@@ -212,9 +452,70 @@ func (r *groupReconciler) updateIngressStatus(ctx context.Context, lbDNS string,
 	return nil
 }
 
+// updateIngressGroupRoute53Records records the Route 53 alias records deployed for members on them, and clears the records of inactive members,
+// so that records can be deleted once they are no longer desired.
+func (r *groupReconciler) updateIngressGroupRoute53Records(ctx context.Context, ingGroup ingress.Group) error {
+	for _, member := range ingGroup.Members {
+		record, err := ingress.BuildIngressRoute53Record(r.annotationParser, member.Ing)
+		if err != nil {
+			return err
+		}
+		if err := r.updateIngressRecordAnnotation(ctx, ingress.AnnotationKeyRoute53Record, record, member.Ing); err != nil {
+			return err
+		}
+	}
+	for _, inactiveMember := range ingGroup.InactiveMembers {
+		if err := r.updateIngressRecordAnnotation(ctx, ingress.AnnotationKeyRoute53Record, "", inactiveMember); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateIngressGroupLoadBalancerARNs records the existing LoadBalancer that IngressGroup is bound to via load-balancer-arn annotation on members,
+// and clears the records of inactive members, so that listeners created on it can be deleted once IngressGroup is no longer bound to it.
+func (r *groupReconciler) updateIngressGroupLoadBalancerARNs(ctx context.Context, ingGroup ingress.Group, lb *elbv2model.LoadBalancer) error {
+	existingLBARN := ""
+	if lb != nil {
+		existingLBARN = awssdk.StringValue(lb.Spec.ExistingLoadBalancerARN)
+	}
+	for _, member := range ingGroup.Members {
+		if err := r.updateIngressRecordAnnotation(ctx, ingress.AnnotationKeyLoadBalancerARN, existingLBARN, member.Ing); err != nil {
+			return err
+		}
+	}
+	for _, inactiveMember := range ingGroup.InactiveMembers {
+		if err := r.updateIngressRecordAnnotation(ctx, ingress.AnnotationKeyLoadBalancerARN, "", inactiveMember); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateIngressRecordAnnotation sets the annotation recording deployed AWS resources on Ingress to value, or removes it if value is empty.
+func (r *groupReconciler) updateIngressRecordAnnotation(ctx context.Context, key string, value string, ing *networking.Ingress) error {
+	if ing.Annotations[key] == value {
+		return nil
+	}
+	ingOld := ing.DeepCopy()
+	if value == "" {
+		delete(ing.Annotations, key)
+	} else {
+		if ing.Annotations == nil {
+			ing.Annotations = make(map[string]string)
+		}
+		ing.Annotations[key] = value
+	}
+	if err := r.k8sClient.Patch(ctx, ing, client.MergeFrom(ingOld)); client.IgnoreNotFound(err) != nil {
+		return errors.Wrapf(err, "failed to record %v annotation on ingress: %v", key, k8s.NamespacedName(ing))
+	}
+	return nil
+}
+
 func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, clientSet *kubernetes.Clientset) error {
 	c, err := controller.New(controllerName, mgr, controller.Options{
 		MaxConcurrentReconciles: r.maxConcurrentReconciles,
+		RateLimiter:             buildIngressGroupRateLimiter(r.maxExponentialBackoffDelay),
 		Reconciler:              r,
 	})
 	if err != nil {
@@ -232,9 +533,23 @@ func (r *groupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager
 	if err := r.setupWatches(ctx, c, ingressClassResourceAvailable); err != nil {
 		return err
 	}
+	if r.gcInterval > 0 {
+		if err := mgr.Add(r.orphanedResourceGC); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// buildIngressGroupRateLimiter builds the rate limiter of IngressGroup reconciles,
+// which caps the per-item exponential backoff at maxExponentialBackoffDelay, while keeping the overall 10 qps / 100 burst limit of controller-runtime's default.
+func buildIngressGroupRateLimiter(maxExponentialBackoffDelay time.Duration) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, maxExponentialBackoffDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
 func (r *groupReconciler) setupIndexes(ctx context.Context, fieldIndexer client.FieldIndexer, ingressClassResourceAvailable bool) error {
 	if err := fieldIndexer.IndexField(ctx, &networking.Ingress{}, ingress.IndexKeyServiceRefName,
 		func(obj client.Object) []string {
@@ -300,6 +615,18 @@ func (r *groupReconciler) setupWatches(_ context.Context, c controller.Controlle
 	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, secretEventHandler); err != nil {
 		return err
 	}
+	tgbEventHandler := eventhandlers.NewEnqueueRequestsForTargetGroupBindingEvent(ingressTagPrefix,
+		r.logger.WithName("eventHandlers").WithName("targetGroupBinding"))
+	if err := c.Watch(&source.Kind{Type: &elbv2api.TargetGroupBinding{}}, tgbEventHandler); err != nil {
+		return err
+	}
+	if r.defaultAnnotationsRepo != nil {
+		defaultAnnotationsEventHandler := eventhandlers.NewEnqueueRequestsForDefaultAnnotationsEvent(ingEventChan, r.k8sClient,
+			r.logger.WithName("eventHandlers").WithName("defaultAnnotations"))
+		if err := c.Watch(&source.Informer{Informer: r.defaultAnnotationsRepo.Informer()}, defaultAnnotationsEventHandler); err != nil {
+			return err
+		}
+	}
 
 	if ingressClassResourceAvailable {
 		ingClassEventChan := make(chan event.GenericEvent)
@@ -0,0 +1,277 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/fake"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	scaleTestRegion      = "us-west-2"
+	scaleTestVPCID       = "vpc-0123456789abcdef0"
+	scaleTestVPCCIDR     = "192.168.0.0/16"
+	scaleTestClusterName = "scale-test"
+	scaleTestNamespace   = "scale-test"
+)
+
+// Test_groupReconciler_Reconcile_scale reconciles hundreds of Ingresses against in-memory fakes of AWS APIs,
+// to catch regressions in diff logic and in the number of AWS API calls made per Ingress.
+func Test_groupReconciler_Reconcile_scale(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scale test in short mode")
+	}
+	const (
+		ingressCount = 200
+		// every httpsEvery-th Ingress listens on HTTPS as well, with certificate discovered from ACM.
+		httpsEvery = 4
+	)
+	httpsIngressCount := ingressCount / httpsEvery
+
+	ctx := context.Background()
+	cloud := newScaleTestCloud()
+	k8sClient := newScaleTestK8sClient()
+	r := newScaleTestGroupReconciler(t, cloud, k8sClient)
+
+	var ingKeys []types.NamespacedName
+	for i := 0; i < ingressCount; i++ {
+		ing, svc := buildScaleTestIngressAndService(i, i%httpsEvery == 0)
+		if i%httpsEvery == 0 {
+			cloud.FakeACM().AddCertificate(fmt.Sprintf("arn:aws:acm:%v:123456789012:certificate/cert-%04d", scaleTestRegion, i), ing.Spec.Rules[0].Host)
+		}
+		require.NoError(t, k8sClient.Create(ctx, svc))
+		require.NoError(t, k8sClient.Create(ctx, ing))
+		ingKeys = append(ingKeys, k8s.NamespacedName(ing))
+	}
+
+	t.Run("creates AWS resources for all Ingresses", func(t *testing.T) {
+		cloud.ResetAPICallCounts()
+		reconcileScaleTestIngresses(t, r, ingKeys)
+
+		lbs, err := cloud.ELBV2().DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+		require.NoError(t, err)
+		assert.Len(t, lbs, ingressCount)
+		tgs, err := cloud.ELBV2().DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{})
+		require.NoError(t, err)
+		assert.Len(t, tgs, ingressCount)
+		for _, ingKey := range ingKeys {
+			ing := &networking.Ingress{}
+			require.NoError(t, k8sClient.Get(ctx, ingKey, ing))
+			assert.Len(t, ing.Status.LoadBalancer.Ingress, 1, "status of ingress %v", ingKey)
+		}
+
+		counts := cloud.APICallCounts()
+		assert.Equal(t, ingressCount, counts["ELBV2.CreateLoadBalancer"])
+		assert.Equal(t, ingressCount, counts["ELBV2.CreateTargetGroup"])
+		assert.Equal(t, ingressCount+httpsIngressCount, counts["ELBV2.CreateListener"])
+		assert.Equal(t, ingressCount+httpsIngressCount, counts["ELBV2.CreateRule"])
+		assert.Equal(t, ingressCount+1, counts["EC2.CreateSecurityGroup"], "managed security group per Ingress and a shared backend security group")
+		assertAPICallsPerIngressAtMost(t, counts, ingressCount, 25)
+	})
+
+	t.Run("makes no changes when nothing changed", func(t *testing.T) {
+		cloud.ResetAPICallCounts()
+		reconcileScaleTestIngresses(t, r, ingKeys)
+
+		counts := cloud.APICallCounts()
+		for api, count := range counts {
+			assert.False(t, fake.IsMutatingAPI(api), "unexpected %v calls to %v", count, api)
+		}
+		assertAPICallsPerIngressAtMost(t, counts, ingressCount, 20)
+	})
+
+	t.Run("deletes AWS resources of deleted Ingresses", func(t *testing.T) {
+		cloud.ResetAPICallCounts()
+		// Ingresses are deleted one by one, as the shared backend security group is only released along with the last Ingress.
+		for _, ingKey := range ingKeys {
+			ing := &networking.Ingress{}
+			require.NoError(t, k8sClient.Get(ctx, ingKey, ing))
+			require.NoError(t, k8sClient.Delete(ctx, ing))
+			reconcileScaleTestIngresses(t, r, []types.NamespacedName{ingKey})
+		}
+
+		ingList := &networking.IngressList{}
+		require.NoError(t, k8sClient.List(ctx, ingList))
+		assert.Empty(t, ingList.Items)
+		lbs, err := cloud.ELBV2().DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+		require.NoError(t, err)
+		assert.Empty(t, lbs)
+		tgs, err := cloud.ELBV2().DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{})
+		require.NoError(t, err)
+		assert.Empty(t, tgs)
+		sgs, err := cloud.EC2().DescribeSecurityGroupsAsList(ctx, &ec2sdk.DescribeSecurityGroupsInput{})
+		require.NoError(t, err)
+		assert.Empty(t, sgs)
+
+		counts := cloud.APICallCounts()
+		assert.Equal(t, ingressCount, counts["ELBV2.DeleteLoadBalancer"])
+		assert.Equal(t, ingressCount, counts["ELBV2.DeleteTargetGroup"])
+		assert.Equal(t, ingressCount+1, counts["EC2.DeleteSecurityGroup"])
+	})
+}
+
+// reconcileScaleTestIngresses reconciles the implicit IngressGroup of each Ingress.
+func reconcileScaleTestIngresses(t *testing.T, r *groupReconciler, ingKeys []types.NamespacedName) {
+	for _, ingKey := range ingKeys {
+		req := ingress.EncodeGroupIDToReconcileRequest(ingress.NewGroupIDForImplicitGroup(ingKey))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err, "reconcile ingress %v", ingKey)
+	}
+}
+
+// assertAPICallsPerIngressAtMost asserts the average number of AWS API calls made per Ingress reconcile.
+// DescribeTags calls are bounded separately, as LoadBalancers and TargetGroups within the VPC are listed by tags
+// in batches of 20 up to three times per reconcile, in addition to listing listeners and rules of the LoadBalancer.
+func assertAPICallsPerIngressAtMost(t *testing.T, counts map[string]int, ingressCount int, maxCallsPerIngress int) {
+	totalCount := 0
+	for api, count := range counts {
+		if api != "ELBV2.DescribeTags" {
+			totalCount += count
+		}
+	}
+	assert.LessOrEqual(t, totalCount, ingressCount*maxCallsPerIngress, "AWS API calls: %v", counts)
+	maxDescribeTagsCallsPerIngress := 3*((ingressCount+19)/20) + 4
+	assert.LessOrEqual(t, counts["ELBV2.DescribeTags"], ingressCount*maxDescribeTagsCallsPerIngress, "AWS API calls: %v", counts)
+}
+
+func newScaleTestCloud() *fake.Cloud {
+	cloud := fake.NewCloud(scaleTestRegion, scaleTestVPCID, scaleTestVPCCIDR)
+	for i, zone := range []string{"a", "b", "c"} {
+		cloud.FakeEC2().AddSubnet(&ec2sdk.Subnet{
+			AvailabilityZone:        awssdk.String(scaleTestRegion + zone),
+			AvailabilityZoneId:      awssdk.String(fmt.Sprintf("usw2-az%d", i+1)),
+			AvailableIpAddressCount: awssdk.Int64(4000),
+			CidrBlock:               awssdk.String(fmt.Sprintf("192.168.%d.0/20", i*16)),
+			SubnetId:                awssdk.String(fmt.Sprintf("subnet-%017d", i+1)),
+			Tags: []*ec2sdk.Tag{
+				{
+					Key:   awssdk.String("kubernetes.io/role/elb"),
+					Value: awssdk.String("1"),
+				},
+			},
+			VpcId: awssdk.String(scaleTestVPCID),
+		})
+	}
+	return cloud
+}
+
+func newScaleTestK8sClient() client.Client {
+	k8sSchema := k8sruntime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	return testclient.NewFakeClientWithScheme(k8sSchema)
+}
+
+func newScaleTestGroupReconciler(t *testing.T, cloud *fake.Cloud, k8sClient client.Client) *groupReconciler {
+	controllerConfig := config.ControllerConfig{
+		FeatureGates: config.NewFeatureGates(),
+	}
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	controllerConfig.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{
+		"--cluster-name=" + scaleTestClusterName,
+		"--enable-waf=false",
+		"--enable-wafv2=false",
+		"--enable-shield=false",
+		"--listener-certificate-removal-grace-period=0",
+	}))
+
+	logger := &log.NullLogger{}
+	sgManager := networkingpkg.NewDefaultSecurityGroupManager(cloud.EC2(), logger)
+	sgReconciler := networkingpkg.NewDefaultSecurityGroupReconciler(sgManager, logger)
+	azInfoProvider := networkingpkg.NewDefaultAZInfoProvider(cloud.EC2(), logger)
+	subnetsResolver := networkingpkg.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), scaleTestClusterName, logger)
+	backendSGProvider := networkingpkg.NewBackendSGProvider(scaleTestClusterName, "", cloud.VpcID(), cloud.EC2(), k8sClient, nil, logger)
+	groupClaimer := ingress.NewDefaultGroupClaimer(k8sClient, k8sClient, scaleTestNamespace, "scale-test-controller", 0, logger)
+	return NewGroupReconciler(cloud, k8sClient, k8sClient, &record.FakeRecorder{}, k8s.NewDefaultFinalizerManager(k8sClient, logger),
+		sgManager, sgReconciler, subnetsResolver, controllerConfig, backendSGProvider, groupClaimer, nil, logger)
+}
+
+// buildScaleTestIngressAndService builds the index-th Ingress that routes a host to its own Service via IP targets.
+func buildScaleTestIngressAndService(index int, https bool) (*networking.Ingress, *corev1.Service) {
+	name := fmt.Sprintf("app-%04d", index)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: scaleTestNamespace,
+			Name:      name,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	listenPorts := `[{"HTTP": 80}]`
+	if https {
+		listenPorts = `[{"HTTP": 80}, {"HTTPS": 443}]`
+	}
+	pathType := networking.PathTypePrefix
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: scaleTestNamespace,
+			Name:      name,
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class":                "alb",
+				"alb.ingress.kubernetes.io/scheme":           "internet-facing",
+				"alb.ingress.kubernetes.io/target-type":      "ip",
+				"alb.ingress.kubernetes.io/listen-ports":     listenPorts,
+				"alb.ingress.kubernetes.io/healthcheck-path": "/healthz",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: fmt.Sprintf("%v.example.com", name),
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: name,
+											Port: networking.ServiceBackendPort{
+												Name: "http",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return ing, svc
+}
@@ -0,0 +1,116 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+)
+
+// sequenceGroupLoader returns groups in order upon each Load, and repeats the last one afterwards.
+type sequenceGroupLoader struct {
+	ingress.GroupLoader
+	groups    []ingress.Group
+	loadCount int
+}
+
+func (l *sequenceGroupLoader) Load(_ context.Context, _ ingress.GroupID) (ingress.Group, error) {
+	idx := l.loadCount
+	if idx >= len(l.groups) {
+		idx = len(l.groups) - 1
+	}
+	l.loadCount++
+	return l.groups[idx], nil
+}
+
+func Test_groupReconciler_loadAndLockIngressGroup(t *testing.T) {
+	groupID := ingress.NewGroupIDForExplicitGroup("awesome-group")
+	buildIngress := func(name string) *networking.Ingress {
+		return &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: name}}
+	}
+	groupWithIng1 := ingress.Group{
+		ID:      groupID,
+		Members: []ingress.ClassifiedIngress{{Ing: buildIngress("ing-1")}},
+	}
+	groupWithIng1And2 := ingress.Group{
+		ID:      groupID,
+		Members: []ingress.ClassifiedIngress{{Ing: buildIngress("ing-1")}, {Ing: buildIngress("ing-2")}},
+	}
+	groupWithIng2 := ingress.Group{
+		ID:              groupID,
+		Members:         []ingress.ClassifiedIngress{{Ing: buildIngress("ing-2")}},
+		InactiveMembers: []*networking.Ingress{buildIngress("ing-1")},
+	}
+	tests := []struct {
+		name          string
+		groups        []ingress.Group
+		want          ingress.Group
+		wantLoadCount int
+	}{
+		{
+			name:          "members unchanged while waiting for locks",
+			groups:        []ingress.Group{groupWithIng1},
+			want:          groupWithIng1,
+			wantLoadCount: 2,
+		},
+		{
+			name:          "members added while waiting for locks",
+			groups:        []ingress.Group{groupWithIng1, groupWithIng1And2, groupWithIng1And2},
+			want:          groupWithIng1And2,
+			wantLoadCount: 3,
+		},
+		{
+			name:          "members removed while waiting for locks",
+			groups:        []ingress.Group{groupWithIng1And2, groupWithIng1},
+			want:          groupWithIng1,
+			wantLoadCount: 2,
+		},
+		{
+			name:          "members replaced while waiting for locks",
+			groups:        []ingress.Group{groupWithIng1, groupWithIng2, groupWithIng2},
+			want:          groupWithIng2,
+			wantLoadCount: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupLoader := &sequenceGroupLoader{groups: tt.groups}
+			r := &groupReconciler{
+				groupLoader:   groupLoader,
+				ingressLocker: runtime.NewKeyedMutex(),
+			}
+			got, unlock, err := r.loadAndLockIngressGroup(context.Background(), groupID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantLoadCount, groupLoader.loadCount)
+
+			// the locks of all members are released.
+			unlock()
+			r.ingressLocker.LockAll(buildIngressGroupMemberKeys(groupWithIng1And2))()
+		})
+	}
+}
+
+func Test_buildIngressGroupRateLimiter(t *testing.T) {
+	rateLimiter := buildIngressGroupRateLimiter(16*time.Minute + 40*time.Second)
+
+	// the first 100 distinct items are within burst, thus only subject to the per-item exponential backoff.
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 5*time.Millisecond, rateLimiter.When(fmt.Sprintf("awesome-ns/ing-%d", i)))
+	}
+	// further items are limited to 10 qps overall.
+	assert.Greater(t, int64(rateLimiter.When("awesome-ns/ing-100")), int64(50*time.Millisecond))
+
+	// failures of the same item are still backed off exponentially, capped at the max backoff delay.
+	item := "awesome-ns/failing-ing"
+	for i := 0; i < 30; i++ {
+		rateLimiter.When(item)
+	}
+	assert.Equal(t, 16*time.Minute+40*time.Second, rateLimiter.When(item))
+}
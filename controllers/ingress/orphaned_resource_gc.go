@@ -0,0 +1,113 @@
+package ingress
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// newIngressStackIDsLoader constructs new ingressStackIDsLoader.
+func newIngressStackIDsLoader(k8sClient client.Client, annotationParser annotations.Parser, groupLoader ingress.GroupLoader) *ingressStackIDsLoader {
+	return &ingressStackIDsLoader{
+		k8sClient:        k8sClient,
+		annotationParser: annotationParser,
+		groupLoader:      groupLoader,
+	}
+}
+
+var _ deploy.ActiveStackIDsLoader = &ingressStackIDsLoader{}
+
+// ingressStackIDsLoader loads IDs of IngressGroups that still exist.
+// it's intentionally conservative: any IngressGroup that might be referenced by an Ingress is considered active,
+// regardless of the IngressClass of the Ingress.
+type ingressStackIDsLoader struct {
+	k8sClient        client.Client
+	annotationParser annotations.Parser
+	groupLoader      ingress.GroupLoader
+}
+
+func (l *ingressStackIDsLoader) LoadActiveStackIDs(ctx context.Context) (sets.String, error) {
+	ingList := &networking.IngressList{}
+	if err := l.k8sClient.List(ctx, ingList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ingresses")
+	}
+	ingClassParamsList := &elbv2api.IngressClassParamsList{}
+	if err := l.k8sClient.List(ctx, ingClassParamsList); err != nil {
+		return nil, errors.Wrap(err, "failed to list ingressClassParams")
+	}
+
+	activeStackIDs := sets.NewString()
+	for index := range ingList.Items {
+		ing := &ingList.Items[index]
+		activeStackIDs.Insert(ingress.NewGroupIDForImplicitGroup(k8s.NamespacedName(ing)).String())
+		groupName := ""
+		if exists := l.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &groupName, ing.Annotations); exists {
+			activeStackIDs.Insert(ingress.NewGroupIDForExplicitGroup(groupName).String())
+		}
+		for _, groupID := range l.groupLoader.LoadGroupIDsPendingFinalization(ctx, ing) {
+			activeStackIDs.Insert(groupID.String())
+		}
+	}
+	for _, ingClassParams := range ingClassParamsList.Items {
+		if ingClassParams.Spec.Group != nil {
+			activeStackIDs.Insert(ingress.NewGroupIDForExplicitGroup(ingClassParams.Spec.Group.Name).String())
+		}
+	}
+	return activeStackIDs, nil
+}
+
+// newOrphanedResourceGC constructs new orphanedResourceGC.
+func newOrphanedResourceGC(collector deploy.OrphanedResourceCollector, interval time.Duration, logger logr.Logger) *orphanedResourceGC {
+	return &orphanedResourceGC{
+		collector: collector,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+var _ manager.Runnable = &orphanedResourceGC{}
+var _ manager.LeaderElectionRunnable = &orphanedResourceGC{}
+
+// orphanedResourceGC periodically garbage collects AWS resources whose owning Ingresses no longer exist,
+// e.g. when the controller crashed in the middle of deleting an IngressGroup.
+type orphanedResourceGC struct {
+	collector deploy.OrphanedResourceCollector
+	interval  time.Duration
+	logger    logr.Logger
+}
+
+func (gc *orphanedResourceGC) Start(ctx context.Context) error {
+	// the first collection is delayed by one interval, so that IngressGroups pending deletion during controller startup
+	// get a chance to be finalized by the ingress controller itself.
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(gc.interval):
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		gc.logger.Info("collecting orphaned resources")
+		if err := gc.collector.Collect(ctx); err != nil {
+			gc.logger.Error(err, "failed to collect orphaned resources")
+			return
+		}
+		gc.logger.Info("collected orphaned resources")
+	}, gc.interval)
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader collects orphaned resources.
+func (gc *orphanedResourceGC) NeedLeaderElection() bool {
+	return true
+}
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
 )
 
 const (
@@ -60,6 +61,7 @@ func NewServiceReconciler(cloud aws.Cloud, k8sClient client.Client, eventRecorde
 		logger:          logger,
 
 		maxConcurrentReconciles: config.ServiceMaxConcurrentReconciles,
+		gracefulShutdownTimeout: config.RuntimeConfig.GracefulShutdownTimeout,
 	}
 }
 
@@ -77,6 +79,7 @@ type serviceReconciler struct {
 	logger          logr.Logger
 
 	maxConcurrentReconciles int
+	gracefulShutdownTimeout time.Duration
 }
 
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch
@@ -84,7 +87,11 @@ type serviceReconciler struct {
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *serviceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return runtime.HandleReconcileError(r.reconcile(ctx, req), r.logger)
+	// in-flight AWS API calls are completed upon shutdown rather than aborted, so that NLBs aren't left half configured.
+	ctx, cancel := runtime.NewGracefulShutdownContext(ctx, r.gracefulShutdownTimeout)
+	defer cancel()
+	ctx, logger := runtime.NewReconcileContext(ctx, r.logger, "service", req.NamespacedName.String())
+	return runtime.HandleReconcileError(r.reconcile(ctx, req), logger)
 }
 
 func (r *serviceReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
@@ -113,16 +120,21 @@ func (r *serviceReconciler) buildModel(ctx context.Context, svc *corev1.Service)
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedBuildModel, fmt.Sprintf("Failed build model due to %v", err))
 		return nil, nil, err
 	}
-	r.logger.Info("successfully built model", "model", stackJSON)
+	runtime.LoggerFromContext(ctx, r.logger).Info("successfully built model", "model", stackJSON)
 	return stack, lb, nil
 }
 
 func (r *serviceReconciler) deployModel(ctx context.Context, svc *corev1.Service, stack core.Stack) error {
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+		// the model is deployed if requeue is requested, with steps that are completed by a later reconcile.
+		var requeueNeededAfter *runtime.RequeueNeededAfter
+		if errors.As(err, &requeueNeededAfter) {
+			return err
+		}
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %v", err))
 		return err
 	}
-	r.logger.Info("successfully deployed model", "service", k8s.NamespacedName(svc))
+	runtime.LoggerFromContext(ctx, r.logger).Info("successfully deployed model")
 
 	return nil
 }
@@ -132,8 +144,9 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning, k8s.ServiceEventReasonFailedAddFinalizer, fmt.Sprintf("Failed add finalizer due to %v", err))
 		return err
 	}
+	var requeueNeededAfter *runtime.RequeueNeededAfter
 	err := r.deployModel(ctx, svc, stack)
-	if err != nil {
+	if err != nil && !errors.As(err, &requeueNeededAfter) {
 		return err
 	}
 	lbDNS, err := lb.DNSName().Resolve(ctx)
@@ -146,6 +159,9 @@ func (r *serviceReconciler) reconcileLoadBalancerResources(ctx context.Context,
 		return err
 	}
 	r.eventRecorder.Event(svc, corev1.EventTypeNormal, k8s.ServiceEventReasonSuccessfullyReconciled, "Successfully reconciled")
+	if requeueNeededAfter != nil {
+		return requeueNeededAfter
+	}
 	return nil
 }
 
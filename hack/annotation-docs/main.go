@@ -0,0 +1,44 @@
+// annotation-docs regenerates the annotations reference table in Ingress annotations docs from annotation schema.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations/schema"
+)
+
+const (
+	beginMarker = "<!-- BEGIN GENERATED ANNOTATIONS TABLE -->\n"
+	endMarker   = "<!-- END GENERATED ANNOTATIONS TABLE -->\n"
+)
+
+func main() {
+	docsPath := flag.String("docs", "docs/guide/ingress/annotations.md", "path to Ingress annotations docs")
+	flag.Parse()
+
+	if err := generate(*docsPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(docsPath string) error {
+	content, err := ioutil.ReadFile(docsPath)
+	if err != nil {
+		return err
+	}
+	docs := string(content)
+	begin := strings.Index(docs, beginMarker)
+	end := strings.Index(docs, endMarker)
+	if begin < 0 || end < begin {
+		return fmt.Errorf("annotations table markers not found in %v", docsPath)
+	}
+	table := schema.RenderMarkdownTable(annotations.AnnotationPrefixIngress, schema.IngressFields)
+	generated := docs[:begin+len(beginMarker)] + table + docs[end:]
+	return ioutil.WriteFile(docsPath, []byte(generated), 0644)
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/pflag"
 	zapraw "go.uber.org/zap"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -32,7 +36,12 @@ import (
 	"sigs.k8s.io/aws-load-balancer-controller/controllers/service"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/throttle"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/awscontext"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	ingresspkg "sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
@@ -43,12 +52,22 @@ import (
 	elbv2webhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/elbv2"
 	networkingwebhook "sigs.k8s.io/aws-load-balancer-controller/webhooks/networking"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	// +kubebuilder:scaffold:imports
 )
 
+const (
+	// logLevelHandlerPath is the path on metrics server to view and change log level at runtime.
+	logLevelHandlerPath = "/log-level"
+	// cacheSyncCheckTimeout is the time readiness probe waits for informers to be synced.
+	cacheSyncCheckTimeout = 5 * time.Second
+	// groupClaimReleaseTimeout is the time to release IngressGroup claims upon shutdown.
+	groupClaimReleaseTimeout = 10 * time.Second
+)
+
 var (
 	scheme   = k8sruntime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -62,7 +81,7 @@ func init() {
 }
 
 func main() {
-	infoLogger := getLoggerWithLogLevel("info")
+	infoLogger := getLoggerWithLogLevel(getAtomicLogLevel("info"))
 	infoLogger.Info("version",
 		"GitVersion", version.GitVersion,
 		"GitCommit", version.GitCommit,
@@ -73,7 +92,8 @@ func main() {
 		infoLogger.Error(err, "unable to load controller config")
 		os.Exit(1)
 	}
-	ctrl.SetLogger(getLoggerWithLogLevel(controllerCFG.LogLevel))
+	logLevel := getAtomicLogLevel(controllerCFG.LogLevel)
+	ctrl.SetLogger(getLoggerWithLogLevel(logLevel))
 
 	cloud, err := aws.NewCloud(controllerCFG.AWSConfig, metrics.Registry)
 	if err != nil {
@@ -85,13 +105,24 @@ func main() {
 		setupLog.Error(err, "unable to build REST config")
 		os.Exit(1)
 	}
-	rtOpts := config.BuildRuntimeOptions(controllerCFG.RuntimeConfig, scheme)
+	rtOpts, err := config.BuildRuntimeOptions(controllerCFG.RuntimeConfig, scheme)
+	if err != nil {
+		setupLog.Error(err, "unable to build runtime options")
+		os.Exit(1)
+	}
 	mgr, err := ctrl.NewManager(restCFG, rtOpts)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 	config.ConfigureWebhookServer(controllerCFG.RuntimeConfig, mgr)
+	if controllerCFG.EnableLogLevelEndpoint {
+		// GET reports the current log level, and PUT with body like {"level":"debug"} changes it.
+		if err := mgr.AddMetricsExtraHandler(logLevelHandlerPath, logLevel); err != nil {
+			setupLog.Error(err, "unable to add log level endpoint")
+			os.Exit(1)
+		}
+	}
 	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to obtain clientSet")
@@ -105,18 +136,95 @@ func main() {
 	azInfoProvider := networking.NewDefaultAZInfoProvider(cloud.EC2(), ctrl.Log.WithName("az-info-provider"))
 	vpcInfoProvider := networking.NewDefaultVPCInfoProvider(cloud.EC2(), ctrl.Log.WithName("vpc-info-provider"))
 	subnetResolver := networking.NewDefaultSubnetsResolver(azInfoProvider, cloud.EC2(), cloud.VpcID(), controllerCFG.ClusterName, ctrl.Log.WithName("subnets-resolver"))
+	if controllerCFG.EnableAWSContextEndpoint {
+		// cluster tags don't depend on tag prefix.
+		clusterTags := tracking.NewDefaultProvider("", controllerCFG.ClusterName).ClusterTags()
+		awsContextProvider := awscontext.NewDefaultProvider(controllerCFG.ClusterName, cloud.Region(), cloud.VpcID(), clusterTags,
+			controllerCFG.DefaultTags, vpcInfoProvider, subnetResolver, ctrl.Log.WithName("aws-context-provider"))
+		if err := mgr.AddMetricsExtraHandler(awscontext.HandlerPath, awscontext.NewHandler(awsContextProvider, ctrl.Log.WithName("aws-context-handler"))); err != nil {
+			setupLog.Error(err, "unable to add AWS context endpoint")
+			os.Exit(1)
+		}
+	}
+	if controllerCFG.EnableControllerVersionReportEndpoint {
+		// stackIDs are reported for resources provisioned by both Ingress and Service controllers.
+		reporter := deploy.NewDefaultControllerVersionReporter(cloud, sgManager, controllerCFG,
+			[]string{"ingress.k8s.aws", "service.k8s.aws"}, ctrl.Log.WithName("controller-version-reporter"))
+		if err := mgr.AddMetricsExtraHandler(deploy.ControllerVersionReportHandlerPath,
+			deploy.NewControllerVersionReportHandler(reporter, ctrl.Log.WithName("controller-version-report-handler"))); err != nil {
+			setupLog.Error(err, "unable to add controller version report endpoint")
+			os.Exit(1)
+		}
+	}
+	if controllerCFG.IngressConfig.EnableMetricsDimensions {
+		// metrics dimensions are read directly from API server, so that ConfigMaps across the cluster aren't cached.
+		if err := mgr.AddMetricsExtraHandler(ingresspkg.MetricsExpressionsHandlerPath,
+			ingresspkg.NewMetricsExpressionsHandler(mgr.GetAPIReader(), k8s.NewDefaultTokenReviewer(mgr.GetClient()), k8s.NewDefaultAccessReviewer(mgr.GetClient()),
+				ctrl.Log.WithName("ingress-metrics-expressions-handler"))); err != nil {
+			setupLog.Error(err, "unable to add ingress metrics expressions endpoint")
+			os.Exit(1)
+		}
+	}
+	// endpoint resolvers read CNI-specific objects such as CiliumEndpoints as unstructured, which must be served from cache as well.
+	endpointResolverClient, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+		CacheReader:       mgr.GetCache(),
+		Client:            mgr.GetClient(),
+		CacheUnstructured: true,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to build endpoint resolver client")
+		os.Exit(1)
+	}
+	endpointResolver, err := backend.NewDefaultEndpointResolverRegistry().Build(controllerCFG.EndpointResolver, endpointResolverClient, podInfoRepo, ctrl.Log)
+	if err != nil {
+		setupLog.Error(err, "unable to build endpoint resolver")
+		os.Exit(1)
+	}
+	var registrationAuditor targetgroupbinding.RegistrationAuditor
+	if controllerCFG.TargetRegistrationAuditS3Bucket != "" {
+		s3RegistrationAuditor := targetgroupbinding.NewS3RegistrationAuditor(cloud.S3(), controllerCFG.TargetRegistrationAuditS3Bucket,
+			controllerCFG.TargetRegistrationAuditS3Prefix, controllerCFG.TargetRegistrationAuditHistorySize, ctrl.Log.WithName("target-registration-auditor"))
+		bufferedRegistrationAuditor := targetgroupbinding.NewBufferedRegistrationAuditor(s3RegistrationAuditor, targetgroupbinding.RegistrationAuditBufferSize,
+			ctrl.Log.WithName("target-registration-auditor"))
+		if err := mgr.Add(bufferedRegistrationAuditor); err != nil {
+			setupLog.Error(err, "unable to add target registration auditor")
+			os.Exit(1)
+		}
+		registrationAuditor = bufferedRegistrationAuditor
+	}
+	var healthCheckAdjuster targetgroupbinding.HealthCheckAdjuster
+	if controllerCFG.AdaptiveHealthCheckRolloutThreshold > 0 {
+		healthCheckAdjuster = targetgroupbinding.NewDefaultHealthCheckAdjuster(mgr.GetClient(), cloud.ELBV2(), controllerCFG.AdaptiveHealthCheckRolloutThreshold,
+			mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log.WithName("health-check-adjuster"))
+	}
+	var targetsSyncTracker targetgroupbinding.TargetsSyncTracker
+	if controllerCFG.TargetVerificationInterval > 0 {
+		targetsSyncTracker = targetgroupbinding.NewDefaultTargetsSyncTracker(mgr.GetClient(), controllerCFG.EnableEndpointSlices, controllerCFG.TargetVerificationInterval)
+	}
 	tgbResManager := targetgroupbinding.NewDefaultResourceManager(mgr.GetClient(), cloud.ELBV2(), cloud.EC2(),
-		podInfoRepo, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log, controllerCFG.EnableEndpointSlices, controllerCFG.DisableRestrictedSGRules, vpcInfoProvider)
+		endpointResolver, sgManager, sgReconciler, cloud.VpcID(), controllerCFG.ClusterName, mgr.GetEventRecorderFor("targetGroupBinding"), ctrl.Log, controllerCFG.EnableEndpointSlices, controllerCFG.DisableRestrictedSGRules, vpcInfoProvider,
+		registrationAuditor, healthCheckAdjuster, targetsSyncTracker, controllerCFG.TargetDrainTimeout,
+		controllerCFG.EndpointSliceCustomManagers, controllerCFG.EnableCustomEndpointSliceExternalIPs)
 	backendSGProvider := networking.NewBackendSGProvider(controllerCFG.ClusterName, controllerCFG.BackendSecurityGroup,
 		cloud.VpcID(), cloud.EC2(), mgr.GetClient(), controllerCFG.DefaultTags, ctrl.Log.WithName("backend-sg-provider"))
-	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("ingress"),
+	groupClaimer, err := buildGroupClaimer(controllerCFG, mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to build ingressGroup claimer")
+		os.Exit(1)
+	}
+	defaultAnnotationsRepo, err := buildDefaultAnnotationsRepo(controllerCFG, clientSet)
+	if err != nil {
+		setupLog.Error(err, "unable to build default annotations repo")
+		os.Exit(1)
+	}
+	ingGroupReconciler := ingress.NewGroupReconciler(cloud, mgr.GetClient(), mgr.GetAPIReader(), mgr.GetEventRecorderFor("ingress"),
 		finalizerManager, sgManager, sgReconciler, subnetResolver,
-		controllerCFG, backendSGProvider, ctrl.Log.WithName("controllers").WithName("ingress"))
+		controllerCFG, backendSGProvider, groupClaimer, defaultAnnotationsRepo, ctrl.Log.WithName("controllers").WithName("ingress"))
 	svcReconciler := service.NewServiceReconciler(cloud, mgr.GetClient(), mgr.GetEventRecorderFor("service"),
 		finalizerManager, sgManager, sgReconciler, subnetResolver, vpcInfoProvider,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("service"))
 	tgbReconciler := elbv2controller.NewTargetGroupBindingReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("targetGroupBinding"),
-		finalizerManager, tgbResManager,
+		finalizerManager, tgbResManager, targetsSyncTracker,
 		controllerCFG, ctrl.Log.WithName("controllers").WithName("targetGroupBinding"))
 
 	ctx := ctrl.SetupSignalHandler()
@@ -132,6 +240,18 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "TargetGroupBinding")
 		os.Exit(1)
 	}
+	if controllerCFG.TargetHealthReportInterval > 0 {
+		targetHealthReporter, err := targetgroupbinding.NewTargetHealthReporter(mgr.GetClient(), cloud.ELBV2(), endpointResolver,
+			controllerCFG.EnableEndpointSlices, controllerCFG.TargetHealthReportInterval, metrics.Registry, ctrl.Log.WithName("target-health-reporter"))
+		if err != nil {
+			setupLog.Error(err, "unable to create target health reporter")
+			os.Exit(1)
+		}
+		if err := mgr.Add(targetHealthReporter); err != nil {
+			setupLog.Error(err, "unable to add target health reporter")
+			os.Exit(1)
+		}
+	}
 
 	// Add liveness probe
 	err = mgr.AddHealthzCheck("health-ping", healthz.Ping)
@@ -140,10 +260,43 @@ func main() {
 		setupLog.Error(err, "unable add a health check")
 		os.Exit(1)
 	}
+	// Add readiness probes
+	if err := mgr.AddReadyzCheck("informer-sync", k8s.NewCacheSyncChecker(mgr.GetCache(), cacheSyncCheckTimeout)); err != nil {
+		setupLog.Error(err, "unable add a readiness check", "check", "informer-sync")
+		os.Exit(1)
+	}
+	// AWS credentials are monitored rather than gating readiness by default, so that transient AWS outages don't take the webhooks out of service.
+	credentialsChecker, err := aws.NewCredentialsChecker(cloud.Credentials(), cloud.ELBV2(), metrics.Registry, ctrl.Log.WithName("aws-credentials-checker"))
+	if err != nil {
+		setupLog.Error(err, "unable to create AWS credentials checker")
+		os.Exit(1)
+	}
+	if err := mgr.Add(credentialsChecker); err != nil {
+		setupLog.Error(err, "unable to add AWS credentials checker")
+		os.Exit(1)
+	}
+	if err := mgr.AddMetricsExtraHandler(aws.CredentialsCheckHandlerPath, credentialsChecker.Handler()); err != nil {
+		setupLog.Error(err, "unable to add AWS credentials check endpoint")
+		os.Exit(1)
+	}
+	if controllerCFG.AWSConfig.CredentialsReadinessCheck {
+		if err := mgr.AddReadyzCheck("aws-credentials", credentialsChecker.ReadinessChecker()); err != nil {
+			setupLog.Error(err, "unable add a readiness check", "check", "aws-credentials")
+			os.Exit(1)
+		}
+	}
+	if controllerCFG.RuntimeConfig.PprofBindAddress != "" {
+		if err := mgr.Add(runtime.NewPprofServer(controllerCFG.RuntimeConfig.PprofBindAddress, ctrl.Log.WithName("pprof-server"))); err != nil {
+			setupLog.Error(err, "unable to add pprof server")
+			os.Exit(1)
+		}
+	}
 
 	podReadinessGateInjector := inject.NewPodReadinessGate(controllerCFG.PodWebhookConfig,
 		mgr.GetClient(), ctrl.Log.WithName("pod-readiness-gate-injector"))
 	corewebhook.NewPodMutator(podReadinessGateInjector).SetupWithManager(mgr)
+	corewebhook.NewPodEvictionValidator(mgr.GetClient(), mgr.GetAPIReader(), cloud.ELBV2(), endpointResolver, controllerCFG.TargetDrainTimeout,
+		ctrl.Log.WithName("pod-eviction-validator")).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingMutator(cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
 	elbv2webhook.NewTargetGroupBindingValidator(mgr.GetClient(), cloud.ELBV2(), ctrl.Log).SetupWithManager(mgr)
 	networkingwebhook.NewIngressValidator(mgr.GetClient(), controllerCFG.IngressConfig, ctrl.Log).SetupWithManager(mgr)
@@ -160,10 +313,67 @@ func main() {
 		setupLog.Error(err, "problem wait for podInfo repo sync")
 		os.Exit(1)
 	}
+	if defaultAnnotationsRepo != nil {
+		go func() {
+			setupLog.Info("starting default annotations repo")
+			if err := defaultAnnotationsRepo.Start(ctx); err != nil {
+				setupLog.Error(err, "problem running default annotations repo")
+				os.Exit(1)
+			}
+		}()
+		// Ingresses are never reconciled without default annotations, which would revert defaulted settings temporarily.
+		if err := defaultAnnotationsRepo.WaitForCacheSync(ctx); err != nil {
+			setupLog.Error(err, "problem wait for default annotations repo sync")
+			os.Exit(1)
+		}
+	}
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	// reconciles are stopped once manager returns, releasing claims allows the next controller instance to take over IngressGroups
+	// without waiting for claims to expire. claims are kept upon failures above, since reconciles might still be in-flight.
+	releaseCtx, cancel := context.WithTimeout(context.Background(), groupClaimReleaseTimeout)
+	defer cancel()
+	if err := groupClaimer.ReleaseAll(releaseCtx); err != nil {
+		setupLog.Error(err, "problem releasing ingressGroup claims")
+	}
+}
+
+// buildGroupClaimer builds the claimer for IngressGroups, which claims IngressGroups with Leases in the leader election namespace.
+func buildGroupClaimer(controllerCFG config.ControllerConfig, mgr ctrl.Manager) (ingresspkg.GroupClaimer, error) {
+	claimDuration := controllerCFG.IngressConfig.GroupClaimDuration
+	var namespace string
+	if claimDuration > 0 {
+		var err error
+		namespace, err = config.ResolveLeaderElectionNamespace(controllerCFG.RuntimeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	// the unique suffix distinguishes controller instances that share hostname, e.g. restarted containers of the same pod.
+	identity := hostname + "_" + string(uuid.NewUUID())
+	return ingresspkg.NewDefaultGroupClaimer(mgr.GetClient(), mgr.GetAPIReader(), namespace, identity, claimDuration,
+		ctrl.Log.WithName("ingress-group-claimer")), nil
+}
+
+// buildDefaultAnnotationsRepo builds the repo of controller-wide default Ingress annotations, which watches the ConfigMap in the controller namespace.
+// returns nil if default annotations are disabled.
+func buildDefaultAnnotationsRepo(controllerCFG config.ControllerConfig, clientSet *kubernetes.Clientset) (ingresspkg.DefaultAnnotationsRepo, error) {
+	cmName := controllerCFG.IngressConfig.DefaultAnnotationsConfigMap
+	if cmName == "" {
+		return nil, nil
+	}
+	namespace, err := config.ResolveLeaderElectionNamespace(controllerCFG.RuntimeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ingresspkg.NewDefaultAnnotationsRepo(clientSet.CoreV1().RESTClient(), types.NamespacedName{Namespace: namespace, Name: cmName},
+		ctrl.Log.WithName("default-annotations-repo")), nil
 }
 
 // loadControllerConfig loads the controller configuration.
@@ -189,18 +399,20 @@ func loadControllerConfig() (config.ControllerConfig, error) {
 	return controllerCFG, nil
 }
 
-// getLoggerWithLogLevel returns logger with specific log level.
-func getLoggerWithLogLevel(logLevel string) logr.Logger {
-	var zapLevel zapraw.AtomicLevel
+// getAtomicLogLevel returns the log level that can be changed at runtime.
+func getAtomicLogLevel(logLevel string) zapraw.AtomicLevel {
 	switch logLevel {
 	case "info":
-		zapLevel = zapraw.NewAtomicLevelAt(zapraw.InfoLevel)
+		return zapraw.NewAtomicLevelAt(zapraw.InfoLevel)
 	case "debug":
-		zapLevel = zapraw.NewAtomicLevelAt(zapraw.DebugLevel)
+		return zapraw.NewAtomicLevelAt(zapraw.DebugLevel)
 	default:
-		zapLevel = zapraw.NewAtomicLevelAt(zapraw.InfoLevel)
+		return zapraw.NewAtomicLevelAt(zapraw.InfoLevel)
 	}
+}
 
+// getLoggerWithLogLevel returns logger with specific log level.
+func getLoggerWithLogLevel(zapLevel zapraw.AtomicLevel) logr.Logger {
 	logger := zap.New(zap.UseDevMode(false),
 		zap.Level(zapLevel),
 		zap.StacktraceLevel(zapraw.NewAtomicLevelAt(zapraw.FatalLevel)))
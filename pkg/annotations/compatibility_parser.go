@@ -0,0 +1,197 @@
+package annotations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	compatAnnotationForceSSLRedirect            = "ingress.kubernetes.io/force-ssl-redirect"
+	compatAnnotationSecureBackends              = "ingress.kubernetes.io/secure-backends"
+	compatAnnotationNginxForceSSLRedirect       = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+	compatAnnotationNginxBackendProtocol        = "nginx.ingress.kubernetes.io/backend-protocol"
+	compatAnnotationTraefikRedirectEntryPoint   = "traefik.ingress.kubernetes.io/redirect-entry-point"
+	compatAnnotationTraefikServiceServersScheme = "traefik.ingress.kubernetes.io/service.serversscheme"
+
+	sslRedirectPortHTTPS = "443"
+)
+
+// compatibilityTranslation translates an annotation of another Ingress controller into the value of a native annotation.
+type compatibilityTranslation struct {
+	// key is the full annotation key used by another Ingress controller.
+	key string
+	// translate converts raw value of annotation into native value, returns false if there is no native equivalent.
+	translate func(rawValue string) (string, bool)
+}
+
+// compatibilityTranslations are the translations for native annotation suffixes, in the order of precedence.
+var compatibilityTranslations = map[string][]compatibilityTranslation{
+	IngressSuffixSSLRedirect: {
+		{key: compatAnnotationNginxForceSSLRedirect, translate: translateForceSSLRedirect},
+		{key: compatAnnotationForceSSLRedirect, translate: translateForceSSLRedirect},
+		{key: compatAnnotationTraefikRedirectEntryPoint, translate: translateTraefikRedirectEntryPoint},
+	},
+	IngressSuffixBackendProtocol: {
+		{key: compatAnnotationNginxBackendProtocol, translate: translateNginxBackendProtocol},
+		{key: compatAnnotationSecureBackends, translate: translateSecureBackends},
+		{key: compatAnnotationTraefikServiceServersScheme, translate: translateTraefikServersScheme},
+	},
+	IngressSuffixBackendProtocolVersion: {
+		{key: compatAnnotationNginxBackendProtocol, translate: translateNginxBackendProtocolVersion},
+	},
+}
+
+// NewCompatibilityAnnotationParser constructs new compatibilityAnnotationParser.
+func NewCompatibilityAnnotationParser(parser Parser, annotationPrefix string) *compatibilityAnnotationParser {
+	return &compatibilityAnnotationParser{
+		parser:           parser,
+		annotationPrefix: annotationPrefix,
+	}
+}
+
+var _ Parser = (*compatibilityAnnotationParser)(nil)
+
+// compatibilityAnnotationParser is a Parser implementation that accepts common annotations of other Ingress controllers.
+// when a native annotation is absent, equivalent annotations of other Ingress controllers are translated into it,
+// so that Ingresses can be migrated from controllers like ingress-nginx or traefik without rewriting their annotations.
+type compatibilityAnnotationParser struct {
+	parser           Parser
+	annotationPrefix string
+}
+
+func (p *compatibilityAnnotationParser) ParseStringAnnotation(annotation string, value *string, annotations map[string]string, opts ...ParseOption) bool {
+	return p.parser.ParseStringAnnotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+func (p *compatibilityAnnotationParser) ParseBoolAnnotation(annotation string, value *bool, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseBoolAnnotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+func (p *compatibilityAnnotationParser) ParseInt64Annotation(annotation string, value *int64, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseInt64Annotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+func (p *compatibilityAnnotationParser) ParseStringSliceAnnotation(annotation string, value *[]string, annotations map[string]string, opts ...ParseOption) bool {
+	return p.parser.ParseStringSliceAnnotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+func (p *compatibilityAnnotationParser) ParseJSONAnnotation(annotation string, value interface{}, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseJSONAnnotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+func (p *compatibilityAnnotationParser) ParseStringMapAnnotation(annotation string, value *map[string]string, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseStringMapAnnotation(annotation, value, p.translate(annotation, annotations, opts...), opts...)
+}
+
+// IsTranslatedAnnotation checks whether the value parsed by parser for annotation is translated from annotations of other Ingress controllers,
+// rather than supplied by the native annotation.
+func IsTranslatedAnnotation(parser Parser, annotation string, annotations map[string]string) bool {
+	compatParser, ok := parser.(*compatibilityAnnotationParser)
+	if !ok {
+		return false
+	}
+	nativeKey := fmt.Sprintf("%v/%v", compatParser.annotationPrefix, annotation)
+	_, nativeExists := annotations[nativeKey]
+	if nativeExists {
+		return false
+	}
+	_, translated := compatParser.translate(annotation, annotations)[nativeKey]
+	return translated
+}
+
+// translate returns annotations with the native annotation translated from annotations of other Ingress controllers.
+// annotations are returned as is if the native annotation exists, or there is no translatable annotation.
+func (p *compatibilityAnnotationParser) translate(annotation string, annotations map[string]string, opts ...ParseOption) map[string]string {
+	parseOpts := ParseOptions{}
+	for _, opt := range opts {
+		opt(&parseOpts)
+	}
+	if parseOpts.exact {
+		return annotations
+	}
+	translations, ok := compatibilityTranslations[annotation]
+	if !ok {
+		return annotations
+	}
+	nativeKey := fmt.Sprintf("%v/%v", p.annotationPrefix, annotation)
+	if _, exists := annotations[nativeKey]; exists {
+		return annotations
+	}
+	for _, pfx := range parseOpts.alternativePrefixes {
+		if _, exists := annotations[fmt.Sprintf("%v/%v", pfx, annotation)]; exists {
+			return annotations
+		}
+	}
+	for _, translation := range translations {
+		rawValue, exists := annotations[translation.key]
+		if !exists {
+			continue
+		}
+		value, ok := translation.translate(rawValue)
+		if !ok {
+			continue
+		}
+		translated := make(map[string]string, len(annotations)+1)
+		for k, v := range annotations {
+			translated[k] = v
+		}
+		translated[nativeKey] = value
+		return translated
+	}
+	return annotations
+}
+
+func translateForceSSLRedirect(rawValue string) (string, bool) {
+	forceSSLRedirect, err := strconv.ParseBool(rawValue)
+	if err != nil || !forceSSLRedirect {
+		return "", false
+	}
+	return sslRedirectPortHTTPS, true
+}
+
+func translateTraefikRedirectEntryPoint(rawValue string) (string, bool) {
+	if !strings.EqualFold(rawValue, "https") {
+		return "", false
+	}
+	return sslRedirectPortHTTPS, true
+}
+
+func translateNginxBackendProtocol(rawValue string) (string, bool) {
+	switch strings.ToUpper(rawValue) {
+	case "HTTP", "GRPC":
+		return "HTTP", true
+	case "HTTPS", "GRPCS":
+		return "HTTPS", true
+	default:
+		return "", false
+	}
+}
+
+func translateNginxBackendProtocolVersion(rawValue string) (string, bool) {
+	switch strings.ToUpper(rawValue) {
+	case "GRPC", "GRPCS":
+		return "GRPC", true
+	default:
+		return "", false
+	}
+}
+
+func translateSecureBackends(rawValue string) (string, bool) {
+	secureBackends, err := strconv.ParseBool(rawValue)
+	if err != nil || !secureBackends {
+		return "", false
+	}
+	return "HTTPS", true
+}
+
+func translateTraefikServersScheme(rawValue string) (string, bool) {
+	switch strings.ToLower(rawValue) {
+	case "http":
+		return "HTTP", true
+	case "https":
+		return "HTTPS", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,212 @@
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compatibilityAnnotationParser_ParseStringAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []ParseOption
+		suffix      string
+		annotations map[string]string
+		wantExist   bool
+		wantValue   string
+	}{
+		{
+			name:   "native annotation takes precedence",
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/backend-protocol":   "HTTP",
+				"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+			wantExist: true,
+			wantValue: "HTTP",
+		},
+		{
+			name:   "alternative prefix takes precedence",
+			opts:   []ParseOption{WithAlternativePrefixes("alt.io")},
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"alt.io/backend-protocol":                      "HTTP",
+				"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+			wantExist: true,
+			wantValue: "HTTP",
+		},
+		{
+			name:   "nginx backend-protocol GRPCS",
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/backend-protocol": "GRPCS",
+			},
+			wantExist: true,
+			wantValue: "HTTPS",
+		},
+		{
+			name:   "nginx backend-protocol GRPCS as protocol version",
+			suffix: IngressSuffixBackendProtocolVersion,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/backend-protocol": "GRPCS",
+			},
+			wantExist: true,
+			wantValue: "GRPC",
+		},
+		{
+			name:   "nginx backend-protocol HTTPS has no protocol version",
+			suffix: IngressSuffixBackendProtocolVersion,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+			wantExist: false,
+		},
+		{
+			name:   "nginx backend-protocol without native equivalent",
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/backend-protocol": "FCGI",
+			},
+			wantExist: false,
+		},
+		{
+			name:   "secure-backends",
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"ingress.kubernetes.io/secure-backends": "true",
+			},
+			wantExist: true,
+			wantValue: "HTTPS",
+		},
+		{
+			name:   "traefik serversscheme",
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"traefik.ingress.kubernetes.io/service.serversscheme": "https",
+			},
+			wantExist: true,
+			wantValue: "HTTPS",
+		},
+		{
+			name:   "exact match is not translated",
+			opts:   []ParseOption{WithExact()},
+			suffix: IngressSuffixBackendProtocol,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/backend-protocol": "HTTPS",
+			},
+			wantExist: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewCompatibilityAnnotationParser(NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), "alb.ingress.kubernetes.io")
+			value := ""
+			exist := parser.ParseStringAnnotation(tt.suffix, &value, tt.annotations, tt.opts...)
+			assert.Equal(t, tt.wantExist, exist)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func Test_compatibilityAnnotationParser_ParseInt64Annotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantExist   bool
+		wantValue   int64
+	}{
+		{
+			name: "native ssl-redirect takes precedence",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-redirect":         "8443",
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			wantExist: true,
+			wantValue: 8443,
+		},
+		{
+			name: "nginx force-ssl-redirect",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			wantExist: true,
+			wantValue: 443,
+		},
+		{
+			name: "force-ssl-redirect disabled",
+			annotations: map[string]string{
+				"ingress.kubernetes.io/force-ssl-redirect": "false",
+			},
+			wantExist: false,
+		},
+		{
+			name: "traefik redirect-entry-point",
+			annotations: map[string]string{
+				"traefik.ingress.kubernetes.io/redirect-entry-point": "https",
+			},
+			wantExist: true,
+			wantValue: 443,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewCompatibilityAnnotationParser(NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), "alb.ingress.kubernetes.io")
+			var value int64
+			exist, err := parser.ParseInt64Annotation(IngressSuffixSSLRedirect, &value, tt.annotations)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExist, exist)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func Test_IsTranslatedAnnotation(t *testing.T) {
+	suffixParser := NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+	tests := []struct {
+		name        string
+		parser      Parser
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name:   "translated from nginx annotation",
+			parser: NewCompatibilityAnnotationParser(suffixParser, "alb.ingress.kubernetes.io"),
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			want: true,
+		},
+		{
+			name:   "native annotation takes precedence",
+			parser: NewCompatibilityAnnotationParser(suffixParser, "alb.ingress.kubernetes.io"),
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-redirect":         "8443",
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			want: false,
+		},
+		{
+			name:   "value without native equivalent",
+			parser: NewCompatibilityAnnotationParser(suffixParser, "alb.ingress.kubernetes.io"),
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "false",
+			},
+			want: false,
+		},
+		{
+			name:   "compatibility annotations disabled",
+			parser: suffixParser,
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsTranslatedAnnotation(tt.parser, IngressSuffixSSLRedirect, tt.annotations)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
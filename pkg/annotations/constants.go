@@ -7,18 +7,25 @@ const (
 	AnnotationPrefixIngress = "alb.ingress.kubernetes.io"
 	// Ingress annotation suffixes
 	IngressSuffixLoadBalancerName             = "load-balancer-name"
+	IngressSuffixLoadBalancerARN              = "load-balancer-arn"
 	IngressSuffixGroupName                    = "group.name"
 	IngressSuffixGroupOrder                   = "group.order"
+	IngressSuffixPathOrder                    = "path-order"
+	IngressSuffixHostTemplate                 = "host-template"
 	IngressSuffixTags                         = "tags"
 	IngressSuffixIPAddressType                = "ip-address-type"
 	IngressSuffixScheme                       = "scheme"
 	IngressSuffixSubnets                      = "subnets"
 	IngressSuffixCustomerOwnedIPv4Pool        = "customer-owned-ipv4-pool"
 	IngressSuffixLoadBalancerAttributes       = "load-balancer-attributes"
+	IngressSuffixIdleTimeoutSeconds           = "idle-timeout-seconds"
+	IngressSuffixClientKeepAliveSeconds       = "client-keep-alive-seconds"
 	IngressSuffixWAFv2ACLARN                  = "wafv2-acl-arn"
 	IngressSuffixWAFACLID                     = "waf-acl-id"
 	IngressSuffixWebACLID                     = "web-acl-id" // deprecated, use "waf-acl-id" instead.
 	IngressSuffixShieldAdvancedProtection     = "shield-advanced-protection"
+	IngressSuffixCloudWatchDashboard          = "cloudwatch-dashboard"
+	IngressSuffixRoute53HostedZoneID          = "route53-hosted-zone-id"
 	IngressSuffixSecurityGroups               = "security-groups"
 	IngressSuffixListenPorts                  = "listen-ports"
 	IngressSuffixSSLRedirect                  = "ssl-redirect"
@@ -29,6 +36,13 @@ const (
 	IngressSuffixBackendProtocol              = "backend-protocol"
 	IngressSuffixBackendProtocolVersion       = "backend-protocol-version"
 	IngressSuffixTargetGroupAttributes        = "target-group-attributes"
+	IngressSuffixSlowStartSeconds             = "slow-start-seconds"
+	IngressSuffixStickinessEnabled            = "stickiness-enabled"
+	IngressSuffixStickinessType               = "stickiness-type"
+	IngressSuffixStickinessDurationSeconds    = "stickiness-duration-seconds"
+	IngressSuffixStickinessCookieName         = "stickiness-cookie-name"
+	IngressSuffixLoadBalancingAlgorithm       = "load-balancing-algorithm"
+	IngressSuffixAnomalyMitigationEnabled     = "anomaly-mitigation-enabled"
 	IngressSuffixHealthCheckPort              = "healthcheck-port"
 	IngressSuffixHealthCheckProtocol          = "healthcheck-protocol"
 	IngressSuffixHealthCheckPath              = "healthcheck-path"
@@ -37,6 +51,9 @@ const (
 	IngressSuffixHealthyThresholdCount        = "healthy-threshold-count"
 	IngressSuffixUnhealthyThresholdCount      = "unhealthy-threshold-count"
 	IngressSuffixSuccessCodes                 = "success-codes"
+	IngressSuffixGRPCSuccessCodes             = "grpc-success-codes"
+	IngressSuffixHealthCheckSource            = "healthcheck-source"
+	IngressSuffixHealthCheckPreset            = "healthcheck-preset"
 	IngressSuffixAuthType                     = "auth-type"
 	IngressSuffixAuthIDPCognito               = "auth-idp-cognito"
 	IngressSuffixAuthIDPOIDC                  = "auth-idp-oidc"
@@ -45,7 +62,25 @@ const (
 	IngressSuffixAuthSessionCookie            = "auth-session-cookie"
 	IngressSuffixAuthSessionTimeout           = "auth-session-timeout"
 	IngressSuffixTargetNodeLabels             = "target-node-labels"
+	IngressSuffixExternalTargets              = "external-targets"
 	IngressSuffixManageSecurityGroupRules     = "manage-backend-security-group-rules"
+	IngressSuffixAWSAPIMaxRetries             = "aws-api-max-retries"
+	IngressSuffixAWSAPITimeoutSeconds         = "aws-api-timeout-seconds"
+
+	// Ingress annotation suffixes for target group health requirements
+	IngressSuffixDNSFailoverMinimumHealthyTargetsCount                = "dns-failover-minimum-healthy-targets-count"
+	IngressSuffixDNSFailoverMinimumHealthyTargetsPercentage           = "dns-failover-minimum-healthy-targets-percentage"
+	IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsCount      = "unhealthy-state-routing-minimum-healthy-targets-count"
+	IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsPercentage = "unhealthy-state-routing-minimum-healthy-targets-percentage"
+
+	// Placeholders within host-template annotation, which expand to the namespace and name of Ingress
+	HostTemplatePlaceholderNamespace = "{{namespace}}"
+	HostTemplatePlaceholderName      = "{{name}}"
+
+	// Ingress annotation suffix prefixes
+	IngressSuffixPrefixActions    = "actions."
+	IngressSuffixPrefixConditions = "conditions."
+	IngressSuffixPrefixProfile    = "profile."
 
 	// NLB annotation suffixes
 	// prefixes service.beta.kubernetes.io, service.kubernetes.io
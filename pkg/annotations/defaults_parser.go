@@ -0,0 +1,101 @@
+package annotations
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultsProvider provides controller-wide default values of annotations by annotation suffix.
+type DefaultsProvider interface {
+	// Defaults returns the default values of annotations by annotation suffix.
+	Defaults() map[string]string
+}
+
+// NewDefaultsAnnotationParser constructs new defaultsAnnotationParser.
+func NewDefaultsAnnotationParser(parser Parser, annotationPrefix string, defaultsProvider DefaultsProvider) *defaultsAnnotationParser {
+	return &defaultsAnnotationParser{
+		parser:           parser,
+		annotationPrefix: annotationPrefix,
+		defaultsProvider: defaultsProvider,
+	}
+}
+
+var _ Parser = (*defaultsAnnotationParser)(nil)
+
+// defaultsAnnotationParser is a Parser implementation that falls back to controller-wide default values,
+// when neither the native annotation nor any alternative of it exists on objects.
+type defaultsAnnotationParser struct {
+	parser           Parser
+	annotationPrefix string
+	defaultsProvider DefaultsProvider
+}
+
+func (p *defaultsAnnotationParser) ParseStringAnnotation(annotation string, value *string, annotations map[string]string, opts ...ParseOption) bool {
+	return p.parser.ParseStringAnnotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+func (p *defaultsAnnotationParser) ParseBoolAnnotation(annotation string, value *bool, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseBoolAnnotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+func (p *defaultsAnnotationParser) ParseInt64Annotation(annotation string, value *int64, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseInt64Annotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+func (p *defaultsAnnotationParser) ParseStringSliceAnnotation(annotation string, value *[]string, annotations map[string]string, opts ...ParseOption) bool {
+	return p.parser.ParseStringSliceAnnotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+func (p *defaultsAnnotationParser) ParseJSONAnnotation(annotation string, value interface{}, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseJSONAnnotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+func (p *defaultsAnnotationParser) ParseStringMapAnnotation(annotation string, value *map[string]string, annotations map[string]string, opts ...ParseOption) (bool, error) {
+	return p.parser.ParseStringMapAnnotation(annotation, value, p.applyDefault(annotation, annotations, opts...), opts...)
+}
+
+// applyDefault returns annotations with the native annotation set to its default value.
+// annotations are returned as is if the native annotation or any alternative of it exists, or there is no default value.
+func (p *defaultsAnnotationParser) applyDefault(annotation string, annotations map[string]string, opts ...ParseOption) map[string]string {
+	parseOpts := ParseOptions{}
+	for _, opt := range opts {
+		opt(&parseOpts)
+	}
+	if parseOpts.exact || !IsDefaultableIngressAnnotation(annotation) {
+		return annotations
+	}
+	defaultValue, ok := p.defaultsProvider.Defaults()[annotation]
+	if !ok {
+		return annotations
+	}
+	nativeKey := fmt.Sprintf("%v/%v", p.annotationPrefix, annotation)
+	if _, exists := annotations[nativeKey]; exists {
+		return annotations
+	}
+	for _, pfx := range parseOpts.alternativePrefixes {
+		if _, exists := annotations[fmt.Sprintf("%v/%v", pfx, annotation)]; exists {
+			return annotations
+		}
+	}
+	defaulted := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		defaulted[k] = v
+	}
+	defaulted[nativeKey] = defaultValue
+	return defaulted
+}
+
+// IsDefaultableIngressAnnotation checks whether controller-wide default value can be set for Ingress annotation suffix.
+// annotations that decide IngressGroup membership, or refer to specific backends are specific to each Ingress.
+func IsDefaultableIngressAnnotation(annotation string) bool {
+	switch annotation {
+	case IngressSuffixGroupName, IngressSuffixGroupOrder:
+		return false
+	}
+	for _, pfx := range []string{IngressSuffixPrefixActions, IngressSuffixPrefixConditions, IngressSuffixPrefixProfile} {
+		if strings.HasPrefix(annotation, pfx) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticDefaultsProvider map[string]string
+
+func (p staticDefaultsProvider) Defaults() map[string]string {
+	return p
+}
+
+func Test_defaultsAnnotationParser_ParseStringAnnotation(t *testing.T) {
+	defaults := staticDefaultsProvider{
+		IngressSuffixSSLPolicy:   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+		IngressSuffixGroupName:   "default-group",
+		"actions.default-action": `{"type":"fixed-response"}`,
+	}
+	tests := []struct {
+		name        string
+		opts        []ParseOption
+		suffix      string
+		annotations map[string]string
+		wantExist   bool
+		wantValue   string
+	}{
+		{
+			name:   "native annotation takes precedence",
+			suffix: IngressSuffixSSLPolicy,
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-2016-08",
+			},
+			wantExist: true,
+			wantValue: "ELBSecurityPolicy-2016-08",
+		},
+		{
+			name:   "alternative prefix takes precedence",
+			opts:   []ParseOption{WithAlternativePrefixes("alt.io")},
+			suffix: IngressSuffixSSLPolicy,
+			annotations: map[string]string{
+				"alt.io/ssl-policy": "ELBSecurityPolicy-2016-08",
+			},
+			wantExist: true,
+			wantValue: "ELBSecurityPolicy-2016-08",
+		},
+		{
+			name:        "default value is used when annotation is absent",
+			suffix:      IngressSuffixSSLPolicy,
+			annotations: map[string]string{},
+			wantExist:   true,
+			wantValue:   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+		},
+		{
+			name:        "annotation without default value",
+			suffix:      IngressSuffixScheme,
+			annotations: map[string]string{},
+			wantExist:   false,
+		},
+		{
+			name:        "default value is ignored for exact match",
+			opts:        []ParseOption{WithExact()},
+			suffix:      IngressSuffixSSLPolicy,
+			annotations: map[string]string{},
+			wantExist:   false,
+		},
+		{
+			name:        "default value is ignored for group.name",
+			suffix:      IngressSuffixGroupName,
+			annotations: map[string]string{},
+			wantExist:   false,
+		},
+		{
+			name:        "default value is ignored for actions",
+			suffix:      "actions.default-action",
+			annotations: map[string]string{},
+			wantExist:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewDefaultsAnnotationParser(NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), "alb.ingress.kubernetes.io", defaults)
+			value := ""
+			exist := parser.ParseStringAnnotation(tt.suffix, &value, tt.annotations, tt.opts...)
+			assert.Equal(t, tt.wantExist, exist)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func Test_defaultsAnnotationParser_ParseInt64Annotation(t *testing.T) {
+	defaults := staticDefaultsProvider{
+		IngressSuffixHealthCheckIntervalSeconds: "10",
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantExist   bool
+		wantValue   int64
+	}{
+		{
+			name: "native annotation takes precedence",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/healthcheck-interval-seconds": "30",
+			},
+			wantExist: true,
+			wantValue: 30,
+		},
+		{
+			name:        "default value is used when annotation is absent",
+			annotations: map[string]string{},
+			wantExist:   true,
+			wantValue:   10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewDefaultsAnnotationParser(NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), "alb.ingress.kubernetes.io", defaults)
+			var value int64
+			exist, err := parser.ParseInt64Annotation(IngressSuffixHealthCheckIntervalSeconds, &value, tt.annotations)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExist, exist)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
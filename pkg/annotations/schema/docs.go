@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	docsNotApplicable = "N/A"
+)
+
+// RenderMarkdownTable renders the annotations reference table in markdown for fields with annotationPrefix.
+// deprecated fields are omitted.
+func RenderMarkdownTable(annotationPrefix string, fields []Field) string {
+	var sb strings.Builder
+	sb.WriteString("|Name                       | Type |Default|Location|MergeBehavior|\n")
+	sb.WriteString("|---------------------------|------|-------|--------|------|\n")
+	for _, field := range fields {
+		if field.Deprecated {
+			continue
+		}
+		anchor := field.Suffix
+		suffix := field.Suffix
+		if field.IsPrefix() {
+			anchor = strings.TrimSuffix(field.Suffix, ".")
+			suffix = field.Suffix + field.Placeholder
+		}
+		fmt.Fprintf(&sb, "|[%v/%v](#%v)|%v|%v|%v|%v|\n",
+			annotationPrefix, suffix, anchor, renderFieldType(field), renderFieldDefault(field),
+			renderFieldLocations(field), renderFieldMergeBehavior(field))
+	}
+	return sb.String()
+}
+
+func renderFieldType(field Field) string {
+	if field.TypeDoc != "" {
+		return field.TypeDoc
+	}
+	if len(field.Enum) != 0 {
+		return strings.Join(field.Enum, ` \| `)
+	}
+	return string(field.Type)
+}
+
+func renderFieldDefault(field Field) string {
+	if field.Default == "" {
+		return docsNotApplicable
+	}
+	return field.Default
+}
+
+func renderFieldLocations(field Field) string {
+	locations := make([]string, 0, len(field.Locations))
+	for _, location := range field.Locations {
+		locations = append(locations, string(location))
+	}
+	return strings.Join(locations, ",")
+}
+
+func renderFieldMergeBehavior(field Field) string {
+	if field.MergeBehavior == MergeBehaviorNone {
+		return docsNotApplicable
+	}
+	return string(field.MergeBehavior)
+}
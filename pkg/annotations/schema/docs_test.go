@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRenderMarkdownTable(t *testing.T) {
+	fields := []Field{
+		{
+			Suffix:        "scheme",
+			Type:          TypeString,
+			Enum:          []string{"internal", "internet-facing"},
+			Default:       "internal",
+			Locations:     []Location{LocationIngress},
+			MergeBehavior: MergeBehaviorExclusive,
+		},
+		{
+			Suffix:     "web-acl-id",
+			Type:       TypeString,
+			Locations:  []Location{LocationIngress},
+			Deprecated: true,
+		},
+		{
+			Suffix:      "actions.",
+			Placeholder: "${action-name}",
+			Type:        TypeJSON,
+			Locations:   []Location{LocationIngress},
+		},
+		{
+			Suffix:    "healthcheck-port",
+			Type:      TypeString,
+			TypeDoc:   `integer \| traffic-port`,
+			Default:   "traffic-port",
+			Locations: []Location{LocationIngress, LocationService},
+		},
+	}
+	want := "|Name                       | Type |Default|Location|MergeBehavior|\n" +
+		"|---------------------------|------|-------|--------|------|\n" +
+		"|[alb.ingress.kubernetes.io/scheme](#scheme)|internal \\| internet-facing|internal|Ingress|Exclusive|\n" +
+		"|[alb.ingress.kubernetes.io/actions.${action-name}](#actions)|json|N/A|Ingress|N/A|\n" +
+		"|[alb.ingress.kubernetes.io/healthcheck-port](#healthcheck-port)|integer \\| traffic-port|traffic-port|Ingress,Service|N/A|\n"
+	got := RenderMarkdownTable("alb.ingress.kubernetes.io", fields)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,556 @@
+package schema
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+)
+
+var (
+	locationsIngress           = []Location{LocationIngress}
+	locationsIngressAndService = []Location{LocationIngress, LocationService}
+)
+
+// IngressFields declares the annotations with "alb.ingress.kubernetes.io" prefix, in the order they're documented.
+// they're only used to validate annotations and generate docs, model builders parse annotations on their own.
+var IngressFields = []Field{
+	{
+		Suffix:        annotations.IngressSuffixLoadBalancerName,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixLoadBalancerARN,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+		Validate:      validateLoadBalancerARN,
+	},
+	{
+		Suffix:    annotations.IngressSuffixGroupName,
+		Type:      TypeString,
+		Locations: locationsIngress,
+	},
+	{
+		Suffix:    annotations.IngressSuffixGroupOrder,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(1),
+		Maximum:   int64Ptr(1000),
+		Default:   "0",
+		Locations: locationsIngress,
+	},
+	{
+		Suffix:    annotations.IngressSuffixPathOrder,
+		Type:      TypeStringMap,
+		Locations: locationsIngress,
+		Validate:  validatePathOrder,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHostTemplate,
+		Type:      TypeString,
+		Locations: locationsIngress,
+		Validate:  validateHostTemplate,
+	},
+	{
+		Suffix:        annotations.IngressSuffixTags,
+		Type:          TypeStringMap,
+		Locations:     locationsIngressAndService,
+		MergeBehavior: MergeBehaviorMerge,
+	},
+	{
+		Suffix:        annotations.IngressSuffixIPAddressType,
+		Type:          TypeString,
+		Enum:          []string{"ipv4", "dualstack"},
+		Default:       "ipv4",
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixScheme,
+		Type:          TypeString,
+		Enum:          []string{"internal", "internet-facing"},
+		Default:       "internal",
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixSubnets,
+		Type:          TypeStringList,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixSecurityGroups,
+		Type:          TypeStringList,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixManageSecurityGroupRules,
+		Type:          TypeBoolean,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixCustomerOwnedIPv4Pool,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixLoadBalancerAttributes,
+		Type:          TypeStringMap,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixIdleTimeoutSeconds,
+		Type:          TypeInteger,
+		Minimum:       int64Ptr(1),
+		Maximum:       int64Ptr(4000),
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixClientKeepAliveSeconds,
+		Type:          TypeInteger,
+		Minimum:       int64Ptr(60),
+		Maximum:       int64Ptr(604800),
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixWAFv2ACLARN,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixWAFACLID,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixWebACLID,
+		Type:          TypeString,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+		Deprecated:    true,
+	},
+	{
+		Suffix:        annotations.IngressSuffixShieldAdvancedProtection,
+		Type:          TypeBoolean,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixCloudWatchDashboard,
+		Type:          TypeBoolean,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:    annotations.IngressSuffixRoute53HostedZoneID,
+		Type:      TypeString,
+		Locations: locationsIngress,
+	},
+	{
+		Suffix:        annotations.IngressSuffixListenPorts,
+		Type:          TypeJSON,
+		Default:       `'[{"HTTP": 80}]' \| '[{"HTTPS": 443}]'`,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorMerge,
+	},
+	{
+		Suffix:        annotations.IngressSuffixSSLRedirect,
+		Type:          TypeInteger,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixInboundCIDRs,
+		Type:          TypeStringList,
+		Default:       "0.0.0.0/0, ::/0",
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixCertificateARN,
+		Type:          TypeStringList,
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorMerge,
+	},
+	{
+		Suffix:        annotations.IngressSuffixSSLPolicy,
+		Type:          TypeString,
+		TypeDoc:       `string \| stringMap`,
+		Default:       "ELBSecurityPolicy-2016-08",
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+		Validate:      validateSSLPolicy,
+	},
+	{
+		Suffix:    annotations.IngressSuffixTargetType,
+		Type:      TypeString,
+		Enum:      []string{"instance", "ip"},
+		Default:   "instance",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixBackendProtocol,
+		Type:      TypeString,
+		Enum:      []string{"HTTP", "HTTPS"},
+		Default:   "HTTP",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixBackendProtocolVersion,
+		Type:      TypeString,
+		Default:   "HTTP1",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixTargetGroupAttributes,
+		Type:      TypeStringMap,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixSlowStartSeconds,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(0),
+		Maximum:   int64Ptr(900),
+		Locations: locationsIngressAndService,
+		Validate:  validateSlowStartSeconds,
+	},
+	{
+		Suffix:    annotations.IngressSuffixStickinessEnabled,
+		Type:      TypeBoolean,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixStickinessType,
+		Type:      TypeString,
+		Enum:      []string{"lb_cookie", "app_cookie"},
+		Default:   "lb_cookie",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixStickinessDurationSeconds,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(1),
+		Maximum:   int64Ptr(604800),
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixStickinessCookieName,
+		Type:      TypeString,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixLoadBalancingAlgorithm,
+		Type:      TypeString,
+		Enum:      []string{"round_robin", "least_outstanding_requests", "weighted_random"},
+		Default:   "round_robin",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAnomalyMitigationEnabled,
+		Type:      TypeBoolean,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsCount,
+		Type:      TypeString,
+		TypeDoc:   `integer \| off`,
+		Locations: locationsIngressAndService,
+		Validate:  validateMinimumHealthyTargets(1, math.MaxInt32),
+	},
+	{
+		Suffix:    annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsPercentage,
+		Type:      TypeString,
+		TypeDoc:   `integer \| off`,
+		Locations: locationsIngressAndService,
+		Validate:  validateMinimumHealthyTargets(1, 100),
+	},
+	{
+		Suffix:    annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsCount,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(1),
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsPercentage,
+		Type:      TypeString,
+		TypeDoc:   `integer \| off`,
+		Locations: locationsIngressAndService,
+		Validate:  validateMinimumHealthyTargets(1, 100),
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckPort,
+		Type:      TypeString,
+		TypeDoc:   `integer \| traffic-port`,
+		Default:   "traffic-port",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckProtocol,
+		Type:      TypeString,
+		Enum:      []string{"HTTP", "HTTPS"},
+		Default:   "HTTP",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckPath,
+		Type:      TypeString,
+		Default:   `/ \| /AWS.ALB/healthcheck`,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckIntervalSeconds,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(5),
+		Maximum:   int64Ptr(300),
+		Default:   "'15'",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckTimeoutSeconds,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(2),
+		Maximum:   int64Ptr(120),
+		Default:   "'5'",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthyThresholdCount,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(2),
+		Maximum:   int64Ptr(10),
+		Default:   "'2'",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixUnhealthyThresholdCount,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(2),
+		Maximum:   int64Ptr(10),
+		Default:   "'2'",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixSuccessCodes,
+		Type:      TypeString,
+		Default:   `'200' \| '12'`,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixGRPCSuccessCodes,
+		Type:      TypeString,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckSource,
+		Type:      TypeString,
+		Enum:      []string{"target-group", "readiness"},
+		Default:   "target-group",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixHealthCheckPreset,
+		Type:      TypeString,
+		Enum:      []string{"spring-boot", "grpc-health-probe", "rails", "django"},
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthType,
+		Type:      TypeString,
+		Enum:      []string{"none", "oidc", "cognito"},
+		Default:   "none",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthIDPCognito,
+		Type:      TypeJSON,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthIDPOIDC,
+		Type:      TypeJSON,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthOnUnauthenticatedRequest,
+		Type:      TypeString,
+		Enum:      []string{"authenticate", "allow", "deny"},
+		Default:   "authenticate",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthScope,
+		Type:      TypeString,
+		Default:   "openid",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthSessionCookie,
+		Type:      TypeString,
+		Default:   "AWSELBAuthSessionCookie",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixAuthSessionTimeout,
+		Type:      TypeInteger,
+		Minimum:   int64Ptr(1),
+		Maximum:   int64Ptr(604800),
+		Default:   "'604800'",
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:      annotations.IngressSuffixPrefixActions,
+		Placeholder: "${action-name}",
+		Type:        TypeJSON,
+		Locations:   locationsIngress,
+	},
+	{
+		Suffix:      annotations.IngressSuffixPrefixConditions,
+		Placeholder: "${conditions-name}",
+		Type:        TypeJSON,
+		Locations:   locationsIngress,
+	},
+	{
+		Suffix:      annotations.IngressSuffixPrefixProfile,
+		Placeholder: "${profile-name}.{actions,conditions}.${name}",
+		Type:        TypeJSON,
+		Locations:   locationsIngress,
+	},
+	{
+		Suffix:    annotations.IngressSuffixTargetNodeLabels,
+		Type:      TypeStringMap,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:    annotations.IngressSuffixExternalTargets,
+		Type:      TypeStringList,
+		Locations: locationsIngressAndService,
+	},
+	{
+		Suffix:        annotations.IngressSuffixAWSAPIMaxRetries,
+		Type:          TypeInteger,
+		Minimum:       int64Ptr(0),
+		Maximum:       int64Ptr(100),
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+	{
+		Suffix:        annotations.IngressSuffixAWSAPITimeoutSeconds,
+		Type:          TypeInteger,
+		Minimum:       int64Ptr(1),
+		Maximum:       int64Ptr(3600),
+		Locations:     locationsIngress,
+		MergeBehavior: MergeBehaviorExclusive,
+	},
+}
+
+// validateLoadBalancerARN validates the load-balancer-arn annotation, which must be the ARN of an Application Load Balancer.
+func validateLoadBalancerARN(rawValue string) error {
+	lbARN, err := arn.Parse(rawValue)
+	if err != nil {
+		return err
+	}
+	if lbARN.Service != "elasticloadbalancing" || !strings.HasPrefix(lbARN.Resource, "loadbalancer/app/") {
+		return errors.Errorf("not an application load balancer ARN: %v", rawValue)
+	}
+	return nil
+}
+
+// validateSlowStartSeconds validates the slow-start annotation, which is either 0 to disable slow start or within [30, 900].
+func validateSlowStartSeconds(rawValue string) error {
+	slowStartSeconds, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		return err
+	}
+	if slowStartSeconds != 0 && (slowStartSeconds < 30 || slowStartSeconds > 900) {
+		return errors.Errorf("value must be 0 or within [30, 900], got %v", slowStartSeconds)
+	}
+	return nil
+}
+
+// validateMinimumHealthyTargets returns a validator for minimum healthy targets annotations, which are either "off" or an integer within [min, max].
+func validateMinimumHealthyTargets(min int64, max int64) func(rawValue string) error {
+	return func(rawValue string) error {
+		if rawValue == "off" {
+			return nil
+		}
+		value, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			return errors.Errorf("value must be off or an integer, got %v", rawValue)
+		}
+		if value < min || value > max {
+			return errors.Errorf("value must be off or within [%v, %v], got %v", min, max, value)
+		}
+		return nil
+	}
+}
+
+// validatePathOrder validates the path-order annotation, which is a map from path to order within [1, 1000].
+func validatePathOrder(rawValue string) error {
+	var orderByPath map[string]string
+	parser := annotations.NewSuffixAnnotationParser("")
+	rawAnnotations := map[string]string{annotations.IngressSuffixPathOrder: rawValue}
+	if _, err := parser.ParseStringMapAnnotation(annotations.IngressSuffixPathOrder, &orderByPath, rawAnnotations, annotations.WithExact()); err != nil {
+		return err
+	}
+	for path, rawOrder := range orderByPath {
+		order, err := strconv.ParseInt(rawOrder, 10, 64)
+		if err != nil || order < 1 || order > 1000 {
+			return errors.Errorf("order of path %v must be within [1, 1000]: %v", path, rawOrder)
+		}
+	}
+	return nil
+}
+
+// validateHostTemplate validates the host-template annotation, which must be a valid DNS name once its placeholders are expanded.
+func validateHostTemplate(rawValue string) error {
+	expandedHost := strings.NewReplacer(
+		annotations.HostTemplatePlaceholderNamespace, "namespace",
+		annotations.HostTemplatePlaceholderName, "name",
+	).Replace(rawValue)
+	if strings.Contains(expandedHost, "{{") {
+		return errors.Errorf("host template only supports %v and %v placeholders: %v",
+			annotations.HostTemplatePlaceholderNamespace, annotations.HostTemplatePlaceholderName, rawValue)
+	}
+	if errs := validation.IsDNS1123Subdomain(expandedHost); len(errs) != 0 {
+		return errors.Errorf("host template must expand to a valid DNS name: %v", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateSSLPolicy validates the ssl-policy annotation, which is either a single policy or a map from listen port to policy.
+func validateSSLPolicy(rawValue string) error {
+	if !strings.Contains(rawValue, "=") {
+		return nil
+	}
+	var sslPolicyByPort map[string]string
+	parser := annotations.NewSuffixAnnotationParser("")
+	rawAnnotations := map[string]string{annotations.IngressSuffixSSLPolicy: rawValue}
+	if _, err := parser.ParseStringMapAnnotation(annotations.IngressSuffixSSLPolicy, &sslPolicyByPort, rawAnnotations, annotations.WithExact()); err != nil {
+		return err
+	}
+	for rawPort, sslPolicy := range sslPolicyByPort {
+		port, err := strconv.ParseInt(rawPort, 10, 64)
+		if err != nil || port < 1 || port > 65535 {
+			return errors.Errorf("listen port must be within [1, 65535]: %v", rawPort)
+		}
+		if len(sslPolicy) == 0 {
+			return errors.Errorf("empty policy for listen port: %v", rawPort)
+		}
+	}
+	return nil
+}
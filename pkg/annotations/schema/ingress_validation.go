@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+)
+
+// FindIngressField returns the Field declaring annotation suffix with "alb.ingress.kubernetes.io" prefix.
+func FindIngressField(suffix string) (Field, bool) {
+	for _, field := range IngressFields {
+		if field.Matches(suffix) {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// FindUnknownIngressAnnotations returns the sorted annotation keys with the Ingress annotation prefix that are not recognized by this controller.
+func FindUnknownIngressAnnotations(rawAnnotations map[string]string) []string {
+	var unknownAnnotations []string
+	prefix := fmt.Sprintf("%v/", annotations.AnnotationPrefixIngress)
+	for key := range rawAnnotations {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if _, known := FindIngressField(strings.TrimPrefix(key, prefix)); !known {
+			unknownAnnotations = append(unknownAnnotations, key)
+		}
+	}
+	return sets.NewString(unknownAnnotations...).List()
+}
+
+// ValidateIngressAnnotations validates values of known annotations with the Ingress annotation prefix.
+// annotations are validated in sorted order of keys, and the first invalid annotation is reported.
+func ValidateIngressAnnotations(rawAnnotations map[string]string) error {
+	prefix := fmt.Sprintf("%v/", annotations.AnnotationPrefixIngress)
+	for _, key := range sets.StringKeySet(rawAnnotations).List() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		field, known := FindIngressField(strings.TrimPrefix(key, prefix))
+		if !known {
+			continue
+		}
+		if err := field.ValidateValue(rawAnnotations[key]); err != nil {
+			return errors.Wrapf(err, "invalid annotation %v", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindUnknownIngressAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		rawAnnotations map[string]string
+		want           []string
+	}{
+		{
+			name:           "no annotations",
+			rawAnnotations: nil,
+			want:           []string{},
+		},
+		{
+			name: "known annotations only",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":           "internet-facing",
+				"alb.ingress.kubernetes.io/healthcheck-path": "/healthz",
+				"alb.ingress.kubernetes.io/web-acl-id":       "acl-id",
+				"alb.ingress.kubernetes.io/actions.blue":     "{}",
+				"alb.ingress.kubernetes.io/conditions.green": "[]",
+				"kubernetes.io/ingress.class":                "alb",
+				"nginx.ingress.kubernetes.io/rewrite-target": "/",
+			},
+			want: []string{},
+		},
+		{
+			name: "unknown annotations",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":           "internet-facing",
+				"alb.ingress.kubernetes.io/helathcheck-path": "/healthz",
+				"alb.ingress.kubernetes.io/actions.":         "{}",
+				"alb.ingress.kubernetes.io/target_type":      "ip",
+			},
+			want: []string{
+				"alb.ingress.kubernetes.io/actions.",
+				"alb.ingress.kubernetes.io/helathcheck-path",
+				"alb.ingress.kubernetes.io/target_type",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindUnknownIngressAnnotations(tt.rawAnnotations)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateIngressAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		rawAnnotations map[string]string
+		wantErr        error
+	}{
+		{
+			name: "valid annotations",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":                       "internet-facing",
+				"alb.ingress.kubernetes.io/group.order":                  "10",
+				"alb.ingress.kubernetes.io/shield-advanced-protection":   "true",
+				"alb.ingress.kubernetes.io/tags":                         "k1=v1,k2=v2",
+				"alb.ingress.kubernetes.io/listen-ports":                 `[{"HTTP": 80}]`,
+				"alb.ingress.kubernetes.io/actions.blue":                 `{"type": "fixed-response"}`,
+				"alb.ingress.kubernetes.io/healthcheck-interval-seconds": "15",
+				"alb.ingress.kubernetes.io/unknown":                      "ignored",
+				"kubernetes.io/ingress.class":                            "alb",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid integer",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.order": "first",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/group.order: failed to parse int64 annotation, group.order: first: strconv.ParseInt: parsing \"first\": invalid syntax"),
+		},
+		{
+			name: "integer out of range",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/group.order": "1001",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/group.order: value must be no more than 1000, got 1001"),
+		},
+		{
+			name: "invalid boolean",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/shield-advanced-protection": "yes",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/shield-advanced-protection: failed to parse bool annotation, shield-advanced-protection: yes: strconv.ParseBool: parsing \"yes\": invalid syntax"),
+		},
+		{
+			name: "invalid stringMap",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/tags": "k1",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/tags: failed to parse stringMap annotation, tags: k1"),
+		},
+		{
+			name: "invalid json",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.blue": "{",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/actions.blue: failed to parse json annotation: {"),
+		},
+		{
+			name: "invalid enum",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/target-type": "lambda",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/target-type: value must be within [instance, ip], got lambda"),
+		},
+		{
+			name: "valid ssl-policy by listen port",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-policy": "443=ELBSecurityPolicy-TLS13-1-2-2021-06,8443=ELBSecurityPolicy-2016-08",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid ssl-policy listen port",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-policy": "https=ELBSecurityPolicy-TLS13-1-2-2021-06",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/ssl-policy: listen port must be within [1, 65535]: https"),
+		},
+		{
+			name: "invalid path-order",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/path-order": "/=last",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/path-order: order of path / must be within [1, 1000]: last"),
+		},
+		{
+			name: "valid host-template",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/host-template": "{{namespace}}-{{name}}.preview.example.com",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "host-template with unknown placeholder",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/host-template": "{{branch}}.preview.example.com",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/host-template: host template only supports {{namespace}} and {{name}} placeholders: {{branch}}.preview.example.com"),
+		},
+		{
+			name: "valid load-balancer-arn",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/shared-alb/50dc6c495c0c9188",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "load-balancer-arn of network load balancer",
+			rawAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/load-balancer-arn": "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/shared-nlb/50dc6c495c0c9188",
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/load-balancer-arn: not an application load balancer ARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/shared-nlb/50dc6c495c0c9188"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIngressAnnotations(tt.rawAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
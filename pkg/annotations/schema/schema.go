@@ -0,0 +1,139 @@
+// Package schema declares the annotations recognized by this controller, as a validation and docs schema.
+// Annotation validation, unknown annotation detection and the annotation reference docs are derived from the declarations here.
+// The typed parsing of annotations into the model isn't derived from the schema, it stays within model builders,
+// thus adding a new annotation requires a constant, a Field, its documentation section and the parsing within model builders,
+// and the Field must accept exactly the values the model builders accept, which is cross-checked in tests of pkg/ingress.
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+)
+
+// Type is the value type of an annotation.
+type Type string
+
+const (
+	TypeString     Type = "string"
+	TypeInteger    Type = "integer"
+	TypeBoolean    Type = "boolean"
+	TypeStringList Type = "stringList"
+	TypeStringMap  Type = "stringMap"
+	TypeJSON       Type = "json"
+)
+
+// Location is the kind of object an annotation can be applied to.
+type Location string
+
+const (
+	LocationIngress Location = "Ingress"
+	LocationService Location = "Service"
+)
+
+// MergeBehavior is how an annotation is merged across Ingresses within IngressGroup.
+type MergeBehavior string
+
+const (
+	MergeBehaviorNone      MergeBehavior = ""
+	MergeBehaviorExclusive MergeBehavior = "Exclusive"
+	MergeBehaviorMerge     MergeBehavior = "Merge"
+)
+
+// Field declares an annotation.
+type Field struct {
+	// Suffix is the annotation key without annotation prefix.
+	// For prefix fields, it's the common prefix of suffixes, e.g. "actions."
+	Suffix string
+	// Placeholder denotes the variable part of suffix for prefix fields, e.g. "${action-name}".
+	// Fields with Placeholder match any suffix starting with Suffix.
+	Placeholder string
+	// Type is the value type of annotation.
+	Type Type
+	// Enum is the allowed values of annotation if not empty.
+	Enum []string
+	// Minimum is the minimum allowed value for integer annotation if not nil.
+	Minimum *int64
+	// Maximum is the maximum allowed value for integer annotation if not nil.
+	Maximum *int64
+	// TypeDoc overrides the type column of docs, e.g. "integer | traffic-port".
+	TypeDoc string
+	// Default is the default value as shown in docs.
+	Default string
+	// Locations is the kinds of object the annotation can be applied to.
+	Locations []Location
+	// MergeBehavior is how the annotation is merged across Ingresses within IngressGroup.
+	MergeBehavior MergeBehavior
+	// Deprecated annotations are still recognized, but not documented.
+	Deprecated bool
+	// Validate performs additional validation on raw value after type checking if not nil.
+	Validate func(rawValue string) error
+}
+
+// IsPrefix tests whether this field matches a family of suffixes.
+func (f Field) IsPrefix() bool {
+	return f.Placeholder != ""
+}
+
+// Matches tests whether suffix is declared by this field.
+func (f Field) Matches(suffix string) bool {
+	if f.IsPrefix() {
+		return strings.HasPrefix(suffix, f.Suffix) && len(suffix) > len(f.Suffix)
+	}
+	return suffix == f.Suffix
+}
+
+// ValidateValue validates raw value of annotation against this field.
+func (f Field) ValidateValue(rawValue string) error {
+	// reuse annotation parser to make sure validation is consistent with how controller parses annotations.
+	parser := annotations.NewSuffixAnnotationParser("")
+	rawAnnotations := map[string]string{f.Suffix: rawValue}
+	switch f.Type {
+	case TypeInteger:
+		var value int64
+		if _, err := parser.ParseInt64Annotation(f.Suffix, &value, rawAnnotations, annotations.WithExact()); err != nil {
+			return err
+		}
+		if f.Minimum != nil && value < *f.Minimum {
+			return errors.Errorf("value must be no less than %v, got %v", *f.Minimum, value)
+		}
+		if f.Maximum != nil && value > *f.Maximum {
+			return errors.Errorf("value must be no more than %v, got %v", *f.Maximum, value)
+		}
+	case TypeBoolean:
+		var value bool
+		if _, err := parser.ParseBoolAnnotation(f.Suffix, &value, rawAnnotations, annotations.WithExact()); err != nil {
+			return err
+		}
+	case TypeStringMap:
+		if _, err := parser.ParseStringMapAnnotation(f.Suffix, nil, rawAnnotations, annotations.WithExact()); err != nil {
+			return err
+		}
+	case TypeJSON:
+		if !json.Valid([]byte(rawValue)) {
+			return errors.Errorf("failed to parse json annotation: %v", rawValue)
+		}
+	}
+	if len(f.Enum) != 0 && !containsString(f.Enum, rawValue) {
+		return errors.Errorf("value must be within [%v], got %v", strings.Join(f.Enum, ", "), rawValue)
+	}
+	if f.Validate != nil {
+		return f.Validate(rawValue)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
@@ -35,23 +36,45 @@ type Cloud interface {
 	// RGT provides API to AWS RGT
 	RGT() services.RGT
 
+	// Lambda provides API to AWS Lambda
+	Lambda() services.Lambda
+
+	// CloudWatch provides API to AWS CloudWatch
+	CloudWatch() services.CloudWatch
+
+	// S3 provides API to AWS S3
+	S3() services.S3
+
+	// Route53 provides API to AWS Route53
+	Route53() services.Route53
+
 	// Region for the kubernetes cluster
 	Region() string
 
+	// Partition that Region belongs to
+	Partition() string
+
 	// VpcID for the LoadBalancer resources.
 	VpcID() string
+
+	// Credentials used to sign AWS API calls.
+	Credentials() *credentials.Credentials
 }
 
 // NewCloud constructs new Cloud implementation.
 func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud, error) {
-	endpointsResolver := epresolver.NewResolver(cfg.AWSEndpoints)
+	endpointsModel, err := epresolver.LoadModel(cfg.EndpointsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load AWS endpoints metadata")
+	}
+	endpointsResolver := epresolver.NewResolver(cfg.AWSEndpoints, endpointsModel, cfg.UseFIPSEndpoint)
 	metadataCFG := aws.NewConfig().WithEndpointResolver(endpointsResolver)
 	metadataSess := session.Must(session.NewSession(metadataCFG))
 	metadata := services.NewEC2Metadata(metadataSess)
 	if len(cfg.VpcID) == 0 {
 		vpcId, err := metadata.VpcID()
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to introspect vpcID from EC2Metadata, specify --aws-vpc-id instead if EC2Metadata is unavailable")
+			return nil, wrapEC2MetadataError(err, "vpcID", flagAWSVpcID)
 		}
 		cfg.VpcID = vpcId
 	}
@@ -66,14 +89,23 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 			err := (error)(nil)
 			region, err = metadata.Region()
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to introspect region from EC2Metadata, specify --aws-region instead if EC2Metadata is unavailable")
+				return nil, wrapEC2MetadataError(err, "region", flagAWSRegion)
 			}
 		}
 		cfg.Region = region
 	}
+	partition, err := epresolver.PartitionIDForRegion(endpointsModel, cfg.Region)
+	if err != nil {
+		return nil, err
+	}
 	awsCFG := aws.NewConfig().WithRegion(cfg.Region).WithSTSRegionalEndpoint(endpoints.RegionalSTSEndpoint).WithMaxRetries(cfg.MaxRetries).WithEndpointResolver(endpointsResolver)
+	if creds := buildWebIdentityCredentials(session.Must(session.NewSession(awsCFG))); creds != nil {
+		awsCFG = awsCFG.WithCredentials(creds)
+	}
 	sess := session.Must(session.NewSession(awsCFG))
 	injectUserAgent(&sess.Handlers)
+	injectRequestLogger(&sess.Handlers)
+	injectRequestConfig(&sess.Handlers)
 
 	if cfg.ThrottleConfig != nil {
 		throttler := throttle.NewThrottler(cfg.ThrottleConfig)
@@ -89,6 +121,7 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 
 	return &defaultCloud{
 		cfg:         cfg,
+		partition:   partition,
 		ec2:         services.NewEC2(sess),
 		elbv2:       services.NewELBV2(sess),
 		acm:         services.NewACM(sess),
@@ -96,13 +129,27 @@ func NewCloud(cfg CloudConfig, metricsRegisterer prometheus.Registerer) (Cloud,
 		wafRegional: services.NewWAFRegional(sess, cfg.Region),
 		shield:      services.NewShield(sess),
 		rgt:         services.NewRGT(sess),
+		lambda:      services.NewLambda(sess),
+		cloudWatch:  services.NewCloudWatch(sess),
+		s3:          services.NewS3(sess),
+		route53:     services.NewRoute53(sess),
+		credentials: sess.Config.Credentials,
 	}, nil
 }
 
+// wrapEC2MetadataError explains the failure to introspect attribute from EC2Metadata.
+// EC2Metadata is unreachable from pods if IMDSv2 is required on the instance with a hop limit of 1,
+// since the response of IMDSv2 session token is dropped after one hop.
+func wrapEC2MetadataError(err error, attribute string, flag string) error {
+	return errors.Wrapf(err, "failed to introspect %v from EC2Metadata, specify --%v instead if EC2Metadata is unavailable. "+
+		"If IMDSv2 is required on the instance, its metadata hop limit must be 2 or higher to reach EC2Metadata from pods", attribute, flag)
+}
+
 var _ Cloud = &defaultCloud{}
 
 type defaultCloud struct {
-	cfg CloudConfig
+	cfg       CloudConfig
+	partition string
 
 	ec2   services.EC2
 	elbv2 services.ELBV2
@@ -112,6 +159,11 @@ type defaultCloud struct {
 	wafRegional services.WAFRegional
 	shield      services.Shield
 	rgt         services.RGT
+	lambda      services.Lambda
+	cloudWatch  services.CloudWatch
+	s3          services.S3
+	route53     services.Route53
+	credentials *credentials.Credentials
 }
 
 func (c *defaultCloud) EC2() services.EC2 {
@@ -142,10 +194,34 @@ func (c *defaultCloud) RGT() services.RGT {
 	return c.rgt
 }
 
+func (c *defaultCloud) Lambda() services.Lambda {
+	return c.lambda
+}
+
+func (c *defaultCloud) CloudWatch() services.CloudWatch {
+	return c.cloudWatch
+}
+
+func (c *defaultCloud) S3() services.S3 {
+	return c.s3
+}
+
+func (c *defaultCloud) Route53() services.Route53 {
+	return c.route53
+}
+
 func (c *defaultCloud) Region() string {
 	return c.cfg.Region
 }
 
+func (c *defaultCloud) Partition() string {
+	return c.partition
+}
+
 func (c *defaultCloud) VpcID() string {
 	return c.cfg.VpcID
 }
+
+func (c *defaultCloud) Credentials() *credentials.Credentials {
+	return c.credentials
+}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -8,15 +10,18 @@ import (
 )
 
 const (
-	flagAWSRegion        = "aws-region"
-	flagAWSAPIEndpoints  = "aws-api-endpoints"
-	flagAWSAPIThrottle   = "aws-api-throttle"
-	flagAWSVpcID         = "aws-vpc-id"
-	flagAWSVpcCacheTTL   = "aws-vpc-cache-ttl"
-	flagAWSMaxRetries    = "aws-max-retries"
-	defaultVpcID         = ""
-	defaultRegion        = ""
-	defaultAPIMaxRetries = 10
+	flagAWSRegion                    = "aws-region"
+	flagAWSAPIEndpoints              = "aws-api-endpoints"
+	flagAWSEndpointsFile             = "aws-endpoints-file"
+	flagAWSUseFIPS                   = "aws-use-fips-endpoint"
+	flagAWSAPIThrottle               = "aws-api-throttle"
+	flagAWSVpcID                     = "aws-vpc-id"
+	flagAWSVpcCacheTTL               = "aws-vpc-cache-ttl"
+	flagAWSMaxRetries                = "aws-max-retries"
+	flagAWSCredentialsReadinessCheck = "aws-credentials-readiness-check"
+	defaultVpcID                     = ""
+	defaultRegion                    = ""
+	defaultAPIMaxRetries             = 10
 )
 
 type CloudConfig struct {
@@ -37,6 +42,15 @@ type CloudConfig struct {
 
 	// AWS endpoints configuration
 	AWSEndpoints map[string]string
+
+	// Path to AWS regions and partitions metadata in the format of SDK's endpoints.json
+	EndpointsFile string
+
+	// Whether to use FIPS endpoints for AWS APIs that are not customized by AWSEndpoints
+	UseFIPSEndpoint bool
+
+	// Whether the controller is only ready when AWS credentials are allowed to call elasticloadbalancing:DescribeLoadBalancers
+	CredentialsReadinessCheck bool
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
@@ -45,4 +59,9 @@ func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&cfg.VpcID, flagAWSVpcID, defaultVpcID, "AWS VpcID for the LoadBalancer resources")
 	fs.IntVar(&cfg.MaxRetries, flagAWSMaxRetries, defaultAPIMaxRetries, "Maximum retries for AWS APIs")
 	fs.StringToStringVar(&cfg.AWSEndpoints, flagAWSAPIEndpoints, nil, "Custom AWS endpoint configuration, format: serviceID1=URL1,serviceID2=URL2")
+	fs.StringVar(&cfg.EndpointsFile, flagAWSEndpointsFile, "", "Path to AWS regions and partitions metadata in the format of SDK's endpoints.json, the metadata built into SDK is used if empty")
+	fs.BoolVar(&cfg.UseFIPSEndpoint, flagAWSUseFIPS, strings.EqualFold(os.Getenv("AWS_USE_FIPS_ENDPOINT"), "true"),
+		"Use FIPS endpoints for AWS APIs, defaults to the AWS_USE_FIPS_ENDPOINT environment variable")
+	fs.BoolVar(&cfg.CredentialsReadinessCheck, flagAWSCredentialsReadinessCheck, false,
+		"Fail the readiness probe while AWS credentials can't be retrieved or aren't allowed to call elasticloadbalancing:DescribeLoadBalancers")
 }
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+const (
+	// environment variables that configures IAM roles for service accounts(IRSA), which are injected by EKS pod identity webhook.
+	envWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	envRoleARN              = "AWS_ROLE_ARN"
+	envRoleSessionName      = "AWS_ROLE_SESSION_NAME"
+
+	defaultRoleSessionName = "aws-load-balancer-controller"
+	// webIdentityCredentialsExpiryWindow is how long before expiry the web identity credentials are refreshed.
+	webIdentityCredentialsExpiryWindow = 5 * time.Minute
+)
+
+// buildWebIdentityCredentials returns the credentials for IRSA, or nil if IRSA is not configured.
+// Unlike the credentials resolved by SDK's default chain, they are refreshed ahead of expiry, so that AWS API calls never
+// observe expired credentials. The projected token is re-read from file on every refresh, thus token rotation is picked up.
+func buildWebIdentityCredentials(configProvider client.ConfigProvider) *credentials.Credentials {
+	provider := buildWebIdentityRoleProvider(sts.New(configProvider), os.Getenv)
+	if provider == nil {
+		return nil
+	}
+	return credentials.NewCredentials(provider)
+}
+
+func buildWebIdentityRoleProvider(stsClient stsiface.STSAPI, getenv func(string) string) *stscreds.WebIdentityRoleProvider {
+	tokenFile := getenv(envWebIdentityTokenFile)
+	roleARN := getenv(envRoleARN)
+	if len(tokenFile) == 0 || len(roleARN) == 0 {
+		return nil
+	}
+	roleSessionName := getenv(envRoleSessionName)
+	if len(roleSessionName) == 0 {
+		roleSessionName = defaultRoleSessionName
+	}
+	provider := stscreds.NewWebIdentityRoleProvider(stsClient, roleARN, roleSessionName, tokenFile)
+	provider.ExpiryWindow = webIdentityCredentialsExpiryWindow
+	return provider
+}
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// CredentialsCheckHandlerPath is the path on metrics server to view the result of the latest AWS credentials check.
+	CredentialsCheckHandlerPath = "/aws-credentials"
+
+	// credentialsCheckInterval is how long a successful AWS API call with the credentials is trusted by the credentials checker.
+	credentialsCheckInterval = 5 * time.Minute
+	// credentialsMonitorInterval is the interval at which the credentials checker runs.
+	credentialsMonitorInterval = 1 * time.Minute
+
+	metricSubsystemAWS       = "aws"
+	metricCredentialsHealthy = "credentials_healthy"
+)
+
+// NewCredentialsChecker constructs a credentials checker that periodically checks whether AWS credentials can be retrieved and are allowed to call
+// elasticloadbalancing:DescribeLoadBalancers, and registers its metrics to registerer.
+// the result is exposed as the aws_credentials_healthy metric and logged, and it only gates readiness of the controller if ReadinessChecker is registered,
+// so that by default transient AWS outages don't take the webhooks out of service.
+// credentials are cached until expiry, so credential providers(e.g. STS for IRSA) are only contacted when a refresh is due.
+func NewCredentialsChecker(creds *credentials.Credentials, elbv2Client services.ELBV2, registerer prometheus.Registerer, logger logr.Logger) (*credentialsChecker, error) {
+	healthy := prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemAWS,
+		Name:      metricCredentialsHealthy,
+		Help:      "Whether AWS credentials can be retrieved and used by the controller, 1 if healthy and 0 otherwise",
+	})
+	if err := registerer.Register(healthy); err != nil {
+		return nil, err
+	}
+	return &credentialsChecker{
+		creds:       creds,
+		elbv2Client: elbv2Client,
+		healthy:     healthy,
+		logger:      logger,
+		now:         time.Now,
+		lastErr:     errors.New("AWS credentials haven't been checked yet"),
+	}, nil
+}
+
+var _ manager.Runnable = &credentialsChecker{}
+var _ manager.LeaderElectionRunnable = &credentialsChecker{}
+
+type credentialsChecker struct {
+	creds       *credentials.Credentials
+	elbv2Client services.ELBV2
+	healthy     prometheus.Gauge
+	logger      logr.Logger
+	now         func() time.Time
+
+	// mutex protects lastSucceededAt and lastErr
+	mutex           sync.Mutex
+	lastSucceededAt time.Time
+	lastErr         error
+}
+
+// Start runs the credentials check periodically until ctx is done.
+func (c *credentialsChecker) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, c.run, credentialsMonitorInterval)
+	return nil
+}
+
+// NeedLeaderElection ensures credentials are checked on all replicas, since webhooks are served by all replicas.
+func (c *credentialsChecker) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns a http.Handler that responds with the result of the latest credentials check, which can be served by metrics server.
+func (c *credentialsChecker) Handler() http.Handler {
+	return &healthz.CheckHandler{
+		Checker: c.ReadinessChecker(),
+	}
+}
+
+// ReadinessChecker returns a healthz.Checker that fails while the latest credentials check fails, which can be registered as readiness probe.
+// the credentials check isn't performed by probes, so that probes never wait on AWS API calls.
+func (c *credentialsChecker) ReadinessChecker() healthz.Checker {
+	return func(_ *http.Request) error {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return c.lastErr
+	}
+}
+
+func (c *credentialsChecker) run(ctx context.Context) {
+	err := c.check(ctx)
+	c.mutex.Lock()
+	wasHealthy := c.lastErr == nil
+	c.lastErr = err
+	c.mutex.Unlock()
+
+	if err != nil {
+		c.healthy.Set(0)
+		c.logger.Error(err, "AWS credentials check failed")
+		return
+	}
+	c.healthy.Set(1)
+	if !wasHealthy {
+		c.logger.Info("AWS credentials check succeeded")
+	}
+}
+
+func (c *credentialsChecker) check(ctx context.Context) error {
+	if _, err := c.creds.GetWithContext(ctx); err != nil {
+		return errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+
+	// the mutex isn't held across the AWS API call, so that a slow call doesn't block the check endpoint.
+	c.mutex.Lock()
+	lastSucceededAt := c.lastSucceededAt
+	c.mutex.Unlock()
+	if !lastSucceededAt.IsZero() && c.now().Sub(lastSucceededAt) < credentialsCheckInterval {
+		return nil
+	}
+	describeReq := &elbv2sdk.DescribeLoadBalancersInput{
+		PageSize: awssdk.Int64(1),
+	}
+	if _, err := c.elbv2Client.DescribeLoadBalancersWithContext(ctx, describeReq); err != nil {
+		return errors.Wrap(err, "AWS credentials are unable to call elasticloadbalancing:DescribeLoadBalancers")
+	}
+	c.mutex.Lock()
+	c.lastSucceededAt = c.now()
+	c.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,187 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_credentialsChecker_run(t *testing.T) {
+	type describeLoadBalancersCall struct {
+		err error
+	}
+	tests := []struct {
+		name                      string
+		creds                     *credentials.Credentials
+		describeLoadBalancersCall *describeLoadBalancersCall
+		wantHealthy               float64
+		wantStatusCode            int
+	}{
+		{
+			name:                      "credentials can be retrieved and are authorized",
+			creds:                     credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			describeLoadBalancersCall: &describeLoadBalancersCall{},
+			wantHealthy:               1,
+			wantStatusCode:            http.StatusOK,
+		},
+		{
+			name:           "credentials cannot be retrieved",
+			creds:          credentials.NewStaticCredentials("", "", ""),
+			wantHealthy:    0,
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "credentials are not authorized",
+			creds: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			describeLoadBalancersCall: &describeLoadBalancersCall{
+				err: errors.New("AccessDenied"),
+			},
+			wantHealthy:    0,
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.describeLoadBalancersCall != nil {
+				elbv2Client.EXPECT().DescribeLoadBalancersWithContext(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{
+					PageSize: awssdk.Int64(1),
+				}).Return(&elbv2sdk.DescribeLoadBalancersOutput{}, tt.describeLoadBalancersCall.err)
+			}
+			checker, err := NewCredentialsChecker(tt.creds, elbv2Client, prometheus.NewRegistry(), &log.NullLogger{})
+			assert.NoError(t, err)
+			checker.run(context.Background())
+			assert.Equal(t, tt.wantHealthy, testutil.ToFloat64(checker.healthy))
+
+			recorder := httptest.NewRecorder()
+			checker.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", CredentialsCheckHandlerPath, nil))
+			assert.Equal(t, tt.wantStatusCode, recorder.Code)
+			readinessErr := checker.ReadinessChecker()(httptest.NewRequest("GET", "/readyz", nil))
+			assert.Equal(t, tt.wantStatusCode == http.StatusOK, readinessErr == nil)
+		})
+	}
+}
+
+func Test_credentialsChecker_ReadinessChecker(t *testing.T) {
+	checker, err := NewCredentialsChecker(credentials.NewStaticCredentials("AKID", "SECRET", ""), nil, prometheus.NewRegistry(), &log.NullLogger{})
+	assert.NoError(t, err)
+	// the controller isn't ready before credentials are checked for the first time.
+	assert.EqualError(t, checker.ReadinessChecker()(httptest.NewRequest("GET", "/readyz", nil)), "AWS credentials haven't been checked yet")
+}
+
+func Test_credentialsChecker_check(t *testing.T) {
+	type describeLoadBalancersCall struct {
+		err error
+	}
+	tests := []struct {
+		name                      string
+		creds                     *credentials.Credentials
+		describeLoadBalancersCall *describeLoadBalancersCall
+		wantErr                   string
+	}{
+		{
+			name:                      "credentials can be retrieved and are authorized",
+			creds:                     credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			describeLoadBalancersCall: &describeLoadBalancersCall{},
+		},
+		{
+			name:    "credentials cannot be retrieved",
+			creds:   credentials.NewStaticCredentials("", "", ""),
+			wantErr: "failed to retrieve AWS credentials: EmptyStaticCreds: static credentials are empty",
+		},
+		{
+			name:  "credentials are not authorized",
+			creds: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+			describeLoadBalancersCall: &describeLoadBalancersCall{
+				err: errors.New("AccessDenied"),
+			},
+			wantErr: "AWS credentials are unable to call elasticloadbalancing:DescribeLoadBalancers: AccessDenied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.describeLoadBalancersCall != nil {
+				elbv2Client.EXPECT().DescribeLoadBalancersWithContext(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{
+					PageSize: awssdk.Int64(1),
+				}).Return(&elbv2sdk.DescribeLoadBalancersOutput{}, tt.describeLoadBalancersCall.err)
+			}
+			checker := &credentialsChecker{
+				creds:       tt.creds,
+				elbv2Client: elbv2Client,
+				now:         time.Now,
+			}
+			err := checker.check(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_credentialsChecker_check_cachesSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeLoadBalancersWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeLoadBalancersOutput{}, nil).Times(2)
+
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	checker := &credentialsChecker{
+		creds:       credentials.NewStaticCredentials("AKID", "SECRET", ""),
+		elbv2Client: elbv2Client,
+		now:         func() time.Time { return now },
+	}
+	ctx := context.Background()
+	assert.NoError(t, checker.check(ctx))
+
+	now = now.Add(credentialsCheckInterval - time.Second)
+	assert.NoError(t, checker.check(ctx))
+
+	now = now.Add(time.Second)
+	assert.NoError(t, checker.check(ctx))
+}
+
+func Test_credentialsChecker_check_doesNotBlockHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checker, err := NewCredentialsChecker(credentials.NewStaticCredentials("AKID", "SECRET", ""), nil, prometheus.NewRegistry(), &log.NullLogger{})
+	assert.NoError(t, err)
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeLoadBalancersWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *elbv2sdk.DescribeLoadBalancersInput, _ ...interface{}) (*elbv2sdk.DescribeLoadBalancersOutput, error) {
+			// the check endpoint keeps serving the previous result while the AWS API call is in flight.
+			recorder := httptest.NewRecorder()
+			checker.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", CredentialsCheckHandlerPath, nil))
+			assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+			return &elbv2sdk.DescribeLoadBalancersOutput{}, nil
+		})
+	checker.elbv2Client = elbv2Client
+
+	checker.run(context.Background())
+	recorder := httptest.NewRecorder()
+	checker.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", CredentialsCheckHandlerPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildWebIdentityRoleProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantProvider bool
+	}{
+		{
+			name: "IRSA is configured",
+			env: map[string]string{
+				"AWS_WEB_IDENTITY_TOKEN_FILE": "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+				"AWS_ROLE_ARN":                "arn:aws:iam::123456789012:role/my-role",
+			},
+			wantProvider: true,
+		},
+		{
+			name: "IRSA is configured with role session name",
+			env: map[string]string{
+				"AWS_WEB_IDENTITY_TOKEN_FILE": "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+				"AWS_ROLE_ARN":                "arn:aws:iam::123456789012:role/my-role",
+				"AWS_ROLE_SESSION_NAME":       "my-session",
+			},
+			wantProvider: true,
+		},
+		{
+			name: "role ARN is missing",
+			env: map[string]string{
+				"AWS_WEB_IDENTITY_TOKEN_FILE": "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+			},
+			wantProvider: false,
+		},
+		{
+			name:         "IRSA is not configured",
+			env:          map[string]string{},
+			wantProvider: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				return tt.env[key]
+			}
+			got := buildWebIdentityRoleProvider(&sts.STS{}, getenv)
+			if tt.wantProvider {
+				assert.NotNil(t, got)
+				assert.Equal(t, webIdentityCredentialsExpiryWindow, got.ExpiryWindow)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
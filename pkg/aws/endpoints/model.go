@@ -0,0 +1,50 @@
+package endpoints
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	awsendpoints "github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
+)
+
+// LoadModel loads AWS regions and partitions metadata from file in the format of SDK's endpoints.json,
+// so that regions and partitions launched after SDK release can be used without rebuilding the controller.
+// the metadata built into SDK is used if file is empty.
+func LoadModel(file string) (awsendpoints.Resolver, error) {
+	if len(file) == 0 {
+		return awsendpoints.DefaultResolver(), nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	model, err := awsendpoints.DecodeModel(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode endpoints metadata from %v", file)
+	}
+	return model, nil
+}
+
+// PartitionIDForRegion returns the ID of partition that region belongs to.
+// regions unknown to metadata are resolved by the region naming pattern of partitions the same way as SDK resolves their endpoints,
+// so that regions launched after the metadata was published keep working.
+func PartitionIDForRegion(model awsendpoints.Resolver, region string) (string, error) {
+	enumPartitions, ok := model.(awsendpoints.EnumPartitions)
+	if !ok {
+		return "", errors.New("[should never happen] endpoints metadata cannot enumerate partitions")
+	}
+	partitions := enumPartitions.Partitions()
+	if partition, ok := awsendpoints.PartitionForRegion(partitions, region); ok {
+		return partition.ID(), nil
+	}
+	partitionIDs := make([]string, 0, len(partitions))
+	for _, partition := range partitions {
+		partitionIDs = append(partitionIDs, partition.ID())
+	}
+	sort.Strings(partitionIDs)
+	return "", errors.Errorf("unknown AWS region %q, specify --aws-region with a region of partitions %v, or specify --aws-endpoints-file with metadata of its partition",
+		region, strings.Join(partitionIDs, ", "))
+}
@@ -0,0 +1,120 @@
+package endpoints
+
+import (
+	"errors"
+	"testing"
+
+	awsendpoints "github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr error
+	}{
+		{
+			name: "built-in metadata",
+			file: "",
+		},
+		{
+			name: "metadata file",
+			file: "testdata/endpoints.json",
+		},
+		{
+			name:    "invalid metadata file",
+			file:    "testdata/invalid-endpoints.json",
+			wantErr: errors.New("failed to decode endpoints metadata from testdata/invalid-endpoints.json: DecodeEndpointsModelError: failed to decode endpoints model\ncaused by: EOF"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadModel(tt.file)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestPartitionIDForRegion(t *testing.T) {
+	customModel, err := LoadModel("testdata/endpoints.json")
+	assert.NoError(t, err)
+	tests := []struct {
+		name    string
+		model   awsendpoints.Resolver
+		region  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "known region",
+			model:  awsendpoints.DefaultResolver(),
+			region: "us-west-2",
+			want:   "aws",
+		},
+		{
+			name:   "known region in china partition",
+			model:  awsendpoints.DefaultResolver(),
+			region: "cn-north-1",
+			want:   "aws-cn",
+		},
+		{
+			name:   "known region in iso partition",
+			model:  awsendpoints.DefaultResolver(),
+			region: "us-iso-east-1",
+			want:   "aws-iso",
+		},
+		{
+			name:   "known region in iso-b partition",
+			model:  awsendpoints.DefaultResolver(),
+			region: "us-isob-east-1",
+			want:   "aws-iso-b",
+		},
+		{
+			name:   "known region in govcloud partition",
+			model:  awsendpoints.DefaultResolver(),
+			region: "us-gov-west-1",
+			want:   "aws-us-gov",
+		},
+		{
+			name:   "unknown region matching naming pattern of partition",
+			model:  awsendpoints.DefaultResolver(),
+			region: "ap-southwest-9",
+			want:   "aws",
+		},
+		{
+			name:    "invalid region",
+			model:   awsendpoints.DefaultResolver(),
+			region:  "uswest2",
+			wantErr: errors.New("unknown AWS region \"uswest2\", specify --aws-region with a region of partitions aws, aws-cn, aws-iso, aws-iso-b, aws-us-gov, or specify --aws-endpoints-file with metadata of its partition"),
+		},
+		{
+			name:    "region of partition unknown to built-in metadata",
+			model:   awsendpoints.DefaultResolver(),
+			region:  "xx-central-1",
+			wantErr: errors.New("unknown AWS region \"xx-central-1\", specify --aws-region with a region of partitions aws, aws-cn, aws-iso, aws-iso-b, aws-us-gov, or specify --aws-endpoints-file with metadata of its partition"),
+		},
+		{
+			name:   "region of partition from metadata file",
+			model:  customModel,
+			region: "xx-central-1",
+			want:   "aws-xx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PartitionIDForRegion(tt.model, tt.region)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
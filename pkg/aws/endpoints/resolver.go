@@ -1,12 +1,29 @@
 package endpoints
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	awsendpoints "github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/pkg/errors"
 )
 
-func NewResolver(configuration map[string]string) *resolver {
+// ec2MetadataServiceID is the ID of EC2 instance metadata service, which is local to instance and has no FIPS endpoint.
+const ec2MetadataServiceID = "ec2metadata"
+
+// fipsRegionFormats are the formats of pseudo regions that FIPS endpoints are modeled as in regions and partitions metadata,
+// e.g. "fips-us-west-2" for elasticloadbalancing and "us-west-2-fips" for waf-regional.
+var fipsRegionFormats = []string{"fips-%s", "%s-fips"}
+
+// usGovPartitionID is the ID of AWS GovCloud (US) partition, whose standard endpoints are FIPS validated.
+const usGovPartitionID = "aws-us-gov"
+
+func NewResolver(configuration map[string]string, model awsendpoints.Resolver, useFIPSEndpoint bool) *resolver {
 	return &resolver{
-		configuration: configuration,
+		configuration:   configuration,
+		model:           model,
+		useFIPSEndpoint: useFIPSEndpoint,
 	}
 }
 
@@ -15,8 +32,12 @@ var _ awsendpoints.Resolver = &resolver{}
 // resolver is an AWS endpoints.Resolver that allows to customize AWS API endpoints.
 // It can be configured using the following format "${AWSServiceID}=${URL}"
 // e.g. "ec2=https://ec2.domain.com,elasticloadbalancing=https://elbv2.domain.com"
+// endpoints that are not customized are resolved from the regions and partitions metadata model,
+// which are the FIPS endpoints if useFIPSEndpoint is enabled.
 type resolver struct {
-	configuration map[string]string
+	configuration   map[string]string
+	model           awsendpoints.Resolver
+	useFIPSEndpoint bool
 }
 
 func (c *resolver) EndpointFor(service, region string, opts ...func(*awsendpoints.Options)) (awsendpoints.ResolvedEndpoint, error) {
@@ -26,5 +47,56 @@ func (c *resolver) EndpointFor(service, region string, opts ...func(*awsendpoint
 			URL: customEndpoint,
 		}, nil
 	}
-	return awsendpoints.DefaultResolver().EndpointFor(service, region, opts...)
+	if c.useFIPSEndpoint && service != ec2MetadataServiceID {
+		return c.resolveFIPSEndpoint(service, region, opts...)
+	}
+	return c.model.EndpointFor(service, region, opts...)
+}
+
+// resolveFIPSEndpoint resolves the FIPS endpoint of service in region.
+// FIPS endpoints missing from the metadata model, e.g. wafv2, shield and s3 in SDK's built-in metadata,
+// fall back to the FIPS hostname AWS documents as "${service}-fips.${region}.${dnsSuffix}" rather than the non-FIPS endpoint,
+// so that API calls never leave the FIPS boundary silently.
+func (c *resolver) resolveFIPSEndpoint(service, region string, opts ...func(*awsendpoints.Options)) (awsendpoints.ResolvedEndpoint, error) {
+	strictOpts := append(append([]func(*awsendpoints.Options){}, opts...), awsendpoints.StrictMatchingOption)
+	for _, format := range fipsRegionFormats {
+		resolved, err := c.model.EndpointFor(service, fmt.Sprintf(format, region), strictOpts...)
+		if err == nil {
+			return resolved, nil
+		}
+	}
+
+	// services unknown to the metadata model are resolved with the partition's hostname template, like SDK clients do.
+	// the regional endpoint of s3 in us-east-1 carries the region in its hostname like the FIPS endpoint does.
+	regionalOpts := append(append([]func(*awsendpoints.Options){}, opts...), func(o *awsendpoints.Options) {
+		o.ResolveUnknownService = true
+		o.S3UsEast1RegionalEndpoint = awsendpoints.RegionalS3UsEast1Endpoint
+	})
+	resolved, err := c.model.EndpointFor(service, region, regionalOpts...)
+	if err != nil {
+		return awsendpoints.ResolvedEndpoint{}, err
+	}
+	return buildDocumentedFIPSEndpoint(resolved)
+}
+
+// buildDocumentedFIPSEndpoint builds the FIPS endpoint documented by AWS from the non-FIPS endpoint resolved,
+// by suffixing the first label of its hostname with "-fips", e.g. "wafv2.us-west-2.amazonaws.com" to "wafv2-fips.us-west-2.amazonaws.com".
+// the standard endpoints of AWS GovCloud (US) are FIPS validated thus kept as is.
+func buildDocumentedFIPSEndpoint(resolved awsendpoints.ResolvedEndpoint) (awsendpoints.ResolvedEndpoint, error) {
+	if resolved.PartitionID == usGovPartitionID {
+		return resolved, nil
+	}
+	endpointURL, err := url.Parse(resolved.URL)
+	if err != nil {
+		return awsendpoints.ResolvedEndpoint{}, errors.Wrapf(err, "failed to parse endpoint %v", resolved.URL)
+	}
+	hostLabels := strings.SplitN(endpointURL.Host, ".", 2)
+	if len(hostLabels) != 2 {
+		return awsendpoints.ResolvedEndpoint{}, errors.Errorf("failed to build FIPS endpoint from %v, specify it via --aws-api-endpoints instead", resolved.URL)
+	}
+	if !strings.HasSuffix(hostLabels[0], "-fips") {
+		endpointURL.Host = hostLabels[0] + "-fips." + hostLabels[1]
+	}
+	resolved.URL = endpointURL.String()
+	return resolved, nil
 }
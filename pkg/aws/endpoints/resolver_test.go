@@ -14,6 +14,7 @@ func TestAWSEndpointResolver_EndpointFor(t *testing.T) {
 	}
 	c := &resolver{
 		configuration: configuration,
+		model:         awsendpoints.DefaultResolver(),
 	}
 
 	testRegion := "region"
@@ -64,3 +65,84 @@ func TestAWSEndpointResolver_EndpointFor(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSEndpointResolver_EndpointFor_FIPS(t *testing.T) {
+	configuration := map[string]string{
+		awsendpoints.Ec2ServiceID: "https://ec2.domain.com",
+	}
+	c := NewResolver(configuration, awsendpoints.DefaultResolver(), true)
+
+	tests := []struct {
+		name    string
+		service string
+		region  string
+		wantURL string
+		wantErr error
+	}{
+		{
+			name:    "custom endpoint takes precedence",
+			service: awsendpoints.Ec2ServiceID,
+			region:  "us-west-2",
+			wantURL: "https://ec2.domain.com",
+		},
+		{
+			name:    "FIPS endpoint modeled as fips-${region}",
+			service: awsendpoints.ElasticloadbalancingServiceID,
+			region:  "us-west-2",
+			wantURL: "https://elasticloadbalancing-fips.us-west-2.amazonaws.com",
+		},
+		{
+			name:    "FIPS endpoint modeled as ${region}-fips",
+			service: awsendpoints.AcmServiceID,
+			region:  "us-west-2",
+			wantURL: "https://acm-fips.us-west-2.amazonaws.com",
+		},
+		{
+			name:    "EC2 metadata isn't affected",
+			service: "ec2metadata",
+			region:  "us-west-2",
+			wantURL: "http://169.254.169.254/latest",
+		},
+		{
+			name:    "FIPS endpoint missing from metadata falls back to documented hostname",
+			service: "wafv2",
+			region:  "us-west-2",
+			wantURL: "https://wafv2-fips.us-west-2.amazonaws.com",
+		},
+		{
+			name:    "FIPS endpoint of global service missing from metadata falls back to documented hostname",
+			service: awsendpoints.ShieldServiceID,
+			region:  "us-west-2",
+			wantURL: "https://shield-fips.us-east-1.amazonaws.com",
+		},
+		{
+			name:    "FIPS endpoint of s3 in us-east-1 falls back to documented regional hostname",
+			service: awsendpoints.S3ServiceID,
+			region:  "us-east-1",
+			wantURL: "https://s3-fips.us-east-1.amazonaws.com",
+		},
+		{
+			name:    "FIPS endpoint of route53 falls back to documented hostname",
+			service: awsendpoints.Route53ServiceID,
+			region:  "us-west-2",
+			wantURL: "https://route53-fips.amazonaws.com",
+		},
+		{
+			name:    "standard endpoint in GovCloud is FIPS validated",
+			service: awsendpoints.AcmServiceID,
+			region:  "us-gov-west-1",
+			wantURL: "https://acm.us-gov-west-1.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.EndpointFor(tt.service, tt.region)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantURL, res.URL)
+			}
+		})
+	}
+}
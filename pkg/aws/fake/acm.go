@@ -0,0 +1,87 @@
+package fake
+
+import (
+	"context"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	acmsdk "github.com/aws/aws-sdk-go/service/acm"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	serviceACM = "ACM"
+)
+
+// NewACM constructs an in-memory fake of ACM.
+func NewACM() *ACM {
+	return newACM(newAPICallRecorder())
+}
+
+func newACM(recorder *apiCallRecorder) *ACM {
+	return &ACM{
+		recorder:     recorder,
+		certificates: make(map[string]*acmsdk.CertificateDetail),
+	}
+}
+
+var _ services.ACM = &ACM{}
+
+// ACM is an in-memory fake of ACM with seeded certificates.
+// Calling an API that isn't supported panics.
+type ACM struct {
+	// embedded as nil so that APIs that aren't faked are caught.
+	services.ACM
+
+	recorder *apiCallRecorder
+
+	mutex        sync.Mutex
+	certificates map[string]*acmsdk.CertificateDetail
+}
+
+// AddCertificate seeds an issued certificate for domain names.
+func (c *ACM) AddCertificate(certARN string, domainName string, subjectAlternativeNames ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.certificates[certARN] = &acmsdk.CertificateDetail{
+		CertificateArn:          awssdk.String(certARN),
+		DomainName:              awssdk.String(domainName),
+		Status:                  awssdk.String(acmsdk.CertificateStatusIssued),
+		SubjectAlternativeNames: awssdk.StringSlice(append([]string{domainName}, subjectAlternativeNames...)),
+		Type:                    awssdk.String(acmsdk.CertificateTypeAmazonIssued),
+	}
+}
+
+func (c *ACM) ListCertificatesAsList(_ context.Context, input *acmsdk.ListCertificatesInput) ([]*acmsdk.CertificateSummary, error) {
+	c.recorder.record(serviceACM, "ListCertificates")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	statuses := sets.NewString(awssdk.StringValueSlice(input.CertificateStatuses)...)
+	var certSummaries []*acmsdk.CertificateSummary
+	for _, certARN := range sets.StringKeySet(c.certificates).List() {
+		cert := c.certificates[certARN]
+		if statuses.Len() != 0 && !statuses.Has(awssdk.StringValue(cert.Status)) {
+			continue
+		}
+		certSummaries = append(certSummaries, &acmsdk.CertificateSummary{
+			CertificateArn: awssdk.String(certARN),
+			DomainName:     awssdk.String(awssdk.StringValue(cert.DomainName)),
+		})
+	}
+	return certSummaries, nil
+}
+
+func (c *ACM) DescribeCertificateWithContext(_ context.Context, input *acmsdk.DescribeCertificateInput, _ ...request.Option) (*acmsdk.DescribeCertificateOutput, error) {
+	c.recorder.record(serviceACM, "DescribeCertificate")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	certARN := awssdk.StringValue(input.CertificateArn)
+	cert, exists := c.certificates[certARN]
+	if !exists {
+		return nil, newAWSError(acmsdk.ErrCodeResourceNotFoundException, "certificate %v not found", certARN)
+	}
+	return &acmsdk.DescribeCertificateOutput{Certificate: awsutil.CopyOf(cert).(*acmsdk.CertificateDetail)}, nil
+}
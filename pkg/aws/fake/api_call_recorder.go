@@ -0,0 +1,64 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// apiCallRecorder records the number of calls made to each AWS API.
+type apiCallRecorder struct {
+	mutex  sync.Mutex
+	counts map[string]int
+}
+
+func newAPICallRecorder() *apiCallRecorder {
+	return &apiCallRecorder{
+		counts: make(map[string]int),
+	}
+}
+
+// record a call to AWS API of service, e.g. record("ELBV2", "CreateLoadBalancer").
+func (r *apiCallRecorder) record(service string, api string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.counts[service+"."+api]++
+}
+
+// snapshot returns the number of calls made to each AWS API since the last reset.
+func (r *apiCallRecorder) snapshot() map[string]int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	counts := make(map[string]int, len(r.counts))
+	for api, count := range r.counts {
+		counts[api] = count
+	}
+	return counts
+}
+
+func (r *apiCallRecorder) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.counts = make(map[string]int)
+}
+
+// IsMutatingAPI checks whether an AWS API recorded as <service>.<api> changes the state of AWS resources.
+func IsMutatingAPI(api string) bool {
+	apiName := api
+	if idx := strings.LastIndex(api, "."); idx >= 0 {
+		apiName = api[idx+1:]
+	}
+	for _, readOnlyPrefix := range []string{"Describe", "List", "Get"} {
+		if strings.HasPrefix(apiName, readOnlyPrefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// newAWSError constructs an AWS API error with error code and formatted message.
+func newAWSError(code string, format string, args ...interface{}) error {
+	return awserr.New(code, fmt.Sprintf(format, args...), nil)
+}
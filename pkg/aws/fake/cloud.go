@@ -0,0 +1,127 @@
+// Package fake provides in-memory fakes of the AWS APIs used to deploy load balancers,
+// so that reconcile logic can be exercised end to end without calling AWS.
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	defaultAccountID = "123456789012"
+)
+
+// NewCloud constructs a Cloud backed by in-memory fakes of EC2, ELBV2 and ACM, whose API calls are recorded together.
+func NewCloud(region string, vpcID string, vpcCIDR string) *Cloud {
+	recorder := newAPICallRecorder()
+	ec2 := newEC2(region, defaultAccountID, recorder)
+	ec2.AddVPC(vpcID, vpcCIDR)
+	elbv2 := newELBV2(ec2, region, defaultAccountID, recorder)
+	ec2.isSecurityGroupInUse = elbv2.isSecurityGroupInUse
+	return &Cloud{
+		ec2:      ec2,
+		elbv2:    elbv2,
+		acm:      newACM(recorder),
+		region:   region,
+		vpcID:    vpcID,
+		recorder: recorder,
+	}
+}
+
+var _ aws.Cloud = &Cloud{}
+
+// Cloud is a fake aws.Cloud, the services other than EC2, ELBV2 and ACM aren't available.
+type Cloud struct {
+	ec2      *EC2
+	elbv2    *ELBV2
+	acm      *ACM
+	region   string
+	vpcID    string
+	recorder *apiCallRecorder
+}
+
+// FakeEC2 returns the EC2 fake to seed subnets and inspect security groups.
+func (c *Cloud) FakeEC2() *EC2 {
+	return c.ec2
+}
+
+// FakeELBV2 returns the ELBV2 fake to inspect load balancer resources.
+func (c *Cloud) FakeELBV2() *ELBV2 {
+	return c.elbv2
+}
+
+// FakeACM returns the ACM fake to seed certificates.
+func (c *Cloud) FakeACM() *ACM {
+	return c.acm
+}
+
+// APICallCounts returns the number of calls made to each AWS API since the last reset, keyed by <service>.<api>.
+func (c *Cloud) APICallCounts() map[string]int {
+	return c.recorder.snapshot()
+}
+
+// ResetAPICallCounts resets the number of calls made to each AWS API.
+func (c *Cloud) ResetAPICallCounts() {
+	c.recorder.reset()
+}
+
+func (c *Cloud) EC2() services.EC2 {
+	return c.ec2
+}
+
+func (c *Cloud) ELBV2() services.ELBV2 {
+	return c.elbv2
+}
+
+func (c *Cloud) ACM() services.ACM {
+	return c.acm
+}
+
+func (c *Cloud) WAFv2() services.WAFv2 {
+	return nil
+}
+
+func (c *Cloud) WAFRegional() services.WAFRegional {
+	return nil
+}
+
+func (c *Cloud) Shield() services.Shield {
+	return nil
+}
+
+func (c *Cloud) RGT() services.RGT {
+	return nil
+}
+
+func (c *Cloud) Lambda() services.Lambda {
+	return nil
+}
+
+func (c *Cloud) CloudWatch() services.CloudWatch {
+	return nil
+}
+
+func (c *Cloud) S3() services.S3 {
+	return nil
+}
+
+func (c *Cloud) Route53() services.Route53 {
+	return nil
+}
+
+func (c *Cloud) Region() string {
+	return c.region
+}
+
+func (c *Cloud) Partition() string {
+	return "aws"
+}
+
+func (c *Cloud) VpcID() string {
+	return c.vpcID
+}
+
+func (c *Cloud) Credentials() *credentials.Credentials {
+	return nil
+}
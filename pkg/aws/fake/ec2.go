@@ -0,0 +1,497 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	serviceEC2 = "EC2"
+
+	zoneTypeAvailabilityZone = "availability-zone"
+)
+
+// NewEC2 constructs an in-memory fake of EC2.
+func NewEC2(region string, accountID string) *EC2 {
+	return newEC2(region, accountID, newAPICallRecorder())
+}
+
+func newEC2(region string, accountID string, recorder *apiCallRecorder) *EC2 {
+	return &EC2{
+		region:            region,
+		accountID:         accountID,
+		recorder:          recorder,
+		vpcs:              make(map[string]*ec2sdk.Vpc),
+		subnets:           make(map[string]*ec2sdk.Subnet),
+		availabilityZones: make(map[string]*ec2sdk.AvailabilityZone),
+		securityGroups:    make(map[string]*ec2sdk.SecurityGroup),
+	}
+}
+
+var _ services.EC2 = &EC2{}
+
+// EC2 is an in-memory fake of EC2 with stateful security groups and seeded VPCs, subnets and availability zones.
+// Calling an API that isn't supported panics.
+type EC2 struct {
+	// embedded as nil so that APIs that aren't faked are caught.
+	services.EC2
+
+	region    string
+	accountID string
+	recorder  *apiCallRecorder
+	// isSecurityGroupInUse checks whether security group is referenced by other AWS resources.
+	isSecurityGroupInUse func(sgID string) bool
+
+	mutex     sync.Mutex
+	idCounter int64
+	vpcs      map[string]*ec2sdk.Vpc
+	subnets   map[string]*ec2sdk.Subnet
+	// availability zones keyed by zone ID.
+	availabilityZones map[string]*ec2sdk.AvailabilityZone
+	securityGroups    map[string]*ec2sdk.SecurityGroup
+}
+
+// AddVPC seeds a VPC with CIDR block.
+func (c *EC2) AddVPC(vpcID string, cidrBlock string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.vpcs[vpcID] = &ec2sdk.Vpc{
+		CidrBlock: awssdk.String(cidrBlock),
+		CidrBlockAssociationSet: []*ec2sdk.VpcCidrBlockAssociation{
+			{
+				CidrBlock:      awssdk.String(cidrBlock),
+				CidrBlockState: &ec2sdk.VpcCidrBlockState{State: awssdk.String(ec2sdk.VpcCidrBlockStateCodeAssociated)},
+			},
+		},
+		OwnerId: awssdk.String(c.accountID),
+		State:   awssdk.String(ec2sdk.VpcStateAvailable),
+		VpcId:   awssdk.String(vpcID),
+	}
+}
+
+// AddSubnet seeds a subnet, along with its availability zone if it's not seeded yet.
+func (c *EC2) AddSubnet(subnet *ec2sdk.Subnet) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	subnet = awsutil.CopyOf(subnet).(*ec2sdk.Subnet)
+	if subnet.State == nil {
+		subnet.State = awssdk.String(ec2sdk.SubnetStateAvailable)
+	}
+	if subnet.OwnerId == nil {
+		subnet.OwnerId = awssdk.String(c.accountID)
+	}
+	c.subnets[awssdk.StringValue(subnet.SubnetId)] = subnet
+	zoneID := awssdk.StringValue(subnet.AvailabilityZoneId)
+	if _, exists := c.availabilityZones[zoneID]; !exists {
+		c.availabilityZones[zoneID] = &ec2sdk.AvailabilityZone{
+			RegionName: awssdk.String(c.region),
+			State:      awssdk.String(ec2sdk.AvailabilityZoneStateAvailable),
+			ZoneId:     awssdk.String(zoneID),
+			ZoneName:   subnet.AvailabilityZone,
+			ZoneType:   awssdk.String(zoneTypeAvailabilityZone),
+		}
+	}
+}
+
+// AddAvailabilityZone seeds an availability zone, e.g. a local zone.
+func (c *EC2) AddAvailabilityZone(availabilityZone *ec2sdk.AvailabilityZone) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.availabilityZones[awssdk.StringValue(availabilityZone.ZoneId)] = awsutil.CopyOf(availabilityZone).(*ec2sdk.AvailabilityZone)
+}
+
+func (c *EC2) DescribeVpcsWithContext(_ context.Context, input *ec2sdk.DescribeVpcsInput, _ ...request.Option) (*ec2sdk.DescribeVpcsOutput, error) {
+	c.recorder.record(serviceEC2, "DescribeVpcs")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var vpcs []*ec2sdk.Vpc
+	for _, vpcID := range awssdk.StringValueSlice(input.VpcIds) {
+		vpc, exists := c.vpcs[vpcID]
+		if !exists {
+			return nil, newAWSError("InvalidVpcID.NotFound", "the vpc ID '%v' does not exist", vpcID)
+		}
+		vpcs = append(vpcs, awsutil.CopyOf(vpc).(*ec2sdk.Vpc))
+	}
+	return &ec2sdk.DescribeVpcsOutput{Vpcs: vpcs}, nil
+}
+
+func (c *EC2) DescribeSubnetsAsList(_ context.Context, input *ec2sdk.DescribeSubnetsInput) ([]*ec2sdk.Subnet, error) {
+	c.recorder.record(serviceEC2, "DescribeSubnets")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, subnetID := range awssdk.StringValueSlice(input.SubnetIds) {
+		if _, exists := c.subnets[subnetID]; !exists {
+			return nil, newAWSError("InvalidSubnetID.NotFound", "the subnet ID '%v' does not exist", subnetID)
+		}
+	}
+	subnetIDs := sets.NewString(awssdk.StringValueSlice(input.SubnetIds)...)
+	var subnets []*ec2sdk.Subnet
+	for _, subnetID := range sets.StringKeySet(c.subnets).List() {
+		subnet := c.subnets[subnetID]
+		if subnetIDs.Len() != 0 && !subnetIDs.Has(subnetID) {
+			continue
+		}
+		if !matchesFilters(input.Filters, subnet.Tags, func(name string) []string {
+			switch name {
+			case "vpc-id":
+				return []string{awssdk.StringValue(subnet.VpcId)}
+			case "subnet-id":
+				return []string{subnetID}
+			case "availability-zone":
+				return []string{awssdk.StringValue(subnet.AvailabilityZone)}
+			case "availability-zone-id":
+				return []string{awssdk.StringValue(subnet.AvailabilityZoneId)}
+			}
+			return nil
+		}) {
+			continue
+		}
+		subnets = append(subnets, awsutil.CopyOf(subnet).(*ec2sdk.Subnet))
+	}
+	return subnets, nil
+}
+
+func (c *EC2) DescribeAvailabilityZonesWithContext(_ context.Context, input *ec2sdk.DescribeAvailabilityZonesInput, _ ...request.Option) (*ec2sdk.DescribeAvailabilityZonesOutput, error) {
+	c.recorder.record(serviceEC2, "DescribeAvailabilityZones")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	zoneIDs := sets.NewString(awssdk.StringValueSlice(input.ZoneIds)...)
+	zoneNames := sets.NewString(awssdk.StringValueSlice(input.ZoneNames)...)
+	var availabilityZones []*ec2sdk.AvailabilityZone
+	for _, zoneID := range sets.StringKeySet(c.availabilityZones).List() {
+		availabilityZone := c.availabilityZones[zoneID]
+		if zoneIDs.Len() != 0 && !zoneIDs.Has(zoneID) {
+			continue
+		}
+		if zoneNames.Len() != 0 && !zoneNames.Has(awssdk.StringValue(availabilityZone.ZoneName)) {
+			continue
+		}
+		availabilityZones = append(availabilityZones, awsutil.CopyOf(availabilityZone).(*ec2sdk.AvailabilityZone))
+	}
+	if len(availabilityZones) < zoneIDs.Len()+zoneNames.Len() {
+		return nil, newAWSError("InvalidParameterValue", "invalid availability zones: %v", append(zoneIDs.List(), zoneNames.List()...))
+	}
+	return &ec2sdk.DescribeAvailabilityZonesOutput{AvailabilityZones: availabilityZones}, nil
+}
+
+func (c *EC2) CreateSecurityGroupWithContext(_ context.Context, input *ec2sdk.CreateSecurityGroupInput, _ ...request.Option) (*ec2sdk.CreateSecurityGroupOutput, error) {
+	c.recorder.record(serviceEC2, "CreateSecurityGroup")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	vpcID := awssdk.StringValue(input.VpcId)
+	sgName := awssdk.StringValue(input.GroupName)
+	if _, exists := c.vpcs[vpcID]; !exists {
+		return nil, newAWSError("InvalidVpcID.NotFound", "the vpc ID '%v' does not exist", vpcID)
+	}
+	for _, sg := range c.securityGroups {
+		if awssdk.StringValue(sg.VpcId) == vpcID && awssdk.StringValue(sg.GroupName) == sgName {
+			return nil, newAWSError("InvalidGroup.Duplicate", "the security group '%v' already exists for VPC '%v'", sgName, vpcID)
+		}
+	}
+
+	c.idCounter++
+	sgID := fmt.Sprintf("sg-%017x", c.idCounter)
+	sg := &ec2sdk.SecurityGroup{
+		Description: awssdk.String(awssdk.StringValue(input.Description)),
+		GroupId:     awssdk.String(sgID),
+		GroupName:   awssdk.String(sgName),
+		IpPermissionsEgress: []*ec2sdk.IpPermission{
+			{
+				IpProtocol: awssdk.String("-1"),
+				IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("0.0.0.0/0")}},
+			},
+		},
+		OwnerId: awssdk.String(c.accountID),
+		VpcId:   awssdk.String(vpcID),
+	}
+	var tags []*ec2sdk.Tag
+	for _, tagSpec := range input.TagSpecifications {
+		if awssdk.StringValue(tagSpec.ResourceType) == ec2sdk.ResourceTypeSecurityGroup {
+			tags = append(tags, tagSpec.Tags...)
+		}
+	}
+	sg.Tags = mergeEC2Tags(nil, tags)
+	c.securityGroups[sgID] = sg
+	return &ec2sdk.CreateSecurityGroupOutput{GroupId: awssdk.String(sgID)}, nil
+}
+
+func (c *EC2) DeleteSecurityGroupWithContext(_ context.Context, input *ec2sdk.DeleteSecurityGroupInput, _ ...request.Option) (*ec2sdk.DeleteSecurityGroupOutput, error) {
+	c.recorder.record(serviceEC2, "DeleteSecurityGroup")
+	sgID := awssdk.StringValue(input.GroupId)
+	// the usage of security group is checked without holding the lock, as ELBV2 fake resolves subnets from EC2 fake.
+	if c.isSecurityGroupInUse != nil && c.isSecurityGroupInUse(sgID) {
+		return nil, newAWSError("DependencyViolation", "resource %v has a dependent object", sgID)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, exists := c.securityGroups[sgID]; !exists {
+		return nil, newAWSError("InvalidGroup.NotFound", "the security group '%v' does not exist", sgID)
+	}
+	for otherSGID, sg := range c.securityGroups {
+		if otherSGID == sgID {
+			continue
+		}
+		for _, permission := range sg.IpPermissions {
+			for _, groupPair := range permission.UserIdGroupPairs {
+				if awssdk.StringValue(groupPair.GroupId) == sgID {
+					return nil, newAWSError("DependencyViolation", "resource %v has a dependent object", sgID)
+				}
+			}
+		}
+	}
+	delete(c.securityGroups, sgID)
+	return &ec2sdk.DeleteSecurityGroupOutput{}, nil
+}
+
+func (c *EC2) DescribeSecurityGroupsAsList(_ context.Context, input *ec2sdk.DescribeSecurityGroupsInput) ([]*ec2sdk.SecurityGroup, error) {
+	c.recorder.record(serviceEC2, "DescribeSecurityGroups")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, sgID := range awssdk.StringValueSlice(input.GroupIds) {
+		if _, exists := c.securityGroups[sgID]; !exists {
+			return nil, newAWSError("InvalidGroup.NotFound", "the security group '%v' does not exist", sgID)
+		}
+	}
+	sgIDs := sets.NewString(awssdk.StringValueSlice(input.GroupIds)...)
+	var sgs []*ec2sdk.SecurityGroup
+	for _, sgID := range sets.StringKeySet(c.securityGroups).List() {
+		sg := c.securityGroups[sgID]
+		if sgIDs.Len() != 0 && !sgIDs.Has(sgID) {
+			continue
+		}
+		if !matchesFilters(input.Filters, sg.Tags, func(name string) []string {
+			switch name {
+			case "vpc-id":
+				return []string{awssdk.StringValue(sg.VpcId)}
+			case "group-id":
+				return []string{sgID}
+			case "group-name":
+				return []string{awssdk.StringValue(sg.GroupName)}
+			}
+			return nil
+		}) {
+			continue
+		}
+		sgs = append(sgs, awsutil.CopyOf(sg).(*ec2sdk.SecurityGroup))
+	}
+	return sgs, nil
+}
+
+func (c *EC2) AuthorizeSecurityGroupIngressWithContext(_ context.Context, input *ec2sdk.AuthorizeSecurityGroupIngressInput, _ ...request.Option) (*ec2sdk.AuthorizeSecurityGroupIngressOutput, error) {
+	c.recorder.record(serviceEC2, "AuthorizeSecurityGroupIngress")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	sg, err := c.getSecurityGroup(awssdk.StringValue(input.GroupId))
+	if err != nil {
+		return nil, err
+	}
+	permissions := expandIPPermissions(input.IpPermissions)
+	for _, permission := range permissions {
+		if findIPPermission(sg.IpPermissions, permission) >= 0 {
+			return nil, newAWSError("InvalidPermission.Duplicate", "the specified rule %v already exists", permission.String())
+		}
+	}
+	sg.IpPermissions = append(sg.IpPermissions, permissions...)
+	return &ec2sdk.AuthorizeSecurityGroupIngressOutput{Return: awssdk.Bool(true)}, nil
+}
+
+func (c *EC2) RevokeSecurityGroupIngressWithContext(_ context.Context, input *ec2sdk.RevokeSecurityGroupIngressInput, _ ...request.Option) (*ec2sdk.RevokeSecurityGroupIngressOutput, error) {
+	c.recorder.record(serviceEC2, "RevokeSecurityGroupIngress")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	sg, err := c.getSecurityGroup(awssdk.StringValue(input.GroupId))
+	if err != nil {
+		return nil, err
+	}
+	permissions := expandIPPermissions(input.IpPermissions)
+	for _, permission := range permissions {
+		if findIPPermission(sg.IpPermissions, permission) < 0 {
+			return nil, newAWSError("InvalidPermission.NotFound", "the specified rule %v does not exist", permission.String())
+		}
+	}
+	for _, permission := range permissions {
+		idx := findIPPermission(sg.IpPermissions, permission)
+		sg.IpPermissions = append(sg.IpPermissions[:idx], sg.IpPermissions[idx+1:]...)
+	}
+	return &ec2sdk.RevokeSecurityGroupIngressOutput{Return: awssdk.Bool(true)}, nil
+}
+
+func (c *EC2) CreateTagsWithContext(_ context.Context, input *ec2sdk.CreateTagsInput, _ ...request.Option) (*ec2sdk.CreateTagsOutput, error) {
+	c.recorder.record(serviceEC2, "CreateTags")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resID := range awssdk.StringValueSlice(input.Resources) {
+		if sg, exists := c.securityGroups[resID]; exists {
+			sg.Tags = mergeEC2Tags(sg.Tags, input.Tags)
+		} else if subnet, exists := c.subnets[resID]; exists {
+			subnet.Tags = mergeEC2Tags(subnet.Tags, input.Tags)
+		} else {
+			return nil, newAWSError("InvalidID", "the ID '%v' is not valid", resID)
+		}
+	}
+	return &ec2sdk.CreateTagsOutput{}, nil
+}
+
+func (c *EC2) DeleteTagsWithContext(_ context.Context, input *ec2sdk.DeleteTagsInput, _ ...request.Option) (*ec2sdk.DeleteTagsOutput, error) {
+	c.recorder.record(serviceEC2, "DeleteTags")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resID := range awssdk.StringValueSlice(input.Resources) {
+		if sg, exists := c.securityGroups[resID]; exists {
+			sg.Tags = removeEC2Tags(sg.Tags, input.Tags)
+		} else if subnet, exists := c.subnets[resID]; exists {
+			subnet.Tags = removeEC2Tags(subnet.Tags, input.Tags)
+		} else {
+			return nil, newAWSError("InvalidID", "the ID '%v' is not valid", resID)
+		}
+	}
+	return &ec2sdk.DeleteTagsOutput{}, nil
+}
+
+// lookupSubnet returns the subnet with subnetID if it exists.
+func (c *EC2) lookupSubnet(subnetID string) (*ec2sdk.Subnet, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	subnet, exists := c.subnets[subnetID]
+	if !exists {
+		return nil, false
+	}
+	return awsutil.CopyOf(subnet).(*ec2sdk.Subnet), true
+}
+
+func (c *EC2) getSecurityGroup(sgID string) (*ec2sdk.SecurityGroup, error) {
+	sg, exists := c.securityGroups[sgID]
+	if !exists {
+		return nil, newAWSError("InvalidGroup.NotFound", "the security group '%v' does not exist", sgID)
+	}
+	return sg, nil
+}
+
+// matchesFilters checks whether a resource matches all filters.
+// tag filters are matched against tags, other filters against the values returned by fieldValues.
+func matchesFilters(filters []*ec2sdk.Filter, tags []*ec2sdk.Tag, fieldValues func(name string) []string) bool {
+	for _, filter := range filters {
+		name := awssdk.StringValue(filter.Name)
+		var values []string
+		switch {
+		case strings.HasPrefix(name, "tag:"):
+			tagKey := strings.TrimPrefix(name, "tag:")
+			for _, tag := range tags {
+				if awssdk.StringValue(tag.Key) == tagKey {
+					values = append(values, awssdk.StringValue(tag.Value))
+				}
+			}
+		case name == "tag-key":
+			for _, tag := range tags {
+				values = append(values, awssdk.StringValue(tag.Key))
+			}
+		default:
+			values = fieldValues(name)
+		}
+		if !sets.NewString(values...).HasAny(awssdk.StringValueSlice(filter.Values)...) {
+			return false
+		}
+	}
+	return true
+}
+
+// expandIPPermissions expands permissions so that each permission contains a single source.
+func expandIPPermissions(permissions []*ec2sdk.IpPermission) []*ec2sdk.IpPermission {
+	var expanded []*ec2sdk.IpPermission
+	for _, permission := range permissions {
+		base := ec2sdk.IpPermission{
+			FromPort:   permission.FromPort,
+			IpProtocol: permission.IpProtocol,
+			ToPort:     permission.ToPort,
+		}
+		for _, ipRange := range permission.IpRanges {
+			expandedPermission := base
+			expandedPermission.IpRanges = []*ec2sdk.IpRange{ipRange}
+			expanded = append(expanded, awsutil.CopyOf(&expandedPermission).(*ec2sdk.IpPermission))
+		}
+		for _, ipv6Range := range permission.Ipv6Ranges {
+			expandedPermission := base
+			expandedPermission.Ipv6Ranges = []*ec2sdk.Ipv6Range{ipv6Range}
+			expanded = append(expanded, awsutil.CopyOf(&expandedPermission).(*ec2sdk.IpPermission))
+		}
+		for _, prefixListID := range permission.PrefixListIds {
+			expandedPermission := base
+			expandedPermission.PrefixListIds = []*ec2sdk.PrefixListId{prefixListID}
+			expanded = append(expanded, awsutil.CopyOf(&expandedPermission).(*ec2sdk.IpPermission))
+		}
+		for _, groupPair := range permission.UserIdGroupPairs {
+			expandedPermission := base
+			expandedPermission.UserIdGroupPairs = []*ec2sdk.UserIdGroupPair{groupPair}
+			expanded = append(expanded, awsutil.CopyOf(&expandedPermission).(*ec2sdk.IpPermission))
+		}
+	}
+	return expanded
+}
+
+// findIPPermission returns the index of permission with the same protocol, ports and source, or -1 if not found.
+func findIPPermission(permissions []*ec2sdk.IpPermission, permission *ec2sdk.IpPermission) int {
+	for idx, candidate := range permissions {
+		if ipPermissionKey(candidate) == ipPermissionKey(permission) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// ipPermissionKey identifies an expanded permission by its protocol, ports and source, ignoring descriptions.
+func ipPermissionKey(permission *ec2sdk.IpPermission) string {
+	var source string
+	switch {
+	case len(permission.IpRanges) != 0:
+		source = awssdk.StringValue(permission.IpRanges[0].CidrIp)
+	case len(permission.Ipv6Ranges) != 0:
+		source = awssdk.StringValue(permission.Ipv6Ranges[0].CidrIpv6)
+	case len(permission.PrefixListIds) != 0:
+		source = awssdk.StringValue(permission.PrefixListIds[0].PrefixListId)
+	case len(permission.UserIdGroupPairs) != 0:
+		source = awssdk.StringValue(permission.UserIdGroupPairs[0].GroupId)
+	}
+	return fmt.Sprintf("%v/%v/%v/%v", awssdk.StringValue(permission.IpProtocol),
+		awssdk.Int64Value(permission.FromPort), awssdk.Int64Value(permission.ToPort), source)
+}
+
+func mergeEC2Tags(tags []*ec2sdk.Tag, newTags []*ec2sdk.Tag) []*ec2sdk.Tag {
+	tagMap := make(map[string]string, len(tags)+len(newTags))
+	for _, tag := range append(tags, newTags...) {
+		tagMap[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return buildEC2Tags(tagMap)
+}
+
+func removeEC2Tags(tags []*ec2sdk.Tag, tagsToRemove []*ec2sdk.Tag) []*ec2sdk.Tag {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	for _, tag := range tagsToRemove {
+		if tag.Value == nil || awssdk.StringValue(tag.Value) == tagMap[awssdk.StringValue(tag.Key)] {
+			delete(tagMap, awssdk.StringValue(tag.Key))
+		}
+	}
+	return buildEC2Tags(tagMap)
+}
+
+func buildEC2Tags(tagMap map[string]string) []*ec2sdk.Tag {
+	var tags []*ec2sdk.Tag
+	for _, key := range sets.StringKeySet(tagMap).List() {
+		tags = append(tags, &ec2sdk.Tag{
+			Key:   awssdk.String(key),
+			Value: awssdk.String(tagMap[key]),
+		})
+	}
+	return tags
+}
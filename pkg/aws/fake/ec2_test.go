@@ -0,0 +1,123 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEC2_DescribeSubnetsAsList(t *testing.T) {
+	ec2 := NewEC2("us-west-2", defaultAccountID)
+	ec2.AddSubnet(&ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-a"),
+		VpcId:    awssdk.String("vpc-1"),
+		Tags:     []*ec2sdk.Tag{{Key: awssdk.String("kubernetes.io/role/elb"), Value: awssdk.String("1")}},
+	})
+	ec2.AddSubnet(&ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-b"),
+		VpcId:    awssdk.String("vpc-1"),
+	})
+	ec2.AddSubnet(&ec2sdk.Subnet{
+		SubnetId: awssdk.String("subnet-c"),
+		VpcId:    awssdk.String("vpc-2"),
+		Tags:     []*ec2sdk.Tag{{Key: awssdk.String("kubernetes.io/role/elb"), Value: awssdk.String("1")}},
+	})
+
+	tests := []struct {
+		name          string
+		req           *ec2sdk.DescribeSubnetsInput
+		wantSubnetIDs []string
+		wantErr       string
+	}{
+		{
+			name: "by tag and vpc-id filters",
+			req: &ec2sdk.DescribeSubnetsInput{
+				Filters: []*ec2sdk.Filter{
+					{Name: awssdk.String("tag:kubernetes.io/role/elb"), Values: awssdk.StringSlice([]string{"", "1"})},
+					{Name: awssdk.String("vpc-id"), Values: awssdk.StringSlice([]string{"vpc-1"})},
+				},
+			},
+			wantSubnetIDs: []string{"subnet-a"},
+		},
+		{
+			name:          "by subnet IDs",
+			req:           &ec2sdk.DescribeSubnetsInput{SubnetIds: awssdk.StringSlice([]string{"subnet-b", "subnet-c"})},
+			wantSubnetIDs: []string{"subnet-b", "subnet-c"},
+		},
+		{
+			name:    "by unknown subnet IDs",
+			req:     &ec2sdk.DescribeSubnetsInput{SubnetIds: awssdk.StringSlice([]string{"subnet-d"})},
+			wantErr: "InvalidSubnetID.NotFound: the subnet ID 'subnet-d' does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subnets, err := ec2.DescribeSubnetsAsList(context.Background(), tt.req)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var subnetIDs []string
+			for _, subnet := range subnets {
+				subnetIDs = append(subnetIDs, awssdk.StringValue(subnet.SubnetId))
+			}
+			assert.Equal(t, tt.wantSubnetIDs, subnetIDs)
+		})
+	}
+}
+
+func TestEC2_SecurityGroupIngress(t *testing.T) {
+	ctx := context.Background()
+	cloud, lbARN, _, _ := newTestCloudWithLoadBalancer(t)
+	ec2 := cloud.EC2()
+	createResp, err := ec2.CreateSecurityGroupWithContext(ctx, &ec2sdk.CreateSecurityGroupInput{
+		GroupName:   awssdk.String("my-sg"),
+		Description: awssdk.String("my-sg"),
+		VpcId:       awssdk.String("vpc-1"),
+	})
+	require.NoError(t, err)
+	sgID := createResp.GroupId
+	_, err = ec2.CreateSecurityGroupWithContext(ctx, &ec2sdk.CreateSecurityGroupInput{
+		GroupName: awssdk.String("my-sg"),
+		VpcId:     awssdk.String("vpc-1"),
+	})
+	assertAWSErrorCode(t, "InvalidGroup.Duplicate", err)
+
+	permissions := []*ec2sdk.IpPermission{
+		{
+			IpProtocol: awssdk.String("tcp"),
+			FromPort:   awssdk.Int64(80),
+			ToPort:     awssdk.Int64(80),
+			IpRanges:   []*ec2sdk.IpRange{{CidrIp: awssdk.String("0.0.0.0/0")}, {CidrIp: awssdk.String("10.0.0.0/8")}},
+		},
+	}
+	_, err = ec2.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2sdk.AuthorizeSecurityGroupIngressInput{GroupId: sgID, IpPermissions: permissions})
+	require.NoError(t, err)
+	_, err = ec2.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2sdk.AuthorizeSecurityGroupIngressInput{GroupId: sgID, IpPermissions: permissions})
+	assertAWSErrorCode(t, "InvalidPermission.Duplicate", err)
+
+	permissions[0].IpRanges = permissions[0].IpRanges[:1]
+	_, err = ec2.RevokeSecurityGroupIngressWithContext(ctx, &ec2sdk.RevokeSecurityGroupIngressInput{GroupId: sgID, IpPermissions: permissions})
+	require.NoError(t, err)
+	_, err = ec2.RevokeSecurityGroupIngressWithContext(ctx, &ec2sdk.RevokeSecurityGroupIngressInput{GroupId: sgID, IpPermissions: permissions})
+	assertAWSErrorCode(t, "InvalidPermission.NotFound", err)
+
+	sgs, err := ec2.DescribeSecurityGroupsAsList(ctx, &ec2sdk.DescribeSecurityGroupsInput{GroupIds: []*string{sgID}})
+	require.NoError(t, err)
+	require.Len(t, sgs[0].IpPermissions, 1)
+	assert.Equal(t, "10.0.0.0/8", awssdk.StringValue(sgs[0].IpPermissions[0].IpRanges[0].CidrIp))
+
+	_, err = cloud.ELBV2().SetSecurityGroupsWithContext(ctx, &elbv2sdk.SetSecurityGroupsInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+		SecurityGroups:  []*string{sgID},
+	})
+	require.NoError(t, err)
+	_, err = ec2.DeleteSecurityGroupWithContext(ctx, &ec2sdk.DeleteSecurityGroupInput{GroupId: sgID})
+	assertAWSErrorCode(t, "DependencyViolation", err)
+}
@@ -0,0 +1,1426 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	serviceELBV2 = "ELBV2"
+
+	// maximum number of resources per DescribeTags/AddTags/RemoveTags call.
+	maxTagResourcesPerCall = 20
+	// maximum number of tags per resource.
+	maxTagsPerResource = 50
+	// maximum number of rules per listener, excluding the default rule.
+	maxRulesPerListener = 100
+	// range of priorities for listener rules.
+	minRulePriority = 1
+	maxRulePriority = 50000
+	// range of weights for target groups in forward actions.
+	minForwardTargetGroupWeight = 0
+	maxForwardTargetGroupWeight = 999
+
+	defaultRulePriority       = "default"
+	defaultSSLPolicy          = "ELBSecurityPolicy-2016-08"
+	canonicalHostedZoneIDALB  = "Z35SXDOTRQ7X7K"
+	canonicalHostedZoneIDNLB  = "Z26RNL4JYFTOTI"
+	targetGroupProtocolHTTP1  = "HTTP1"
+	healthCheckPortTraffic    = "traffic-port"
+	attrDeletionProtectionKey = "deletion_protection.enabled"
+)
+
+// defaultLoadBalancerAttributes are the attributes of newly created load balancers, keyed by load balancer type.
+var defaultLoadBalancerAttributes = map[string]map[string]string{
+	elbv2sdk.LoadBalancerTypeEnumApplication: {
+		"access_logs.s3.enabled":                          "false",
+		"access_logs.s3.bucket":                           "",
+		"access_logs.s3.prefix":                           "",
+		"connection_logs.s3.enabled":                      "false",
+		"connection_logs.s3.bucket":                       "",
+		"connection_logs.s3.prefix":                       "",
+		"deletion_protection.enabled":                     "false",
+		"idle_timeout.timeout_seconds":                    "60",
+		"client_keep_alive.seconds":                       "3600",
+		"routing.http2.enabled":                           "true",
+		"routing.http.drop_invalid_header_fields.enabled": "false",
+	},
+	elbv2sdk.LoadBalancerTypeEnumNetwork: {
+		"access_logs.s3.enabled":            "false",
+		"access_logs.s3.bucket":             "",
+		"access_logs.s3.prefix":             "",
+		"deletion_protection.enabled":       "false",
+		"load_balancing.cross_zone.enabled": "false",
+	},
+}
+
+// defaultTargetGroupAttributes are the attributes of newly created target groups.
+var defaultTargetGroupAttributes = map[string]string{
+	"deregistration_delay.timeout_seconds":  "300",
+	"stickiness.enabled":                    "false",
+	"stickiness.type":                       "lb_cookie",
+	"stickiness.lb_cookie.duration_seconds": "86400",
+	"slow_start.duration_seconds":           "0",
+	"load_balancing.algorithm.type":         "round_robin",
+}
+
+// NewELBV2 constructs an in-memory fake of ELBV2 that resolves subnets from the EC2 fake.
+func NewELBV2(ec2 *EC2, region string, accountID string) *ELBV2 {
+	return newELBV2(ec2, region, accountID, newAPICallRecorder())
+}
+
+func newELBV2(ec2 *EC2, region string, accountID string, recorder *apiCallRecorder) *ELBV2 {
+	return &ELBV2{
+		ec2:           ec2,
+		region:        region,
+		accountID:     accountID,
+		recorder:      recorder,
+		loadBalancers: make(map[string]*loadBalancerState),
+		targetGroups:  make(map[string]*targetGroupState),
+		listeners:     make(map[string]*listenerState),
+		rules:         make(map[string]*ruleState),
+		tags:          make(map[string]map[string]string),
+	}
+}
+
+var _ services.ELBV2 = &ELBV2{}
+
+// ELBV2 is an in-memory fake of ELBV2 with stateful Create/Describe/Modify/Delete semantics.
+// Calling an API that isn't supported panics.
+type ELBV2 struct {
+	// embedded as nil so that APIs that aren't faked are caught.
+	services.ELBV2
+
+	ec2       *EC2
+	region    string
+	accountID string
+	recorder  *apiCallRecorder
+
+	mutex         sync.Mutex
+	idCounter     int64
+	loadBalancers map[string]*loadBalancerState
+	targetGroups  map[string]*targetGroupState
+	listeners     map[string]*listenerState
+	rules         map[string]*ruleState
+	tags          map[string]map[string]string
+}
+
+type loadBalancerState struct {
+	loadBalancer *elbv2sdk.LoadBalancer
+	attributes   map[string]string
+}
+
+type targetGroupState struct {
+	targetGroup *elbv2sdk.TargetGroup
+	attributes  map[string]string
+	// targets keyed by id and port.
+	targets map[string]*elbv2sdk.TargetDescription
+}
+
+type listenerState struct {
+	listener *elbv2sdk.Listener
+	// certificates in addition to the default certificate.
+	extraCertARNs  []string
+	defaultRuleARN string
+}
+
+type ruleState struct {
+	rule        *elbv2sdk.Rule
+	listenerARN string
+}
+
+// APICallCounts returns the number of calls made to each AWS API since the last reset.
+func (c *ELBV2) APICallCounts() map[string]int {
+	return c.recorder.snapshot()
+}
+
+// ResetAPICallCounts resets the number of calls made to each AWS API.
+func (c *ELBV2) ResetAPICallCounts() {
+	c.recorder.reset()
+}
+
+func (c *ELBV2) CreateLoadBalancerWithContext(_ context.Context, input *elbv2sdk.CreateLoadBalancerInput, _ ...request.Option) (*elbv2sdk.CreateLoadBalancerOutput, error) {
+	c.recorder.record(serviceELBV2, "CreateLoadBalancer")
+	lbType := stringValueOrDefault(input.Type, elbv2sdk.LoadBalancerTypeEnumApplication)
+	subnetMappings := buildSubnetMappings(input.Subnets, input.SubnetMappings)
+	availabilityZones, vpcID, err := c.resolveAvailabilityZones(lbType, subnetMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbName := awssdk.StringValue(input.Name)
+	if len(lbName) == 0 || len(lbName) > 32 {
+		return nil, newAWSError("ValidationError", "load balancer name must be 1 to 32 characters, got %q", lbName)
+	}
+	scheme := stringValueOrDefault(input.Scheme, elbv2sdk.LoadBalancerSchemeEnumInternetFacing)
+	for _, lbState := range c.loadBalancers {
+		lb := lbState.loadBalancer
+		if awssdk.StringValue(lb.LoadBalancerName) != lbName {
+			continue
+		}
+		if awssdk.StringValue(lb.Type) == lbType && awssdk.StringValue(lb.Scheme) == scheme {
+			return &elbv2sdk.CreateLoadBalancerOutput{LoadBalancers: []*elbv2sdk.LoadBalancer{copyLoadBalancer(lb)}}, nil
+		}
+		return nil, newAWSError(elbv2sdk.ErrCodeDuplicateLoadBalancerNameException, "a load balancer with name %v already exists", lbName)
+	}
+	if err := validateTags(input.Tags); err != nil {
+		return nil, err
+	}
+
+	lbID := c.nextID()
+	lbARN := fmt.Sprintf("arn:aws:elasticloadbalancing:%v:%v:loadbalancer/%v/%v/%v", c.region, c.accountID, lbTypeShortName(lbType), lbName, lbID)
+	canonicalHostedZoneID := canonicalHostedZoneIDALB
+	dnsName := fmt.Sprintf("%v-%v.%v.elb.amazonaws.com", lbName, lbID[len(lbID)-8:], c.region)
+	if lbType == elbv2sdk.LoadBalancerTypeEnumNetwork {
+		canonicalHostedZoneID = canonicalHostedZoneIDNLB
+		dnsName = fmt.Sprintf("%v-%v.elb.%v.amazonaws.com", lbName, lbID[len(lbID)-8:], c.region)
+	}
+	if scheme == elbv2sdk.LoadBalancerSchemeEnumInternal {
+		dnsName = "internal-" + dnsName
+	}
+	lb := &elbv2sdk.LoadBalancer{
+		AvailabilityZones:     availabilityZones,
+		CanonicalHostedZoneId: awssdk.String(canonicalHostedZoneID),
+		CreatedTime:           awssdk.Time(time.Now()),
+		CustomerOwnedIpv4Pool: input.CustomerOwnedIpv4Pool,
+		DNSName:               awssdk.String(dnsName),
+		IpAddressType:         awssdk.String(stringValueOrDefault(input.IpAddressType, elbv2sdk.IpAddressTypeIpv4)),
+		LoadBalancerArn:       awssdk.String(lbARN),
+		LoadBalancerName:      awssdk.String(lbName),
+		Scheme:                awssdk.String(scheme),
+		State:                 &elbv2sdk.LoadBalancerState{Code: awssdk.String(elbv2sdk.LoadBalancerStateEnumActive)},
+		Type:                  awssdk.String(lbType),
+		VpcId:                 awssdk.String(vpcID),
+	}
+	if len(input.SecurityGroups) > 0 {
+		lb.SecurityGroups = awssdk.StringSlice(awssdk.StringValueSlice(input.SecurityGroups))
+	}
+	attributes := make(map[string]string, len(defaultLoadBalancerAttributes[lbType]))
+	for key, value := range defaultLoadBalancerAttributes[lbType] {
+		attributes[key] = value
+	}
+	c.loadBalancers[lbARN] = &loadBalancerState{
+		loadBalancer: lb,
+		attributes:   attributes,
+	}
+	c.tags[lbARN] = buildTagMap(input.Tags)
+	return &elbv2sdk.CreateLoadBalancerOutput{LoadBalancers: []*elbv2sdk.LoadBalancer{copyLoadBalancer(lb)}}, nil
+}
+
+func (c *ELBV2) DeleteLoadBalancerWithContext(_ context.Context, input *elbv2sdk.DeleteLoadBalancerInput, _ ...request.Option) (*elbv2sdk.DeleteLoadBalancerOutput, error) {
+	c.recorder.record(serviceELBV2, "DeleteLoadBalancer")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbARN := awssdk.StringValue(input.LoadBalancerArn)
+	lbState, exists := c.loadBalancers[lbARN]
+	if !exists {
+		return &elbv2sdk.DeleteLoadBalancerOutput{}, nil
+	}
+	if lbState.attributes[attrDeletionProtectionKey] == "true" {
+		return nil, newAWSError(elbv2sdk.ErrCodeOperationNotPermittedException, "load balancer %v cannot be deleted because deletion protection is enabled", lbARN)
+	}
+	for lsARN, lsState := range c.listeners {
+		if awssdk.StringValue(lsState.listener.LoadBalancerArn) == lbARN {
+			c.deleteListener(lsARN)
+		}
+	}
+	delete(c.loadBalancers, lbARN)
+	delete(c.tags, lbARN)
+	return &elbv2sdk.DeleteLoadBalancerOutput{}, nil
+}
+
+func (c *ELBV2) DescribeLoadBalancersWithContext(ctx context.Context, input *elbv2sdk.DescribeLoadBalancersInput, _ ...request.Option) (*elbv2sdk.DescribeLoadBalancersOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeLoadBalancers")
+	lbs, err := c.describeLoadBalancers(input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeLoadBalancersOutput{LoadBalancers: lbs}, nil
+}
+
+func (c *ELBV2) DescribeLoadBalancersAsList(ctx context.Context, input *elbv2sdk.DescribeLoadBalancersInput) ([]*elbv2sdk.LoadBalancer, error) {
+	c.recorder.record(serviceELBV2, "DescribeLoadBalancers")
+	return c.describeLoadBalancers(input)
+}
+
+func (c *ELBV2) describeLoadBalancers(input *elbv2sdk.DescribeLoadBalancersInput) ([]*elbv2sdk.LoadBalancer, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(input.LoadBalancerArns) == 0 && len(input.Names) == 0 {
+		var lbs []*elbv2sdk.LoadBalancer
+		for _, lbARN := range sets.StringKeySet(c.loadBalancers).List() {
+			lbs = append(lbs, copyLoadBalancer(c.loadBalancers[lbARN].loadBalancer))
+		}
+		return lbs, nil
+	}
+
+	var lbs []*elbv2sdk.LoadBalancer
+	for _, lbARN := range awssdk.StringValueSlice(input.LoadBalancerArns) {
+		lbState, err := c.getLoadBalancer(lbARN)
+		if err != nil {
+			return nil, err
+		}
+		lbs = append(lbs, copyLoadBalancer(lbState.loadBalancer))
+	}
+	for _, lbName := range awssdk.StringValueSlice(input.Names) {
+		var found *elbv2sdk.LoadBalancer
+		for _, lbState := range c.loadBalancers {
+			if awssdk.StringValue(lbState.loadBalancer.LoadBalancerName) == lbName {
+				found = lbState.loadBalancer
+				break
+			}
+		}
+		if found == nil {
+			return nil, newAWSError(elbv2sdk.ErrCodeLoadBalancerNotFoundException, "load balancers %v not found", lbName)
+		}
+		lbs = append(lbs, copyLoadBalancer(found))
+	}
+	return lbs, nil
+}
+
+func (c *ELBV2) SetIpAddressTypeWithContext(_ context.Context, input *elbv2sdk.SetIpAddressTypeInput, _ ...request.Option) (*elbv2sdk.SetIpAddressTypeOutput, error) {
+	c.recorder.record(serviceELBV2, "SetIpAddressType")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbState, err := c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+	lbState.loadBalancer.IpAddressType = awssdk.String(awssdk.StringValue(input.IpAddressType))
+	return &elbv2sdk.SetIpAddressTypeOutput{IpAddressType: awssdk.String(awssdk.StringValue(input.IpAddressType))}, nil
+}
+
+func (c *ELBV2) SetSubnetsWithContext(_ context.Context, input *elbv2sdk.SetSubnetsInput, _ ...request.Option) (*elbv2sdk.SetSubnetsOutput, error) {
+	c.recorder.record(serviceELBV2, "SetSubnets")
+	c.mutex.Lock()
+	lbState, err := c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	var lbType string
+	if err == nil {
+		lbType = awssdk.StringValue(lbState.loadBalancer.Type)
+	}
+	c.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// subnets are resolved from EC2 fake without holding the lock, see EC2.DeleteSecurityGroupWithContext.
+	availabilityZones, _, err := c.resolveAvailabilityZones(lbType, buildSubnetMappings(input.Subnets, input.SubnetMappings))
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbState, err = c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+	lbState.loadBalancer.AvailabilityZones = availabilityZones
+	if input.IpAddressType != nil {
+		lbState.loadBalancer.IpAddressType = awssdk.String(awssdk.StringValue(input.IpAddressType))
+	}
+	return &elbv2sdk.SetSubnetsOutput{
+		AvailabilityZones: copyLoadBalancer(lbState.loadBalancer).AvailabilityZones,
+		IpAddressType:     awssdk.String(awssdk.StringValue(lbState.loadBalancer.IpAddressType)),
+	}, nil
+}
+
+func (c *ELBV2) SetSecurityGroupsWithContext(_ context.Context, input *elbv2sdk.SetSecurityGroupsInput, _ ...request.Option) (*elbv2sdk.SetSecurityGroupsOutput, error) {
+	c.recorder.record(serviceELBV2, "SetSecurityGroups")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbState, err := c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+	if awssdk.StringValue(lbState.loadBalancer.Type) != elbv2sdk.LoadBalancerTypeEnumApplication {
+		return nil, newAWSError(elbv2sdk.ErrCodeInvalidConfigurationRequestException, "security groups are not supported for load balancer %v", awssdk.StringValue(input.LoadBalancerArn))
+	}
+	lbState.loadBalancer.SecurityGroups = awssdk.StringSlice(awssdk.StringValueSlice(input.SecurityGroups))
+	return &elbv2sdk.SetSecurityGroupsOutput{SecurityGroupIds: awssdk.StringSlice(awssdk.StringValueSlice(input.SecurityGroups))}, nil
+}
+
+func (c *ELBV2) DescribeLoadBalancerAttributesWithContext(_ context.Context, input *elbv2sdk.DescribeLoadBalancerAttributesInput, _ ...request.Option) (*elbv2sdk.DescribeLoadBalancerAttributesOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeLoadBalancerAttributes")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbState, err := c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeLoadBalancerAttributesOutput{Attributes: buildLoadBalancerAttributes(lbState.attributes)}, nil
+}
+
+func (c *ELBV2) ModifyLoadBalancerAttributesWithContext(_ context.Context, input *elbv2sdk.ModifyLoadBalancerAttributesInput, _ ...request.Option) (*elbv2sdk.ModifyLoadBalancerAttributesOutput, error) {
+	c.recorder.record(serviceELBV2, "ModifyLoadBalancerAttributes")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbState, err := c.getLoadBalancer(awssdk.StringValue(input.LoadBalancerArn))
+	if err != nil {
+		return nil, err
+	}
+	supportedAttributes := defaultLoadBalancerAttributes[awssdk.StringValue(lbState.loadBalancer.Type)]
+	for _, attr := range input.Attributes {
+		if _, ok := supportedAttributes[awssdk.StringValue(attr.Key)]; !ok {
+			return nil, newAWSError("ValidationError", "load balancer attribute key %v is not recognized", awssdk.StringValue(attr.Key))
+		}
+	}
+	for _, attr := range input.Attributes {
+		lbState.attributes[awssdk.StringValue(attr.Key)] = awssdk.StringValue(attr.Value)
+	}
+	return &elbv2sdk.ModifyLoadBalancerAttributesOutput{Attributes: buildLoadBalancerAttributes(lbState.attributes)}, nil
+}
+
+func (c *ELBV2) CreateTargetGroupWithContext(_ context.Context, input *elbv2sdk.CreateTargetGroupInput, _ ...request.Option) (*elbv2sdk.CreateTargetGroupOutput, error) {
+	c.recorder.record(serviceELBV2, "CreateTargetGroup")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgName := awssdk.StringValue(input.Name)
+	if len(tgName) == 0 || len(tgName) > 32 {
+		return nil, newAWSError("ValidationError", "target group name must be 1 to 32 characters, got %q", tgName)
+	}
+	targetType := stringValueOrDefault(input.TargetType, elbv2sdk.TargetTypeEnumInstance)
+	for _, tgState := range c.targetGroups {
+		tg := tgState.targetGroup
+		if awssdk.StringValue(tg.TargetGroupName) != tgName {
+			continue
+		}
+		if awssdk.StringValue(tg.Protocol) == awssdk.StringValue(input.Protocol) &&
+			awssdk.Int64Value(tg.Port) == awssdk.Int64Value(input.Port) &&
+			awssdk.StringValue(tg.TargetType) == targetType &&
+			awssdk.StringValue(tg.VpcId) == awssdk.StringValue(input.VpcId) {
+			return &elbv2sdk.CreateTargetGroupOutput{TargetGroups: []*elbv2sdk.TargetGroup{c.copyTargetGroup(tg)}}, nil
+		}
+		return nil, newAWSError(elbv2sdk.ErrCodeDuplicateTargetGroupNameException, "a target group with name %v already exists", tgName)
+	}
+	if targetType != elbv2sdk.TargetTypeEnumLambda && (input.Protocol == nil || input.Port == nil || input.VpcId == nil) {
+		return nil, newAWSError("ValidationError", "protocol, port and VPC ID must be specified for target group %v", tgName)
+	}
+	if err := validateTags(input.Tags); err != nil {
+		return nil, err
+	}
+
+	tgARN := fmt.Sprintf("arn:aws:elasticloadbalancing:%v:%v:targetgroup/%v/%v", c.region, c.accountID, tgName, c.nextID())
+	tg := &elbv2sdk.TargetGroup{
+		HealthCheckEnabled:         awssdk.Bool(boolValueOrDefault(input.HealthCheckEnabled, true)),
+		HealthCheckIntervalSeconds: awssdk.Int64(int64ValueOrDefault(input.HealthCheckIntervalSeconds, 30)),
+		HealthCheckPort:            awssdk.String(stringValueOrDefault(input.HealthCheckPort, healthCheckPortTraffic)),
+		HealthCheckProtocol:        input.HealthCheckProtocol,
+		HealthCheckTimeoutSeconds:  awssdk.Int64(int64ValueOrDefault(input.HealthCheckTimeoutSeconds, 5)),
+		HealthyThresholdCount:      awssdk.Int64(int64ValueOrDefault(input.HealthyThresholdCount, 5)),
+		IpAddressType:              awssdk.String(stringValueOrDefault(input.IpAddressType, elbv2sdk.TargetGroupIpAddressTypeEnumIpv4)),
+		Port:                       input.Port,
+		Protocol:                   input.Protocol,
+		TargetGroupArn:             awssdk.String(tgARN),
+		TargetGroupName:            awssdk.String(tgName),
+		TargetType:                 awssdk.String(targetType),
+		UnhealthyThresholdCount:    awssdk.Int64(int64ValueOrDefault(input.UnhealthyThresholdCount, 2)),
+		VpcId:                      input.VpcId,
+		Matcher:                    input.Matcher,
+		ProtocolVersion:            input.ProtocolVersion,
+		HealthCheckPath:            input.HealthCheckPath,
+	}
+	if tg.HealthCheckProtocol == nil {
+		tg.HealthCheckProtocol = input.Protocol
+	}
+	switch awssdk.StringValue(tg.Protocol) {
+	case elbv2sdk.ProtocolEnumHttp, elbv2sdk.ProtocolEnumHttps:
+		if tg.ProtocolVersion == nil {
+			tg.ProtocolVersion = awssdk.String(targetGroupProtocolHTTP1)
+		}
+		if tg.HealthCheckPath == nil {
+			tg.HealthCheckPath = awssdk.String("/")
+		}
+		if tg.Matcher == nil {
+			tg.Matcher = &elbv2sdk.Matcher{HttpCode: awssdk.String("200")}
+		}
+	}
+	tg = awsutil.CopyOf(tg).(*elbv2sdk.TargetGroup)
+	attributes := make(map[string]string, len(defaultTargetGroupAttributes))
+	for key, value := range defaultTargetGroupAttributes {
+		attributes[key] = value
+	}
+	c.targetGroups[tgARN] = &targetGroupState{
+		targetGroup: tg,
+		attributes:  attributes,
+		targets:     make(map[string]*elbv2sdk.TargetDescription),
+	}
+	c.tags[tgARN] = buildTagMap(input.Tags)
+	return &elbv2sdk.CreateTargetGroupOutput{TargetGroups: []*elbv2sdk.TargetGroup{c.copyTargetGroup(tg)}}, nil
+}
+
+func (c *ELBV2) DeleteTargetGroupWithContext(_ context.Context, input *elbv2sdk.DeleteTargetGroupInput, _ ...request.Option) (*elbv2sdk.DeleteTargetGroupOutput, error) {
+	c.recorder.record(serviceELBV2, "DeleteTargetGroup")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgARN := awssdk.StringValue(input.TargetGroupArn)
+	if _, exists := c.targetGroups[tgARN]; !exists {
+		return &elbv2sdk.DeleteTargetGroupOutput{}, nil
+	}
+	if len(c.loadBalancerARNsForTargetGroup(tgARN)) != 0 {
+		return nil, newAWSError(elbv2sdk.ErrCodeResourceInUseException, "target group %v is currently in use by a listener or a rule", tgARN)
+	}
+	delete(c.targetGroups, tgARN)
+	delete(c.tags, tgARN)
+	return &elbv2sdk.DeleteTargetGroupOutput{}, nil
+}
+
+func (c *ELBV2) DescribeTargetGroupsWithContext(_ context.Context, input *elbv2sdk.DescribeTargetGroupsInput, _ ...request.Option) (*elbv2sdk.DescribeTargetGroupsOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeTargetGroups")
+	tgs, err := c.describeTargetGroups(input)
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeTargetGroupsOutput{TargetGroups: tgs}, nil
+}
+
+func (c *ELBV2) DescribeTargetGroupsAsList(_ context.Context, input *elbv2sdk.DescribeTargetGroupsInput) ([]*elbv2sdk.TargetGroup, error) {
+	c.recorder.record(serviceELBV2, "DescribeTargetGroups")
+	return c.describeTargetGroups(input)
+}
+
+func (c *ELBV2) describeTargetGroups(input *elbv2sdk.DescribeTargetGroupsInput) ([]*elbv2sdk.TargetGroup, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var tgs []*elbv2sdk.TargetGroup
+	switch {
+	case input.LoadBalancerArn != nil:
+		lbARN := awssdk.StringValue(input.LoadBalancerArn)
+		if _, err := c.getLoadBalancer(lbARN); err != nil {
+			return nil, err
+		}
+		for _, tgARN := range sets.StringKeySet(c.targetGroups).List() {
+			if sets.NewString(c.loadBalancerARNsForTargetGroup(tgARN)...).Has(lbARN) {
+				tgs = append(tgs, c.copyTargetGroup(c.targetGroups[tgARN].targetGroup))
+			}
+		}
+	case len(input.TargetGroupArns) != 0 || len(input.Names) != 0:
+		for _, tgARN := range awssdk.StringValueSlice(input.TargetGroupArns) {
+			tgState, err := c.getTargetGroup(tgARN)
+			if err != nil {
+				return nil, err
+			}
+			tgs = append(tgs, c.copyTargetGroup(tgState.targetGroup))
+		}
+		for _, tgName := range awssdk.StringValueSlice(input.Names) {
+			var found *elbv2sdk.TargetGroup
+			for _, tgState := range c.targetGroups {
+				if awssdk.StringValue(tgState.targetGroup.TargetGroupName) == tgName {
+					found = tgState.targetGroup
+					break
+				}
+			}
+			if found == nil {
+				return nil, newAWSError(elbv2sdk.ErrCodeTargetGroupNotFoundException, "target groups %v not found", tgName)
+			}
+			tgs = append(tgs, c.copyTargetGroup(found))
+		}
+	default:
+		for _, tgARN := range sets.StringKeySet(c.targetGroups).List() {
+			tgs = append(tgs, c.copyTargetGroup(c.targetGroups[tgARN].targetGroup))
+		}
+	}
+	return tgs, nil
+}
+
+func (c *ELBV2) ModifyTargetGroupWithContext(_ context.Context, input *elbv2sdk.ModifyTargetGroupInput, _ ...request.Option) (*elbv2sdk.ModifyTargetGroupOutput, error) {
+	c.recorder.record(serviceELBV2, "ModifyTargetGroup")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	tg := tgState.targetGroup
+	if input.HealthCheckEnabled != nil {
+		tg.HealthCheckEnabled = awssdk.Bool(awssdk.BoolValue(input.HealthCheckEnabled))
+	}
+	if input.HealthCheckIntervalSeconds != nil {
+		tg.HealthCheckIntervalSeconds = awssdk.Int64(awssdk.Int64Value(input.HealthCheckIntervalSeconds))
+	}
+	if input.HealthCheckPath != nil {
+		tg.HealthCheckPath = awssdk.String(awssdk.StringValue(input.HealthCheckPath))
+	}
+	if input.HealthCheckPort != nil {
+		tg.HealthCheckPort = awssdk.String(awssdk.StringValue(input.HealthCheckPort))
+	}
+	if input.HealthCheckProtocol != nil {
+		tg.HealthCheckProtocol = awssdk.String(awssdk.StringValue(input.HealthCheckProtocol))
+	}
+	if input.HealthCheckTimeoutSeconds != nil {
+		tg.HealthCheckTimeoutSeconds = awssdk.Int64(awssdk.Int64Value(input.HealthCheckTimeoutSeconds))
+	}
+	if input.HealthyThresholdCount != nil {
+		tg.HealthyThresholdCount = awssdk.Int64(awssdk.Int64Value(input.HealthyThresholdCount))
+	}
+	if input.UnhealthyThresholdCount != nil {
+		tg.UnhealthyThresholdCount = awssdk.Int64(awssdk.Int64Value(input.UnhealthyThresholdCount))
+	}
+	if input.Matcher != nil {
+		tg.Matcher = awsutil.CopyOf(input.Matcher).(*elbv2sdk.Matcher)
+	}
+	return &elbv2sdk.ModifyTargetGroupOutput{TargetGroups: []*elbv2sdk.TargetGroup{c.copyTargetGroup(tg)}}, nil
+}
+
+func (c *ELBV2) DescribeTargetGroupAttributesWithContext(_ context.Context, input *elbv2sdk.DescribeTargetGroupAttributesInput, _ ...request.Option) (*elbv2sdk.DescribeTargetGroupAttributesOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeTargetGroupAttributes")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	return &elbv2sdk.DescribeTargetGroupAttributesOutput{Attributes: buildTargetGroupAttributes(tgState.attributes)}, nil
+}
+
+func (c *ELBV2) ModifyTargetGroupAttributesWithContext(_ context.Context, input *elbv2sdk.ModifyTargetGroupAttributesInput, _ ...request.Option) (*elbv2sdk.ModifyTargetGroupAttributesOutput, error) {
+	c.recorder.record(serviceELBV2, "ModifyTargetGroupAttributes")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range input.Attributes {
+		tgState.attributes[awssdk.StringValue(attr.Key)] = awssdk.StringValue(attr.Value)
+	}
+	return &elbv2sdk.ModifyTargetGroupAttributesOutput{Attributes: buildTargetGroupAttributes(tgState.attributes)}, nil
+}
+
+func (c *ELBV2) RegisterTargetsWithContext(_ context.Context, input *elbv2sdk.RegisterTargetsInput, _ ...request.Option) (*elbv2sdk.RegisterTargetsOutput, error) {
+	c.recorder.record(serviceELBV2, "RegisterTargets")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range input.Targets {
+		target = awsutil.CopyOf(target).(*elbv2sdk.TargetDescription)
+		if target.Port == nil {
+			target.Port = tgState.targetGroup.Port
+		}
+		tgState.targets[targetKey(target)] = target
+	}
+	return &elbv2sdk.RegisterTargetsOutput{}, nil
+}
+
+func (c *ELBV2) DeregisterTargetsWithContext(_ context.Context, input *elbv2sdk.DeregisterTargetsInput, _ ...request.Option) (*elbv2sdk.DeregisterTargetsOutput, error) {
+	c.recorder.record(serviceELBV2, "DeregisterTargets")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range input.Targets {
+		target = awsutil.CopyOf(target).(*elbv2sdk.TargetDescription)
+		if target.Port == nil {
+			target.Port = tgState.targetGroup.Port
+		}
+		delete(tgState.targets, targetKey(target))
+	}
+	return &elbv2sdk.DeregisterTargetsOutput{}, nil
+}
+
+func (c *ELBV2) DescribeTargetHealthWithContext(_ context.Context, input *elbv2sdk.DescribeTargetHealthInput, _ ...request.Option) (*elbv2sdk.DescribeTargetHealthOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeTargetHealth")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tgState, err := c.getTargetGroup(awssdk.StringValue(input.TargetGroupArn))
+	if err != nil {
+		return nil, err
+	}
+	var targetHealthDescriptions []*elbv2sdk.TargetHealthDescription
+	for _, key := range sets.StringKeySet(tgState.targets).List() {
+		target := tgState.targets[key]
+		targetHealthDescriptions = append(targetHealthDescriptions, &elbv2sdk.TargetHealthDescription{
+			HealthCheckPort: awssdk.String(strconv.FormatInt(awssdk.Int64Value(target.Port), 10)),
+			Target:          awsutil.CopyOf(target).(*elbv2sdk.TargetDescription),
+			TargetHealth:    &elbv2sdk.TargetHealth{State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy)},
+		})
+	}
+	return &elbv2sdk.DescribeTargetHealthOutput{TargetHealthDescriptions: targetHealthDescriptions}, nil
+}
+
+func (c *ELBV2) CreateListenerWithContext(_ context.Context, input *elbv2sdk.CreateListenerInput, _ ...request.Option) (*elbv2sdk.CreateListenerOutput, error) {
+	c.recorder.record(serviceELBV2, "CreateListener")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lbARN := awssdk.StringValue(input.LoadBalancerArn)
+	lbState, err := c.getLoadBalancer(lbARN)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateListenerPort(lbARN, "", awssdk.Int64Value(input.Port)); err != nil {
+		return nil, err
+	}
+	if err := validateListenerCertificates(awssdk.StringValue(input.Protocol), input.Certificates); err != nil {
+		return nil, err
+	}
+	if err := c.validateActions(lbARN, input.DefaultActions); err != nil {
+		return nil, err
+	}
+	if err := validateTags(input.Tags); err != nil {
+		return nil, err
+	}
+
+	lbID := lbIDFromARN(awssdk.StringValue(lbState.loadBalancer.LoadBalancerArn))
+	lsARN := fmt.Sprintf("arn:aws:elasticloadbalancing:%v:%v:listener/%v/%v/%v/%v", c.region, c.accountID,
+		lbTypeShortName(awssdk.StringValue(lbState.loadBalancer.Type)), awssdk.StringValue(lbState.loadBalancer.LoadBalancerName), lbID, c.nextID())
+	ls := &elbv2sdk.Listener{
+		AlpnPolicy:      input.AlpnPolicy,
+		Certificates:    input.Certificates,
+		DefaultActions:  input.DefaultActions,
+		ListenerArn:     awssdk.String(lsARN),
+		LoadBalancerArn: awssdk.String(lbARN),
+		Port:            input.Port,
+		Protocol:        input.Protocol,
+		SslPolicy:       input.SslPolicy,
+	}
+	if isSecureListenerProtocol(awssdk.StringValue(input.Protocol)) && ls.SslPolicy == nil {
+		ls.SslPolicy = awssdk.String(defaultSSLPolicy)
+	}
+	ls = awsutil.CopyOf(ls).(*elbv2sdk.Listener)
+	defaultRuleARN := c.buildRuleARN(lsARN)
+	c.listeners[lsARN] = &listenerState{
+		listener:       ls,
+		defaultRuleARN: defaultRuleARN,
+	}
+	c.tags[lsARN] = buildTagMap(input.Tags)
+	c.tags[defaultRuleARN] = make(map[string]string)
+	return &elbv2sdk.CreateListenerOutput{Listeners: []*elbv2sdk.Listener{copyListener(ls)}}, nil
+}
+
+func (c *ELBV2) ModifyListenerWithContext(_ context.Context, input *elbv2sdk.ModifyListenerInput, _ ...request.Option) (*elbv2sdk.ModifyListenerOutput, error) {
+	c.recorder.record(serviceELBV2, "ModifyListener")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsARN := awssdk.StringValue(input.ListenerArn)
+	lsState, err := c.getListener(lsARN)
+	if err != nil {
+		return nil, err
+	}
+	ls := lsState.listener
+	lbARN := awssdk.StringValue(ls.LoadBalancerArn)
+	if input.Port != nil {
+		if err := c.validateListenerPort(lbARN, lsARN, awssdk.Int64Value(input.Port)); err != nil {
+			return nil, err
+		}
+	}
+	protocol := awssdk.StringValue(ls.Protocol)
+	if input.Protocol != nil {
+		protocol = awssdk.StringValue(input.Protocol)
+	}
+	certificates := ls.Certificates
+	if input.Certificates != nil {
+		certificates = input.Certificates
+	}
+	if err := validateListenerCertificates(protocol, certificates); err != nil {
+		return nil, err
+	}
+	if input.DefaultActions != nil {
+		if err := c.validateActions(lbARN, input.DefaultActions); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Port != nil {
+		ls.Port = awssdk.Int64(awssdk.Int64Value(input.Port))
+	}
+	ls.Protocol = awssdk.String(protocol)
+	if input.SslPolicy != nil {
+		ls.SslPolicy = awssdk.String(awssdk.StringValue(input.SslPolicy))
+	}
+	if !isSecureListenerProtocol(protocol) {
+		ls.SslPolicy = nil
+		ls.Certificates = nil
+	} else {
+		if ls.SslPolicy == nil {
+			ls.SslPolicy = awssdk.String(defaultSSLPolicy)
+		}
+		ls.Certificates = certificates
+	}
+	if input.AlpnPolicy != nil {
+		ls.AlpnPolicy = awssdk.StringSlice(awssdk.StringValueSlice(input.AlpnPolicy))
+	}
+	if input.DefaultActions != nil {
+		ls.DefaultActions = input.DefaultActions
+	}
+	lsState.listener = copyListener(ls)
+	return &elbv2sdk.ModifyListenerOutput{Listeners: []*elbv2sdk.Listener{copyListener(ls)}}, nil
+}
+
+func (c *ELBV2) DeleteListenerWithContext(_ context.Context, input *elbv2sdk.DeleteListenerInput, _ ...request.Option) (*elbv2sdk.DeleteListenerOutput, error) {
+	c.recorder.record(serviceELBV2, "DeleteListener")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsARN := awssdk.StringValue(input.ListenerArn)
+	if _, err := c.getListener(lsARN); err != nil {
+		return nil, err
+	}
+	c.deleteListener(lsARN)
+	return &elbv2sdk.DeleteListenerOutput{}, nil
+}
+
+// deleteListener deletes listener along with its rules.
+func (c *ELBV2) deleteListener(lsARN string) {
+	for ruleARN, ruleState := range c.rules {
+		if ruleState.listenerARN == lsARN {
+			delete(c.rules, ruleARN)
+			delete(c.tags, ruleARN)
+		}
+	}
+	delete(c.tags, c.listeners[lsARN].defaultRuleARN)
+	delete(c.listeners, lsARN)
+	delete(c.tags, lsARN)
+}
+
+func (c *ELBV2) DescribeListenersAsList(_ context.Context, input *elbv2sdk.DescribeListenersInput) ([]*elbv2sdk.Listener, error) {
+	c.recorder.record(serviceELBV2, "DescribeListeners")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var listeners []*elbv2sdk.Listener
+	if input.LoadBalancerArn != nil {
+		lbARN := awssdk.StringValue(input.LoadBalancerArn)
+		if _, err := c.getLoadBalancer(lbARN); err != nil {
+			return nil, err
+		}
+		for _, lsARN := range sets.StringKeySet(c.listeners).List() {
+			if awssdk.StringValue(c.listeners[lsARN].listener.LoadBalancerArn) == lbARN {
+				listeners = append(listeners, copyListener(c.listeners[lsARN].listener))
+			}
+		}
+		return listeners, nil
+	}
+	for _, lsARN := range awssdk.StringValueSlice(input.ListenerArns) {
+		lsState, err := c.getListener(lsARN)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, copyListener(lsState.listener))
+	}
+	return listeners, nil
+}
+
+func (c *ELBV2) AddListenerCertificatesWithContext(_ context.Context, input *elbv2sdk.AddListenerCertificatesInput, _ ...request.Option) (*elbv2sdk.AddListenerCertificatesOutput, error) {
+	c.recorder.record(serviceELBV2, "AddListenerCertificates")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsState, err := c.getListener(awssdk.StringValue(input.ListenerArn))
+	if err != nil {
+		return nil, err
+	}
+	certARNs := sets.NewString(lsState.extraCertARNs...)
+	for _, cert := range input.Certificates {
+		certARN := awssdk.StringValue(cert.CertificateArn)
+		if !certARNs.Has(certARN) {
+			certARNs.Insert(certARN)
+			lsState.extraCertARNs = append(lsState.extraCertARNs, certARN)
+		}
+	}
+	var certs []*elbv2sdk.Certificate
+	for _, cert := range input.Certificates {
+		certs = append(certs, &elbv2sdk.Certificate{CertificateArn: awssdk.String(awssdk.StringValue(cert.CertificateArn))})
+	}
+	return &elbv2sdk.AddListenerCertificatesOutput{Certificates: certs}, nil
+}
+
+func (c *ELBV2) RemoveListenerCertificatesWithContext(_ context.Context, input *elbv2sdk.RemoveListenerCertificatesInput, _ ...request.Option) (*elbv2sdk.RemoveListenerCertificatesOutput, error) {
+	c.recorder.record(serviceELBV2, "RemoveListenerCertificates")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsState, err := c.getListener(awssdk.StringValue(input.ListenerArn))
+	if err != nil {
+		return nil, err
+	}
+	certARNsToRemove := sets.NewString()
+	for _, cert := range input.Certificates {
+		certARNsToRemove.Insert(awssdk.StringValue(cert.CertificateArn))
+	}
+	var extraCertARNs []string
+	for _, certARN := range lsState.extraCertARNs {
+		if !certARNsToRemove.Has(certARN) {
+			extraCertARNs = append(extraCertARNs, certARN)
+		}
+	}
+	lsState.extraCertARNs = extraCertARNs
+	return &elbv2sdk.RemoveListenerCertificatesOutput{}, nil
+}
+
+func (c *ELBV2) DescribeListenerCertificatesAsList(_ context.Context, input *elbv2sdk.DescribeListenerCertificatesInput) ([]*elbv2sdk.Certificate, error) {
+	c.recorder.record(serviceELBV2, "DescribeListenerCertificates")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsState, err := c.getListener(awssdk.StringValue(input.ListenerArn))
+	if err != nil {
+		return nil, err
+	}
+	var certs []*elbv2sdk.Certificate
+	for _, cert := range lsState.listener.Certificates {
+		certs = append(certs, &elbv2sdk.Certificate{
+			CertificateArn: awssdk.String(awssdk.StringValue(cert.CertificateArn)),
+			IsDefault:      awssdk.Bool(true),
+		})
+	}
+	for _, certARN := range lsState.extraCertARNs {
+		certs = append(certs, &elbv2sdk.Certificate{
+			CertificateArn: awssdk.String(certARN),
+			IsDefault:      awssdk.Bool(false),
+		})
+	}
+	return certs, nil
+}
+
+func (c *ELBV2) CreateRuleWithContext(_ context.Context, input *elbv2sdk.CreateRuleInput, _ ...request.Option) (*elbv2sdk.CreateRuleOutput, error) {
+	c.recorder.record(serviceELBV2, "CreateRule")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lsARN := awssdk.StringValue(input.ListenerArn)
+	lsState, err := c.getListener(lsARN)
+	if err != nil {
+		return nil, err
+	}
+	priority := awssdk.Int64Value(input.Priority)
+	if priority < minRulePriority || priority > maxRulePriority {
+		return nil, newAWSError("ValidationError", "rule priority must be within [%v, %v], got %v", minRulePriority, maxRulePriority, priority)
+	}
+	rulesCount := 0
+	for _, ruleState := range c.rules {
+		if ruleState.listenerARN != lsARN {
+			continue
+		}
+		rulesCount++
+		if awssdk.StringValue(ruleState.rule.Priority) == strconv.FormatInt(priority, 10) {
+			return nil, newAWSError(elbv2sdk.ErrCodePriorityInUseException, "priority %v is currently in use", priority)
+		}
+	}
+	if rulesCount >= maxRulesPerListener {
+		return nil, newAWSError(elbv2sdk.ErrCodeTooManyRulesException, "listener %v has reached the limit of %v rules", lsARN, maxRulesPerListener)
+	}
+	if len(input.Conditions) == 0 {
+		return nil, newAWSError("ValidationError", "at least one condition must be specified for rule")
+	}
+	if err := c.validateActions(awssdk.StringValue(lsState.listener.LoadBalancerArn), input.Actions); err != nil {
+		return nil, err
+	}
+	if err := validateTags(input.Tags); err != nil {
+		return nil, err
+	}
+
+	ruleARN := c.buildRuleARN(lsARN)
+	rule := copyRule(&elbv2sdk.Rule{
+		Actions:    input.Actions,
+		Conditions: input.Conditions,
+		IsDefault:  awssdk.Bool(false),
+		Priority:   awssdk.String(strconv.FormatInt(priority, 10)),
+		RuleArn:    awssdk.String(ruleARN),
+	})
+	c.rules[ruleARN] = &ruleState{
+		rule:        rule,
+		listenerARN: lsARN,
+	}
+	c.tags[ruleARN] = buildTagMap(input.Tags)
+	return &elbv2sdk.CreateRuleOutput{Rules: []*elbv2sdk.Rule{copyRule(rule)}}, nil
+}
+
+func (c *ELBV2) ModifyRuleWithContext(_ context.Context, input *elbv2sdk.ModifyRuleInput, _ ...request.Option) (*elbv2sdk.ModifyRuleOutput, error) {
+	c.recorder.record(serviceELBV2, "ModifyRule")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ruleState, err := c.getRule(awssdk.StringValue(input.RuleArn))
+	if err != nil {
+		return nil, err
+	}
+	if input.Actions != nil {
+		lsState := c.listeners[ruleState.listenerARN]
+		if err := c.validateActions(awssdk.StringValue(lsState.listener.LoadBalancerArn), input.Actions); err != nil {
+			return nil, err
+		}
+		ruleState.rule.Actions = input.Actions
+	}
+	if input.Conditions != nil {
+		ruleState.rule.Conditions = input.Conditions
+	}
+	ruleState.rule = copyRule(ruleState.rule)
+	return &elbv2sdk.ModifyRuleOutput{Rules: []*elbv2sdk.Rule{copyRule(ruleState.rule)}}, nil
+}
+
+func (c *ELBV2) DeleteRuleWithContext(_ context.Context, input *elbv2sdk.DeleteRuleInput, _ ...request.Option) (*elbv2sdk.DeleteRuleOutput, error) {
+	c.recorder.record(serviceELBV2, "DeleteRule")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ruleARN := awssdk.StringValue(input.RuleArn)
+	if _, err := c.getRule(ruleARN); err != nil {
+		return nil, err
+	}
+	delete(c.rules, ruleARN)
+	delete(c.tags, ruleARN)
+	return &elbv2sdk.DeleteRuleOutput{}, nil
+}
+
+func (c *ELBV2) SetRulePrioritiesWithContext(_ context.Context, input *elbv2sdk.SetRulePrioritiesInput, _ ...request.Option) (*elbv2sdk.SetRulePrioritiesOutput, error) {
+	c.recorder.record(serviceELBV2, "SetRulePriorities")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	newPriorityByRuleARN := make(map[string]string, len(input.RulePriorities))
+	for _, rulePriority := range input.RulePriorities {
+		ruleARN := awssdk.StringValue(rulePriority.RuleArn)
+		if _, err := c.getRule(ruleARN); err != nil {
+			return nil, err
+		}
+		priority := awssdk.Int64Value(rulePriority.Priority)
+		if priority < minRulePriority || priority > maxRulePriority {
+			return nil, newAWSError("ValidationError", "rule priority must be within [%v, %v], got %v", minRulePriority, maxRulePriority, priority)
+		}
+		newPriorityByRuleARN[ruleARN] = strconv.FormatInt(priority, 10)
+	}
+
+	// priorities are validated against the state after all priorities are set.
+	rulesByListenerAndPriority := make(map[string]string)
+	for ruleARN, ruleState := range c.rules {
+		priority := awssdk.StringValue(ruleState.rule.Priority)
+		if newPriority, ok := newPriorityByRuleARN[ruleARN]; ok {
+			priority = newPriority
+		}
+		key := ruleState.listenerARN + "/" + priority
+		if _, exists := rulesByListenerAndPriority[key]; exists {
+			return nil, newAWSError(elbv2sdk.ErrCodePriorityInUseException, "priority %v is currently in use", priority)
+		}
+		rulesByListenerAndPriority[key] = ruleARN
+	}
+	var rules []*elbv2sdk.Rule
+	for _, rulePriority := range input.RulePriorities {
+		ruleState := c.rules[awssdk.StringValue(rulePriority.RuleArn)]
+		ruleState.rule.Priority = awssdk.String(newPriorityByRuleARN[awssdk.StringValue(rulePriority.RuleArn)])
+		rules = append(rules, copyRule(ruleState.rule))
+	}
+	return &elbv2sdk.SetRulePrioritiesOutput{Rules: rules}, nil
+}
+
+func (c *ELBV2) DescribeRulesAsList(_ context.Context, input *elbv2sdk.DescribeRulesInput) ([]*elbv2sdk.Rule, error) {
+	c.recorder.record(serviceELBV2, "DescribeRules")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if input.ListenerArn != nil {
+		lsARN := awssdk.StringValue(input.ListenerArn)
+		lsState, err := c.getListener(lsARN)
+		if err != nil {
+			return nil, err
+		}
+		var rules []*elbv2sdk.Rule
+		for _, ruleState := range c.rules {
+			if ruleState.listenerARN == lsARN {
+				rules = append(rules, copyRule(ruleState.rule))
+			}
+		}
+		sort.Slice(rules, func(i, j int) bool {
+			iPriority, _ := strconv.ParseInt(awssdk.StringValue(rules[i].Priority), 10, 64)
+			jPriority, _ := strconv.ParseInt(awssdk.StringValue(rules[j].Priority), 10, 64)
+			return iPriority < jPriority
+		})
+		return append(rules, buildDefaultRule(lsState)), nil
+	}
+
+	var rules []*elbv2sdk.Rule
+	for _, ruleARN := range awssdk.StringValueSlice(input.RuleArns) {
+		ruleState, err := c.getRule(ruleARN)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, copyRule(ruleState.rule))
+	}
+	return rules, nil
+}
+
+func (c *ELBV2) AddTagsWithContext(_ context.Context, input *elbv2sdk.AddTagsInput, _ ...request.Option) (*elbv2sdk.AddTagsOutput, error) {
+	c.recorder.record(serviceELBV2, "AddTags")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.validateTagResources(input.ResourceArns); err != nil {
+		return nil, err
+	}
+	for _, resARN := range awssdk.StringValueSlice(input.ResourceArns) {
+		tags := make(map[string]string, len(c.tags[resARN])+len(input.Tags))
+		for key, value := range c.tags[resARN] {
+			tags[key] = value
+		}
+		for _, tag := range input.Tags {
+			tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+		}
+		if len(tags) > maxTagsPerResource {
+			return nil, newAWSError(elbv2sdk.ErrCodeTooManyTagsException, "resource %v has reached the limit of %v tags", resARN, maxTagsPerResource)
+		}
+		c.tags[resARN] = tags
+	}
+	return &elbv2sdk.AddTagsOutput{}, nil
+}
+
+func (c *ELBV2) RemoveTagsWithContext(_ context.Context, input *elbv2sdk.RemoveTagsInput, _ ...request.Option) (*elbv2sdk.RemoveTagsOutput, error) {
+	c.recorder.record(serviceELBV2, "RemoveTags")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.validateTagResources(input.ResourceArns); err != nil {
+		return nil, err
+	}
+	for _, resARN := range awssdk.StringValueSlice(input.ResourceArns) {
+		for _, tagKey := range awssdk.StringValueSlice(input.TagKeys) {
+			delete(c.tags[resARN], tagKey)
+		}
+	}
+	return &elbv2sdk.RemoveTagsOutput{}, nil
+}
+
+func (c *ELBV2) DescribeTagsWithContext(_ context.Context, input *elbv2sdk.DescribeTagsInput, _ ...request.Option) (*elbv2sdk.DescribeTagsOutput, error) {
+	c.recorder.record(serviceELBV2, "DescribeTags")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.validateTagResources(input.ResourceArns); err != nil {
+		return nil, err
+	}
+	var tagDescriptions []*elbv2sdk.TagDescription
+	for _, resARN := range awssdk.StringValueSlice(input.ResourceArns) {
+		tagDescription := &elbv2sdk.TagDescription{ResourceArn: awssdk.String(resARN)}
+		for _, tagKey := range sets.StringKeySet(c.tags[resARN]).List() {
+			tagDescription.Tags = append(tagDescription.Tags, &elbv2sdk.Tag{
+				Key:   awssdk.String(tagKey),
+				Value: awssdk.String(c.tags[resARN][tagKey]),
+			})
+		}
+		tagDescriptions = append(tagDescriptions, tagDescription)
+	}
+	return &elbv2sdk.DescribeTagsOutput{TagDescriptions: tagDescriptions}, nil
+}
+
+// isSecurityGroupInUse checks whether any load balancer is associated with security group.
+func (c *ELBV2) isSecurityGroupInUse(sgID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, lbState := range c.loadBalancers {
+		if sets.NewString(awssdk.StringValueSlice(lbState.loadBalancer.SecurityGroups)...).Has(sgID) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAvailabilityZones resolves the availability zones and VPC of load balancer from subnets.
+// it must be called without holding the lock.
+func (c *ELBV2) resolveAvailabilityZones(lbType string, subnetMappings []*elbv2sdk.SubnetMapping) ([]*elbv2sdk.AvailabilityZone, string, error) {
+	var availabilityZones []*elbv2sdk.AvailabilityZone
+	zoneNames := sets.NewString()
+	vpcIDs := sets.NewString()
+	for _, subnetMapping := range subnetMappings {
+		subnetID := awssdk.StringValue(subnetMapping.SubnetId)
+		subnet, exists := c.ec2.lookupSubnet(subnetID)
+		if !exists {
+			return nil, "", newAWSError(elbv2sdk.ErrCodeSubnetNotFoundException, "subnet %v not found", subnetID)
+		}
+		zoneName := awssdk.StringValue(subnet.AvailabilityZone)
+		if zoneNames.Has(zoneName) {
+			return nil, "", newAWSError(elbv2sdk.ErrCodeInvalidConfigurationRequestException, "only one subnet per availability zone can be specified, got multiple subnets in %v", zoneName)
+		}
+		zoneNames.Insert(zoneName)
+		vpcIDs.Insert(awssdk.StringValue(subnet.VpcId))
+		availabilityZones = append(availabilityZones, &elbv2sdk.AvailabilityZone{
+			SubnetId: awssdk.String(subnetID),
+			ZoneName: awssdk.String(zoneName),
+		})
+	}
+	if vpcIDs.Len() > 1 {
+		return nil, "", newAWSError(elbv2sdk.ErrCodeInvalidSubnetException, "subnets must belong to the same VPC, got %v", vpcIDs.List())
+	}
+	minSubnets := 1
+	if lbType == elbv2sdk.LoadBalancerTypeEnumApplication {
+		minSubnets = 2
+	}
+	if len(availabilityZones) < minSubnets {
+		return nil, "", newAWSError("ValidationError", "at least %v subnets in different availability zones must be specified", minSubnets)
+	}
+	vpcID, _ := vpcIDs.PopAny()
+	return availabilityZones, vpcID, nil
+}
+
+// validateListenerPort validates no other listener of load balancer uses the same port.
+func (c *ELBV2) validateListenerPort(lbARN string, lsARN string, port int64) error {
+	for otherLSARN, lsState := range c.listeners {
+		if otherLSARN == lsARN || awssdk.StringValue(lsState.listener.LoadBalancerArn) != lbARN {
+			continue
+		}
+		if awssdk.Int64Value(lsState.listener.Port) == port {
+			return newAWSError(elbv2sdk.ErrCodeDuplicateListenerException, "a listener already exists on port %v", port)
+		}
+	}
+	return nil
+}
+
+// validateActions validates the forward weights are in range, and the target groups referenced by actions exist and aren't used by other load balancers.
+func (c *ELBV2) validateActions(lbARN string, actions []*elbv2sdk.Action) error {
+	if len(actions) == 0 {
+		return newAWSError("ValidationError", "at least one action must be specified")
+	}
+	for _, action := range actions {
+		if action.ForwardConfig == nil {
+			continue
+		}
+		for _, tgTuple := range action.ForwardConfig.TargetGroups {
+			if tgTuple.Weight == nil {
+				continue
+			}
+			weight := awssdk.Int64Value(tgTuple.Weight)
+			if weight < minForwardTargetGroupWeight || weight > maxForwardTargetGroupWeight {
+				return newAWSError("ValidationError", "weight %v of target group %v must be in range [%v, %v]",
+					weight, awssdk.StringValue(tgTuple.TargetGroupArn), minForwardTargetGroupWeight, maxForwardTargetGroupWeight)
+			}
+		}
+	}
+	for _, tgARN := range targetGroupARNsFromActions(actions) {
+		if _, err := c.getTargetGroup(tgARN); err != nil {
+			return err
+		}
+		for _, otherLBARN := range c.loadBalancerARNsForTargetGroup(tgARN) {
+			if otherLBARN != lbARN {
+				return newAWSError(elbv2sdk.ErrCodeTargetGroupAssociationLimitException, "target group %v is currently associated with load balancer %v", tgARN, otherLBARN)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTagResources validates the resources to tag exist.
+func (c *ELBV2) validateTagResources(resARNs []*string) error {
+	if len(resARNs) > maxTagResourcesPerCall {
+		return newAWSError("ValidationError", "at most %v resources can be specified, got %v", maxTagResourcesPerCall, len(resARNs))
+	}
+	for _, resARN := range awssdk.StringValueSlice(resARNs) {
+		if _, exists := c.tags[resARN]; !exists {
+			return newAWSError("ValidationError", "resource %v not found", resARN)
+		}
+	}
+	return nil
+}
+
+// loadBalancerARNsForTargetGroup returns the load balancers whose listeners or rules forward to target group.
+func (c *ELBV2) loadBalancerARNsForTargetGroup(tgARN string) []string {
+	lbARNs := sets.NewString()
+	for _, lsState := range c.listeners {
+		if sets.NewString(targetGroupARNsFromActions(lsState.listener.DefaultActions)...).Has(tgARN) {
+			lbARNs.Insert(awssdk.StringValue(lsState.listener.LoadBalancerArn))
+		}
+	}
+	for _, ruleState := range c.rules {
+		if sets.NewString(targetGroupARNsFromActions(ruleState.rule.Actions)...).Has(tgARN) {
+			lbARNs.Insert(awssdk.StringValue(c.listeners[ruleState.listenerARN].listener.LoadBalancerArn))
+		}
+	}
+	return lbARNs.List()
+}
+
+func (c *ELBV2) getLoadBalancer(lbARN string) (*loadBalancerState, error) {
+	lbState, exists := c.loadBalancers[lbARN]
+	if !exists {
+		return nil, newAWSError(elbv2sdk.ErrCodeLoadBalancerNotFoundException, "load balancer %v not found", lbARN)
+	}
+	return lbState, nil
+}
+
+func (c *ELBV2) getTargetGroup(tgARN string) (*targetGroupState, error) {
+	tgState, exists := c.targetGroups[tgARN]
+	if !exists {
+		return nil, newAWSError(elbv2sdk.ErrCodeTargetGroupNotFoundException, "target group %v not found", tgARN)
+	}
+	return tgState, nil
+}
+
+func (c *ELBV2) getListener(lsARN string) (*listenerState, error) {
+	lsState, exists := c.listeners[lsARN]
+	if !exists {
+		return nil, newAWSError(elbv2sdk.ErrCodeListenerNotFoundException, "listener %v not found", lsARN)
+	}
+	return lsState, nil
+}
+
+func (c *ELBV2) getRule(ruleARN string) (*ruleState, error) {
+	ruleState, exists := c.rules[ruleARN]
+	if !exists {
+		for _, lsState := range c.listeners {
+			if lsState.defaultRuleARN == ruleARN {
+				return nil, newAWSError(elbv2sdk.ErrCodeOperationNotPermittedException, "default rule %v cannot be modified", ruleARN)
+			}
+		}
+		return nil, newAWSError(elbv2sdk.ErrCodeRuleNotFoundException, "rule %v not found", ruleARN)
+	}
+	return ruleState, nil
+}
+
+func (c *ELBV2) buildRuleARN(lsARN string) string {
+	lsPath := lsARN[len(fmt.Sprintf("arn:aws:elasticloadbalancing:%v:%v:listener/", c.region, c.accountID)):]
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:%v:%v:listener-rule/%v/%v", c.region, c.accountID, lsPath, c.nextID())
+}
+
+// nextID generates IDs that sort in the order of resource creation.
+func (c *ELBV2) nextID() string {
+	c.idCounter++
+	return fmt.Sprintf("%016x", c.idCounter)
+}
+
+// copyTargetGroup copies target group along with the load balancers it's associated with.
+func (c *ELBV2) copyTargetGroup(tg *elbv2sdk.TargetGroup) *elbv2sdk.TargetGroup {
+	tgCopy := awsutil.CopyOf(tg).(*elbv2sdk.TargetGroup)
+	tgCopy.LoadBalancerArns = awssdk.StringSlice(c.loadBalancerARNsForTargetGroup(awssdk.StringValue(tg.TargetGroupArn)))
+	return tgCopy
+}
+
+func buildDefaultRule(lsState *listenerState) *elbv2sdk.Rule {
+	return copyRule(&elbv2sdk.Rule{
+		Actions:    lsState.listener.DefaultActions,
+		Conditions: []*elbv2sdk.RuleCondition{},
+		IsDefault:  awssdk.Bool(true),
+		Priority:   awssdk.String(defaultRulePriority),
+		RuleArn:    awssdk.String(lsState.defaultRuleARN),
+	})
+}
+
+func buildSubnetMappings(subnets []*string, subnetMappings []*elbv2sdk.SubnetMapping) []*elbv2sdk.SubnetMapping {
+	if len(subnetMappings) != 0 {
+		return subnetMappings
+	}
+	result := make([]*elbv2sdk.SubnetMapping, 0, len(subnets))
+	for _, subnetID := range subnets {
+		result = append(result, &elbv2sdk.SubnetMapping{SubnetId: subnetID})
+	}
+	return result
+}
+
+func buildLoadBalancerAttributes(attributes map[string]string) []*elbv2sdk.LoadBalancerAttribute {
+	result := make([]*elbv2sdk.LoadBalancerAttribute, 0, len(attributes))
+	for _, key := range sets.StringKeySet(attributes).List() {
+		result = append(result, &elbv2sdk.LoadBalancerAttribute{
+			Key:   awssdk.String(key),
+			Value: awssdk.String(attributes[key]),
+		})
+	}
+	return result
+}
+
+func buildTargetGroupAttributes(attributes map[string]string) []*elbv2sdk.TargetGroupAttribute {
+	result := make([]*elbv2sdk.TargetGroupAttribute, 0, len(attributes))
+	for _, key := range sets.StringKeySet(attributes).List() {
+		result = append(result, &elbv2sdk.TargetGroupAttribute{
+			Key:   awssdk.String(key),
+			Value: awssdk.String(attributes[key]),
+		})
+	}
+	return result
+}
+
+func buildTagMap(tags []*elbv2sdk.Tag) map[string]string {
+	tagMap := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagMap[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return tagMap
+}
+
+func validateTags(tags []*elbv2sdk.Tag) error {
+	if len(tags) > maxTagsPerResource {
+		return newAWSError(elbv2sdk.ErrCodeTooManyTagsException, "at most %v tags can be specified, got %v", maxTagsPerResource, len(tags))
+	}
+	return nil
+}
+
+func validateListenerCertificates(protocol string, certificates []*elbv2sdk.Certificate) error {
+	if isSecureListenerProtocol(protocol) && len(certificates) != 1 {
+		return newAWSError(elbv2sdk.ErrCodeCertificateNotFoundException, "exactly one default certificate must be specified for %v listener, got %v", protocol, len(certificates))
+	}
+	return nil
+}
+
+func isSecureListenerProtocol(protocol string) bool {
+	return protocol == elbv2sdk.ProtocolEnumHttps || protocol == elbv2sdk.ProtocolEnumTls
+}
+
+func targetGroupARNsFromActions(actions []*elbv2sdk.Action) []string {
+	var tgARNs []string
+	for _, action := range actions {
+		if action.TargetGroupArn != nil {
+			tgARNs = append(tgARNs, awssdk.StringValue(action.TargetGroupArn))
+		}
+		if action.ForwardConfig != nil {
+			for _, tgTuple := range action.ForwardConfig.TargetGroups {
+				tgARNs = append(tgARNs, awssdk.StringValue(tgTuple.TargetGroupArn))
+			}
+		}
+	}
+	return tgARNs
+}
+
+func targetKey(target *elbv2sdk.TargetDescription) string {
+	return fmt.Sprintf("%v:%v", awssdk.StringValue(target.Id), awssdk.Int64Value(target.Port))
+}
+
+func lbTypeShortName(lbType string) string {
+	switch lbType {
+	case elbv2sdk.LoadBalancerTypeEnumNetwork:
+		return "net"
+	case elbv2sdk.LoadBalancerTypeEnumGateway:
+		return "gwy"
+	default:
+		return "app"
+	}
+}
+
+// lbIDFromARN returns the trailing ID of load balancer ARN.
+func lbIDFromARN(lbARN string) string {
+	for i := len(lbARN) - 1; i >= 0; i-- {
+		if lbARN[i] == '/' {
+			return lbARN[i+1:]
+		}
+	}
+	return lbARN
+}
+
+func copyLoadBalancer(lb *elbv2sdk.LoadBalancer) *elbv2sdk.LoadBalancer {
+	return awsutil.CopyOf(lb).(*elbv2sdk.LoadBalancer)
+}
+
+func copyListener(ls *elbv2sdk.Listener) *elbv2sdk.Listener {
+	return awsutil.CopyOf(ls).(*elbv2sdk.Listener)
+}
+
+func copyRule(rule *elbv2sdk.Rule) *elbv2sdk.Rule {
+	return awsutil.CopyOf(rule).(*elbv2sdk.Rule)
+}
+
+func stringValueOrDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+func boolValueOrDefault(value *bool, defaultValue bool) bool {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+func int64ValueOrDefault(value *int64, defaultValue int64) int64 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
@@ -0,0 +1,253 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCloudWithLoadBalancer constructs a Cloud with an ALB that forwards HTTP:80 to a target group.
+func newTestCloudWithLoadBalancer(t *testing.T) (*Cloud, string, string, string) {
+	ctx := context.Background()
+	cloud := NewCloud("us-west-2", "vpc-1", "10.0.0.0/16")
+	for _, subnet := range []struct{ id, zone, zoneID string }{{"subnet-a", "us-west-2a", "usw2-az1"}, {"subnet-b", "us-west-2b", "usw2-az2"}} {
+		cloud.FakeEC2().AddSubnet(&ec2sdk.Subnet{
+			SubnetId:           awssdk.String(subnet.id),
+			AvailabilityZone:   awssdk.String(subnet.zone),
+			AvailabilityZoneId: awssdk.String(subnet.zoneID),
+			VpcId:              awssdk.String("vpc-1"),
+		})
+	}
+	lbResp, err := cloud.ELBV2().CreateLoadBalancerWithContext(ctx, &elbv2sdk.CreateLoadBalancerInput{
+		Name:    awssdk.String("my-lb"),
+		Subnets: awssdk.StringSlice([]string{"subnet-a", "subnet-b"}),
+	})
+	require.NoError(t, err)
+	tgResp, err := cloud.ELBV2().CreateTargetGroupWithContext(ctx, &elbv2sdk.CreateTargetGroupInput{
+		Name:       awssdk.String("my-tg"),
+		Port:       awssdk.Int64(80),
+		Protocol:   awssdk.String("HTTP"),
+		TargetType: awssdk.String("ip"),
+		VpcId:      awssdk.String("vpc-1"),
+	})
+	require.NoError(t, err)
+	lsResp, err := cloud.ELBV2().CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+		LoadBalancerArn: lbResp.LoadBalancers[0].LoadBalancerArn,
+		Port:            awssdk.Int64(80),
+		Protocol:        awssdk.String("HTTP"),
+		DefaultActions: []*elbv2sdk.Action{
+			{
+				Type:           awssdk.String("forward"),
+				TargetGroupArn: tgResp.TargetGroups[0].TargetGroupArn,
+			},
+		},
+	})
+	require.NoError(t, err)
+	return cloud, awssdk.StringValue(lbResp.LoadBalancers[0].LoadBalancerArn), awssdk.StringValue(tgResp.TargetGroups[0].TargetGroupArn),
+		awssdk.StringValue(lsResp.Listeners[0].ListenerArn)
+}
+
+func assertAWSErrorCode(t *testing.T, wantCode string, err error) {
+	var awsErr awserr.Error
+	require.ErrorAs(t, err, &awsErr)
+	assert.Equal(t, wantCode, awsErr.Code())
+}
+
+func TestELBV2_LoadBalancer(t *testing.T) {
+	ctx := context.Background()
+	cloud, lbARN, tgARN, _ := newTestCloudWithLoadBalancer(t)
+
+	lbs, err := cloud.ELBV2().DescribeLoadBalancersAsList(ctx, &elbv2sdk.DescribeLoadBalancersInput{})
+	require.NoError(t, err)
+	require.Len(t, lbs, 1)
+	assert.Equal(t, "vpc-1", awssdk.StringValue(lbs[0].VpcId))
+	assert.Equal(t, "internet-facing", awssdk.StringValue(lbs[0].Scheme))
+	assert.Len(t, lbs[0].AvailabilityZones, 2)
+
+	tgs, err := cloud.ELBV2().DescribeTargetGroupsAsList(ctx, &elbv2sdk.DescribeTargetGroupsInput{TargetGroupArns: awssdk.StringSlice([]string{tgARN})})
+	require.NoError(t, err)
+	assert.Equal(t, []string{lbARN}, awssdk.StringValueSlice(tgs[0].LoadBalancerArns))
+	assert.Equal(t, "HTTP1", awssdk.StringValue(tgs[0].ProtocolVersion))
+
+	_, err = cloud.ELBV2().ModifyLoadBalancerAttributesWithContext(ctx, &elbv2sdk.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+		Attributes:      []*elbv2sdk.LoadBalancerAttribute{{Key: awssdk.String("unknown.key"), Value: awssdk.String("1")}},
+	})
+	assertAWSErrorCode(t, "ValidationError", err)
+	_, err = cloud.ELBV2().ModifyLoadBalancerAttributesWithContext(ctx, &elbv2sdk.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+		Attributes:      []*elbv2sdk.LoadBalancerAttribute{{Key: awssdk.String("deletion_protection.enabled"), Value: awssdk.String("true")}},
+	})
+	require.NoError(t, err)
+	_, err = cloud.ELBV2().DeleteLoadBalancerWithContext(ctx, &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String(lbARN)})
+	assertAWSErrorCode(t, "OperationNotPermitted", err)
+
+	_, err = cloud.ELBV2().DeleteTargetGroupWithContext(ctx, &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String(tgARN)})
+	assertAWSErrorCode(t, "ResourceInUse", err)
+
+	_, err = cloud.ELBV2().ModifyLoadBalancerAttributesWithContext(ctx, &elbv2sdk.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+		Attributes:      []*elbv2sdk.LoadBalancerAttribute{{Key: awssdk.String("deletion_protection.enabled"), Value: awssdk.String("false")}},
+	})
+	require.NoError(t, err)
+	_, err = cloud.ELBV2().DeleteLoadBalancerWithContext(ctx, &elbv2sdk.DeleteLoadBalancerInput{LoadBalancerArn: awssdk.String(lbARN)})
+	require.NoError(t, err)
+	_, err = cloud.ELBV2().DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{LoadBalancerArn: awssdk.String(lbARN)})
+	assertAWSErrorCode(t, "LoadBalancerNotFound", err)
+	_, err = cloud.ELBV2().DeleteTargetGroupWithContext(ctx, &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String(tgARN)})
+	require.NoError(t, err)
+}
+
+func TestELBV2_Rules(t *testing.T) {
+	ctx := context.Background()
+	cloud, _, tgARN, lsARN := newTestCloudWithLoadBalancer(t)
+	createRule := func(priority int64, path string) (string, error) {
+		resp, err := cloud.ELBV2().CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+			ListenerArn: awssdk.String(lsARN),
+			Priority:    awssdk.Int64(priority),
+			Conditions: []*elbv2sdk.RuleCondition{
+				{
+					Field:             awssdk.String("path-pattern"),
+					PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{Values: awssdk.StringSlice([]string{path})},
+				},
+			},
+			Actions: []*elbv2sdk.Action{
+				{
+					Type:           awssdk.String("forward"),
+					TargetGroupArn: awssdk.String(tgARN),
+				},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		return awssdk.StringValue(resp.Rules[0].RuleArn), nil
+	}
+
+	rule1ARN, err := createRule(1, "/a")
+	require.NoError(t, err)
+	rule2ARN, err := createRule(2, "/b")
+	require.NoError(t, err)
+	_, err = createRule(2, "/c")
+	assertAWSErrorCode(t, "PriorityInUse", err)
+	_, err = cloud.ELBV2().CreateRuleWithContext(ctx, &elbv2sdk.CreateRuleInput{
+		ListenerArn: awssdk.String(lsARN),
+		Priority:    awssdk.Int64(3),
+		Conditions: []*elbv2sdk.RuleCondition{
+			{
+				Field:             awssdk.String("path-pattern"),
+				PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{Values: awssdk.StringSlice([]string{"/d"})},
+			},
+		},
+		Actions: []*elbv2sdk.Action{
+			{
+				Type: awssdk.String("forward"),
+				ForwardConfig: &elbv2sdk.ForwardActionConfig{
+					TargetGroups: []*elbv2sdk.TargetGroupTuple{{TargetGroupArn: awssdk.String(tgARN), Weight: awssdk.Int64(1000)}},
+				},
+			},
+		},
+	})
+	assertAWSErrorCode(t, "ValidationError", err)
+
+	_, err = cloud.ELBV2().SetRulePrioritiesWithContext(ctx, &elbv2sdk.SetRulePrioritiesInput{
+		RulePriorities: []*elbv2sdk.RulePriorityPair{{RuleArn: awssdk.String(rule1ARN), Priority: awssdk.Int64(2)}},
+	})
+	assertAWSErrorCode(t, "PriorityInUse", err)
+	_, err = cloud.ELBV2().SetRulePrioritiesWithContext(ctx, &elbv2sdk.SetRulePrioritiesInput{
+		RulePriorities: []*elbv2sdk.RulePriorityPair{
+			{RuleArn: awssdk.String(rule1ARN), Priority: awssdk.Int64(2)},
+			{RuleArn: awssdk.String(rule2ARN), Priority: awssdk.Int64(1)},
+		},
+	})
+	require.NoError(t, err)
+
+	rules, err := cloud.ELBV2().DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{ListenerArn: awssdk.String(lsARN)})
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, rule2ARN, awssdk.StringValue(rules[0].RuleArn))
+	assert.Equal(t, rule1ARN, awssdk.StringValue(rules[1].RuleArn))
+	assert.Equal(t, "default", awssdk.StringValue(rules[2].Priority))
+	assert.True(t, awssdk.BoolValue(rules[2].IsDefault))
+
+	_, err = cloud.ELBV2().DeleteRuleWithContext(ctx, &elbv2sdk.DeleteRuleInput{RuleArn: rules[2].RuleArn})
+	assertAWSErrorCode(t, "OperationNotPermitted", err)
+	tagsResp, err := cloud.ELBV2().DescribeTagsWithContext(ctx, &elbv2sdk.DescribeTagsInput{ResourceArns: []*string{rules[2].RuleArn}})
+	require.NoError(t, err)
+	assert.Empty(t, tagsResp.TagDescriptions[0].Tags)
+}
+
+func TestELBV2_ListenerCertificates(t *testing.T) {
+	ctx := context.Background()
+	cloud, lbARN, tgARN, _ := newTestCloudWithLoadBalancer(t)
+	createHTTPSListener := func(certificates []*elbv2sdk.Certificate) (*elbv2sdk.CreateListenerOutput, error) {
+		return cloud.ELBV2().CreateListenerWithContext(ctx, &elbv2sdk.CreateListenerInput{
+			LoadBalancerArn: awssdk.String(lbARN),
+			Port:            awssdk.Int64(443),
+			Protocol:        awssdk.String("HTTPS"),
+			Certificates:    certificates,
+			DefaultActions:  []*elbv2sdk.Action{{Type: awssdk.String("forward"), TargetGroupArn: awssdk.String(tgARN)}},
+		})
+	}
+
+	_, err := createHTTPSListener(nil)
+	assertAWSErrorCode(t, "CertificateNotFound", err)
+	lsResp, err := createHTTPSListener([]*elbv2sdk.Certificate{{CertificateArn: awssdk.String("cert-1")}})
+	require.NoError(t, err)
+	assert.Equal(t, "ELBSecurityPolicy-2016-08", awssdk.StringValue(lsResp.Listeners[0].SslPolicy))
+	_, err = createHTTPSListener([]*elbv2sdk.Certificate{{CertificateArn: awssdk.String("cert-1")}})
+	assertAWSErrorCode(t, "DuplicateListener", err)
+
+	lsARN := lsResp.Listeners[0].ListenerArn
+	_, err = cloud.ELBV2().AddListenerCertificatesWithContext(ctx, &elbv2sdk.AddListenerCertificatesInput{
+		ListenerArn:  lsARN,
+		Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String("cert-2")}, {CertificateArn: awssdk.String("cert-3")}},
+	})
+	require.NoError(t, err)
+	_, err = cloud.ELBV2().RemoveListenerCertificatesWithContext(ctx, &elbv2sdk.RemoveListenerCertificatesInput{
+		ListenerArn:  lsARN,
+		Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String("cert-2")}},
+	})
+	require.NoError(t, err)
+	certs, err := cloud.ELBV2().DescribeListenerCertificatesAsList(ctx, &elbv2sdk.DescribeListenerCertificatesInput{ListenerArn: lsARN})
+	require.NoError(t, err)
+	assert.Equal(t, []*elbv2sdk.Certificate{
+		{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+		{CertificateArn: awssdk.String("cert-3"), IsDefault: awssdk.Bool(false)},
+	}, certs)
+}
+
+func TestCloud_APICallCounts(t *testing.T) {
+	cloud, _, _, _ := newTestCloudWithLoadBalancer(t)
+	assert.Equal(t, map[string]int{
+		"ELBV2.CreateLoadBalancer": 1,
+		"ELBV2.CreateTargetGroup":  1,
+		"ELBV2.CreateListener":     1,
+	}, cloud.APICallCounts())
+
+	cloud.ResetAPICallCounts()
+	assert.Empty(t, cloud.APICallCounts())
+}
+
+func TestIsMutatingAPI(t *testing.T) {
+	tests := []struct {
+		api  string
+		want bool
+	}{
+		{api: "ELBV2.CreateLoadBalancer", want: true},
+		{api: "EC2.AuthorizeSecurityGroupIngress", want: true},
+		{api: "ELBV2.DescribeTags", want: false},
+		{api: "ACM.ListCertificates", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.api, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsMutatingAPI(tt.api))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/retry"
+)
+
+type requestConfigContextKey struct{}
+
+// RequestConfig overrides the retry and timeout behavior of AWS API calls made with the context carrying it.
+type RequestConfig struct {
+	// MaxRetries overrides the maximum retries of each API call if specified.
+	MaxRetries *int
+
+	// Timeout limits the duration of each API call including retries if specified.
+	Timeout *time.Duration
+}
+
+// ContextWithRequestConfig returns a copy of ctx carrying requestConfig, which applies to AWS API calls made with the returned context.
+func ContextWithRequestConfig(ctx context.Context, requestConfig RequestConfig) context.Context {
+	return context.WithValue(ctx, requestConfigContextKey{}, requestConfig)
+}
+
+// RequestConfigFromContext returns the RequestConfig carried by ctx if any.
+func RequestConfigFromContext(ctx context.Context) (RequestConfig, bool) {
+	requestConfig, ok := ctx.Value(requestConfigContextKey{}).(RequestConfig)
+	return requestConfig, ok
+}
+
+// injectRequestConfig will inject handler that applies the RequestConfig carried by request context into awsSDK.
+// It runs before API calls are validated, so that the overrides are in effect for the whole API call.
+func injectRequestConfig(handlers *request.Handlers) {
+	handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: fmt.Sprintf("%s/request-config", appName),
+		Fn:   applyRequestConfig,
+	})
+}
+
+func applyRequestConfig(r *request.Request) {
+	requestConfig, ok := RequestConfigFromContext(r.Context())
+	if !ok {
+		return
+	}
+	if requestConfig.MaxRetries != nil {
+		r.ApplyOptions(retry.WithMaxRetries(*requestConfig.MaxRetries))
+	}
+	if requestConfig.Timeout != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), *requestConfig.Timeout)
+		r.SetContext(ctx)
+		// handlers of request are copied from session, so this only releases the timer of this API call.
+		r.Handlers.Complete.PushBack(func(_ *request.Request) {
+			cancel()
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyRequestConfig(t *testing.T) {
+	maxRetries := 20
+	timeout := 5 * time.Minute
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		wantMaxRetries int
+		wantDeadline   bool
+	}{
+		{
+			name:           "context without requestConfig",
+			ctx:            context.Background(),
+			wantMaxRetries: 10,
+			wantDeadline:   false,
+		},
+		{
+			name:           "context with empty requestConfig",
+			ctx:            ContextWithRequestConfig(context.Background(), RequestConfig{}),
+			wantMaxRetries: 10,
+			wantDeadline:   false,
+		},
+		{
+			name: "context with maxRetries",
+			ctx: ContextWithRequestConfig(context.Background(), RequestConfig{
+				MaxRetries: &maxRetries,
+			}),
+			wantMaxRetries: 20,
+			wantDeadline:   false,
+		},
+		{
+			name: "context with timeout",
+			ctx: ContextWithRequestConfig(context.Background(), RequestConfig{
+				Timeout: &timeout,
+			}),
+			wantMaxRetries: 10,
+			wantDeadline:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := request.New(*awssdk.NewConfig(), metadata.ClientInfo{}, request.Handlers{},
+				client.DefaultRetryer{NumMaxRetries: 10}, &request.Operation{Name: "DescribeLoadBalancers"}, nil, nil)
+			r.SetContext(tt.ctx)
+			applyRequestConfig(r)
+			assert.Equal(t, tt.wantMaxRetries, r.MaxRetries())
+			_, gotDeadline := r.Context().Deadline()
+			assert.Equal(t, tt.wantDeadline, gotDeadline)
+			if tt.wantDeadline {
+				r.Handlers.Complete.Run(r)
+				assert.Error(t, r.Context().Err())
+			}
+		})
+	}
+}
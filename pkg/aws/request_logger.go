@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/go-logr/logr"
+)
+
+// injectRequestLogger will inject handler that logs AWS API calls along with AWS request IDs into awsSDK.
+// API calls are logged at debug level with the logger carried by request context, which is annotated by controllers
+// with reconcile correlation values, so that API calls made during a reconcile can be traced back to the reconciled object.
+func injectRequestLogger(handlers *request.Handlers) {
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: fmt.Sprintf("%s/request-logger", appName),
+		Fn:   logRequest,
+	})
+}
+
+func logRequest(r *request.Request) {
+	logger := logr.FromContext(r.Context())
+	if logger == nil {
+		return
+	}
+	keysAndValues := []interface{}{
+		"service", r.ClientInfo.ServiceID,
+		"operation", r.Operation.Name,
+		"requestID", r.RequestID,
+		"retryCount", r.RetryCount,
+		"duration", time.Since(r.Time).String(),
+	}
+	if r.Error != nil {
+		keysAndValues = append(keysAndValues, "error", r.Error.Error())
+	}
+	logger.V(1).Info("AWS API call", keysAndValues...)
+}
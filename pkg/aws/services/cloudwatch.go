@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+type CloudWatch interface {
+	cloudwatchiface.CloudWatchAPI
+}
+
+// NewCloudWatch constructs new CloudWatch implementation.
+func NewCloudWatch(session *session.Session) CloudWatch {
+	return &defaultCloudWatch{
+		CloudWatchAPI: cloudwatch.New(session),
+	}
+}
+
+// default implementation for CloudWatch.
+type defaultCloudWatch struct {
+	cloudwatchiface.CloudWatchAPI
+}
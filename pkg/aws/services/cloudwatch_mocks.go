@@ -0,0 +1,2134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services (interfaces: CloudWatch)
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request "github.com/aws/aws-sdk-go/aws/request"
+	cloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCloudWatch is a mock of CloudWatch interface.
+type MockCloudWatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudWatchMockRecorder
+}
+
+// MockCloudWatchMockRecorder is the mock recorder for MockCloudWatch.
+type MockCloudWatchMockRecorder struct {
+	mock *MockCloudWatch
+}
+
+// NewMockCloudWatch creates a new mock instance.
+func NewMockCloudWatch(ctrl *gomock.Controller) *MockCloudWatch {
+	mock := &MockCloudWatch{ctrl: ctrl}
+	mock.recorder = &MockCloudWatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudWatch) EXPECT() *MockCloudWatchMockRecorder {
+	return m.recorder
+}
+
+// DeleteAlarms mocks base method.
+func (m *MockCloudWatch) DeleteAlarms(arg0 *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarms", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DeleteAlarmsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAlarms indicates an expected call of DeleteAlarms.
+func (mr *MockCloudWatchMockRecorder) DeleteAlarms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarms", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAlarms), arg0)
+}
+
+// DeleteAlarmsRequest mocks base method.
+func (m *MockCloudWatch) DeleteAlarmsRequest(arg0 *cloudwatch.DeleteAlarmsInput) (*request.Request, *cloudwatch.DeleteAlarmsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlarmsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DeleteAlarmsOutput)
+	return ret0, ret1
+}
+
+// DeleteAlarmsRequest indicates an expected call of DeleteAlarmsRequest.
+func (mr *MockCloudWatchMockRecorder) DeleteAlarmsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmsRequest", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAlarmsRequest), arg0)
+}
+
+// DeleteAlarmsWithContext mocks base method.
+func (m *MockCloudWatch) DeleteAlarmsWithContext(arg0 context.Context, arg1 *cloudwatch.DeleteAlarmsInput, arg2 ...request.Option) (*cloudwatch.DeleteAlarmsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAlarmsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DeleteAlarmsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAlarmsWithContext indicates an expected call of DeleteAlarmsWithContext.
+func (mr *MockCloudWatchMockRecorder) DeleteAlarmsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlarmsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAlarmsWithContext), varargs...)
+}
+
+// DeleteAnomalyDetector mocks base method.
+func (m *MockCloudWatch) DeleteAnomalyDetector(arg0 *cloudwatch.DeleteAnomalyDetectorInput) (*cloudwatch.DeleteAnomalyDetectorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnomalyDetector", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DeleteAnomalyDetectorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAnomalyDetector indicates an expected call of DeleteAnomalyDetector.
+func (mr *MockCloudWatchMockRecorder) DeleteAnomalyDetector(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnomalyDetector", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAnomalyDetector), arg0)
+}
+
+// DeleteAnomalyDetectorRequest mocks base method.
+func (m *MockCloudWatch) DeleteAnomalyDetectorRequest(arg0 *cloudwatch.DeleteAnomalyDetectorInput) (*request.Request, *cloudwatch.DeleteAnomalyDetectorOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAnomalyDetectorRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DeleteAnomalyDetectorOutput)
+	return ret0, ret1
+}
+
+// DeleteAnomalyDetectorRequest indicates an expected call of DeleteAnomalyDetectorRequest.
+func (mr *MockCloudWatchMockRecorder) DeleteAnomalyDetectorRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnomalyDetectorRequest", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAnomalyDetectorRequest), arg0)
+}
+
+// DeleteAnomalyDetectorWithContext mocks base method.
+func (m *MockCloudWatch) DeleteAnomalyDetectorWithContext(arg0 context.Context, arg1 *cloudwatch.DeleteAnomalyDetectorInput, arg2 ...request.Option) (*cloudwatch.DeleteAnomalyDetectorOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAnomalyDetectorWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DeleteAnomalyDetectorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAnomalyDetectorWithContext indicates an expected call of DeleteAnomalyDetectorWithContext.
+func (mr *MockCloudWatchMockRecorder) DeleteAnomalyDetectorWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAnomalyDetectorWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DeleteAnomalyDetectorWithContext), varargs...)
+}
+
+// DeleteDashboards mocks base method.
+func (m *MockCloudWatch) DeleteDashboards(arg0 *cloudwatch.DeleteDashboardsInput) (*cloudwatch.DeleteDashboardsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDashboards", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DeleteDashboardsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDashboards indicates an expected call of DeleteDashboards.
+func (mr *MockCloudWatchMockRecorder) DeleteDashboards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboards", reflect.TypeOf((*MockCloudWatch)(nil).DeleteDashboards), arg0)
+}
+
+// DeleteDashboardsRequest mocks base method.
+func (m *MockCloudWatch) DeleteDashboardsRequest(arg0 *cloudwatch.DeleteDashboardsInput) (*request.Request, *cloudwatch.DeleteDashboardsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDashboardsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DeleteDashboardsOutput)
+	return ret0, ret1
+}
+
+// DeleteDashboardsRequest indicates an expected call of DeleteDashboardsRequest.
+func (mr *MockCloudWatchMockRecorder) DeleteDashboardsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboardsRequest", reflect.TypeOf((*MockCloudWatch)(nil).DeleteDashboardsRequest), arg0)
+}
+
+// DeleteDashboardsWithContext mocks base method.
+func (m *MockCloudWatch) DeleteDashboardsWithContext(arg0 context.Context, arg1 *cloudwatch.DeleteDashboardsInput, arg2 ...request.Option) (*cloudwatch.DeleteDashboardsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteDashboardsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DeleteDashboardsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDashboardsWithContext indicates an expected call of DeleteDashboardsWithContext.
+func (mr *MockCloudWatchMockRecorder) DeleteDashboardsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboardsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DeleteDashboardsWithContext), varargs...)
+}
+
+// DeleteInsightRules mocks base method.
+func (m *MockCloudWatch) DeleteInsightRules(arg0 *cloudwatch.DeleteInsightRulesInput) (*cloudwatch.DeleteInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInsightRules", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DeleteInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInsightRules indicates an expected call of DeleteInsightRules.
+func (mr *MockCloudWatchMockRecorder) DeleteInsightRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInsightRules", reflect.TypeOf((*MockCloudWatch)(nil).DeleteInsightRules), arg0)
+}
+
+// DeleteInsightRulesRequest mocks base method.
+func (m *MockCloudWatch) DeleteInsightRulesRequest(arg0 *cloudwatch.DeleteInsightRulesInput) (*request.Request, *cloudwatch.DeleteInsightRulesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInsightRulesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DeleteInsightRulesOutput)
+	return ret0, ret1
+}
+
+// DeleteInsightRulesRequest indicates an expected call of DeleteInsightRulesRequest.
+func (mr *MockCloudWatchMockRecorder) DeleteInsightRulesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInsightRulesRequest", reflect.TypeOf((*MockCloudWatch)(nil).DeleteInsightRulesRequest), arg0)
+}
+
+// DeleteInsightRulesWithContext mocks base method.
+func (m *MockCloudWatch) DeleteInsightRulesWithContext(arg0 context.Context, arg1 *cloudwatch.DeleteInsightRulesInput, arg2 ...request.Option) (*cloudwatch.DeleteInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteInsightRulesWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DeleteInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInsightRulesWithContext indicates an expected call of DeleteInsightRulesWithContext.
+func (mr *MockCloudWatchMockRecorder) DeleteInsightRulesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInsightRulesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DeleteInsightRulesWithContext), varargs...)
+}
+
+// DeleteMetricStream mocks base method.
+func (m *MockCloudWatch) DeleteMetricStream(arg0 *cloudwatch.DeleteMetricStreamInput) (*cloudwatch.DeleteMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMetricStream", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DeleteMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMetricStream indicates an expected call of DeleteMetricStream.
+func (mr *MockCloudWatchMockRecorder) DeleteMetricStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricStream", reflect.TypeOf((*MockCloudWatch)(nil).DeleteMetricStream), arg0)
+}
+
+// DeleteMetricStreamRequest mocks base method.
+func (m *MockCloudWatch) DeleteMetricStreamRequest(arg0 *cloudwatch.DeleteMetricStreamInput) (*request.Request, *cloudwatch.DeleteMetricStreamOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMetricStreamRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DeleteMetricStreamOutput)
+	return ret0, ret1
+}
+
+// DeleteMetricStreamRequest indicates an expected call of DeleteMetricStreamRequest.
+func (mr *MockCloudWatchMockRecorder) DeleteMetricStreamRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricStreamRequest", reflect.TypeOf((*MockCloudWatch)(nil).DeleteMetricStreamRequest), arg0)
+}
+
+// DeleteMetricStreamWithContext mocks base method.
+func (m *MockCloudWatch) DeleteMetricStreamWithContext(arg0 context.Context, arg1 *cloudwatch.DeleteMetricStreamInput, arg2 ...request.Option) (*cloudwatch.DeleteMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMetricStreamWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DeleteMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMetricStreamWithContext indicates an expected call of DeleteMetricStreamWithContext.
+func (mr *MockCloudWatchMockRecorder) DeleteMetricStreamWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMetricStreamWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DeleteMetricStreamWithContext), varargs...)
+}
+
+// DescribeAlarmHistory mocks base method.
+func (m *MockCloudWatch) DescribeAlarmHistory(arg0 *cloudwatch.DescribeAlarmHistoryInput) (*cloudwatch.DescribeAlarmHistoryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmHistory", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmHistoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarmHistory indicates an expected call of DescribeAlarmHistory.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmHistory", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmHistory), arg0)
+}
+
+// DescribeAlarmHistoryPages mocks base method.
+func (m *MockCloudWatch) DescribeAlarmHistoryPages(arg0 *cloudwatch.DescribeAlarmHistoryInput, arg1 func(*cloudwatch.DescribeAlarmHistoryOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmHistoryPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeAlarmHistoryPages indicates an expected call of DescribeAlarmHistoryPages.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmHistoryPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmHistoryPages", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmHistoryPages), arg0, arg1)
+}
+
+// DescribeAlarmHistoryPagesWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAlarmHistoryPagesWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmHistoryInput, arg2 func(*cloudwatch.DescribeAlarmHistoryOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAlarmHistoryPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeAlarmHistoryPagesWithContext indicates an expected call of DescribeAlarmHistoryPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmHistoryPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmHistoryPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmHistoryPagesWithContext), varargs...)
+}
+
+// DescribeAlarmHistoryRequest mocks base method.
+func (m *MockCloudWatch) DescribeAlarmHistoryRequest(arg0 *cloudwatch.DescribeAlarmHistoryInput) (*request.Request, *cloudwatch.DescribeAlarmHistoryOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmHistoryRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DescribeAlarmHistoryOutput)
+	return ret0, ret1
+}
+
+// DescribeAlarmHistoryRequest indicates an expected call of DescribeAlarmHistoryRequest.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmHistoryRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmHistoryRequest", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmHistoryRequest), arg0)
+}
+
+// DescribeAlarmHistoryWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAlarmHistoryWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmHistoryInput, arg2 ...request.Option) (*cloudwatch.DescribeAlarmHistoryOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAlarmHistoryWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmHistoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarmHistoryWithContext indicates an expected call of DescribeAlarmHistoryWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmHistoryWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmHistoryWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmHistoryWithContext), varargs...)
+}
+
+// DescribeAlarms mocks base method.
+func (m *MockCloudWatch) DescribeAlarms(arg0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarms", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarms indicates an expected call of DescribeAlarms.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarms", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarms), arg0)
+}
+
+// DescribeAlarmsForMetric mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsForMetric(arg0 *cloudwatch.DescribeAlarmsForMetricInput) (*cloudwatch.DescribeAlarmsForMetricOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmsForMetric", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmsForMetricOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarmsForMetric indicates an expected call of DescribeAlarmsForMetric.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsForMetric(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsForMetric", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsForMetric), arg0)
+}
+
+// DescribeAlarmsForMetricRequest mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsForMetricRequest(arg0 *cloudwatch.DescribeAlarmsForMetricInput) (*request.Request, *cloudwatch.DescribeAlarmsForMetricOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmsForMetricRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DescribeAlarmsForMetricOutput)
+	return ret0, ret1
+}
+
+// DescribeAlarmsForMetricRequest indicates an expected call of DescribeAlarmsForMetricRequest.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsForMetricRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsForMetricRequest", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsForMetricRequest), arg0)
+}
+
+// DescribeAlarmsForMetricWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsForMetricWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmsForMetricInput, arg2 ...request.Option) (*cloudwatch.DescribeAlarmsForMetricOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAlarmsForMetricWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmsForMetricOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarmsForMetricWithContext indicates an expected call of DescribeAlarmsForMetricWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsForMetricWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsForMetricWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsForMetricWithContext), varargs...)
+}
+
+// DescribeAlarmsPages mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsPages(arg0 *cloudwatch.DescribeAlarmsInput, arg1 func(*cloudwatch.DescribeAlarmsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeAlarmsPages indicates an expected call of DescribeAlarmsPages.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsPages", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsPages), arg0, arg1)
+}
+
+// DescribeAlarmsPagesWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsPagesWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmsInput, arg2 func(*cloudwatch.DescribeAlarmsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAlarmsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeAlarmsPagesWithContext indicates an expected call of DescribeAlarmsPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsPagesWithContext), varargs...)
+}
+
+// DescribeAlarmsRequest mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsRequest(arg0 *cloudwatch.DescribeAlarmsInput) (*request.Request, *cloudwatch.DescribeAlarmsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAlarmsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DescribeAlarmsOutput)
+	return ret0, ret1
+}
+
+// DescribeAlarmsRequest indicates an expected call of DescribeAlarmsRequest.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsRequest", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsRequest), arg0)
+}
+
+// DescribeAlarmsWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAlarmsWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmsInput, arg2 ...request.Option) (*cloudwatch.DescribeAlarmsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAlarmsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAlarmsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAlarmsWithContext indicates an expected call of DescribeAlarmsWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAlarmsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAlarmsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAlarmsWithContext), varargs...)
+}
+
+// DescribeAnomalyDetectors mocks base method.
+func (m *MockCloudWatch) DescribeAnomalyDetectors(arg0 *cloudwatch.DescribeAnomalyDetectorsInput) (*cloudwatch.DescribeAnomalyDetectorsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAnomalyDetectors", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAnomalyDetectorsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAnomalyDetectors indicates an expected call of DescribeAnomalyDetectors.
+func (mr *MockCloudWatchMockRecorder) DescribeAnomalyDetectors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAnomalyDetectors", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAnomalyDetectors), arg0)
+}
+
+// DescribeAnomalyDetectorsRequest mocks base method.
+func (m *MockCloudWatch) DescribeAnomalyDetectorsRequest(arg0 *cloudwatch.DescribeAnomalyDetectorsInput) (*request.Request, *cloudwatch.DescribeAnomalyDetectorsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeAnomalyDetectorsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DescribeAnomalyDetectorsOutput)
+	return ret0, ret1
+}
+
+// DescribeAnomalyDetectorsRequest indicates an expected call of DescribeAnomalyDetectorsRequest.
+func (mr *MockCloudWatchMockRecorder) DescribeAnomalyDetectorsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAnomalyDetectorsRequest", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAnomalyDetectorsRequest), arg0)
+}
+
+// DescribeAnomalyDetectorsWithContext mocks base method.
+func (m *MockCloudWatch) DescribeAnomalyDetectorsWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAnomalyDetectorsInput, arg2 ...request.Option) (*cloudwatch.DescribeAnomalyDetectorsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeAnomalyDetectorsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DescribeAnomalyDetectorsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeAnomalyDetectorsWithContext indicates an expected call of DescribeAnomalyDetectorsWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeAnomalyDetectorsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAnomalyDetectorsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeAnomalyDetectorsWithContext), varargs...)
+}
+
+// DescribeInsightRules mocks base method.
+func (m *MockCloudWatch) DescribeInsightRules(arg0 *cloudwatch.DescribeInsightRulesInput) (*cloudwatch.DescribeInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInsightRules", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DescribeInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInsightRules indicates an expected call of DescribeInsightRules.
+func (mr *MockCloudWatchMockRecorder) DescribeInsightRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInsightRules", reflect.TypeOf((*MockCloudWatch)(nil).DescribeInsightRules), arg0)
+}
+
+// DescribeInsightRulesPages mocks base method.
+func (m *MockCloudWatch) DescribeInsightRulesPages(arg0 *cloudwatch.DescribeInsightRulesInput, arg1 func(*cloudwatch.DescribeInsightRulesOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInsightRulesPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeInsightRulesPages indicates an expected call of DescribeInsightRulesPages.
+func (mr *MockCloudWatchMockRecorder) DescribeInsightRulesPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInsightRulesPages", reflect.TypeOf((*MockCloudWatch)(nil).DescribeInsightRulesPages), arg0, arg1)
+}
+
+// DescribeInsightRulesPagesWithContext mocks base method.
+func (m *MockCloudWatch) DescribeInsightRulesPagesWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeInsightRulesInput, arg2 func(*cloudwatch.DescribeInsightRulesOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeInsightRulesPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DescribeInsightRulesPagesWithContext indicates an expected call of DescribeInsightRulesPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeInsightRulesPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInsightRulesPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeInsightRulesPagesWithContext), varargs...)
+}
+
+// DescribeInsightRulesRequest mocks base method.
+func (m *MockCloudWatch) DescribeInsightRulesRequest(arg0 *cloudwatch.DescribeInsightRulesInput) (*request.Request, *cloudwatch.DescribeInsightRulesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInsightRulesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DescribeInsightRulesOutput)
+	return ret0, ret1
+}
+
+// DescribeInsightRulesRequest indicates an expected call of DescribeInsightRulesRequest.
+func (mr *MockCloudWatchMockRecorder) DescribeInsightRulesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInsightRulesRequest", reflect.TypeOf((*MockCloudWatch)(nil).DescribeInsightRulesRequest), arg0)
+}
+
+// DescribeInsightRulesWithContext mocks base method.
+func (m *MockCloudWatch) DescribeInsightRulesWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeInsightRulesInput, arg2 ...request.Option) (*cloudwatch.DescribeInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeInsightRulesWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DescribeInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInsightRulesWithContext indicates an expected call of DescribeInsightRulesWithContext.
+func (mr *MockCloudWatchMockRecorder) DescribeInsightRulesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInsightRulesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DescribeInsightRulesWithContext), varargs...)
+}
+
+// DisableAlarmActions mocks base method.
+func (m *MockCloudWatch) DisableAlarmActions(arg0 *cloudwatch.DisableAlarmActionsInput) (*cloudwatch.DisableAlarmActionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableAlarmActions", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DisableAlarmActionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableAlarmActions indicates an expected call of DisableAlarmActions.
+func (mr *MockCloudWatchMockRecorder) DisableAlarmActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAlarmActions", reflect.TypeOf((*MockCloudWatch)(nil).DisableAlarmActions), arg0)
+}
+
+// DisableAlarmActionsRequest mocks base method.
+func (m *MockCloudWatch) DisableAlarmActionsRequest(arg0 *cloudwatch.DisableAlarmActionsInput) (*request.Request, *cloudwatch.DisableAlarmActionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableAlarmActionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DisableAlarmActionsOutput)
+	return ret0, ret1
+}
+
+// DisableAlarmActionsRequest indicates an expected call of DisableAlarmActionsRequest.
+func (mr *MockCloudWatchMockRecorder) DisableAlarmActionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAlarmActionsRequest", reflect.TypeOf((*MockCloudWatch)(nil).DisableAlarmActionsRequest), arg0)
+}
+
+// DisableAlarmActionsWithContext mocks base method.
+func (m *MockCloudWatch) DisableAlarmActionsWithContext(arg0 context.Context, arg1 *cloudwatch.DisableAlarmActionsInput, arg2 ...request.Option) (*cloudwatch.DisableAlarmActionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableAlarmActionsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DisableAlarmActionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableAlarmActionsWithContext indicates an expected call of DisableAlarmActionsWithContext.
+func (mr *MockCloudWatchMockRecorder) DisableAlarmActionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAlarmActionsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DisableAlarmActionsWithContext), varargs...)
+}
+
+// DisableInsightRules mocks base method.
+func (m *MockCloudWatch) DisableInsightRules(arg0 *cloudwatch.DisableInsightRulesInput) (*cloudwatch.DisableInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableInsightRules", arg0)
+	ret0, _ := ret[0].(*cloudwatch.DisableInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableInsightRules indicates an expected call of DisableInsightRules.
+func (mr *MockCloudWatchMockRecorder) DisableInsightRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableInsightRules", reflect.TypeOf((*MockCloudWatch)(nil).DisableInsightRules), arg0)
+}
+
+// DisableInsightRulesRequest mocks base method.
+func (m *MockCloudWatch) DisableInsightRulesRequest(arg0 *cloudwatch.DisableInsightRulesInput) (*request.Request, *cloudwatch.DisableInsightRulesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableInsightRulesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.DisableInsightRulesOutput)
+	return ret0, ret1
+}
+
+// DisableInsightRulesRequest indicates an expected call of DisableInsightRulesRequest.
+func (mr *MockCloudWatchMockRecorder) DisableInsightRulesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableInsightRulesRequest", reflect.TypeOf((*MockCloudWatch)(nil).DisableInsightRulesRequest), arg0)
+}
+
+// DisableInsightRulesWithContext mocks base method.
+func (m *MockCloudWatch) DisableInsightRulesWithContext(arg0 context.Context, arg1 *cloudwatch.DisableInsightRulesInput, arg2 ...request.Option) (*cloudwatch.DisableInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableInsightRulesWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.DisableInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableInsightRulesWithContext indicates an expected call of DisableInsightRulesWithContext.
+func (mr *MockCloudWatchMockRecorder) DisableInsightRulesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableInsightRulesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).DisableInsightRulesWithContext), varargs...)
+}
+
+// EnableAlarmActions mocks base method.
+func (m *MockCloudWatch) EnableAlarmActions(arg0 *cloudwatch.EnableAlarmActionsInput) (*cloudwatch.EnableAlarmActionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAlarmActions", arg0)
+	ret0, _ := ret[0].(*cloudwatch.EnableAlarmActionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableAlarmActions indicates an expected call of EnableAlarmActions.
+func (mr *MockCloudWatchMockRecorder) EnableAlarmActions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAlarmActions", reflect.TypeOf((*MockCloudWatch)(nil).EnableAlarmActions), arg0)
+}
+
+// EnableAlarmActionsRequest mocks base method.
+func (m *MockCloudWatch) EnableAlarmActionsRequest(arg0 *cloudwatch.EnableAlarmActionsInput) (*request.Request, *cloudwatch.EnableAlarmActionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAlarmActionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.EnableAlarmActionsOutput)
+	return ret0, ret1
+}
+
+// EnableAlarmActionsRequest indicates an expected call of EnableAlarmActionsRequest.
+func (mr *MockCloudWatchMockRecorder) EnableAlarmActionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAlarmActionsRequest", reflect.TypeOf((*MockCloudWatch)(nil).EnableAlarmActionsRequest), arg0)
+}
+
+// EnableAlarmActionsWithContext mocks base method.
+func (m *MockCloudWatch) EnableAlarmActionsWithContext(arg0 context.Context, arg1 *cloudwatch.EnableAlarmActionsInput, arg2 ...request.Option) (*cloudwatch.EnableAlarmActionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableAlarmActionsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.EnableAlarmActionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableAlarmActionsWithContext indicates an expected call of EnableAlarmActionsWithContext.
+func (mr *MockCloudWatchMockRecorder) EnableAlarmActionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAlarmActionsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).EnableAlarmActionsWithContext), varargs...)
+}
+
+// EnableInsightRules mocks base method.
+func (m *MockCloudWatch) EnableInsightRules(arg0 *cloudwatch.EnableInsightRulesInput) (*cloudwatch.EnableInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableInsightRules", arg0)
+	ret0, _ := ret[0].(*cloudwatch.EnableInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableInsightRules indicates an expected call of EnableInsightRules.
+func (mr *MockCloudWatchMockRecorder) EnableInsightRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableInsightRules", reflect.TypeOf((*MockCloudWatch)(nil).EnableInsightRules), arg0)
+}
+
+// EnableInsightRulesRequest mocks base method.
+func (m *MockCloudWatch) EnableInsightRulesRequest(arg0 *cloudwatch.EnableInsightRulesInput) (*request.Request, *cloudwatch.EnableInsightRulesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableInsightRulesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.EnableInsightRulesOutput)
+	return ret0, ret1
+}
+
+// EnableInsightRulesRequest indicates an expected call of EnableInsightRulesRequest.
+func (mr *MockCloudWatchMockRecorder) EnableInsightRulesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableInsightRulesRequest", reflect.TypeOf((*MockCloudWatch)(nil).EnableInsightRulesRequest), arg0)
+}
+
+// EnableInsightRulesWithContext mocks base method.
+func (m *MockCloudWatch) EnableInsightRulesWithContext(arg0 context.Context, arg1 *cloudwatch.EnableInsightRulesInput, arg2 ...request.Option) (*cloudwatch.EnableInsightRulesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableInsightRulesWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.EnableInsightRulesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableInsightRulesWithContext indicates an expected call of EnableInsightRulesWithContext.
+func (mr *MockCloudWatchMockRecorder) EnableInsightRulesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableInsightRulesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).EnableInsightRulesWithContext), varargs...)
+}
+
+// GetDashboard mocks base method.
+func (m *MockCloudWatch) GetDashboard(arg0 *cloudwatch.GetDashboardInput) (*cloudwatch.GetDashboardOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboard", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetDashboardOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboard indicates an expected call of GetDashboard.
+func (mr *MockCloudWatchMockRecorder) GetDashboard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboard", reflect.TypeOf((*MockCloudWatch)(nil).GetDashboard), arg0)
+}
+
+// GetDashboardRequest mocks base method.
+func (m *MockCloudWatch) GetDashboardRequest(arg0 *cloudwatch.GetDashboardInput) (*request.Request, *cloudwatch.GetDashboardOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboardRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetDashboardOutput)
+	return ret0, ret1
+}
+
+// GetDashboardRequest indicates an expected call of GetDashboardRequest.
+func (mr *MockCloudWatchMockRecorder) GetDashboardRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboardRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetDashboardRequest), arg0)
+}
+
+// GetDashboardWithContext mocks base method.
+func (m *MockCloudWatch) GetDashboardWithContext(arg0 context.Context, arg1 *cloudwatch.GetDashboardInput, arg2 ...request.Option) (*cloudwatch.GetDashboardOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDashboardWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetDashboardOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboardWithContext indicates an expected call of GetDashboardWithContext.
+func (mr *MockCloudWatchMockRecorder) GetDashboardWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboardWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetDashboardWithContext), varargs...)
+}
+
+// GetInsightRuleReport mocks base method.
+func (m *MockCloudWatch) GetInsightRuleReport(arg0 *cloudwatch.GetInsightRuleReportInput) (*cloudwatch.GetInsightRuleReportOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInsightRuleReport", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetInsightRuleReportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInsightRuleReport indicates an expected call of GetInsightRuleReport.
+func (mr *MockCloudWatchMockRecorder) GetInsightRuleReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInsightRuleReport", reflect.TypeOf((*MockCloudWatch)(nil).GetInsightRuleReport), arg0)
+}
+
+// GetInsightRuleReportRequest mocks base method.
+func (m *MockCloudWatch) GetInsightRuleReportRequest(arg0 *cloudwatch.GetInsightRuleReportInput) (*request.Request, *cloudwatch.GetInsightRuleReportOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInsightRuleReportRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetInsightRuleReportOutput)
+	return ret0, ret1
+}
+
+// GetInsightRuleReportRequest indicates an expected call of GetInsightRuleReportRequest.
+func (mr *MockCloudWatchMockRecorder) GetInsightRuleReportRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInsightRuleReportRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetInsightRuleReportRequest), arg0)
+}
+
+// GetInsightRuleReportWithContext mocks base method.
+func (m *MockCloudWatch) GetInsightRuleReportWithContext(arg0 context.Context, arg1 *cloudwatch.GetInsightRuleReportInput, arg2 ...request.Option) (*cloudwatch.GetInsightRuleReportOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetInsightRuleReportWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetInsightRuleReportOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInsightRuleReportWithContext indicates an expected call of GetInsightRuleReportWithContext.
+func (mr *MockCloudWatchMockRecorder) GetInsightRuleReportWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInsightRuleReportWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetInsightRuleReportWithContext), varargs...)
+}
+
+// GetMetricData mocks base method.
+func (m *MockCloudWatch) GetMetricData(arg0 *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricData", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricDataOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricData indicates an expected call of GetMetricData.
+func (mr *MockCloudWatchMockRecorder) GetMetricData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricData", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricData), arg0)
+}
+
+// GetMetricDataPages mocks base method.
+func (m *MockCloudWatch) GetMetricDataPages(arg0 *cloudwatch.GetMetricDataInput, arg1 func(*cloudwatch.GetMetricDataOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricDataPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMetricDataPages indicates an expected call of GetMetricDataPages.
+func (mr *MockCloudWatchMockRecorder) GetMetricDataPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricDataPages", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricDataPages), arg0, arg1)
+}
+
+// GetMetricDataPagesWithContext mocks base method.
+func (m *MockCloudWatch) GetMetricDataPagesWithContext(arg0 context.Context, arg1 *cloudwatch.GetMetricDataInput, arg2 func(*cloudwatch.GetMetricDataOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMetricDataPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMetricDataPagesWithContext indicates an expected call of GetMetricDataPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) GetMetricDataPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricDataPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricDataPagesWithContext), varargs...)
+}
+
+// GetMetricDataRequest mocks base method.
+func (m *MockCloudWatch) GetMetricDataRequest(arg0 *cloudwatch.GetMetricDataInput) (*request.Request, *cloudwatch.GetMetricDataOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricDataRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetMetricDataOutput)
+	return ret0, ret1
+}
+
+// GetMetricDataRequest indicates an expected call of GetMetricDataRequest.
+func (mr *MockCloudWatchMockRecorder) GetMetricDataRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricDataRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricDataRequest), arg0)
+}
+
+// GetMetricDataWithContext mocks base method.
+func (m *MockCloudWatch) GetMetricDataWithContext(arg0 context.Context, arg1 *cloudwatch.GetMetricDataInput, arg2 ...request.Option) (*cloudwatch.GetMetricDataOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMetricDataWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricDataOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricDataWithContext indicates an expected call of GetMetricDataWithContext.
+func (mr *MockCloudWatchMockRecorder) GetMetricDataWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricDataWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricDataWithContext), varargs...)
+}
+
+// GetMetricStatistics mocks base method.
+func (m *MockCloudWatch) GetMetricStatistics(arg0 *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricStatistics", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricStatisticsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricStatistics indicates an expected call of GetMetricStatistics.
+func (mr *MockCloudWatchMockRecorder) GetMetricStatistics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStatistics", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStatistics), arg0)
+}
+
+// GetMetricStatisticsRequest mocks base method.
+func (m *MockCloudWatch) GetMetricStatisticsRequest(arg0 *cloudwatch.GetMetricStatisticsInput) (*request.Request, *cloudwatch.GetMetricStatisticsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricStatisticsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetMetricStatisticsOutput)
+	return ret0, ret1
+}
+
+// GetMetricStatisticsRequest indicates an expected call of GetMetricStatisticsRequest.
+func (mr *MockCloudWatchMockRecorder) GetMetricStatisticsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStatisticsRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStatisticsRequest), arg0)
+}
+
+// GetMetricStatisticsWithContext mocks base method.
+func (m *MockCloudWatch) GetMetricStatisticsWithContext(arg0 context.Context, arg1 *cloudwatch.GetMetricStatisticsInput, arg2 ...request.Option) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMetricStatisticsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricStatisticsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricStatisticsWithContext indicates an expected call of GetMetricStatisticsWithContext.
+func (mr *MockCloudWatchMockRecorder) GetMetricStatisticsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStatisticsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStatisticsWithContext), varargs...)
+}
+
+// GetMetricStream mocks base method.
+func (m *MockCloudWatch) GetMetricStream(arg0 *cloudwatch.GetMetricStreamInput) (*cloudwatch.GetMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricStream", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricStream indicates an expected call of GetMetricStream.
+func (mr *MockCloudWatchMockRecorder) GetMetricStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStream", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStream), arg0)
+}
+
+// GetMetricStreamRequest mocks base method.
+func (m *MockCloudWatch) GetMetricStreamRequest(arg0 *cloudwatch.GetMetricStreamInput) (*request.Request, *cloudwatch.GetMetricStreamOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricStreamRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetMetricStreamOutput)
+	return ret0, ret1
+}
+
+// GetMetricStreamRequest indicates an expected call of GetMetricStreamRequest.
+func (mr *MockCloudWatchMockRecorder) GetMetricStreamRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStreamRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStreamRequest), arg0)
+}
+
+// GetMetricStreamWithContext mocks base method.
+func (m *MockCloudWatch) GetMetricStreamWithContext(arg0 context.Context, arg1 *cloudwatch.GetMetricStreamInput, arg2 ...request.Option) (*cloudwatch.GetMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMetricStreamWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricStreamWithContext indicates an expected call of GetMetricStreamWithContext.
+func (mr *MockCloudWatchMockRecorder) GetMetricStreamWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricStreamWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricStreamWithContext), varargs...)
+}
+
+// GetMetricWidgetImage mocks base method.
+func (m *MockCloudWatch) GetMetricWidgetImage(arg0 *cloudwatch.GetMetricWidgetImageInput) (*cloudwatch.GetMetricWidgetImageOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricWidgetImage", arg0)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricWidgetImageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricWidgetImage indicates an expected call of GetMetricWidgetImage.
+func (mr *MockCloudWatchMockRecorder) GetMetricWidgetImage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricWidgetImage", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricWidgetImage), arg0)
+}
+
+// GetMetricWidgetImageRequest mocks base method.
+func (m *MockCloudWatch) GetMetricWidgetImageRequest(arg0 *cloudwatch.GetMetricWidgetImageInput) (*request.Request, *cloudwatch.GetMetricWidgetImageOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricWidgetImageRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.GetMetricWidgetImageOutput)
+	return ret0, ret1
+}
+
+// GetMetricWidgetImageRequest indicates an expected call of GetMetricWidgetImageRequest.
+func (mr *MockCloudWatchMockRecorder) GetMetricWidgetImageRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricWidgetImageRequest", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricWidgetImageRequest), arg0)
+}
+
+// GetMetricWidgetImageWithContext mocks base method.
+func (m *MockCloudWatch) GetMetricWidgetImageWithContext(arg0 context.Context, arg1 *cloudwatch.GetMetricWidgetImageInput, arg2 ...request.Option) (*cloudwatch.GetMetricWidgetImageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetMetricWidgetImageWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.GetMetricWidgetImageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetricWidgetImageWithContext indicates an expected call of GetMetricWidgetImageWithContext.
+func (mr *MockCloudWatchMockRecorder) GetMetricWidgetImageWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricWidgetImageWithContext", reflect.TypeOf((*MockCloudWatch)(nil).GetMetricWidgetImageWithContext), varargs...)
+}
+
+// ListDashboards mocks base method.
+func (m *MockCloudWatch) ListDashboards(arg0 *cloudwatch.ListDashboardsInput) (*cloudwatch.ListDashboardsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDashboards", arg0)
+	ret0, _ := ret[0].(*cloudwatch.ListDashboardsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDashboards indicates an expected call of ListDashboards.
+func (mr *MockCloudWatchMockRecorder) ListDashboards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboards", reflect.TypeOf((*MockCloudWatch)(nil).ListDashboards), arg0)
+}
+
+// ListDashboardsPages mocks base method.
+func (m *MockCloudWatch) ListDashboardsPages(arg0 *cloudwatch.ListDashboardsInput, arg1 func(*cloudwatch.ListDashboardsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDashboardsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListDashboardsPages indicates an expected call of ListDashboardsPages.
+func (mr *MockCloudWatchMockRecorder) ListDashboardsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboardsPages", reflect.TypeOf((*MockCloudWatch)(nil).ListDashboardsPages), arg0, arg1)
+}
+
+// ListDashboardsPagesWithContext mocks base method.
+func (m *MockCloudWatch) ListDashboardsPagesWithContext(arg0 context.Context, arg1 *cloudwatch.ListDashboardsInput, arg2 func(*cloudwatch.ListDashboardsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDashboardsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListDashboardsPagesWithContext indicates an expected call of ListDashboardsPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) ListDashboardsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboardsPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListDashboardsPagesWithContext), varargs...)
+}
+
+// ListDashboardsRequest mocks base method.
+func (m *MockCloudWatch) ListDashboardsRequest(arg0 *cloudwatch.ListDashboardsInput) (*request.Request, *cloudwatch.ListDashboardsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDashboardsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.ListDashboardsOutput)
+	return ret0, ret1
+}
+
+// ListDashboardsRequest indicates an expected call of ListDashboardsRequest.
+func (mr *MockCloudWatchMockRecorder) ListDashboardsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboardsRequest", reflect.TypeOf((*MockCloudWatch)(nil).ListDashboardsRequest), arg0)
+}
+
+// ListDashboardsWithContext mocks base method.
+func (m *MockCloudWatch) ListDashboardsWithContext(arg0 context.Context, arg1 *cloudwatch.ListDashboardsInput, arg2 ...request.Option) (*cloudwatch.ListDashboardsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDashboardsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.ListDashboardsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDashboardsWithContext indicates an expected call of ListDashboardsWithContext.
+func (mr *MockCloudWatchMockRecorder) ListDashboardsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboardsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListDashboardsWithContext), varargs...)
+}
+
+// ListMetricStreams mocks base method.
+func (m *MockCloudWatch) ListMetricStreams(arg0 *cloudwatch.ListMetricStreamsInput) (*cloudwatch.ListMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricStreams", arg0)
+	ret0, _ := ret[0].(*cloudwatch.ListMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricStreams indicates an expected call of ListMetricStreams.
+func (mr *MockCloudWatchMockRecorder) ListMetricStreams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricStreams", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricStreams), arg0)
+}
+
+// ListMetricStreamsPages mocks base method.
+func (m *MockCloudWatch) ListMetricStreamsPages(arg0 *cloudwatch.ListMetricStreamsInput, arg1 func(*cloudwatch.ListMetricStreamsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricStreamsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListMetricStreamsPages indicates an expected call of ListMetricStreamsPages.
+func (mr *MockCloudWatchMockRecorder) ListMetricStreamsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricStreamsPages", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricStreamsPages), arg0, arg1)
+}
+
+// ListMetricStreamsPagesWithContext mocks base method.
+func (m *MockCloudWatch) ListMetricStreamsPagesWithContext(arg0 context.Context, arg1 *cloudwatch.ListMetricStreamsInput, arg2 func(*cloudwatch.ListMetricStreamsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMetricStreamsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListMetricStreamsPagesWithContext indicates an expected call of ListMetricStreamsPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) ListMetricStreamsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricStreamsPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricStreamsPagesWithContext), varargs...)
+}
+
+// ListMetricStreamsRequest mocks base method.
+func (m *MockCloudWatch) ListMetricStreamsRequest(arg0 *cloudwatch.ListMetricStreamsInput) (*request.Request, *cloudwatch.ListMetricStreamsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricStreamsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.ListMetricStreamsOutput)
+	return ret0, ret1
+}
+
+// ListMetricStreamsRequest indicates an expected call of ListMetricStreamsRequest.
+func (mr *MockCloudWatchMockRecorder) ListMetricStreamsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricStreamsRequest", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricStreamsRequest), arg0)
+}
+
+// ListMetricStreamsWithContext mocks base method.
+func (m *MockCloudWatch) ListMetricStreamsWithContext(arg0 context.Context, arg1 *cloudwatch.ListMetricStreamsInput, arg2 ...request.Option) (*cloudwatch.ListMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMetricStreamsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.ListMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricStreamsWithContext indicates an expected call of ListMetricStreamsWithContext.
+func (mr *MockCloudWatchMockRecorder) ListMetricStreamsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricStreamsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricStreamsWithContext), varargs...)
+}
+
+// ListMetrics mocks base method.
+func (m *MockCloudWatch) ListMetrics(arg0 *cloudwatch.ListMetricsInput) (*cloudwatch.ListMetricsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetrics", arg0)
+	ret0, _ := ret[0].(*cloudwatch.ListMetricsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetrics indicates an expected call of ListMetrics.
+func (mr *MockCloudWatchMockRecorder) ListMetrics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetrics", reflect.TypeOf((*MockCloudWatch)(nil).ListMetrics), arg0)
+}
+
+// ListMetricsPages mocks base method.
+func (m *MockCloudWatch) ListMetricsPages(arg0 *cloudwatch.ListMetricsInput, arg1 func(*cloudwatch.ListMetricsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListMetricsPages indicates an expected call of ListMetricsPages.
+func (mr *MockCloudWatchMockRecorder) ListMetricsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsPages", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricsPages), arg0, arg1)
+}
+
+// ListMetricsPagesWithContext mocks base method.
+func (m *MockCloudWatch) ListMetricsPagesWithContext(arg0 context.Context, arg1 *cloudwatch.ListMetricsInput, arg2 func(*cloudwatch.ListMetricsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMetricsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListMetricsPagesWithContext indicates an expected call of ListMetricsPagesWithContext.
+func (mr *MockCloudWatchMockRecorder) ListMetricsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsPagesWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricsPagesWithContext), varargs...)
+}
+
+// ListMetricsRequest mocks base method.
+func (m *MockCloudWatch) ListMetricsRequest(arg0 *cloudwatch.ListMetricsInput) (*request.Request, *cloudwatch.ListMetricsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMetricsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.ListMetricsOutput)
+	return ret0, ret1
+}
+
+// ListMetricsRequest indicates an expected call of ListMetricsRequest.
+func (mr *MockCloudWatchMockRecorder) ListMetricsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsRequest", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricsRequest), arg0)
+}
+
+// ListMetricsWithContext mocks base method.
+func (m *MockCloudWatch) ListMetricsWithContext(arg0 context.Context, arg1 *cloudwatch.ListMetricsInput, arg2 ...request.Option) (*cloudwatch.ListMetricsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListMetricsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.ListMetricsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMetricsWithContext indicates an expected call of ListMetricsWithContext.
+func (mr *MockCloudWatchMockRecorder) ListMetricsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMetricsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListMetricsWithContext), varargs...)
+}
+
+// ListTagsForResource mocks base method.
+func (m *MockCloudWatch) ListTagsForResource(arg0 *cloudwatch.ListTagsForResourceInput) (*cloudwatch.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResource", arg0)
+	ret0, _ := ret[0].(*cloudwatch.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResource indicates an expected call of ListTagsForResource.
+func (mr *MockCloudWatchMockRecorder) ListTagsForResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockCloudWatch)(nil).ListTagsForResource), arg0)
+}
+
+// ListTagsForResourceRequest mocks base method.
+func (m *MockCloudWatch) ListTagsForResourceRequest(arg0 *cloudwatch.ListTagsForResourceInput) (*request.Request, *cloudwatch.ListTagsForResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.ListTagsForResourceOutput)
+	return ret0, ret1
+}
+
+// ListTagsForResourceRequest indicates an expected call of ListTagsForResourceRequest.
+func (mr *MockCloudWatchMockRecorder) ListTagsForResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceRequest", reflect.TypeOf((*MockCloudWatch)(nil).ListTagsForResourceRequest), arg0)
+}
+
+// ListTagsForResourceWithContext mocks base method.
+func (m *MockCloudWatch) ListTagsForResourceWithContext(arg0 context.Context, arg1 *cloudwatch.ListTagsForResourceInput, arg2 ...request.Option) (*cloudwatch.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResourceWithContext indicates an expected call of ListTagsForResourceWithContext.
+func (mr *MockCloudWatchMockRecorder) ListTagsForResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceWithContext", reflect.TypeOf((*MockCloudWatch)(nil).ListTagsForResourceWithContext), varargs...)
+}
+
+// PutAnomalyDetector mocks base method.
+func (m *MockCloudWatch) PutAnomalyDetector(arg0 *cloudwatch.PutAnomalyDetectorInput) (*cloudwatch.PutAnomalyDetectorOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutAnomalyDetector", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutAnomalyDetectorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutAnomalyDetector indicates an expected call of PutAnomalyDetector.
+func (mr *MockCloudWatchMockRecorder) PutAnomalyDetector(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAnomalyDetector", reflect.TypeOf((*MockCloudWatch)(nil).PutAnomalyDetector), arg0)
+}
+
+// PutAnomalyDetectorRequest mocks base method.
+func (m *MockCloudWatch) PutAnomalyDetectorRequest(arg0 *cloudwatch.PutAnomalyDetectorInput) (*request.Request, *cloudwatch.PutAnomalyDetectorOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutAnomalyDetectorRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutAnomalyDetectorOutput)
+	return ret0, ret1
+}
+
+// PutAnomalyDetectorRequest indicates an expected call of PutAnomalyDetectorRequest.
+func (mr *MockCloudWatchMockRecorder) PutAnomalyDetectorRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAnomalyDetectorRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutAnomalyDetectorRequest), arg0)
+}
+
+// PutAnomalyDetectorWithContext mocks base method.
+func (m *MockCloudWatch) PutAnomalyDetectorWithContext(arg0 context.Context, arg1 *cloudwatch.PutAnomalyDetectorInput, arg2 ...request.Option) (*cloudwatch.PutAnomalyDetectorOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutAnomalyDetectorWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutAnomalyDetectorOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutAnomalyDetectorWithContext indicates an expected call of PutAnomalyDetectorWithContext.
+func (mr *MockCloudWatchMockRecorder) PutAnomalyDetectorWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutAnomalyDetectorWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutAnomalyDetectorWithContext), varargs...)
+}
+
+// PutCompositeAlarm mocks base method.
+func (m *MockCloudWatch) PutCompositeAlarm(arg0 *cloudwatch.PutCompositeAlarmInput) (*cloudwatch.PutCompositeAlarmOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutCompositeAlarm", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutCompositeAlarmOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutCompositeAlarm indicates an expected call of PutCompositeAlarm.
+func (mr *MockCloudWatchMockRecorder) PutCompositeAlarm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCompositeAlarm", reflect.TypeOf((*MockCloudWatch)(nil).PutCompositeAlarm), arg0)
+}
+
+// PutCompositeAlarmRequest mocks base method.
+func (m *MockCloudWatch) PutCompositeAlarmRequest(arg0 *cloudwatch.PutCompositeAlarmInput) (*request.Request, *cloudwatch.PutCompositeAlarmOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutCompositeAlarmRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutCompositeAlarmOutput)
+	return ret0, ret1
+}
+
+// PutCompositeAlarmRequest indicates an expected call of PutCompositeAlarmRequest.
+func (mr *MockCloudWatchMockRecorder) PutCompositeAlarmRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCompositeAlarmRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutCompositeAlarmRequest), arg0)
+}
+
+// PutCompositeAlarmWithContext mocks base method.
+func (m *MockCloudWatch) PutCompositeAlarmWithContext(arg0 context.Context, arg1 *cloudwatch.PutCompositeAlarmInput, arg2 ...request.Option) (*cloudwatch.PutCompositeAlarmOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutCompositeAlarmWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutCompositeAlarmOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutCompositeAlarmWithContext indicates an expected call of PutCompositeAlarmWithContext.
+func (mr *MockCloudWatchMockRecorder) PutCompositeAlarmWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCompositeAlarmWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutCompositeAlarmWithContext), varargs...)
+}
+
+// PutDashboard mocks base method.
+func (m *MockCloudWatch) PutDashboard(arg0 *cloudwatch.PutDashboardInput) (*cloudwatch.PutDashboardOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutDashboard", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutDashboardOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutDashboard indicates an expected call of PutDashboard.
+func (mr *MockCloudWatchMockRecorder) PutDashboard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDashboard", reflect.TypeOf((*MockCloudWatch)(nil).PutDashboard), arg0)
+}
+
+// PutDashboardRequest mocks base method.
+func (m *MockCloudWatch) PutDashboardRequest(arg0 *cloudwatch.PutDashboardInput) (*request.Request, *cloudwatch.PutDashboardOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutDashboardRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutDashboardOutput)
+	return ret0, ret1
+}
+
+// PutDashboardRequest indicates an expected call of PutDashboardRequest.
+func (mr *MockCloudWatchMockRecorder) PutDashboardRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDashboardRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutDashboardRequest), arg0)
+}
+
+// PutDashboardWithContext mocks base method.
+func (m *MockCloudWatch) PutDashboardWithContext(arg0 context.Context, arg1 *cloudwatch.PutDashboardInput, arg2 ...request.Option) (*cloudwatch.PutDashboardOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutDashboardWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutDashboardOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutDashboardWithContext indicates an expected call of PutDashboardWithContext.
+func (mr *MockCloudWatchMockRecorder) PutDashboardWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDashboardWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutDashboardWithContext), varargs...)
+}
+
+// PutInsightRule mocks base method.
+func (m *MockCloudWatch) PutInsightRule(arg0 *cloudwatch.PutInsightRuleInput) (*cloudwatch.PutInsightRuleOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutInsightRule", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutInsightRuleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutInsightRule indicates an expected call of PutInsightRule.
+func (mr *MockCloudWatchMockRecorder) PutInsightRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInsightRule", reflect.TypeOf((*MockCloudWatch)(nil).PutInsightRule), arg0)
+}
+
+// PutInsightRuleRequest mocks base method.
+func (m *MockCloudWatch) PutInsightRuleRequest(arg0 *cloudwatch.PutInsightRuleInput) (*request.Request, *cloudwatch.PutInsightRuleOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutInsightRuleRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutInsightRuleOutput)
+	return ret0, ret1
+}
+
+// PutInsightRuleRequest indicates an expected call of PutInsightRuleRequest.
+func (mr *MockCloudWatchMockRecorder) PutInsightRuleRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInsightRuleRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutInsightRuleRequest), arg0)
+}
+
+// PutInsightRuleWithContext mocks base method.
+func (m *MockCloudWatch) PutInsightRuleWithContext(arg0 context.Context, arg1 *cloudwatch.PutInsightRuleInput, arg2 ...request.Option) (*cloudwatch.PutInsightRuleOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutInsightRuleWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutInsightRuleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutInsightRuleWithContext indicates an expected call of PutInsightRuleWithContext.
+func (mr *MockCloudWatchMockRecorder) PutInsightRuleWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInsightRuleWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutInsightRuleWithContext), varargs...)
+}
+
+// PutMetricAlarm mocks base method.
+func (m *MockCloudWatch) PutMetricAlarm(arg0 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricAlarm", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricAlarmOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricAlarm indicates an expected call of PutMetricAlarm.
+func (mr *MockCloudWatchMockRecorder) PutMetricAlarm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricAlarm", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricAlarm), arg0)
+}
+
+// PutMetricAlarmRequest mocks base method.
+func (m *MockCloudWatch) PutMetricAlarmRequest(arg0 *cloudwatch.PutMetricAlarmInput) (*request.Request, *cloudwatch.PutMetricAlarmOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricAlarmRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutMetricAlarmOutput)
+	return ret0, ret1
+}
+
+// PutMetricAlarmRequest indicates an expected call of PutMetricAlarmRequest.
+func (mr *MockCloudWatchMockRecorder) PutMetricAlarmRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricAlarmRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricAlarmRequest), arg0)
+}
+
+// PutMetricAlarmWithContext mocks base method.
+func (m *MockCloudWatch) PutMetricAlarmWithContext(arg0 context.Context, arg1 *cloudwatch.PutMetricAlarmInput, arg2 ...request.Option) (*cloudwatch.PutMetricAlarmOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutMetricAlarmWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricAlarmOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricAlarmWithContext indicates an expected call of PutMetricAlarmWithContext.
+func (mr *MockCloudWatchMockRecorder) PutMetricAlarmWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricAlarmWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricAlarmWithContext), varargs...)
+}
+
+// PutMetricData mocks base method.
+func (m *MockCloudWatch) PutMetricData(arg0 *cloudwatch.PutMetricDataInput) (*cloudwatch.PutMetricDataOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricData", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricDataOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricData indicates an expected call of PutMetricData.
+func (mr *MockCloudWatchMockRecorder) PutMetricData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricData", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricData), arg0)
+}
+
+// PutMetricDataRequest mocks base method.
+func (m *MockCloudWatch) PutMetricDataRequest(arg0 *cloudwatch.PutMetricDataInput) (*request.Request, *cloudwatch.PutMetricDataOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricDataRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutMetricDataOutput)
+	return ret0, ret1
+}
+
+// PutMetricDataRequest indicates an expected call of PutMetricDataRequest.
+func (mr *MockCloudWatchMockRecorder) PutMetricDataRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricDataRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricDataRequest), arg0)
+}
+
+// PutMetricDataWithContext mocks base method.
+func (m *MockCloudWatch) PutMetricDataWithContext(arg0 context.Context, arg1 *cloudwatch.PutMetricDataInput, arg2 ...request.Option) (*cloudwatch.PutMetricDataOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutMetricDataWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricDataOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricDataWithContext indicates an expected call of PutMetricDataWithContext.
+func (mr *MockCloudWatchMockRecorder) PutMetricDataWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricDataWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricDataWithContext), varargs...)
+}
+
+// PutMetricStream mocks base method.
+func (m *MockCloudWatch) PutMetricStream(arg0 *cloudwatch.PutMetricStreamInput) (*cloudwatch.PutMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricStream", arg0)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricStream indicates an expected call of PutMetricStream.
+func (mr *MockCloudWatchMockRecorder) PutMetricStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricStream", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricStream), arg0)
+}
+
+// PutMetricStreamRequest mocks base method.
+func (m *MockCloudWatch) PutMetricStreamRequest(arg0 *cloudwatch.PutMetricStreamInput) (*request.Request, *cloudwatch.PutMetricStreamOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMetricStreamRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.PutMetricStreamOutput)
+	return ret0, ret1
+}
+
+// PutMetricStreamRequest indicates an expected call of PutMetricStreamRequest.
+func (mr *MockCloudWatchMockRecorder) PutMetricStreamRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricStreamRequest", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricStreamRequest), arg0)
+}
+
+// PutMetricStreamWithContext mocks base method.
+func (m *MockCloudWatch) PutMetricStreamWithContext(arg0 context.Context, arg1 *cloudwatch.PutMetricStreamInput, arg2 ...request.Option) (*cloudwatch.PutMetricStreamOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutMetricStreamWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.PutMetricStreamOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutMetricStreamWithContext indicates an expected call of PutMetricStreamWithContext.
+func (mr *MockCloudWatchMockRecorder) PutMetricStreamWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMetricStreamWithContext", reflect.TypeOf((*MockCloudWatch)(nil).PutMetricStreamWithContext), varargs...)
+}
+
+// SetAlarmState mocks base method.
+func (m *MockCloudWatch) SetAlarmState(arg0 *cloudwatch.SetAlarmStateInput) (*cloudwatch.SetAlarmStateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlarmState", arg0)
+	ret0, _ := ret[0].(*cloudwatch.SetAlarmStateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAlarmState indicates an expected call of SetAlarmState.
+func (mr *MockCloudWatchMockRecorder) SetAlarmState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlarmState", reflect.TypeOf((*MockCloudWatch)(nil).SetAlarmState), arg0)
+}
+
+// SetAlarmStateRequest mocks base method.
+func (m *MockCloudWatch) SetAlarmStateRequest(arg0 *cloudwatch.SetAlarmStateInput) (*request.Request, *cloudwatch.SetAlarmStateOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlarmStateRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.SetAlarmStateOutput)
+	return ret0, ret1
+}
+
+// SetAlarmStateRequest indicates an expected call of SetAlarmStateRequest.
+func (mr *MockCloudWatchMockRecorder) SetAlarmStateRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlarmStateRequest", reflect.TypeOf((*MockCloudWatch)(nil).SetAlarmStateRequest), arg0)
+}
+
+// SetAlarmStateWithContext mocks base method.
+func (m *MockCloudWatch) SetAlarmStateWithContext(arg0 context.Context, arg1 *cloudwatch.SetAlarmStateInput, arg2 ...request.Option) (*cloudwatch.SetAlarmStateOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetAlarmStateWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.SetAlarmStateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAlarmStateWithContext indicates an expected call of SetAlarmStateWithContext.
+func (mr *MockCloudWatchMockRecorder) SetAlarmStateWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlarmStateWithContext", reflect.TypeOf((*MockCloudWatch)(nil).SetAlarmStateWithContext), varargs...)
+}
+
+// StartMetricStreams mocks base method.
+func (m *MockCloudWatch) StartMetricStreams(arg0 *cloudwatch.StartMetricStreamsInput) (*cloudwatch.StartMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartMetricStreams", arg0)
+	ret0, _ := ret[0].(*cloudwatch.StartMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartMetricStreams indicates an expected call of StartMetricStreams.
+func (mr *MockCloudWatchMockRecorder) StartMetricStreams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMetricStreams", reflect.TypeOf((*MockCloudWatch)(nil).StartMetricStreams), arg0)
+}
+
+// StartMetricStreamsRequest mocks base method.
+func (m *MockCloudWatch) StartMetricStreamsRequest(arg0 *cloudwatch.StartMetricStreamsInput) (*request.Request, *cloudwatch.StartMetricStreamsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartMetricStreamsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.StartMetricStreamsOutput)
+	return ret0, ret1
+}
+
+// StartMetricStreamsRequest indicates an expected call of StartMetricStreamsRequest.
+func (mr *MockCloudWatchMockRecorder) StartMetricStreamsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMetricStreamsRequest", reflect.TypeOf((*MockCloudWatch)(nil).StartMetricStreamsRequest), arg0)
+}
+
+// StartMetricStreamsWithContext mocks base method.
+func (m *MockCloudWatch) StartMetricStreamsWithContext(arg0 context.Context, arg1 *cloudwatch.StartMetricStreamsInput, arg2 ...request.Option) (*cloudwatch.StartMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartMetricStreamsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.StartMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartMetricStreamsWithContext indicates an expected call of StartMetricStreamsWithContext.
+func (mr *MockCloudWatchMockRecorder) StartMetricStreamsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartMetricStreamsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).StartMetricStreamsWithContext), varargs...)
+}
+
+// StopMetricStreams mocks base method.
+func (m *MockCloudWatch) StopMetricStreams(arg0 *cloudwatch.StopMetricStreamsInput) (*cloudwatch.StopMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopMetricStreams", arg0)
+	ret0, _ := ret[0].(*cloudwatch.StopMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopMetricStreams indicates an expected call of StopMetricStreams.
+func (mr *MockCloudWatchMockRecorder) StopMetricStreams(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMetricStreams", reflect.TypeOf((*MockCloudWatch)(nil).StopMetricStreams), arg0)
+}
+
+// StopMetricStreamsRequest mocks base method.
+func (m *MockCloudWatch) StopMetricStreamsRequest(arg0 *cloudwatch.StopMetricStreamsInput) (*request.Request, *cloudwatch.StopMetricStreamsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopMetricStreamsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.StopMetricStreamsOutput)
+	return ret0, ret1
+}
+
+// StopMetricStreamsRequest indicates an expected call of StopMetricStreamsRequest.
+func (mr *MockCloudWatchMockRecorder) StopMetricStreamsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMetricStreamsRequest", reflect.TypeOf((*MockCloudWatch)(nil).StopMetricStreamsRequest), arg0)
+}
+
+// StopMetricStreamsWithContext mocks base method.
+func (m *MockCloudWatch) StopMetricStreamsWithContext(arg0 context.Context, arg1 *cloudwatch.StopMetricStreamsInput, arg2 ...request.Option) (*cloudwatch.StopMetricStreamsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopMetricStreamsWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.StopMetricStreamsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopMetricStreamsWithContext indicates an expected call of StopMetricStreamsWithContext.
+func (mr *MockCloudWatchMockRecorder) StopMetricStreamsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopMetricStreamsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).StopMetricStreamsWithContext), varargs...)
+}
+
+// TagResource mocks base method.
+func (m *MockCloudWatch) TagResource(arg0 *cloudwatch.TagResourceInput) (*cloudwatch.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResource", arg0)
+	ret0, _ := ret[0].(*cloudwatch.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResource indicates an expected call of TagResource.
+func (mr *MockCloudWatchMockRecorder) TagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResource", reflect.TypeOf((*MockCloudWatch)(nil).TagResource), arg0)
+}
+
+// TagResourceRequest mocks base method.
+func (m *MockCloudWatch) TagResourceRequest(arg0 *cloudwatch.TagResourceInput) (*request.Request, *cloudwatch.TagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.TagResourceOutput)
+	return ret0, ret1
+}
+
+// TagResourceRequest indicates an expected call of TagResourceRequest.
+func (mr *MockCloudWatchMockRecorder) TagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceRequest", reflect.TypeOf((*MockCloudWatch)(nil).TagResourceRequest), arg0)
+}
+
+// TagResourceWithContext mocks base method.
+func (m *MockCloudWatch) TagResourceWithContext(arg0 context.Context, arg1 *cloudwatch.TagResourceInput, arg2 ...request.Option) (*cloudwatch.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResourceWithContext indicates an expected call of TagResourceWithContext.
+func (mr *MockCloudWatchMockRecorder) TagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceWithContext", reflect.TypeOf((*MockCloudWatch)(nil).TagResourceWithContext), varargs...)
+}
+
+// UntagResource mocks base method.
+func (m *MockCloudWatch) UntagResource(arg0 *cloudwatch.UntagResourceInput) (*cloudwatch.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResource", arg0)
+	ret0, _ := ret[0].(*cloudwatch.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResource indicates an expected call of UntagResource.
+func (mr *MockCloudWatchMockRecorder) UntagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResource", reflect.TypeOf((*MockCloudWatch)(nil).UntagResource), arg0)
+}
+
+// UntagResourceRequest mocks base method.
+func (m *MockCloudWatch) UntagResourceRequest(arg0 *cloudwatch.UntagResourceInput) (*request.Request, *cloudwatch.UntagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*cloudwatch.UntagResourceOutput)
+	return ret0, ret1
+}
+
+// UntagResourceRequest indicates an expected call of UntagResourceRequest.
+func (mr *MockCloudWatchMockRecorder) UntagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceRequest", reflect.TypeOf((*MockCloudWatch)(nil).UntagResourceRequest), arg0)
+}
+
+// UntagResourceWithContext mocks base method.
+func (m *MockCloudWatch) UntagResourceWithContext(arg0 context.Context, arg1 *cloudwatch.UntagResourceInput, arg2 ...request.Option) (*cloudwatch.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UntagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*cloudwatch.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResourceWithContext indicates an expected call of UntagResourceWithContext.
+func (mr *MockCloudWatchMockRecorder) UntagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceWithContext", reflect.TypeOf((*MockCloudWatch)(nil).UntagResourceWithContext), varargs...)
+}
+
+// WaitUntilAlarmExists mocks base method.
+func (m *MockCloudWatch) WaitUntilAlarmExists(arg0 *cloudwatch.DescribeAlarmsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilAlarmExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilAlarmExists indicates an expected call of WaitUntilAlarmExists.
+func (mr *MockCloudWatchMockRecorder) WaitUntilAlarmExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilAlarmExists", reflect.TypeOf((*MockCloudWatch)(nil).WaitUntilAlarmExists), arg0)
+}
+
+// WaitUntilAlarmExistsWithContext mocks base method.
+func (m *MockCloudWatch) WaitUntilAlarmExistsWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmsInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilAlarmExistsWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilAlarmExistsWithContext indicates an expected call of WaitUntilAlarmExistsWithContext.
+func (mr *MockCloudWatchMockRecorder) WaitUntilAlarmExistsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilAlarmExistsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).WaitUntilAlarmExistsWithContext), varargs...)
+}
+
+// WaitUntilCompositeAlarmExists mocks base method.
+func (m *MockCloudWatch) WaitUntilCompositeAlarmExists(arg0 *cloudwatch.DescribeAlarmsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilCompositeAlarmExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilCompositeAlarmExists indicates an expected call of WaitUntilCompositeAlarmExists.
+func (mr *MockCloudWatchMockRecorder) WaitUntilCompositeAlarmExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilCompositeAlarmExists", reflect.TypeOf((*MockCloudWatch)(nil).WaitUntilCompositeAlarmExists), arg0)
+}
+
+// WaitUntilCompositeAlarmExistsWithContext mocks base method.
+func (m *MockCloudWatch) WaitUntilCompositeAlarmExistsWithContext(arg0 context.Context, arg1 *cloudwatch.DescribeAlarmsInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilCompositeAlarmExistsWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilCompositeAlarmExistsWithContext indicates an expected call of WaitUntilCompositeAlarmExistsWithContext.
+func (mr *MockCloudWatchMockRecorder) WaitUntilCompositeAlarmExistsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilCompositeAlarmExistsWithContext", reflect.TypeOf((*MockCloudWatch)(nil).WaitUntilCompositeAlarmExistsWithContext), varargs...)
+}
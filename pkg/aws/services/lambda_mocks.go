@@ -0,0 +1,3366 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services (interfaces: Lambda)
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request "github.com/aws/aws-sdk-go/aws/request"
+	lambda "github.com/aws/aws-sdk-go/service/lambda"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLambda is a mock of Lambda interface.
+type MockLambda struct {
+	ctrl     *gomock.Controller
+	recorder *MockLambdaMockRecorder
+}
+
+// MockLambdaMockRecorder is the mock recorder for MockLambda.
+type MockLambdaMockRecorder struct {
+	mock *MockLambda
+}
+
+// NewMockLambda creates a new mock instance.
+func NewMockLambda(ctrl *gomock.Controller) *MockLambda {
+	mock := &MockLambda{ctrl: ctrl}
+	mock.recorder = &MockLambdaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLambda) EXPECT() *MockLambdaMockRecorder {
+	return m.recorder
+}
+
+// AddLayerVersionPermission mocks base method.
+func (m *MockLambda) AddLayerVersionPermission(arg0 *lambda.AddLayerVersionPermissionInput) (*lambda.AddLayerVersionPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLayerVersionPermission", arg0)
+	ret0, _ := ret[0].(*lambda.AddLayerVersionPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLayerVersionPermission indicates an expected call of AddLayerVersionPermission.
+func (mr *MockLambdaMockRecorder) AddLayerVersionPermission(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLayerVersionPermission", reflect.TypeOf((*MockLambda)(nil).AddLayerVersionPermission), arg0)
+}
+
+// AddLayerVersionPermissionRequest mocks base method.
+func (m *MockLambda) AddLayerVersionPermissionRequest(arg0 *lambda.AddLayerVersionPermissionInput) (*request.Request, *lambda.AddLayerVersionPermissionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLayerVersionPermissionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.AddLayerVersionPermissionOutput)
+	return ret0, ret1
+}
+
+// AddLayerVersionPermissionRequest indicates an expected call of AddLayerVersionPermissionRequest.
+func (mr *MockLambdaMockRecorder) AddLayerVersionPermissionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLayerVersionPermissionRequest", reflect.TypeOf((*MockLambda)(nil).AddLayerVersionPermissionRequest), arg0)
+}
+
+// AddLayerVersionPermissionWithContext mocks base method.
+func (m *MockLambda) AddLayerVersionPermissionWithContext(arg0 context.Context, arg1 *lambda.AddLayerVersionPermissionInput, arg2 ...request.Option) (*lambda.AddLayerVersionPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddLayerVersionPermissionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.AddLayerVersionPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLayerVersionPermissionWithContext indicates an expected call of AddLayerVersionPermissionWithContext.
+func (mr *MockLambdaMockRecorder) AddLayerVersionPermissionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLayerVersionPermissionWithContext", reflect.TypeOf((*MockLambda)(nil).AddLayerVersionPermissionWithContext), varargs...)
+}
+
+// AddPermission mocks base method.
+func (m *MockLambda) AddPermission(arg0 *lambda.AddPermissionInput) (*lambda.AddPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPermission", arg0)
+	ret0, _ := ret[0].(*lambda.AddPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPermission indicates an expected call of AddPermission.
+func (mr *MockLambdaMockRecorder) AddPermission(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPermission", reflect.TypeOf((*MockLambda)(nil).AddPermission), arg0)
+}
+
+// AddPermissionRequest mocks base method.
+func (m *MockLambda) AddPermissionRequest(arg0 *lambda.AddPermissionInput) (*request.Request, *lambda.AddPermissionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPermissionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.AddPermissionOutput)
+	return ret0, ret1
+}
+
+// AddPermissionRequest indicates an expected call of AddPermissionRequest.
+func (mr *MockLambdaMockRecorder) AddPermissionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPermissionRequest", reflect.TypeOf((*MockLambda)(nil).AddPermissionRequest), arg0)
+}
+
+// AddPermissionWithContext mocks base method.
+func (m *MockLambda) AddPermissionWithContext(arg0 context.Context, arg1 *lambda.AddPermissionInput, arg2 ...request.Option) (*lambda.AddPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddPermissionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.AddPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPermissionWithContext indicates an expected call of AddPermissionWithContext.
+func (mr *MockLambdaMockRecorder) AddPermissionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPermissionWithContext", reflect.TypeOf((*MockLambda)(nil).AddPermissionWithContext), varargs...)
+}
+
+// CreateAlias mocks base method.
+func (m *MockLambda) CreateAlias(arg0 *lambda.CreateAliasInput) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlias", arg0)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlias indicates an expected call of CreateAlias.
+func (mr *MockLambdaMockRecorder) CreateAlias(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlias", reflect.TypeOf((*MockLambda)(nil).CreateAlias), arg0)
+}
+
+// CreateAliasRequest mocks base method.
+func (m *MockLambda) CreateAliasRequest(arg0 *lambda.CreateAliasInput) (*request.Request, *lambda.AliasConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAliasRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.AliasConfiguration)
+	return ret0, ret1
+}
+
+// CreateAliasRequest indicates an expected call of CreateAliasRequest.
+func (mr *MockLambdaMockRecorder) CreateAliasRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAliasRequest", reflect.TypeOf((*MockLambda)(nil).CreateAliasRequest), arg0)
+}
+
+// CreateAliasWithContext mocks base method.
+func (m *MockLambda) CreateAliasWithContext(arg0 context.Context, arg1 *lambda.CreateAliasInput, arg2 ...request.Option) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAliasWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAliasWithContext indicates an expected call of CreateAliasWithContext.
+func (mr *MockLambdaMockRecorder) CreateAliasWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAliasWithContext", reflect.TypeOf((*MockLambda)(nil).CreateAliasWithContext), varargs...)
+}
+
+// CreateCodeSigningConfig mocks base method.
+func (m *MockLambda) CreateCodeSigningConfig(arg0 *lambda.CreateCodeSigningConfigInput) (*lambda.CreateCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.CreateCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCodeSigningConfig indicates an expected call of CreateCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) CreateCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).CreateCodeSigningConfig), arg0)
+}
+
+// CreateCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) CreateCodeSigningConfigRequest(arg0 *lambda.CreateCodeSigningConfigInput) (*request.Request, *lambda.CreateCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.CreateCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// CreateCodeSigningConfigRequest indicates an expected call of CreateCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) CreateCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).CreateCodeSigningConfigRequest), arg0)
+}
+
+// CreateCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) CreateCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.CreateCodeSigningConfigInput, arg2 ...request.Option) (*lambda.CreateCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.CreateCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCodeSigningConfigWithContext indicates an expected call of CreateCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) CreateCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).CreateCodeSigningConfigWithContext), varargs...)
+}
+
+// CreateEventSourceMapping mocks base method.
+func (m *MockLambda) CreateEventSourceMapping(arg0 *lambda.CreateEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventSourceMapping", arg0)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventSourceMapping indicates an expected call of CreateEventSourceMapping.
+func (mr *MockLambdaMockRecorder) CreateEventSourceMapping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventSourceMapping", reflect.TypeOf((*MockLambda)(nil).CreateEventSourceMapping), arg0)
+}
+
+// CreateEventSourceMappingRequest mocks base method.
+func (m *MockLambda) CreateEventSourceMappingRequest(arg0 *lambda.CreateEventSourceMappingInput) (*request.Request, *lambda.EventSourceMappingConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventSourceMappingRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.EventSourceMappingConfiguration)
+	return ret0, ret1
+}
+
+// CreateEventSourceMappingRequest indicates an expected call of CreateEventSourceMappingRequest.
+func (mr *MockLambdaMockRecorder) CreateEventSourceMappingRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventSourceMappingRequest", reflect.TypeOf((*MockLambda)(nil).CreateEventSourceMappingRequest), arg0)
+}
+
+// CreateEventSourceMappingWithContext mocks base method.
+func (m *MockLambda) CreateEventSourceMappingWithContext(arg0 context.Context, arg1 *lambda.CreateEventSourceMappingInput, arg2 ...request.Option) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateEventSourceMappingWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventSourceMappingWithContext indicates an expected call of CreateEventSourceMappingWithContext.
+func (mr *MockLambdaMockRecorder) CreateEventSourceMappingWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventSourceMappingWithContext", reflect.TypeOf((*MockLambda)(nil).CreateEventSourceMappingWithContext), varargs...)
+}
+
+// CreateFunction mocks base method.
+func (m *MockLambda) CreateFunction(arg0 *lambda.CreateFunctionInput) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFunction", arg0)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFunction indicates an expected call of CreateFunction.
+func (mr *MockLambdaMockRecorder) CreateFunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunction", reflect.TypeOf((*MockLambda)(nil).CreateFunction), arg0)
+}
+
+// CreateFunctionRequest mocks base method.
+func (m *MockLambda) CreateFunctionRequest(arg0 *lambda.CreateFunctionInput) (*request.Request, *lambda.FunctionConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFunctionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.FunctionConfiguration)
+	return ret0, ret1
+}
+
+// CreateFunctionRequest indicates an expected call of CreateFunctionRequest.
+func (mr *MockLambdaMockRecorder) CreateFunctionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunctionRequest", reflect.TypeOf((*MockLambda)(nil).CreateFunctionRequest), arg0)
+}
+
+// CreateFunctionWithContext mocks base method.
+func (m *MockLambda) CreateFunctionWithContext(arg0 context.Context, arg1 *lambda.CreateFunctionInput, arg2 ...request.Option) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFunctionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFunctionWithContext indicates an expected call of CreateFunctionWithContext.
+func (mr *MockLambdaMockRecorder) CreateFunctionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFunctionWithContext", reflect.TypeOf((*MockLambda)(nil).CreateFunctionWithContext), varargs...)
+}
+
+// DeleteAlias mocks base method.
+func (m *MockLambda) DeleteAlias(arg0 *lambda.DeleteAliasInput) (*lambda.DeleteAliasOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlias", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAlias indicates an expected call of DeleteAlias.
+func (mr *MockLambdaMockRecorder) DeleteAlias(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlias", reflect.TypeOf((*MockLambda)(nil).DeleteAlias), arg0)
+}
+
+// DeleteAliasRequest mocks base method.
+func (m *MockLambda) DeleteAliasRequest(arg0 *lambda.DeleteAliasInput) (*request.Request, *lambda.DeleteAliasOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAliasRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteAliasOutput)
+	return ret0, ret1
+}
+
+// DeleteAliasRequest indicates an expected call of DeleteAliasRequest.
+func (mr *MockLambdaMockRecorder) DeleteAliasRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAliasRequest", reflect.TypeOf((*MockLambda)(nil).DeleteAliasRequest), arg0)
+}
+
+// DeleteAliasWithContext mocks base method.
+func (m *MockLambda) DeleteAliasWithContext(arg0 context.Context, arg1 *lambda.DeleteAliasInput, arg2 ...request.Option) (*lambda.DeleteAliasOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAliasWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAliasWithContext indicates an expected call of DeleteAliasWithContext.
+func (mr *MockLambdaMockRecorder) DeleteAliasWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAliasWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteAliasWithContext), varargs...)
+}
+
+// DeleteCodeSigningConfig mocks base method.
+func (m *MockLambda) DeleteCodeSigningConfig(arg0 *lambda.DeleteCodeSigningConfigInput) (*lambda.DeleteCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCodeSigningConfig indicates an expected call of DeleteCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) DeleteCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).DeleteCodeSigningConfig), arg0)
+}
+
+// DeleteCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) DeleteCodeSigningConfigRequest(arg0 *lambda.DeleteCodeSigningConfigInput) (*request.Request, *lambda.DeleteCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// DeleteCodeSigningConfigRequest indicates an expected call of DeleteCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) DeleteCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).DeleteCodeSigningConfigRequest), arg0)
+}
+
+// DeleteCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) DeleteCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.DeleteCodeSigningConfigInput, arg2 ...request.Option) (*lambda.DeleteCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCodeSigningConfigWithContext indicates an expected call of DeleteCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) DeleteCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteCodeSigningConfigWithContext), varargs...)
+}
+
+// DeleteEventSourceMapping mocks base method.
+func (m *MockLambda) DeleteEventSourceMapping(arg0 *lambda.DeleteEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventSourceMapping", arg0)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventSourceMapping indicates an expected call of DeleteEventSourceMapping.
+func (mr *MockLambdaMockRecorder) DeleteEventSourceMapping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventSourceMapping", reflect.TypeOf((*MockLambda)(nil).DeleteEventSourceMapping), arg0)
+}
+
+// DeleteEventSourceMappingRequest mocks base method.
+func (m *MockLambda) DeleteEventSourceMappingRequest(arg0 *lambda.DeleteEventSourceMappingInput) (*request.Request, *lambda.EventSourceMappingConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventSourceMappingRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.EventSourceMappingConfiguration)
+	return ret0, ret1
+}
+
+// DeleteEventSourceMappingRequest indicates an expected call of DeleteEventSourceMappingRequest.
+func (mr *MockLambdaMockRecorder) DeleteEventSourceMappingRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventSourceMappingRequest", reflect.TypeOf((*MockLambda)(nil).DeleteEventSourceMappingRequest), arg0)
+}
+
+// DeleteEventSourceMappingWithContext mocks base method.
+func (m *MockLambda) DeleteEventSourceMappingWithContext(arg0 context.Context, arg1 *lambda.DeleteEventSourceMappingInput, arg2 ...request.Option) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteEventSourceMappingWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventSourceMappingWithContext indicates an expected call of DeleteEventSourceMappingWithContext.
+func (mr *MockLambdaMockRecorder) DeleteEventSourceMappingWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventSourceMappingWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteEventSourceMappingWithContext), varargs...)
+}
+
+// DeleteFunction mocks base method.
+func (m *MockLambda) DeleteFunction(arg0 *lambda.DeleteFunctionInput) (*lambda.DeleteFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunction", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunction indicates an expected call of DeleteFunction.
+func (mr *MockLambdaMockRecorder) DeleteFunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunction", reflect.TypeOf((*MockLambda)(nil).DeleteFunction), arg0)
+}
+
+// DeleteFunctionCodeSigningConfig mocks base method.
+func (m *MockLambda) DeleteFunctionCodeSigningConfig(arg0 *lambda.DeleteFunctionCodeSigningConfigInput) (*lambda.DeleteFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionCodeSigningConfig indicates an expected call of DeleteFunctionCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) DeleteFunctionCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionCodeSigningConfig), arg0)
+}
+
+// DeleteFunctionCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) DeleteFunctionCodeSigningConfigRequest(arg0 *lambda.DeleteFunctionCodeSigningConfigInput) (*request.Request, *lambda.DeleteFunctionCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteFunctionCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// DeleteFunctionCodeSigningConfigRequest indicates an expected call of DeleteFunctionCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) DeleteFunctionCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionCodeSigningConfigRequest), arg0)
+}
+
+// DeleteFunctionCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) DeleteFunctionCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.DeleteFunctionCodeSigningConfigInput, arg2 ...request.Option) (*lambda.DeleteFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFunctionCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionCodeSigningConfigWithContext indicates an expected call of DeleteFunctionCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) DeleteFunctionCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionCodeSigningConfigWithContext), varargs...)
+}
+
+// DeleteFunctionConcurrency mocks base method.
+func (m *MockLambda) DeleteFunctionConcurrency(arg0 *lambda.DeleteFunctionConcurrencyInput) (*lambda.DeleteFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionConcurrency", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionConcurrency indicates an expected call of DeleteFunctionConcurrency.
+func (mr *MockLambdaMockRecorder) DeleteFunctionConcurrency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionConcurrency", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionConcurrency), arg0)
+}
+
+// DeleteFunctionConcurrencyRequest mocks base method.
+func (m *MockLambda) DeleteFunctionConcurrencyRequest(arg0 *lambda.DeleteFunctionConcurrencyInput) (*request.Request, *lambda.DeleteFunctionConcurrencyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionConcurrencyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteFunctionConcurrencyOutput)
+	return ret0, ret1
+}
+
+// DeleteFunctionConcurrencyRequest indicates an expected call of DeleteFunctionConcurrencyRequest.
+func (mr *MockLambdaMockRecorder) DeleteFunctionConcurrencyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionConcurrencyRequest", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionConcurrencyRequest), arg0)
+}
+
+// DeleteFunctionConcurrencyWithContext mocks base method.
+func (m *MockLambda) DeleteFunctionConcurrencyWithContext(arg0 context.Context, arg1 *lambda.DeleteFunctionConcurrencyInput, arg2 ...request.Option) (*lambda.DeleteFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFunctionConcurrencyWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionConcurrencyWithContext indicates an expected call of DeleteFunctionConcurrencyWithContext.
+func (mr *MockLambdaMockRecorder) DeleteFunctionConcurrencyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionConcurrencyWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionConcurrencyWithContext), varargs...)
+}
+
+// DeleteFunctionEventInvokeConfig mocks base method.
+func (m *MockLambda) DeleteFunctionEventInvokeConfig(arg0 *lambda.DeleteFunctionEventInvokeConfigInput) (*lambda.DeleteFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionEventInvokeConfig", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionEventInvokeConfig indicates an expected call of DeleteFunctionEventInvokeConfig.
+func (mr *MockLambdaMockRecorder) DeleteFunctionEventInvokeConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionEventInvokeConfig", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionEventInvokeConfig), arg0)
+}
+
+// DeleteFunctionEventInvokeConfigRequest mocks base method.
+func (m *MockLambda) DeleteFunctionEventInvokeConfigRequest(arg0 *lambda.DeleteFunctionEventInvokeConfigInput) (*request.Request, *lambda.DeleteFunctionEventInvokeConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionEventInvokeConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteFunctionEventInvokeConfigOutput)
+	return ret0, ret1
+}
+
+// DeleteFunctionEventInvokeConfigRequest indicates an expected call of DeleteFunctionEventInvokeConfigRequest.
+func (mr *MockLambdaMockRecorder) DeleteFunctionEventInvokeConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionEventInvokeConfigRequest", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionEventInvokeConfigRequest), arg0)
+}
+
+// DeleteFunctionEventInvokeConfigWithContext mocks base method.
+func (m *MockLambda) DeleteFunctionEventInvokeConfigWithContext(arg0 context.Context, arg1 *lambda.DeleteFunctionEventInvokeConfigInput, arg2 ...request.Option) (*lambda.DeleteFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFunctionEventInvokeConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionEventInvokeConfigWithContext indicates an expected call of DeleteFunctionEventInvokeConfigWithContext.
+func (mr *MockLambdaMockRecorder) DeleteFunctionEventInvokeConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionEventInvokeConfigWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionEventInvokeConfigWithContext), varargs...)
+}
+
+// DeleteFunctionRequest mocks base method.
+func (m *MockLambda) DeleteFunctionRequest(arg0 *lambda.DeleteFunctionInput) (*request.Request, *lambda.DeleteFunctionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFunctionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteFunctionOutput)
+	return ret0, ret1
+}
+
+// DeleteFunctionRequest indicates an expected call of DeleteFunctionRequest.
+func (mr *MockLambdaMockRecorder) DeleteFunctionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionRequest", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionRequest), arg0)
+}
+
+// DeleteFunctionWithContext mocks base method.
+func (m *MockLambda) DeleteFunctionWithContext(arg0 context.Context, arg1 *lambda.DeleteFunctionInput, arg2 ...request.Option) (*lambda.DeleteFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFunctionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFunctionWithContext indicates an expected call of DeleteFunctionWithContext.
+func (mr *MockLambdaMockRecorder) DeleteFunctionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunctionWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteFunctionWithContext), varargs...)
+}
+
+// DeleteLayerVersion mocks base method.
+func (m *MockLambda) DeleteLayerVersion(arg0 *lambda.DeleteLayerVersionInput) (*lambda.DeleteLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLayerVersion", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLayerVersion indicates an expected call of DeleteLayerVersion.
+func (mr *MockLambdaMockRecorder) DeleteLayerVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLayerVersion", reflect.TypeOf((*MockLambda)(nil).DeleteLayerVersion), arg0)
+}
+
+// DeleteLayerVersionRequest mocks base method.
+func (m *MockLambda) DeleteLayerVersionRequest(arg0 *lambda.DeleteLayerVersionInput) (*request.Request, *lambda.DeleteLayerVersionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLayerVersionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteLayerVersionOutput)
+	return ret0, ret1
+}
+
+// DeleteLayerVersionRequest indicates an expected call of DeleteLayerVersionRequest.
+func (mr *MockLambdaMockRecorder) DeleteLayerVersionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLayerVersionRequest", reflect.TypeOf((*MockLambda)(nil).DeleteLayerVersionRequest), arg0)
+}
+
+// DeleteLayerVersionWithContext mocks base method.
+func (m *MockLambda) DeleteLayerVersionWithContext(arg0 context.Context, arg1 *lambda.DeleteLayerVersionInput, arg2 ...request.Option) (*lambda.DeleteLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteLayerVersionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLayerVersionWithContext indicates an expected call of DeleteLayerVersionWithContext.
+func (mr *MockLambdaMockRecorder) DeleteLayerVersionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLayerVersionWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteLayerVersionWithContext), varargs...)
+}
+
+// DeleteProvisionedConcurrencyConfig mocks base method.
+func (m *MockLambda) DeleteProvisionedConcurrencyConfig(arg0 *lambda.DeleteProvisionedConcurrencyConfigInput) (*lambda.DeleteProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProvisionedConcurrencyConfig", arg0)
+	ret0, _ := ret[0].(*lambda.DeleteProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProvisionedConcurrencyConfig indicates an expected call of DeleteProvisionedConcurrencyConfig.
+func (mr *MockLambdaMockRecorder) DeleteProvisionedConcurrencyConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvisionedConcurrencyConfig", reflect.TypeOf((*MockLambda)(nil).DeleteProvisionedConcurrencyConfig), arg0)
+}
+
+// DeleteProvisionedConcurrencyConfigRequest mocks base method.
+func (m *MockLambda) DeleteProvisionedConcurrencyConfigRequest(arg0 *lambda.DeleteProvisionedConcurrencyConfigInput) (*request.Request, *lambda.DeleteProvisionedConcurrencyConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProvisionedConcurrencyConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.DeleteProvisionedConcurrencyConfigOutput)
+	return ret0, ret1
+}
+
+// DeleteProvisionedConcurrencyConfigRequest indicates an expected call of DeleteProvisionedConcurrencyConfigRequest.
+func (mr *MockLambdaMockRecorder) DeleteProvisionedConcurrencyConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvisionedConcurrencyConfigRequest", reflect.TypeOf((*MockLambda)(nil).DeleteProvisionedConcurrencyConfigRequest), arg0)
+}
+
+// DeleteProvisionedConcurrencyConfigWithContext mocks base method.
+func (m *MockLambda) DeleteProvisionedConcurrencyConfigWithContext(arg0 context.Context, arg1 *lambda.DeleteProvisionedConcurrencyConfigInput, arg2 ...request.Option) (*lambda.DeleteProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteProvisionedConcurrencyConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.DeleteProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProvisionedConcurrencyConfigWithContext indicates an expected call of DeleteProvisionedConcurrencyConfigWithContext.
+func (mr *MockLambdaMockRecorder) DeleteProvisionedConcurrencyConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvisionedConcurrencyConfigWithContext", reflect.TypeOf((*MockLambda)(nil).DeleteProvisionedConcurrencyConfigWithContext), varargs...)
+}
+
+// GetAccountSettings mocks base method.
+func (m *MockLambda) GetAccountSettings(arg0 *lambda.GetAccountSettingsInput) (*lambda.GetAccountSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountSettings", arg0)
+	ret0, _ := ret[0].(*lambda.GetAccountSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountSettings indicates an expected call of GetAccountSettings.
+func (mr *MockLambdaMockRecorder) GetAccountSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSettings", reflect.TypeOf((*MockLambda)(nil).GetAccountSettings), arg0)
+}
+
+// GetAccountSettingsRequest mocks base method.
+func (m *MockLambda) GetAccountSettingsRequest(arg0 *lambda.GetAccountSettingsInput) (*request.Request, *lambda.GetAccountSettingsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountSettingsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetAccountSettingsOutput)
+	return ret0, ret1
+}
+
+// GetAccountSettingsRequest indicates an expected call of GetAccountSettingsRequest.
+func (mr *MockLambdaMockRecorder) GetAccountSettingsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSettingsRequest", reflect.TypeOf((*MockLambda)(nil).GetAccountSettingsRequest), arg0)
+}
+
+// GetAccountSettingsWithContext mocks base method.
+func (m *MockLambda) GetAccountSettingsWithContext(arg0 context.Context, arg1 *lambda.GetAccountSettingsInput, arg2 ...request.Option) (*lambda.GetAccountSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountSettingsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetAccountSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountSettingsWithContext indicates an expected call of GetAccountSettingsWithContext.
+func (mr *MockLambdaMockRecorder) GetAccountSettingsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountSettingsWithContext", reflect.TypeOf((*MockLambda)(nil).GetAccountSettingsWithContext), varargs...)
+}
+
+// GetAlias mocks base method.
+func (m *MockLambda) GetAlias(arg0 *lambda.GetAliasInput) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlias", arg0)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlias indicates an expected call of GetAlias.
+func (mr *MockLambdaMockRecorder) GetAlias(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlias", reflect.TypeOf((*MockLambda)(nil).GetAlias), arg0)
+}
+
+// GetAliasRequest mocks base method.
+func (m *MockLambda) GetAliasRequest(arg0 *lambda.GetAliasInput) (*request.Request, *lambda.AliasConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAliasRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.AliasConfiguration)
+	return ret0, ret1
+}
+
+// GetAliasRequest indicates an expected call of GetAliasRequest.
+func (mr *MockLambdaMockRecorder) GetAliasRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliasRequest", reflect.TypeOf((*MockLambda)(nil).GetAliasRequest), arg0)
+}
+
+// GetAliasWithContext mocks base method.
+func (m *MockLambda) GetAliasWithContext(arg0 context.Context, arg1 *lambda.GetAliasInput, arg2 ...request.Option) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAliasWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAliasWithContext indicates an expected call of GetAliasWithContext.
+func (mr *MockLambdaMockRecorder) GetAliasWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAliasWithContext", reflect.TypeOf((*MockLambda)(nil).GetAliasWithContext), varargs...)
+}
+
+// GetCodeSigningConfig mocks base method.
+func (m *MockLambda) GetCodeSigningConfig(arg0 *lambda.GetCodeSigningConfigInput) (*lambda.GetCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.GetCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeSigningConfig indicates an expected call of GetCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) GetCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).GetCodeSigningConfig), arg0)
+}
+
+// GetCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) GetCodeSigningConfigRequest(arg0 *lambda.GetCodeSigningConfigInput) (*request.Request, *lambda.GetCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// GetCodeSigningConfigRequest indicates an expected call of GetCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) GetCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).GetCodeSigningConfigRequest), arg0)
+}
+
+// GetCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) GetCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.GetCodeSigningConfigInput, arg2 ...request.Option) (*lambda.GetCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeSigningConfigWithContext indicates an expected call of GetCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) GetCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).GetCodeSigningConfigWithContext), varargs...)
+}
+
+// GetEventSourceMapping mocks base method.
+func (m *MockLambda) GetEventSourceMapping(arg0 *lambda.GetEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventSourceMapping", arg0)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventSourceMapping indicates an expected call of GetEventSourceMapping.
+func (mr *MockLambdaMockRecorder) GetEventSourceMapping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventSourceMapping", reflect.TypeOf((*MockLambda)(nil).GetEventSourceMapping), arg0)
+}
+
+// GetEventSourceMappingRequest mocks base method.
+func (m *MockLambda) GetEventSourceMappingRequest(arg0 *lambda.GetEventSourceMappingInput) (*request.Request, *lambda.EventSourceMappingConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventSourceMappingRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.EventSourceMappingConfiguration)
+	return ret0, ret1
+}
+
+// GetEventSourceMappingRequest indicates an expected call of GetEventSourceMappingRequest.
+func (mr *MockLambdaMockRecorder) GetEventSourceMappingRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventSourceMappingRequest", reflect.TypeOf((*MockLambda)(nil).GetEventSourceMappingRequest), arg0)
+}
+
+// GetEventSourceMappingWithContext mocks base method.
+func (m *MockLambda) GetEventSourceMappingWithContext(arg0 context.Context, arg1 *lambda.GetEventSourceMappingInput, arg2 ...request.Option) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEventSourceMappingWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventSourceMappingWithContext indicates an expected call of GetEventSourceMappingWithContext.
+func (mr *MockLambdaMockRecorder) GetEventSourceMappingWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventSourceMappingWithContext", reflect.TypeOf((*MockLambda)(nil).GetEventSourceMappingWithContext), varargs...)
+}
+
+// GetFunction mocks base method.
+func (m *MockLambda) GetFunction(arg0 *lambda.GetFunctionInput) (*lambda.GetFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunction", arg0)
+	ret0, _ := ret[0].(*lambda.GetFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunction indicates an expected call of GetFunction.
+func (mr *MockLambdaMockRecorder) GetFunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunction", reflect.TypeOf((*MockLambda)(nil).GetFunction), arg0)
+}
+
+// GetFunctionCodeSigningConfig mocks base method.
+func (m *MockLambda) GetFunctionCodeSigningConfig(arg0 *lambda.GetFunctionCodeSigningConfigInput) (*lambda.GetFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.GetFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionCodeSigningConfig indicates an expected call of GetFunctionCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) GetFunctionCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).GetFunctionCodeSigningConfig), arg0)
+}
+
+// GetFunctionCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) GetFunctionCodeSigningConfigRequest(arg0 *lambda.GetFunctionCodeSigningConfigInput) (*request.Request, *lambda.GetFunctionCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetFunctionCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// GetFunctionCodeSigningConfigRequest indicates an expected call of GetFunctionCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) GetFunctionCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).GetFunctionCodeSigningConfigRequest), arg0)
+}
+
+// GetFunctionCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) GetFunctionCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.GetFunctionCodeSigningConfigInput, arg2 ...request.Option) (*lambda.GetFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionCodeSigningConfigWithContext indicates an expected call of GetFunctionCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) GetFunctionCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).GetFunctionCodeSigningConfigWithContext), varargs...)
+}
+
+// GetFunctionConcurrency mocks base method.
+func (m *MockLambda) GetFunctionConcurrency(arg0 *lambda.GetFunctionConcurrencyInput) (*lambda.GetFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionConcurrency", arg0)
+	ret0, _ := ret[0].(*lambda.GetFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionConcurrency indicates an expected call of GetFunctionConcurrency.
+func (mr *MockLambdaMockRecorder) GetFunctionConcurrency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConcurrency", reflect.TypeOf((*MockLambda)(nil).GetFunctionConcurrency), arg0)
+}
+
+// GetFunctionConcurrencyRequest mocks base method.
+func (m *MockLambda) GetFunctionConcurrencyRequest(arg0 *lambda.GetFunctionConcurrencyInput) (*request.Request, *lambda.GetFunctionConcurrencyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionConcurrencyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetFunctionConcurrencyOutput)
+	return ret0, ret1
+}
+
+// GetFunctionConcurrencyRequest indicates an expected call of GetFunctionConcurrencyRequest.
+func (mr *MockLambdaMockRecorder) GetFunctionConcurrencyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConcurrencyRequest", reflect.TypeOf((*MockLambda)(nil).GetFunctionConcurrencyRequest), arg0)
+}
+
+// GetFunctionConcurrencyWithContext mocks base method.
+func (m *MockLambda) GetFunctionConcurrencyWithContext(arg0 context.Context, arg1 *lambda.GetFunctionConcurrencyInput, arg2 ...request.Option) (*lambda.GetFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionConcurrencyWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionConcurrencyWithContext indicates an expected call of GetFunctionConcurrencyWithContext.
+func (mr *MockLambdaMockRecorder) GetFunctionConcurrencyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConcurrencyWithContext", reflect.TypeOf((*MockLambda)(nil).GetFunctionConcurrencyWithContext), varargs...)
+}
+
+// GetFunctionConfiguration mocks base method.
+func (m *MockLambda) GetFunctionConfiguration(arg0 *lambda.GetFunctionConfigurationInput) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionConfiguration", arg0)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionConfiguration indicates an expected call of GetFunctionConfiguration.
+func (mr *MockLambdaMockRecorder) GetFunctionConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConfiguration", reflect.TypeOf((*MockLambda)(nil).GetFunctionConfiguration), arg0)
+}
+
+// GetFunctionConfigurationRequest mocks base method.
+func (m *MockLambda) GetFunctionConfigurationRequest(arg0 *lambda.GetFunctionConfigurationInput) (*request.Request, *lambda.FunctionConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.FunctionConfiguration)
+	return ret0, ret1
+}
+
+// GetFunctionConfigurationRequest indicates an expected call of GetFunctionConfigurationRequest.
+func (mr *MockLambdaMockRecorder) GetFunctionConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConfigurationRequest", reflect.TypeOf((*MockLambda)(nil).GetFunctionConfigurationRequest), arg0)
+}
+
+// GetFunctionConfigurationWithContext mocks base method.
+func (m *MockLambda) GetFunctionConfigurationWithContext(arg0 context.Context, arg1 *lambda.GetFunctionConfigurationInput, arg2 ...request.Option) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionConfigurationWithContext indicates an expected call of GetFunctionConfigurationWithContext.
+func (mr *MockLambdaMockRecorder) GetFunctionConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionConfigurationWithContext", reflect.TypeOf((*MockLambda)(nil).GetFunctionConfigurationWithContext), varargs...)
+}
+
+// GetFunctionEventInvokeConfig mocks base method.
+func (m *MockLambda) GetFunctionEventInvokeConfig(arg0 *lambda.GetFunctionEventInvokeConfigInput) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionEventInvokeConfig", arg0)
+	ret0, _ := ret[0].(*lambda.GetFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionEventInvokeConfig indicates an expected call of GetFunctionEventInvokeConfig.
+func (mr *MockLambdaMockRecorder) GetFunctionEventInvokeConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionEventInvokeConfig", reflect.TypeOf((*MockLambda)(nil).GetFunctionEventInvokeConfig), arg0)
+}
+
+// GetFunctionEventInvokeConfigRequest mocks base method.
+func (m *MockLambda) GetFunctionEventInvokeConfigRequest(arg0 *lambda.GetFunctionEventInvokeConfigInput) (*request.Request, *lambda.GetFunctionEventInvokeConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionEventInvokeConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetFunctionEventInvokeConfigOutput)
+	return ret0, ret1
+}
+
+// GetFunctionEventInvokeConfigRequest indicates an expected call of GetFunctionEventInvokeConfigRequest.
+func (mr *MockLambdaMockRecorder) GetFunctionEventInvokeConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionEventInvokeConfigRequest", reflect.TypeOf((*MockLambda)(nil).GetFunctionEventInvokeConfigRequest), arg0)
+}
+
+// GetFunctionEventInvokeConfigWithContext mocks base method.
+func (m *MockLambda) GetFunctionEventInvokeConfigWithContext(arg0 context.Context, arg1 *lambda.GetFunctionEventInvokeConfigInput, arg2 ...request.Option) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionEventInvokeConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionEventInvokeConfigWithContext indicates an expected call of GetFunctionEventInvokeConfigWithContext.
+func (mr *MockLambdaMockRecorder) GetFunctionEventInvokeConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionEventInvokeConfigWithContext", reflect.TypeOf((*MockLambda)(nil).GetFunctionEventInvokeConfigWithContext), varargs...)
+}
+
+// GetFunctionRequest mocks base method.
+func (m *MockLambda) GetFunctionRequest(arg0 *lambda.GetFunctionInput) (*request.Request, *lambda.GetFunctionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetFunctionOutput)
+	return ret0, ret1
+}
+
+// GetFunctionRequest indicates an expected call of GetFunctionRequest.
+func (mr *MockLambdaMockRecorder) GetFunctionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionRequest", reflect.TypeOf((*MockLambda)(nil).GetFunctionRequest), arg0)
+}
+
+// GetFunctionWithContext mocks base method.
+func (m *MockLambda) GetFunctionWithContext(arg0 context.Context, arg1 *lambda.GetFunctionInput, arg2 ...request.Option) (*lambda.GetFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionWithContext indicates an expected call of GetFunctionWithContext.
+func (mr *MockLambdaMockRecorder) GetFunctionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionWithContext", reflect.TypeOf((*MockLambda)(nil).GetFunctionWithContext), varargs...)
+}
+
+// GetLayerVersion mocks base method.
+func (m *MockLambda) GetLayerVersion(arg0 *lambda.GetLayerVersionInput) (*lambda.GetLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersion", arg0)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersion indicates an expected call of GetLayerVersion.
+func (mr *MockLambdaMockRecorder) GetLayerVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersion", reflect.TypeOf((*MockLambda)(nil).GetLayerVersion), arg0)
+}
+
+// GetLayerVersionByArn mocks base method.
+func (m *MockLambda) GetLayerVersionByArn(arg0 *lambda.GetLayerVersionByArnInput) (*lambda.GetLayerVersionByArnOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersionByArn", arg0)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionByArnOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersionByArn indicates an expected call of GetLayerVersionByArn.
+func (mr *MockLambdaMockRecorder) GetLayerVersionByArn(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionByArn", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionByArn), arg0)
+}
+
+// GetLayerVersionByArnRequest mocks base method.
+func (m *MockLambda) GetLayerVersionByArnRequest(arg0 *lambda.GetLayerVersionByArnInput) (*request.Request, *lambda.GetLayerVersionByArnOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersionByArnRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetLayerVersionByArnOutput)
+	return ret0, ret1
+}
+
+// GetLayerVersionByArnRequest indicates an expected call of GetLayerVersionByArnRequest.
+func (mr *MockLambdaMockRecorder) GetLayerVersionByArnRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionByArnRequest", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionByArnRequest), arg0)
+}
+
+// GetLayerVersionByArnWithContext mocks base method.
+func (m *MockLambda) GetLayerVersionByArnWithContext(arg0 context.Context, arg1 *lambda.GetLayerVersionByArnInput, arg2 ...request.Option) (*lambda.GetLayerVersionByArnOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLayerVersionByArnWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionByArnOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersionByArnWithContext indicates an expected call of GetLayerVersionByArnWithContext.
+func (mr *MockLambdaMockRecorder) GetLayerVersionByArnWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionByArnWithContext", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionByArnWithContext), varargs...)
+}
+
+// GetLayerVersionPolicy mocks base method.
+func (m *MockLambda) GetLayerVersionPolicy(arg0 *lambda.GetLayerVersionPolicyInput) (*lambda.GetLayerVersionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersionPolicy", arg0)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersionPolicy indicates an expected call of GetLayerVersionPolicy.
+func (mr *MockLambdaMockRecorder) GetLayerVersionPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionPolicy", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionPolicy), arg0)
+}
+
+// GetLayerVersionPolicyRequest mocks base method.
+func (m *MockLambda) GetLayerVersionPolicyRequest(arg0 *lambda.GetLayerVersionPolicyInput) (*request.Request, *lambda.GetLayerVersionPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersionPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetLayerVersionPolicyOutput)
+	return ret0, ret1
+}
+
+// GetLayerVersionPolicyRequest indicates an expected call of GetLayerVersionPolicyRequest.
+func (mr *MockLambdaMockRecorder) GetLayerVersionPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionPolicyRequest", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionPolicyRequest), arg0)
+}
+
+// GetLayerVersionPolicyWithContext mocks base method.
+func (m *MockLambda) GetLayerVersionPolicyWithContext(arg0 context.Context, arg1 *lambda.GetLayerVersionPolicyInput, arg2 ...request.Option) (*lambda.GetLayerVersionPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLayerVersionPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersionPolicyWithContext indicates an expected call of GetLayerVersionPolicyWithContext.
+func (mr *MockLambdaMockRecorder) GetLayerVersionPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionPolicyWithContext", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionPolicyWithContext), varargs...)
+}
+
+// GetLayerVersionRequest mocks base method.
+func (m *MockLambda) GetLayerVersionRequest(arg0 *lambda.GetLayerVersionInput) (*request.Request, *lambda.GetLayerVersionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayerVersionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetLayerVersionOutput)
+	return ret0, ret1
+}
+
+// GetLayerVersionRequest indicates an expected call of GetLayerVersionRequest.
+func (mr *MockLambdaMockRecorder) GetLayerVersionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionRequest", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionRequest), arg0)
+}
+
+// GetLayerVersionWithContext mocks base method.
+func (m *MockLambda) GetLayerVersionWithContext(arg0 context.Context, arg1 *lambda.GetLayerVersionInput, arg2 ...request.Option) (*lambda.GetLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLayerVersionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLayerVersionWithContext indicates an expected call of GetLayerVersionWithContext.
+func (mr *MockLambdaMockRecorder) GetLayerVersionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayerVersionWithContext", reflect.TypeOf((*MockLambda)(nil).GetLayerVersionWithContext), varargs...)
+}
+
+// GetPolicy mocks base method.
+func (m *MockLambda) GetPolicy(arg0 *lambda.GetPolicyInput) (*lambda.GetPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", arg0)
+	ret0, _ := ret[0].(*lambda.GetPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockLambdaMockRecorder) GetPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockLambda)(nil).GetPolicy), arg0)
+}
+
+// GetPolicyRequest mocks base method.
+func (m *MockLambda) GetPolicyRequest(arg0 *lambda.GetPolicyInput) (*request.Request, *lambda.GetPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetPolicyOutput)
+	return ret0, ret1
+}
+
+// GetPolicyRequest indicates an expected call of GetPolicyRequest.
+func (mr *MockLambdaMockRecorder) GetPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyRequest", reflect.TypeOf((*MockLambda)(nil).GetPolicyRequest), arg0)
+}
+
+// GetPolicyWithContext mocks base method.
+func (m *MockLambda) GetPolicyWithContext(arg0 context.Context, arg1 *lambda.GetPolicyInput, arg2 ...request.Option) (*lambda.GetPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyWithContext indicates an expected call of GetPolicyWithContext.
+func (mr *MockLambdaMockRecorder) GetPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyWithContext", reflect.TypeOf((*MockLambda)(nil).GetPolicyWithContext), varargs...)
+}
+
+// GetProvisionedConcurrencyConfig mocks base method.
+func (m *MockLambda) GetProvisionedConcurrencyConfig(arg0 *lambda.GetProvisionedConcurrencyConfigInput) (*lambda.GetProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisionedConcurrencyConfig", arg0)
+	ret0, _ := ret[0].(*lambda.GetProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisionedConcurrencyConfig indicates an expected call of GetProvisionedConcurrencyConfig.
+func (mr *MockLambdaMockRecorder) GetProvisionedConcurrencyConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionedConcurrencyConfig", reflect.TypeOf((*MockLambda)(nil).GetProvisionedConcurrencyConfig), arg0)
+}
+
+// GetProvisionedConcurrencyConfigRequest mocks base method.
+func (m *MockLambda) GetProvisionedConcurrencyConfigRequest(arg0 *lambda.GetProvisionedConcurrencyConfigInput) (*request.Request, *lambda.GetProvisionedConcurrencyConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisionedConcurrencyConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.GetProvisionedConcurrencyConfigOutput)
+	return ret0, ret1
+}
+
+// GetProvisionedConcurrencyConfigRequest indicates an expected call of GetProvisionedConcurrencyConfigRequest.
+func (mr *MockLambdaMockRecorder) GetProvisionedConcurrencyConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionedConcurrencyConfigRequest", reflect.TypeOf((*MockLambda)(nil).GetProvisionedConcurrencyConfigRequest), arg0)
+}
+
+// GetProvisionedConcurrencyConfigWithContext mocks base method.
+func (m *MockLambda) GetProvisionedConcurrencyConfigWithContext(arg0 context.Context, arg1 *lambda.GetProvisionedConcurrencyConfigInput, arg2 ...request.Option) (*lambda.GetProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProvisionedConcurrencyConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.GetProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisionedConcurrencyConfigWithContext indicates an expected call of GetProvisionedConcurrencyConfigWithContext.
+func (mr *MockLambdaMockRecorder) GetProvisionedConcurrencyConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisionedConcurrencyConfigWithContext", reflect.TypeOf((*MockLambda)(nil).GetProvisionedConcurrencyConfigWithContext), varargs...)
+}
+
+// Invoke mocks base method.
+func (m *MockLambda) Invoke(arg0 *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invoke", arg0)
+	ret0, _ := ret[0].(*lambda.InvokeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invoke indicates an expected call of Invoke.
+func (mr *MockLambdaMockRecorder) Invoke(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invoke", reflect.TypeOf((*MockLambda)(nil).Invoke), arg0)
+}
+
+// InvokeAsync mocks base method.
+func (m *MockLambda) InvokeAsync(arg0 *lambda.InvokeAsyncInput) (*lambda.InvokeAsyncOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeAsync", arg0)
+	ret0, _ := ret[0].(*lambda.InvokeAsyncOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeAsync indicates an expected call of InvokeAsync.
+func (mr *MockLambdaMockRecorder) InvokeAsync(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeAsync", reflect.TypeOf((*MockLambda)(nil).InvokeAsync), arg0)
+}
+
+// InvokeAsyncRequest mocks base method.
+func (m *MockLambda) InvokeAsyncRequest(arg0 *lambda.InvokeAsyncInput) (*request.Request, *lambda.InvokeAsyncOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeAsyncRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.InvokeAsyncOutput)
+	return ret0, ret1
+}
+
+// InvokeAsyncRequest indicates an expected call of InvokeAsyncRequest.
+func (mr *MockLambdaMockRecorder) InvokeAsyncRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeAsyncRequest", reflect.TypeOf((*MockLambda)(nil).InvokeAsyncRequest), arg0)
+}
+
+// InvokeAsyncWithContext mocks base method.
+func (m *MockLambda) InvokeAsyncWithContext(arg0 context.Context, arg1 *lambda.InvokeAsyncInput, arg2 ...request.Option) (*lambda.InvokeAsyncOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeAsyncWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.InvokeAsyncOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeAsyncWithContext indicates an expected call of InvokeAsyncWithContext.
+func (mr *MockLambdaMockRecorder) InvokeAsyncWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeAsyncWithContext", reflect.TypeOf((*MockLambda)(nil).InvokeAsyncWithContext), varargs...)
+}
+
+// InvokeRequest mocks base method.
+func (m *MockLambda) InvokeRequest(arg0 *lambda.InvokeInput) (*request.Request, *lambda.InvokeOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.InvokeOutput)
+	return ret0, ret1
+}
+
+// InvokeRequest indicates an expected call of InvokeRequest.
+func (mr *MockLambdaMockRecorder) InvokeRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeRequest", reflect.TypeOf((*MockLambda)(nil).InvokeRequest), arg0)
+}
+
+// InvokeWithContext mocks base method.
+func (m *MockLambda) InvokeWithContext(arg0 context.Context, arg1 *lambda.InvokeInput, arg2 ...request.Option) (*lambda.InvokeOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.InvokeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeWithContext indicates an expected call of InvokeWithContext.
+func (mr *MockLambdaMockRecorder) InvokeWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeWithContext", reflect.TypeOf((*MockLambda)(nil).InvokeWithContext), varargs...)
+}
+
+// ListAliases mocks base method.
+func (m *MockLambda) ListAliases(arg0 *lambda.ListAliasesInput) (*lambda.ListAliasesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliases", arg0)
+	ret0, _ := ret[0].(*lambda.ListAliasesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAliases indicates an expected call of ListAliases.
+func (mr *MockLambdaMockRecorder) ListAliases(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliases", reflect.TypeOf((*MockLambda)(nil).ListAliases), arg0)
+}
+
+// ListAliasesPages mocks base method.
+func (m *MockLambda) ListAliasesPages(arg0 *lambda.ListAliasesInput, arg1 func(*lambda.ListAliasesOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliasesPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListAliasesPages indicates an expected call of ListAliasesPages.
+func (mr *MockLambdaMockRecorder) ListAliasesPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliasesPages", reflect.TypeOf((*MockLambda)(nil).ListAliasesPages), arg0, arg1)
+}
+
+// ListAliasesPagesWithContext mocks base method.
+func (m *MockLambda) ListAliasesPagesWithContext(arg0 context.Context, arg1 *lambda.ListAliasesInput, arg2 func(*lambda.ListAliasesOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAliasesPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListAliasesPagesWithContext indicates an expected call of ListAliasesPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListAliasesPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliasesPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListAliasesPagesWithContext), varargs...)
+}
+
+// ListAliasesRequest mocks base method.
+func (m *MockLambda) ListAliasesRequest(arg0 *lambda.ListAliasesInput) (*request.Request, *lambda.ListAliasesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAliasesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListAliasesOutput)
+	return ret0, ret1
+}
+
+// ListAliasesRequest indicates an expected call of ListAliasesRequest.
+func (mr *MockLambdaMockRecorder) ListAliasesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliasesRequest", reflect.TypeOf((*MockLambda)(nil).ListAliasesRequest), arg0)
+}
+
+// ListAliasesWithContext mocks base method.
+func (m *MockLambda) ListAliasesWithContext(arg0 context.Context, arg1 *lambda.ListAliasesInput, arg2 ...request.Option) (*lambda.ListAliasesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAliasesWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListAliasesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAliasesWithContext indicates an expected call of ListAliasesWithContext.
+func (mr *MockLambdaMockRecorder) ListAliasesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAliasesWithContext", reflect.TypeOf((*MockLambda)(nil).ListAliasesWithContext), varargs...)
+}
+
+// ListCodeSigningConfigs mocks base method.
+func (m *MockLambda) ListCodeSigningConfigs(arg0 *lambda.ListCodeSigningConfigsInput) (*lambda.ListCodeSigningConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodeSigningConfigs", arg0)
+	ret0, _ := ret[0].(*lambda.ListCodeSigningConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCodeSigningConfigs indicates an expected call of ListCodeSigningConfigs.
+func (mr *MockLambdaMockRecorder) ListCodeSigningConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeSigningConfigs", reflect.TypeOf((*MockLambda)(nil).ListCodeSigningConfigs), arg0)
+}
+
+// ListCodeSigningConfigsPages mocks base method.
+func (m *MockLambda) ListCodeSigningConfigsPages(arg0 *lambda.ListCodeSigningConfigsInput, arg1 func(*lambda.ListCodeSigningConfigsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodeSigningConfigsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListCodeSigningConfigsPages indicates an expected call of ListCodeSigningConfigsPages.
+func (mr *MockLambdaMockRecorder) ListCodeSigningConfigsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeSigningConfigsPages", reflect.TypeOf((*MockLambda)(nil).ListCodeSigningConfigsPages), arg0, arg1)
+}
+
+// ListCodeSigningConfigsPagesWithContext mocks base method.
+func (m *MockLambda) ListCodeSigningConfigsPagesWithContext(arg0 context.Context, arg1 *lambda.ListCodeSigningConfigsInput, arg2 func(*lambda.ListCodeSigningConfigsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCodeSigningConfigsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListCodeSigningConfigsPagesWithContext indicates an expected call of ListCodeSigningConfigsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListCodeSigningConfigsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeSigningConfigsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListCodeSigningConfigsPagesWithContext), varargs...)
+}
+
+// ListCodeSigningConfigsRequest mocks base method.
+func (m *MockLambda) ListCodeSigningConfigsRequest(arg0 *lambda.ListCodeSigningConfigsInput) (*request.Request, *lambda.ListCodeSigningConfigsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodeSigningConfigsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListCodeSigningConfigsOutput)
+	return ret0, ret1
+}
+
+// ListCodeSigningConfigsRequest indicates an expected call of ListCodeSigningConfigsRequest.
+func (mr *MockLambdaMockRecorder) ListCodeSigningConfigsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeSigningConfigsRequest", reflect.TypeOf((*MockLambda)(nil).ListCodeSigningConfigsRequest), arg0)
+}
+
+// ListCodeSigningConfigsWithContext mocks base method.
+func (m *MockLambda) ListCodeSigningConfigsWithContext(arg0 context.Context, arg1 *lambda.ListCodeSigningConfigsInput, arg2 ...request.Option) (*lambda.ListCodeSigningConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCodeSigningConfigsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListCodeSigningConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCodeSigningConfigsWithContext indicates an expected call of ListCodeSigningConfigsWithContext.
+func (mr *MockLambdaMockRecorder) ListCodeSigningConfigsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeSigningConfigsWithContext", reflect.TypeOf((*MockLambda)(nil).ListCodeSigningConfigsWithContext), varargs...)
+}
+
+// ListEventSourceMappings mocks base method.
+func (m *MockLambda) ListEventSourceMappings(arg0 *lambda.ListEventSourceMappingsInput) (*lambda.ListEventSourceMappingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventSourceMappings", arg0)
+	ret0, _ := ret[0].(*lambda.ListEventSourceMappingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventSourceMappings indicates an expected call of ListEventSourceMappings.
+func (mr *MockLambdaMockRecorder) ListEventSourceMappings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappings", reflect.TypeOf((*MockLambda)(nil).ListEventSourceMappings), arg0)
+}
+
+// ListEventSourceMappingsPages mocks base method.
+func (m *MockLambda) ListEventSourceMappingsPages(arg0 *lambda.ListEventSourceMappingsInput, arg1 func(*lambda.ListEventSourceMappingsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventSourceMappingsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListEventSourceMappingsPages indicates an expected call of ListEventSourceMappingsPages.
+func (mr *MockLambdaMockRecorder) ListEventSourceMappingsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappingsPages", reflect.TypeOf((*MockLambda)(nil).ListEventSourceMappingsPages), arg0, arg1)
+}
+
+// ListEventSourceMappingsPagesWithContext mocks base method.
+func (m *MockLambda) ListEventSourceMappingsPagesWithContext(arg0 context.Context, arg1 *lambda.ListEventSourceMappingsInput, arg2 func(*lambda.ListEventSourceMappingsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEventSourceMappingsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListEventSourceMappingsPagesWithContext indicates an expected call of ListEventSourceMappingsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListEventSourceMappingsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappingsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListEventSourceMappingsPagesWithContext), varargs...)
+}
+
+// ListEventSourceMappingsRequest mocks base method.
+func (m *MockLambda) ListEventSourceMappingsRequest(arg0 *lambda.ListEventSourceMappingsInput) (*request.Request, *lambda.ListEventSourceMappingsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventSourceMappingsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListEventSourceMappingsOutput)
+	return ret0, ret1
+}
+
+// ListEventSourceMappingsRequest indicates an expected call of ListEventSourceMappingsRequest.
+func (mr *MockLambdaMockRecorder) ListEventSourceMappingsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappingsRequest", reflect.TypeOf((*MockLambda)(nil).ListEventSourceMappingsRequest), arg0)
+}
+
+// ListEventSourceMappingsWithContext mocks base method.
+func (m *MockLambda) ListEventSourceMappingsWithContext(arg0 context.Context, arg1 *lambda.ListEventSourceMappingsInput, arg2 ...request.Option) (*lambda.ListEventSourceMappingsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEventSourceMappingsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListEventSourceMappingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventSourceMappingsWithContext indicates an expected call of ListEventSourceMappingsWithContext.
+func (mr *MockLambdaMockRecorder) ListEventSourceMappingsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappingsWithContext", reflect.TypeOf((*MockLambda)(nil).ListEventSourceMappingsWithContext), varargs...)
+}
+
+// ListFunctionEventInvokeConfigs mocks base method.
+func (m *MockLambda) ListFunctionEventInvokeConfigs(arg0 *lambda.ListFunctionEventInvokeConfigsInput) (*lambda.ListFunctionEventInvokeConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionEventInvokeConfigs", arg0)
+	ret0, _ := ret[0].(*lambda.ListFunctionEventInvokeConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionEventInvokeConfigs indicates an expected call of ListFunctionEventInvokeConfigs.
+func (mr *MockLambdaMockRecorder) ListFunctionEventInvokeConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionEventInvokeConfigs", reflect.TypeOf((*MockLambda)(nil).ListFunctionEventInvokeConfigs), arg0)
+}
+
+// ListFunctionEventInvokeConfigsPages mocks base method.
+func (m *MockLambda) ListFunctionEventInvokeConfigsPages(arg0 *lambda.ListFunctionEventInvokeConfigsInput, arg1 func(*lambda.ListFunctionEventInvokeConfigsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionEventInvokeConfigsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionEventInvokeConfigsPages indicates an expected call of ListFunctionEventInvokeConfigsPages.
+func (mr *MockLambdaMockRecorder) ListFunctionEventInvokeConfigsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionEventInvokeConfigsPages", reflect.TypeOf((*MockLambda)(nil).ListFunctionEventInvokeConfigsPages), arg0, arg1)
+}
+
+// ListFunctionEventInvokeConfigsPagesWithContext mocks base method.
+func (m *MockLambda) ListFunctionEventInvokeConfigsPagesWithContext(arg0 context.Context, arg1 *lambda.ListFunctionEventInvokeConfigsInput, arg2 func(*lambda.ListFunctionEventInvokeConfigsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionEventInvokeConfigsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionEventInvokeConfigsPagesWithContext indicates an expected call of ListFunctionEventInvokeConfigsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionEventInvokeConfigsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionEventInvokeConfigsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionEventInvokeConfigsPagesWithContext), varargs...)
+}
+
+// ListFunctionEventInvokeConfigsRequest mocks base method.
+func (m *MockLambda) ListFunctionEventInvokeConfigsRequest(arg0 *lambda.ListFunctionEventInvokeConfigsInput) (*request.Request, *lambda.ListFunctionEventInvokeConfigsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionEventInvokeConfigsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListFunctionEventInvokeConfigsOutput)
+	return ret0, ret1
+}
+
+// ListFunctionEventInvokeConfigsRequest indicates an expected call of ListFunctionEventInvokeConfigsRequest.
+func (mr *MockLambdaMockRecorder) ListFunctionEventInvokeConfigsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionEventInvokeConfigsRequest", reflect.TypeOf((*MockLambda)(nil).ListFunctionEventInvokeConfigsRequest), arg0)
+}
+
+// ListFunctionEventInvokeConfigsWithContext mocks base method.
+func (m *MockLambda) ListFunctionEventInvokeConfigsWithContext(arg0 context.Context, arg1 *lambda.ListFunctionEventInvokeConfigsInput, arg2 ...request.Option) (*lambda.ListFunctionEventInvokeConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionEventInvokeConfigsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListFunctionEventInvokeConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionEventInvokeConfigsWithContext indicates an expected call of ListFunctionEventInvokeConfigsWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionEventInvokeConfigsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionEventInvokeConfigsWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionEventInvokeConfigsWithContext), varargs...)
+}
+
+// ListFunctions mocks base method.
+func (m *MockLambda) ListFunctions(arg0 *lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctions", arg0)
+	ret0, _ := ret[0].(*lambda.ListFunctionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctions indicates an expected call of ListFunctions.
+func (mr *MockLambdaMockRecorder) ListFunctions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctions", reflect.TypeOf((*MockLambda)(nil).ListFunctions), arg0)
+}
+
+// ListFunctionsByCodeSigningConfig mocks base method.
+func (m *MockLambda) ListFunctionsByCodeSigningConfig(arg0 *lambda.ListFunctionsByCodeSigningConfigInput) (*lambda.ListFunctionsByCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsByCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.ListFunctionsByCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionsByCodeSigningConfig indicates an expected call of ListFunctionsByCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) ListFunctionsByCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsByCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).ListFunctionsByCodeSigningConfig), arg0)
+}
+
+// ListFunctionsByCodeSigningConfigPages mocks base method.
+func (m *MockLambda) ListFunctionsByCodeSigningConfigPages(arg0 *lambda.ListFunctionsByCodeSigningConfigInput, arg1 func(*lambda.ListFunctionsByCodeSigningConfigOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsByCodeSigningConfigPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionsByCodeSigningConfigPages indicates an expected call of ListFunctionsByCodeSigningConfigPages.
+func (mr *MockLambdaMockRecorder) ListFunctionsByCodeSigningConfigPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsByCodeSigningConfigPages", reflect.TypeOf((*MockLambda)(nil).ListFunctionsByCodeSigningConfigPages), arg0, arg1)
+}
+
+// ListFunctionsByCodeSigningConfigPagesWithContext mocks base method.
+func (m *MockLambda) ListFunctionsByCodeSigningConfigPagesWithContext(arg0 context.Context, arg1 *lambda.ListFunctionsByCodeSigningConfigInput, arg2 func(*lambda.ListFunctionsByCodeSigningConfigOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionsByCodeSigningConfigPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionsByCodeSigningConfigPagesWithContext indicates an expected call of ListFunctionsByCodeSigningConfigPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionsByCodeSigningConfigPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsByCodeSigningConfigPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionsByCodeSigningConfigPagesWithContext), varargs...)
+}
+
+// ListFunctionsByCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) ListFunctionsByCodeSigningConfigRequest(arg0 *lambda.ListFunctionsByCodeSigningConfigInput) (*request.Request, *lambda.ListFunctionsByCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsByCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListFunctionsByCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// ListFunctionsByCodeSigningConfigRequest indicates an expected call of ListFunctionsByCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) ListFunctionsByCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsByCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).ListFunctionsByCodeSigningConfigRequest), arg0)
+}
+
+// ListFunctionsByCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) ListFunctionsByCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.ListFunctionsByCodeSigningConfigInput, arg2 ...request.Option) (*lambda.ListFunctionsByCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionsByCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListFunctionsByCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionsByCodeSigningConfigWithContext indicates an expected call of ListFunctionsByCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionsByCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsByCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionsByCodeSigningConfigWithContext), varargs...)
+}
+
+// ListFunctionsPages mocks base method.
+func (m *MockLambda) ListFunctionsPages(arg0 *lambda.ListFunctionsInput, arg1 func(*lambda.ListFunctionsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionsPages indicates an expected call of ListFunctionsPages.
+func (mr *MockLambdaMockRecorder) ListFunctionsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsPages", reflect.TypeOf((*MockLambda)(nil).ListFunctionsPages), arg0, arg1)
+}
+
+// ListFunctionsPagesWithContext mocks base method.
+func (m *MockLambda) ListFunctionsPagesWithContext(arg0 context.Context, arg1 *lambda.ListFunctionsInput, arg2 func(*lambda.ListFunctionsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListFunctionsPagesWithContext indicates an expected call of ListFunctionsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionsPagesWithContext), varargs...)
+}
+
+// ListFunctionsRequest mocks base method.
+func (m *MockLambda) ListFunctionsRequest(arg0 *lambda.ListFunctionsInput) (*request.Request, *lambda.ListFunctionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListFunctionsOutput)
+	return ret0, ret1
+}
+
+// ListFunctionsRequest indicates an expected call of ListFunctionsRequest.
+func (mr *MockLambdaMockRecorder) ListFunctionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsRequest", reflect.TypeOf((*MockLambda)(nil).ListFunctionsRequest), arg0)
+}
+
+// ListFunctionsWithContext mocks base method.
+func (m *MockLambda) ListFunctionsWithContext(arg0 context.Context, arg1 *lambda.ListFunctionsInput, arg2 ...request.Option) (*lambda.ListFunctionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFunctionsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListFunctionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionsWithContext indicates an expected call of ListFunctionsWithContext.
+func (mr *MockLambdaMockRecorder) ListFunctionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsWithContext", reflect.TypeOf((*MockLambda)(nil).ListFunctionsWithContext), varargs...)
+}
+
+// ListLayerVersions mocks base method.
+func (m *MockLambda) ListLayerVersions(arg0 *lambda.ListLayerVersionsInput) (*lambda.ListLayerVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayerVersions", arg0)
+	ret0, _ := ret[0].(*lambda.ListLayerVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLayerVersions indicates an expected call of ListLayerVersions.
+func (mr *MockLambdaMockRecorder) ListLayerVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersions", reflect.TypeOf((*MockLambda)(nil).ListLayerVersions), arg0)
+}
+
+// ListLayerVersionsPages mocks base method.
+func (m *MockLambda) ListLayerVersionsPages(arg0 *lambda.ListLayerVersionsInput, arg1 func(*lambda.ListLayerVersionsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayerVersionsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListLayerVersionsPages indicates an expected call of ListLayerVersionsPages.
+func (mr *MockLambdaMockRecorder) ListLayerVersionsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersionsPages", reflect.TypeOf((*MockLambda)(nil).ListLayerVersionsPages), arg0, arg1)
+}
+
+// ListLayerVersionsPagesWithContext mocks base method.
+func (m *MockLambda) ListLayerVersionsPagesWithContext(arg0 context.Context, arg1 *lambda.ListLayerVersionsInput, arg2 func(*lambda.ListLayerVersionsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLayerVersionsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListLayerVersionsPagesWithContext indicates an expected call of ListLayerVersionsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListLayerVersionsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersionsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListLayerVersionsPagesWithContext), varargs...)
+}
+
+// ListLayerVersionsRequest mocks base method.
+func (m *MockLambda) ListLayerVersionsRequest(arg0 *lambda.ListLayerVersionsInput) (*request.Request, *lambda.ListLayerVersionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayerVersionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListLayerVersionsOutput)
+	return ret0, ret1
+}
+
+// ListLayerVersionsRequest indicates an expected call of ListLayerVersionsRequest.
+func (mr *MockLambdaMockRecorder) ListLayerVersionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersionsRequest", reflect.TypeOf((*MockLambda)(nil).ListLayerVersionsRequest), arg0)
+}
+
+// ListLayerVersionsWithContext mocks base method.
+func (m *MockLambda) ListLayerVersionsWithContext(arg0 context.Context, arg1 *lambda.ListLayerVersionsInput, arg2 ...request.Option) (*lambda.ListLayerVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLayerVersionsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListLayerVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLayerVersionsWithContext indicates an expected call of ListLayerVersionsWithContext.
+func (mr *MockLambdaMockRecorder) ListLayerVersionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersionsWithContext", reflect.TypeOf((*MockLambda)(nil).ListLayerVersionsWithContext), varargs...)
+}
+
+// ListLayers mocks base method.
+func (m *MockLambda) ListLayers(arg0 *lambda.ListLayersInput) (*lambda.ListLayersOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayers", arg0)
+	ret0, _ := ret[0].(*lambda.ListLayersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLayers indicates an expected call of ListLayers.
+func (mr *MockLambdaMockRecorder) ListLayers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayers", reflect.TypeOf((*MockLambda)(nil).ListLayers), arg0)
+}
+
+// ListLayersPages mocks base method.
+func (m *MockLambda) ListLayersPages(arg0 *lambda.ListLayersInput, arg1 func(*lambda.ListLayersOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayersPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListLayersPages indicates an expected call of ListLayersPages.
+func (mr *MockLambdaMockRecorder) ListLayersPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayersPages", reflect.TypeOf((*MockLambda)(nil).ListLayersPages), arg0, arg1)
+}
+
+// ListLayersPagesWithContext mocks base method.
+func (m *MockLambda) ListLayersPagesWithContext(arg0 context.Context, arg1 *lambda.ListLayersInput, arg2 func(*lambda.ListLayersOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLayersPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListLayersPagesWithContext indicates an expected call of ListLayersPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListLayersPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayersPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListLayersPagesWithContext), varargs...)
+}
+
+// ListLayersRequest mocks base method.
+func (m *MockLambda) ListLayersRequest(arg0 *lambda.ListLayersInput) (*request.Request, *lambda.ListLayersOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayersRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListLayersOutput)
+	return ret0, ret1
+}
+
+// ListLayersRequest indicates an expected call of ListLayersRequest.
+func (mr *MockLambdaMockRecorder) ListLayersRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayersRequest", reflect.TypeOf((*MockLambda)(nil).ListLayersRequest), arg0)
+}
+
+// ListLayersWithContext mocks base method.
+func (m *MockLambda) ListLayersWithContext(arg0 context.Context, arg1 *lambda.ListLayersInput, arg2 ...request.Option) (*lambda.ListLayersOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLayersWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListLayersOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLayersWithContext indicates an expected call of ListLayersWithContext.
+func (mr *MockLambdaMockRecorder) ListLayersWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayersWithContext", reflect.TypeOf((*MockLambda)(nil).ListLayersWithContext), varargs...)
+}
+
+// ListProvisionedConcurrencyConfigs mocks base method.
+func (m *MockLambda) ListProvisionedConcurrencyConfigs(arg0 *lambda.ListProvisionedConcurrencyConfigsInput) (*lambda.ListProvisionedConcurrencyConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProvisionedConcurrencyConfigs", arg0)
+	ret0, _ := ret[0].(*lambda.ListProvisionedConcurrencyConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProvisionedConcurrencyConfigs indicates an expected call of ListProvisionedConcurrencyConfigs.
+func (mr *MockLambdaMockRecorder) ListProvisionedConcurrencyConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProvisionedConcurrencyConfigs", reflect.TypeOf((*MockLambda)(nil).ListProvisionedConcurrencyConfigs), arg0)
+}
+
+// ListProvisionedConcurrencyConfigsPages mocks base method.
+func (m *MockLambda) ListProvisionedConcurrencyConfigsPages(arg0 *lambda.ListProvisionedConcurrencyConfigsInput, arg1 func(*lambda.ListProvisionedConcurrencyConfigsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProvisionedConcurrencyConfigsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListProvisionedConcurrencyConfigsPages indicates an expected call of ListProvisionedConcurrencyConfigsPages.
+func (mr *MockLambdaMockRecorder) ListProvisionedConcurrencyConfigsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProvisionedConcurrencyConfigsPages", reflect.TypeOf((*MockLambda)(nil).ListProvisionedConcurrencyConfigsPages), arg0, arg1)
+}
+
+// ListProvisionedConcurrencyConfigsPagesWithContext mocks base method.
+func (m *MockLambda) ListProvisionedConcurrencyConfigsPagesWithContext(arg0 context.Context, arg1 *lambda.ListProvisionedConcurrencyConfigsInput, arg2 func(*lambda.ListProvisionedConcurrencyConfigsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProvisionedConcurrencyConfigsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListProvisionedConcurrencyConfigsPagesWithContext indicates an expected call of ListProvisionedConcurrencyConfigsPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListProvisionedConcurrencyConfigsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProvisionedConcurrencyConfigsPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListProvisionedConcurrencyConfigsPagesWithContext), varargs...)
+}
+
+// ListProvisionedConcurrencyConfigsRequest mocks base method.
+func (m *MockLambda) ListProvisionedConcurrencyConfigsRequest(arg0 *lambda.ListProvisionedConcurrencyConfigsInput) (*request.Request, *lambda.ListProvisionedConcurrencyConfigsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProvisionedConcurrencyConfigsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListProvisionedConcurrencyConfigsOutput)
+	return ret0, ret1
+}
+
+// ListProvisionedConcurrencyConfigsRequest indicates an expected call of ListProvisionedConcurrencyConfigsRequest.
+func (mr *MockLambdaMockRecorder) ListProvisionedConcurrencyConfigsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProvisionedConcurrencyConfigsRequest", reflect.TypeOf((*MockLambda)(nil).ListProvisionedConcurrencyConfigsRequest), arg0)
+}
+
+// ListProvisionedConcurrencyConfigsWithContext mocks base method.
+func (m *MockLambda) ListProvisionedConcurrencyConfigsWithContext(arg0 context.Context, arg1 *lambda.ListProvisionedConcurrencyConfigsInput, arg2 ...request.Option) (*lambda.ListProvisionedConcurrencyConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProvisionedConcurrencyConfigsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListProvisionedConcurrencyConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProvisionedConcurrencyConfigsWithContext indicates an expected call of ListProvisionedConcurrencyConfigsWithContext.
+func (mr *MockLambdaMockRecorder) ListProvisionedConcurrencyConfigsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProvisionedConcurrencyConfigsWithContext", reflect.TypeOf((*MockLambda)(nil).ListProvisionedConcurrencyConfigsWithContext), varargs...)
+}
+
+// ListTags mocks base method.
+func (m *MockLambda) ListTags(arg0 *lambda.ListTagsInput) (*lambda.ListTagsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0)
+	ret0, _ := ret[0].(*lambda.ListTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockLambdaMockRecorder) ListTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockLambda)(nil).ListTags), arg0)
+}
+
+// ListTagsRequest mocks base method.
+func (m *MockLambda) ListTagsRequest(arg0 *lambda.ListTagsInput) (*request.Request, *lambda.ListTagsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListTagsOutput)
+	return ret0, ret1
+}
+
+// ListTagsRequest indicates an expected call of ListTagsRequest.
+func (mr *MockLambdaMockRecorder) ListTagsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsRequest", reflect.TypeOf((*MockLambda)(nil).ListTagsRequest), arg0)
+}
+
+// ListTagsWithContext mocks base method.
+func (m *MockLambda) ListTagsWithContext(arg0 context.Context, arg1 *lambda.ListTagsInput, arg2 ...request.Option) (*lambda.ListTagsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListTagsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsWithContext indicates an expected call of ListTagsWithContext.
+func (mr *MockLambdaMockRecorder) ListTagsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsWithContext", reflect.TypeOf((*MockLambda)(nil).ListTagsWithContext), varargs...)
+}
+
+// ListVersionsByFunction mocks base method.
+func (m *MockLambda) ListVersionsByFunction(arg0 *lambda.ListVersionsByFunctionInput) (*lambda.ListVersionsByFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersionsByFunction", arg0)
+	ret0, _ := ret[0].(*lambda.ListVersionsByFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersionsByFunction indicates an expected call of ListVersionsByFunction.
+func (mr *MockLambdaMockRecorder) ListVersionsByFunction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionsByFunction", reflect.TypeOf((*MockLambda)(nil).ListVersionsByFunction), arg0)
+}
+
+// ListVersionsByFunctionPages mocks base method.
+func (m *MockLambda) ListVersionsByFunctionPages(arg0 *lambda.ListVersionsByFunctionInput, arg1 func(*lambda.ListVersionsByFunctionOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersionsByFunctionPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListVersionsByFunctionPages indicates an expected call of ListVersionsByFunctionPages.
+func (mr *MockLambdaMockRecorder) ListVersionsByFunctionPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionsByFunctionPages", reflect.TypeOf((*MockLambda)(nil).ListVersionsByFunctionPages), arg0, arg1)
+}
+
+// ListVersionsByFunctionPagesWithContext mocks base method.
+func (m *MockLambda) ListVersionsByFunctionPagesWithContext(arg0 context.Context, arg1 *lambda.ListVersionsByFunctionInput, arg2 func(*lambda.ListVersionsByFunctionOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListVersionsByFunctionPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListVersionsByFunctionPagesWithContext indicates an expected call of ListVersionsByFunctionPagesWithContext.
+func (mr *MockLambdaMockRecorder) ListVersionsByFunctionPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionsByFunctionPagesWithContext", reflect.TypeOf((*MockLambda)(nil).ListVersionsByFunctionPagesWithContext), varargs...)
+}
+
+// ListVersionsByFunctionRequest mocks base method.
+func (m *MockLambda) ListVersionsByFunctionRequest(arg0 *lambda.ListVersionsByFunctionInput) (*request.Request, *lambda.ListVersionsByFunctionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersionsByFunctionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.ListVersionsByFunctionOutput)
+	return ret0, ret1
+}
+
+// ListVersionsByFunctionRequest indicates an expected call of ListVersionsByFunctionRequest.
+func (mr *MockLambdaMockRecorder) ListVersionsByFunctionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionsByFunctionRequest", reflect.TypeOf((*MockLambda)(nil).ListVersionsByFunctionRequest), arg0)
+}
+
+// ListVersionsByFunctionWithContext mocks base method.
+func (m *MockLambda) ListVersionsByFunctionWithContext(arg0 context.Context, arg1 *lambda.ListVersionsByFunctionInput, arg2 ...request.Option) (*lambda.ListVersionsByFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListVersionsByFunctionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.ListVersionsByFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersionsByFunctionWithContext indicates an expected call of ListVersionsByFunctionWithContext.
+func (mr *MockLambdaMockRecorder) ListVersionsByFunctionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionsByFunctionWithContext", reflect.TypeOf((*MockLambda)(nil).ListVersionsByFunctionWithContext), varargs...)
+}
+
+// PublishLayerVersion mocks base method.
+func (m *MockLambda) PublishLayerVersion(arg0 *lambda.PublishLayerVersionInput) (*lambda.PublishLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishLayerVersion", arg0)
+	ret0, _ := ret[0].(*lambda.PublishLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishLayerVersion indicates an expected call of PublishLayerVersion.
+func (mr *MockLambdaMockRecorder) PublishLayerVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishLayerVersion", reflect.TypeOf((*MockLambda)(nil).PublishLayerVersion), arg0)
+}
+
+// PublishLayerVersionRequest mocks base method.
+func (m *MockLambda) PublishLayerVersionRequest(arg0 *lambda.PublishLayerVersionInput) (*request.Request, *lambda.PublishLayerVersionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishLayerVersionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.PublishLayerVersionOutput)
+	return ret0, ret1
+}
+
+// PublishLayerVersionRequest indicates an expected call of PublishLayerVersionRequest.
+func (mr *MockLambdaMockRecorder) PublishLayerVersionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishLayerVersionRequest", reflect.TypeOf((*MockLambda)(nil).PublishLayerVersionRequest), arg0)
+}
+
+// PublishLayerVersionWithContext mocks base method.
+func (m *MockLambda) PublishLayerVersionWithContext(arg0 context.Context, arg1 *lambda.PublishLayerVersionInput, arg2 ...request.Option) (*lambda.PublishLayerVersionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishLayerVersionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.PublishLayerVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishLayerVersionWithContext indicates an expected call of PublishLayerVersionWithContext.
+func (mr *MockLambdaMockRecorder) PublishLayerVersionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishLayerVersionWithContext", reflect.TypeOf((*MockLambda)(nil).PublishLayerVersionWithContext), varargs...)
+}
+
+// PublishVersion mocks base method.
+func (m *MockLambda) PublishVersion(arg0 *lambda.PublishVersionInput) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishVersion", arg0)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishVersion indicates an expected call of PublishVersion.
+func (mr *MockLambdaMockRecorder) PublishVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVersion", reflect.TypeOf((*MockLambda)(nil).PublishVersion), arg0)
+}
+
+// PublishVersionRequest mocks base method.
+func (m *MockLambda) PublishVersionRequest(arg0 *lambda.PublishVersionInput) (*request.Request, *lambda.FunctionConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishVersionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.FunctionConfiguration)
+	return ret0, ret1
+}
+
+// PublishVersionRequest indicates an expected call of PublishVersionRequest.
+func (mr *MockLambdaMockRecorder) PublishVersionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVersionRequest", reflect.TypeOf((*MockLambda)(nil).PublishVersionRequest), arg0)
+}
+
+// PublishVersionWithContext mocks base method.
+func (m *MockLambda) PublishVersionWithContext(arg0 context.Context, arg1 *lambda.PublishVersionInput, arg2 ...request.Option) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishVersionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishVersionWithContext indicates an expected call of PublishVersionWithContext.
+func (mr *MockLambdaMockRecorder) PublishVersionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVersionWithContext", reflect.TypeOf((*MockLambda)(nil).PublishVersionWithContext), varargs...)
+}
+
+// PutFunctionCodeSigningConfig mocks base method.
+func (m *MockLambda) PutFunctionCodeSigningConfig(arg0 *lambda.PutFunctionCodeSigningConfigInput) (*lambda.PutFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.PutFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionCodeSigningConfig indicates an expected call of PutFunctionCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) PutFunctionCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).PutFunctionCodeSigningConfig), arg0)
+}
+
+// PutFunctionCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) PutFunctionCodeSigningConfigRequest(arg0 *lambda.PutFunctionCodeSigningConfigInput) (*request.Request, *lambda.PutFunctionCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.PutFunctionCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// PutFunctionCodeSigningConfigRequest indicates an expected call of PutFunctionCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) PutFunctionCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).PutFunctionCodeSigningConfigRequest), arg0)
+}
+
+// PutFunctionCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) PutFunctionCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.PutFunctionCodeSigningConfigInput, arg2 ...request.Option) (*lambda.PutFunctionCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFunctionCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.PutFunctionCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionCodeSigningConfigWithContext indicates an expected call of PutFunctionCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) PutFunctionCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).PutFunctionCodeSigningConfigWithContext), varargs...)
+}
+
+// PutFunctionConcurrency mocks base method.
+func (m *MockLambda) PutFunctionConcurrency(arg0 *lambda.PutFunctionConcurrencyInput) (*lambda.PutFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionConcurrency", arg0)
+	ret0, _ := ret[0].(*lambda.PutFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionConcurrency indicates an expected call of PutFunctionConcurrency.
+func (mr *MockLambdaMockRecorder) PutFunctionConcurrency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionConcurrency", reflect.TypeOf((*MockLambda)(nil).PutFunctionConcurrency), arg0)
+}
+
+// PutFunctionConcurrencyRequest mocks base method.
+func (m *MockLambda) PutFunctionConcurrencyRequest(arg0 *lambda.PutFunctionConcurrencyInput) (*request.Request, *lambda.PutFunctionConcurrencyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionConcurrencyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.PutFunctionConcurrencyOutput)
+	return ret0, ret1
+}
+
+// PutFunctionConcurrencyRequest indicates an expected call of PutFunctionConcurrencyRequest.
+func (mr *MockLambdaMockRecorder) PutFunctionConcurrencyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionConcurrencyRequest", reflect.TypeOf((*MockLambda)(nil).PutFunctionConcurrencyRequest), arg0)
+}
+
+// PutFunctionConcurrencyWithContext mocks base method.
+func (m *MockLambda) PutFunctionConcurrencyWithContext(arg0 context.Context, arg1 *lambda.PutFunctionConcurrencyInput, arg2 ...request.Option) (*lambda.PutFunctionConcurrencyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFunctionConcurrencyWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.PutFunctionConcurrencyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionConcurrencyWithContext indicates an expected call of PutFunctionConcurrencyWithContext.
+func (mr *MockLambdaMockRecorder) PutFunctionConcurrencyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionConcurrencyWithContext", reflect.TypeOf((*MockLambda)(nil).PutFunctionConcurrencyWithContext), varargs...)
+}
+
+// PutFunctionEventInvokeConfig mocks base method.
+func (m *MockLambda) PutFunctionEventInvokeConfig(arg0 *lambda.PutFunctionEventInvokeConfigInput) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionEventInvokeConfig", arg0)
+	ret0, _ := ret[0].(*lambda.PutFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionEventInvokeConfig indicates an expected call of PutFunctionEventInvokeConfig.
+func (mr *MockLambdaMockRecorder) PutFunctionEventInvokeConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionEventInvokeConfig", reflect.TypeOf((*MockLambda)(nil).PutFunctionEventInvokeConfig), arg0)
+}
+
+// PutFunctionEventInvokeConfigRequest mocks base method.
+func (m *MockLambda) PutFunctionEventInvokeConfigRequest(arg0 *lambda.PutFunctionEventInvokeConfigInput) (*request.Request, *lambda.PutFunctionEventInvokeConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFunctionEventInvokeConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.PutFunctionEventInvokeConfigOutput)
+	return ret0, ret1
+}
+
+// PutFunctionEventInvokeConfigRequest indicates an expected call of PutFunctionEventInvokeConfigRequest.
+func (mr *MockLambdaMockRecorder) PutFunctionEventInvokeConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionEventInvokeConfigRequest", reflect.TypeOf((*MockLambda)(nil).PutFunctionEventInvokeConfigRequest), arg0)
+}
+
+// PutFunctionEventInvokeConfigWithContext mocks base method.
+func (m *MockLambda) PutFunctionEventInvokeConfigWithContext(arg0 context.Context, arg1 *lambda.PutFunctionEventInvokeConfigInput, arg2 ...request.Option) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFunctionEventInvokeConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.PutFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionEventInvokeConfigWithContext indicates an expected call of PutFunctionEventInvokeConfigWithContext.
+func (mr *MockLambdaMockRecorder) PutFunctionEventInvokeConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionEventInvokeConfigWithContext", reflect.TypeOf((*MockLambda)(nil).PutFunctionEventInvokeConfigWithContext), varargs...)
+}
+
+// PutProvisionedConcurrencyConfig mocks base method.
+func (m *MockLambda) PutProvisionedConcurrencyConfig(arg0 *lambda.PutProvisionedConcurrencyConfigInput) (*lambda.PutProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutProvisionedConcurrencyConfig", arg0)
+	ret0, _ := ret[0].(*lambda.PutProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutProvisionedConcurrencyConfig indicates an expected call of PutProvisionedConcurrencyConfig.
+func (mr *MockLambdaMockRecorder) PutProvisionedConcurrencyConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutProvisionedConcurrencyConfig", reflect.TypeOf((*MockLambda)(nil).PutProvisionedConcurrencyConfig), arg0)
+}
+
+// PutProvisionedConcurrencyConfigRequest mocks base method.
+func (m *MockLambda) PutProvisionedConcurrencyConfigRequest(arg0 *lambda.PutProvisionedConcurrencyConfigInput) (*request.Request, *lambda.PutProvisionedConcurrencyConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutProvisionedConcurrencyConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.PutProvisionedConcurrencyConfigOutput)
+	return ret0, ret1
+}
+
+// PutProvisionedConcurrencyConfigRequest indicates an expected call of PutProvisionedConcurrencyConfigRequest.
+func (mr *MockLambdaMockRecorder) PutProvisionedConcurrencyConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutProvisionedConcurrencyConfigRequest", reflect.TypeOf((*MockLambda)(nil).PutProvisionedConcurrencyConfigRequest), arg0)
+}
+
+// PutProvisionedConcurrencyConfigWithContext mocks base method.
+func (m *MockLambda) PutProvisionedConcurrencyConfigWithContext(arg0 context.Context, arg1 *lambda.PutProvisionedConcurrencyConfigInput, arg2 ...request.Option) (*lambda.PutProvisionedConcurrencyConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutProvisionedConcurrencyConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.PutProvisionedConcurrencyConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutProvisionedConcurrencyConfigWithContext indicates an expected call of PutProvisionedConcurrencyConfigWithContext.
+func (mr *MockLambdaMockRecorder) PutProvisionedConcurrencyConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutProvisionedConcurrencyConfigWithContext", reflect.TypeOf((*MockLambda)(nil).PutProvisionedConcurrencyConfigWithContext), varargs...)
+}
+
+// RemoveLayerVersionPermission mocks base method.
+func (m *MockLambda) RemoveLayerVersionPermission(arg0 *lambda.RemoveLayerVersionPermissionInput) (*lambda.RemoveLayerVersionPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLayerVersionPermission", arg0)
+	ret0, _ := ret[0].(*lambda.RemoveLayerVersionPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveLayerVersionPermission indicates an expected call of RemoveLayerVersionPermission.
+func (mr *MockLambdaMockRecorder) RemoveLayerVersionPermission(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLayerVersionPermission", reflect.TypeOf((*MockLambda)(nil).RemoveLayerVersionPermission), arg0)
+}
+
+// RemoveLayerVersionPermissionRequest mocks base method.
+func (m *MockLambda) RemoveLayerVersionPermissionRequest(arg0 *lambda.RemoveLayerVersionPermissionInput) (*request.Request, *lambda.RemoveLayerVersionPermissionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLayerVersionPermissionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.RemoveLayerVersionPermissionOutput)
+	return ret0, ret1
+}
+
+// RemoveLayerVersionPermissionRequest indicates an expected call of RemoveLayerVersionPermissionRequest.
+func (mr *MockLambdaMockRecorder) RemoveLayerVersionPermissionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLayerVersionPermissionRequest", reflect.TypeOf((*MockLambda)(nil).RemoveLayerVersionPermissionRequest), arg0)
+}
+
+// RemoveLayerVersionPermissionWithContext mocks base method.
+func (m *MockLambda) RemoveLayerVersionPermissionWithContext(arg0 context.Context, arg1 *lambda.RemoveLayerVersionPermissionInput, arg2 ...request.Option) (*lambda.RemoveLayerVersionPermissionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveLayerVersionPermissionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.RemoveLayerVersionPermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveLayerVersionPermissionWithContext indicates an expected call of RemoveLayerVersionPermissionWithContext.
+func (mr *MockLambdaMockRecorder) RemoveLayerVersionPermissionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLayerVersionPermissionWithContext", reflect.TypeOf((*MockLambda)(nil).RemoveLayerVersionPermissionWithContext), varargs...)
+}
+
+// RemovePermission mocks base method.
+func (m *MockLambda) RemovePermission(arg0 *lambda.RemovePermissionInput) (*lambda.RemovePermissionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePermission", arg0)
+	ret0, _ := ret[0].(*lambda.RemovePermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePermission indicates an expected call of RemovePermission.
+func (mr *MockLambdaMockRecorder) RemovePermission(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePermission", reflect.TypeOf((*MockLambda)(nil).RemovePermission), arg0)
+}
+
+// RemovePermissionRequest mocks base method.
+func (m *MockLambda) RemovePermissionRequest(arg0 *lambda.RemovePermissionInput) (*request.Request, *lambda.RemovePermissionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePermissionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.RemovePermissionOutput)
+	return ret0, ret1
+}
+
+// RemovePermissionRequest indicates an expected call of RemovePermissionRequest.
+func (mr *MockLambdaMockRecorder) RemovePermissionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePermissionRequest", reflect.TypeOf((*MockLambda)(nil).RemovePermissionRequest), arg0)
+}
+
+// RemovePermissionWithContext mocks base method.
+func (m *MockLambda) RemovePermissionWithContext(arg0 context.Context, arg1 *lambda.RemovePermissionInput, arg2 ...request.Option) (*lambda.RemovePermissionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemovePermissionWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.RemovePermissionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePermissionWithContext indicates an expected call of RemovePermissionWithContext.
+func (mr *MockLambdaMockRecorder) RemovePermissionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePermissionWithContext", reflect.TypeOf((*MockLambda)(nil).RemovePermissionWithContext), varargs...)
+}
+
+// TagResource mocks base method.
+func (m *MockLambda) TagResource(arg0 *lambda.TagResourceInput) (*lambda.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResource", arg0)
+	ret0, _ := ret[0].(*lambda.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResource indicates an expected call of TagResource.
+func (mr *MockLambdaMockRecorder) TagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResource", reflect.TypeOf((*MockLambda)(nil).TagResource), arg0)
+}
+
+// TagResourceRequest mocks base method.
+func (m *MockLambda) TagResourceRequest(arg0 *lambda.TagResourceInput) (*request.Request, *lambda.TagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.TagResourceOutput)
+	return ret0, ret1
+}
+
+// TagResourceRequest indicates an expected call of TagResourceRequest.
+func (mr *MockLambdaMockRecorder) TagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceRequest", reflect.TypeOf((*MockLambda)(nil).TagResourceRequest), arg0)
+}
+
+// TagResourceWithContext mocks base method.
+func (m *MockLambda) TagResourceWithContext(arg0 context.Context, arg1 *lambda.TagResourceInput, arg2 ...request.Option) (*lambda.TagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.TagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagResourceWithContext indicates an expected call of TagResourceWithContext.
+func (mr *MockLambdaMockRecorder) TagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagResourceWithContext", reflect.TypeOf((*MockLambda)(nil).TagResourceWithContext), varargs...)
+}
+
+// UntagResource mocks base method.
+func (m *MockLambda) UntagResource(arg0 *lambda.UntagResourceInput) (*lambda.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResource", arg0)
+	ret0, _ := ret[0].(*lambda.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResource indicates an expected call of UntagResource.
+func (mr *MockLambdaMockRecorder) UntagResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResource", reflect.TypeOf((*MockLambda)(nil).UntagResource), arg0)
+}
+
+// UntagResourceRequest mocks base method.
+func (m *MockLambda) UntagResourceRequest(arg0 *lambda.UntagResourceInput) (*request.Request, *lambda.UntagResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.UntagResourceOutput)
+	return ret0, ret1
+}
+
+// UntagResourceRequest indicates an expected call of UntagResourceRequest.
+func (mr *MockLambdaMockRecorder) UntagResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceRequest", reflect.TypeOf((*MockLambda)(nil).UntagResourceRequest), arg0)
+}
+
+// UntagResourceWithContext mocks base method.
+func (m *MockLambda) UntagResourceWithContext(arg0 context.Context, arg1 *lambda.UntagResourceInput, arg2 ...request.Option) (*lambda.UntagResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UntagResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.UntagResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntagResourceWithContext indicates an expected call of UntagResourceWithContext.
+func (mr *MockLambdaMockRecorder) UntagResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagResourceWithContext", reflect.TypeOf((*MockLambda)(nil).UntagResourceWithContext), varargs...)
+}
+
+// UpdateAlias mocks base method.
+func (m *MockLambda) UpdateAlias(arg0 *lambda.UpdateAliasInput) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlias", arg0)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlias indicates an expected call of UpdateAlias.
+func (mr *MockLambdaMockRecorder) UpdateAlias(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlias", reflect.TypeOf((*MockLambda)(nil).UpdateAlias), arg0)
+}
+
+// UpdateAliasRequest mocks base method.
+func (m *MockLambda) UpdateAliasRequest(arg0 *lambda.UpdateAliasInput) (*request.Request, *lambda.AliasConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAliasRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.AliasConfiguration)
+	return ret0, ret1
+}
+
+// UpdateAliasRequest indicates an expected call of UpdateAliasRequest.
+func (mr *MockLambdaMockRecorder) UpdateAliasRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAliasRequest", reflect.TypeOf((*MockLambda)(nil).UpdateAliasRequest), arg0)
+}
+
+// UpdateAliasWithContext mocks base method.
+func (m *MockLambda) UpdateAliasWithContext(arg0 context.Context, arg1 *lambda.UpdateAliasInput, arg2 ...request.Option) (*lambda.AliasConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateAliasWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.AliasConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAliasWithContext indicates an expected call of UpdateAliasWithContext.
+func (mr *MockLambdaMockRecorder) UpdateAliasWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAliasWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateAliasWithContext), varargs...)
+}
+
+// UpdateCodeSigningConfig mocks base method.
+func (m *MockLambda) UpdateCodeSigningConfig(arg0 *lambda.UpdateCodeSigningConfigInput) (*lambda.UpdateCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCodeSigningConfig", arg0)
+	ret0, _ := ret[0].(*lambda.UpdateCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCodeSigningConfig indicates an expected call of UpdateCodeSigningConfig.
+func (mr *MockLambdaMockRecorder) UpdateCodeSigningConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCodeSigningConfig", reflect.TypeOf((*MockLambda)(nil).UpdateCodeSigningConfig), arg0)
+}
+
+// UpdateCodeSigningConfigRequest mocks base method.
+func (m *MockLambda) UpdateCodeSigningConfigRequest(arg0 *lambda.UpdateCodeSigningConfigInput) (*request.Request, *lambda.UpdateCodeSigningConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCodeSigningConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.UpdateCodeSigningConfigOutput)
+	return ret0, ret1
+}
+
+// UpdateCodeSigningConfigRequest indicates an expected call of UpdateCodeSigningConfigRequest.
+func (mr *MockLambdaMockRecorder) UpdateCodeSigningConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCodeSigningConfigRequest", reflect.TypeOf((*MockLambda)(nil).UpdateCodeSigningConfigRequest), arg0)
+}
+
+// UpdateCodeSigningConfigWithContext mocks base method.
+func (m *MockLambda) UpdateCodeSigningConfigWithContext(arg0 context.Context, arg1 *lambda.UpdateCodeSigningConfigInput, arg2 ...request.Option) (*lambda.UpdateCodeSigningConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCodeSigningConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.UpdateCodeSigningConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCodeSigningConfigWithContext indicates an expected call of UpdateCodeSigningConfigWithContext.
+func (mr *MockLambdaMockRecorder) UpdateCodeSigningConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCodeSigningConfigWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateCodeSigningConfigWithContext), varargs...)
+}
+
+// UpdateEventSourceMapping mocks base method.
+func (m *MockLambda) UpdateEventSourceMapping(arg0 *lambda.UpdateEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventSourceMapping", arg0)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEventSourceMapping indicates an expected call of UpdateEventSourceMapping.
+func (mr *MockLambdaMockRecorder) UpdateEventSourceMapping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventSourceMapping", reflect.TypeOf((*MockLambda)(nil).UpdateEventSourceMapping), arg0)
+}
+
+// UpdateEventSourceMappingRequest mocks base method.
+func (m *MockLambda) UpdateEventSourceMappingRequest(arg0 *lambda.UpdateEventSourceMappingInput) (*request.Request, *lambda.EventSourceMappingConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventSourceMappingRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.EventSourceMappingConfiguration)
+	return ret0, ret1
+}
+
+// UpdateEventSourceMappingRequest indicates an expected call of UpdateEventSourceMappingRequest.
+func (mr *MockLambdaMockRecorder) UpdateEventSourceMappingRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventSourceMappingRequest", reflect.TypeOf((*MockLambda)(nil).UpdateEventSourceMappingRequest), arg0)
+}
+
+// UpdateEventSourceMappingWithContext mocks base method.
+func (m *MockLambda) UpdateEventSourceMappingWithContext(arg0 context.Context, arg1 *lambda.UpdateEventSourceMappingInput, arg2 ...request.Option) (*lambda.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateEventSourceMappingWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEventSourceMappingWithContext indicates an expected call of UpdateEventSourceMappingWithContext.
+func (mr *MockLambdaMockRecorder) UpdateEventSourceMappingWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventSourceMappingWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateEventSourceMappingWithContext), varargs...)
+}
+
+// UpdateFunctionCode mocks base method.
+func (m *MockLambda) UpdateFunctionCode(arg0 *lambda.UpdateFunctionCodeInput) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionCode", arg0)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionCode indicates an expected call of UpdateFunctionCode.
+func (mr *MockLambdaMockRecorder) UpdateFunctionCode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionCode", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionCode), arg0)
+}
+
+// UpdateFunctionCodeRequest mocks base method.
+func (m *MockLambda) UpdateFunctionCodeRequest(arg0 *lambda.UpdateFunctionCodeInput) (*request.Request, *lambda.FunctionConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionCodeRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.FunctionConfiguration)
+	return ret0, ret1
+}
+
+// UpdateFunctionCodeRequest indicates an expected call of UpdateFunctionCodeRequest.
+func (mr *MockLambdaMockRecorder) UpdateFunctionCodeRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionCodeRequest", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionCodeRequest), arg0)
+}
+
+// UpdateFunctionCodeWithContext mocks base method.
+func (m *MockLambda) UpdateFunctionCodeWithContext(arg0 context.Context, arg1 *lambda.UpdateFunctionCodeInput, arg2 ...request.Option) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFunctionCodeWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionCodeWithContext indicates an expected call of UpdateFunctionCodeWithContext.
+func (mr *MockLambdaMockRecorder) UpdateFunctionCodeWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionCodeWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionCodeWithContext), varargs...)
+}
+
+// UpdateFunctionConfiguration mocks base method.
+func (m *MockLambda) UpdateFunctionConfiguration(arg0 *lambda.UpdateFunctionConfigurationInput) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionConfiguration", arg0)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionConfiguration indicates an expected call of UpdateFunctionConfiguration.
+func (mr *MockLambdaMockRecorder) UpdateFunctionConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionConfiguration", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionConfiguration), arg0)
+}
+
+// UpdateFunctionConfigurationRequest mocks base method.
+func (m *MockLambda) UpdateFunctionConfigurationRequest(arg0 *lambda.UpdateFunctionConfigurationInput) (*request.Request, *lambda.FunctionConfiguration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionConfigurationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.FunctionConfiguration)
+	return ret0, ret1
+}
+
+// UpdateFunctionConfigurationRequest indicates an expected call of UpdateFunctionConfigurationRequest.
+func (mr *MockLambdaMockRecorder) UpdateFunctionConfigurationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionConfigurationRequest", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionConfigurationRequest), arg0)
+}
+
+// UpdateFunctionConfigurationWithContext mocks base method.
+func (m *MockLambda) UpdateFunctionConfigurationWithContext(arg0 context.Context, arg1 *lambda.UpdateFunctionConfigurationInput, arg2 ...request.Option) (*lambda.FunctionConfiguration, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFunctionConfigurationWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.FunctionConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionConfigurationWithContext indicates an expected call of UpdateFunctionConfigurationWithContext.
+func (mr *MockLambdaMockRecorder) UpdateFunctionConfigurationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionConfigurationWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionConfigurationWithContext), varargs...)
+}
+
+// UpdateFunctionEventInvokeConfig mocks base method.
+func (m *MockLambda) UpdateFunctionEventInvokeConfig(arg0 *lambda.UpdateFunctionEventInvokeConfigInput) (*lambda.UpdateFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionEventInvokeConfig", arg0)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionEventInvokeConfig indicates an expected call of UpdateFunctionEventInvokeConfig.
+func (mr *MockLambdaMockRecorder) UpdateFunctionEventInvokeConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionEventInvokeConfig", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionEventInvokeConfig), arg0)
+}
+
+// UpdateFunctionEventInvokeConfigRequest mocks base method.
+func (m *MockLambda) UpdateFunctionEventInvokeConfigRequest(arg0 *lambda.UpdateFunctionEventInvokeConfigInput) (*request.Request, *lambda.UpdateFunctionEventInvokeConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFunctionEventInvokeConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*lambda.UpdateFunctionEventInvokeConfigOutput)
+	return ret0, ret1
+}
+
+// UpdateFunctionEventInvokeConfigRequest indicates an expected call of UpdateFunctionEventInvokeConfigRequest.
+func (mr *MockLambdaMockRecorder) UpdateFunctionEventInvokeConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionEventInvokeConfigRequest", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionEventInvokeConfigRequest), arg0)
+}
+
+// UpdateFunctionEventInvokeConfigWithContext mocks base method.
+func (m *MockLambda) UpdateFunctionEventInvokeConfigWithContext(arg0 context.Context, arg1 *lambda.UpdateFunctionEventInvokeConfigInput, arg2 ...request.Option) (*lambda.UpdateFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFunctionEventInvokeConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionEventInvokeConfigWithContext indicates an expected call of UpdateFunctionEventInvokeConfigWithContext.
+func (mr *MockLambdaMockRecorder) UpdateFunctionEventInvokeConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionEventInvokeConfigWithContext", reflect.TypeOf((*MockLambda)(nil).UpdateFunctionEventInvokeConfigWithContext), varargs...)
+}
+
+// WaitUntilFunctionActive mocks base method.
+func (m *MockLambda) WaitUntilFunctionActive(arg0 *lambda.GetFunctionConfigurationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilFunctionActive", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionActive indicates an expected call of WaitUntilFunctionActive.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionActive(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionActive", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionActive), arg0)
+}
+
+// WaitUntilFunctionActiveWithContext mocks base method.
+func (m *MockLambda) WaitUntilFunctionActiveWithContext(arg0 context.Context, arg1 *lambda.GetFunctionConfigurationInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilFunctionActiveWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionActiveWithContext indicates an expected call of WaitUntilFunctionActiveWithContext.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionActiveWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionActiveWithContext", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionActiveWithContext), varargs...)
+}
+
+// WaitUntilFunctionExists mocks base method.
+func (m *MockLambda) WaitUntilFunctionExists(arg0 *lambda.GetFunctionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilFunctionExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionExists indicates an expected call of WaitUntilFunctionExists.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionExists", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionExists), arg0)
+}
+
+// WaitUntilFunctionExistsWithContext mocks base method.
+func (m *MockLambda) WaitUntilFunctionExistsWithContext(arg0 context.Context, arg1 *lambda.GetFunctionInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilFunctionExistsWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionExistsWithContext indicates an expected call of WaitUntilFunctionExistsWithContext.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionExistsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionExistsWithContext", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionExistsWithContext), varargs...)
+}
+
+// WaitUntilFunctionUpdated mocks base method.
+func (m *MockLambda) WaitUntilFunctionUpdated(arg0 *lambda.GetFunctionConfigurationInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilFunctionUpdated", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionUpdated indicates an expected call of WaitUntilFunctionUpdated.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionUpdated(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionUpdated", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionUpdated), arg0)
+}
+
+// WaitUntilFunctionUpdatedWithContext mocks base method.
+func (m *MockLambda) WaitUntilFunctionUpdatedWithContext(arg0 context.Context, arg1 *lambda.GetFunctionConfigurationInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilFunctionUpdatedWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilFunctionUpdatedWithContext indicates an expected call of WaitUntilFunctionUpdatedWithContext.
+func (mr *MockLambdaMockRecorder) WaitUntilFunctionUpdatedWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilFunctionUpdatedWithContext", reflect.TypeOf((*MockLambda)(nil).WaitUntilFunctionUpdatedWithContext), varargs...)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+)
+
+type Route53 interface {
+	route53iface.Route53API
+
+	// wrapper to ListResourceRecordSetsPagesWithContext API, which aggregates paged results into list.
+	ListResourceRecordSetsAsList(ctx context.Context, input *route53.ListResourceRecordSetsInput) ([]*route53.ResourceRecordSet, error)
+}
+
+// NewRoute53 constructs new Route53 implementation.
+func NewRoute53(session *session.Session) Route53 {
+	return &defaultRoute53{
+		Route53API: route53.New(session),
+	}
+}
+
+// default implementation for Route53.
+type defaultRoute53 struct {
+	route53iface.Route53API
+}
+
+func (c *defaultRoute53) ListResourceRecordSetsAsList(ctx context.Context, input *route53.ListResourceRecordSetsInput) ([]*route53.ResourceRecordSet, error) {
+	var result []*route53.ResourceRecordSet
+	if err := c.ListResourceRecordSetsPagesWithContext(ctx, input, func(output *route53.ListResourceRecordSetsOutput, _ bool) bool {
+		result = append(result, output.ResourceRecordSets...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
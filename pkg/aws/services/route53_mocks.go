@@ -0,0 +1,3417 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services (interfaces: Route53)
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	request "github.com/aws/aws-sdk-go/aws/request"
+	route53 "github.com/aws/aws-sdk-go/service/route53"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRoute53 is a mock of Route53 interface.
+type MockRoute53 struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoute53MockRecorder
+}
+
+// MockRoute53MockRecorder is the mock recorder for MockRoute53.
+type MockRoute53MockRecorder struct {
+	mock *MockRoute53
+}
+
+// NewMockRoute53 creates a new mock instance.
+func NewMockRoute53(ctrl *gomock.Controller) *MockRoute53 {
+	mock := &MockRoute53{ctrl: ctrl}
+	mock.recorder = &MockRoute53MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoute53) EXPECT() *MockRoute53MockRecorder {
+	return m.recorder
+}
+
+// ActivateKeySigningKey mocks base method.
+func (m *MockRoute53) ActivateKeySigningKey(arg0 *route53.ActivateKeySigningKeyInput) (*route53.ActivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.ActivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateKeySigningKey indicates an expected call of ActivateKeySigningKey.
+func (mr *MockRoute53MockRecorder) ActivateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeySigningKey", reflect.TypeOf((*MockRoute53)(nil).ActivateKeySigningKey), arg0)
+}
+
+// ActivateKeySigningKeyRequest mocks base method.
+func (m *MockRoute53) ActivateKeySigningKeyRequest(arg0 *route53.ActivateKeySigningKeyInput) (*request.Request, *route53.ActivateKeySigningKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateKeySigningKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ActivateKeySigningKeyOutput)
+	return ret0, ret1
+}
+
+// ActivateKeySigningKeyRequest indicates an expected call of ActivateKeySigningKeyRequest.
+func (mr *MockRoute53MockRecorder) ActivateKeySigningKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeySigningKeyRequest", reflect.TypeOf((*MockRoute53)(nil).ActivateKeySigningKeyRequest), arg0)
+}
+
+// ActivateKeySigningKeyWithContext mocks base method.
+func (m *MockRoute53) ActivateKeySigningKeyWithContext(arg0 context.Context, arg1 *route53.ActivateKeySigningKeyInput, arg2 ...request.Option) (*route53.ActivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActivateKeySigningKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ActivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateKeySigningKeyWithContext indicates an expected call of ActivateKeySigningKeyWithContext.
+func (mr *MockRoute53MockRecorder) ActivateKeySigningKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateKeySigningKeyWithContext", reflect.TypeOf((*MockRoute53)(nil).ActivateKeySigningKeyWithContext), varargs...)
+}
+
+// AssociateVPCWithHostedZone mocks base method.
+func (m *MockRoute53) AssociateVPCWithHostedZone(arg0 *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateVPCWithHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.AssociateVPCWithHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateVPCWithHostedZone indicates an expected call of AssociateVPCWithHostedZone.
+func (mr *MockRoute53MockRecorder) AssociateVPCWithHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateVPCWithHostedZone", reflect.TypeOf((*MockRoute53)(nil).AssociateVPCWithHostedZone), arg0)
+}
+
+// AssociateVPCWithHostedZoneRequest mocks base method.
+func (m *MockRoute53) AssociateVPCWithHostedZoneRequest(arg0 *route53.AssociateVPCWithHostedZoneInput) (*request.Request, *route53.AssociateVPCWithHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateVPCWithHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.AssociateVPCWithHostedZoneOutput)
+	return ret0, ret1
+}
+
+// AssociateVPCWithHostedZoneRequest indicates an expected call of AssociateVPCWithHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) AssociateVPCWithHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateVPCWithHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).AssociateVPCWithHostedZoneRequest), arg0)
+}
+
+// AssociateVPCWithHostedZoneWithContext mocks base method.
+func (m *MockRoute53) AssociateVPCWithHostedZoneWithContext(arg0 context.Context, arg1 *route53.AssociateVPCWithHostedZoneInput, arg2 ...request.Option) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AssociateVPCWithHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.AssociateVPCWithHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateVPCWithHostedZoneWithContext indicates an expected call of AssociateVPCWithHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) AssociateVPCWithHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateVPCWithHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).AssociateVPCWithHostedZoneWithContext), varargs...)
+}
+
+// ChangeResourceRecordSets mocks base method.
+func (m *MockRoute53) ChangeResourceRecordSets(arg0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeResourceRecordSets", arg0)
+	ret0, _ := ret[0].(*route53.ChangeResourceRecordSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeResourceRecordSets indicates an expected call of ChangeResourceRecordSets.
+func (mr *MockRoute53MockRecorder) ChangeResourceRecordSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSets", reflect.TypeOf((*MockRoute53)(nil).ChangeResourceRecordSets), arg0)
+}
+
+// ChangeResourceRecordSetsRequest mocks base method.
+func (m *MockRoute53) ChangeResourceRecordSetsRequest(arg0 *route53.ChangeResourceRecordSetsInput) (*request.Request, *route53.ChangeResourceRecordSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeResourceRecordSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ChangeResourceRecordSetsOutput)
+	return ret0, ret1
+}
+
+// ChangeResourceRecordSetsRequest indicates an expected call of ChangeResourceRecordSetsRequest.
+func (mr *MockRoute53MockRecorder) ChangeResourceRecordSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSetsRequest", reflect.TypeOf((*MockRoute53)(nil).ChangeResourceRecordSetsRequest), arg0)
+}
+
+// ChangeResourceRecordSetsWithContext mocks base method.
+func (m *MockRoute53) ChangeResourceRecordSetsWithContext(arg0 context.Context, arg1 *route53.ChangeResourceRecordSetsInput, arg2 ...request.Option) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangeResourceRecordSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ChangeResourceRecordSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeResourceRecordSetsWithContext indicates an expected call of ChangeResourceRecordSetsWithContext.
+func (mr *MockRoute53MockRecorder) ChangeResourceRecordSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeResourceRecordSetsWithContext", reflect.TypeOf((*MockRoute53)(nil).ChangeResourceRecordSetsWithContext), varargs...)
+}
+
+// ChangeTagsForResource mocks base method.
+func (m *MockRoute53) ChangeTagsForResource(arg0 *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTagsForResource", arg0)
+	ret0, _ := ret[0].(*route53.ChangeTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeTagsForResource indicates an expected call of ChangeTagsForResource.
+func (mr *MockRoute53MockRecorder) ChangeTagsForResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTagsForResource", reflect.TypeOf((*MockRoute53)(nil).ChangeTagsForResource), arg0)
+}
+
+// ChangeTagsForResourceRequest mocks base method.
+func (m *MockRoute53) ChangeTagsForResourceRequest(arg0 *route53.ChangeTagsForResourceInput) (*request.Request, *route53.ChangeTagsForResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTagsForResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ChangeTagsForResourceOutput)
+	return ret0, ret1
+}
+
+// ChangeTagsForResourceRequest indicates an expected call of ChangeTagsForResourceRequest.
+func (mr *MockRoute53MockRecorder) ChangeTagsForResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTagsForResourceRequest", reflect.TypeOf((*MockRoute53)(nil).ChangeTagsForResourceRequest), arg0)
+}
+
+// ChangeTagsForResourceWithContext mocks base method.
+func (m *MockRoute53) ChangeTagsForResourceWithContext(arg0 context.Context, arg1 *route53.ChangeTagsForResourceInput, arg2 ...request.Option) (*route53.ChangeTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangeTagsForResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ChangeTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeTagsForResourceWithContext indicates an expected call of ChangeTagsForResourceWithContext.
+func (mr *MockRoute53MockRecorder) ChangeTagsForResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTagsForResourceWithContext", reflect.TypeOf((*MockRoute53)(nil).ChangeTagsForResourceWithContext), varargs...)
+}
+
+// CreateHealthCheck mocks base method.
+func (m *MockRoute53) CreateHealthCheck(arg0 *route53.CreateHealthCheckInput) (*route53.CreateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHealthCheck", arg0)
+	ret0, _ := ret[0].(*route53.CreateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHealthCheck indicates an expected call of CreateHealthCheck.
+func (mr *MockRoute53MockRecorder) CreateHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHealthCheck", reflect.TypeOf((*MockRoute53)(nil).CreateHealthCheck), arg0)
+}
+
+// CreateHealthCheckRequest mocks base method.
+func (m *MockRoute53) CreateHealthCheckRequest(arg0 *route53.CreateHealthCheckInput) (*request.Request, *route53.CreateHealthCheckOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHealthCheckRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateHealthCheckOutput)
+	return ret0, ret1
+}
+
+// CreateHealthCheckRequest indicates an expected call of CreateHealthCheckRequest.
+func (mr *MockRoute53MockRecorder) CreateHealthCheckRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHealthCheckRequest", reflect.TypeOf((*MockRoute53)(nil).CreateHealthCheckRequest), arg0)
+}
+
+// CreateHealthCheckWithContext mocks base method.
+func (m *MockRoute53) CreateHealthCheckWithContext(arg0 context.Context, arg1 *route53.CreateHealthCheckInput, arg2 ...request.Option) (*route53.CreateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateHealthCheckWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHealthCheckWithContext indicates an expected call of CreateHealthCheckWithContext.
+func (mr *MockRoute53MockRecorder) CreateHealthCheckWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHealthCheckWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateHealthCheckWithContext), varargs...)
+}
+
+// CreateHostedZone mocks base method.
+func (m *MockRoute53) CreateHostedZone(arg0 *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.CreateHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHostedZone indicates an expected call of CreateHostedZone.
+func (mr *MockRoute53MockRecorder) CreateHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHostedZone", reflect.TypeOf((*MockRoute53)(nil).CreateHostedZone), arg0)
+}
+
+// CreateHostedZoneRequest mocks base method.
+func (m *MockRoute53) CreateHostedZoneRequest(arg0 *route53.CreateHostedZoneInput) (*request.Request, *route53.CreateHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateHostedZoneOutput)
+	return ret0, ret1
+}
+
+// CreateHostedZoneRequest indicates an expected call of CreateHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) CreateHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).CreateHostedZoneRequest), arg0)
+}
+
+// CreateHostedZoneWithContext mocks base method.
+func (m *MockRoute53) CreateHostedZoneWithContext(arg0 context.Context, arg1 *route53.CreateHostedZoneInput, arg2 ...request.Option) (*route53.CreateHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHostedZoneWithContext indicates an expected call of CreateHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) CreateHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateHostedZoneWithContext), varargs...)
+}
+
+// CreateKeySigningKey mocks base method.
+func (m *MockRoute53) CreateKeySigningKey(arg0 *route53.CreateKeySigningKeyInput) (*route53.CreateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.CreateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKeySigningKey indicates an expected call of CreateKeySigningKey.
+func (mr *MockRoute53MockRecorder) CreateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeySigningKey", reflect.TypeOf((*MockRoute53)(nil).CreateKeySigningKey), arg0)
+}
+
+// CreateKeySigningKeyRequest mocks base method.
+func (m *MockRoute53) CreateKeySigningKeyRequest(arg0 *route53.CreateKeySigningKeyInput) (*request.Request, *route53.CreateKeySigningKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKeySigningKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateKeySigningKeyOutput)
+	return ret0, ret1
+}
+
+// CreateKeySigningKeyRequest indicates an expected call of CreateKeySigningKeyRequest.
+func (mr *MockRoute53MockRecorder) CreateKeySigningKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeySigningKeyRequest", reflect.TypeOf((*MockRoute53)(nil).CreateKeySigningKeyRequest), arg0)
+}
+
+// CreateKeySigningKeyWithContext mocks base method.
+func (m *MockRoute53) CreateKeySigningKeyWithContext(arg0 context.Context, arg1 *route53.CreateKeySigningKeyInput, arg2 ...request.Option) (*route53.CreateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateKeySigningKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKeySigningKeyWithContext indicates an expected call of CreateKeySigningKeyWithContext.
+func (mr *MockRoute53MockRecorder) CreateKeySigningKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeySigningKeyWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateKeySigningKeyWithContext), varargs...)
+}
+
+// CreateQueryLoggingConfig mocks base method.
+func (m *MockRoute53) CreateQueryLoggingConfig(arg0 *route53.CreateQueryLoggingConfigInput) (*route53.CreateQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueryLoggingConfig", arg0)
+	ret0, _ := ret[0].(*route53.CreateQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueryLoggingConfig indicates an expected call of CreateQueryLoggingConfig.
+func (mr *MockRoute53MockRecorder) CreateQueryLoggingConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueryLoggingConfig", reflect.TypeOf((*MockRoute53)(nil).CreateQueryLoggingConfig), arg0)
+}
+
+// CreateQueryLoggingConfigRequest mocks base method.
+func (m *MockRoute53) CreateQueryLoggingConfigRequest(arg0 *route53.CreateQueryLoggingConfigInput) (*request.Request, *route53.CreateQueryLoggingConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueryLoggingConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateQueryLoggingConfigOutput)
+	return ret0, ret1
+}
+
+// CreateQueryLoggingConfigRequest indicates an expected call of CreateQueryLoggingConfigRequest.
+func (mr *MockRoute53MockRecorder) CreateQueryLoggingConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueryLoggingConfigRequest", reflect.TypeOf((*MockRoute53)(nil).CreateQueryLoggingConfigRequest), arg0)
+}
+
+// CreateQueryLoggingConfigWithContext mocks base method.
+func (m *MockRoute53) CreateQueryLoggingConfigWithContext(arg0 context.Context, arg1 *route53.CreateQueryLoggingConfigInput, arg2 ...request.Option) (*route53.CreateQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateQueryLoggingConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueryLoggingConfigWithContext indicates an expected call of CreateQueryLoggingConfigWithContext.
+func (mr *MockRoute53MockRecorder) CreateQueryLoggingConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueryLoggingConfigWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateQueryLoggingConfigWithContext), varargs...)
+}
+
+// CreateReusableDelegationSet mocks base method.
+func (m *MockRoute53) CreateReusableDelegationSet(arg0 *route53.CreateReusableDelegationSetInput) (*route53.CreateReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReusableDelegationSet", arg0)
+	ret0, _ := ret[0].(*route53.CreateReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReusableDelegationSet indicates an expected call of CreateReusableDelegationSet.
+func (mr *MockRoute53MockRecorder) CreateReusableDelegationSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReusableDelegationSet", reflect.TypeOf((*MockRoute53)(nil).CreateReusableDelegationSet), arg0)
+}
+
+// CreateReusableDelegationSetRequest mocks base method.
+func (m *MockRoute53) CreateReusableDelegationSetRequest(arg0 *route53.CreateReusableDelegationSetInput) (*request.Request, *route53.CreateReusableDelegationSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReusableDelegationSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateReusableDelegationSetOutput)
+	return ret0, ret1
+}
+
+// CreateReusableDelegationSetRequest indicates an expected call of CreateReusableDelegationSetRequest.
+func (mr *MockRoute53MockRecorder) CreateReusableDelegationSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReusableDelegationSetRequest", reflect.TypeOf((*MockRoute53)(nil).CreateReusableDelegationSetRequest), arg0)
+}
+
+// CreateReusableDelegationSetWithContext mocks base method.
+func (m *MockRoute53) CreateReusableDelegationSetWithContext(arg0 context.Context, arg1 *route53.CreateReusableDelegationSetInput, arg2 ...request.Option) (*route53.CreateReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateReusableDelegationSetWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReusableDelegationSetWithContext indicates an expected call of CreateReusableDelegationSetWithContext.
+func (mr *MockRoute53MockRecorder) CreateReusableDelegationSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReusableDelegationSetWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateReusableDelegationSetWithContext), varargs...)
+}
+
+// CreateTrafficPolicy mocks base method.
+func (m *MockRoute53) CreateTrafficPolicy(arg0 *route53.CreateTrafficPolicyInput) (*route53.CreateTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicy", arg0)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicy indicates an expected call of CreateTrafficPolicy.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicy", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicy), arg0)
+}
+
+// CreateTrafficPolicyInstance mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyInstance(arg0 *route53.CreateTrafficPolicyInstanceInput) (*route53.CreateTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyInstance", arg0)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyInstance indicates an expected call of CreateTrafficPolicyInstance.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyInstance", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyInstance), arg0)
+}
+
+// CreateTrafficPolicyInstanceRequest mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyInstanceRequest(arg0 *route53.CreateTrafficPolicyInstanceInput) (*request.Request, *route53.CreateTrafficPolicyInstanceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyInstanceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateTrafficPolicyInstanceOutput)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyInstanceRequest indicates an expected call of CreateTrafficPolicyInstanceRequest.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyInstanceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyInstanceRequest", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyInstanceRequest), arg0)
+}
+
+// CreateTrafficPolicyInstanceWithContext mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyInstanceWithContext(arg0 context.Context, arg1 *route53.CreateTrafficPolicyInstanceInput, arg2 ...request.Option) (*route53.CreateTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyInstanceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyInstanceWithContext indicates an expected call of CreateTrafficPolicyInstanceWithContext.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyInstanceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyInstanceWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyInstanceWithContext), varargs...)
+}
+
+// CreateTrafficPolicyRequest mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyRequest(arg0 *route53.CreateTrafficPolicyInput) (*request.Request, *route53.CreateTrafficPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateTrafficPolicyOutput)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyRequest indicates an expected call of CreateTrafficPolicyRequest.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyRequest", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyRequest), arg0)
+}
+
+// CreateTrafficPolicyVersion mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyVersion(arg0 *route53.CreateTrafficPolicyVersionInput) (*route53.CreateTrafficPolicyVersionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyVersion", arg0)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyVersion indicates an expected call of CreateTrafficPolicyVersion.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyVersion", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyVersion), arg0)
+}
+
+// CreateTrafficPolicyVersionRequest mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyVersionRequest(arg0 *route53.CreateTrafficPolicyVersionInput) (*request.Request, *route53.CreateTrafficPolicyVersionOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyVersionRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateTrafficPolicyVersionOutput)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyVersionRequest indicates an expected call of CreateTrafficPolicyVersionRequest.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyVersionRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyVersionRequest", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyVersionRequest), arg0)
+}
+
+// CreateTrafficPolicyVersionWithContext mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyVersionWithContext(arg0 context.Context, arg1 *route53.CreateTrafficPolicyVersionInput, arg2 ...request.Option) (*route53.CreateTrafficPolicyVersionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyVersionWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyVersionWithContext indicates an expected call of CreateTrafficPolicyVersionWithContext.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyVersionWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyVersionWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyVersionWithContext), varargs...)
+}
+
+// CreateTrafficPolicyWithContext mocks base method.
+func (m *MockRoute53) CreateTrafficPolicyWithContext(arg0 context.Context, arg1 *route53.CreateTrafficPolicyInput, arg2 ...request.Option) (*route53.CreateTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTrafficPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrafficPolicyWithContext indicates an expected call of CreateTrafficPolicyWithContext.
+func (mr *MockRoute53MockRecorder) CreateTrafficPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrafficPolicyWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateTrafficPolicyWithContext), varargs...)
+}
+
+// CreateVPCAssociationAuthorization mocks base method.
+func (m *MockRoute53) CreateVPCAssociationAuthorization(arg0 *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCAssociationAuthorization", arg0)
+	ret0, _ := ret[0].(*route53.CreateVPCAssociationAuthorizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVPCAssociationAuthorization indicates an expected call of CreateVPCAssociationAuthorization.
+func (mr *MockRoute53MockRecorder) CreateVPCAssociationAuthorization(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCAssociationAuthorization", reflect.TypeOf((*MockRoute53)(nil).CreateVPCAssociationAuthorization), arg0)
+}
+
+// CreateVPCAssociationAuthorizationRequest mocks base method.
+func (m *MockRoute53) CreateVPCAssociationAuthorizationRequest(arg0 *route53.CreateVPCAssociationAuthorizationInput) (*request.Request, *route53.CreateVPCAssociationAuthorizationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVPCAssociationAuthorizationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.CreateVPCAssociationAuthorizationOutput)
+	return ret0, ret1
+}
+
+// CreateVPCAssociationAuthorizationRequest indicates an expected call of CreateVPCAssociationAuthorizationRequest.
+func (mr *MockRoute53MockRecorder) CreateVPCAssociationAuthorizationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCAssociationAuthorizationRequest", reflect.TypeOf((*MockRoute53)(nil).CreateVPCAssociationAuthorizationRequest), arg0)
+}
+
+// CreateVPCAssociationAuthorizationWithContext mocks base method.
+func (m *MockRoute53) CreateVPCAssociationAuthorizationWithContext(arg0 context.Context, arg1 *route53.CreateVPCAssociationAuthorizationInput, arg2 ...request.Option) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateVPCAssociationAuthorizationWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.CreateVPCAssociationAuthorizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVPCAssociationAuthorizationWithContext indicates an expected call of CreateVPCAssociationAuthorizationWithContext.
+func (mr *MockRoute53MockRecorder) CreateVPCAssociationAuthorizationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVPCAssociationAuthorizationWithContext", reflect.TypeOf((*MockRoute53)(nil).CreateVPCAssociationAuthorizationWithContext), varargs...)
+}
+
+// DeactivateKeySigningKey mocks base method.
+func (m *MockRoute53) DeactivateKeySigningKey(arg0 *route53.DeactivateKeySigningKeyInput) (*route53.DeactivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.DeactivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKeySigningKey indicates an expected call of DeactivateKeySigningKey.
+func (mr *MockRoute53MockRecorder) DeactivateKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKeySigningKey", reflect.TypeOf((*MockRoute53)(nil).DeactivateKeySigningKey), arg0)
+}
+
+// DeactivateKeySigningKeyRequest mocks base method.
+func (m *MockRoute53) DeactivateKeySigningKeyRequest(arg0 *route53.DeactivateKeySigningKeyInput) (*request.Request, *route53.DeactivateKeySigningKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateKeySigningKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeactivateKeySigningKeyOutput)
+	return ret0, ret1
+}
+
+// DeactivateKeySigningKeyRequest indicates an expected call of DeactivateKeySigningKeyRequest.
+func (mr *MockRoute53MockRecorder) DeactivateKeySigningKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKeySigningKeyRequest", reflect.TypeOf((*MockRoute53)(nil).DeactivateKeySigningKeyRequest), arg0)
+}
+
+// DeactivateKeySigningKeyWithContext mocks base method.
+func (m *MockRoute53) DeactivateKeySigningKeyWithContext(arg0 context.Context, arg1 *route53.DeactivateKeySigningKeyInput, arg2 ...request.Option) (*route53.DeactivateKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeactivateKeySigningKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeactivateKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateKeySigningKeyWithContext indicates an expected call of DeactivateKeySigningKeyWithContext.
+func (mr *MockRoute53MockRecorder) DeactivateKeySigningKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateKeySigningKeyWithContext", reflect.TypeOf((*MockRoute53)(nil).DeactivateKeySigningKeyWithContext), varargs...)
+}
+
+// DeleteHealthCheck mocks base method.
+func (m *MockRoute53) DeleteHealthCheck(arg0 *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHealthCheck", arg0)
+	ret0, _ := ret[0].(*route53.DeleteHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHealthCheck indicates an expected call of DeleteHealthCheck.
+func (mr *MockRoute53MockRecorder) DeleteHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHealthCheck", reflect.TypeOf((*MockRoute53)(nil).DeleteHealthCheck), arg0)
+}
+
+// DeleteHealthCheckRequest mocks base method.
+func (m *MockRoute53) DeleteHealthCheckRequest(arg0 *route53.DeleteHealthCheckInput) (*request.Request, *route53.DeleteHealthCheckOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHealthCheckRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteHealthCheckOutput)
+	return ret0, ret1
+}
+
+// DeleteHealthCheckRequest indicates an expected call of DeleteHealthCheckRequest.
+func (mr *MockRoute53MockRecorder) DeleteHealthCheckRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHealthCheckRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteHealthCheckRequest), arg0)
+}
+
+// DeleteHealthCheckWithContext mocks base method.
+func (m *MockRoute53) DeleteHealthCheckWithContext(arg0 context.Context, arg1 *route53.DeleteHealthCheckInput, arg2 ...request.Option) (*route53.DeleteHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteHealthCheckWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHealthCheckWithContext indicates an expected call of DeleteHealthCheckWithContext.
+func (mr *MockRoute53MockRecorder) DeleteHealthCheckWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHealthCheckWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteHealthCheckWithContext), varargs...)
+}
+
+// DeleteHostedZone mocks base method.
+func (m *MockRoute53) DeleteHostedZone(arg0 *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.DeleteHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHostedZone indicates an expected call of DeleteHostedZone.
+func (mr *MockRoute53MockRecorder) DeleteHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHostedZone", reflect.TypeOf((*MockRoute53)(nil).DeleteHostedZone), arg0)
+}
+
+// DeleteHostedZoneRequest mocks base method.
+func (m *MockRoute53) DeleteHostedZoneRequest(arg0 *route53.DeleteHostedZoneInput) (*request.Request, *route53.DeleteHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteHostedZoneOutput)
+	return ret0, ret1
+}
+
+// DeleteHostedZoneRequest indicates an expected call of DeleteHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) DeleteHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteHostedZoneRequest), arg0)
+}
+
+// DeleteHostedZoneWithContext mocks base method.
+func (m *MockRoute53) DeleteHostedZoneWithContext(arg0 context.Context, arg1 *route53.DeleteHostedZoneInput, arg2 ...request.Option) (*route53.DeleteHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHostedZoneWithContext indicates an expected call of DeleteHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) DeleteHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteHostedZoneWithContext), varargs...)
+}
+
+// DeleteKeySigningKey mocks base method.
+func (m *MockRoute53) DeleteKeySigningKey(arg0 *route53.DeleteKeySigningKeyInput) (*route53.DeleteKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeySigningKey", arg0)
+	ret0, _ := ret[0].(*route53.DeleteKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteKeySigningKey indicates an expected call of DeleteKeySigningKey.
+func (mr *MockRoute53MockRecorder) DeleteKeySigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeySigningKey", reflect.TypeOf((*MockRoute53)(nil).DeleteKeySigningKey), arg0)
+}
+
+// DeleteKeySigningKeyRequest mocks base method.
+func (m *MockRoute53) DeleteKeySigningKeyRequest(arg0 *route53.DeleteKeySigningKeyInput) (*request.Request, *route53.DeleteKeySigningKeyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeySigningKeyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteKeySigningKeyOutput)
+	return ret0, ret1
+}
+
+// DeleteKeySigningKeyRequest indicates an expected call of DeleteKeySigningKeyRequest.
+func (mr *MockRoute53MockRecorder) DeleteKeySigningKeyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeySigningKeyRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteKeySigningKeyRequest), arg0)
+}
+
+// DeleteKeySigningKeyWithContext mocks base method.
+func (m *MockRoute53) DeleteKeySigningKeyWithContext(arg0 context.Context, arg1 *route53.DeleteKeySigningKeyInput, arg2 ...request.Option) (*route53.DeleteKeySigningKeyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteKeySigningKeyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteKeySigningKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteKeySigningKeyWithContext indicates an expected call of DeleteKeySigningKeyWithContext.
+func (mr *MockRoute53MockRecorder) DeleteKeySigningKeyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeySigningKeyWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteKeySigningKeyWithContext), varargs...)
+}
+
+// DeleteQueryLoggingConfig mocks base method.
+func (m *MockRoute53) DeleteQueryLoggingConfig(arg0 *route53.DeleteQueryLoggingConfigInput) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueryLoggingConfig", arg0)
+	ret0, _ := ret[0].(*route53.DeleteQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteQueryLoggingConfig indicates an expected call of DeleteQueryLoggingConfig.
+func (mr *MockRoute53MockRecorder) DeleteQueryLoggingConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryLoggingConfig", reflect.TypeOf((*MockRoute53)(nil).DeleteQueryLoggingConfig), arg0)
+}
+
+// DeleteQueryLoggingConfigRequest mocks base method.
+func (m *MockRoute53) DeleteQueryLoggingConfigRequest(arg0 *route53.DeleteQueryLoggingConfigInput) (*request.Request, *route53.DeleteQueryLoggingConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueryLoggingConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteQueryLoggingConfigOutput)
+	return ret0, ret1
+}
+
+// DeleteQueryLoggingConfigRequest indicates an expected call of DeleteQueryLoggingConfigRequest.
+func (mr *MockRoute53MockRecorder) DeleteQueryLoggingConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryLoggingConfigRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteQueryLoggingConfigRequest), arg0)
+}
+
+// DeleteQueryLoggingConfigWithContext mocks base method.
+func (m *MockRoute53) DeleteQueryLoggingConfigWithContext(arg0 context.Context, arg1 *route53.DeleteQueryLoggingConfigInput, arg2 ...request.Option) (*route53.DeleteQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteQueryLoggingConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteQueryLoggingConfigWithContext indicates an expected call of DeleteQueryLoggingConfigWithContext.
+func (mr *MockRoute53MockRecorder) DeleteQueryLoggingConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueryLoggingConfigWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteQueryLoggingConfigWithContext), varargs...)
+}
+
+// DeleteReusableDelegationSet mocks base method.
+func (m *MockRoute53) DeleteReusableDelegationSet(arg0 *route53.DeleteReusableDelegationSetInput) (*route53.DeleteReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReusableDelegationSet", arg0)
+	ret0, _ := ret[0].(*route53.DeleteReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReusableDelegationSet indicates an expected call of DeleteReusableDelegationSet.
+func (mr *MockRoute53MockRecorder) DeleteReusableDelegationSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReusableDelegationSet", reflect.TypeOf((*MockRoute53)(nil).DeleteReusableDelegationSet), arg0)
+}
+
+// DeleteReusableDelegationSetRequest mocks base method.
+func (m *MockRoute53) DeleteReusableDelegationSetRequest(arg0 *route53.DeleteReusableDelegationSetInput) (*request.Request, *route53.DeleteReusableDelegationSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReusableDelegationSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteReusableDelegationSetOutput)
+	return ret0, ret1
+}
+
+// DeleteReusableDelegationSetRequest indicates an expected call of DeleteReusableDelegationSetRequest.
+func (mr *MockRoute53MockRecorder) DeleteReusableDelegationSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReusableDelegationSetRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteReusableDelegationSetRequest), arg0)
+}
+
+// DeleteReusableDelegationSetWithContext mocks base method.
+func (m *MockRoute53) DeleteReusableDelegationSetWithContext(arg0 context.Context, arg1 *route53.DeleteReusableDelegationSetInput, arg2 ...request.Option) (*route53.DeleteReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteReusableDelegationSetWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReusableDelegationSetWithContext indicates an expected call of DeleteReusableDelegationSetWithContext.
+func (mr *MockRoute53MockRecorder) DeleteReusableDelegationSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReusableDelegationSetWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteReusableDelegationSetWithContext), varargs...)
+}
+
+// DeleteTrafficPolicy mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicy(arg0 *route53.DeleteTrafficPolicyInput) (*route53.DeleteTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicy", arg0)
+	ret0, _ := ret[0].(*route53.DeleteTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicy indicates an expected call of DeleteTrafficPolicy.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicy", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicy), arg0)
+}
+
+// DeleteTrafficPolicyInstance mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicyInstance(arg0 *route53.DeleteTrafficPolicyInstanceInput) (*route53.DeleteTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicyInstance", arg0)
+	ret0, _ := ret[0].(*route53.DeleteTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicyInstance indicates an expected call of DeleteTrafficPolicyInstance.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicyInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicyInstance", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicyInstance), arg0)
+}
+
+// DeleteTrafficPolicyInstanceRequest mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicyInstanceRequest(arg0 *route53.DeleteTrafficPolicyInstanceInput) (*request.Request, *route53.DeleteTrafficPolicyInstanceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicyInstanceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteTrafficPolicyInstanceOutput)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicyInstanceRequest indicates an expected call of DeleteTrafficPolicyInstanceRequest.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicyInstanceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicyInstanceRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicyInstanceRequest), arg0)
+}
+
+// DeleteTrafficPolicyInstanceWithContext mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicyInstanceWithContext(arg0 context.Context, arg1 *route53.DeleteTrafficPolicyInstanceInput, arg2 ...request.Option) (*route53.DeleteTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicyInstanceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicyInstanceWithContext indicates an expected call of DeleteTrafficPolicyInstanceWithContext.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicyInstanceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicyInstanceWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicyInstanceWithContext), varargs...)
+}
+
+// DeleteTrafficPolicyRequest mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicyRequest(arg0 *route53.DeleteTrafficPolicyInput) (*request.Request, *route53.DeleteTrafficPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteTrafficPolicyOutput)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicyRequest indicates an expected call of DeleteTrafficPolicyRequest.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicyRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicyRequest), arg0)
+}
+
+// DeleteTrafficPolicyWithContext mocks base method.
+func (m *MockRoute53) DeleteTrafficPolicyWithContext(arg0 context.Context, arg1 *route53.DeleteTrafficPolicyInput, arg2 ...request.Option) (*route53.DeleteTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTrafficPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTrafficPolicyWithContext indicates an expected call of DeleteTrafficPolicyWithContext.
+func (mr *MockRoute53MockRecorder) DeleteTrafficPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrafficPolicyWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteTrafficPolicyWithContext), varargs...)
+}
+
+// DeleteVPCAssociationAuthorization mocks base method.
+func (m *MockRoute53) DeleteVPCAssociationAuthorization(arg0 *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCAssociationAuthorization", arg0)
+	ret0, _ := ret[0].(*route53.DeleteVPCAssociationAuthorizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVPCAssociationAuthorization indicates an expected call of DeleteVPCAssociationAuthorization.
+func (mr *MockRoute53MockRecorder) DeleteVPCAssociationAuthorization(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCAssociationAuthorization", reflect.TypeOf((*MockRoute53)(nil).DeleteVPCAssociationAuthorization), arg0)
+}
+
+// DeleteVPCAssociationAuthorizationRequest mocks base method.
+func (m *MockRoute53) DeleteVPCAssociationAuthorizationRequest(arg0 *route53.DeleteVPCAssociationAuthorizationInput) (*request.Request, *route53.DeleteVPCAssociationAuthorizationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCAssociationAuthorizationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DeleteVPCAssociationAuthorizationOutput)
+	return ret0, ret1
+}
+
+// DeleteVPCAssociationAuthorizationRequest indicates an expected call of DeleteVPCAssociationAuthorizationRequest.
+func (mr *MockRoute53MockRecorder) DeleteVPCAssociationAuthorizationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCAssociationAuthorizationRequest", reflect.TypeOf((*MockRoute53)(nil).DeleteVPCAssociationAuthorizationRequest), arg0)
+}
+
+// DeleteVPCAssociationAuthorizationWithContext mocks base method.
+func (m *MockRoute53) DeleteVPCAssociationAuthorizationWithContext(arg0 context.Context, arg1 *route53.DeleteVPCAssociationAuthorizationInput, arg2 ...request.Option) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteVPCAssociationAuthorizationWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DeleteVPCAssociationAuthorizationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteVPCAssociationAuthorizationWithContext indicates an expected call of DeleteVPCAssociationAuthorizationWithContext.
+func (mr *MockRoute53MockRecorder) DeleteVPCAssociationAuthorizationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCAssociationAuthorizationWithContext", reflect.TypeOf((*MockRoute53)(nil).DeleteVPCAssociationAuthorizationWithContext), varargs...)
+}
+
+// DisableHostedZoneDNSSEC mocks base method.
+func (m *MockRoute53) DisableHostedZoneDNSSEC(arg0 *route53.DisableHostedZoneDNSSECInput) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableHostedZoneDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.DisableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableHostedZoneDNSSEC indicates an expected call of DisableHostedZoneDNSSEC.
+func (mr *MockRoute53MockRecorder) DisableHostedZoneDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHostedZoneDNSSEC", reflect.TypeOf((*MockRoute53)(nil).DisableHostedZoneDNSSEC), arg0)
+}
+
+// DisableHostedZoneDNSSECRequest mocks base method.
+func (m *MockRoute53) DisableHostedZoneDNSSECRequest(arg0 *route53.DisableHostedZoneDNSSECInput) (*request.Request, *route53.DisableHostedZoneDNSSECOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableHostedZoneDNSSECRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DisableHostedZoneDNSSECOutput)
+	return ret0, ret1
+}
+
+// DisableHostedZoneDNSSECRequest indicates an expected call of DisableHostedZoneDNSSECRequest.
+func (mr *MockRoute53MockRecorder) DisableHostedZoneDNSSECRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHostedZoneDNSSECRequest", reflect.TypeOf((*MockRoute53)(nil).DisableHostedZoneDNSSECRequest), arg0)
+}
+
+// DisableHostedZoneDNSSECWithContext mocks base method.
+func (m *MockRoute53) DisableHostedZoneDNSSECWithContext(arg0 context.Context, arg1 *route53.DisableHostedZoneDNSSECInput, arg2 ...request.Option) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableHostedZoneDNSSECWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DisableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableHostedZoneDNSSECWithContext indicates an expected call of DisableHostedZoneDNSSECWithContext.
+func (mr *MockRoute53MockRecorder) DisableHostedZoneDNSSECWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableHostedZoneDNSSECWithContext", reflect.TypeOf((*MockRoute53)(nil).DisableHostedZoneDNSSECWithContext), varargs...)
+}
+
+// DisassociateVPCFromHostedZone mocks base method.
+func (m *MockRoute53) DisassociateVPCFromHostedZone(arg0 *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateVPCFromHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.DisassociateVPCFromHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateVPCFromHostedZone indicates an expected call of DisassociateVPCFromHostedZone.
+func (mr *MockRoute53MockRecorder) DisassociateVPCFromHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZone", reflect.TypeOf((*MockRoute53)(nil).DisassociateVPCFromHostedZone), arg0)
+}
+
+// DisassociateVPCFromHostedZoneRequest mocks base method.
+func (m *MockRoute53) DisassociateVPCFromHostedZoneRequest(arg0 *route53.DisassociateVPCFromHostedZoneInput) (*request.Request, *route53.DisassociateVPCFromHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateVPCFromHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.DisassociateVPCFromHostedZoneOutput)
+	return ret0, ret1
+}
+
+// DisassociateVPCFromHostedZoneRequest indicates an expected call of DisassociateVPCFromHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) DisassociateVPCFromHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).DisassociateVPCFromHostedZoneRequest), arg0)
+}
+
+// DisassociateVPCFromHostedZoneWithContext mocks base method.
+func (m *MockRoute53) DisassociateVPCFromHostedZoneWithContext(arg0 context.Context, arg1 *route53.DisassociateVPCFromHostedZoneInput, arg2 ...request.Option) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisassociateVPCFromHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.DisassociateVPCFromHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateVPCFromHostedZoneWithContext indicates an expected call of DisassociateVPCFromHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) DisassociateVPCFromHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateVPCFromHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).DisassociateVPCFromHostedZoneWithContext), varargs...)
+}
+
+// EnableHostedZoneDNSSEC mocks base method.
+func (m *MockRoute53) EnableHostedZoneDNSSEC(arg0 *route53.EnableHostedZoneDNSSECInput) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableHostedZoneDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.EnableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableHostedZoneDNSSEC indicates an expected call of EnableHostedZoneDNSSEC.
+func (mr *MockRoute53MockRecorder) EnableHostedZoneDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHostedZoneDNSSEC", reflect.TypeOf((*MockRoute53)(nil).EnableHostedZoneDNSSEC), arg0)
+}
+
+// EnableHostedZoneDNSSECRequest mocks base method.
+func (m *MockRoute53) EnableHostedZoneDNSSECRequest(arg0 *route53.EnableHostedZoneDNSSECInput) (*request.Request, *route53.EnableHostedZoneDNSSECOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableHostedZoneDNSSECRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.EnableHostedZoneDNSSECOutput)
+	return ret0, ret1
+}
+
+// EnableHostedZoneDNSSECRequest indicates an expected call of EnableHostedZoneDNSSECRequest.
+func (mr *MockRoute53MockRecorder) EnableHostedZoneDNSSECRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHostedZoneDNSSECRequest", reflect.TypeOf((*MockRoute53)(nil).EnableHostedZoneDNSSECRequest), arg0)
+}
+
+// EnableHostedZoneDNSSECWithContext mocks base method.
+func (m *MockRoute53) EnableHostedZoneDNSSECWithContext(arg0 context.Context, arg1 *route53.EnableHostedZoneDNSSECInput, arg2 ...request.Option) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableHostedZoneDNSSECWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.EnableHostedZoneDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableHostedZoneDNSSECWithContext indicates an expected call of EnableHostedZoneDNSSECWithContext.
+func (mr *MockRoute53MockRecorder) EnableHostedZoneDNSSECWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableHostedZoneDNSSECWithContext", reflect.TypeOf((*MockRoute53)(nil).EnableHostedZoneDNSSECWithContext), varargs...)
+}
+
+// GetAccountLimit mocks base method.
+func (m *MockRoute53) GetAccountLimit(arg0 *route53.GetAccountLimitInput) (*route53.GetAccountLimitOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountLimit", arg0)
+	ret0, _ := ret[0].(*route53.GetAccountLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLimit indicates an expected call of GetAccountLimit.
+func (mr *MockRoute53MockRecorder) GetAccountLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLimit", reflect.TypeOf((*MockRoute53)(nil).GetAccountLimit), arg0)
+}
+
+// GetAccountLimitRequest mocks base method.
+func (m *MockRoute53) GetAccountLimitRequest(arg0 *route53.GetAccountLimitInput) (*request.Request, *route53.GetAccountLimitOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountLimitRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetAccountLimitOutput)
+	return ret0, ret1
+}
+
+// GetAccountLimitRequest indicates an expected call of GetAccountLimitRequest.
+func (mr *MockRoute53MockRecorder) GetAccountLimitRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLimitRequest", reflect.TypeOf((*MockRoute53)(nil).GetAccountLimitRequest), arg0)
+}
+
+// GetAccountLimitWithContext mocks base method.
+func (m *MockRoute53) GetAccountLimitWithContext(arg0 context.Context, arg1 *route53.GetAccountLimitInput, arg2 ...request.Option) (*route53.GetAccountLimitOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetAccountLimitWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetAccountLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLimitWithContext indicates an expected call of GetAccountLimitWithContext.
+func (mr *MockRoute53MockRecorder) GetAccountLimitWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLimitWithContext", reflect.TypeOf((*MockRoute53)(nil).GetAccountLimitWithContext), varargs...)
+}
+
+// GetChange mocks base method.
+func (m *MockRoute53) GetChange(arg0 *route53.GetChangeInput) (*route53.GetChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChange", arg0)
+	ret0, _ := ret[0].(*route53.GetChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChange indicates an expected call of GetChange.
+func (mr *MockRoute53MockRecorder) GetChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChange", reflect.TypeOf((*MockRoute53)(nil).GetChange), arg0)
+}
+
+// GetChangeRequest mocks base method.
+func (m *MockRoute53) GetChangeRequest(arg0 *route53.GetChangeInput) (*request.Request, *route53.GetChangeOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetChangeOutput)
+	return ret0, ret1
+}
+
+// GetChangeRequest indicates an expected call of GetChangeRequest.
+func (mr *MockRoute53MockRecorder) GetChangeRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeRequest", reflect.TypeOf((*MockRoute53)(nil).GetChangeRequest), arg0)
+}
+
+// GetChangeWithContext mocks base method.
+func (m *MockRoute53) GetChangeWithContext(arg0 context.Context, arg1 *route53.GetChangeInput, arg2 ...request.Option) (*route53.GetChangeOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetChangeWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangeWithContext indicates an expected call of GetChangeWithContext.
+func (mr *MockRoute53MockRecorder) GetChangeWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeWithContext", reflect.TypeOf((*MockRoute53)(nil).GetChangeWithContext), varargs...)
+}
+
+// GetCheckerIpRanges mocks base method.
+func (m *MockRoute53) GetCheckerIpRanges(arg0 *route53.GetCheckerIpRangesInput) (*route53.GetCheckerIpRangesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckerIpRanges", arg0)
+	ret0, _ := ret[0].(*route53.GetCheckerIpRangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckerIpRanges indicates an expected call of GetCheckerIpRanges.
+func (mr *MockRoute53MockRecorder) GetCheckerIpRanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckerIpRanges", reflect.TypeOf((*MockRoute53)(nil).GetCheckerIpRanges), arg0)
+}
+
+// GetCheckerIpRangesRequest mocks base method.
+func (m *MockRoute53) GetCheckerIpRangesRequest(arg0 *route53.GetCheckerIpRangesInput) (*request.Request, *route53.GetCheckerIpRangesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckerIpRangesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetCheckerIpRangesOutput)
+	return ret0, ret1
+}
+
+// GetCheckerIpRangesRequest indicates an expected call of GetCheckerIpRangesRequest.
+func (mr *MockRoute53MockRecorder) GetCheckerIpRangesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckerIpRangesRequest", reflect.TypeOf((*MockRoute53)(nil).GetCheckerIpRangesRequest), arg0)
+}
+
+// GetCheckerIpRangesWithContext mocks base method.
+func (m *MockRoute53) GetCheckerIpRangesWithContext(arg0 context.Context, arg1 *route53.GetCheckerIpRangesInput, arg2 ...request.Option) (*route53.GetCheckerIpRangesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCheckerIpRangesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetCheckerIpRangesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckerIpRangesWithContext indicates an expected call of GetCheckerIpRangesWithContext.
+func (mr *MockRoute53MockRecorder) GetCheckerIpRangesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckerIpRangesWithContext", reflect.TypeOf((*MockRoute53)(nil).GetCheckerIpRangesWithContext), varargs...)
+}
+
+// GetDNSSEC mocks base method.
+func (m *MockRoute53) GetDNSSEC(arg0 *route53.GetDNSSECInput) (*route53.GetDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSSEC", arg0)
+	ret0, _ := ret[0].(*route53.GetDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSSEC indicates an expected call of GetDNSSEC.
+func (mr *MockRoute53MockRecorder) GetDNSSEC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSSEC", reflect.TypeOf((*MockRoute53)(nil).GetDNSSEC), arg0)
+}
+
+// GetDNSSECRequest mocks base method.
+func (m *MockRoute53) GetDNSSECRequest(arg0 *route53.GetDNSSECInput) (*request.Request, *route53.GetDNSSECOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSSECRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetDNSSECOutput)
+	return ret0, ret1
+}
+
+// GetDNSSECRequest indicates an expected call of GetDNSSECRequest.
+func (mr *MockRoute53MockRecorder) GetDNSSECRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSSECRequest", reflect.TypeOf((*MockRoute53)(nil).GetDNSSECRequest), arg0)
+}
+
+// GetDNSSECWithContext mocks base method.
+func (m *MockRoute53) GetDNSSECWithContext(arg0 context.Context, arg1 *route53.GetDNSSECInput, arg2 ...request.Option) (*route53.GetDNSSECOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDNSSECWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetDNSSECOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSSECWithContext indicates an expected call of GetDNSSECWithContext.
+func (mr *MockRoute53MockRecorder) GetDNSSECWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSSECWithContext", reflect.TypeOf((*MockRoute53)(nil).GetDNSSECWithContext), varargs...)
+}
+
+// GetGeoLocation mocks base method.
+func (m *MockRoute53) GetGeoLocation(arg0 *route53.GetGeoLocationInput) (*route53.GetGeoLocationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeoLocation", arg0)
+	ret0, _ := ret[0].(*route53.GetGeoLocationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeoLocation indicates an expected call of GetGeoLocation.
+func (mr *MockRoute53MockRecorder) GetGeoLocation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoLocation", reflect.TypeOf((*MockRoute53)(nil).GetGeoLocation), arg0)
+}
+
+// GetGeoLocationRequest mocks base method.
+func (m *MockRoute53) GetGeoLocationRequest(arg0 *route53.GetGeoLocationInput) (*request.Request, *route53.GetGeoLocationOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeoLocationRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetGeoLocationOutput)
+	return ret0, ret1
+}
+
+// GetGeoLocationRequest indicates an expected call of GetGeoLocationRequest.
+func (mr *MockRoute53MockRecorder) GetGeoLocationRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoLocationRequest", reflect.TypeOf((*MockRoute53)(nil).GetGeoLocationRequest), arg0)
+}
+
+// GetGeoLocationWithContext mocks base method.
+func (m *MockRoute53) GetGeoLocationWithContext(arg0 context.Context, arg1 *route53.GetGeoLocationInput, arg2 ...request.Option) (*route53.GetGeoLocationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGeoLocationWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetGeoLocationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeoLocationWithContext indicates an expected call of GetGeoLocationWithContext.
+func (mr *MockRoute53MockRecorder) GetGeoLocationWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoLocationWithContext", reflect.TypeOf((*MockRoute53)(nil).GetGeoLocationWithContext), varargs...)
+}
+
+// GetHealthCheck mocks base method.
+func (m *MockRoute53) GetHealthCheck(arg0 *route53.GetHealthCheckInput) (*route53.GetHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheck", arg0)
+	ret0, _ := ret[0].(*route53.GetHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheck indicates an expected call of GetHealthCheck.
+func (mr *MockRoute53MockRecorder) GetHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheck", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheck), arg0)
+}
+
+// GetHealthCheckCount mocks base method.
+func (m *MockRoute53) GetHealthCheckCount(arg0 *route53.GetHealthCheckCountInput) (*route53.GetHealthCheckCountOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckCount", arg0)
+	ret0, _ := ret[0].(*route53.GetHealthCheckCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckCount indicates an expected call of GetHealthCheckCount.
+func (mr *MockRoute53MockRecorder) GetHealthCheckCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckCount", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckCount), arg0)
+}
+
+// GetHealthCheckCountRequest mocks base method.
+func (m *MockRoute53) GetHealthCheckCountRequest(arg0 *route53.GetHealthCheckCountInput) (*request.Request, *route53.GetHealthCheckCountOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckCountRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHealthCheckCountOutput)
+	return ret0, ret1
+}
+
+// GetHealthCheckCountRequest indicates an expected call of GetHealthCheckCountRequest.
+func (mr *MockRoute53MockRecorder) GetHealthCheckCountRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckCountRequest", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckCountRequest), arg0)
+}
+
+// GetHealthCheckCountWithContext mocks base method.
+func (m *MockRoute53) GetHealthCheckCountWithContext(arg0 context.Context, arg1 *route53.GetHealthCheckCountInput, arg2 ...request.Option) (*route53.GetHealthCheckCountOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealthCheckCountWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHealthCheckCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckCountWithContext indicates an expected call of GetHealthCheckCountWithContext.
+func (mr *MockRoute53MockRecorder) GetHealthCheckCountWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckCountWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckCountWithContext), varargs...)
+}
+
+// GetHealthCheckLastFailureReason mocks base method.
+func (m *MockRoute53) GetHealthCheckLastFailureReason(arg0 *route53.GetHealthCheckLastFailureReasonInput) (*route53.GetHealthCheckLastFailureReasonOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckLastFailureReason", arg0)
+	ret0, _ := ret[0].(*route53.GetHealthCheckLastFailureReasonOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckLastFailureReason indicates an expected call of GetHealthCheckLastFailureReason.
+func (mr *MockRoute53MockRecorder) GetHealthCheckLastFailureReason(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckLastFailureReason", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckLastFailureReason), arg0)
+}
+
+// GetHealthCheckLastFailureReasonRequest mocks base method.
+func (m *MockRoute53) GetHealthCheckLastFailureReasonRequest(arg0 *route53.GetHealthCheckLastFailureReasonInput) (*request.Request, *route53.GetHealthCheckLastFailureReasonOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckLastFailureReasonRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHealthCheckLastFailureReasonOutput)
+	return ret0, ret1
+}
+
+// GetHealthCheckLastFailureReasonRequest indicates an expected call of GetHealthCheckLastFailureReasonRequest.
+func (mr *MockRoute53MockRecorder) GetHealthCheckLastFailureReasonRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckLastFailureReasonRequest", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckLastFailureReasonRequest), arg0)
+}
+
+// GetHealthCheckLastFailureReasonWithContext mocks base method.
+func (m *MockRoute53) GetHealthCheckLastFailureReasonWithContext(arg0 context.Context, arg1 *route53.GetHealthCheckLastFailureReasonInput, arg2 ...request.Option) (*route53.GetHealthCheckLastFailureReasonOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealthCheckLastFailureReasonWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHealthCheckLastFailureReasonOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckLastFailureReasonWithContext indicates an expected call of GetHealthCheckLastFailureReasonWithContext.
+func (mr *MockRoute53MockRecorder) GetHealthCheckLastFailureReasonWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckLastFailureReasonWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckLastFailureReasonWithContext), varargs...)
+}
+
+// GetHealthCheckRequest mocks base method.
+func (m *MockRoute53) GetHealthCheckRequest(arg0 *route53.GetHealthCheckInput) (*request.Request, *route53.GetHealthCheckOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHealthCheckOutput)
+	return ret0, ret1
+}
+
+// GetHealthCheckRequest indicates an expected call of GetHealthCheckRequest.
+func (mr *MockRoute53MockRecorder) GetHealthCheckRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckRequest", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckRequest), arg0)
+}
+
+// GetHealthCheckStatus mocks base method.
+func (m *MockRoute53) GetHealthCheckStatus(arg0 *route53.GetHealthCheckStatusInput) (*route53.GetHealthCheckStatusOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckStatus", arg0)
+	ret0, _ := ret[0].(*route53.GetHealthCheckStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckStatus indicates an expected call of GetHealthCheckStatus.
+func (mr *MockRoute53MockRecorder) GetHealthCheckStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckStatus", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckStatus), arg0)
+}
+
+// GetHealthCheckStatusRequest mocks base method.
+func (m *MockRoute53) GetHealthCheckStatusRequest(arg0 *route53.GetHealthCheckStatusInput) (*request.Request, *route53.GetHealthCheckStatusOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHealthCheckStatusRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHealthCheckStatusOutput)
+	return ret0, ret1
+}
+
+// GetHealthCheckStatusRequest indicates an expected call of GetHealthCheckStatusRequest.
+func (mr *MockRoute53MockRecorder) GetHealthCheckStatusRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckStatusRequest", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckStatusRequest), arg0)
+}
+
+// GetHealthCheckStatusWithContext mocks base method.
+func (m *MockRoute53) GetHealthCheckStatusWithContext(arg0 context.Context, arg1 *route53.GetHealthCheckStatusInput, arg2 ...request.Option) (*route53.GetHealthCheckStatusOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealthCheckStatusWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHealthCheckStatusOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckStatusWithContext indicates an expected call of GetHealthCheckStatusWithContext.
+func (mr *MockRoute53MockRecorder) GetHealthCheckStatusWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckStatusWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckStatusWithContext), varargs...)
+}
+
+// GetHealthCheckWithContext mocks base method.
+func (m *MockRoute53) GetHealthCheckWithContext(arg0 context.Context, arg1 *route53.GetHealthCheckInput, arg2 ...request.Option) (*route53.GetHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHealthCheckWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHealthCheckWithContext indicates an expected call of GetHealthCheckWithContext.
+func (mr *MockRoute53MockRecorder) GetHealthCheckWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthCheckWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHealthCheckWithContext), varargs...)
+}
+
+// GetHostedZone mocks base method.
+func (m *MockRoute53) GetHostedZone(arg0 *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.GetHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZone indicates an expected call of GetHostedZone.
+func (mr *MockRoute53MockRecorder) GetHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZone", reflect.TypeOf((*MockRoute53)(nil).GetHostedZone), arg0)
+}
+
+// GetHostedZoneCount mocks base method.
+func (m *MockRoute53) GetHostedZoneCount(arg0 *route53.GetHostedZoneCountInput) (*route53.GetHostedZoneCountOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZoneCount", arg0)
+	ret0, _ := ret[0].(*route53.GetHostedZoneCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneCount indicates an expected call of GetHostedZoneCount.
+func (mr *MockRoute53MockRecorder) GetHostedZoneCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneCount", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneCount), arg0)
+}
+
+// GetHostedZoneCountRequest mocks base method.
+func (m *MockRoute53) GetHostedZoneCountRequest(arg0 *route53.GetHostedZoneCountInput) (*request.Request, *route53.GetHostedZoneCountOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZoneCountRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHostedZoneCountOutput)
+	return ret0, ret1
+}
+
+// GetHostedZoneCountRequest indicates an expected call of GetHostedZoneCountRequest.
+func (mr *MockRoute53MockRecorder) GetHostedZoneCountRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneCountRequest", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneCountRequest), arg0)
+}
+
+// GetHostedZoneCountWithContext mocks base method.
+func (m *MockRoute53) GetHostedZoneCountWithContext(arg0 context.Context, arg1 *route53.GetHostedZoneCountInput, arg2 ...request.Option) (*route53.GetHostedZoneCountOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostedZoneCountWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHostedZoneCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneCountWithContext indicates an expected call of GetHostedZoneCountWithContext.
+func (mr *MockRoute53MockRecorder) GetHostedZoneCountWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneCountWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneCountWithContext), varargs...)
+}
+
+// GetHostedZoneLimit mocks base method.
+func (m *MockRoute53) GetHostedZoneLimit(arg0 *route53.GetHostedZoneLimitInput) (*route53.GetHostedZoneLimitOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZoneLimit", arg0)
+	ret0, _ := ret[0].(*route53.GetHostedZoneLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneLimit indicates an expected call of GetHostedZoneLimit.
+func (mr *MockRoute53MockRecorder) GetHostedZoneLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneLimit", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneLimit), arg0)
+}
+
+// GetHostedZoneLimitRequest mocks base method.
+func (m *MockRoute53) GetHostedZoneLimitRequest(arg0 *route53.GetHostedZoneLimitInput) (*request.Request, *route53.GetHostedZoneLimitOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZoneLimitRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHostedZoneLimitOutput)
+	return ret0, ret1
+}
+
+// GetHostedZoneLimitRequest indicates an expected call of GetHostedZoneLimitRequest.
+func (mr *MockRoute53MockRecorder) GetHostedZoneLimitRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneLimitRequest", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneLimitRequest), arg0)
+}
+
+// GetHostedZoneLimitWithContext mocks base method.
+func (m *MockRoute53) GetHostedZoneLimitWithContext(arg0 context.Context, arg1 *route53.GetHostedZoneLimitInput, arg2 ...request.Option) (*route53.GetHostedZoneLimitOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostedZoneLimitWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHostedZoneLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneLimitWithContext indicates an expected call of GetHostedZoneLimitWithContext.
+func (mr *MockRoute53MockRecorder) GetHostedZoneLimitWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneLimitWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneLimitWithContext), varargs...)
+}
+
+// GetHostedZoneRequest mocks base method.
+func (m *MockRoute53) GetHostedZoneRequest(arg0 *route53.GetHostedZoneInput) (*request.Request, *route53.GetHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetHostedZoneOutput)
+	return ret0, ret1
+}
+
+// GetHostedZoneRequest indicates an expected call of GetHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) GetHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneRequest), arg0)
+}
+
+// GetHostedZoneWithContext mocks base method.
+func (m *MockRoute53) GetHostedZoneWithContext(arg0 context.Context, arg1 *route53.GetHostedZoneInput, arg2 ...request.Option) (*route53.GetHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZoneWithContext indicates an expected call of GetHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) GetHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).GetHostedZoneWithContext), varargs...)
+}
+
+// GetQueryLoggingConfig mocks base method.
+func (m *MockRoute53) GetQueryLoggingConfig(arg0 *route53.GetQueryLoggingConfigInput) (*route53.GetQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryLoggingConfig", arg0)
+	ret0, _ := ret[0].(*route53.GetQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryLoggingConfig indicates an expected call of GetQueryLoggingConfig.
+func (mr *MockRoute53MockRecorder) GetQueryLoggingConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryLoggingConfig", reflect.TypeOf((*MockRoute53)(nil).GetQueryLoggingConfig), arg0)
+}
+
+// GetQueryLoggingConfigRequest mocks base method.
+func (m *MockRoute53) GetQueryLoggingConfigRequest(arg0 *route53.GetQueryLoggingConfigInput) (*request.Request, *route53.GetQueryLoggingConfigOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryLoggingConfigRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetQueryLoggingConfigOutput)
+	return ret0, ret1
+}
+
+// GetQueryLoggingConfigRequest indicates an expected call of GetQueryLoggingConfigRequest.
+func (mr *MockRoute53MockRecorder) GetQueryLoggingConfigRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryLoggingConfigRequest", reflect.TypeOf((*MockRoute53)(nil).GetQueryLoggingConfigRequest), arg0)
+}
+
+// GetQueryLoggingConfigWithContext mocks base method.
+func (m *MockRoute53) GetQueryLoggingConfigWithContext(arg0 context.Context, arg1 *route53.GetQueryLoggingConfigInput, arg2 ...request.Option) (*route53.GetQueryLoggingConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetQueryLoggingConfigWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetQueryLoggingConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryLoggingConfigWithContext indicates an expected call of GetQueryLoggingConfigWithContext.
+func (mr *MockRoute53MockRecorder) GetQueryLoggingConfigWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryLoggingConfigWithContext", reflect.TypeOf((*MockRoute53)(nil).GetQueryLoggingConfigWithContext), varargs...)
+}
+
+// GetReusableDelegationSet mocks base method.
+func (m *MockRoute53) GetReusableDelegationSet(arg0 *route53.GetReusableDelegationSetInput) (*route53.GetReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReusableDelegationSet", arg0)
+	ret0, _ := ret[0].(*route53.GetReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSet indicates an expected call of GetReusableDelegationSet.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSet", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSet), arg0)
+}
+
+// GetReusableDelegationSetLimit mocks base method.
+func (m *MockRoute53) GetReusableDelegationSetLimit(arg0 *route53.GetReusableDelegationSetLimitInput) (*route53.GetReusableDelegationSetLimitOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReusableDelegationSetLimit", arg0)
+	ret0, _ := ret[0].(*route53.GetReusableDelegationSetLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSetLimit indicates an expected call of GetReusableDelegationSetLimit.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSetLimit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSetLimit", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSetLimit), arg0)
+}
+
+// GetReusableDelegationSetLimitRequest mocks base method.
+func (m *MockRoute53) GetReusableDelegationSetLimitRequest(arg0 *route53.GetReusableDelegationSetLimitInput) (*request.Request, *route53.GetReusableDelegationSetLimitOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReusableDelegationSetLimitRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetReusableDelegationSetLimitOutput)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSetLimitRequest indicates an expected call of GetReusableDelegationSetLimitRequest.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSetLimitRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSetLimitRequest", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSetLimitRequest), arg0)
+}
+
+// GetReusableDelegationSetLimitWithContext mocks base method.
+func (m *MockRoute53) GetReusableDelegationSetLimitWithContext(arg0 context.Context, arg1 *route53.GetReusableDelegationSetLimitInput, arg2 ...request.Option) (*route53.GetReusableDelegationSetLimitOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReusableDelegationSetLimitWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetReusableDelegationSetLimitOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSetLimitWithContext indicates an expected call of GetReusableDelegationSetLimitWithContext.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSetLimitWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSetLimitWithContext", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSetLimitWithContext), varargs...)
+}
+
+// GetReusableDelegationSetRequest mocks base method.
+func (m *MockRoute53) GetReusableDelegationSetRequest(arg0 *route53.GetReusableDelegationSetInput) (*request.Request, *route53.GetReusableDelegationSetOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReusableDelegationSetRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetReusableDelegationSetOutput)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSetRequest indicates an expected call of GetReusableDelegationSetRequest.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSetRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSetRequest", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSetRequest), arg0)
+}
+
+// GetReusableDelegationSetWithContext mocks base method.
+func (m *MockRoute53) GetReusableDelegationSetWithContext(arg0 context.Context, arg1 *route53.GetReusableDelegationSetInput, arg2 ...request.Option) (*route53.GetReusableDelegationSetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReusableDelegationSetWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetReusableDelegationSetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReusableDelegationSetWithContext indicates an expected call of GetReusableDelegationSetWithContext.
+func (mr *MockRoute53MockRecorder) GetReusableDelegationSetWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReusableDelegationSetWithContext", reflect.TypeOf((*MockRoute53)(nil).GetReusableDelegationSetWithContext), varargs...)
+}
+
+// GetTrafficPolicy mocks base method.
+func (m *MockRoute53) GetTrafficPolicy(arg0 *route53.GetTrafficPolicyInput) (*route53.GetTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicy", arg0)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicy indicates an expected call of GetTrafficPolicy.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicy", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicy), arg0)
+}
+
+// GetTrafficPolicyInstance mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstance(arg0 *route53.GetTrafficPolicyInstanceInput) (*route53.GetTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstance", arg0)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstance indicates an expected call of GetTrafficPolicyInstance.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstance", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstance), arg0)
+}
+
+// GetTrafficPolicyInstanceCount mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstanceCount(arg0 *route53.GetTrafficPolicyInstanceCountInput) (*route53.GetTrafficPolicyInstanceCountOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstanceCount", arg0)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyInstanceCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstanceCount indicates an expected call of GetTrafficPolicyInstanceCount.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstanceCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstanceCount", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstanceCount), arg0)
+}
+
+// GetTrafficPolicyInstanceCountRequest mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstanceCountRequest(arg0 *route53.GetTrafficPolicyInstanceCountInput) (*request.Request, *route53.GetTrafficPolicyInstanceCountOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstanceCountRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetTrafficPolicyInstanceCountOutput)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstanceCountRequest indicates an expected call of GetTrafficPolicyInstanceCountRequest.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstanceCountRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstanceCountRequest", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstanceCountRequest), arg0)
+}
+
+// GetTrafficPolicyInstanceCountWithContext mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstanceCountWithContext(arg0 context.Context, arg1 *route53.GetTrafficPolicyInstanceCountInput, arg2 ...request.Option) (*route53.GetTrafficPolicyInstanceCountOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstanceCountWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyInstanceCountOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstanceCountWithContext indicates an expected call of GetTrafficPolicyInstanceCountWithContext.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstanceCountWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstanceCountWithContext", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstanceCountWithContext), varargs...)
+}
+
+// GetTrafficPolicyInstanceRequest mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstanceRequest(arg0 *route53.GetTrafficPolicyInstanceInput) (*request.Request, *route53.GetTrafficPolicyInstanceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstanceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetTrafficPolicyInstanceOutput)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstanceRequest indicates an expected call of GetTrafficPolicyInstanceRequest.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstanceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstanceRequest", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstanceRequest), arg0)
+}
+
+// GetTrafficPolicyInstanceWithContext mocks base method.
+func (m *MockRoute53) GetTrafficPolicyInstanceWithContext(arg0 context.Context, arg1 *route53.GetTrafficPolicyInstanceInput, arg2 ...request.Option) (*route53.GetTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrafficPolicyInstanceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyInstanceWithContext indicates an expected call of GetTrafficPolicyInstanceWithContext.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyInstanceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyInstanceWithContext", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyInstanceWithContext), varargs...)
+}
+
+// GetTrafficPolicyRequest mocks base method.
+func (m *MockRoute53) GetTrafficPolicyRequest(arg0 *route53.GetTrafficPolicyInput) (*request.Request, *route53.GetTrafficPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrafficPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.GetTrafficPolicyOutput)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyRequest indicates an expected call of GetTrafficPolicyRequest.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyRequest", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyRequest), arg0)
+}
+
+// GetTrafficPolicyWithContext mocks base method.
+func (m *MockRoute53) GetTrafficPolicyWithContext(arg0 context.Context, arg1 *route53.GetTrafficPolicyInput, arg2 ...request.Option) (*route53.GetTrafficPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTrafficPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.GetTrafficPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrafficPolicyWithContext indicates an expected call of GetTrafficPolicyWithContext.
+func (mr *MockRoute53MockRecorder) GetTrafficPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrafficPolicyWithContext", reflect.TypeOf((*MockRoute53)(nil).GetTrafficPolicyWithContext), varargs...)
+}
+
+// ListGeoLocations mocks base method.
+func (m *MockRoute53) ListGeoLocations(arg0 *route53.ListGeoLocationsInput) (*route53.ListGeoLocationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeoLocations", arg0)
+	ret0, _ := ret[0].(*route53.ListGeoLocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeoLocations indicates an expected call of ListGeoLocations.
+func (mr *MockRoute53MockRecorder) ListGeoLocations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeoLocations", reflect.TypeOf((*MockRoute53)(nil).ListGeoLocations), arg0)
+}
+
+// ListGeoLocationsRequest mocks base method.
+func (m *MockRoute53) ListGeoLocationsRequest(arg0 *route53.ListGeoLocationsInput) (*request.Request, *route53.ListGeoLocationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeoLocationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListGeoLocationsOutput)
+	return ret0, ret1
+}
+
+// ListGeoLocationsRequest indicates an expected call of ListGeoLocationsRequest.
+func (mr *MockRoute53MockRecorder) ListGeoLocationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeoLocationsRequest", reflect.TypeOf((*MockRoute53)(nil).ListGeoLocationsRequest), arg0)
+}
+
+// ListGeoLocationsWithContext mocks base method.
+func (m *MockRoute53) ListGeoLocationsWithContext(arg0 context.Context, arg1 *route53.ListGeoLocationsInput, arg2 ...request.Option) (*route53.ListGeoLocationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListGeoLocationsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListGeoLocationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeoLocationsWithContext indicates an expected call of ListGeoLocationsWithContext.
+func (mr *MockRoute53MockRecorder) ListGeoLocationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeoLocationsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListGeoLocationsWithContext), varargs...)
+}
+
+// ListHealthChecks mocks base method.
+func (m *MockRoute53) ListHealthChecks(arg0 *route53.ListHealthChecksInput) (*route53.ListHealthChecksOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHealthChecks", arg0)
+	ret0, _ := ret[0].(*route53.ListHealthChecksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHealthChecks indicates an expected call of ListHealthChecks.
+func (mr *MockRoute53MockRecorder) ListHealthChecks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecks", reflect.TypeOf((*MockRoute53)(nil).ListHealthChecks), arg0)
+}
+
+// ListHealthChecksPages mocks base method.
+func (m *MockRoute53) ListHealthChecksPages(arg0 *route53.ListHealthChecksInput, arg1 func(*route53.ListHealthChecksOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHealthChecksPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListHealthChecksPages indicates an expected call of ListHealthChecksPages.
+func (mr *MockRoute53MockRecorder) ListHealthChecksPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecksPages", reflect.TypeOf((*MockRoute53)(nil).ListHealthChecksPages), arg0, arg1)
+}
+
+// ListHealthChecksPagesWithContext mocks base method.
+func (m *MockRoute53) ListHealthChecksPagesWithContext(arg0 context.Context, arg1 *route53.ListHealthChecksInput, arg2 func(*route53.ListHealthChecksOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHealthChecksPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListHealthChecksPagesWithContext indicates an expected call of ListHealthChecksPagesWithContext.
+func (mr *MockRoute53MockRecorder) ListHealthChecksPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecksPagesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHealthChecksPagesWithContext), varargs...)
+}
+
+// ListHealthChecksRequest mocks base method.
+func (m *MockRoute53) ListHealthChecksRequest(arg0 *route53.ListHealthChecksInput) (*request.Request, *route53.ListHealthChecksOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHealthChecksRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListHealthChecksOutput)
+	return ret0, ret1
+}
+
+// ListHealthChecksRequest indicates an expected call of ListHealthChecksRequest.
+func (mr *MockRoute53MockRecorder) ListHealthChecksRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecksRequest", reflect.TypeOf((*MockRoute53)(nil).ListHealthChecksRequest), arg0)
+}
+
+// ListHealthChecksWithContext mocks base method.
+func (m *MockRoute53) ListHealthChecksWithContext(arg0 context.Context, arg1 *route53.ListHealthChecksInput, arg2 ...request.Option) (*route53.ListHealthChecksOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHealthChecksWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListHealthChecksOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHealthChecksWithContext indicates an expected call of ListHealthChecksWithContext.
+func (mr *MockRoute53MockRecorder) ListHealthChecksWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHealthChecksWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHealthChecksWithContext), varargs...)
+}
+
+// ListHostedZones mocks base method.
+func (m *MockRoute53) ListHostedZones(arg0 *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZones", arg0)
+	ret0, _ := ret[0].(*route53.ListHostedZonesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZones indicates an expected call of ListHostedZones.
+func (mr *MockRoute53MockRecorder) ListHostedZones(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZones", reflect.TypeOf((*MockRoute53)(nil).ListHostedZones), arg0)
+}
+
+// ListHostedZonesByName mocks base method.
+func (m *MockRoute53) ListHostedZonesByName(arg0 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesByName", arg0)
+	ret0, _ := ret[0].(*route53.ListHostedZonesByNameOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesByName indicates an expected call of ListHostedZonesByName.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByName(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByName", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByName), arg0)
+}
+
+// ListHostedZonesByNameRequest mocks base method.
+func (m *MockRoute53) ListHostedZonesByNameRequest(arg0 *route53.ListHostedZonesByNameInput) (*request.Request, *route53.ListHostedZonesByNameOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesByNameRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListHostedZonesByNameOutput)
+	return ret0, ret1
+}
+
+// ListHostedZonesByNameRequest indicates an expected call of ListHostedZonesByNameRequest.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByNameRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByNameRequest", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByNameRequest), arg0)
+}
+
+// ListHostedZonesByNameWithContext mocks base method.
+func (m *MockRoute53) ListHostedZonesByNameWithContext(arg0 context.Context, arg1 *route53.ListHostedZonesByNameInput, arg2 ...request.Option) (*route53.ListHostedZonesByNameOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHostedZonesByNameWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListHostedZonesByNameOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesByNameWithContext indicates an expected call of ListHostedZonesByNameWithContext.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByNameWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByNameWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByNameWithContext), varargs...)
+}
+
+// ListHostedZonesByVPC mocks base method.
+func (m *MockRoute53) ListHostedZonesByVPC(arg0 *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesByVPC", arg0)
+	ret0, _ := ret[0].(*route53.ListHostedZonesByVPCOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesByVPC indicates an expected call of ListHostedZonesByVPC.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByVPC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByVPC", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByVPC), arg0)
+}
+
+// ListHostedZonesByVPCRequest mocks base method.
+func (m *MockRoute53) ListHostedZonesByVPCRequest(arg0 *route53.ListHostedZonesByVPCInput) (*request.Request, *route53.ListHostedZonesByVPCOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesByVPCRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListHostedZonesByVPCOutput)
+	return ret0, ret1
+}
+
+// ListHostedZonesByVPCRequest indicates an expected call of ListHostedZonesByVPCRequest.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByVPCRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByVPCRequest", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByVPCRequest), arg0)
+}
+
+// ListHostedZonesByVPCWithContext mocks base method.
+func (m *MockRoute53) ListHostedZonesByVPCWithContext(arg0 context.Context, arg1 *route53.ListHostedZonesByVPCInput, arg2 ...request.Option) (*route53.ListHostedZonesByVPCOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHostedZonesByVPCWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListHostedZonesByVPCOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesByVPCWithContext indicates an expected call of ListHostedZonesByVPCWithContext.
+func (mr *MockRoute53MockRecorder) ListHostedZonesByVPCWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesByVPCWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesByVPCWithContext), varargs...)
+}
+
+// ListHostedZonesPages mocks base method.
+func (m *MockRoute53) ListHostedZonesPages(arg0 *route53.ListHostedZonesInput, arg1 func(*route53.ListHostedZonesOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListHostedZonesPages indicates an expected call of ListHostedZonesPages.
+func (mr *MockRoute53MockRecorder) ListHostedZonesPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesPages", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesPages), arg0, arg1)
+}
+
+// ListHostedZonesPagesWithContext mocks base method.
+func (m *MockRoute53) ListHostedZonesPagesWithContext(arg0 context.Context, arg1 *route53.ListHostedZonesInput, arg2 func(*route53.ListHostedZonesOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHostedZonesPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListHostedZonesPagesWithContext indicates an expected call of ListHostedZonesPagesWithContext.
+func (mr *MockRoute53MockRecorder) ListHostedZonesPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesPagesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesPagesWithContext), varargs...)
+}
+
+// ListHostedZonesRequest mocks base method.
+func (m *MockRoute53) ListHostedZonesRequest(arg0 *route53.ListHostedZonesInput) (*request.Request, *route53.ListHostedZonesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHostedZonesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListHostedZonesOutput)
+	return ret0, ret1
+}
+
+// ListHostedZonesRequest indicates an expected call of ListHostedZonesRequest.
+func (mr *MockRoute53MockRecorder) ListHostedZonesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesRequest", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesRequest), arg0)
+}
+
+// ListHostedZonesWithContext mocks base method.
+func (m *MockRoute53) ListHostedZonesWithContext(arg0 context.Context, arg1 *route53.ListHostedZonesInput, arg2 ...request.Option) (*route53.ListHostedZonesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListHostedZonesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListHostedZonesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHostedZonesWithContext indicates an expected call of ListHostedZonesWithContext.
+func (mr *MockRoute53MockRecorder) ListHostedZonesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHostedZonesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListHostedZonesWithContext), varargs...)
+}
+
+// ListQueryLoggingConfigs mocks base method.
+func (m *MockRoute53) ListQueryLoggingConfigs(arg0 *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigs", arg0)
+	ret0, _ := ret[0].(*route53.ListQueryLoggingConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueryLoggingConfigs indicates an expected call of ListQueryLoggingConfigs.
+func (mr *MockRoute53MockRecorder) ListQueryLoggingConfigs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigs", reflect.TypeOf((*MockRoute53)(nil).ListQueryLoggingConfigs), arg0)
+}
+
+// ListQueryLoggingConfigsPages mocks base method.
+func (m *MockRoute53) ListQueryLoggingConfigsPages(arg0 *route53.ListQueryLoggingConfigsInput, arg1 func(*route53.ListQueryLoggingConfigsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListQueryLoggingConfigsPages indicates an expected call of ListQueryLoggingConfigsPages.
+func (mr *MockRoute53MockRecorder) ListQueryLoggingConfigsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigsPages", reflect.TypeOf((*MockRoute53)(nil).ListQueryLoggingConfigsPages), arg0, arg1)
+}
+
+// ListQueryLoggingConfigsPagesWithContext mocks base method.
+func (m *MockRoute53) ListQueryLoggingConfigsPagesWithContext(arg0 context.Context, arg1 *route53.ListQueryLoggingConfigsInput, arg2 func(*route53.ListQueryLoggingConfigsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListQueryLoggingConfigsPagesWithContext indicates an expected call of ListQueryLoggingConfigsPagesWithContext.
+func (mr *MockRoute53MockRecorder) ListQueryLoggingConfigsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigsPagesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListQueryLoggingConfigsPagesWithContext), varargs...)
+}
+
+// ListQueryLoggingConfigsRequest mocks base method.
+func (m *MockRoute53) ListQueryLoggingConfigsRequest(arg0 *route53.ListQueryLoggingConfigsInput) (*request.Request, *route53.ListQueryLoggingConfigsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListQueryLoggingConfigsOutput)
+	return ret0, ret1
+}
+
+// ListQueryLoggingConfigsRequest indicates an expected call of ListQueryLoggingConfigsRequest.
+func (mr *MockRoute53MockRecorder) ListQueryLoggingConfigsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigsRequest", reflect.TypeOf((*MockRoute53)(nil).ListQueryLoggingConfigsRequest), arg0)
+}
+
+// ListQueryLoggingConfigsWithContext mocks base method.
+func (m *MockRoute53) ListQueryLoggingConfigsWithContext(arg0 context.Context, arg1 *route53.ListQueryLoggingConfigsInput, arg2 ...request.Option) (*route53.ListQueryLoggingConfigsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListQueryLoggingConfigsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListQueryLoggingConfigsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueryLoggingConfigsWithContext indicates an expected call of ListQueryLoggingConfigsWithContext.
+func (mr *MockRoute53MockRecorder) ListQueryLoggingConfigsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueryLoggingConfigsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListQueryLoggingConfigsWithContext), varargs...)
+}
+
+// ListResourceRecordSets mocks base method.
+func (m *MockRoute53) ListResourceRecordSets(arg0 *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceRecordSets", arg0)
+	ret0, _ := ret[0].(*route53.ListResourceRecordSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceRecordSets indicates an expected call of ListResourceRecordSets.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSets", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSets), arg0)
+}
+
+// ListResourceRecordSetsAsList mocks base method.
+func (m *MockRoute53) ListResourceRecordSetsAsList(arg0 context.Context, arg1 *route53.ListResourceRecordSetsInput) ([]*route53.ResourceRecordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsAsList", arg0, arg1)
+	ret0, _ := ret[0].([]*route53.ResourceRecordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceRecordSetsAsList indicates an expected call of ListResourceRecordSetsAsList.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSetsAsList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsAsList", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSetsAsList), arg0, arg1)
+}
+
+// ListResourceRecordSetsPages mocks base method.
+func (m *MockRoute53) ListResourceRecordSetsPages(arg0 *route53.ListResourceRecordSetsInput, arg1 func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsPages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListResourceRecordSetsPages indicates an expected call of ListResourceRecordSetsPages.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSetsPages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsPages", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSetsPages), arg0, arg1)
+}
+
+// ListResourceRecordSetsPagesWithContext mocks base method.
+func (m *MockRoute53) ListResourceRecordSetsPagesWithContext(arg0 context.Context, arg1 *route53.ListResourceRecordSetsInput, arg2 func(*route53.ListResourceRecordSetsOutput, bool) bool, arg3 ...request.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsPagesWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListResourceRecordSetsPagesWithContext indicates an expected call of ListResourceRecordSetsPagesWithContext.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSetsPagesWithContext(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsPagesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSetsPagesWithContext), varargs...)
+}
+
+// ListResourceRecordSetsRequest mocks base method.
+func (m *MockRoute53) ListResourceRecordSetsRequest(arg0 *route53.ListResourceRecordSetsInput) (*request.Request, *route53.ListResourceRecordSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListResourceRecordSetsOutput)
+	return ret0, ret1
+}
+
+// ListResourceRecordSetsRequest indicates an expected call of ListResourceRecordSetsRequest.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsRequest", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSetsRequest), arg0)
+}
+
+// ListResourceRecordSetsWithContext mocks base method.
+func (m *MockRoute53) ListResourceRecordSetsWithContext(arg0 context.Context, arg1 *route53.ListResourceRecordSetsInput, arg2 ...request.Option) (*route53.ListResourceRecordSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListResourceRecordSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListResourceRecordSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceRecordSetsWithContext indicates an expected call of ListResourceRecordSetsWithContext.
+func (mr *MockRoute53MockRecorder) ListResourceRecordSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceRecordSetsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListResourceRecordSetsWithContext), varargs...)
+}
+
+// ListReusableDelegationSets mocks base method.
+func (m *MockRoute53) ListReusableDelegationSets(arg0 *route53.ListReusableDelegationSetsInput) (*route53.ListReusableDelegationSetsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReusableDelegationSets", arg0)
+	ret0, _ := ret[0].(*route53.ListReusableDelegationSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReusableDelegationSets indicates an expected call of ListReusableDelegationSets.
+func (mr *MockRoute53MockRecorder) ListReusableDelegationSets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReusableDelegationSets", reflect.TypeOf((*MockRoute53)(nil).ListReusableDelegationSets), arg0)
+}
+
+// ListReusableDelegationSetsRequest mocks base method.
+func (m *MockRoute53) ListReusableDelegationSetsRequest(arg0 *route53.ListReusableDelegationSetsInput) (*request.Request, *route53.ListReusableDelegationSetsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReusableDelegationSetsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListReusableDelegationSetsOutput)
+	return ret0, ret1
+}
+
+// ListReusableDelegationSetsRequest indicates an expected call of ListReusableDelegationSetsRequest.
+func (mr *MockRoute53MockRecorder) ListReusableDelegationSetsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReusableDelegationSetsRequest", reflect.TypeOf((*MockRoute53)(nil).ListReusableDelegationSetsRequest), arg0)
+}
+
+// ListReusableDelegationSetsWithContext mocks base method.
+func (m *MockRoute53) ListReusableDelegationSetsWithContext(arg0 context.Context, arg1 *route53.ListReusableDelegationSetsInput, arg2 ...request.Option) (*route53.ListReusableDelegationSetsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListReusableDelegationSetsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListReusableDelegationSetsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReusableDelegationSetsWithContext indicates an expected call of ListReusableDelegationSetsWithContext.
+func (mr *MockRoute53MockRecorder) ListReusableDelegationSetsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReusableDelegationSetsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListReusableDelegationSetsWithContext), varargs...)
+}
+
+// ListTagsForResource mocks base method.
+func (m *MockRoute53) ListTagsForResource(arg0 *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResource", arg0)
+	ret0, _ := ret[0].(*route53.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResource indicates an expected call of ListTagsForResource.
+func (mr *MockRoute53MockRecorder) ListTagsForResource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResource", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResource), arg0)
+}
+
+// ListTagsForResourceRequest mocks base method.
+func (m *MockRoute53) ListTagsForResourceRequest(arg0 *route53.ListTagsForResourceInput) (*request.Request, *route53.ListTagsForResourceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResourceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTagsForResourceOutput)
+	return ret0, ret1
+}
+
+// ListTagsForResourceRequest indicates an expected call of ListTagsForResourceRequest.
+func (mr *MockRoute53MockRecorder) ListTagsForResourceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceRequest", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResourceRequest), arg0)
+}
+
+// ListTagsForResourceWithContext mocks base method.
+func (m *MockRoute53) ListTagsForResourceWithContext(arg0 context.Context, arg1 *route53.ListTagsForResourceInput, arg2 ...request.Option) (*route53.ListTagsForResourceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResourceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTagsForResourceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResourceWithContext indicates an expected call of ListTagsForResourceWithContext.
+func (mr *MockRoute53MockRecorder) ListTagsForResourceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourceWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResourceWithContext), varargs...)
+}
+
+// ListTagsForResources mocks base method.
+func (m *MockRoute53) ListTagsForResources(arg0 *route53.ListTagsForResourcesInput) (*route53.ListTagsForResourcesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResources", arg0)
+	ret0, _ := ret[0].(*route53.ListTagsForResourcesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResources indicates an expected call of ListTagsForResources.
+func (mr *MockRoute53MockRecorder) ListTagsForResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResources", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResources), arg0)
+}
+
+// ListTagsForResourcesRequest mocks base method.
+func (m *MockRoute53) ListTagsForResourcesRequest(arg0 *route53.ListTagsForResourcesInput) (*request.Request, *route53.ListTagsForResourcesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsForResourcesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTagsForResourcesOutput)
+	return ret0, ret1
+}
+
+// ListTagsForResourcesRequest indicates an expected call of ListTagsForResourcesRequest.
+func (mr *MockRoute53MockRecorder) ListTagsForResourcesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourcesRequest", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResourcesRequest), arg0)
+}
+
+// ListTagsForResourcesWithContext mocks base method.
+func (m *MockRoute53) ListTagsForResourcesWithContext(arg0 context.Context, arg1 *route53.ListTagsForResourcesInput, arg2 ...request.Option) (*route53.ListTagsForResourcesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTagsForResourcesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTagsForResourcesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsForResourcesWithContext indicates an expected call of ListTagsForResourcesWithContext.
+func (mr *MockRoute53MockRecorder) ListTagsForResourcesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsForResourcesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTagsForResourcesWithContext), varargs...)
+}
+
+// ListTrafficPolicies mocks base method.
+func (m *MockRoute53) ListTrafficPolicies(arg0 *route53.ListTrafficPoliciesInput) (*route53.ListTrafficPoliciesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicies", arg0)
+	ret0, _ := ret[0].(*route53.ListTrafficPoliciesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicies indicates an expected call of ListTrafficPolicies.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicies", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicies), arg0)
+}
+
+// ListTrafficPoliciesRequest mocks base method.
+func (m *MockRoute53) ListTrafficPoliciesRequest(arg0 *route53.ListTrafficPoliciesInput) (*request.Request, *route53.ListTrafficPoliciesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPoliciesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTrafficPoliciesOutput)
+	return ret0, ret1
+}
+
+// ListTrafficPoliciesRequest indicates an expected call of ListTrafficPoliciesRequest.
+func (mr *MockRoute53MockRecorder) ListTrafficPoliciesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPoliciesRequest", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPoliciesRequest), arg0)
+}
+
+// ListTrafficPoliciesWithContext mocks base method.
+func (m *MockRoute53) ListTrafficPoliciesWithContext(arg0 context.Context, arg1 *route53.ListTrafficPoliciesInput, arg2 ...request.Option) (*route53.ListTrafficPoliciesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrafficPoliciesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTrafficPoliciesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPoliciesWithContext indicates an expected call of ListTrafficPoliciesWithContext.
+func (mr *MockRoute53MockRecorder) ListTrafficPoliciesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPoliciesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPoliciesWithContext), varargs...)
+}
+
+// ListTrafficPolicyInstances mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstances(arg0 *route53.ListTrafficPolicyInstancesInput) (*route53.ListTrafficPolicyInstancesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstances", arg0)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstances indicates an expected call of ListTrafficPolicyInstances.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstances(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstances", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstances), arg0)
+}
+
+// ListTrafficPolicyInstancesByHostedZone mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByHostedZone(arg0 *route53.ListTrafficPolicyInstancesByHostedZoneInput) (*route53.ListTrafficPolicyInstancesByHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByHostedZone", arg0)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesByHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByHostedZone indicates an expected call of ListTrafficPolicyInstancesByHostedZone.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByHostedZone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByHostedZone", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByHostedZone), arg0)
+}
+
+// ListTrafficPolicyInstancesByHostedZoneRequest mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByHostedZoneRequest(arg0 *route53.ListTrafficPolicyInstancesByHostedZoneInput) (*request.Request, *route53.ListTrafficPolicyInstancesByHostedZoneOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByHostedZoneRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTrafficPolicyInstancesByHostedZoneOutput)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByHostedZoneRequest indicates an expected call of ListTrafficPolicyInstancesByHostedZoneRequest.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByHostedZoneRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByHostedZoneRequest", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByHostedZoneRequest), arg0)
+}
+
+// ListTrafficPolicyInstancesByHostedZoneWithContext mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByHostedZoneWithContext(arg0 context.Context, arg1 *route53.ListTrafficPolicyInstancesByHostedZoneInput, arg2 ...request.Option) (*route53.ListTrafficPolicyInstancesByHostedZoneOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByHostedZoneWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesByHostedZoneOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByHostedZoneWithContext indicates an expected call of ListTrafficPolicyInstancesByHostedZoneWithContext.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByHostedZoneWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByHostedZoneWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByHostedZoneWithContext), varargs...)
+}
+
+// ListTrafficPolicyInstancesByPolicy mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByPolicy(arg0 *route53.ListTrafficPolicyInstancesByPolicyInput) (*route53.ListTrafficPolicyInstancesByPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByPolicy", arg0)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesByPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByPolicy indicates an expected call of ListTrafficPolicyInstancesByPolicy.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByPolicy", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByPolicy), arg0)
+}
+
+// ListTrafficPolicyInstancesByPolicyRequest mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByPolicyRequest(arg0 *route53.ListTrafficPolicyInstancesByPolicyInput) (*request.Request, *route53.ListTrafficPolicyInstancesByPolicyOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByPolicyRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTrafficPolicyInstancesByPolicyOutput)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByPolicyRequest indicates an expected call of ListTrafficPolicyInstancesByPolicyRequest.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByPolicyRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByPolicyRequest", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByPolicyRequest), arg0)
+}
+
+// ListTrafficPolicyInstancesByPolicyWithContext mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesByPolicyWithContext(arg0 context.Context, arg1 *route53.ListTrafficPolicyInstancesByPolicyInput, arg2 ...request.Option) (*route53.ListTrafficPolicyInstancesByPolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesByPolicyWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesByPolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesByPolicyWithContext indicates an expected call of ListTrafficPolicyInstancesByPolicyWithContext.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesByPolicyWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesByPolicyWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesByPolicyWithContext), varargs...)
+}
+
+// ListTrafficPolicyInstancesRequest mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesRequest(arg0 *route53.ListTrafficPolicyInstancesInput) (*request.Request, *route53.ListTrafficPolicyInstancesOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTrafficPolicyInstancesOutput)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesRequest indicates an expected call of ListTrafficPolicyInstancesRequest.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesRequest", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesRequest), arg0)
+}
+
+// ListTrafficPolicyInstancesWithContext mocks base method.
+func (m *MockRoute53) ListTrafficPolicyInstancesWithContext(arg0 context.Context, arg1 *route53.ListTrafficPolicyInstancesInput, arg2 ...request.Option) (*route53.ListTrafficPolicyInstancesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrafficPolicyInstancesWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyInstancesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyInstancesWithContext indicates an expected call of ListTrafficPolicyInstancesWithContext.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyInstancesWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyInstancesWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyInstancesWithContext), varargs...)
+}
+
+// ListTrafficPolicyVersions mocks base method.
+func (m *MockRoute53) ListTrafficPolicyVersions(arg0 *route53.ListTrafficPolicyVersionsInput) (*route53.ListTrafficPolicyVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyVersions", arg0)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyVersions indicates an expected call of ListTrafficPolicyVersions.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyVersions", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyVersions), arg0)
+}
+
+// ListTrafficPolicyVersionsRequest mocks base method.
+func (m *MockRoute53) ListTrafficPolicyVersionsRequest(arg0 *route53.ListTrafficPolicyVersionsInput) (*request.Request, *route53.ListTrafficPolicyVersionsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrafficPolicyVersionsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListTrafficPolicyVersionsOutput)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyVersionsRequest indicates an expected call of ListTrafficPolicyVersionsRequest.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyVersionsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyVersionsRequest", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyVersionsRequest), arg0)
+}
+
+// ListTrafficPolicyVersionsWithContext mocks base method.
+func (m *MockRoute53) ListTrafficPolicyVersionsWithContext(arg0 context.Context, arg1 *route53.ListTrafficPolicyVersionsInput, arg2 ...request.Option) (*route53.ListTrafficPolicyVersionsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrafficPolicyVersionsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListTrafficPolicyVersionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrafficPolicyVersionsWithContext indicates an expected call of ListTrafficPolicyVersionsWithContext.
+func (mr *MockRoute53MockRecorder) ListTrafficPolicyVersionsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrafficPolicyVersionsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListTrafficPolicyVersionsWithContext), varargs...)
+}
+
+// ListVPCAssociationAuthorizations mocks base method.
+func (m *MockRoute53) ListVPCAssociationAuthorizations(arg0 *route53.ListVPCAssociationAuthorizationsInput) (*route53.ListVPCAssociationAuthorizationsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCAssociationAuthorizations", arg0)
+	ret0, _ := ret[0].(*route53.ListVPCAssociationAuthorizationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVPCAssociationAuthorizations indicates an expected call of ListVPCAssociationAuthorizations.
+func (mr *MockRoute53MockRecorder) ListVPCAssociationAuthorizations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCAssociationAuthorizations", reflect.TypeOf((*MockRoute53)(nil).ListVPCAssociationAuthorizations), arg0)
+}
+
+// ListVPCAssociationAuthorizationsRequest mocks base method.
+func (m *MockRoute53) ListVPCAssociationAuthorizationsRequest(arg0 *route53.ListVPCAssociationAuthorizationsInput) (*request.Request, *route53.ListVPCAssociationAuthorizationsOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVPCAssociationAuthorizationsRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.ListVPCAssociationAuthorizationsOutput)
+	return ret0, ret1
+}
+
+// ListVPCAssociationAuthorizationsRequest indicates an expected call of ListVPCAssociationAuthorizationsRequest.
+func (mr *MockRoute53MockRecorder) ListVPCAssociationAuthorizationsRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCAssociationAuthorizationsRequest", reflect.TypeOf((*MockRoute53)(nil).ListVPCAssociationAuthorizationsRequest), arg0)
+}
+
+// ListVPCAssociationAuthorizationsWithContext mocks base method.
+func (m *MockRoute53) ListVPCAssociationAuthorizationsWithContext(arg0 context.Context, arg1 *route53.ListVPCAssociationAuthorizationsInput, arg2 ...request.Option) (*route53.ListVPCAssociationAuthorizationsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListVPCAssociationAuthorizationsWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.ListVPCAssociationAuthorizationsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVPCAssociationAuthorizationsWithContext indicates an expected call of ListVPCAssociationAuthorizationsWithContext.
+func (mr *MockRoute53MockRecorder) ListVPCAssociationAuthorizationsWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVPCAssociationAuthorizationsWithContext", reflect.TypeOf((*MockRoute53)(nil).ListVPCAssociationAuthorizationsWithContext), varargs...)
+}
+
+// TestDNSAnswer mocks base method.
+func (m *MockRoute53) TestDNSAnswer(arg0 *route53.TestDNSAnswerInput) (*route53.TestDNSAnswerOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestDNSAnswer", arg0)
+	ret0, _ := ret[0].(*route53.TestDNSAnswerOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestDNSAnswer indicates an expected call of TestDNSAnswer.
+func (mr *MockRoute53MockRecorder) TestDNSAnswer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestDNSAnswer", reflect.TypeOf((*MockRoute53)(nil).TestDNSAnswer), arg0)
+}
+
+// TestDNSAnswerRequest mocks base method.
+func (m *MockRoute53) TestDNSAnswerRequest(arg0 *route53.TestDNSAnswerInput) (*request.Request, *route53.TestDNSAnswerOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestDNSAnswerRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.TestDNSAnswerOutput)
+	return ret0, ret1
+}
+
+// TestDNSAnswerRequest indicates an expected call of TestDNSAnswerRequest.
+func (mr *MockRoute53MockRecorder) TestDNSAnswerRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestDNSAnswerRequest", reflect.TypeOf((*MockRoute53)(nil).TestDNSAnswerRequest), arg0)
+}
+
+// TestDNSAnswerWithContext mocks base method.
+func (m *MockRoute53) TestDNSAnswerWithContext(arg0 context.Context, arg1 *route53.TestDNSAnswerInput, arg2 ...request.Option) (*route53.TestDNSAnswerOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TestDNSAnswerWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.TestDNSAnswerOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestDNSAnswerWithContext indicates an expected call of TestDNSAnswerWithContext.
+func (mr *MockRoute53MockRecorder) TestDNSAnswerWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestDNSAnswerWithContext", reflect.TypeOf((*MockRoute53)(nil).TestDNSAnswerWithContext), varargs...)
+}
+
+// UpdateHealthCheck mocks base method.
+func (m *MockRoute53) UpdateHealthCheck(arg0 *route53.UpdateHealthCheckInput) (*route53.UpdateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealthCheck", arg0)
+	ret0, _ := ret[0].(*route53.UpdateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHealthCheck indicates an expected call of UpdateHealthCheck.
+func (mr *MockRoute53MockRecorder) UpdateHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealthCheck", reflect.TypeOf((*MockRoute53)(nil).UpdateHealthCheck), arg0)
+}
+
+// UpdateHealthCheckRequest mocks base method.
+func (m *MockRoute53) UpdateHealthCheckRequest(arg0 *route53.UpdateHealthCheckInput) (*request.Request, *route53.UpdateHealthCheckOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealthCheckRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.UpdateHealthCheckOutput)
+	return ret0, ret1
+}
+
+// UpdateHealthCheckRequest indicates an expected call of UpdateHealthCheckRequest.
+func (mr *MockRoute53MockRecorder) UpdateHealthCheckRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealthCheckRequest", reflect.TypeOf((*MockRoute53)(nil).UpdateHealthCheckRequest), arg0)
+}
+
+// UpdateHealthCheckWithContext mocks base method.
+func (m *MockRoute53) UpdateHealthCheckWithContext(arg0 context.Context, arg1 *route53.UpdateHealthCheckInput, arg2 ...request.Option) (*route53.UpdateHealthCheckOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateHealthCheckWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.UpdateHealthCheckOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHealthCheckWithContext indicates an expected call of UpdateHealthCheckWithContext.
+func (mr *MockRoute53MockRecorder) UpdateHealthCheckWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealthCheckWithContext", reflect.TypeOf((*MockRoute53)(nil).UpdateHealthCheckWithContext), varargs...)
+}
+
+// UpdateHostedZoneComment mocks base method.
+func (m *MockRoute53) UpdateHostedZoneComment(arg0 *route53.UpdateHostedZoneCommentInput) (*route53.UpdateHostedZoneCommentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHostedZoneComment", arg0)
+	ret0, _ := ret[0].(*route53.UpdateHostedZoneCommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHostedZoneComment indicates an expected call of UpdateHostedZoneComment.
+func (mr *MockRoute53MockRecorder) UpdateHostedZoneComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostedZoneComment", reflect.TypeOf((*MockRoute53)(nil).UpdateHostedZoneComment), arg0)
+}
+
+// UpdateHostedZoneCommentRequest mocks base method.
+func (m *MockRoute53) UpdateHostedZoneCommentRequest(arg0 *route53.UpdateHostedZoneCommentInput) (*request.Request, *route53.UpdateHostedZoneCommentOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHostedZoneCommentRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.UpdateHostedZoneCommentOutput)
+	return ret0, ret1
+}
+
+// UpdateHostedZoneCommentRequest indicates an expected call of UpdateHostedZoneCommentRequest.
+func (mr *MockRoute53MockRecorder) UpdateHostedZoneCommentRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostedZoneCommentRequest", reflect.TypeOf((*MockRoute53)(nil).UpdateHostedZoneCommentRequest), arg0)
+}
+
+// UpdateHostedZoneCommentWithContext mocks base method.
+func (m *MockRoute53) UpdateHostedZoneCommentWithContext(arg0 context.Context, arg1 *route53.UpdateHostedZoneCommentInput, arg2 ...request.Option) (*route53.UpdateHostedZoneCommentOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateHostedZoneCommentWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.UpdateHostedZoneCommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHostedZoneCommentWithContext indicates an expected call of UpdateHostedZoneCommentWithContext.
+func (mr *MockRoute53MockRecorder) UpdateHostedZoneCommentWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHostedZoneCommentWithContext", reflect.TypeOf((*MockRoute53)(nil).UpdateHostedZoneCommentWithContext), varargs...)
+}
+
+// UpdateTrafficPolicyComment mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyComment(arg0 *route53.UpdateTrafficPolicyCommentInput) (*route53.UpdateTrafficPolicyCommentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyComment", arg0)
+	ret0, _ := ret[0].(*route53.UpdateTrafficPolicyCommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyComment indicates an expected call of UpdateTrafficPolicyComment.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyComment", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyComment), arg0)
+}
+
+// UpdateTrafficPolicyCommentRequest mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyCommentRequest(arg0 *route53.UpdateTrafficPolicyCommentInput) (*request.Request, *route53.UpdateTrafficPolicyCommentOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyCommentRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.UpdateTrafficPolicyCommentOutput)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyCommentRequest indicates an expected call of UpdateTrafficPolicyCommentRequest.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyCommentRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyCommentRequest", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyCommentRequest), arg0)
+}
+
+// UpdateTrafficPolicyCommentWithContext mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyCommentWithContext(arg0 context.Context, arg1 *route53.UpdateTrafficPolicyCommentInput, arg2 ...request.Option) (*route53.UpdateTrafficPolicyCommentOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyCommentWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.UpdateTrafficPolicyCommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyCommentWithContext indicates an expected call of UpdateTrafficPolicyCommentWithContext.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyCommentWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyCommentWithContext", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyCommentWithContext), varargs...)
+}
+
+// UpdateTrafficPolicyInstance mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyInstance(arg0 *route53.UpdateTrafficPolicyInstanceInput) (*route53.UpdateTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyInstance", arg0)
+	ret0, _ := ret[0].(*route53.UpdateTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyInstance indicates an expected call of UpdateTrafficPolicyInstance.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyInstance", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyInstance), arg0)
+}
+
+// UpdateTrafficPolicyInstanceRequest mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyInstanceRequest(arg0 *route53.UpdateTrafficPolicyInstanceInput) (*request.Request, *route53.UpdateTrafficPolicyInstanceOutput) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyInstanceRequest", arg0)
+	ret0, _ := ret[0].(*request.Request)
+	ret1, _ := ret[1].(*route53.UpdateTrafficPolicyInstanceOutput)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyInstanceRequest indicates an expected call of UpdateTrafficPolicyInstanceRequest.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyInstanceRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyInstanceRequest", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyInstanceRequest), arg0)
+}
+
+// UpdateTrafficPolicyInstanceWithContext mocks base method.
+func (m *MockRoute53) UpdateTrafficPolicyInstanceWithContext(arg0 context.Context, arg1 *route53.UpdateTrafficPolicyInstanceInput, arg2 ...request.Option) (*route53.UpdateTrafficPolicyInstanceOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTrafficPolicyInstanceWithContext", varargs...)
+	ret0, _ := ret[0].(*route53.UpdateTrafficPolicyInstanceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrafficPolicyInstanceWithContext indicates an expected call of UpdateTrafficPolicyInstanceWithContext.
+func (mr *MockRoute53MockRecorder) UpdateTrafficPolicyInstanceWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrafficPolicyInstanceWithContext", reflect.TypeOf((*MockRoute53)(nil).UpdateTrafficPolicyInstanceWithContext), varargs...)
+}
+
+// WaitUntilResourceRecordSetsChanged mocks base method.
+func (m *MockRoute53) WaitUntilResourceRecordSetsChanged(arg0 *route53.GetChangeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilResourceRecordSetsChanged", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilResourceRecordSetsChanged indicates an expected call of WaitUntilResourceRecordSetsChanged.
+func (mr *MockRoute53MockRecorder) WaitUntilResourceRecordSetsChanged(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilResourceRecordSetsChanged", reflect.TypeOf((*MockRoute53)(nil).WaitUntilResourceRecordSetsChanged), arg0)
+}
+
+// WaitUntilResourceRecordSetsChangedWithContext mocks base method.
+func (m *MockRoute53) WaitUntilResourceRecordSetsChangedWithContext(arg0 context.Context, arg1 *route53.GetChangeInput, arg2 ...request.WaiterOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitUntilResourceRecordSetsChangedWithContext", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilResourceRecordSetsChangedWithContext indicates an expected call of WaitUntilResourceRecordSetsChangedWithContext.
+func (mr *MockRoute53MockRecorder) WaitUntilResourceRecordSetsChangedWithContext(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilResourceRecordSetsChangedWithContext", reflect.TypeOf((*MockRoute53)(nil).WaitUntilResourceRecordSetsChangedWithContext), varargs...)
+}
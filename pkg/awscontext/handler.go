@@ -0,0 +1,31 @@
+package awscontext
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+)
+
+// HandlerPath is the path to serve the AWS context on the metrics server.
+const HandlerPath = "/aws-context"
+
+// NewHandler constructs a read-only http.Handler that serves the AWS context from provider in JSON.
+func NewHandler(provider Provider, logger logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		awsContext := provider.Resolve(req.Context())
+		payload, err := json.MarshalIndent(awsContext, "", "  ")
+		if err != nil {
+			logger.Error(err, "failed to encode AWS context")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(payload)
+	})
+}
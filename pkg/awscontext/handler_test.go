@@ -0,0 +1,79 @@
+package awscontext
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type staticProvider struct {
+	awsContext AWSContext
+}
+
+func (p *staticProvider) Resolve(_ context.Context) AWSContext {
+	return p.awsContext
+}
+
+func TestNewHandler(t *testing.T) {
+	provider := &staticProvider{
+		awsContext: AWSContext{
+			ClusterName: "cluster-1",
+			Region:      "us-west-2",
+			VPCID:       "vpc-1",
+			DiscoveredSubnets: map[elbv2model.LoadBalancerScheme][]SubnetInfo{
+				elbv2model.LoadBalancerSchemeInternal: {
+					{SubnetID: "subnet-1", AvailabilityZone: "us-west-2a", CIDR: "192.168.0.0/19"},
+				},
+			},
+			ResolvedAt: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "GET request",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody: `{
+  "clusterName": "cluster-1",
+  "region": "us-west-2",
+  "vpcID": "vpc-1",
+  "discoveredSubnets": {
+    "internal": [
+      {
+        "subnetID": "subnet-1",
+        "availabilityZone": "us-west-2a",
+        "cidr": "192.168.0.0/19"
+      }
+    ]
+  },
+  "resolvedAt": "2021-07-01T00:00:00Z"
+}`,
+		},
+		{
+			name:       "POST request",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "method not allowed\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(provider, &log.NullLogger{})
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, HandlerPath, nil))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package awscontext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+)
+
+const defaultAWSContextCacheTTL = 1 * time.Minute
+
+// AWSContext is the AWS context resolved by the controller.
+type AWSContext struct {
+	// ClusterName is the name of the Kubernetes cluster.
+	ClusterName string `json:"clusterName"`
+	// Region is the AWS region of the cluster.
+	Region string `json:"region"`
+	// VPCID is the ID of VPC for load balancer resources.
+	VPCID string `json:"vpcID"`
+	// VPCIPv4CIDRs is the associated IPv4 CIDRs of VPC.
+	VPCIPv4CIDRs []string `json:"vpcIPv4CIDRs,omitempty"`
+	// VPCIPv6CIDRs is the associated IPv6 CIDRs of VPC.
+	VPCIPv6CIDRs []string `json:"vpcIPv6CIDRs,omitempty"`
+	// ClusterTags is the tags applied to AWS resources to identify the cluster.
+	ClusterTags map[string]string `json:"clusterTags,omitempty"`
+	// DefaultTags is the tags applied to all AWS resources managed by the controller.
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+	// DiscoveredSubnets is the subnets auto-discovered for application load balancers, by scheme.
+	DiscoveredSubnets map[elbv2model.LoadBalancerScheme][]SubnetInfo `json:"discoveredSubnets"`
+	// Errors is the errors encountered during resolve.
+	Errors []string `json:"errors,omitempty"`
+	// ResolvedAt is the time when this context is resolved.
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// SubnetInfo is the information of a subnet.
+type SubnetInfo struct {
+	SubnetID         string `json:"subnetID"`
+	AvailabilityZone string `json:"availabilityZone"`
+	CIDR             string `json:"cidr"`
+}
+
+// Provider provides the AWS context resolved by the controller.
+type Provider interface {
+	// Resolve resolves the AWS context, results are cached for a short period.
+	Resolve(ctx context.Context) AWSContext
+}
+
+// NewDefaultProvider constructs new defaultProvider.
+func NewDefaultProvider(clusterName string, region string, vpcID string, clusterTags map[string]string, defaultTags map[string]string,
+	vpcInfoProvider networking.VPCInfoProvider, subnetsResolver networking.SubnetsResolver, logger logr.Logger) *defaultProvider {
+	return &defaultProvider{
+		clusterName:     clusterName,
+		region:          region,
+		vpcID:           vpcID,
+		clusterTags:     clusterTags,
+		defaultTags:     defaultTags,
+		vpcInfoProvider: vpcInfoProvider,
+		subnetsResolver: subnetsResolver,
+		logger:          logger,
+		cacheTTL:        defaultAWSContextCacheTTL,
+	}
+}
+
+var _ Provider = &defaultProvider{}
+
+// default implementation for Provider
+type defaultProvider struct {
+	clusterName     string
+	region          string
+	vpcID           string
+	clusterTags     map[string]string
+	defaultTags     map[string]string
+	vpcInfoProvider networking.VPCInfoProvider
+	subnetsResolver networking.SubnetsResolver
+	logger          logr.Logger
+
+	cacheTTL   time.Duration
+	cacheMutex sync.Mutex
+	cached     *AWSContext
+}
+
+func (p *defaultProvider) Resolve(ctx context.Context) AWSContext {
+	p.cacheMutex.Lock()
+	defer p.cacheMutex.Unlock()
+	if p.cached != nil && time.Since(p.cached.ResolvedAt) < p.cacheTTL {
+		return *p.cached
+	}
+	awsContext := p.resolve(ctx)
+	p.cached = &awsContext
+	return awsContext
+}
+
+func (p *defaultProvider) resolve(ctx context.Context) AWSContext {
+	awsContext := AWSContext{
+		ClusterName:       p.clusterName,
+		Region:            p.region,
+		VPCID:             p.vpcID,
+		ClusterTags:       p.clusterTags,
+		DefaultTags:       p.defaultTags,
+		DiscoveredSubnets: make(map[elbv2model.LoadBalancerScheme][]SubnetInfo),
+		ResolvedAt:        time.Now(),
+	}
+	vpcInfo, err := p.vpcInfoProvider.FetchVPCInfo(ctx, p.vpcID)
+	if err != nil {
+		p.logger.Error(err, "failed to fetch VPC info", "vpcID", p.vpcID)
+		awsContext.Errors = append(awsContext.Errors, err.Error())
+	} else {
+		awsContext.VPCIPv4CIDRs = vpcInfo.AssociatedIPv4CIDRs()
+		awsContext.VPCIPv6CIDRs = vpcInfo.AssociatedIPv6CIDRs()
+	}
+
+	for _, scheme := range []elbv2model.LoadBalancerScheme{elbv2model.LoadBalancerSchemeInternal, elbv2model.LoadBalancerSchemeInternetFacing} {
+		subnets, err := p.subnetsResolver.ResolveViaDiscovery(ctx,
+			networking.WithSubnetsResolveLBType(elbv2model.LoadBalancerTypeApplication),
+			networking.WithSubnetsResolveLBScheme(scheme),
+		)
+		if err != nil {
+			p.logger.Error(err, "failed to discover subnets", "scheme", scheme)
+			awsContext.Errors = append(awsContext.Errors, err.Error())
+			continue
+		}
+		awsContext.DiscoveredSubnets[scheme] = buildSubnetInfos(subnets)
+	}
+	return awsContext
+}
+
+func buildSubnetInfos(subnets []*ec2sdk.Subnet) []SubnetInfo {
+	subnetInfos := make([]SubnetInfo, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetInfos = append(subnetInfos, SubnetInfo{
+			SubnetID:         awssdk.StringValue(subnet.SubnetId),
+			AvailabilityZone: awssdk.StringValue(subnet.AvailabilityZone),
+			CIDR:             awssdk.StringValue(subnet.CidrBlock),
+		})
+	}
+	return subnetInfos
+}
@@ -0,0 +1,134 @@
+package awscontext
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	ec2sdk "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultProvider_Resolve(t *testing.T) {
+	type fetchVPCInfoCall struct {
+		vpcInfo networking.VPCInfo
+		err     error
+	}
+	type resolveViaDiscoveryCall struct {
+		subnets []*ec2sdk.Subnet
+		err     error
+	}
+	vpcInfo := networking.VPCInfo{
+		CidrBlockAssociationSet: []*ec2sdk.VpcCidrBlockAssociation{
+			{
+				CidrBlock: awssdk.String("192.168.0.0/16"),
+				CidrBlockState: &ec2sdk.VpcCidrBlockState{
+					State: awssdk.String(ec2sdk.VpcCidrBlockStateCodeAssociated),
+				},
+			},
+		},
+	}
+	internalSubnets := []*ec2sdk.Subnet{
+		{
+			SubnetId:         awssdk.String("subnet-1"),
+			AvailabilityZone: awssdk.String("us-west-2a"),
+			CidrBlock:        awssdk.String("192.168.0.0/19"),
+		},
+		{
+			SubnetId:         awssdk.String("subnet-2"),
+			AvailabilityZone: awssdk.String("us-west-2b"),
+			CidrBlock:        awssdk.String("192.168.32.0/19"),
+		},
+	}
+	publicSubnets := []*ec2sdk.Subnet{
+		{
+			SubnetId:         awssdk.String("subnet-3"),
+			AvailabilityZone: awssdk.String("us-west-2a"),
+			CidrBlock:        awssdk.String("192.168.64.0/19"),
+		},
+	}
+	tests := []struct {
+		name                     string
+		fetchVPCInfoCall         fetchVPCInfoCall
+		resolveViaDiscoveryCalls []resolveViaDiscoveryCall
+		want                     AWSContext
+	}{
+		{
+			name:             "all resolved",
+			fetchVPCInfoCall: fetchVPCInfoCall{vpcInfo: vpcInfo},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{
+				{subnets: internalSubnets},
+				{subnets: publicSubnets},
+			},
+			want: AWSContext{
+				ClusterName:  "cluster-1",
+				Region:       "us-west-2",
+				VPCID:        "vpc-1",
+				VPCIPv4CIDRs: []string{"192.168.0.0/16"},
+				ClusterTags:  map[string]string{"elbv2.k8s.aws/cluster": "cluster-1"},
+				DiscoveredSubnets: map[elbv2model.LoadBalancerScheme][]SubnetInfo{
+					elbv2model.LoadBalancerSchemeInternal: {
+						{SubnetID: "subnet-1", AvailabilityZone: "us-west-2a", CIDR: "192.168.0.0/19"},
+						{SubnetID: "subnet-2", AvailabilityZone: "us-west-2b", CIDR: "192.168.32.0/19"},
+					},
+					elbv2model.LoadBalancerSchemeInternetFacing: {
+						{SubnetID: "subnet-3", AvailabilityZone: "us-west-2a", CIDR: "192.168.64.0/19"},
+					},
+				},
+			},
+		},
+		{
+			name:             "failed to resolve partially",
+			fetchVPCInfoCall: fetchVPCInfoCall{err: errors.New("some aws error")},
+			resolveViaDiscoveryCalls: []resolveViaDiscoveryCall{
+				{subnets: internalSubnets},
+				{err: errors.New("unable to discover at least one subnet")},
+			},
+			want: AWSContext{
+				ClusterName: "cluster-1",
+				Region:      "us-west-2",
+				VPCID:       "vpc-1",
+				ClusterTags: map[string]string{"elbv2.k8s.aws/cluster": "cluster-1"},
+				DiscoveredSubnets: map[elbv2model.LoadBalancerScheme][]SubnetInfo{
+					elbv2model.LoadBalancerSchemeInternal: {
+						{SubnetID: "subnet-1", AvailabilityZone: "us-west-2a", CIDR: "192.168.0.0/19"},
+						{SubnetID: "subnet-2", AvailabilityZone: "us-west-2b", CIDR: "192.168.32.0/19"},
+					},
+				},
+				Errors: []string{"some aws error", "unable to discover at least one subnet"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vpcInfoProvider := networking.NewMockVPCInfoProvider(ctrl)
+			vpcInfoProvider.EXPECT().FetchVPCInfo(gomock.Any(), "vpc-1").Return(tt.fetchVPCInfoCall.vpcInfo, tt.fetchVPCInfoCall.err)
+			subnetsResolver := networking.NewMockSubnetsResolver(ctrl)
+			var calls []*gomock.Call
+			for _, call := range tt.resolveViaDiscoveryCalls {
+				calls = append(calls, subnetsResolver.EXPECT().ResolveViaDiscovery(gomock.Any(), gomock.Any(), gomock.Any()).Return(call.subnets, call.err))
+			}
+			gomock.InOrder(calls...)
+
+			p := NewDefaultProvider("cluster-1", "us-west-2", "vpc-1", map[string]string{"elbv2.k8s.aws/cluster": "cluster-1"}, nil,
+				vpcInfoProvider, subnetsResolver, &log.NullLogger{})
+			got := p.Resolve(context.Background())
+			assert.False(t, got.ResolvedAt.IsZero())
+			got.ResolvedAt = tt.want.ResolvedAt
+			assert.Equal(t, tt.want, got)
+
+			// subsequent resolves should be served from cache.
+			gotCached := p.Resolve(context.Background())
+			gotCached.ResolvedAt = tt.want.ResolvedAt
+			assert.Equal(t, tt.want, gotCached)
+		})
+	}
+}
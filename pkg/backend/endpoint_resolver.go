@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -16,7 +17,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const svcNameLabel = "kubernetes.io/service-name"
+const (
+	svcNameLabel = "kubernetes.io/service-name"
+	// endpointSliceControllerName is the managed-by label value of EndpointSlices managed by the EndpointSlice controller in kube-controller-manager.
+	endpointSliceControllerName = "endpointslice-controller.k8s.io"
+)
 
 var ErrNotFound = errors.New("backend not found")
 
@@ -40,6 +45,9 @@ type EndpointResolver interface {
 	// ResolveNodePortEndpoints will resolve endpoints backed by nodePort.
 	ResolveNodePortEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString,
 		opts ...EndpointResolveOption) ([]NodePortEndpoint, error)
+
+	// ResolvePodIPs will resolve the IPs of pod that are registered into IP-mode target groups.
+	ResolvePodIPs(ctx context.Context, pod *corev1.Pod) ([]string, error)
 }
 
 // NewDefaultEndpointResolver constructs new defaultEndpointResolver
@@ -154,11 +162,19 @@ func (r *defaultEndpointResolver) ResolvePodEndpointsFromSlices(ctx context.Cont
 	containsPotentialReadyEndpoints := false
 	var endpoints []PodEndpoint
 	usedAddrs := make(sets.String)
+	customEPSliceManagers := sets.NewString(resolveOpts.CustomEndpointSliceManagers...)
 	for _, epSlice := range epSlicesList.Items {
+		if epSlice.AddressType == discv1.AddressTypeFQDN {
+			continue
+		}
+		isCustomEPSlice := isCustomEndpointSlice(epSlice, customEPSliceManagers)
 		// process epSlice
 		for _, epPort := range epSlice.Ports {
 			// servicePort.Name is optional if there is only one port
-			if svcPort.Name != "" && svcPort.Name != *epPort.Name {
+			if svcPort.Name != "" && svcPort.Name != awssdk.StringValue(epPort.Name) {
+				continue
+			}
+			if epPort.Port == nil {
 				continue
 			}
 			for _, ep := range epSlice.Endpoints {
@@ -167,6 +183,11 @@ func (r *defaultEndpointResolver) ResolvePodEndpointsFromSlices(ctx context.Cont
 						continue
 					}
 					if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" {
+						// endpoints of custom EndpointSlices are registered as-is if they're not backed by pods.
+						if isCustomEPSlice && (ep.Conditions.Ready == nil || *ep.Conditions.Ready) {
+							usedAddrs.Insert(epAddr)
+							endpoints = append(endpoints, buildCustomPodEndpointFromSlice(epAddr, epPort))
+						}
 						continue
 					}
 					if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
@@ -219,6 +240,9 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 		return nil, errors.Errorf("service type must be either 'NodePort' or 'LoadBalancer': %v", svcKey)
 	}
 	svcNodePort := svcPort.NodePort
+	if svcNodePort == 0 {
+		return nil, errors.Errorf("service port %v has no nodePort allocated: %v", port.String(), svcKey)
+	}
 	nodeList := &corev1.NodeList{}
 	if err := r.k8sClient.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: resolveOpts.NodeSelector}); err != nil {
 		return nil, err
@@ -240,6 +264,17 @@ func (r *defaultEndpointResolver) ResolveNodePortEndpoints(ctx context.Context,
 	return endpoints, nil
 }
 
+func (r *defaultEndpointResolver) ResolvePodIPs(_ context.Context, pod *corev1.Pod) ([]string, error) {
+	podIPs := sets.NewString()
+	if pod.Status.PodIP != "" {
+		podIPs.Insert(pod.Status.PodIP)
+	}
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs.Insert(podIP.IP)
+	}
+	return podIPs.List(), nil
+}
+
 func (r *defaultEndpointResolver) findServiceAndServicePort(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString) (*corev1.Service, corev1.ServicePort, error) {
 	svc := &corev1.Service{}
 	if err := r.k8sClient.Get(ctx, svcKey, svc); err != nil {
@@ -277,6 +312,21 @@ func buildPodEndpointFromSlice(pod k8s.PodInfo, epAddr string, epPort discv1.End
 	}
 }
 
+// buildCustomPodEndpointFromSlice builds the PodEndpoint for an endpoint of custom EndpointSlice that isn't backed by a pod.
+func buildCustomPodEndpointFromSlice(epAddr string, epPort discv1.EndpointPort) PodEndpoint {
+	return PodEndpoint{
+		IP:   epAddr,
+		Port: int64(*epPort.Port),
+	}
+}
+
+// isCustomEndpointSlice checks whether the EndpointSlice is provided by one of the allowed customManagers other than the EndpointSlice controller,
+// e.g. the EndpointSlice mirroring controller for Services without selectors, or operators that compute their own backends.
+func isCustomEndpointSlice(epSlice discv1.EndpointSlice, customManagers sets.String) bool {
+	managedBy := epSlice.Labels[discv1.LabelManagedBy]
+	return managedBy != endpointSliceControllerName && customManagers.Has(managedBy)
+}
+
 func buildNodePortEndpoint(node *corev1.Node, instanceID string, nodePort int32) NodePortEndpoint {
 	return NodePortEndpoint{
 		InstanceID: instanceID,
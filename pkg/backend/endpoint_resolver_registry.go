@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EndpointResolverEndpoints resolves pod IPs from Endpoints or EndpointSlices, which is the default.
+	EndpointResolverEndpoints = "endpoints"
+	// EndpointResolverCalico resolves pod IPs from the podIP annotation set by Calico CNI.
+	EndpointResolverCalico = "calico"
+	// EndpointResolverCilium resolves pod IPs from CiliumEndpoint objects.
+	EndpointResolverCilium = "cilium"
+	// EndpointResolverMultus resolves pod IPs from the network-status annotation set by Multus CNI,
+	// using the network selected by the elbv2.k8s.aws/target-network annotation on pods.
+	EndpointResolverMultus = "multus"
+)
+
+// EndpointResolverFactory constructs an EndpointResolver.
+type EndpointResolverFactory func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver
+
+// EndpointResolverRegistry tracks the EndpointResolver implementations by name.
+type EndpointResolverRegistry interface {
+	// Register registers an EndpointResolverFactory with name.
+	Register(name string, factory EndpointResolverFactory) error
+
+	// Build constructs the EndpointResolver registered with name.
+	Build(name string, k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) (EndpointResolver, error)
+
+	// Names returns the sorted names of registered EndpointResolvers.
+	Names() []string
+}
+
+// NewDefaultEndpointResolverRegistry constructs new defaultEndpointResolverRegistry with built-in EndpointResolvers registered.
+func NewDefaultEndpointResolverRegistry() *defaultEndpointResolverRegistry {
+	registry := &defaultEndpointResolverRegistry{
+		factories: make(map[string]EndpointResolverFactory),
+	}
+	registry.factories[EndpointResolverEndpoints] = func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver {
+		return NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
+	}
+	registry.factories[EndpointResolverCalico] = func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver {
+		return NewPodIPSourceEndpointResolver(NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger), NewCalicoPodIPSource())
+	}
+	registry.factories[EndpointResolverCilium] = func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver {
+		return NewPodIPSourceEndpointResolver(NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger), NewCiliumPodIPSource(k8sClient))
+	}
+	registry.factories[EndpointResolverMultus] = func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver {
+		return NewPodIPSourceEndpointResolver(NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger), NewMultusPodIPSource())
+	}
+	return registry
+}
+
+var _ EndpointResolverRegistry = &defaultEndpointResolverRegistry{}
+
+// default implementation for EndpointResolverRegistry
+type defaultEndpointResolverRegistry struct {
+	factoriesMutex sync.RWMutex
+	factories      map[string]EndpointResolverFactory
+}
+
+func (r *defaultEndpointResolverRegistry) Register(name string, factory EndpointResolverFactory) error {
+	r.factoriesMutex.Lock()
+	defer r.factoriesMutex.Unlock()
+	if _, exists := r.factories[name]; exists {
+		return errors.Errorf("endpoint resolver %v already registered", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+func (r *defaultEndpointResolverRegistry) Build(name string, k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) (EndpointResolver, error) {
+	r.factoriesMutex.RLock()
+	factory, exists := r.factories[name]
+	r.factoriesMutex.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("unknown endpoint resolver %v, must be one of %v", name, r.Names())
+	}
+	return factory(k8sClient, podInfoRepo, logger), nil
+}
+
+func (r *defaultEndpointResolverRegistry) Names() []string {
+	r.factoriesMutex.RLock()
+	defer r.factoriesMutex.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
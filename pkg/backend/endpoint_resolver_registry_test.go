@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultEndpointResolverRegistry_Build(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver string
+		wantErr  error
+	}{
+		{
+			name:     "endpoints resolver",
+			resolver: "endpoints",
+		},
+		{
+			name:     "calico resolver",
+			resolver: "calico",
+		},
+		{
+			name:     "cilium resolver",
+			resolver: "cilium",
+		},
+		{
+			name:     "multus resolver",
+			resolver: "multus",
+		},
+		{
+			name:     "unknown resolver",
+			resolver: "weave",
+			wantErr:  errors.New("unknown endpoint resolver weave, must be one of [calico cilium endpoints multus]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDefaultEndpointResolverRegistry()
+			got, err := r.Build(tt.resolver, nil, nil, &log.NullLogger{})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func Test_defaultEndpointResolverRegistry_Register(t *testing.T) {
+	factory := func(k8sClient client.Client, podInfoRepo k8s.PodInfoRepo, logger logr.Logger) EndpointResolver {
+		return NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
+	}
+	r := NewDefaultEndpointResolverRegistry()
+	assert.NoError(t, r.Register("custom", factory))
+	assert.EqualError(t, r.Register("custom", factory), "endpoint resolver custom already registered")
+	assert.Equal(t, []string{"calico", "cilium", "custom", "endpoints", "multus"}, r.Names())
+}
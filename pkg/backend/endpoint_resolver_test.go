@@ -932,6 +932,21 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 		},
 	}
+	svc1WithoutNodePort := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1",
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+			},
+		},
+	}
 	svc2 := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNS,
@@ -1065,6 +1080,19 @@ func Test_defaultEndpointResolver_ResolveNodePortEndpoints(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "unable to find port http on service test-ns/svc-1"),
 		},
+		{
+			name: "service port without nodePort allocated",
+			env: env{
+				nodes:    []*corev1.Node{node1, node2, node3, node4},
+				services: []*corev1.Service{svc1WithoutNodePort},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithNodeSelector(labels.Everything())},
+			},
+			wantErr: errors.New("service port http has no nodePort allocated: test-ns/svc-1"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1593,6 +1621,65 @@ func Test_defaultEndpointResolver_ResolvePodEndpointsFromSlices(t *testing.T) {
 			},
 		},
 	}
+	port1FName := "http"
+	port1FNumber := int32(9090)
+	epSlice1FConditions := []bool{true, false}
+	epSlice1F := &discv1.EndpointSlice{
+		AddressType: discv1.AddressTypeIPv4,
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-1F",
+			Labels: map[string]string{
+				"kubernetes.io/service-name":             "svc-1",
+				"endpointslice.kubernetes.io/managed-by": "mirroring.example.com",
+			},
+		},
+		Ports: []discv1.EndpointPort{
+			{
+				Name: &port1FName,
+				Port: &port1FNumber,
+			},
+		},
+		Endpoints: []discv1.Endpoint{
+			{
+				Addresses: []string{"10.100.0.1"},
+				Conditions: discv1.EndpointConditions{
+					Ready: &epSlice1FConditions[0],
+				},
+			},
+			{
+				Addresses: []string{"10.100.0.2"},
+				Conditions: discv1.EndpointConditions{
+					Ready: &epSlice1FConditions[1],
+				},
+			},
+			{
+				Addresses: []string{"10.100.0.3"},
+			},
+		},
+	}
+	epSlice1G := &discv1.EndpointSlice{
+		AddressType: discv1.AddressTypeIPv4,
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      "svc-1-1G",
+			Labels: map[string]string{
+				"kubernetes.io/service-name":             "svc-1",
+				"endpointslice.kubernetes.io/managed-by": "endpointslice-controller.k8s.io",
+			},
+		},
+		Ports: []discv1.EndpointPort{
+			{
+				Name: &port1FName,
+				Port: &port1FNumber,
+			},
+		},
+		Endpoints: []discv1.Endpoint{
+			{
+				Addresses: []string{"10.100.0.4"},
+			},
+		},
+	}
 
 	type podInfoRepoGetCall struct {
 		key    types.NamespacedName
@@ -1984,6 +2071,92 @@ func Test_defaultEndpointResolver_ResolvePodEndpointsFromSlices(t *testing.T) {
 			},
 			wantErr: fmt.Errorf("%w: %v", ErrNotFound, "endpointslices for \"svc-1\" not found"),
 		},
+		{
+			name: "ready endpoints without pods from allowed custom endpointslices will be included",
+			env: env{
+				services:    []*corev1.Service{svc1},
+				epSliceList: []*discv1.EndpointSlice{epSlice1A, epSlice1F, epSlice1G},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithCustomEndpointSliceManagers("mirroring.example.com")},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "10.100.0.1",
+					Port: 9090,
+				},
+				{
+					IP:   "10.100.0.3",
+					Port: 9090,
+				},
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
+		{
+			name: "endpoints without pods from custom endpointslices that aren't allowed will be ignored",
+			env: env{
+				services:    []*corev1.Service{svc1},
+				epSliceList: []*discv1.EndpointSlice{epSlice1A, epSlice1F, epSlice1G},
+			},
+			fields: fields{
+				podInfoRepoGetCalls: []podInfoRepoGetCall{
+					{
+						key:    pod1.Key,
+						pod:    pod1,
+						exists: true,
+					},
+					{
+						key:    pod2.Key,
+						pod:    pod2,
+						exists: true,
+					},
+				},
+			},
+			args: args{
+				svcKey: k8s.NamespacedName(svc1),
+				port:   intstr.FromString("http"),
+				opts:   []EndpointResolveOption{WithCustomEndpointSliceManagers("other.example.com")},
+			},
+			want: []PodEndpoint{
+				{
+					IP:   "192.168.1.1",
+					Port: 8080,
+					Pod:  pod1,
+				},
+				{
+					IP:   "192.168.1.2",
+					Port: 8080,
+					Pod:  pod2,
+				},
+			},
+			wantContainsPotentialReadyEndpoints: false,
+		},
 	}
 
 	for _, tt := range tests {
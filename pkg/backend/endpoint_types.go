@@ -13,9 +13,15 @@ type PodEndpoint struct {
 	// Pod's container port.
 	Port int64
 	// Pod that provides this endpoint.
+	// It's empty for endpoints provided by custom EndpointSlices without pods, e.g. backends in other clusters.
 	Pod k8s.PodInfo
 }
 
+// IsPodBacked returns whether this endpoint is provided by a pod.
+func (e PodEndpoint) IsPodBacked() bool {
+	return e.Pod.Key.Name != ""
+}
+
 // An endpoint provided by nodePort as traffic proxy.
 type NodePortEndpoint struct {
 	// Node's instanceID.
@@ -35,6 +41,10 @@ type EndpointResolveOptions struct {
 	// [Pod Endpoint] If pod readinessGates is defined, then pods from unready addresses with any of these readinessGates and containersReady condition will be included as well.
 	// By default, no readinessGate is specified.
 	PodReadinessGates []corev1.PodConditionType
+
+	// [Pod Endpoint] ready endpoints that aren't backed by pods from custom EndpointSlices managed by any of these managers will be included as well.
+	// By default, no custom EndpointSlice is accepted.
+	CustomEndpointSliceManagers []string
 }
 
 func (opts *EndpointResolveOptions) ApplyOptions(options []EndpointResolveOption) {
@@ -59,6 +69,13 @@ func WithPodReadinessGate(cond corev1.PodConditionType) EndpointResolveOption {
 	}
 }
 
+// WithCustomEndpointSliceManagers is a option that appends custom EndpointSlice managers into EndpointResolveOptions.
+func WithCustomEndpointSliceManagers(managers ...string) EndpointResolveOption {
+	return func(opts *EndpointResolveOptions) {
+		opts.CustomEndpointSliceManagers = append(opts.CustomEndpointSliceManagers, managers...)
+	}
+}
+
 // defaultEndpointResolveOptions returns the default value for EndpointResolveOptions.
 func defaultEndpointResolveOptions() EndpointResolveOptions {
 	return EndpointResolveOptions{
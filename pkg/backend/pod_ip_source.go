@@ -0,0 +1,215 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var ciliumEndpointGVK = schema.GroupVersionKind{Group: "cilium.io", Version: "v2", Kind: "CiliumEndpoint"}
+
+// NewCiliumEndpoint constructs an empty CiliumEndpoint object, which is read as unstructured since Cilium APIs aren't vendored.
+func NewCiliumEndpoint() *unstructured.Unstructured {
+	ciliumEndpoint := &unstructured.Unstructured{}
+	ciliumEndpoint.SetGroupVersionKind(ciliumEndpointGVK)
+	return ciliumEndpoint
+}
+
+// PodIPSource resolves the IP that will be registered into IP-mode target groups for pod endpoints.
+type PodIPSource interface {
+	// ResolvePodIP returns the IP for pod, endpointIP is the pod's IP reported by Endpoints or EndpointSlices.
+	ResolvePodIP(ctx context.Context, pod k8s.PodInfo, endpointIP string) (string, error)
+}
+
+// NewPodIPSourceEndpointResolver constructs new podIPSourceEndpointResolver
+func NewPodIPSourceEndpointResolver(endpointResolver EndpointResolver, ipSource PodIPSource) *podIPSourceEndpointResolver {
+	return &podIPSourceEndpointResolver{
+		EndpointResolver: endpointResolver,
+		ipSource:         ipSource,
+	}
+}
+
+var _ EndpointResolver = &podIPSourceEndpointResolver{}
+
+// podIPSourceEndpointResolver is an EndpointResolver that overrides IPs of pod endpoints with IPs from PodIPSource.
+type podIPSourceEndpointResolver struct {
+	EndpointResolver
+	ipSource PodIPSource
+}
+
+func (r *podIPSourceEndpointResolver) ResolvePodEndpoints(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString,
+	opts ...EndpointResolveOption) ([]PodEndpoint, bool, error) {
+	endpoints, containsPotentialReadyEndpoints, err := r.EndpointResolver.ResolvePodEndpoints(ctx, svcKey, port, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := r.overridePodEndpointIPs(ctx, endpoints); err != nil {
+		return nil, false, err
+	}
+	return endpoints, containsPotentialReadyEndpoints, nil
+}
+
+func (r *podIPSourceEndpointResolver) ResolvePodEndpointsFromSlices(ctx context.Context, svcKey types.NamespacedName, port intstr.IntOrString,
+	opts ...EndpointResolveOption) ([]PodEndpoint, bool, error) {
+	endpoints, containsPotentialReadyEndpoints, err := r.EndpointResolver.ResolvePodEndpointsFromSlices(ctx, svcKey, port, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := r.overridePodEndpointIPs(ctx, endpoints); err != nil {
+		return nil, false, err
+	}
+	return endpoints, containsPotentialReadyEndpoints, nil
+}
+
+func (r *podIPSourceEndpointResolver) ResolvePodIPs(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	endpointIPs, err := r.EndpointResolver.ResolvePodIPs(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	podInfo := k8s.BuildPodInfo(pod)
+	podIPs := sets.NewString()
+	for _, endpointIP := range endpointIPs {
+		podIP, err := r.ipSource.ResolvePodIP(ctx, podInfo, endpointIP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve IP for pod %v", podInfo.Key)
+		}
+		podIPs.Insert(podIP)
+	}
+	return podIPs.List(), nil
+}
+
+func (r *podIPSourceEndpointResolver) overridePodEndpointIPs(ctx context.Context, endpoints []PodEndpoint) error {
+	for i := range endpoints {
+		if !endpoints[i].IsPodBacked() {
+			continue
+		}
+		podIP, err := r.ipSource.ResolvePodIP(ctx, endpoints[i].Pod, endpoints[i].IP)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve IP for pod %v", endpoints[i].Pod.Key)
+		}
+		endpoints[i].IP = podIP
+	}
+	return nil
+}
+
+// NewCalicoPodIPSource constructs new calicoPodIPSource
+func NewCalicoPodIPSource() *calicoPodIPSource {
+	return &calicoPodIPSource{}
+}
+
+var _ PodIPSource = &calicoPodIPSource{}
+
+// calicoPodIPSource resolves pod IP from the podIP annotation set by Calico CNI.
+// pods without the annotation will use IP reported by Endpoints.
+type calicoPodIPSource struct{}
+
+func (s *calicoPodIPSource) ResolvePodIP(_ context.Context, pod k8s.PodInfo, endpointIP string) (string, error) {
+	rawPodIP, exists := pod.NetworkAnnotations[k8s.AnnotationKeyCalicoPodIP]
+	if !exists {
+		return endpointIP, nil
+	}
+	podIP, _, err := net.ParseCIDR(rawPodIP)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse annotation %v: %v", k8s.AnnotationKeyCalicoPodIP, rawPodIP)
+	}
+	return podIP.String(), nil
+}
+
+// NewCiliumPodIPSource constructs new ciliumPodIPSource
+// k8sClient must serve unstructured objects from cache, so that CiliumEndpoints are read from informer instead of API server.
+func NewCiliumPodIPSource(k8sClient client.Client) *ciliumPodIPSource {
+	return &ciliumPodIPSource{
+		k8sClient: k8sClient,
+	}
+}
+
+var _ PodIPSource = &ciliumPodIPSource{}
+
+// ciliumPodIPSource resolves pod IP from the CiliumEndpoint with same name as pod.
+// pods without CiliumEndpoint will use IP reported by Endpoints.
+type ciliumPodIPSource struct {
+	k8sClient client.Client
+}
+
+func (s *ciliumPodIPSource) ResolvePodIP(ctx context.Context, pod k8s.PodInfo, endpointIP string) (string, error) {
+	ciliumEndpoint := NewCiliumEndpoint()
+	if err := s.k8sClient.Get(ctx, pod.Key, ciliumEndpoint); err != nil {
+		if apierrors.IsNotFound(err) {
+			return endpointIP, nil
+		}
+		return "", err
+	}
+	addressing, _, err := unstructured.NestedSlice(ciliumEndpoint.Object, "status", "networking", "addressing")
+	if err != nil {
+		return "", err
+	}
+	ipFamilyKey := "ipv4"
+	if ip := net.ParseIP(endpointIP); ip != nil && ip.To4() == nil {
+		ipFamilyKey = "ipv6"
+	}
+	for _, rawAddress := range addressing {
+		address, ok := rawAddress.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if podIP, ok := address[ipFamilyKey].(string); ok && podIP != "" {
+			return podIP, nil
+		}
+	}
+	return endpointIP, nil
+}
+
+// NewMultusPodIPSource constructs new multusPodIPSource
+func NewMultusPodIPSource() *multusPodIPSource {
+	return &multusPodIPSource{}
+}
+
+var _ PodIPSource = &multusPodIPSource{}
+
+// multusPodIPSource resolves pod IP from the network-status annotation set by Multus CNI.
+// the network is selected by the target-network annotation on pod, pods without it will use IP reported by Endpoints.
+type multusPodIPSource struct{}
+
+// multusNetworkStatus is the network status of pod reported by Multus CNI.
+type multusNetworkStatus struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	Default   bool     `json:"default,omitempty"`
+}
+
+func (s *multusPodIPSource) ResolvePodIP(_ context.Context, pod k8s.PodInfo, endpointIP string) (string, error) {
+	targetNetwork, exists := pod.NetworkAnnotations[k8s.AnnotationKeyTargetNetwork]
+	if !exists {
+		return endpointIP, nil
+	}
+	rawNetworkStatus, exists := pod.NetworkAnnotations[k8s.AnnotationKeyMultusNetworkStatus]
+	if !exists {
+		return "", errors.Errorf("annotation %v not found", k8s.AnnotationKeyMultusNetworkStatus)
+	}
+	var networkStatuses []multusNetworkStatus
+	if err := json.Unmarshal([]byte(rawNetworkStatus), &networkStatuses); err != nil {
+		return "", errors.Wrapf(err, "failed to parse annotation %v", k8s.AnnotationKeyMultusNetworkStatus)
+	}
+	for _, networkStatus := range networkStatuses {
+		if networkStatus.Name != targetNetwork {
+			continue
+		}
+		if len(networkStatus.IPs) == 0 {
+			return "", errors.Errorf("network %v has no IPs", targetNetwork)
+		}
+		return networkStatus.IPs[0], nil
+	}
+	return "", errors.Errorf("network %v not found", targetNetwork)
+}
@@ -0,0 +1,282 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_calicoPodIPSource_ResolvePodIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		pod        k8s.PodInfo
+		endpointIP string
+		want       string
+		wantErr    error
+	}{
+		{
+			name: "podIP annotation exists",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				NetworkAnnotations: map[string]string{
+					"cni.projectcalico.org/podIP": "10.1.1.1/32",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			want:       "10.1.1.1",
+		},
+		{
+			name: "podIP annotation didn't exist",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+			},
+			endpointIP: "192.168.1.1",
+			want:       "192.168.1.1",
+		},
+		{
+			name: "podIP annotation invalid",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+				NetworkAnnotations: map[string]string{
+					"cni.projectcalico.org/podIP": "10.1.1.1",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			wantErr:    errors.New("failed to parse annotation cni.projectcalico.org/podIP: 10.1.1.1: invalid CIDR address: 10.1.1.1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCalicoPodIPSource()
+			got, err := s.ResolvePodIP(context.Background(), tt.pod, tt.endpointIP)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_ciliumPodIPSource_ResolvePodIP(t *testing.T) {
+	ciliumEndpoint := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cilium.io/v2",
+			"kind":       "CiliumEndpoint",
+			"metadata": map[string]interface{}{
+				"namespace": "ns-1",
+				"name":      "pod-1",
+			},
+			"status": map[string]interface{}{
+				"networking": map[string]interface{}{
+					"addressing": []interface{}{
+						map[string]interface{}{
+							"ipv4": "10.1.1.1",
+						},
+						map[string]interface{}{
+							"ipv6": "2600:1f14::1",
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		pod        k8s.PodInfo
+		endpointIP string
+		want       string
+	}{
+		{
+			name: "ciliumEndpoint exists - ipv4",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+			},
+			endpointIP: "192.168.1.1",
+			want:       "10.1.1.1",
+		},
+		{
+			name: "ciliumEndpoint exists - ipv6",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-1"},
+			},
+			endpointIP: "2600:1f14::ffff",
+			want:       "2600:1f14::1",
+		},
+		{
+			name: "ciliumEndpoint didn't exist",
+			pod: k8s.PodInfo{
+				Key: types.NamespacedName{Namespace: "ns-1", Name: "pod-2"},
+			},
+			endpointIP: "192.168.1.2",
+			want:       "192.168.1.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := testclient.NewClientBuilder().WithScheme(runtime.NewScheme()).WithRuntimeObjects(ciliumEndpoint.DeepCopy()).Build()
+			s := NewCiliumPodIPSource(k8sClient)
+			got, err := s.ResolvePodIP(context.Background(), tt.pod, tt.endpointIP)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_multusPodIPSource_ResolvePodIP(t *testing.T) {
+	networkStatus := `[{"name":"cbr0","interface":"eth0","ips":["192.168.1.1"],"default":true},{"name":"ns-1/macvlan","interface":"net1","ips":["10.1.1.1"]},{"name":"ns-1/empty","interface":"net2"}]`
+	tests := []struct {
+		name       string
+		pod        k8s.PodInfo
+		endpointIP string
+		want       string
+		wantErr    error
+	}{
+		{
+			name: "target network selected",
+			pod: k8s.PodInfo{
+				NetworkAnnotations: map[string]string{
+					"k8s.v1.cni.cncf.io/network-status": networkStatus,
+					"elbv2.k8s.aws/target-network":      "ns-1/macvlan",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			want:       "10.1.1.1",
+		},
+		{
+			name: "target network not selected",
+			pod: k8s.PodInfo{
+				NetworkAnnotations: map[string]string{
+					"k8s.v1.cni.cncf.io/network-status": networkStatus,
+				},
+			},
+			endpointIP: "192.168.1.1",
+			want:       "192.168.1.1",
+		},
+		{
+			name: "target network not found",
+			pod: k8s.PodInfo{
+				NetworkAnnotations: map[string]string{
+					"k8s.v1.cni.cncf.io/network-status": networkStatus,
+					"elbv2.k8s.aws/target-network":      "ns-1/ipvlan",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			wantErr:    errors.New("network ns-1/ipvlan not found"),
+		},
+		{
+			name: "target network has no IPs",
+			pod: k8s.PodInfo{
+				NetworkAnnotations: map[string]string{
+					"k8s.v1.cni.cncf.io/network-status": networkStatus,
+					"elbv2.k8s.aws/target-network":      "ns-1/empty",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			wantErr:    errors.New("network ns-1/empty has no IPs"),
+		},
+		{
+			name: "network-status annotation didn't exist",
+			pod: k8s.PodInfo{
+				NetworkAnnotations: map[string]string{
+					"elbv2.k8s.aws/target-network": "ns-1/macvlan",
+				},
+			},
+			endpointIP: "192.168.1.1",
+			wantErr:    errors.New("annotation k8s.v1.cni.cncf.io/network-status not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMultusPodIPSource()
+			got, err := s.ResolvePodIP(context.Background(), tt.pod, tt.endpointIP)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_podIPSourceEndpointResolver_ResolvePodIPs(t *testing.T) {
+	networkStatus := `[{"name":"cbr0","interface":"eth0","ips":["192.168.1.1"],"default":true},{"name":"ns-1/macvlan","interface":"net1","ips":["10.1.1.1"]}]`
+	tests := []struct {
+		name    string
+		pod     *corev1.Pod
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "pod with target network selected",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "pod-1",
+					Annotations: map[string]string{
+						"k8s.v1.cni.cncf.io/network-status": networkStatus,
+						"elbv2.k8s.aws/target-network":      "ns-1/macvlan",
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: "192.168.1.1",
+				},
+			},
+			want: []string{"10.1.1.1"},
+		},
+		{
+			name: "pod without target network selected",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "pod-1",
+				},
+				Status: corev1.PodStatus{
+					PodIP:  "192.168.1.1",
+					PodIPs: []corev1.PodIP{{IP: "192.168.1.1"}, {IP: "2001:db8::1"}},
+				},
+			},
+			want: []string{"192.168.1.1", "2001:db8::1"},
+		},
+		{
+			name: "pod with unknown target network selected",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns-1",
+					Name:      "pod-1",
+					Annotations: map[string]string{
+						"k8s.v1.cni.cncf.io/network-status": networkStatus,
+						"elbv2.k8s.aws/target-network":      "ns-1/ipvlan",
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: "192.168.1.1",
+				},
+			},
+			wantErr: errors.New("failed to resolve IP for pod ns-1/pod-1: network ns-1/ipvlan not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewPodIPSourceEndpointResolver(NewDefaultEndpointResolver(nil, nil, nil), NewMultusPodIPSource())
+			got, err := r.ResolvePodIPs(context.Background(), tt.pod)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -3,10 +3,13 @@ package config
 import "github.com/spf13/pflag"
 
 const (
-	flagWAFEnabled    = "enable-waf"
-	flagWAFV2Enabled  = "enable-wafv2"
-	flagShieldEnabled = "enable-shield"
-	defaultEnabled    = true
+	flagWAFEnabled                 = "enable-waf"
+	flagWAFV2Enabled               = "enable-wafv2"
+	flagShieldEnabled              = "enable-shield"
+	flagCloudWatchDashboardEnabled = "enable-cloudwatch-dashboard"
+	flagRoute53RecordsEnabled      = "enable-route53-records"
+	flagRoute53AllowedHostedZones  = "route53-allowed-hosted-zone-ids"
+	defaultEnabled                 = true
 )
 
 // AddonsConfig contains configuration for the addon features
@@ -17,6 +20,12 @@ type AddonsConfig struct {
 	WAFV2Enabled bool
 	// Shield addon for ALB
 	ShieldEnabled bool
+	// CloudWatch dashboard addon for ALB
+	CloudWatchDashboardEnabled bool
+	// Route 53 records addon for ALB
+	Route53RecordsEnabled bool
+	// IDs of Route 53 hosted zones that Route 53 records addon is allowed to manage records in
+	Route53AllowedHostedZoneIDs []string
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -24,4 +33,8 @@ func (f *AddonsConfig) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&f.WAFEnabled, flagWAFEnabled, defaultEnabled, "Enable WAF addon for ALB")
 	fs.BoolVar(&f.WAFV2Enabled, flagWAFV2Enabled, defaultEnabled, "Enable WAF V2 addon for ALB")
 	fs.BoolVar(&f.ShieldEnabled, flagShieldEnabled, defaultEnabled, "Enable Shield addon for ALB")
+	fs.BoolVar(&f.CloudWatchDashboardEnabled, flagCloudWatchDashboardEnabled, false, "Enable CloudWatch dashboard addon for ALB")
+	fs.BoolVar(&f.Route53RecordsEnabled, flagRoute53RecordsEnabled, false, "Enable Route 53 records addon for ALB")
+	fs.StringSliceVar(&f.Route53AllowedHostedZoneIDs, flagRoute53AllowedHostedZones, nil,
+		"IDs of Route 53 hosted zones that Route 53 records addon is allowed to manage records in")
 }
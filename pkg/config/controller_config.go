@@ -1,11 +1,13 @@
 package config
 
 import (
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/inject"
@@ -23,25 +25,64 @@ const (
 	flagEnableBackendSG                              = "enable-backend-security-group"
 	flagBackendSecurityGroup                         = "backend-security-group"
 	flagEnableEndpointSlices                         = "enable-endpoint-slices"
+	flagEndpointSliceCustomManagers                  = "endpoint-slice-custom-managers"
+	flagEnableCustomEndpointSliceExternalIPs         = "enable-custom-endpoint-slice-external-ips"
 	flagDisableRestrictedSGRules                     = "disable-restricted-sg-rules"
+	flagEndpointResolver                             = "endpoint-resolver"
+	flagEnableAWSContextEndpoint                     = "enable-aws-context-endpoint"
+	flagEnableControllerVersionReportEndpoint        = "enable-controller-version-report-endpoint"
+	flagEnableAccessLogsBucketPolicy                 = "enable-access-logs-bucket-policy"
+	flagEnableLogLevelEndpoint                       = "enable-log-level-endpoint"
+	flagTargetRegistrationAuditS3Bucket              = "target-registration-audit-s3-bucket"
+	flagTargetRegistrationAuditS3Prefix              = "target-registration-audit-s3-prefix"
+	flagTargetRegistrationAuditHistorySize           = "target-registration-audit-history-size"
+	flagAdaptiveHealthCheckRolloutThreshold          = "adaptive-health-check-rollout-threshold"
+	flagTargetDrainTimeout                           = "target-drain-timeout"
+	flagTargetVerificationInterval                   = "target-verification-interval"
+	flagTargetHealthReportInterval                   = "target-health-report-interval"
+	flagListenerCertificateRemovalGracePeriod        = "listener-certificate-removal-grace-period"
 	defaultLogLevel                                  = "info"
 	defaultMaxConcurrentReconciles                   = 3
 	defaultMaxExponentialBackoffDelay                = time.Second * 1000
 	defaultSSLPolicy                                 = "ELBSecurityPolicy-2016-08"
 	defaultEnableBackendSG                           = true
 	defaultEnableEndpointSlices                      = false
+	defaultEnableCustomEndpointSliceExternalIPs      = false
 	defaultDisableRestrictedSGRules                  = false
+	defaultEndpointResolver                          = "endpoints"
+	defaultEnableAWSContextEndpoint                  = false
+	defaultEnableControllerVersionReportEndpoint     = false
+	defaultEnableAccessLogsBucketPolicy              = false
+	defaultEnableLogLevelEndpoint                    = false
+	defaultTargetRegistrationAuditS3Prefix           = "target-registration-audit"
+	defaultTargetRegistrationAuditHistorySize        = 20
+	defaultAdaptiveHealthCheckRolloutThreshold       = 0
+	defaultTargetDrainTimeout                        = 0
+	defaultTargetVerificationInterval                = 0
+	defaultTargetHealthReportInterval                = 0
+	defaultListenerCertificateRemovalGracePeriod     = 10 * time.Second
+
+	// generated names of ALBs and TargetGroups are limited to 32 characters, a 10 characters uuid included.
+	// limiting the characters other than placeholders keeps at least 7 characters for namespace and name.
+	maxIngressResourceNameTemplateLiteralLength = 15
 )
 
 var (
 	trackingTagKeys = sets.NewString(
 		"elbv2.k8s.aws/cluster",
+		"elbv2.k8s.aws/controller-version",
 		"elbv2.k8s.aws/resource",
 		"ingress.k8s.aws/stack",
 		"ingress.k8s.aws/resource",
 		"service.k8s.aws/stack",
 		"service.k8s.aws/resource",
 	)
+
+	ingressResourceNameTemplatePlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+	ingressResourceNameTemplateLiteralPattern     = regexp.MustCompile("^[a-zA-Z0-9-]*$")
+	ingressResourceNameTemplatePlaceholders       = sets.NewString("{namespace}", "{name}", "{hash}")
+	// profiles are embedded into annotation keys as alb.ingress.kubernetes.io/profile.<profile>.actions.<name>, thus cannot contain dots.
+	ingressProfilePattern = regexp.MustCompile("^[a-z0-9]([a-z0-9-]*[a-z0-9])?$")
 )
 
 // ControllerConfig contains the controller configuration
@@ -76,6 +117,16 @@ type ControllerConfig struct {
 	// Enable EndpointSlices for IP targets instead of Endpoints
 	EnableEndpointSlices bool
 
+	// EndpointSliceCustomManagers are the managed-by label values of custom EndpointSlices accepted as target sources for IP targets,
+	// besides the EndpointSlices managed by kube-controller-manager. custom EndpointSlices are ignored if it's empty.
+	EndpointSliceCustomManagers []string
+
+	// EnableCustomEndpointSliceExternalIPs specifies whether endpoints of custom EndpointSlices outside the VPC are registered as targets.
+	EnableCustomEndpointSliceExternalIPs bool
+
+	// EndpointResolver specifies the name of endpoint resolver that supplies pod IPs for IP targets
+	EndpointResolver string
+
 	// Max concurrent reconcile loops for Service objects
 	ServiceMaxConcurrentReconciles int
 	// Max concurrent reconcile loops for TargetGroupBinding objects
@@ -93,6 +144,48 @@ type ControllerConfig struct {
 	// DisableRestrictedSGRules specifies whether to use restricted security group rules
 	DisableRestrictedSGRules bool
 
+	// EnableAWSContextEndpoint specifies whether to serve the resolved AWS context on the metrics server
+	EnableAWSContextEndpoint bool
+
+	// EnableControllerVersionReportEndpoint specifies whether to serve the report of resources last reconciled by other controller versions on the metrics server
+	EnableControllerVersionReportEndpoint bool
+
+	// EnableAccessLogsBucketPolicy specifies whether to grant log delivery on access logs S3 buckets via bucket policy
+	EnableAccessLogsBucketPolicy bool
+
+	// EnableLogLevelEndpoint specifies whether to serve the endpoint to change log level at runtime on the metrics server
+	EnableLogLevelEndpoint bool
+
+	// TargetRegistrationAuditS3Bucket is the S3 bucket to persist audit trail of target registration batches into.
+	// target registration audit is disabled if it's empty.
+	TargetRegistrationAuditS3Bucket string
+
+	// TargetRegistrationAuditS3Prefix is the key prefix of target registration audit trail objects.
+	TargetRegistrationAuditS3Prefix string
+
+	// TargetRegistrationAuditHistorySize is the number of most recent target registration batches kept per TargetGroup.
+	TargetRegistrationAuditHistorySize int
+
+	// AdaptiveHealthCheckRolloutThreshold is the number of targets pending registration in a TargetGroup
+	// at which its health check is relaxed until the rollout completes. adaptive health check is disabled if it's zero.
+	AdaptiveHealthCheckRolloutThreshold int
+
+	// TargetDrainTimeout is the maximum duration that evictions of pods are blocked until their targets are drained from TargetGroups.
+	// blocking evictions is disabled if it's zero.
+	TargetDrainTimeout time.Duration
+
+	// TargetVerificationInterval is the maximum duration that reconciling targets of TargetGroupBindings with IP targets is skipped
+	// while their Service and Endpoints are unchanged. skipping is disabled if it's zero.
+	TargetVerificationInterval time.Duration
+
+	// TargetHealthReportInterval is the interval at which target health of TargetGroupBindings is reported as metrics and pod conditions.
+	// reporting is disabled if it's zero.
+	TargetHealthReportInterval time.Duration
+
+	// ListenerCertificateRemovalGracePeriod is the duration that certificates replaced on listeners are kept as SNI certificates
+	// after the default certificate is switched or new certificates are added, before they are removed.
+	ListenerCertificateRemovalGracePeriod time.Duration
+
 	FeatureGates FeatureGates
 }
 
@@ -119,8 +212,38 @@ func (cfg *ControllerConfig) BindFlags(fs *pflag.FlagSet) {
 		"Backend security group id to use for the ingress rules on the worker node SG")
 	fs.BoolVar(&cfg.EnableEndpointSlices, flagEnableEndpointSlices, defaultEnableEndpointSlices,
 		"Enable EndpointSlices for IP targets instead of Endpoints")
+	fs.StringSliceVar(&cfg.EndpointSliceCustomManagers, flagEndpointSliceCustomManagers, nil,
+		"Managed-by label values of custom EndpointSlices accepted as target sources for IP targets, custom EndpointSlices are ignored if empty")
+	fs.BoolVar(&cfg.EnableCustomEndpointSliceExternalIPs, flagEnableCustomEndpointSliceExternalIPs, defaultEnableCustomEndpointSliceExternalIPs,
+		"Register endpoints of custom EndpointSlices outside the VPC as targets, which are ignored otherwise")
+	fs.StringVar(&cfg.EndpointResolver, flagEndpointResolver, defaultEndpointResolver,
+		"Endpoint resolver that supplies pod IPs for IP targets - endpoints(default), calico, cilium, multus")
 	fs.BoolVar(&cfg.DisableRestrictedSGRules, flagDisableRestrictedSGRules, defaultDisableRestrictedSGRules,
 		"Disable the usage of restricted security group rules")
+	fs.BoolVar(&cfg.EnableAWSContextEndpoint, flagEnableAWSContextEndpoint, defaultEnableAWSContextEndpoint,
+		"Enable the read-only endpoint on the metrics server that publishes the resolved AWS context")
+	fs.BoolVar(&cfg.EnableControllerVersionReportEndpoint, flagEnableControllerVersionReportEndpoint, defaultEnableControllerVersionReportEndpoint,
+		"Enable the read-only endpoint on the metrics server that reports AWS resources last reconciled by other controller versions")
+	fs.BoolVar(&cfg.EnableAccessLogsBucketPolicy, flagEnableAccessLogsBucketPolicy, defaultEnableAccessLogsBucketPolicy,
+		"Enable granting log delivery on access logs S3 buckets via bucket policy")
+	fs.BoolVar(&cfg.EnableLogLevelEndpoint, flagEnableLogLevelEndpoint, defaultEnableLogLevelEndpoint,
+		"Enable the endpoint on the metrics server to view and change the log level at runtime")
+	fs.StringVar(&cfg.TargetRegistrationAuditS3Bucket, flagTargetRegistrationAuditS3Bucket, "",
+		"S3 bucket to persist audit trail of target registration batches into, disabled if empty")
+	fs.StringVar(&cfg.TargetRegistrationAuditS3Prefix, flagTargetRegistrationAuditS3Prefix, defaultTargetRegistrationAuditS3Prefix,
+		"Key prefix of target registration audit trail objects in S3 bucket")
+	fs.IntVar(&cfg.TargetRegistrationAuditHistorySize, flagTargetRegistrationAuditHistorySize, defaultTargetRegistrationAuditHistorySize,
+		"Number of most recent target registration batches kept per target group in audit trail")
+	fs.IntVar(&cfg.AdaptiveHealthCheckRolloutThreshold, flagAdaptiveHealthCheckRolloutThreshold, defaultAdaptiveHealthCheckRolloutThreshold,
+		"Number of targets pending registration in a target group at which its health check is relaxed until the rollout completes, disabled if zero")
+	fs.DurationVar(&cfg.TargetDrainTimeout, flagTargetDrainTimeout, defaultTargetDrainTimeout,
+		"Maximum duration that evictions of pods are blocked until their targets are drained from target groups, disabled if zero")
+	fs.DurationVar(&cfg.TargetVerificationInterval, flagTargetVerificationInterval, defaultTargetVerificationInterval,
+		"Maximum duration that reconciling targets is skipped while the Service and Endpoints of target group binding are unchanged, disabled if zero")
+	fs.DurationVar(&cfg.TargetHealthReportInterval, flagTargetHealthReportInterval, defaultTargetHealthReportInterval,
+		"Interval at which target health of target group bindings is reported as metrics and pod conditions, disabled if zero")
+	fs.DurationVar(&cfg.ListenerCertificateRemovalGracePeriod, flagListenerCertificateRemovalGracePeriod, defaultListenerCertificateRemovalGracePeriod,
+		"Duration that replaced certificates are kept on listeners after switching to new certificates, before they are removed")
 
 	cfg.FeatureGates.BindFlags(fs)
 	cfg.AWSConfig.BindFlags(fs)
@@ -150,6 +273,30 @@ func (cfg *ControllerConfig) Validate() error {
 	if err := cfg.validateBackendSecurityGroupConfiguration(); err != nil {
 		return err
 	}
+	if err := cfg.validateGCConfiguration(); err != nil {
+		return err
+	}
+	if err := cfg.validateTargetRegistrationAuditConfiguration(); err != nil {
+		return err
+	}
+	if err := cfg.validateIngressResourceNameTemplate(); err != nil {
+		return err
+	}
+	if err := cfg.validateIngressGroupClaimDuration(); err != nil {
+		return err
+	}
+	if err := cfg.validateIngressProfile(); err != nil {
+		return err
+	}
+	if err := cfg.validateIngressLabelSelector(); err != nil {
+		return err
+	}
+	if err := cfg.validateRoute53Configuration(); err != nil {
+		return err
+	}
+	if err := cfg.validateCustomEndpointSliceConfiguration(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -190,3 +337,107 @@ func (cfg *ControllerConfig) validateBackendSecurityGroupConfiguration() error {
 	}
 	return nil
 }
+
+func (cfg *ControllerConfig) validateTargetRegistrationAuditConfiguration() error {
+	if cfg.TargetRegistrationAuditS3Bucket == "" {
+		return nil
+	}
+	if cfg.TargetRegistrationAuditHistorySize <= 0 {
+		return errors.Errorf("%v flag must be positive, got %v", flagTargetRegistrationAuditHistorySize, cfg.TargetRegistrationAuditHistorySize)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateGCConfiguration() error {
+	if cfg.IngressConfig.GCInterval <= 0 {
+		return nil
+	}
+	// Ingresses outside watched namespaces are invisible to this controller, thus resources provisioned for them would be considered as orphaned.
+	if cfg.RuntimeConfig.IsNamespaceScoped() {
+		return errors.Errorf("%v flag cannot be specified together with %v or %v flag", flagGCInterval, flagWatchNamespace, flagWatchNamespaces)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateRoute53Configuration() error {
+	// records are only managed in explicitly allowed hosted zones, so that Ingress authors cannot take over records of arbitrary hosted zones.
+	if cfg.AddonsConfig.Route53RecordsEnabled && len(cfg.AddonsConfig.Route53AllowedHostedZoneIDs) == 0 {
+		return errors.Errorf("%v flag must be specified together with %v flag", flagRoute53AllowedHostedZones, flagRoute53RecordsEnabled)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateCustomEndpointSliceConfiguration() error {
+	if len(cfg.EndpointSliceCustomManagers) != 0 && !cfg.EnableEndpointSlices {
+		return errors.Errorf("%v flag requires %v flag", flagEndpointSliceCustomManagers, flagEnableEndpointSlices)
+	}
+	if cfg.EnableCustomEndpointSliceExternalIPs && len(cfg.EndpointSliceCustomManagers) == 0 {
+		return errors.Errorf("%v flag requires %v flag", flagEnableCustomEndpointSliceExternalIPs, flagEndpointSliceCustomManagers)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateIngressResourceNameTemplate() error {
+	template := cfg.IngressConfig.ResourceNameTemplate
+	placeholders := ingressResourceNameTemplatePlaceholderPattern.FindAllString(template, -1)
+	seenPlaceholders := sets.NewString()
+	for _, placeholder := range placeholders {
+		if !ingressResourceNameTemplatePlaceholders.Has(placeholder) {
+			return errors.Errorf("%v flag contains unknown placeholder %v, must be one of %v, got %q",
+				flagIngressResourceNameTemplate, placeholder, strings.Join(ingressResourceNameTemplatePlaceholders.List(), ", "), template)
+		}
+		if seenPlaceholders.Has(placeholder) {
+			return errors.Errorf("%v flag contains placeholder %v more than once, got %q", flagIngressResourceNameTemplate, placeholder, template)
+		}
+		seenPlaceholders.Insert(placeholder)
+	}
+	// the hash is computed from untruncated identities, which keeps names of different Ingresses and Services unique.
+	if !seenPlaceholders.Has("{hash}") {
+		return errors.Errorf("%v flag must contain placeholder {hash}, got %q", flagIngressResourceNameTemplate, template)
+	}
+	literal := ingressResourceNameTemplatePlaceholderPattern.ReplaceAllString(template, "")
+	if !ingressResourceNameTemplateLiteralPattern.MatchString(literal) || strings.HasPrefix(template, "-") || strings.HasSuffix(template, "-") {
+		return errors.Errorf("%v flag must only contain alphanumeric characters and hyphens besides placeholders, and cannot begin or end with a hyphen, got %q",
+			flagIngressResourceNameTemplate, template)
+	}
+	if len(literal) > maxIngressResourceNameTemplateLiteralLength {
+		return errors.Errorf("%v flag must have at most %v characters besides placeholders, got %q",
+			flagIngressResourceNameTemplate, maxIngressResourceNameTemplateLiteralLength, template)
+	}
+	// ALB names cannot begin with "internal-".
+	if strings.HasPrefix(template, "internal") {
+		return errors.Errorf("%v flag cannot begin with internal, got %q", flagIngressResourceNameTemplate, template)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateIngressProfile() error {
+	for _, profile := range cfg.IngressConfig.Profiles {
+		if !ingressProfilePattern.MatchString(profile) {
+			return errors.Errorf("%v flag must only contain lowercase alphanumeric characters and hyphens, and cannot begin or end with a hyphen, got %q",
+				flagIngressProfiles, profile)
+		}
+	}
+	profile := cfg.IngressConfig.Profile
+	if len(profile) != 0 && !sets.NewString(cfg.IngressConfig.Profiles...).Has(profile) {
+		return errors.Errorf("unknown profile %q in %v flag, must be one of %v flag: %v",
+			profile, flagIngressProfile, flagIngressProfiles, strings.Join(cfg.IngressConfig.Profiles, ","))
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateIngressGroupClaimDuration() error {
+	claimDuration := cfg.IngressConfig.GroupClaimDuration
+	// claims are Leases, whose durations are in seconds.
+	if claimDuration < 0 || (claimDuration > 0 && claimDuration%time.Second != 0) {
+		return errors.Errorf("%v flag must be zero or whole seconds, got %v", flagIngressGroupClaimDuration, claimDuration)
+	}
+	return nil
+}
+
+func (cfg *ControllerConfig) validateIngressLabelSelector() error {
+	if _, err := labels.Parse(cfg.IngressConfig.LabelSelector); err != nil {
+		return errors.Wrapf(err, "failed to parse %v flag", flagIngressLabelSelector)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestControllerConfig_validateDefaultTagsCollisionWithTrackingTags(t *testing.T) {
@@ -164,3 +165,427 @@ func TestControllerConfig_validateExternalManagedTagsCollisionWithDefaultTags(t
 		})
 	}
 }
+
+func TestControllerConfig_validateGCConfiguration(t *testing.T) {
+	type fields struct {
+		GCInterval      time.Duration
+		WatchNamespace  string
+		WatchNamespaces []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "gc disabled with watch namespace",
+			fields: fields{
+				GCInterval:     0,
+				WatchNamespace: "awesome-ns",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "gc enabled without watch namespace",
+			fields: fields{
+				GCInterval:     time.Hour,
+				WatchNamespace: "",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "gc enabled with watch namespace",
+			fields: fields{
+				GCInterval:     time.Hour,
+				WatchNamespace: "awesome-ns",
+			},
+			wantErr: errors.New("gc-interval flag cannot be specified together with watch-namespace or watch-namespaces flag"),
+		},
+		{
+			name: "gc enabled with watch namespaces",
+			fields: fields{
+				GCInterval:      time.Hour,
+				WatchNamespaces: []string{"awesome-ns", "another-ns"},
+			},
+			wantErr: errors.New("gc-interval flag cannot be specified together with watch-namespace or watch-namespaces flag"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				IngressConfig: IngressConfig{
+					GCInterval: tt.fields.GCInterval,
+				},
+				RuntimeConfig: RuntimeConfig{
+					WatchNamespace:  tt.fields.WatchNamespace,
+					WatchNamespaces: tt.fields.WatchNamespaces,
+				},
+			}
+			err := cfg.validateGCConfiguration()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateTargetRegistrationAuditConfiguration(t *testing.T) {
+	type fields struct {
+		TargetRegistrationAuditS3Bucket    string
+		TargetRegistrationAuditHistorySize int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "audit disabled",
+			fields: fields{
+				TargetRegistrationAuditS3Bucket:    "",
+				TargetRegistrationAuditHistorySize: 0,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "audit enabled with positive history size",
+			fields: fields{
+				TargetRegistrationAuditS3Bucket:    "my-bucket",
+				TargetRegistrationAuditHistorySize: 20,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "audit enabled with zero history size",
+			fields: fields{
+				TargetRegistrationAuditS3Bucket:    "my-bucket",
+				TargetRegistrationAuditHistorySize: 0,
+			},
+			wantErr: errors.New("target-registration-audit-history-size flag must be positive, got 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				TargetRegistrationAuditS3Bucket:    tt.fields.TargetRegistrationAuditS3Bucket,
+				TargetRegistrationAuditHistorySize: tt.fields.TargetRegistrationAuditHistorySize,
+			}
+			err := cfg.validateTargetRegistrationAuditConfiguration()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateIngressResourceNameTemplate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		resourceNameTemplate string
+		wantErr              error
+	}{
+		{
+			name:                 "default template",
+			resourceNameTemplate: "k8s-{namespace}-{name}-{hash}",
+			wantErr:              nil,
+		},
+		{
+			name:                 "template with longer prefix",
+			resourceNameTemplate: "prod-eks-{namespace}-{name}-{hash}",
+			wantErr:              nil,
+		},
+		{
+			name:                 "template with reordered placeholders",
+			resourceNameTemplate: "{name}-{namespace}-{hash}",
+			wantErr:              nil,
+		},
+		{
+			name:                 "template with hash only",
+			resourceNameTemplate: "prod-{hash}",
+			wantErr:              nil,
+		},
+		{
+			name:                 "empty template",
+			resourceNameTemplate: "",
+			wantErr:              errors.New("ingress-resource-name-template flag must contain placeholder {hash}, got \"\""),
+		},
+		{
+			name:                 "template without hash",
+			resourceNameTemplate: "k8s-{namespace}-{name}",
+			wantErr:              errors.New("ingress-resource-name-template flag must contain placeholder {hash}, got \"k8s-{namespace}-{name}\""),
+		},
+		{
+			name:                 "template with unknown placeholder",
+			resourceNameTemplate: "{cluster}-{namespace}-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag contains unknown placeholder {cluster}, must be one of {hash}, {namespace}, {name}, got \"{cluster}-{namespace}-{name}-{hash}\""),
+		},
+		{
+			name:                 "template with repeated placeholder",
+			resourceNameTemplate: "{name}-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag contains placeholder {name} more than once, got \"{name}-{name}-{hash}\""),
+		},
+		{
+			name:                 "template ending with hyphen",
+			resourceNameTemplate: "k8s-{namespace}-{name}-{hash}-",
+			wantErr:              errors.New("ingress-resource-name-template flag must only contain alphanumeric characters and hyphens besides placeholders, and cannot begin or end with a hyphen, got \"k8s-{namespace}-{name}-{hash}-\""),
+		},
+		{
+			name:                 "template with invalid character",
+			resourceNameTemplate: "prod_eks-{namespace}-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag must only contain alphanumeric characters and hyphens besides placeholders, and cannot begin or end with a hyphen, got \"prod_eks-{namespace}-{name}-{hash}\""),
+		},
+		{
+			name:                 "template with unclosed placeholder",
+			resourceNameTemplate: "k8s-{namespace-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag must only contain alphanumeric characters and hyphens besides placeholders, and cannot begin or end with a hyphen, got \"k8s-{namespace-{name}-{hash}\""),
+		},
+		{
+			name:                 "template too long",
+			resourceNameTemplate: "my-production-cluster-{namespace}-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag must have at most 15 characters besides placeholders, got \"my-production-cluster-{namespace}-{name}-{hash}\""),
+		},
+		{
+			name:                 "internal template",
+			resourceNameTemplate: "internal-{namespace}-{name}-{hash}",
+			wantErr:              errors.New("ingress-resource-name-template flag cannot begin with internal, got \"internal-{namespace}-{name}-{hash}\""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				IngressConfig: IngressConfig{
+					ResourceNameTemplate: tt.resourceNameTemplate,
+				},
+			}
+			err := cfg.validateIngressResourceNameTemplate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateIngressProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  string
+		profiles []string
+		wantErr  error
+	}{
+		{
+			name:     "no active profile",
+			profile:  "",
+			profiles: []string{"dev", "stage", "prod"},
+			wantErr:  nil,
+		},
+		{
+			name:     "known profile",
+			profile:  "prod",
+			profiles: []string{"dev", "stage", "prod"},
+			wantErr:  nil,
+		},
+		{
+			name:     "unknown profile",
+			profile:  "production",
+			profiles: []string{"dev", "stage", "prod"},
+			wantErr:  errors.New("unknown profile \"production\" in ingress-profile flag, must be one of ingress-profiles flag: dev,stage,prod"),
+		},
+		{
+			name:     "no known profiles",
+			profile:  "prod",
+			profiles: nil,
+			wantErr:  errors.New("unknown profile \"prod\" in ingress-profile flag, must be one of ingress-profiles flag: "),
+		},
+		{
+			name:     "known profile with dot",
+			profile:  "",
+			profiles: []string{"prod.eu"},
+			wantErr:  errors.New("ingress-profiles flag must only contain lowercase alphanumeric characters and hyphens, and cannot begin or end with a hyphen, got \"prod.eu\""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				IngressConfig: IngressConfig{
+					Profile:  tt.profile,
+					Profiles: tt.profiles,
+				},
+			}
+			err := cfg.validateIngressProfile()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateIngressGroupClaimDuration(t *testing.T) {
+	tests := []struct {
+		name          string
+		claimDuration time.Duration
+		wantErr       error
+	}{
+		{
+			name:          "claims disabled",
+			claimDuration: 0,
+		},
+		{
+			name:          "whole seconds",
+			claimDuration: 2 * time.Minute,
+		},
+		{
+			name:          "fractional seconds",
+			claimDuration: 1500 * time.Millisecond,
+			wantErr:       errors.New("ingress-group-claim-duration flag must be zero or whole seconds, got 1.5s"),
+		},
+		{
+			name:          "negative",
+			claimDuration: -time.Minute,
+			wantErr:       errors.New("ingress-group-claim-duration flag must be zero or whole seconds, got -1m0s"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				IngressConfig: IngressConfig{
+					GroupClaimDuration: tt.claimDuration,
+				},
+			}
+			err := cfg.validateIngressGroupClaimDuration()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateIngressLabelSelector(t *testing.T) {
+	tests := []struct {
+		name          string
+		labelSelector string
+		wantErr       error
+	}{
+		{
+			name:          "label selector disabled",
+			labelSelector: "",
+		},
+		{
+			name:          "valid label selector",
+			labelSelector: "team=payments,environment in (staging, production)",
+		},
+		{
+			name:          "invalid label selector",
+			labelSelector: "team in payments",
+			wantErr:       errors.New("failed to parse ingress-label-selector flag: unable to parse requirement: found 'payments' expected: '('"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				IngressConfig: IngressConfig{
+					LabelSelector: tt.labelSelector,
+				},
+			}
+			err := cfg.validateIngressLabelSelector()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateRoute53Configuration(t *testing.T) {
+	tests := []struct {
+		name         string
+		addonsConfig AddonsConfig
+		wantErr      error
+	}{
+		{
+			name: "route53 records disabled",
+		},
+		{
+			name: "route53 records enabled with allowed hosted zones",
+			addonsConfig: AddonsConfig{
+				Route53RecordsEnabled:       true,
+				Route53AllowedHostedZoneIDs: []string{"Z1D633PJN98FT9"},
+			},
+		},
+		{
+			name: "route53 records enabled without allowed hosted zones",
+			addonsConfig: AddonsConfig{
+				Route53RecordsEnabled: true,
+			},
+			wantErr: errors.New("route53-allowed-hosted-zone-ids flag must be specified together with enable-route53-records flag"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &ControllerConfig{
+				AddonsConfig: tt.addonsConfig,
+			}
+			err := cfg.validateRoute53Configuration()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerConfig_validateCustomEndpointSliceConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ControllerConfig
+		wantErr error
+	}{
+		{
+			name: "custom EndpointSlices disabled",
+		},
+		{
+			name: "custom EndpointSlices enabled with external IPs",
+			cfg: ControllerConfig{
+				EnableEndpointSlices:                 true,
+				EndpointSliceCustomManagers:          []string{"mirroring.example.com"},
+				EnableCustomEndpointSliceExternalIPs: true,
+			},
+		},
+		{
+			name: "custom EndpointSlices without EndpointSlices",
+			cfg: ControllerConfig{
+				EndpointSliceCustomManagers: []string{"mirroring.example.com"},
+			},
+			wantErr: errors.New("endpoint-slice-custom-managers flag requires enable-endpoint-slices flag"),
+		},
+		{
+			name: "external IPs without custom EndpointSlices",
+			cfg: ControllerConfig{
+				EnableEndpointSlices:                 true,
+				EnableCustomEndpointSliceExternalIPs: true,
+			},
+			wantErr: errors.New("enable-custom-endpoint-slice-external-ips flag requires endpoint-slice-custom-managers flag"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateCustomEndpointSliceConfiguration()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,16 +1,57 @@
 package config
 
-import "github.com/spf13/pflag"
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
 
 const (
 	flagIngressClass                         = "ingress-class"
 	flagDisableIngressClassAnnotation        = "disable-ingress-class-annotation"
 	flagDisableIngressGroupNameAnnotation    = "disable-ingress-group-name-annotation"
 	flagIngressMaxConcurrentReconciles       = "ingress-max-concurrent-reconciles"
+	flagIngressMaxExponentialBackoffDelay    = "ingress-max-exponential-backoff-delay"
+	flagStrictIngressAnnotations             = "strict-ingress-annotations"
+	flagIngressResyncPeriod                  = "ingress-resync-period"
+	flagEnableDriftDetection                 = "enable-drift-detection"
+	flagGCInterval                           = "gc-interval"
+	flagGCDryRun                             = "gc-dry-run"
+	flagIngressProfile                       = "ingress-profile"
+	flagIngressProfiles                      = "ingress-profiles"
+	flagEnableFargateTargetTypeFallback      = "enable-fargate-target-type-fallback"
+	flagEnableCompatibilityAnnotations       = "enable-compatibility-annotations"
+	flagIngressResourceNameTemplate          = "ingress-resource-name-template"
+	flagEnableLoadBalancerInventory          = "enable-load-balancer-inventory"
+	flagIngressGroupClaimDuration            = "ingress-group-claim-duration"
+	flagDefaultAnnotationsConfigMap          = "ingress-default-annotations-configmap"
+	flagEnableIngressMetricsDimensions       = "enable-ingress-metrics-dimensions"
+	flagIngressLabelSelector                 = "ingress-label-selector"
+	flagEnableIngressGroupAccessReview       = "enable-ingress-group-access-review"
 	defaultIngressClass                      = "alb"
 	defaultDisableIngressClassAnnotation     = false
 	defaultDisableIngressGroupNameAnnotation = false
 	defaultMaxIngressConcurrentReconciles    = 3
+	defaultIngressMaxExponentialBackoffDelay = time.Second * 1000
+	defaultStrictIngressAnnotations          = false
+	defaultIngressResyncPeriod               = 0
+	defaultEnableDriftDetection              = false
+	defaultGCInterval                        = 0
+	defaultGCDryRun                          = false
+	defaultIngressProfile                    = ""
+	defaultEnableFargateTargetTypeFallback   = false
+	defaultEnableCompatibilityAnnotations    = false
+	defaultIngressResourceNameTemplate       = "k8s-{namespace}-{name}-{hash}"
+	defaultEnableLoadBalancerInventory       = false
+	defaultIngressGroupClaimDuration         = 0
+	defaultDefaultAnnotationsConfigMap       = ""
+	defaultEnableIngressMetricsDimensions    = false
+	defaultIngressLabelSelector              = ""
+	defaultEnableIngressGroupAccessReview    = false
+)
+
+var (
+	defaultIngressProfiles = []string{"dev", "stage", "prod"}
 )
 
 // IngressConfig contains the configurations for the Ingress controller
@@ -27,6 +68,68 @@ type IngressConfig struct {
 
 	// Max concurrent reconcile loops for Ingress objects
 	MaxConcurrentReconciles int
+
+	// Max exponential backoff delay for reconcile failures of Ingress objects
+	MaxExponentialBackoffDelay time.Duration
+
+	// StrictIngressAnnotations specifies whether to reject Ingresses with unknown alb.ingress.kubernetes.io annotations.
+	StrictIngressAnnotations bool
+
+	// ResyncPeriod is the interval at which IngressGroups are periodically reconciled to detect and revert out-of-band changes.
+	// periodic resync is disabled if it's zero.
+	ResyncPeriod time.Duration
+
+	// EnableDriftDetection specifies whether to snapshot the deployed LoadBalancer of IngressGroups after each reconcile,
+	// and emit an event describing out-of-band changes found on the next reconcile.
+	EnableDriftDetection bool
+
+	// GCInterval is the interval at which AWS resources provisioned for Ingresses that no longer exist are garbage collected.
+	// garbage collection is disabled if it's zero.
+	GCInterval time.Duration
+
+	// GCDryRun specifies whether garbage collection only logs orphaned AWS resources instead of deleting them.
+	GCDryRun bool
+
+	// Profile is the active profile for profile scoped actions and conditions annotations.
+	// only unscoped actions and conditions annotations are used if it's empty.
+	Profile string
+
+	// Profiles are the known profiles, which the active profile must be one of.
+	Profiles []string
+
+	// EnableFargateTargetTypeFallback specifies whether to use ip targetType for backends whose pods all run on Fargate,
+	// when instance targetType is requested.
+	EnableFargateTargetTypeFallback bool
+
+	// EnableCompatibilityAnnotations specifies whether to translate common annotations of other Ingress controllers
+	// into their native equivalents when native annotations are absent.
+	EnableCompatibilityAnnotations bool
+
+	// ResourceNameTemplate is the template of generated names for ALBs and TargetGroups provisioned for Ingresses.
+	ResourceNameTemplate string
+
+	// EnableLoadBalancerInventory specifies whether to maintain a LoadBalancerInventory for each IngressGroup that describes its ALB.
+	EnableLoadBalancerInventory bool
+
+	// GroupClaimDuration is how long a controller instance claims an IngressGroup after each reconcile, other controller instances
+	// don't reconcile the IngressGroup until the claim is released or expired. claims are disabled if it's zero.
+	GroupClaimDuration time.Duration
+
+	// DefaultAnnotationsConfigMap is the name of ConfigMap in the controller namespace, which contains controller-wide default values
+	// of Ingress annotations keyed by annotation suffix. default annotations are disabled if it's empty.
+	DefaultAnnotationsConfigMap string
+
+	// EnableMetricsDimensions specifies whether to publish CloudWatch metrics dimensions of each Ingress path into a ConfigMap
+	// alongside the Ingress, and serve metric math expressions built from them on the metrics server.
+	EnableMetricsDimensions bool
+
+	// LabelSelector selects the Ingresses this controller manages, in addition to the IngressClass.
+	// all Ingresses of the IngressClass are managed if it's empty.
+	LabelSelector string
+
+	// EnableGroupAccessReview specifies whether the webhook authorizes users joining explicit IngressGroups or changing their order
+	// within IngressGroups via SubjectAccessReview.
+	EnableGroupAccessReview bool
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -39,4 +142,38 @@ func (cfg *IngressConfig) BindFlags(fs *pflag.FlagSet) {
 		"Disable new usage of alb.ingress.kubernetes.io/group.name annotation")
 	fs.IntVar(&cfg.MaxConcurrentReconciles, flagIngressMaxConcurrentReconciles, defaultMaxIngressConcurrentReconciles,
 		"Maximum number of concurrently running reconcile loops for ingress")
+	fs.DurationVar(&cfg.MaxExponentialBackoffDelay, flagIngressMaxExponentialBackoffDelay, defaultIngressMaxExponentialBackoffDelay,
+		"Maximum duration of exponential backoff for ingress reconcile failures")
+	fs.BoolVar(&cfg.StrictIngressAnnotations, flagStrictIngressAnnotations, defaultStrictIngressAnnotations,
+		"Reject Ingresses with unknown alb.ingress.kubernetes.io annotations")
+	fs.DurationVar(&cfg.ResyncPeriod, flagIngressResyncPeriod, defaultIngressResyncPeriod,
+		"Period at which IngressGroups are reconciled to detect and revert out-of-band changes to AWS resources, disabled if zero")
+	fs.BoolVar(&cfg.EnableDriftDetection, flagEnableDriftDetection, defaultEnableDriftDetection,
+		"Emit an event describing out-of-band changes to the ALB of IngressGroups detected on reconcile, which costs extra ELBv2 API calls per reconcile")
+	fs.DurationVar(&cfg.GCInterval, flagGCInterval, defaultGCInterval,
+		"Interval at which AWS resources provisioned for Ingresses that no longer exist are garbage collected, disabled if zero")
+	fs.BoolVar(&cfg.GCDryRun, flagGCDryRun, defaultGCDryRun,
+		"Only log orphaned AWS resources found by garbage collection instead of deleting them")
+	fs.StringVar(&cfg.Profile, flagIngressProfile, defaultIngressProfile,
+		"Active profile, actions and conditions annotations scoped to it via alb.ingress.kubernetes.io/profile.<profile> take precedence")
+	fs.StringSliceVar(&cfg.Profiles, flagIngressProfiles, defaultIngressProfiles,
+		"Known profiles, the active profile must be one of them")
+	fs.BoolVar(&cfg.EnableFargateTargetTypeFallback, flagEnableFargateTargetTypeFallback, defaultEnableFargateTargetTypeFallback,
+		"Use ip target type for Ingress backends whose pods all run on Fargate when instance target type is requested")
+	fs.BoolVar(&cfg.EnableCompatibilityAnnotations, flagEnableCompatibilityAnnotations, defaultEnableCompatibilityAnnotations,
+		"Translate common annotations of other Ingress controllers like ingress-nginx and traefik into native annotations")
+	fs.StringVar(&cfg.ResourceNameTemplate, flagIngressResourceNameTemplate, defaultIngressResourceNameTemplate,
+		"Template of generated names for ALBs and target groups provisioned for Ingresses, with placeholders {namespace}, {name} and {hash}")
+	fs.BoolVar(&cfg.EnableLoadBalancerInventory, flagEnableLoadBalancerInventory, defaultEnableLoadBalancerInventory,
+		"Maintain a cluster-scoped LoadBalancerInventory for each IngressGroup listing its ALB with its Ingresses, listeners, certificates and target groups")
+	fs.DurationVar(&cfg.GroupClaimDuration, flagIngressGroupClaimDuration, defaultIngressGroupClaimDuration,
+		"Duration a controller instance claims an IngressGroup for after each reconcile, so that controller instances never reconcile the same IngressGroup concurrently during upgrades, disabled if zero")
+	fs.StringVar(&cfg.DefaultAnnotationsConfigMap, flagDefaultAnnotationsConfigMap, defaultDefaultAnnotationsConfigMap,
+		"Name of ConfigMap in the controller namespace whose data supplies default values of alb.ingress.kubernetes.io annotations keyed by annotation suffix, which Ingresses can override, disabled if empty")
+	fs.BoolVar(&cfg.EnableMetricsDimensions, flagEnableIngressMetricsDimensions, defaultEnableIngressMetricsDimensions,
+		"Publish CloudWatch LoadBalancer and TargetGroup dimensions of each Ingress path into a ConfigMap alongside the Ingress, and serve metric math expressions for them on the metrics server")
+	fs.StringVar(&cfg.LabelSelector, flagIngressLabelSelector, defaultIngressLabelSelector,
+		"Label selector of Ingresses this controller manages in addition to the ingress class, all Ingresses of the ingress class are managed if empty")
+	fs.BoolVar(&cfg.EnableGroupAccessReview, flagEnableIngressGroupAccessReview, defaultEnableIngressGroupAccessReview,
+		"Authorize users joining explicit IngressGroups or changing the order of Ingresses within IngressGroups via SubjectAccessReview in the webhook")
 }
@@ -1,29 +1,39 @@
 package config
 
 import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"time"
 )
 
 const (
 	flagMetricsBindAddr         = "metrics-bind-addr"
 	flagHealthProbeBindAddr     = "health-probe-bind-addr"
+	flagPprofBindAddr           = "pprof-bind-addr"
 	flagWebhookBindPort         = "webhook-bind-port"
 	flagEnableLeaderElection    = "enable-leader-election"
 	flagLeaderElectionID        = "leader-election-id"
 	flagLeaderElectionNamespace = "leader-election-namespace"
 	flagWatchNamespace          = "watch-namespace"
+	flagWatchNamespaces         = "watch-namespaces"
 	flagSyncPeriod              = "sync-period"
+	flagSyncPeriodByKind        = "sync-period-by-kind"
 	flagKubeconfig              = "kubeconfig"
 	flagWebhookCertDir          = "webhook-cert-dir"
 	flagWebhookCertName         = "webhook-cert-file"
 	flagWebhookKeyName          = "webhook-key-file"
+	flagGracefulShutdownTimeout = "graceful-shutdown-timeout"
 
 	defaultKubeconfig              = ""
 	defaultLeaderElectionID        = "aws-load-balancer-controller-leader"
@@ -31,8 +41,10 @@ const (
 	defaultWatchNamespace          = corev1.NamespaceAll
 	defaultMetricsAddr             = ":8080"
 	defaultHealthProbeBindAddress  = ":61779"
+	defaultPprofBindAddress        = ""
 	defaultSyncPeriod              = 60 * time.Minute
 	defaultWebhookBindPort         = 9443
+	defaultGracefulShutdownTimeout = 30 * time.Second
 	// High enough QPS to fit all expected use cases. QPS=0 is not set here, because
 	// client code is overriding it.
 	defaultQPS = 1e6
@@ -42,6 +54,9 @@ const (
 	defaultWebhookCertDir  = ""
 	defaultWebhookCertName = ""
 	defaultWebhookKeyName  = ""
+
+	// inClusterNamespacePath is the path to the namespace of controller pod when running in cluster.
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 // RuntimeConfig stores the configuration for the controller-runtime
@@ -51,14 +66,18 @@ type RuntimeConfig struct {
 	WebhookBindPort         int
 	MetricsBindAddress      string
 	HealthProbeBindAddress  string
+	PprofBindAddress        string
 	EnableLeaderElection    bool
 	LeaderElectionID        string
 	LeaderElectionNamespace string
 	WatchNamespace          string
+	WatchNamespaces         []string
 	SyncPeriod              time.Duration
+	SyncPeriodByKind        map[string]string
 	WebhookCertDir          string
 	WebhookCertName         string
 	WebhookKeyName          string
+	GracefulShutdownTimeout time.Duration
 }
 
 // BindFlags binds the command line flags to the fields in the config object
@@ -69,6 +88,8 @@ func (c *RuntimeConfig) BindFlags(fs *pflag.FlagSet) {
 		"The address the metric endpoint binds to.")
 	fs.StringVar(&c.HealthProbeBindAddress, flagHealthProbeBindAddr, defaultHealthProbeBindAddress,
 		"The address the health probes binds to.")
+	fs.StringVar(&c.PprofBindAddress, flagPprofBindAddr, defaultPprofBindAddress,
+		"The address the pprof server binds to. pprof server is disabled if empty.")
 	fs.IntVar(&c.WebhookBindPort, flagWebhookBindPort, defaultWebhookBindPort,
 		"The TCP port the Webhook server binds to.")
 	fs.BoolVar(&c.EnableLeaderElection, flagEnableLeaderElection, true,
@@ -80,11 +101,18 @@ func (c *RuntimeConfig) BindFlags(fs *pflag.FlagSet) {
 		"Name of the leader election ID to use for this controller")
 	fs.StringVar(&c.WatchNamespace, flagWatchNamespace, defaultWatchNamespace,
 		"Namespace the controller watches for updates to Kubernetes objects, If empty, all namespaces are watched.")
+	fs.StringSliceVar(&c.WatchNamespaces, flagWatchNamespaces, nil,
+		"Comma-separated namespaces the controller watches for updates to Kubernetes objects, cannot be specified together with watch-namespace.")
 	fs.DurationVar(&c.SyncPeriod, flagSyncPeriod, defaultSyncPeriod,
 		"Period at which the controller forces the repopulation of its local object stores.")
+	fs.StringToStringVar(&c.SyncPeriodByKind, flagSyncPeriodByKind, nil,
+		"Period at which the controller forces the repopulation of its local object stores by object kind, overrides sync-period for specified kinds, "+
+			"format: kind1=period1,kind2=period2. Repopulation is disabled for a kind if its period is zero.")
 	fs.StringVar(&c.WebhookCertDir, flagWebhookCertDir, defaultWebhookCertDir, "WebhookCertDir is the directory that contains the webhook server key and certificate.")
 	fs.StringVar(&c.WebhookCertName, flagWebhookCertName, defaultWebhookCertName, "WebhookCertName is the webhook server certificate name.")
 	fs.StringVar(&c.WebhookKeyName, flagWebhookKeyName, defaultWebhookKeyName, "WebhookKeyName is the webhook server key name.")
+	fs.DurationVar(&c.GracefulShutdownTimeout, flagGracefulShutdownTimeout, defaultGracefulShutdownTimeout,
+		"Duration given to in-flight reconciles to complete upon shutdown, before they are cancelled and the controller exits.")
 
 }
 
@@ -108,8 +136,8 @@ func BuildRestConfig(rtCfg RuntimeConfig) (*rest.Config, error) {
 }
 
 // BuildRuntimeOptions builds the options for the controller runtime based on config
-func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) ctrl.Options {
-	return ctrl.Options{
+func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) (ctrl.Options, error) {
+	opts := ctrl.Options{
 		Scheme:                     scheme,
 		Port:                       rtCfg.WebhookBindPort,
 		CertDir:                    rtCfg.WebhookCertDir,
@@ -121,7 +149,55 @@ func BuildRuntimeOptions(rtCfg RuntimeConfig, scheme *runtime.Scheme) ctrl.Optio
 		LeaderElectionNamespace:    rtCfg.LeaderElectionNamespace,
 		Namespace:                  rtCfg.WatchNamespace,
 		SyncPeriod:                 &rtCfg.SyncPeriod,
+		GracefulShutdownTimeout:    &rtCfg.GracefulShutdownTimeout,
+	}
+	if len(rtCfg.WatchNamespaces) != 0 {
+		if rtCfg.WatchNamespace != corev1.NamespaceAll {
+			return ctrl.Options{}, errors.Errorf("%v flag cannot be specified together with %v flag", flagWatchNamespaces, flagWatchNamespace)
+		}
+		opts.NewCache = cache.MultiNamespacedCacheBuilder(rtCfg.WatchNamespaces)
+	}
+	if len(rtCfg.SyncPeriodByKind) != 0 {
+		syncPeriodByKind, err := parseSyncPeriodByKind(rtCfg.SyncPeriodByKind)
+		if err != nil {
+			return ctrl.Options{}, err
+		}
+		opts.NewCache = k8s.NewResyncPeriodCacheBuilder(syncPeriodByKind, opts.NewCache)
+	}
+	return opts, nil
+}
+
+// IsNamespaceScoped returns whether the controller only watches Kubernetes objects in some namespaces.
+func (c *RuntimeConfig) IsNamespaceScoped() bool {
+	return c.WatchNamespace != corev1.NamespaceAll || len(c.WatchNamespaces) != 0
+}
+
+// ResolveLeaderElectionNamespace returns the namespace for leader election, which defaults to the namespace of controller pod.
+func ResolveLeaderElectionNamespace(rtCfg RuntimeConfig) (string, error) {
+	if rtCfg.LeaderElectionNamespace != "" {
+		return rtCfg.LeaderElectionNamespace, nil
+	}
+	namespace, err := ioutil.ReadFile(inClusterNamespacePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read namespace of controller pod, %v flag must be specified when running out of cluster", flagLeaderElectionNamespace)
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
+
+// parseSyncPeriodByKind parses the sync period settings by object kind.
+func parseSyncPeriodByKind(rawSyncPeriodByKind map[string]string) (map[string]time.Duration, error) {
+	syncPeriodByKind := make(map[string]time.Duration, len(rawSyncPeriodByKind))
+	for kind, rawSyncPeriod := range rawSyncPeriodByKind {
+		syncPeriod, err := time.ParseDuration(rawSyncPeriod)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %v flag for kind %v", flagSyncPeriodByKind, kind)
+		}
+		if syncPeriod < 0 {
+			return nil, errors.Errorf("%v flag for kind %v cannot be negative, got %v", flagSyncPeriodByKind, kind, rawSyncPeriod)
+		}
+		syncPeriodByKind[kind] = syncPeriod
 	}
+	return syncPeriodByKind, nil
 }
 
 // ConfigureWebhookServer set up the server cert for the webhook server.
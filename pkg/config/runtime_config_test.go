@@ -0,0 +1,54 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_parseSyncPeriodByKind(t *testing.T) {
+	tests := []struct {
+		name                string
+		rawSyncPeriodByKind map[string]string
+		want                map[string]time.Duration
+		wantErr             error
+	}{
+		{
+			name: "valid sync periods",
+			rawSyncPeriodByKind: map[string]string{
+				"Ingress":   "10h",
+				"Endpoints": "0",
+			},
+			want: map[string]time.Duration{
+				"Ingress":   10 * time.Hour,
+				"Endpoints": 0,
+			},
+		},
+		{
+			name: "invalid sync period",
+			rawSyncPeriodByKind: map[string]string{
+				"Ingress": "daily",
+			},
+			wantErr: errors.New("failed to parse sync-period-by-kind flag for kind Ingress: time: invalid duration \"daily\""),
+		},
+		{
+			name: "negative sync period",
+			rawSyncPeriodByKind: map[string]string{
+				"Ingress": "-1h",
+			},
+			wantErr: errors.New("sync-period-by-kind flag for kind Ingress cannot be negative, got -1h"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSyncPeriodByKind(tt.rawSyncPeriodByKind)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cloudwatch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"strings"
+)
+
+const (
+	// NamespaceApplicationELB is the CloudWatch namespace of ALB metrics.
+	NamespaceApplicationELB = "AWS/ApplicationELB"
+
+	dashboardWidgetWidth  = 12
+	dashboardWidgetHeight = 6
+)
+
+// dashboardBody is the structure of CloudWatch dashboard body.
+// see https://docs.aws.amazon.com/AmazonCloudWatch/latest/APIReference/CloudWatch-Dashboard-Body-Structure.html
+type dashboardBody struct {
+	Widgets []dashboardWidget `json:"widgets"`
+}
+
+type dashboardWidget struct {
+	Type       string                    `json:"type"`
+	X          int                       `json:"x"`
+	Y          int                       `json:"y"`
+	Width      int                       `json:"width"`
+	Height     int                       `json:"height"`
+	Properties dashboardWidgetProperties `json:"properties"`
+}
+
+type dashboardWidgetProperties struct {
+	Title   string          `json:"title"`
+	Region  string          `json:"region"`
+	View    string          `json:"view"`
+	Stat    string          `json:"stat"`
+	Period  int             `json:"period"`
+	Metrics [][]interface{} `json:"metrics"`
+}
+
+// metricTemplate describes a metric widget in the built-in dashboard template.
+type metricTemplate struct {
+	title       string
+	stat        string
+	metricNames []string
+}
+
+var loadBalancerMetricTemplates = []metricTemplate{
+	{title: "Requests", stat: "Sum", metricNames: []string{"RequestCount"}},
+	{title: "HTTP errors", stat: "Sum", metricNames: []string{"HTTPCode_ELB_4XX_Count", "HTTPCode_ELB_5XX_Count", "HTTPCode_Target_4XX_Count", "HTTPCode_Target_5XX_Count"}},
+	{title: "Target response time", stat: "p99", metricNames: []string{"TargetResponseTime"}},
+	{title: "Connections", stat: "Sum", metricNames: []string{"ActiveConnectionCount", "NewConnectionCount", "RejectedConnectionCount"}},
+}
+
+var targetGroupMetricTemplates = []metricTemplate{
+	{title: "Healthy hosts", stat: "Minimum", metricNames: []string{"HealthyHostCount", "UnHealthyHostCount"}},
+	{title: "Requests per target", stat: "Sum", metricNames: []string{"RequestCountPerTarget"}},
+	{title: "Target response time", stat: "p99", metricNames: []string{"TargetResponseTime"}},
+	{title: "Target errors", stat: "Sum", metricNames: []string{"HTTPCode_Target_5XX_Count", "TargetConnectionErrorCount"}},
+}
+
+// buildDashboardNamePrefix computes the prefix of dashboard names for stack.
+// CloudWatch dashboards cannot be tagged, so the prefix identifies dashboards owned by the stack, which allows orphaned dashboards to be garbage collected.
+func buildDashboardNamePrefix(clusterName string, stackID core.StackID) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(clusterName))
+	_, _ = uuidHash.Write([]byte(stackID.String()))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+	return fmt.Sprintf("k8s-lbc-%.10s-", uuid)
+}
+
+// buildDashboardName computes the dashboard name for LoadBalancer, which is the LoadBalancer's name with namePrefix.
+func buildDashboardName(namePrefix string, lbARN string) (string, error) {
+	lbDimension, err := BuildLoadBalancerDimension(lbARN)
+	if err != nil {
+		return "", err
+	}
+	// lbDimension is in format of app/<name>/<id>
+	return namePrefix + strings.Split(lbDimension, "/")[1], nil
+}
+
+// buildDashboardBody renders the built-in dashboard template for LoadBalancer and its TargetGroups.
+func buildDashboardBody(region string, lbARN string, tgARNs []string) (string, error) {
+	lbDimension, err := BuildLoadBalancerDimension(lbARN)
+	if err != nil {
+		return "", err
+	}
+	var widgets []dashboardWidget
+	for _, tmpl := range loadBalancerMetricTemplates {
+		var metrics [][]interface{}
+		for _, metricName := range tmpl.metricNames {
+			metrics = append(metrics, []interface{}{NamespaceApplicationELB, metricName, "LoadBalancer", lbDimension})
+		}
+		widgets = append(widgets, buildMetricWidget(len(widgets), region, tmpl.title, tmpl.stat, metrics))
+	}
+	for _, tmpl := range targetGroupMetricTemplates {
+		var metrics [][]interface{}
+		for _, tgARN := range tgARNs {
+			tgDimension, err := BuildTargetGroupDimension(tgARN)
+			if err != nil {
+				return "", err
+			}
+			for _, metricName := range tmpl.metricNames {
+				metrics = append(metrics, []interface{}{NamespaceApplicationELB, metricName, "TargetGroup", tgDimension, "LoadBalancer", lbDimension})
+			}
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		widgets = append(widgets, buildMetricWidget(len(widgets), region, "TargetGroups - "+tmpl.title, tmpl.stat, metrics))
+	}
+	payload, err := json.Marshal(dashboardBody{Widgets: widgets})
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func buildMetricWidget(index int, region string, title string, stat string, metrics [][]interface{}) dashboardWidget {
+	return dashboardWidget{
+		Type:   "metric",
+		X:      (index % 2) * dashboardWidgetWidth,
+		Y:      (index / 2) * dashboardWidgetHeight,
+		Width:  dashboardWidgetWidth,
+		Height: dashboardWidgetHeight,
+		Properties: dashboardWidgetProperties{
+			Title:   title,
+			Region:  region,
+			View:    "timeSeries",
+			Stat:    stat,
+			Period:  60,
+			Metrics: metrics,
+		},
+	}
+}
+
+// BuildLoadBalancerDimension computes the LoadBalancer metric dimension, in format of app/<name>/<id>.
+func BuildLoadBalancerDimension(lbARN string) (string, error) {
+	parsedARN, err := arn.Parse(lbARN)
+	if err != nil || !strings.HasPrefix(parsedARN.Resource, "loadbalancer/") || len(strings.Split(parsedARN.Resource, "/")) != 4 {
+		return "", errors.Errorf("invalid LoadBalancer ARN: %v", lbARN)
+	}
+	return strings.TrimPrefix(parsedARN.Resource, "loadbalancer/"), nil
+}
+
+// BuildTargetGroupDimension computes the TargetGroup metric dimension, in format of targetgroup/<name>/<id>.
+func BuildTargetGroupDimension(tgARN string) (string, error) {
+	parsedARN, err := arn.Parse(tgARN)
+	if err != nil || !strings.HasPrefix(parsedARN.Resource, "targetgroup/") {
+		return "", errors.Errorf("invalid TargetGroup ARN: %v", tgARN)
+	}
+	return parsedARN.Resource, nil
+}
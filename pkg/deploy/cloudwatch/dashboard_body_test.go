@@ -0,0 +1,143 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"testing"
+)
+
+func Test_buildDashboardNamePrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusterName string
+		stackID     core.StackID
+		want        string
+	}{
+		{
+			name:        "explicit IngressGroup",
+			clusterName: "my-cluster",
+			stackID:     core.StackID{Name: "my-group"},
+			want:        "k8s-lbc-e7cd369ac5-",
+		},
+		{
+			name:        "implicit IngressGroup",
+			clusterName: "my-cluster",
+			stackID:     core.StackID{Namespace: "my-ns", Name: "my-group"},
+			want:        "k8s-lbc-150d79d949-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildDashboardNamePrefix(tt.clusterName, tt.stackID)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildDashboardName(t *testing.T) {
+	tests := []struct {
+		name       string
+		namePrefix string
+		lbARN      string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "application LoadBalancer",
+			namePrefix: "k8s-lbc-0123456789-",
+			lbARN:      "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-ns-ing-a1b2c3/50dc6c495c0c9188",
+			want:       "k8s-lbc-0123456789-k8s-ns-ing-a1b2c3",
+		},
+		{
+			name:       "invalid ARN",
+			namePrefix: "k8s-lbc-0123456789-",
+			lbARN:      "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+			wantErr:    errors.New("invalid LoadBalancer ARN: arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildDashboardName(tt.namePrefix, tt.lbARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_buildTargetGroupDimension(t *testing.T) {
+	tests := []struct {
+		name    string
+		tgARN   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "valid TargetGroup ARN",
+			tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+			want:  "targetgroup/my-tg/73e2d6bc24d8a067",
+		},
+		{
+			name:    "invalid ARN",
+			tgARN:   "my-tg",
+			wantErr: errors.New("invalid TargetGroup ARN: my-tg"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildTargetGroupDimension(tt.tgARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_buildDashboardBody(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	tests := []struct {
+		name        string
+		tgARNs      []string
+		wantWidgets int
+	}{
+		{
+			name:        "LoadBalancer without TargetGroups",
+			tgARNs:      nil,
+			wantWidgets: len(loadBalancerMetricTemplates),
+		},
+		{
+			name: "LoadBalancer with TargetGroups",
+			tgARNs: []string{
+				"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-a/73e2d6bc24d8a067",
+				"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-b/83e2d6bc24d8a067",
+			},
+			wantWidgets: len(loadBalancerMetricTemplates) + len(targetGroupMetricTemplates),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildDashboardBody("us-west-2", lbARN, tt.tgARNs)
+			assert.NoError(t, err)
+			body := dashboardBody{}
+			assert.NoError(t, json.Unmarshal([]byte(got), &body))
+			assert.Len(t, body.Widgets, tt.wantWidgets)
+			for _, widget := range body.Widgets {
+				assert.Equal(t, "us-west-2", widget.Properties.Region)
+				for _, metric := range widget.Properties.Metrics {
+					assert.Contains(t, metric, "app/my-lb/50dc6c495c0c9188")
+				}
+			}
+			// rendering must be stable so that unchanged dashboards won't be updated.
+			again, _ := buildDashboardBody("us-west-2", lbARN, tt.tgARNs)
+			assert.Equal(t, got, again)
+		})
+	}
+}
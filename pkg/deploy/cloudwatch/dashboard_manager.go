@@ -0,0 +1,138 @@
+package cloudwatch
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	cloudwatchsdk "github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const defaultDashboardBodyByNameCacheTTL = 10 * time.Minute
+
+// DashboardManager is responsible for manage CloudWatch dashboards.
+type DashboardManager interface {
+	// PutDashboard creates or updates dashboard with body.
+	PutDashboard(ctx context.Context, dashboardName string, dashboardBody string) error
+
+	// DeleteDashboard deletes dashboard.
+	DeleteDashboard(ctx context.Context, dashboardName string) error
+
+	// GetDashboardBody returns the body of dashboard, returns empty if dashboard doesn't exist.
+	GetDashboardBody(ctx context.Context, dashboardName string) (string, error)
+
+	// ListDashboardNames returns the names of dashboards with namePrefix.
+	ListDashboardNames(ctx context.Context, namePrefix string) ([]string, error)
+}
+
+// NewDefaultDashboardManager constructs new defaultDashboardManager.
+func NewDefaultDashboardManager(cloudWatchClient services.CloudWatch, logger logr.Logger) *defaultDashboardManager {
+	return &defaultDashboardManager{
+		cloudWatchClient:            cloudWatchClient,
+		logger:                      logger,
+		dashboardBodyByNameCache:    cache.NewExpiring(),
+		dashboardBodyByNameCacheTTL: defaultDashboardBodyByNameCacheTTL,
+	}
+}
+
+var _ DashboardManager = &defaultDashboardManager{}
+
+// default implementation for DashboardManager.
+type defaultDashboardManager struct {
+	cloudWatchClient services.CloudWatch
+	logger           logr.Logger
+
+	// cache that stores dashboardBody indexed by dashboardName
+	// The cache value is string, while "" represents no dashboard.
+	dashboardBodyByNameCache *cache.Expiring
+	// ttl for dashboardBodyByNameCache
+	dashboardBodyByNameCacheTTL time.Duration
+}
+
+func (m *defaultDashboardManager) PutDashboard(ctx context.Context, dashboardName string, dashboardBody string) error {
+	req := &cloudwatchsdk.PutDashboardInput{
+		DashboardName: awssdk.String(dashboardName),
+		DashboardBody: awssdk.String(dashboardBody),
+	}
+	m.logger.Info("putting CloudWatch dashboard",
+		"dashboardName", dashboardName)
+	resp, err := m.cloudWatchClient.PutDashboardWithContext(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(resp.DashboardValidationMessages) != 0 {
+		m.logger.Info("CloudWatch dashboard validation messages",
+			"dashboardName", dashboardName,
+			"messages", resp.DashboardValidationMessages)
+	}
+	m.logger.Info("put CloudWatch dashboard",
+		"dashboardName", dashboardName)
+	m.dashboardBodyByNameCache.Set(dashboardName, dashboardBody, m.dashboardBodyByNameCacheTTL)
+	return nil
+}
+
+func (m *defaultDashboardManager) DeleteDashboard(ctx context.Context, dashboardName string) error {
+	req := &cloudwatchsdk.DeleteDashboardsInput{
+		DashboardNames: awssdk.StringSlice([]string{dashboardName}),
+	}
+	m.logger.Info("deleting CloudWatch dashboard",
+		"dashboardName", dashboardName)
+	if _, err := m.cloudWatchClient.DeleteDashboardsWithContext(ctx, req); err != nil && !isDashboardNotFoundError(err) {
+		return err
+	}
+	m.logger.Info("deleted CloudWatch dashboard",
+		"dashboardName", dashboardName)
+	m.dashboardBodyByNameCache.Set(dashboardName, "", m.dashboardBodyByNameCacheTTL)
+	return nil
+}
+
+func (m *defaultDashboardManager) GetDashboardBody(ctx context.Context, dashboardName string) (string, error) {
+	rawCacheItem, exists := m.dashboardBodyByNameCache.Get(dashboardName)
+	if exists {
+		return rawCacheItem.(string), nil
+	}
+
+	req := &cloudwatchsdk.GetDashboardInput{
+		DashboardName: awssdk.String(dashboardName),
+	}
+	var dashboardBody string
+	resp, err := m.cloudWatchClient.GetDashboardWithContext(ctx, req)
+	if err != nil {
+		if !isDashboardNotFoundError(err) {
+			return "", err
+		}
+	} else {
+		dashboardBody = awssdk.StringValue(resp.DashboardBody)
+	}
+
+	m.dashboardBodyByNameCache.Set(dashboardName, dashboardBody, m.dashboardBodyByNameCacheTTL)
+	return dashboardBody, nil
+}
+
+func (m *defaultDashboardManager) ListDashboardNames(ctx context.Context, namePrefix string) ([]string, error) {
+	req := &cloudwatchsdk.ListDashboardsInput{
+		DashboardNamePrefix: awssdk.String(namePrefix),
+	}
+	var dashboardNames []string
+	if err := m.cloudWatchClient.ListDashboardsPagesWithContext(ctx, req, func(output *cloudwatchsdk.ListDashboardsOutput, _ bool) bool {
+		for _, entry := range output.DashboardEntries {
+			dashboardNames = append(dashboardNames, awssdk.StringValue(entry.DashboardName))
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return dashboardNames, nil
+}
+
+func isDashboardNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == cloudwatchsdk.ErrCodeDashboardNotFoundError
+	}
+	return false
+}
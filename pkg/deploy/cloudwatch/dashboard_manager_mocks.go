@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch (interfaces: DashboardManager)
+
+// Package cloudwatch is a generated GoMock package.
+package cloudwatch
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDashboardManager is a mock of DashboardManager interface.
+type MockDashboardManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardManagerMockRecorder
+}
+
+// MockDashboardManagerMockRecorder is the mock recorder for MockDashboardManager.
+type MockDashboardManagerMockRecorder struct {
+	mock *MockDashboardManager
+}
+
+// NewMockDashboardManager creates a new mock instance.
+func NewMockDashboardManager(ctrl *gomock.Controller) *MockDashboardManager {
+	mock := &MockDashboardManager{ctrl: ctrl}
+	mock.recorder = &MockDashboardManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardManager) EXPECT() *MockDashboardManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteDashboard mocks base method.
+func (m *MockDashboardManager) DeleteDashboard(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDashboard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDashboard indicates an expected call of DeleteDashboard.
+func (mr *MockDashboardManagerMockRecorder) DeleteDashboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboard", reflect.TypeOf((*MockDashboardManager)(nil).DeleteDashboard), arg0, arg1)
+}
+
+// GetDashboardBody mocks base method.
+func (m *MockDashboardManager) GetDashboardBody(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDashboardBody", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDashboardBody indicates an expected call of GetDashboardBody.
+func (mr *MockDashboardManagerMockRecorder) GetDashboardBody(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDashboardBody", reflect.TypeOf((*MockDashboardManager)(nil).GetDashboardBody), arg0, arg1)
+}
+
+// ListDashboardNames mocks base method.
+func (m *MockDashboardManager) ListDashboardNames(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDashboardNames", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDashboardNames indicates an expected call of ListDashboardNames.
+func (mr *MockDashboardManagerMockRecorder) ListDashboardNames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDashboardNames", reflect.TypeOf((*MockDashboardManager)(nil).ListDashboardNames), arg0, arg1)
+}
+
+// PutDashboard mocks base method.
+func (m *MockDashboardManager) PutDashboard(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutDashboard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutDashboard indicates an expected call of PutDashboard.
+func (mr *MockDashboardManagerMockRecorder) PutDashboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDashboard", reflect.TypeOf((*MockDashboardManager)(nil).PutDashboard), arg0, arg1, arg2)
+}
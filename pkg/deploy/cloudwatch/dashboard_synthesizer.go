@@ -0,0 +1,102 @@
+package cloudwatch
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	cloudwatchmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+)
+
+// NewDashboardSynthesizer constructs new dashboardSynthesizer
+func NewDashboardSynthesizer(dashboardManager DashboardManager, region string, clusterName string, logger logr.Logger, stack core.Stack) *dashboardSynthesizer {
+	return &dashboardSynthesizer{
+		dashboardManager: dashboardManager,
+		region:           region,
+		clusterName:      clusterName,
+		logger:           logger,
+		stack:            stack,
+	}
+}
+
+type dashboardSynthesizer struct {
+	dashboardManager DashboardManager
+	region           string
+	clusterName      string
+	logger           logr.Logger
+	stack            core.Stack
+}
+
+func (s *dashboardSynthesizer) Synthesize(ctx context.Context) error {
+	var resDashboards []*cloudwatchmodel.Dashboard
+	s.stack.ListResources(&resDashboards)
+	namePrefix := buildDashboardNamePrefix(s.clusterName, s.stack.StackID())
+	desiredBodyByName, err := s.buildDesiredDashboardBodyByName(ctx, namePrefix, resDashboards)
+	if err != nil {
+		return err
+	}
+
+	for dashboardName, desiredBody := range desiredBodyByName {
+		currentBody, err := s.dashboardManager.GetDashboardBody(ctx, dashboardName)
+		if err != nil {
+			return err
+		}
+		if desiredBody != currentBody {
+			if err := s.dashboardManager.PutDashboard(ctx, dashboardName, desiredBody); err != nil {
+				return errors.Wrap(err, "failed to put CloudWatch dashboard for LoadBalancer")
+			}
+		}
+	}
+
+	// dashboards of LoadBalancers that are deleted or replaced, or with the annotation turned off, are garbage collected.
+	currentDashboardNames, err := s.dashboardManager.ListDashboardNames(ctx, namePrefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to list CloudWatch dashboards")
+	}
+	for _, dashboardName := range currentDashboardNames {
+		if _, desired := desiredBodyByName[dashboardName]; desired {
+			continue
+		}
+		if err := s.dashboardManager.DeleteDashboard(ctx, dashboardName); err != nil {
+			return errors.Wrap(err, "failed to delete CloudWatch dashboard")
+		}
+	}
+	return nil
+}
+
+func (s *dashboardSynthesizer) PostSynthesize(ctx context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+func (s *dashboardSynthesizer) buildDesiredDashboardBodyByName(ctx context.Context, namePrefix string, resDashboards []*cloudwatchmodel.Dashboard) (map[string]string, error) {
+	desiredBodyByName := make(map[string]string, len(resDashboards))
+	for _, resDashboard := range resDashboards {
+		lbARN, err := resDashboard.Spec.LoadBalancerARN.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		dashboardName, err := buildDashboardName(namePrefix, lbARN)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := desiredBodyByName[dashboardName]; exists {
+			return nil, errors.Errorf("[should never happen] multiple CloudWatch dashboards desired on LoadBalancer: %v", lbARN)
+		}
+
+		var tgARNs []string
+		for _, tgARNToken := range resDashboard.Spec.TargetGroupARNs {
+			tgARN, err := tgARNToken.Resolve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			tgARNs = append(tgARNs, tgARN)
+		}
+		desiredBody, err := buildDashboardBody(s.region, lbARN, tgARNs)
+		if err != nil {
+			return nil, err
+		}
+		desiredBodyByName[dashboardName] = desiredBody
+	}
+	return desiredBodyByName, nil
+}
@@ -0,0 +1,117 @@
+package cloudwatch
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	cloudwatchmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_dashboardSynthesizer_Synthesize(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-ns-ing-a1b2c3/50dc6c495c0c9188"
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-ns-svc-d4e5f6/73e2d6bc24d8a067"
+	stackID := core.StackID{Namespace: "my-ns", Name: "my-ing"}
+	namePrefix := buildDashboardNamePrefix("my-cluster", stackID)
+	dashboardName := namePrefix + "k8s-ns-ing-a1b2c3"
+	desiredBody, err := buildDashboardBody("us-west-2", lbARN, []string{tgARN})
+	assert.NoError(t, err)
+
+	type getDashboardBodyCall struct {
+		dashboardName string
+		body          string
+	}
+	type listDashboardNamesCall struct {
+		dashboardNames []string
+		err            error
+	}
+	tests := []struct {
+		name                   string
+		dashboardDesired       bool
+		getDashboardBodyCalls  []getDashboardBodyCall
+		putDashboardNames      []string
+		listDashboardNamesCall listDashboardNamesCall
+		deleteDashboardNames   []string
+		wantErr                error
+	}{
+		{
+			name:             "dashboard is created",
+			dashboardDesired: true,
+			getDashboardBodyCalls: []getDashboardBodyCall{
+				{dashboardName: dashboardName, body: ""},
+			},
+			putDashboardNames:      []string{dashboardName},
+			listDashboardNamesCall: listDashboardNamesCall{dashboardNames: []string{dashboardName}},
+		},
+		{
+			name:             "dashboard is up to date",
+			dashboardDesired: true,
+			getDashboardBodyCalls: []getDashboardBodyCall{
+				{dashboardName: dashboardName, body: desiredBody},
+			},
+			listDashboardNamesCall: listDashboardNamesCall{dashboardNames: []string{dashboardName}},
+		},
+		{
+			name:             "dashboard of replaced LoadBalancer is garbage collected",
+			dashboardDesired: true,
+			getDashboardBodyCalls: []getDashboardBodyCall{
+				{dashboardName: dashboardName, body: desiredBody},
+			},
+			listDashboardNamesCall: listDashboardNamesCall{dashboardNames: []string{namePrefix + "k8s-ns-ing-0f9e8d", dashboardName}},
+			deleteDashboardNames:   []string{namePrefix + "k8s-ns-ing-0f9e8d"},
+		},
+		{
+			name:                   "dashboard of deleted LoadBalancer is garbage collected",
+			dashboardDesired:       false,
+			listDashboardNamesCall: listDashboardNamesCall{dashboardNames: []string{dashboardName}},
+			deleteDashboardNames:   []string{dashboardName},
+		},
+		{
+			name:                   "no dashboard",
+			dashboardDesired:       false,
+			listDashboardNamesCall: listDashboardNamesCall{},
+		},
+		{
+			name:                   "failed to list dashboards",
+			dashboardDesired:       false,
+			listDashboardNamesCall: listDashboardNamesCall{err: errors.New("AccessDenied")},
+			wantErr:                errors.New("failed to list CloudWatch dashboards: AccessDenied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dashboardManager := NewMockDashboardManager(ctrl)
+			for _, call := range tt.getDashboardBodyCalls {
+				dashboardManager.EXPECT().GetDashboardBody(gomock.Any(), call.dashboardName).Return(call.body, nil)
+			}
+			for _, name := range tt.putDashboardNames {
+				dashboardManager.EXPECT().PutDashboard(gomock.Any(), name, desiredBody).Return(nil)
+			}
+			dashboardManager.EXPECT().ListDashboardNames(gomock.Any(), namePrefix).Return(tt.listDashboardNamesCall.dashboardNames, tt.listDashboardNamesCall.err)
+			for _, name := range tt.deleteDashboardNames {
+				dashboardManager.EXPECT().DeleteDashboard(gomock.Any(), name).Return(nil)
+			}
+
+			stack := core.NewDefaultStack(stackID)
+			if tt.dashboardDesired {
+				cloudwatchmodel.NewDashboard(stack, "LoadBalancer", cloudwatchmodel.DashboardSpec{
+					LoadBalancerARN: core.LiteralStringToken(lbARN),
+					TargetGroupARNs: []core.StringToken{core.LiteralStringToken(tgARN)},
+				})
+			}
+			s := NewDashboardSynthesizer(dashboardManager, "us-west-2", "my-cluster", &log.NullLogger{}, stack)
+			err := s.Synthesize(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
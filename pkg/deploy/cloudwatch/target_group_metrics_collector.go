@@ -0,0 +1,148 @@
+package cloudwatch
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	cloudwatchsdk "github.com/aws/aws-sdk-go/service/cloudwatch"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"time"
+)
+
+const (
+	metricQueryIDRequestCount       = "requests"
+	metricQueryIDHTTPCodeTarget5XX  = "target5xx"
+	metricQueryIDUnHealthyHostCount = "unhealthy"
+)
+
+// TargetGroupMetrics contains the CloudWatch metrics of a TargetGroup over a period.
+type TargetGroupMetrics struct {
+	// RequestCount is the number of requests routed to the TargetGroup.
+	RequestCount float64
+	// HTTPCodeTarget5XXCount is the number of HTTP 5XX responses generated by targets of the TargetGroup.
+	HTTPCodeTarget5XXCount float64
+	// MaxUnHealthyHostCount is the maximum number of unhealthy targets of the TargetGroup.
+	MaxUnHealthyHostCount float64
+}
+
+// TargetGroupMetricsCollector is responsible for collecting CloudWatch metrics of TargetGroups.
+type TargetGroupMetricsCollector interface {
+	// Collect returns the metrics of TargetGroup over the period until now.
+	// the TargetGroup must be associated with an Application LoadBalancer.
+	Collect(ctx context.Context, tgARN string, period time.Duration) (TargetGroupMetrics, error)
+}
+
+// NewDefaultTargetGroupMetricsCollector constructs new defaultTargetGroupMetricsCollector.
+func NewDefaultTargetGroupMetricsCollector(elbv2Client services.ELBV2, cloudWatchClient services.CloudWatch) *defaultTargetGroupMetricsCollector {
+	return &defaultTargetGroupMetricsCollector{
+		elbv2Client:      elbv2Client,
+		cloudWatchClient: cloudWatchClient,
+		now:              time.Now,
+	}
+}
+
+var _ TargetGroupMetricsCollector = &defaultTargetGroupMetricsCollector{}
+
+// default implementation for TargetGroupMetricsCollector.
+type defaultTargetGroupMetricsCollector struct {
+	elbv2Client      services.ELBV2
+	cloudWatchClient services.CloudWatch
+	now              func() time.Time
+}
+
+func (c *defaultTargetGroupMetricsCollector) Collect(ctx context.Context, tgARN string, period time.Duration) (TargetGroupMetrics, error) {
+	tgDimension, err := BuildTargetGroupDimension(tgARN)
+	if err != nil {
+		return TargetGroupMetrics{}, err
+	}
+	lbARN, err := c.findLoadBalancerARN(ctx, tgARN)
+	if err != nil {
+		return TargetGroupMetrics{}, err
+	}
+	lbDimension, err := BuildLoadBalancerDimension(lbARN)
+	if err != nil {
+		return TargetGroupMetrics{}, err
+	}
+	dimensions := []*cloudwatchsdk.Dimension{
+		{Name: awssdk.String("TargetGroup"), Value: awssdk.String(tgDimension)},
+		{Name: awssdk.String("LoadBalancer"), Value: awssdk.String(lbDimension)},
+	}
+	periodSeconds := int64(period / time.Second)
+	endTime := c.now()
+	req := &cloudwatchsdk.GetMetricDataInput{
+		StartTime: awssdk.Time(endTime.Add(-period)),
+		EndTime:   awssdk.Time(endTime),
+		MetricDataQueries: []*cloudwatchsdk.MetricDataQuery{
+			buildMetricDataQuery(metricQueryIDRequestCount, "RequestCount", "Sum", dimensions, periodSeconds),
+			buildMetricDataQuery(metricQueryIDHTTPCodeTarget5XX, "HTTPCode_Target_5XX_Count", "Sum", dimensions, periodSeconds),
+			buildMetricDataQuery(metricQueryIDUnHealthyHostCount, "UnHealthyHostCount", "Maximum", dimensions, periodSeconds),
+		},
+	}
+	resp, err := c.cloudWatchClient.GetMetricDataWithContext(ctx, req)
+	if err != nil {
+		return TargetGroupMetrics{}, errors.Wrapf(err, "failed to get metrics of targetGroup: %v", tgARN)
+	}
+
+	var metrics TargetGroupMetrics
+	for _, result := range resp.MetricDataResults {
+		switch awssdk.StringValue(result.Id) {
+		case metricQueryIDRequestCount:
+			metrics.RequestCount = sumMetricValues(result.Values)
+		case metricQueryIDHTTPCodeTarget5XX:
+			metrics.HTTPCodeTarget5XXCount = sumMetricValues(result.Values)
+		case metricQueryIDUnHealthyHostCount:
+			metrics.MaxUnHealthyHostCount = maxMetricValue(result.Values)
+		}
+	}
+	return metrics, nil
+}
+
+// findLoadBalancerARN returns the ARN of LoadBalancer that TargetGroup is associated with.
+func (c *defaultTargetGroupMetricsCollector) findLoadBalancerARN(ctx context.Context, tgARN string) (string, error) {
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	}
+	tgs, err := c.elbv2Client.DescribeTargetGroupsAsList(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if len(tgs) == 0 || len(tgs[0].LoadBalancerArns) == 0 {
+		return "", errors.Errorf("targetGroup is not associated with any loadBalancer: %v", tgARN)
+	}
+	return awssdk.StringValue(tgs[0].LoadBalancerArns[0]), nil
+}
+
+func buildMetricDataQuery(id string, metricName string, stat string, dimensions []*cloudwatchsdk.Dimension, periodSeconds int64) *cloudwatchsdk.MetricDataQuery {
+	return &cloudwatchsdk.MetricDataQuery{
+		Id: awssdk.String(id),
+		MetricStat: &cloudwatchsdk.MetricStat{
+			Metric: &cloudwatchsdk.Metric{
+				Namespace:  awssdk.String(NamespaceApplicationELB),
+				MetricName: awssdk.String(metricName),
+				Dimensions: dimensions,
+			},
+			Period: awssdk.Int64(periodSeconds),
+			Stat:   awssdk.String(stat),
+		},
+		ReturnData: awssdk.Bool(true),
+	}
+}
+
+func sumMetricValues(values []*float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += awssdk.Float64Value(value)
+	}
+	return sum
+}
+
+func maxMetricValue(values []*float64) float64 {
+	var max float64
+	for _, value := range values {
+		if awssdk.Float64Value(value) > max {
+			max = awssdk.Float64Value(value)
+		}
+	}
+	return max
+}
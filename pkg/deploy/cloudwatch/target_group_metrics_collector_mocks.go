@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch (interfaces: TargetGroupMetricsCollector)
+
+// Package cloudwatch is a generated GoMock package.
+package cloudwatch
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTargetGroupMetricsCollector is a mock of TargetGroupMetricsCollector interface.
+type MockTargetGroupMetricsCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockTargetGroupMetricsCollectorMockRecorder
+}
+
+// MockTargetGroupMetricsCollectorMockRecorder is the mock recorder for MockTargetGroupMetricsCollector.
+type MockTargetGroupMetricsCollectorMockRecorder struct {
+	mock *MockTargetGroupMetricsCollector
+}
+
+// NewMockTargetGroupMetricsCollector creates a new mock instance.
+func NewMockTargetGroupMetricsCollector(ctrl *gomock.Controller) *MockTargetGroupMetricsCollector {
+	mock := &MockTargetGroupMetricsCollector{ctrl: ctrl}
+	mock.recorder = &MockTargetGroupMetricsCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTargetGroupMetricsCollector) EXPECT() *MockTargetGroupMetricsCollectorMockRecorder {
+	return m.recorder
+}
+
+// Collect mocks base method.
+func (m *MockTargetGroupMetricsCollector) Collect(arg0 context.Context, arg1 string, arg2 time.Duration) (TargetGroupMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collect", arg0, arg1, arg2)
+	ret0, _ := ret[0].(TargetGroupMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collect indicates an expected call of Collect.
+func (mr *MockTargetGroupMetricsCollectorMockRecorder) Collect(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockTargetGroupMetricsCollector)(nil).Collect), arg0, arg1, arg2)
+}
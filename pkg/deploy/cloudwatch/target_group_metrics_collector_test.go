@@ -0,0 +1,151 @@
+package cloudwatch
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	cloudwatchsdk "github.com/aws/aws-sdk-go/service/cloudwatch"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"testing"
+	"time"
+)
+
+func Test_defaultTargetGroupMetricsCollector_Collect(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	dimensions := []*cloudwatchsdk.Dimension{
+		{Name: awssdk.String("TargetGroup"), Value: awssdk.String("targetgroup/my-tg/73e2d6bc24d8a067")},
+		{Name: awssdk.String("LoadBalancer"), Value: awssdk.String("app/my-lb/50dc6c495c0c9188")},
+	}
+	type describeTargetGroupsAsListCall struct {
+		resp []*elbv2sdk.TargetGroup
+		err  error
+	}
+	type getMetricDataCall struct {
+		resp *cloudwatchsdk.GetMetricDataOutput
+		err  error
+	}
+	tests := []struct {
+		name                           string
+		describeTargetGroupsAsListCall describeTargetGroupsAsListCall
+		getMetricDataCalls             []getMetricDataCall
+		want                           TargetGroupMetrics
+		wantErr                        error
+	}{
+		{
+			name: "metrics collected",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn:   awssdk.String(tgARN),
+						LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+					},
+				},
+			},
+			getMetricDataCalls: []getMetricDataCall{
+				{
+					resp: &cloudwatchsdk.GetMetricDataOutput{
+						MetricDataResults: []*cloudwatchsdk.MetricDataResult{
+							{Id: awssdk.String("requests"), Values: awssdk.Float64Slice([]float64{100, 200})},
+							{Id: awssdk.String("target5xx"), Values: awssdk.Float64Slice([]float64{5, 10})},
+							{Id: awssdk.String("unhealthy"), Values: awssdk.Float64Slice([]float64{1, 3, 2})},
+						},
+					},
+				},
+			},
+			want: TargetGroupMetrics{
+				RequestCount:           300,
+				HTTPCodeTarget5XXCount: 15,
+				MaxUnHealthyHostCount:  3,
+			},
+		},
+		{
+			name: "no datapoints",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn:   awssdk.String(tgARN),
+						LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+					},
+				},
+			},
+			getMetricDataCalls: []getMetricDataCall{
+				{
+					resp: &cloudwatchsdk.GetMetricDataOutput{
+						MetricDataResults: []*cloudwatchsdk.MetricDataResult{
+							{Id: awssdk.String("requests")},
+							{Id: awssdk.String("target5xx")},
+							{Id: awssdk.String("unhealthy")},
+						},
+					},
+				},
+			},
+			want: TargetGroupMetrics{},
+		},
+		{
+			name: "targetGroup not associated with loadBalancer",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn: awssdk.String(tgARN),
+					},
+				},
+			},
+			wantErr: errors.New("targetGroup is not associated with any loadBalancer: arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"),
+		},
+		{
+			name: "failed to get metrics",
+			describeTargetGroupsAsListCall: describeTargetGroupsAsListCall{
+				resp: []*elbv2sdk.TargetGroup{
+					{
+						TargetGroupArn:   awssdk.String(tgARN),
+						LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+					},
+				},
+			},
+			getMetricDataCalls: []getMetricDataCall{
+				{
+					err: errors.New("some error"),
+				},
+			},
+			wantErr: errors.New("failed to get metrics of targetGroup: arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067: some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeTargetGroupsAsList(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{
+				TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+			}).Return(tt.describeTargetGroupsAsListCall.resp, tt.describeTargetGroupsAsListCall.err)
+			cloudWatchClient := services.NewMockCloudWatch(ctrl)
+			for _, call := range tt.getMetricDataCalls {
+				cloudWatchClient.EXPECT().GetMetricDataWithContext(gomock.Any(), &cloudwatchsdk.GetMetricDataInput{
+					StartTime: awssdk.Time(now.Add(-5 * time.Minute)),
+					EndTime:   awssdk.Time(now),
+					MetricDataQueries: []*cloudwatchsdk.MetricDataQuery{
+						buildMetricDataQuery("requests", "RequestCount", "Sum", dimensions, 300),
+						buildMetricDataQuery("target5xx", "HTTPCode_Target_5XX_Count", "Sum", dimensions, 300),
+						buildMetricDataQuery("unhealthy", "UnHealthyHostCount", "Maximum", dimensions, 300),
+					},
+				}).Return(call.resp, call.err)
+			}
+
+			c := NewDefaultTargetGroupMetricsCollector(elbv2Client, cloudWatchClient)
+			c.now = func() time.Time { return now }
+			got, err := c.Collect(context.Background(), tgARN, 5*time.Minute)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
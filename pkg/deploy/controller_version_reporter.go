@@ -0,0 +1,177 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
+)
+
+// ControllerVersionReportHandlerPath is the path to serve the controller version report on the metrics server.
+const ControllerVersionReportHandlerPath = "/controller-version-report"
+
+const (
+	managedResourceKindLoadBalancer  = "LoadBalancer"
+	managedResourceKindTargetGroup   = "TargetGroup"
+	managedResourceKindSecurityGroup = "SecurityGroup"
+)
+
+// ControllerVersionReport reports AWS resources last reconciled by controller versions other than the running one.
+type ControllerVersionReport struct {
+	// ControllerVersion is the version of the running controller.
+	ControllerVersion string `json:"controllerVersion"`
+	// Resources last reconciled by other controller versions.
+	Resources []ManagedResourceVersion `json:"resources"`
+}
+
+// ManagedResourceVersion is the controller version that last reconciled an AWS resource.
+type ManagedResourceVersion struct {
+	// Kind of the AWS resource, one of LoadBalancer, TargetGroup or SecurityGroup.
+	Kind string `json:"kind"`
+	// ID of the AWS resource, which is the ARN for ELBV2 resources.
+	ID string `json:"id"`
+	// StackID of the Kubernetes resource the AWS resource is provisioned for.
+	StackID string `json:"stackID,omitempty"`
+	// ControllerVersion that last reconciled the AWS resource, empty if it's reconciled before versions are stamped.
+	ControllerVersion string `json:"controllerVersion,omitempty"`
+}
+
+// ControllerVersionReporter reports the controller versions that last reconciled AWS resources.
+type ControllerVersionReporter interface {
+	// Report returns AWS resources tagged with this cluster which are last reconciled by other controller versions.
+	Report(ctx context.Context) (ControllerVersionReport, error)
+}
+
+// NewDefaultControllerVersionReporter constructs new defaultControllerVersionReporter.
+// tagPrefixes are the tag prefixes of controllers whose stackIDs are reported.
+func NewDefaultControllerVersionReporter(cloud aws.Cloud, networkingSGManager networking.SecurityGroupManager,
+	config config.ControllerConfig, tagPrefixes []string, logger logr.Logger) *defaultControllerVersionReporter {
+	// cluster tags and controller version tag don't depend on tag prefix.
+	trackingProvider := tracking.NewDefaultProvider("", config.ClusterName)
+	stackIDTagKeys := make([]string, 0, len(tagPrefixes))
+	for _, tagPrefix := range tagPrefixes {
+		stackIDTagKeys = append(stackIDTagKeys, tracking.NewDefaultProvider(tagPrefix, config.ClusterName).StackIDTagKey())
+	}
+	return &defaultControllerVersionReporter{
+		clusterTags:             trackingProvider.ClusterTags(),
+		controllerVersionTagKey: trackingProvider.ControllerVersionTagKey(),
+		stackIDTagKeys:          stackIDTagKeys,
+		ec2TaggingManager:       ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger),
+		elbv2TaggingManager:     elbv2.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), config.FeatureGates, logger),
+		controllerVersion:       version.GitVersion,
+	}
+}
+
+var _ ControllerVersionReporter = &defaultControllerVersionReporter{}
+
+// defaultControllerVersionReporter is the default implementation for ControllerVersionReporter
+type defaultControllerVersionReporter struct {
+	clusterTags             map[string]string
+	controllerVersionTagKey string
+	stackIDTagKeys          []string
+	ec2TaggingManager       ec2.TaggingManager
+	elbv2TaggingManager     elbv2.TaggingManager
+	controllerVersion       string
+}
+
+func (r *defaultControllerVersionReporter) Report(ctx context.Context) (ControllerVersionReport, error) {
+	tagFilter := tracking.TagsAsTagFilter(r.clusterTags)
+	sdkLBs, err := r.elbv2TaggingManager.ListLoadBalancers(ctx, tagFilter)
+	if err != nil {
+		return ControllerVersionReport{}, err
+	}
+	sdkTGs, err := r.elbv2TaggingManager.ListTargetGroups(ctx, tagFilter)
+	if err != nil {
+		return ControllerVersionReport{}, err
+	}
+	sdkSGs, err := r.ec2TaggingManager.ListSecurityGroups(ctx, tagFilter)
+	if err != nil {
+		return ControllerVersionReport{}, err
+	}
+
+	var resources []ManagedResourceVersion
+	for _, sdkLB := range sdkLBs {
+		resources = append(resources, r.buildManagedResourceVersion(managedResourceKindLoadBalancer,
+			awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn), sdkLB.Tags))
+	}
+	for _, sdkTG := range sdkTGs {
+		resources = append(resources, r.buildManagedResourceVersion(managedResourceKindTargetGroup,
+			awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn), sdkTG.Tags))
+	}
+	for _, sdkSG := range sdkSGs {
+		resources = append(resources, r.buildManagedResourceVersion(managedResourceKindSecurityGroup,
+			sdkSG.SecurityGroupID, sdkSG.Tags))
+	}
+	return ControllerVersionReport{
+		ControllerVersion: r.controllerVersion,
+		Resources:         findOutdatedResources(r.controllerVersion, resources),
+	}, nil
+}
+
+func (r *defaultControllerVersionReporter) buildManagedResourceVersion(kind string, id string, tags map[string]string) ManagedResourceVersion {
+	resource := ManagedResourceVersion{
+		Kind:              kind,
+		ID:                id,
+		ControllerVersion: tags[r.controllerVersionTagKey],
+	}
+	for _, stackIDTagKey := range r.stackIDTagKeys {
+		if stackID, ok := tags[stackIDTagKey]; ok {
+			resource.StackID = stackID
+			break
+		}
+	}
+	return resource
+}
+
+// findOutdatedResources finds resources last reconciled by controller versions other than controllerVersion.
+// the returned resources are sorted by kind and ID.
+func findOutdatedResources(controllerVersion string, resources []ManagedResourceVersion) []ManagedResourceVersion {
+	outdated := make([]ManagedResourceVersion, 0, len(resources))
+	for _, resource := range resources {
+		if resource.ControllerVersion != controllerVersion {
+			outdated = append(outdated, resource)
+		}
+	}
+	sort.Slice(outdated, func(i, j int) bool {
+		if outdated[i].Kind != outdated[j].Kind {
+			return outdated[i].Kind < outdated[j].Kind
+		}
+		return outdated[i].ID < outdated[j].ID
+	})
+	return outdated
+}
+
+// NewControllerVersionReportHandler constructs a read-only http.Handler that serves the report from reporter in JSON.
+func NewControllerVersionReportHandler(reporter ControllerVersionReporter, logger logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := reporter.Report(req.Context())
+		if err != nil {
+			logger.Error(err, "failed to build controller version report")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		payload, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			logger.Error(err, "failed to encode controller version report")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(payload)
+	})
+}
@@ -0,0 +1,191 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultControllerVersionReporter_buildManagedResourceVersion(t *testing.T) {
+	reporter := &defaultControllerVersionReporter{
+		controllerVersionTagKey: "elbv2.k8s.aws/controller-version",
+		stackIDTagKeys:          []string{"ingress.k8s.aws/stack", "service.k8s.aws/stack"},
+	}
+	type args struct {
+		kind string
+		id   string
+		tags map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want ManagedResourceVersion
+	}{
+		{
+			name: "resource provisioned for Ingress",
+			args: args{
+				kind: "LoadBalancer",
+				id:   "lb-1",
+				tags: map[string]string{
+					"elbv2.k8s.aws/cluster":            "cluster-name",
+					"elbv2.k8s.aws/controller-version": "v2.2.0",
+					"ingress.k8s.aws/stack":            "awesome-ns/ing-1",
+				},
+			},
+			want: ManagedResourceVersion{
+				Kind:              "LoadBalancer",
+				ID:                "lb-1",
+				StackID:           "awesome-ns/ing-1",
+				ControllerVersion: "v2.2.0",
+			},
+		},
+		{
+			name: "resource provisioned for Service",
+			args: args{
+				kind: "TargetGroup",
+				id:   "tg-1",
+				tags: map[string]string{
+					"elbv2.k8s.aws/cluster":            "cluster-name",
+					"elbv2.k8s.aws/controller-version": "v2.3.0",
+					"service.k8s.aws/stack":            "awesome-ns/svc-1",
+				},
+			},
+			want: ManagedResourceVersion{
+				Kind:              "TargetGroup",
+				ID:                "tg-1",
+				StackID:           "awesome-ns/svc-1",
+				ControllerVersion: "v2.3.0",
+			},
+		},
+		{
+			name: "resource without controller version",
+			args: args{
+				kind: "SecurityGroup",
+				id:   "sg-1",
+				tags: map[string]string{
+					"elbv2.k8s.aws/cluster": "cluster-name",
+				},
+			},
+			want: ManagedResourceVersion{
+				Kind: "SecurityGroup",
+				ID:   "sg-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reporter.buildManagedResourceVersion(tt.args.kind, tt.args.id, tt.args.tags)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_findOutdatedResources(t *testing.T) {
+	currentLB := ManagedResourceVersion{Kind: "LoadBalancer", ID: "lb-1", ControllerVersion: "v2.3.0"}
+	outdatedLB := ManagedResourceVersion{Kind: "LoadBalancer", ID: "lb-2", ControllerVersion: "v2.2.0"}
+	unstampedTG := ManagedResourceVersion{Kind: "TargetGroup", ID: "tg-1"}
+	outdatedSG := ManagedResourceVersion{Kind: "SecurityGroup", ID: "sg-1", ControllerVersion: "v2.2.0"}
+
+	type args struct {
+		controllerVersion string
+		resources         []ManagedResourceVersion
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ManagedResourceVersion
+	}{
+		{
+			name: "no resources",
+			args: args{
+				controllerVersion: "v2.3.0",
+				resources:         nil,
+			},
+			want: []ManagedResourceVersion{},
+		},
+		{
+			name: "all resources are current",
+			args: args{
+				controllerVersion: "v2.3.0",
+				resources:         []ManagedResourceVersion{currentLB},
+			},
+			want: []ManagedResourceVersion{},
+		},
+		{
+			name: "outdated and unstamped resources",
+			args: args{
+				controllerVersion: "v2.3.0",
+				resources:         []ManagedResourceVersion{unstampedTG, outdatedLB, currentLB, outdatedSG},
+			},
+			want: []ManagedResourceVersion{outdatedLB, outdatedSG, unstampedTG},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findOutdatedResources(tt.args.controllerVersion, tt.args.resources)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type staticControllerVersionReporter struct {
+	report ControllerVersionReport
+}
+
+func (r *staticControllerVersionReporter) Report(_ context.Context) (ControllerVersionReport, error) {
+	return r.report, nil
+}
+
+func TestNewControllerVersionReportHandler(t *testing.T) {
+	reporter := &staticControllerVersionReporter{
+		report: ControllerVersionReport{
+			ControllerVersion: "v2.3.0",
+			Resources: []ManagedResourceVersion{
+				{Kind: "LoadBalancer", ID: "lb-1", StackID: "awesome-ns/ing-1", ControllerVersion: "v2.2.0"},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		method     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "GET request",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody: `{
+  "controllerVersion": "v2.3.0",
+  "resources": [
+    {
+      "kind": "LoadBalancer",
+      "id": "lb-1",
+      "stackID": "awesome-ns/ing-1",
+      "controllerVersion": "v2.2.0"
+    }
+  ]
+}`,
+		},
+		{
+			name:       "PUT request",
+			method:     http.MethodPut,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "method not allowed\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewControllerVersionReportHandler(reporter, &log.NullLogger{})
+			req := httptest.NewRequest(tt.method, ControllerVersionReportHandlerPath, nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantBody, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package elbv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	LBAttrsAccessLogsS3Enabled = "access_logs.s3.enabled"
+	LBAttrsAccessLogsS3Bucket  = "access_logs.s3.bucket"
+	LBAttrsAccessLogsS3Prefix  = "access_logs.s3.prefix"
+
+	LBAttrsConnectionLogsS3Enabled = "connection_logs.s3.enabled"
+	LBAttrsConnectionLogsS3Bucket  = "connection_logs.s3.bucket"
+	LBAttrsConnectionLogsS3Prefix  = "connection_logs.s3.prefix"
+
+	// accessLogsBucketPolicySID is the Sid of bucket policy statement managed by controller to grant log delivery.
+	accessLogsBucketPolicySID = "AWSLoadBalancerControllerAccessLogsDelivery"
+	// elbLogDeliveryServicePrincipal is the principal to grant log delivery for regions without an ELB account.
+	elbLogDeliveryServicePrincipal = "logdelivery.elasticloadbalancing.amazonaws.com"
+)
+
+// elbAccountIDByRegion is the AWS account ID of Elastic Load Balancing that delivers access logs, by region.
+// see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-access-logs.html#access-logging-bucket-permissions
+var elbAccountIDByRegion = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"af-south-1":     "098369216593",
+	"ap-east-1":      "754344448648",
+	"ap-southeast-3": "589379963580",
+	"ap-south-1":     "718504428378",
+	"ap-northeast-3": "383597477331",
+	"ap-northeast-2": "600734575887",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-northeast-1": "582318560864",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-south-1":     "635631232127",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"me-south-1":     "076674570225",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+// AccessLogsConfigurator validates and configures S3 buckets for load balancer access logs.
+type AccessLogsConfigurator interface {
+	// Configure validates the S3 bucket for access logs exists in the same region as load balancer,
+	// and grants log delivery on it if bucket policy management is enabled.
+	Configure(ctx context.Context, bucket string, prefix string) error
+}
+
+// NewDefaultAccessLogsConfigurator constructs new defaultAccessLogsConfigurator.
+func NewDefaultAccessLogsConfigurator(s3Client services.S3, region string, partition string, manageBucketPolicy bool, logger logr.Logger) *defaultAccessLogsConfigurator {
+	return &defaultAccessLogsConfigurator{
+		s3Client:           s3Client,
+		region:             region,
+		partition:          partition,
+		manageBucketPolicy: manageBucketPolicy,
+		logger:             logger,
+	}
+}
+
+var _ AccessLogsConfigurator = &defaultAccessLogsConfigurator{}
+
+// default implementation for AccessLogsConfigurator
+type defaultAccessLogsConfigurator struct {
+	s3Client           services.S3
+	region             string
+	partition          string
+	manageBucketPolicy bool
+	logger             logr.Logger
+}
+
+func (c *defaultAccessLogsConfigurator) Configure(ctx context.Context, bucket string, prefix string) error {
+	if err := c.validateBucket(ctx, bucket); err != nil {
+		return err
+	}
+	if c.manageBucketPolicy {
+		if err := c.ensureBucketPolicy(ctx, bucket, prefix); err != nil {
+			return errors.Wrapf(err, "failed to grant access logs delivery on S3 bucket %v", bucket)
+		}
+	}
+	return nil
+}
+
+// validateBucket validates bucket exists and is in the same region as load balancer.
+// validations that require permissions the controller doesn't have are skipped.
+func (c *defaultAccessLogsConfigurator) validateBucket(ctx context.Context, bucket string) error {
+	if _, err := c.s3Client.HeadBucketWithContext(ctx, &s3sdk.HeadBucketInput{Bucket: awssdk.String(bucket)}); err != nil {
+		var reqErr awserr.RequestFailure
+		if errors.As(err, &reqErr) {
+			switch reqErr.StatusCode() {
+			case http.StatusNotFound:
+				return errors.Errorf("access logs S3 bucket %v doesn't exist", bucket)
+			case http.StatusForbidden:
+				c.logger.V(1).Info("unable to verify access logs S3 bucket, permission denied", "bucket", bucket)
+				return nil
+			}
+		}
+		return errors.Wrapf(err, "failed to verify access logs S3 bucket %v", bucket)
+	}
+
+	resp, err := c.s3Client.GetBucketLocationWithContext(ctx, &s3sdk.GetBucketLocationInput{Bucket: awssdk.String(bucket)})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == "AccessDenied" {
+			c.logger.V(1).Info("unable to verify access logs S3 bucket region, permission denied", "bucket", bucket)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to verify access logs S3 bucket %v", bucket)
+	}
+	bucketRegion := s3sdk.NormalizeBucketLocation(awssdk.StringValue(resp.LocationConstraint))
+	if bucketRegion != c.region {
+		return errors.Errorf("access logs S3 bucket %v must be in the same region as load balancer, bucket region: %v, load balancer region: %v",
+			bucket, bucketRegion, c.region)
+	}
+	return nil
+}
+
+// ensureBucketPolicy ensures bucket policy grants log delivery for prefix.
+func (c *defaultAccessLogsConfigurator) ensureBucketPolicy(ctx context.Context, bucket string, prefix string) error {
+	policy := map[string]interface{}{
+		"Version":   "2012-10-17",
+		"Statement": []interface{}{},
+	}
+	resp, err := c.s3Client.GetBucketPolicyWithContext(ctx, &s3sdk.GetBucketPolicyInput{Bucket: awssdk.String(bucket)})
+	if err != nil {
+		var awsErr awserr.Error
+		if !errors.As(err, &awsErr) || awsErr.Code() != "NoSuchBucketPolicy" {
+			return err
+		}
+	} else if err := json.Unmarshal([]byte(awssdk.StringValue(resp.Policy)), &policy); err != nil {
+		return errors.Wrap(err, "failed to parse bucket policy")
+	}
+
+	resource := buildAccessLogsBucketPolicyResource(c.partition, bucket, prefix)
+	updatedPolicy, changed := mergeAccessLogsBucketPolicyStatement(policy, buildAccessLogsBucketPolicyPrincipal(c.partition, c.region), resource)
+	if !changed {
+		return nil
+	}
+	payload, err := json.Marshal(updatedPolicy)
+	if err != nil {
+		return err
+	}
+	c.logger.Info("granting access logs delivery on S3 bucket", "bucket", bucket, "resource", resource)
+	if _, err := c.s3Client.PutBucketPolicyWithContext(ctx, &s3sdk.PutBucketPolicyInput{
+		Bucket: awssdk.String(bucket),
+		Policy: awssdk.String(string(payload)),
+	}); err != nil {
+		return err
+	}
+	c.logger.Info("granted access logs delivery on S3 bucket", "bucket", bucket, "resource", resource)
+	return nil
+}
+
+// buildAccessLogsBucketPolicyPrincipal builds the principal that delivers access logs in region.
+func buildAccessLogsBucketPolicyPrincipal(partition string, region string) map[string]interface{} {
+	if accountID, ok := elbAccountIDByRegion[region]; ok {
+		return map[string]interface{}{"AWS": fmt.Sprintf("arn:%v:iam::%v:root", partition, accountID)}
+	}
+	return map[string]interface{}{"Service": elbLogDeliveryServicePrincipal}
+}
+
+// buildAccessLogsBucketPolicyResource builds the S3 object ARN pattern that access logs are delivered to.
+func buildAccessLogsBucketPolicyResource(partition string, bucket string, prefix string) string {
+	if prefix == "" {
+		return fmt.Sprintf("arn:%v:s3:::%v/AWSLogs/*", partition, bucket)
+	}
+	return fmt.Sprintf("arn:%v:s3:::%v/%v/AWSLogs/*", partition, bucket, prefix)
+}
+
+// mergeAccessLogsBucketPolicyStatement merges the log delivery statement for resource into policy.
+// returns the merged policy and whether policy is changed.
+func mergeAccessLogsBucketPolicyStatement(policy map[string]interface{}, principal map[string]interface{}, resource string) (map[string]interface{}, bool) {
+	var statements []interface{}
+	switch rawStatements := policy["Statement"].(type) {
+	case []interface{}:
+		statements = rawStatements
+	case map[string]interface{}:
+		statements = []interface{}{rawStatements}
+	}
+
+	resources := []interface{}{resource}
+	managedStatementIndex := -1
+	for i, rawStatement := range statements {
+		statement, ok := rawStatement.(map[string]interface{})
+		if !ok || statement["Sid"] != accessLogsBucketPolicySID {
+			continue
+		}
+		managedStatementIndex = i
+		switch rawResources := statement["Resource"].(type) {
+		case string:
+			resources = []interface{}{rawResources}
+		case []interface{}:
+			resources = append([]interface{}{}, rawResources...)
+		}
+		if !containsResource(resources, resource) {
+			resources = append(resources, resource)
+		}
+		break
+	}
+	desiredStatement := map[string]interface{}{
+		"Sid":       accessLogsBucketPolicySID,
+		"Effect":    "Allow",
+		"Principal": principal,
+		"Action":    "s3:PutObject",
+		"Resource":  resources,
+	}
+
+	mergedStatements := make([]interface{}, 0, len(statements)+1)
+	mergedStatements = append(mergedStatements, statements...)
+	if managedStatementIndex < 0 {
+		mergedStatements = append(mergedStatements, desiredStatement)
+	} else {
+		if reflect.DeepEqual(normalizeJSON(statements[managedStatementIndex]), normalizeJSON(desiredStatement)) {
+			return policy, false
+		}
+		mergedStatements[managedStatementIndex] = desiredStatement
+	}
+	mergedPolicy := make(map[string]interface{}, len(policy))
+	for k, v := range policy {
+		mergedPolicy[k] = v
+	}
+	mergedPolicy["Statement"] = mergedStatements
+	return mergedPolicy, true
+}
+
+func containsResource(resources []interface{}, resource string) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeJSON converts value into its generic JSON representation for comparison.
+func normalizeJSON(value interface{}) interface{} {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(payload, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2 (interfaces: AccessLogsConfigurator)
+
+// Package elbv2 is a generated GoMock package.
+package elbv2
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccessLogsConfigurator is a mock of AccessLogsConfigurator interface.
+type MockAccessLogsConfigurator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessLogsConfiguratorMockRecorder
+}
+
+// MockAccessLogsConfiguratorMockRecorder is the mock recorder for MockAccessLogsConfigurator.
+type MockAccessLogsConfiguratorMockRecorder struct {
+	mock *MockAccessLogsConfigurator
+}
+
+// NewMockAccessLogsConfigurator creates a new mock instance.
+func NewMockAccessLogsConfigurator(ctrl *gomock.Controller) *MockAccessLogsConfigurator {
+	mock := &MockAccessLogsConfigurator{ctrl: ctrl}
+	mock.recorder = &MockAccessLogsConfiguratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessLogsConfigurator) EXPECT() *MockAccessLogsConfiguratorMockRecorder {
+	return m.recorder
+}
+
+// Configure mocks base method.
+func (m *MockAccessLogsConfigurator) Configure(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Configure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Configure indicates an expected call of Configure.
+func (mr *MockAccessLogsConfiguratorMockRecorder) Configure(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockAccessLogsConfigurator)(nil).Configure), arg0, arg1, arg2)
+}
@@ -0,0 +1,318 @@
+package elbv2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultAccessLogsConfigurator_Configure(t *testing.T) {
+	type headBucketCall struct {
+		err error
+	}
+	type getBucketLocationCall struct {
+		resp *s3sdk.GetBucketLocationOutput
+		err  error
+	}
+	type getBucketPolicyCall struct {
+		resp *s3sdk.GetBucketPolicyOutput
+		err  error
+	}
+	type putBucketPolicyCall struct {
+		policy string
+		err    error
+	}
+	type fields struct {
+		manageBucketPolicy     bool
+		headBucketCalls        []headBucketCall
+		getBucketLocationCalls []getBucketLocationCall
+		getBucketPolicyCalls   []getBucketPolicyCall
+		putBucketPolicyCalls   []putBucketPolicyCall
+	}
+	type args struct {
+		bucket string
+		prefix string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "bucket exists in same region",
+			fields: fields{
+				headBucketCalls: []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{resp: &s3sdk.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")}},
+				},
+			},
+			args: args{bucket: "my-bucket"},
+		},
+		{
+			name: "bucket doesn't exist",
+			fields: fields{
+				headBucketCalls: []headBucketCall{
+					{err: awserr.NewRequestFailure(awserr.New("NotFound", "Not Found", nil), http.StatusNotFound, "req-id")},
+				},
+			},
+			args:    args{bucket: "my-bucket"},
+			wantErr: errors.New("access logs S3 bucket my-bucket doesn't exist"),
+		},
+		{
+			name: "bucket exists but permission denied",
+			fields: fields{
+				headBucketCalls: []headBucketCall{
+					{err: awserr.NewRequestFailure(awserr.New("Forbidden", "Forbidden", nil), http.StatusForbidden, "req-id")},
+				},
+			},
+			args: args{bucket: "my-bucket"},
+		},
+		{
+			name: "bucket in different region",
+			fields: fields{
+				headBucketCalls: []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{resp: &s3sdk.GetBucketLocationOutput{LocationConstraint: nil}},
+				},
+			},
+			args:    args{bucket: "my-bucket"},
+			wantErr: errors.New("access logs S3 bucket my-bucket must be in the same region as load balancer, bucket region: us-east-1, load balancer region: us-west-2"),
+		},
+		{
+			name: "bucket region permission denied",
+			fields: fields{
+				headBucketCalls: []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{err: awserr.New("AccessDenied", "Access Denied", nil)},
+				},
+			},
+			args: args{bucket: "my-bucket"},
+		},
+		{
+			name: "bucket policy created when there is no bucket policy",
+			fields: fields{
+				manageBucketPolicy: true,
+				headBucketCalls:    []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{resp: &s3sdk.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")}},
+				},
+				getBucketPolicyCalls: []getBucketPolicyCall{
+					{err: awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)},
+				},
+				putBucketPolicyCalls: []putBucketPolicyCall{
+					{policy: `{"Statement":[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::797873946194:root"},"Resource":["arn:aws:s3:::my-bucket/my-prefix/AWSLogs/*"],"Sid":"AWSLoadBalancerControllerAccessLogsDelivery"}],"Version":"2012-10-17"}`},
+				},
+			},
+			args: args{bucket: "my-bucket", prefix: "my-prefix"},
+		},
+		{
+			name: "bucket policy already grants log delivery",
+			fields: fields{
+				manageBucketPolicy: true,
+				headBucketCalls:    []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{resp: &s3sdk.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")}},
+				},
+				getBucketPolicyCalls: []getBucketPolicyCall{
+					{resp: &s3sdk.GetBucketPolicyOutput{Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Sid":"AWSLoadBalancerControllerAccessLogsDelivery","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::797873946194:root"},"Action":"s3:PutObject","Resource":["arn:aws:s3:::my-bucket/my-prefix/AWSLogs/*"]}]}`)}},
+				},
+			},
+			args: args{bucket: "my-bucket", prefix: "my-prefix"},
+		},
+		{
+			name: "failed to put bucket policy",
+			fields: fields{
+				manageBucketPolicy: true,
+				headBucketCalls:    []headBucketCall{{}},
+				getBucketLocationCalls: []getBucketLocationCall{
+					{resp: &s3sdk.GetBucketLocationOutput{LocationConstraint: awssdk.String("us-west-2")}},
+				},
+				getBucketPolicyCalls: []getBucketPolicyCall{
+					{resp: &s3sdk.GetBucketPolicyOutput{Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[]}`)}},
+				},
+				putBucketPolicyCalls: []putBucketPolicyCall{
+					{
+						policy: `{"Statement":[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::797873946194:root"},"Resource":["arn:aws:s3:::my-bucket/AWSLogs/*"],"Sid":"AWSLoadBalancerControllerAccessLogsDelivery"}],"Version":"2012-10-17"}`,
+						err:    errors.New("AccessDenied"),
+					},
+				},
+			},
+			args:    args{bucket: "my-bucket"},
+			wantErr: errors.New("failed to grant access logs delivery on S3 bucket my-bucket: AccessDenied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s3Client := services.NewMockS3(ctrl)
+			for _, call := range tt.fields.headBucketCalls {
+				s3Client.EXPECT().HeadBucketWithContext(gomock.Any(), &s3sdk.HeadBucketInput{Bucket: awssdk.String(tt.args.bucket)}).Return(&s3sdk.HeadBucketOutput{}, call.err)
+			}
+			for _, call := range tt.fields.getBucketLocationCalls {
+				s3Client.EXPECT().GetBucketLocationWithContext(gomock.Any(), &s3sdk.GetBucketLocationInput{Bucket: awssdk.String(tt.args.bucket)}).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.getBucketPolicyCalls {
+				s3Client.EXPECT().GetBucketPolicyWithContext(gomock.Any(), &s3sdk.GetBucketPolicyInput{Bucket: awssdk.String(tt.args.bucket)}).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.putBucketPolicyCalls {
+				s3Client.EXPECT().PutBucketPolicyWithContext(gomock.Any(), &s3sdk.PutBucketPolicyInput{
+					Bucket: awssdk.String(tt.args.bucket),
+					Policy: awssdk.String(call.policy),
+				}).Return(&s3sdk.PutBucketPolicyOutput{}, call.err)
+			}
+
+			c := NewDefaultAccessLogsConfigurator(s3Client, "us-west-2", "aws", tt.fields.manageBucketPolicy, &log.NullLogger{})
+			err := c.Configure(context.Background(), tt.args.bucket, tt.args.prefix)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_mergeAccessLogsBucketPolicyStatement(t *testing.T) {
+	principal := map[string]interface{}{"AWS": "arn:aws:iam::797873946194:root"}
+	tests := []struct {
+		name        string
+		policy      map[string]interface{}
+		resource    string
+		want        map[string]interface{}
+		wantChanged bool
+	}{
+		{
+			name: "statement appended to existing statements",
+			policy: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": map[string]interface{}{
+					"Sid":    "Other",
+					"Effect": "Deny",
+				},
+			},
+			resource: "arn:aws:s3:::my-bucket/AWSLogs/*",
+			want: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Sid":    "Other",
+						"Effect": "Deny",
+					},
+					map[string]interface{}{
+						"Sid":       "AWSLoadBalancerControllerAccessLogsDelivery",
+						"Effect":    "Allow",
+						"Principal": principal,
+						"Action":    "s3:PutObject",
+						"Resource":  []interface{}{"arn:aws:s3:::my-bucket/AWSLogs/*"},
+					},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "resource added to managed statement",
+			policy: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Sid":       "AWSLoadBalancerControllerAccessLogsDelivery",
+						"Effect":    "Allow",
+						"Principal": principal,
+						"Action":    "s3:PutObject",
+						"Resource":  "arn:aws:s3:::my-bucket/prefix-1/AWSLogs/*",
+					},
+				},
+			},
+			resource: "arn:aws:s3:::my-bucket/prefix-2/AWSLogs/*",
+			want: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Sid":       "AWSLoadBalancerControllerAccessLogsDelivery",
+						"Effect":    "Allow",
+						"Principal": principal,
+						"Action":    "s3:PutObject",
+						"Resource":  []interface{}{"arn:aws:s3:::my-bucket/prefix-1/AWSLogs/*", "arn:aws:s3:::my-bucket/prefix-2/AWSLogs/*"},
+					},
+				},
+			},
+			wantChanged: true,
+		},
+		{
+			name: "managed statement up to date",
+			policy: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Sid":       "AWSLoadBalancerControllerAccessLogsDelivery",
+						"Effect":    "Allow",
+						"Principal": map[string]interface{}{"AWS": "arn:aws:iam::797873946194:root"},
+						"Action":    "s3:PutObject",
+						"Resource":  []interface{}{"arn:aws:s3:::my-bucket/AWSLogs/*"},
+					},
+				},
+			},
+			resource: "arn:aws:s3:::my-bucket/AWSLogs/*",
+			want: map[string]interface{}{
+				"Version": "2012-10-17",
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Sid":       "AWSLoadBalancerControllerAccessLogsDelivery",
+						"Effect":    "Allow",
+						"Principal": map[string]interface{}{"AWS": "arn:aws:iam::797873946194:root"},
+						"Action":    "s3:PutObject",
+						"Resource":  []interface{}{"arn:aws:s3:::my-bucket/AWSLogs/*"},
+					},
+				},
+			},
+			wantChanged: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotChanged := mergeAccessLogsBucketPolicyStatement(tt.policy, principal, tt.resource)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantChanged, gotChanged)
+		})
+	}
+}
+
+func Test_buildAccessLogsBucketPolicyPrincipal(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		region    string
+		want      map[string]interface{}
+	}{
+		{
+			name:      "region with ELB account",
+			partition: "aws-cn",
+			region:    "cn-north-1",
+			want:      map[string]interface{}{"AWS": "arn:aws-cn:iam::638102146993:root"},
+		},
+		{
+			name:      "region without ELB account",
+			partition: "aws",
+			region:    "ap-south-2",
+			want:      map[string]interface{}{"Service": "logdelivery.elasticloadbalancing.amazonaws.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildAccessLogsBucketPolicyPrincipal(tt.partition, tt.region)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sync"
 	"time"
 )
 
@@ -28,16 +29,21 @@ type ListenerManager interface {
 }
 
 func NewDefaultListenerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, externalManagedTags []string, featureGates config.FeatureGates, logger logr.Logger) *defaultListenerManager {
+	taggingManager TaggingManager, externalManagedTags []string, featureGates config.FeatureGates,
+	certRemovalGracePeriod time.Duration, logger logr.Logger) *defaultListenerManager {
 	return &defaultListenerManager{
 		elbv2Client:                 elbv2Client,
 		trackingProvider:            trackingProvider,
 		taggingManager:              taggingManager,
 		externalManagedTags:         externalManagedTags,
 		featureGates:                featureGates,
+		certRemovalGracePeriod:      certRemovalGracePeriod,
+		certObsoletedAt:             make(map[string]map[string]time.Time),
 		logger:                      logger,
 		waitLSExistencePollInterval: defaultWaitLSExistencePollInterval,
 		waitLSExistenceTimeout:      defaultWaitLSExistenceTimeout,
+		waitCertActivePollInterval:  defaultWaitCertActivePollInterval,
+		waitCertActiveTimeout:       defaultWaitCertActiveTimeout,
 	}
 }
 
@@ -50,10 +56,17 @@ type defaultListenerManager struct {
 	taggingManager      TaggingManager
 	externalManagedTags []string
 	featureGates        config.FeatureGates
-	logger              logr.Logger
+	// certRemovalGracePeriod is the duration that replaced certificates are kept on listener before they are removed.
+	certRemovalGracePeriod time.Duration
+	// certObsoletedAt tracks when certificates on listener became no longer desired, indexed by listener ARN and certificate ARN.
+	certObsoletedAt      map[string]map[string]time.Time
+	certObsoletedAtMutex sync.Mutex
+	logger               logr.Logger
 
 	waitLSExistencePollInterval time.Duration
 	waitLSExistenceTimeout      time.Duration
+	waitCertActivePollInterval  time.Duration
+	waitCertActiveTimeout       time.Duration
 }
 
 func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.Listener) (elbv2model.ListenerStatus, error) {
@@ -84,7 +97,7 @@ func (m *defaultListenerManager) Create(ctx context.Context, resLS *elbv2model.L
 		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn))
 
 	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
-		return m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, true)
+		return m.createSDKListenerExtraCertificates(ctx, resLS, sdkLS)
 	}); err != nil {
 		return elbv2model.ListenerStatus{}, errors.Wrap(err, "failed to update extra certificates on listener")
 	}
@@ -97,10 +110,12 @@ func (m *defaultListenerManager) Update(ctx context.Context, resLS *elbv2model.L
 			return elbv2model.ListenerStatus{}, err
 		}
 	}
-	if err := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS); err != nil {
-		return elbv2model.ListenerStatus{}, err
-	}
-	if err := m.updateSDKListenerWithExtraCertificates(ctx, resLS, sdkLS, false); err != nil {
+	if err := m.updateSDKListenerWithSettingsAndCertificates(ctx, resLS, sdkLS); err != nil {
+		// listener is updated except for replaced certificates, which are removed by a later reconcile.
+		var requeueNeededAfter *runtime.RequeueNeededAfter
+		if errors.As(err, &requeueNeededAfter) {
+			return buildResListenerStatus(sdkLS), err
+		}
 		return elbv2model.ListenerStatus{}, err
 	}
 	return buildResListenerStatus(sdkLS), nil
@@ -115,6 +130,7 @@ func (m *defaultListenerManager) Delete(ctx context.Context, sdkLS ListenerWithT
 	if _, err := m.elbv2Client.DeleteListenerWithContext(ctx, req); err != nil {
 		return err
 	}
+	m.forgetObsoleteCertificates(awssdk.StringValue(req.ListenerArn))
 	m.logger.Info("deleted listener",
 		"arn", awssdk.StringValue(req.ListenerArn))
 	return nil
@@ -152,78 +168,208 @@ func (m *defaultListenerManager) updateSDKListenerWithSettings(ctx context.Conte
 	return nil
 }
 
-// updateSDKListenerWithExtraCertificates will update the extra certificates on listener.
-// currentExtraCertificates is the current extra certificates, if it's nil, the current extra certificates will be fetched from AWS.
-func (m *defaultListenerManager) updateSDKListenerWithExtraCertificates(ctx context.Context, resLS *elbv2model.Listener,
-	sdkLS ListenerWithTags, isNewSDKListener bool) error {
+// updateSDKListenerWithSettingsAndCertificates will update the settings and certificates on listener.
+// certificates are switched over without interrupting TLS handshakes of clients as following:
+//  1. certificates to add are added as extra certificates first, including the new default certificate and the replaced default certificate.
+//  2. wait until the added certificates are active on listener.
+//  3. switch the default certificate along with other settings.
+//  4. remove extra certificates that are no longer desired once the grace period passed since they became obsolete.
+//     a RequeueNeededAfter error is returned if any of them is still in grace period, so that they're removed by a later reconcile.
+func (m *defaultListenerManager) updateSDKListenerWithSettingsAndCertificates(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) error {
 	// if TLS is not supported, we shouldn't update
 	if sdkLS.Listener.SslPolicy == nil {
 		m.logger.V(1).Info("SDK Listner doesn't have SSL Policy set, we skip updating extra certs for non-TLS listener.")
-		return nil
+		return m.updateSDKListenerWithSettings(ctx, resLS, sdkLS)
 	}
 
+	desiredDefaultCerts, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
 	desiredExtraCertARNs := sets.NewString()
-	_, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
 	for _, cert := range desiredExtraCerts {
 		desiredExtraCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
 	}
-	currentExtraCertARNs := sets.NewString()
-	if !isNewSDKListener {
-		certARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
-		if err != nil {
+	certARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
+	if err != nil {
+		return err
+	}
+	currentExtraCertARNs := sets.NewString(certARNs...)
+
+	// the default certificate is kept as an extra certificate once added, since it cannot be removed from listener.
+	keptCertARNs := sets.NewString(desiredExtraCertARNs.UnsortedList()...)
+	transitionalCertARNs := sets.NewString(desiredExtraCertARNs.UnsortedList()...)
+	if len(desiredDefaultCerts) != 0 {
+		desiredDefaultCertARN := awssdk.StringValue(desiredDefaultCerts[0].CertificateArn)
+		keptCertARNs.Insert(desiredDefaultCertARN)
+		if len(sdkLS.Listener.Certificates) != 0 {
+			currentDefaultCertARN := awssdk.StringValue(sdkLS.Listener.Certificates[0].CertificateArn)
+			if desiredDefaultCertARN != currentDefaultCertARN {
+				transitionalCertARNs.Insert(desiredDefaultCertARN, currentDefaultCertARN)
+			}
+		}
+	}
+	certARNsToAdd := transitionalCertARNs.Difference(currentExtraCertARNs)
+	for _, certARN := range certARNsToAdd.List() {
+		if err := m.addSDKListenerCertificate(ctx, resLS, sdkLS, certARN); err != nil {
 			return err
 		}
-		currentExtraCertARNs.Insert(certARNs...)
 	}
-
-	for _, certARN := range desiredExtraCertARNs.Difference(currentExtraCertARNs).List() {
-		req := &elbv2sdk.AddListenerCertificatesInput{
-			ListenerArn: sdkLS.Listener.ListenerArn,
-			Certificates: []*elbv2sdk.Certificate{
-				{
-					CertificateArn: awssdk.String(certARN),
-				},
-			},
+	if certARNsToAdd.Len() != 0 {
+		if err := m.waitSDKListenerCertificatesActive(ctx, sdkLS, certARNsToAdd); err != nil {
+			return err
 		}
-		m.logger.Info("adding certificate to listener",
-			"stackID", resLS.Stack().StackID(),
-			"resourceID", resLS.ID(),
-			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
-			"certificateARN", certARN)
-		if _, err := m.elbv2Client.AddListenerCertificatesWithContext(ctx, req); err != nil {
+	}
+
+	if err := m.updateSDKListenerWithSettings(ctx, resLS, sdkLS); err != nil {
+		return err
+	}
+
+	obsoleteCertARNs := currentExtraCertARNs.Union(certARNsToAdd).Difference(keptCertARNs)
+	certARNsToRemove, requeueAfter := m.trackObsoleteCertificates(awssdk.StringValue(sdkLS.Listener.ListenerArn), obsoleteCertARNs, time.Now())
+	for _, certARN := range certARNsToRemove.List() {
+		if err := m.removeSDKListenerCertificate(ctx, resLS, sdkLS, certARN); err != nil {
 			return err
 		}
-		m.logger.Info("added certificate to listener",
+	}
+	if requeueAfter > 0 {
+		m.logger.Info("keeping replaced certificates on listener for grace period",
 			"stackID", resLS.Stack().StackID(),
 			"resourceID", resLS.ID(),
 			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
-			"certificateARN", certARN)
+			"certificateARNs", obsoleteCertARNs.Difference(certARNsToRemove).List(),
+			"requeueAfter", requeueAfter)
+		return runtime.NewRequeueNeededAfter("remove replaced certificates from listener", requeueAfter)
 	}
+	return nil
+}
 
-	for _, certARN := range currentExtraCertARNs.Difference(desiredExtraCertARNs).List() {
-		req := &elbv2sdk.RemoveListenerCertificatesInput{
-			ListenerArn: sdkLS.Listener.ListenerArn,
-			Certificates: []*elbv2sdk.Certificate{
-				{
-					CertificateArn: awssdk.String(certARN),
-				},
-			},
+// trackObsoleteCertificates records when the obsolete certificates on listener became obsolete.
+// it returns the obsolete certificates whose grace period passed, along with the duration until the grace period of remaining ones pass.
+func (m *defaultListenerManager) trackObsoleteCertificates(lsARN string, obsoleteCertARNs sets.String, now time.Time) (sets.String, time.Duration) {
+	if m.certRemovalGracePeriod <= 0 {
+		return obsoleteCertARNs, 0
+	}
+	m.certObsoletedAtMutex.Lock()
+	defer m.certObsoletedAtMutex.Unlock()
+
+	// certificates that are removed or desired again are no longer tracked, thus they get full grace period if replaced again.
+	certObsoletedAt := make(map[string]time.Time, obsoleteCertARNs.Len())
+	certARNsToRemove := sets.NewString()
+	var requeueAfter time.Duration
+	for _, certARN := range obsoleteCertARNs.List() {
+		obsoletedAt, ok := m.certObsoletedAt[lsARN][certARN]
+		if !ok {
+			obsoletedAt = now
 		}
-		m.logger.Info("removing certificate from listener",
-			"stackID", resLS.Stack().StackID(),
-			"resourceID", resLS.ID(),
-			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
-			"certificateARN", certARN)
-		if _, err := m.elbv2Client.RemoveListenerCertificatesWithContext(ctx, req); err != nil {
+		remaining := obsoletedAt.Add(m.certRemovalGracePeriod).Sub(now)
+		if remaining <= 0 {
+			certARNsToRemove.Insert(certARN)
+			continue
+		}
+		certObsoletedAt[certARN] = obsoletedAt
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+	if len(certObsoletedAt) == 0 {
+		delete(m.certObsoletedAt, lsARN)
+	} else {
+		m.certObsoletedAt[lsARN] = certObsoletedAt
+	}
+	return certARNsToRemove, requeueAfter
+}
+
+// forgetObsoleteCertificates stops tracking obsolete certificates on listener.
+func (m *defaultListenerManager) forgetObsoleteCertificates(lsARN string) {
+	m.certObsoletedAtMutex.Lock()
+	defer m.certObsoletedAtMutex.Unlock()
+	delete(m.certObsoletedAt, lsARN)
+}
+
+// createSDKListenerExtraCertificates will add the extra certificates on newly created listener.
+func (m *defaultListenerManager) createSDKListenerExtraCertificates(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags) error {
+	// if TLS is not supported, we shouldn't update
+	if sdkLS.Listener.SslPolicy == nil {
+		m.logger.V(1).Info("SDK Listner doesn't have SSL Policy set, we skip updating extra certs for non-TLS listener.")
+		return nil
+	}
+
+	desiredExtraCertARNs := sets.NewString()
+	_, desiredExtraCerts := buildSDKCertificates(resLS.Spec.Certificates)
+	for _, cert := range desiredExtraCerts {
+		desiredExtraCertARNs.Insert(awssdk.StringValue(cert.CertificateArn))
+	}
+	for _, certARN := range desiredExtraCertARNs.List() {
+		if err := m.addSDKListenerCertificate(ctx, resLS, sdkLS, certARN); err != nil {
 			return err
 		}
-		m.logger.Info("removed certificate from listener",
-			"stackID", resLS.Stack().StackID(),
-			"resourceID", resLS.ID(),
-			"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
-			"certificateARN", certARN)
 	}
+	return nil
+}
+
+func (m *defaultListenerManager) addSDKListenerCertificate(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags, certARN string) error {
+	req := &elbv2sdk.AddListenerCertificatesInput{
+		ListenerArn: sdkLS.Listener.ListenerArn,
+		Certificates: []*elbv2sdk.Certificate{
+			{
+				CertificateArn: awssdk.String(certARN),
+			},
+		},
+	}
+	m.logger.Info("adding certificate to listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
+		"certificateARN", certARN)
+	if _, err := m.elbv2Client.AddListenerCertificatesWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("added certificate to listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
+		"certificateARN", certARN)
+	return nil
+}
+
+func (m *defaultListenerManager) removeSDKListenerCertificate(ctx context.Context, resLS *elbv2model.Listener, sdkLS ListenerWithTags, certARN string) error {
+	req := &elbv2sdk.RemoveListenerCertificatesInput{
+		ListenerArn: sdkLS.Listener.ListenerArn,
+		Certificates: []*elbv2sdk.Certificate{
+			{
+				CertificateArn: awssdk.String(certARN),
+			},
+		},
+	}
+	m.logger.Info("removing certificate from listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
+		"certificateARN", certARN)
+	if _, err := m.elbv2Client.RemoveListenerCertificatesWithContext(ctx, req); err != nil {
+		return err
+	}
+	m.logger.Info("removed certificate from listener",
+		"stackID", resLS.Stack().StackID(),
+		"resourceID", resLS.ID(),
+		"arn", awssdk.StringValue(sdkLS.Listener.ListenerArn),
+		"certificateARN", certARN)
+	return nil
+}
 
+// waitSDKListenerCertificatesActive waits until certificates are listed as extra certificates of listener.
+func (m *defaultListenerManager) waitSDKListenerCertificatesActive(ctx context.Context, sdkLS ListenerWithTags, certARNs sets.String) error {
+	err := runtime.RetryImmediateOnError(m.waitCertActivePollInterval, m.waitCertActiveTimeout, isCertificatesNotActiveError, func() error {
+		activeCertARNs, err := m.fetchSDKListenerExtraCertificateARNs(ctx, sdkLS)
+		if err != nil {
+			return err
+		}
+		if !sets.NewString(activeCertARNs...).IsSuperset(certARNs) {
+			return errCertificatesNotActive
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait certificates to be active on listener: %v", certARNs.List())
+	}
 	return nil
 }
 
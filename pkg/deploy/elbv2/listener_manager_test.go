@@ -1,11 +1,20 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_isSDKListenerSettingsDrifted(t *testing.T) {
@@ -176,6 +185,52 @@ func Test_isSDKListenerSettingsDrifted(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "listener has drifted if sslPolicy differs",
+			args: args{
+				lsSpec: elbv2model.ListenerSpec{
+					Port:      8443,
+					Protocol:  elbv2model.ProtocolHTTPS,
+					SSLPolicy: awssdk.String("ELBSecurityPolicy-2016-08"),
+				},
+				sdkLS: ListenerWithTags{
+					Listener: &elbv2sdk.Listener{
+						Port:     awssdk.Int64(8443),
+						Protocol: awssdk.String("HTTPS"),
+						Certificates: []*elbv2sdk.Certificate{
+							{
+								CertificateArn: awssdk.String("cert-arn1"),
+								IsDefault:      awssdk.Bool(true),
+							},
+						},
+						DefaultActions: []*elbv2sdk.Action{
+							{
+								Type: awssdk.String("fixed-response"),
+								FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+									StatusCode: awssdk.String("404"),
+								},
+							},
+						},
+						SslPolicy: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					},
+				},
+				desiredDefaultCerts: []*elbv2sdk.Certificate{
+					{
+						CertificateArn: awssdk.String("cert-arn1"),
+						IsDefault:      awssdk.Bool(true),
+					},
+				},
+				desiredDefaultActions: []*elbv2sdk.Action{
+					{
+						Type: awssdk.String("fixed-response"),
+						FixedResponseConfig: &elbv2sdk.FixedResponseActionConfig{
+							StatusCode: awssdk.String("404"),
+						},
+					},
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,3 +239,238 @@ func Test_isSDKListenerSettingsDrifted(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultListenerManager_updateSDKListenerWithSettingsAndCertificates(t *testing.T) {
+	type describeListenerCertificatesCall struct {
+		certs []*elbv2sdk.Certificate
+	}
+	tests := []struct {
+		name                              string
+		certRemovalGracePeriod            time.Duration
+		certObsoletedAgo                  map[string]time.Duration
+		certARNs                          []string
+		currentDefaultCertARN             string
+		describeListenerCertificatesCalls []describeListenerCertificatesCall
+		wantAddedCertARNs                 []string
+		wantModified                      bool
+		wantRemovedCertARNs               []string
+		wantRequeue                       bool
+		wantObsoleteCertARNs              []string
+	}{
+		{
+			name:                  "default certificate switched, without grace period",
+			certARNs:              []string{"cert-2"},
+			currentDefaultCertARN: "cert-1",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+					},
+				},
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+			wantAddedCertARNs:   []string{"cert-1", "cert-2"},
+			wantModified:        true,
+			wantRemovedCertARNs: []string{"cert-1"},
+		},
+		{
+			name:                   "default certificate switched, replaced certificate kept for grace period",
+			certRemovalGracePeriod: time.Hour,
+			certARNs:               []string{"cert-2"},
+			currentDefaultCertARN:  "cert-1",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+					},
+				},
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+			wantAddedCertARNs:    []string{"cert-1", "cert-2"},
+			wantModified:         true,
+			wantRequeue:          true,
+			wantObsoleteCertARNs: []string{"cert-1"},
+		},
+		{
+			name:                   "default certificate switched, replaced certificate still in grace period",
+			certRemovalGracePeriod: time.Hour,
+			certObsoletedAgo:       map[string]time.Duration{"cert-1": time.Minute},
+			certARNs:               []string{"cert-2"},
+			currentDefaultCertARN:  "cert-2",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+			wantRequeue:          true,
+			wantObsoleteCertARNs: []string{"cert-1"},
+		},
+		{
+			name:                   "default certificate switched, replaced certificate removed after grace period",
+			certRemovalGracePeriod: time.Hour,
+			certObsoletedAgo:       map[string]time.Duration{"cert-1": 2 * time.Hour},
+			certARNs:               []string{"cert-2"},
+			currentDefaultCertARN:  "cert-2",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+			wantRemovedCertARNs: []string{"cert-1"},
+		},
+		{
+			name:                   "replaced certificate desired again is no longer tracked",
+			certRemovalGracePeriod: time.Hour,
+			certObsoletedAgo:       map[string]time.Duration{"cert-1": time.Minute},
+			certARNs:               []string{"cert-2", "cert-1"},
+			currentDefaultCertARN:  "cert-2",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+		},
+		{
+			name:                  "extra certificate replaced",
+			certARNs:              []string{"cert-1", "cert-3"},
+			currentDefaultCertARN: "cert-1",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-1"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+						{CertificateArn: awssdk.String("cert-3"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+			wantAddedCertARNs:   []string{"cert-3"},
+			wantRemovedCertARNs: []string{"cert-2"},
+		},
+		{
+			name:                  "certificates unchanged, with default certificate kept as extra certificate",
+			certARNs:              []string{"cert-2"},
+			currentDefaultCertARN: "cert-2",
+			describeListenerCertificatesCalls: []describeListenerCertificatesCall{
+				{
+					certs: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(true)},
+						{CertificateArn: awssdk.String("cert-2"), IsDefault: awssdk.Bool(false)},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			var calls []*gomock.Call
+			calls = append(calls, elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), gomock.Any()).
+				Return(tt.describeListenerCertificatesCalls[0].certs, nil))
+			for _, certARN := range tt.wantAddedCertARNs {
+				calls = append(calls, elbv2Client.EXPECT().AddListenerCertificatesWithContext(gomock.Any(), &elbv2sdk.AddListenerCertificatesInput{
+					ListenerArn:  awssdk.String("ls-arn"),
+					Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String(certARN)}},
+				}).Return(&elbv2sdk.AddListenerCertificatesOutput{}, nil))
+			}
+			for _, call := range tt.describeListenerCertificatesCalls[1:] {
+				calls = append(calls, elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), gomock.Any()).
+					Return(call.certs, nil))
+			}
+			if tt.wantModified {
+				calls = append(calls, elbv2Client.EXPECT().ModifyListenerWithContext(gomock.Any(), gomock.Any()).
+					Return(&elbv2sdk.ModifyListenerOutput{}, nil))
+			}
+			for _, certARN := range tt.wantRemovedCertARNs {
+				calls = append(calls, elbv2Client.EXPECT().RemoveListenerCertificatesWithContext(gomock.Any(), &elbv2sdk.RemoveListenerCertificatesInput{
+					ListenerArn:  awssdk.String("ls-arn"),
+					Certificates: []*elbv2sdk.Certificate{{CertificateArn: awssdk.String(certARN)}},
+				}).Return(&elbv2sdk.RemoveListenerCertificatesOutput{}, nil))
+			}
+			gomock.InOrder(calls...)
+
+			var modelCerts []elbv2model.Certificate
+			for _, certARN := range tt.certARNs {
+				modelCerts = append(modelCerts, elbv2model.Certificate{CertificateARN: awssdk.String(certARN)})
+			}
+			stack := core.NewDefaultStack(core.StackID{Namespace: "namespace", Name: "name"})
+			resLS := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+				LoadBalancerARN: core.LiteralStringToken("lb-arn"),
+				Port:            443,
+				Protocol:        elbv2model.ProtocolHTTPS,
+				Certificates:    modelCerts,
+			})
+			sdkLS := ListenerWithTags{
+				Listener: &elbv2sdk.Listener{
+					ListenerArn: awssdk.String("ls-arn"),
+					Port:        awssdk.Int64(443),
+					Protocol:    awssdk.String("HTTPS"),
+					Certificates: []*elbv2sdk.Certificate{
+						{CertificateArn: awssdk.String(tt.currentDefaultCertARN)},
+					},
+					SslPolicy: awssdk.String("ELBSecurityPolicy-2016-08"),
+				},
+			}
+			m := &defaultListenerManager{
+				elbv2Client:                elbv2Client,
+				featureGates:               config.NewFeatureGates(),
+				certRemovalGracePeriod:     tt.certRemovalGracePeriod,
+				certObsoletedAt:            make(map[string]map[string]time.Time),
+				logger:                     &log.NullLogger{},
+				waitCertActivePollInterval: time.Millisecond,
+				waitCertActiveTimeout:      time.Second,
+			}
+			if len(tt.certObsoletedAgo) != 0 {
+				m.certObsoletedAt["ls-arn"] = make(map[string]time.Time)
+				for certARN, ago := range tt.certObsoletedAgo {
+					m.certObsoletedAt["ls-arn"][certARN] = time.Now().Add(-ago)
+				}
+			}
+			err := m.updateSDKListenerWithSettingsAndCertificates(context.Background(), resLS, sdkLS)
+			if tt.wantRequeue {
+				var requeueNeededAfter *runtime.RequeueNeededAfter
+				assert.True(t, errors.As(err, &requeueNeededAfter))
+				assert.True(t, requeueNeededAfter.Duration() > 0 && requeueNeededAfter.Duration() <= tt.certRemovalGracePeriod)
+			} else {
+				assert.NoError(t, err)
+			}
+			var gotObsoleteCertARNs []string
+			for certARN := range m.certObsoletedAt["ls-arn"] {
+				gotObsoleteCertARNs = append(gotObsoleteCertARNs, certARN)
+			}
+			assert.ElementsMatch(t, tt.wantObsoleteCertARNs, gotObsoleteCertARNs)
+		})
+	}
+}
@@ -16,12 +16,22 @@ import (
 	"time"
 )
 
+// ListenerRulePriority is the desired priority of a listener rule.
+type ListenerRulePriority struct {
+	RuleARN  string
+	Priority int64
+}
+
 // ListenerRuleManager is responsible for create/update/delete ListenerRule resources.
 type ListenerRuleManager interface {
-	Create(ctx context.Context, resLR *elbv2model.ListenerRule) (elbv2model.ListenerRuleStatus, error)
+	// Create creates the ListenerRule with specified priority, which may differ from its desired priority.
+	Create(ctx context.Context, resLR *elbv2model.ListenerRule, priority int64) (elbv2model.ListenerRuleStatus, error)
 
 	Update(ctx context.Context, resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) (elbv2model.ListenerRuleStatus, error)
 
+	// SetPriorities changes priorities of listener rules on one listener in a single batch.
+	SetPriorities(ctx context.Context, priorities []ListenerRulePriority) error
+
 	Delete(ctx context.Context, sdkLR ListenerRuleWithTags) error
 }
 
@@ -53,11 +63,12 @@ type defaultListenerRuleManager struct {
 	waitLSExistenceTimeout      time.Duration
 }
 
-func (m *defaultListenerRuleManager) Create(ctx context.Context, resLR *elbv2model.ListenerRule) (elbv2model.ListenerRuleStatus, error) {
+func (m *defaultListenerRuleManager) Create(ctx context.Context, resLR *elbv2model.ListenerRule, priority int64) (elbv2model.ListenerRuleStatus, error) {
 	req, err := buildSDKCreateListenerRuleInput(resLR.Spec, m.featureGates)
 	if err != nil {
 		return elbv2model.ListenerRuleStatus{}, err
 	}
+	req.Priority = awssdk.Int64(priority)
 	var ruleTags map[string]string
 	if m.featureGates.Enabled(config.ListenerRulesTagging) {
 		ruleTags = m.trackingProvider.ResourceTags(resLR.Stack(), resLR, resLR.Spec.Tags)
@@ -66,7 +77,8 @@ func (m *defaultListenerRuleManager) Create(ctx context.Context, resLR *elbv2mod
 
 	m.logger.Info("creating listener rule",
 		"stackID", resLR.Stack().StackID(),
-		"resourceID", resLR.ID())
+		"resourceID", resLR.ID(),
+		"priority", priority)
 	var sdkLR ListenerRuleWithTags
 	if err := runtime.RetryImmediateOnError(m.waitLSExistencePollInterval, m.waitLSExistenceTimeout, isListenerNotFoundError, func() error {
 		resp, err := m.elbv2Client.CreateRuleWithContext(ctx, req)
@@ -101,6 +113,24 @@ func (m *defaultListenerRuleManager) Update(ctx context.Context, resLR *elbv2mod
 	return buildResListenerRuleStatus(sdkLR), nil
 }
 
+func (m *defaultListenerRuleManager) SetPriorities(ctx context.Context, priorities []ListenerRulePriority) error {
+	req := &elbv2sdk.SetRulePrioritiesInput{}
+	for _, priority := range priorities {
+		req.RulePriorities = append(req.RulePriorities, &elbv2sdk.RulePriorityPair{
+			RuleArn:  awssdk.String(priority.RuleARN),
+			Priority: awssdk.Int64(priority.Priority),
+		})
+	}
+	m.logger.Info("setting listener rule priorities",
+		"priorities", priorities)
+	if _, err := m.elbv2Client.SetRulePrioritiesWithContext(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to set listener rule priorities")
+	}
+	m.logger.Info("set listener rule priorities",
+		"priorities", priorities)
+	return nil
+}
+
 func (m *defaultListenerRuleManager) Delete(ctx context.Context, sdkLR ListenerRuleWithTags) error {
 	req := &elbv2sdk.DeleteRuleInput{
 		RuleArn: sdkLR.ListenerRule.RuleArn,
@@ -4,13 +4,23 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2equality "sigs.k8s.io/aws-load-balancer-controller/pkg/equality/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sort"
 	"strconv"
 )
 
+const (
+	// listener rule priorities are within [1, 50000].
+	minListenerRulePriority = 1
+	maxListenerRulePriority = 50000
+)
+
 // NewListenerRuleSynthesizer constructs new listenerRuleSynthesizer.
 func NewListenerRuleSynthesizer(elbv2Client services.ELBV2, taggingManager TaggingManager,
 	lrManager ListenerRuleManager, logger logr.Logger, stack core.Stack) *listenerRuleSynthesizer {
@@ -65,26 +75,47 @@ func (s *listenerRuleSynthesizer) synthesizeListenerRulesOnListener(ctx context.
 	if err != nil {
 		return err
 	}
+	changeSet, err := buildListenerRuleChangeSet(resLRs, sdkLRs)
+	if err != nil {
+		return err
+	}
+	return s.applyListenerRuleChangeSet(ctx, changeSet)
+}
 
-	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs := matchResAndSDKListenerRules(resLRs, sdkLRs)
-	for _, sdkLR := range unmatchedSDKLRs {
-		if err := s.lrManager.Delete(ctx, sdkLR); err != nil {
+// applyListenerRuleChangeSet applies changes to listener rules on one listener in the order of
+// creates, modifies, a single priority renumbering and deletes.
+// rules are only deleted after rules replacing them are in place, and never conflict on priorities in between.
+func (s *listenerRuleSynthesizer) applyListenerRuleChangeSet(ctx context.Context, changeSet listenerRuleChangeSet) error {
+	priorityChanges := append([]ListenerRulePriority(nil), changeSet.priorityChanges...)
+	for _, create := range changeSet.creates {
+		lrStatus, err := s.lrManager.Create(ctx, create.resLR, create.priority)
+		if err != nil {
 			return err
 		}
+		create.resLR.SetStatus(lrStatus)
+		if create.priority != create.resLR.Spec.Priority {
+			priorityChanges = append(priorityChanges, ListenerRulePriority{
+				RuleARN:  lrStatus.RuleARN,
+				Priority: create.resLR.Spec.Priority,
+			})
+		}
 	}
-	for _, resLR := range unmatchedResLRs {
-		lrStatus, err := s.lrManager.Create(ctx, resLR)
+	for _, resAndSDKLR := range changeSet.modifies {
+		lrStatus, err := s.lrManager.Update(ctx, resAndSDKLR.resLR, resAndSDKLR.sdkLR)
 		if err != nil {
 			return err
 		}
-		resLR.SetStatus(lrStatus)
+		resAndSDKLR.resLR.SetStatus(lrStatus)
 	}
-	for _, resAndSDKLR := range matchedResAndSDKLRs {
-		lsStatus, err := s.lrManager.Update(ctx, resAndSDKLR.resLR, resAndSDKLR.sdkLR)
-		if err != nil {
+	if len(priorityChanges) != 0 {
+		if err := s.lrManager.SetPriorities(ctx, priorityChanges); err != nil {
+			return err
+		}
+	}
+	for _, sdkLR := range changeSet.deletes {
+		if err := s.lrManager.Delete(ctx, sdkLR); err != nil {
 			return err
 		}
-		resAndSDKLR.resLR.SetStatus(lsStatus)
 	}
 	return nil
 }
@@ -110,48 +141,149 @@ type resAndSDKListenerRulePair struct {
 	sdkLR ListenerRuleWithTags
 }
 
-func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []ListenerRuleWithTags) ([]resAndSDKListenerRulePair, []*elbv2model.ListenerRule, []ListenerRuleWithTags) {
-	var matchedResAndSDKLRs []resAndSDKListenerRulePair
-	var unmatchedResLRs []*elbv2model.ListenerRule
-	var unmatchedSDKLRs []ListenerRuleWithTags
-
-	resLRByPriority := mapResListenerRuleByPriority(resLRs)
-	sdkLRByPriority := mapSDKListenerRuleByPriority(sdkLRs)
-	resLRPriorities := sets.Int64KeySet(resLRByPriority)
-	sdkLRPriorities := sets.Int64KeySet(sdkLRByPriority)
-	for _, priority := range resLRPriorities.Intersection(sdkLRPriorities).List() {
-		resLR := resLRByPriority[priority]
-		sdkLR := sdkLRByPriority[priority]
-		matchedResAndSDKLRs = append(matchedResAndSDKLRs, resAndSDKListenerRulePair{
-			resLR: resLR,
-			sdkLR: sdkLR,
-		})
+// listenerRuleCreate is a listener rule to create, along with the priority to create it with.
+type listenerRuleCreate struct {
+	resLR *elbv2model.ListenerRule
+	// priority differs from the desired priority of resLR if it's occupied by an existing rule,
+	// in which case the rule is moved to its desired priority during priority renumbering.
+	priority int64
+}
+
+// listenerRuleChangeSet is the unit of work to reconcile listener rules on one listener.
+type listenerRuleChangeSet struct {
+	creates         []listenerRuleCreate
+	modifies        []resAndSDKListenerRulePair
+	priorityChanges []ListenerRulePriority
+	deletes         []ListenerRuleWithTags
+}
+
+// buildListenerRuleChangeSet computes the changes to reconcile existing listener rules on a listener to desired ones.
+// existing rules are matched with desired rules by conditions, so that a rule keeps serving its traffic
+// when its priority shifts because of rules added or removed before it.
+func buildListenerRuleChangeSet(resLRs []*elbv2model.ListenerRule, sdkLRs []ListenerRuleWithTags) (listenerRuleChangeSet, error) {
+	matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs := matchResAndSDKListenerRules(resLRs, sdkLRs)
+
+	occupiedPriorities := sets.NewInt64()
+	for _, sdkLR := range sdkLRs {
+		occupiedPriorities.Insert(sdkListenerRulePriority(sdkLR))
 	}
-	for _, priority := range resLRPriorities.Difference(sdkLRPriorities).List() {
-		unmatchedResLRs = append(unmatchedResLRs, resLRByPriority[priority])
+	desiredPriorities := sets.NewInt64()
+	for _, resLR := range resLRs {
+		desiredPriorities.Insert(resLR.Spec.Priority)
 	}
-	for _, priority := range sdkLRPriorities.Difference(resLRPriorities).List() {
-		unmatchedSDKLRs = append(unmatchedSDKLRs, sdkLRByPriority[priority])
+	allocateTemporaryPriority := func() (int64, error) {
+		for priority := int64(maxListenerRulePriority); priority >= minListenerRulePriority; priority-- {
+			if !occupiedPriorities.Has(priority) && !desiredPriorities.Has(priority) {
+				occupiedPriorities.Insert(priority)
+				return priority, nil
+			}
+		}
+		return 0, errors.New("no free listener rule priority available")
 	}
 
-	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs
+	changeSet := listenerRuleChangeSet{
+		modifies: matchedResAndSDKLRs,
+		deletes:  unmatchedSDKLRs,
+	}
+	for _, resLR := range unmatchedResLRs {
+		priority := resLR.Spec.Priority
+		if occupiedPriorities.Has(priority) {
+			temporaryPriority, err := allocateTemporaryPriority()
+			if err != nil {
+				return listenerRuleChangeSet{}, err
+			}
+			priority = temporaryPriority
+		}
+		occupiedPriorities.Insert(priority)
+		changeSet.creates = append(changeSet.creates, listenerRuleCreate{
+			resLR:    resLR,
+			priority: priority,
+		})
+	}
+	for _, resAndSDKLR := range matchedResAndSDKLRs {
+		if sdkListenerRulePriority(resAndSDKLR.sdkLR) != resAndSDKLR.resLR.Spec.Priority {
+			changeSet.priorityChanges = append(changeSet.priorityChanges, ListenerRulePriority{
+				RuleARN:  awssdk.StringValue(resAndSDKLR.sdkLR.ListenerRule.RuleArn),
+				Priority: resAndSDKLR.resLR.Spec.Priority,
+			})
+		}
+	}
+	// rules to delete are moved out of the way if their priority is desired by other rules.
+	for _, sdkLR := range unmatchedSDKLRs {
+		if desiredPriorities.Has(sdkListenerRulePriority(sdkLR)) {
+			temporaryPriority, err := allocateTemporaryPriority()
+			if err != nil {
+				return listenerRuleChangeSet{}, err
+			}
+			changeSet.priorityChanges = append(changeSet.priorityChanges, ListenerRulePriority{
+				RuleARN:  awssdk.StringValue(sdkLR.ListenerRule.RuleArn),
+				Priority: temporaryPriority,
+			})
+		}
+	}
+	return changeSet, nil
 }
 
-func mapResListenerRuleByPriority(resLRs []*elbv2model.ListenerRule) map[int64]*elbv2model.ListenerRule {
-	resLRByPriority := make(map[int64]*elbv2model.ListenerRule, len(resLRs))
-	for _, resLR := range resLRs {
-		resLRByPriority[resLR.Spec.Priority] = resLR
+// matchResAndSDKListenerRules matches desired and existing listener rules in three passes:
+// rules with same priority and conditions, rules with same conditions, and rules with same priority.
+func matchResAndSDKListenerRules(resLRs []*elbv2model.ListenerRule, sdkLRs []ListenerRuleWithTags) ([]resAndSDKListenerRulePair, []*elbv2model.ListenerRule, []ListenerRuleWithTags) {
+	unmatchedResLRs := append([]*elbv2model.ListenerRule(nil), resLRs...)
+	sort.Slice(unmatchedResLRs, func(i, j int) bool {
+		return unmatchedResLRs[i].Spec.Priority < unmatchedResLRs[j].Spec.Priority
+	})
+	unmatchedSDKLRs := append([]ListenerRuleWithTags(nil), sdkLRs...)
+	sort.Slice(unmatchedSDKLRs, func(i, j int) bool {
+		return sdkListenerRulePriority(unmatchedSDKLRs[i]) < sdkListenerRulePriority(unmatchedSDKLRs[j])
+	})
+
+	var matchedResAndSDKLRs []resAndSDKListenerRulePair
+	matchPass := func(matches func(resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) bool) {
+		var remainingResLRs []*elbv2model.ListenerRule
+		matchedSDKLRIndexes := sets.NewInt()
+		for _, resLR := range unmatchedResLRs {
+			matchedIndex := -1
+			for i, sdkLR := range unmatchedSDKLRs {
+				if !matchedSDKLRIndexes.Has(i) && matches(resLR, sdkLR) {
+					matchedIndex = i
+					break
+				}
+			}
+			if matchedIndex < 0 {
+				remainingResLRs = append(remainingResLRs, resLR)
+				continue
+			}
+			matchedSDKLRIndexes.Insert(matchedIndex)
+			matchedResAndSDKLRs = append(matchedResAndSDKLRs, resAndSDKListenerRulePair{
+				resLR: resLR,
+				sdkLR: unmatchedSDKLRs[matchedIndex],
+			})
+		}
+		var remainingSDKLRs []ListenerRuleWithTags
+		for i, sdkLR := range unmatchedSDKLRs {
+			if !matchedSDKLRIndexes.Has(i) {
+				remainingSDKLRs = append(remainingSDKLRs, sdkLR)
+			}
+		}
+		unmatchedResLRs = remainingResLRs
+		unmatchedSDKLRs = remainingSDKLRs
+	}
+	samePriority := func(resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) bool {
+		return resLR.Spec.Priority == sdkListenerRulePriority(sdkLR)
+	}
+	sameConditions := func(resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) bool {
+		return cmp.Equal(buildSDKRuleConditions(resLR.Spec.Conditions), sdkLR.ListenerRule.Conditions, elbv2equality.CompareOptionForRuleConditions())
 	}
-	return resLRByPriority
+	matchPass(func(resLR *elbv2model.ListenerRule, sdkLR ListenerRuleWithTags) bool {
+		return samePriority(resLR, sdkLR) && sameConditions(resLR, sdkLR)
+	})
+	matchPass(sameConditions)
+	matchPass(samePriority)
+	return matchedResAndSDKLRs, unmatchedResLRs, unmatchedSDKLRs
 }
 
-func mapSDKListenerRuleByPriority(sdkLRs []ListenerRuleWithTags) map[int64]ListenerRuleWithTags {
-	sdkLRByPriority := make(map[int64]ListenerRuleWithTags, len(sdkLRs))
-	for _, sdkLR := range sdkLRs {
-		priority, _ := strconv.ParseInt(awssdk.StringValue(sdkLR.ListenerRule.Priority), 10, 64)
-		sdkLRByPriority[priority] = sdkLR
-	}
-	return sdkLRByPriority
+func sdkListenerRulePriority(sdkLR ListenerRuleWithTags) int64 {
+	priority, _ := strconv.ParseInt(awssdk.StringValue(sdkLR.ListenerRule.Priority), 10, 64)
+	return priority
 }
 
 func mapResListenerRuleByListenerARN(resLRs []*elbv2model.ListenerRule) (map[string][]*elbv2model.ListenerRule, error) {
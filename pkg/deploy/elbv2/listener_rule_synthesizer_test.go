@@ -0,0 +1,170 @@
+package elbv2
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strconv"
+	"testing"
+)
+
+func Test_buildListenerRuleChangeSet(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	resLRWithPath := func(id string, priority int64, path string) *elbv2model.ListenerRule {
+		return &elbv2model.ListenerRule{
+			ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::ListenerRule", id),
+			Spec: elbv2model.ListenerRuleSpec{
+				Priority: priority,
+				Conditions: []elbv2model.RuleCondition{
+					{
+						Field: elbv2model.RuleConditionFieldPathPattern,
+						PathPatternConfig: &elbv2model.PathPatternConditionConfig{
+							Values: []string{path},
+						},
+					},
+				},
+			},
+		}
+	}
+	sdkLRWithPath := func(arn string, priority int64, path string) ListenerRuleWithTags {
+		return ListenerRuleWithTags{
+			ListenerRule: &elbv2sdk.Rule{
+				RuleArn:  awssdk.String(arn),
+				Priority: awssdk.String(strconv.FormatInt(priority, 10)),
+				Conditions: []*elbv2sdk.RuleCondition{
+					{
+						Field: awssdk.String("path-pattern"),
+						PathPatternConfig: &elbv2sdk.PathPatternConditionConfig{
+							Values: awssdk.StringSlice([]string{path}),
+						},
+						Values: awssdk.StringSlice([]string{path}),
+					},
+				},
+			},
+		}
+	}
+
+	resLRA1 := resLRWithPath("rule-a", 1, "/a")
+	resLRA2 := resLRWithPath("rule-a", 2, "/a")
+	resLRB1 := resLRWithPath("rule-b", 1, "/b")
+	resLRB2 := resLRWithPath("rule-b", 2, "/b")
+	resLRB3 := resLRWithPath("rule-b", 3, "/b")
+	resLRC1 := resLRWithPath("rule-c", 1, "/c")
+	resLRNew1 := resLRWithPath("rule-new", 1, "/new")
+	sdkLRA1 := sdkLRWithPath("arn-a", 1, "/a")
+	sdkLRB2 := sdkLRWithPath("arn-b", 2, "/b")
+
+	type args struct {
+		resLRs []*elbv2model.ListenerRule
+		sdkLRs []ListenerRuleWithTags
+	}
+	tests := []struct {
+		name string
+		args args
+		want listenerRuleChangeSet
+	}{
+		{
+			name: "rules unchanged",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRA1, resLRB2},
+				sdkLRs: []ListenerRuleWithTags{sdkLRA1, sdkLRB2},
+			},
+			want: listenerRuleChangeSet{
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRA1, sdkLR: sdkLRA1},
+					{resLR: resLRB2, sdkLR: sdkLRB2},
+				},
+			},
+		},
+		{
+			name: "rule inserted before existing rules",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRNew1, resLRA2, resLRB3},
+				sdkLRs: []ListenerRuleWithTags{sdkLRA1, sdkLRB2},
+			},
+			want: listenerRuleChangeSet{
+				creates: []listenerRuleCreate{
+					{resLR: resLRNew1, priority: 50000},
+				},
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRA2, sdkLR: sdkLRA1},
+					{resLR: resLRB3, sdkLR: sdkLRB2},
+				},
+				priorityChanges: []ListenerRulePriority{
+					{RuleARN: "arn-a", Priority: 2},
+					{RuleARN: "arn-b", Priority: 3},
+				},
+			},
+		},
+		{
+			name: "rule removed before existing rules",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRB1},
+				sdkLRs: []ListenerRuleWithTags{sdkLRA1, sdkLRB2},
+			},
+			want: listenerRuleChangeSet{
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRB1, sdkLR: sdkLRB2},
+				},
+				priorityChanges: []ListenerRulePriority{
+					{RuleARN: "arn-b", Priority: 1},
+					{RuleARN: "arn-a", Priority: 50000},
+				},
+				deletes: []ListenerRuleWithTags{sdkLRA1},
+			},
+		},
+		{
+			name: "rules swapped",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRB1, resLRA2},
+				sdkLRs: []ListenerRuleWithTags{sdkLRA1, sdkLRB2},
+			},
+			want: listenerRuleChangeSet{
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRB1, sdkLR: sdkLRB2},
+					{resLR: resLRA2, sdkLR: sdkLRA1},
+				},
+				priorityChanges: []ListenerRulePriority{
+					{RuleARN: "arn-b", Priority: 1},
+					{RuleARN: "arn-a", Priority: 2},
+				},
+			},
+		},
+		{
+			name: "rule conditions changed",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRC1},
+				sdkLRs: []ListenerRuleWithTags{sdkLRA1},
+			},
+			want: listenerRuleChangeSet{
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRC1, sdkLR: sdkLRA1},
+				},
+			},
+		},
+		{
+			name: "rule created at free priority",
+			args: args{
+				resLRs: []*elbv2model.ListenerRule{resLRNew1, resLRB2},
+				sdkLRs: []ListenerRuleWithTags{sdkLRB2},
+			},
+			want: listenerRuleChangeSet{
+				creates: []listenerRuleCreate{
+					{resLR: resLRNew1, priority: 1},
+				},
+				modifies: []resAndSDKListenerRulePair{
+					{resLR: resLRB2, sdkLR: sdkLRB2},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildListenerRuleChangeSet(tt.args.resLRs, tt.args.sdkLRs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
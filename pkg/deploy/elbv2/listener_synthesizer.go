@@ -4,28 +4,34 @@ import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+	"time"
 )
 
-func NewListenerSynthesizer(elbv2Client services.ELBV2, taggingManager TaggingManager,
+func NewListenerSynthesizer(elbv2Client services.ELBV2, trackingProvider tracking.Provider, taggingManager TaggingManager,
 	lsManager ListenerManager, logger logr.Logger, stack core.Stack) *listenerSynthesizer {
 	return &listenerSynthesizer{
-		elbv2Client:    elbv2Client,
-		lsManager:      lsManager,
-		logger:         logger,
-		taggingManager: taggingManager,
-		stack:          stack,
+		elbv2Client:      elbv2Client,
+		trackingProvider: trackingProvider,
+		lsManager:        lsManager,
+		logger:           logger,
+		taggingManager:   taggingManager,
+		stack:            stack,
 	}
 }
 
 type listenerSynthesizer struct {
-	elbv2Client    services.ELBV2
-	lsManager      ListenerManager
-	logger         logr.Logger
-	taggingManager TaggingManager
+	elbv2Client      services.ELBV2
+	trackingProvider tracking.Provider
+	lsManager        ListenerManager
+	logger           logr.Logger
+	taggingManager   TaggingManager
 
 	stack core.Stack
 }
@@ -38,10 +44,33 @@ func (s *listenerSynthesizer) Synthesize(ctx context.Context) error {
 		return err
 	}
 
+	var resLBs []*elbv2model.LoadBalancer
+	s.stack.ListResources(&resLBs)
+	existingLBARNs := sets.NewString()
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN == nil {
+			continue
+		}
+		// listeners on existing LoadBalancer are synthesized even if none is desired, so that ones created for stack are cleaned up.
+		lbARN := awssdk.StringValue(resLB.Spec.ExistingLoadBalancerARN)
+		existingLBARNs.Insert(lbARN)
+		if _, ok := resLSsByLBARN[lbARN]; !ok {
+			resLSsByLBARN[lbARN] = nil
+		}
+	}
+
+	var requeueAfter time.Duration
 	for lbARN, resLSs := range resLSsByLBARN {
-		if err := s.synthesizeListenersOnLB(ctx, lbARN, resLSs); err != nil {
+		lbRequeueAfter, err := s.synthesizeListenersOnLB(ctx, lbARN, resLSs, existingLBARNs.Has(lbARN))
+		if err != nil {
 			return err
 		}
+		if lbRequeueAfter > 0 && (requeueAfter == 0 || lbRequeueAfter < requeueAfter) {
+			requeueAfter = lbRequeueAfter
+		}
+	}
+	if requeueAfter > 0 {
+		return runtime.NewRequeueNeededAfter("remove replaced certificates from listeners", requeueAfter)
 	}
 	return nil
 }
@@ -51,32 +80,52 @@ func (s *listenerSynthesizer) PostSynthesize(ctx context.Context) error {
 	return nil
 }
 
-func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN string, resLSs []*elbv2model.Listener) error {
+// synthesizeListenersOnLB synthesizes listeners on LoadBalancer.
+// it returns the duration after which listeners need to be synthesized again, to remove certificates still in grace period.
+func (s *listenerSynthesizer) synthesizeListenersOnLB(ctx context.Context, lbARN string, resLSs []*elbv2model.Listener, isExistingLB bool) (time.Duration, error) {
 	sdkLSs, err := s.findSDKListenersOnLB(ctx, lbARN)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	matchedResAndSDKLSs, unmatchedResLSs, unmatchedSDKLSs := matchResAndSDKListeners(resLSs, sdkLSs)
+	stackTags := s.trackingProvider.StackTags(s.stack)
+	if isExistingLB {
+		// existing LoadBalancer is shared with others, thus we refuse to take over listeners that aren't created for stack.
+		if err := validateSDKListenersOwnedByStack(matchedResAndSDKLSs, stackTags); err != nil {
+			return 0, err
+		}
+	}
 	for _, sdkLS := range unmatchedSDKLSs {
+		// existing LoadBalancer is shared with others, thus we only delete listeners created for stack.
+		if isExistingLB && !isSDKListenerOwnedByStack(sdkLS, stackTags) {
+			continue
+		}
 		if err := s.lsManager.Delete(ctx, sdkLS); err != nil {
-			return err
+			return 0, err
 		}
 	}
 	for _, resLS := range unmatchedResLSs {
 		lsStatus, err := s.lsManager.Create(ctx, resLS)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		resLS.SetStatus(lsStatus)
 	}
+	var requeueAfter time.Duration
 	for _, resAndSDKLS := range matchedResAndSDKLSs {
 		lsStatus, err := s.lsManager.Update(ctx, resAndSDKLS.resLS, resAndSDKLS.sdkLS)
 		if err != nil {
-			return err
+			var requeueNeededAfter *runtime.RequeueNeededAfter
+			if !errors.As(err, &requeueNeededAfter) {
+				return 0, err
+			}
+			if requeueAfter == 0 || requeueNeededAfter.Duration() < requeueAfter {
+				requeueAfter = requeueNeededAfter.Duration()
+			}
 		}
 		resAndSDKLS.resLS.SetStatus(lsStatus)
 	}
-	return nil
+	return requeueAfter, nil
 }
 
 // findSDKListenersOnLB returns the listeners configured on LoadBalancer.
@@ -84,6 +133,27 @@ func (s *listenerSynthesizer) findSDKListenersOnLB(ctx context.Context, lbARN st
 	return s.taggingManager.ListListeners(ctx, lbARN)
 }
 
+// isSDKListenerOwnedByStack checks whether a sdk Listener is tagged as created for stack.
+func isSDKListenerOwnedByStack(sdkLS ListenerWithTags, stackTags map[string]string) bool {
+	for key, value := range stackTags {
+		if sdkLSValue, ok := sdkLS.Tags[key]; !ok || sdkLSValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSDKListenersOwnedByStack checks whether the sdk Listeners matched on an existing LoadBalancer are all tagged as created for stack.
+func validateSDKListenersOwnedByStack(matchedResAndSDKLSs []resAndSDKListenerPair, stackTags map[string]string) error {
+	for _, resAndSDKLS := range matchedResAndSDKLSs {
+		if !isSDKListenerOwnedByStack(resAndSDKLS.sdkLS, stackTags) {
+			return errors.Errorf("listener %v on port %v of existing LoadBalancer isn't created for stack, the port must be reserved for stack",
+				awssdk.StringValue(resAndSDKLS.sdkLS.Listener.ListenerArn), awssdk.Int64Value(resAndSDKLS.sdkLS.Listener.Port))
+		}
+	}
+	return nil
+}
+
 type resAndSDKListenerPair struct {
 	resLS *elbv2model.Listener
 	sdkLS ListenerWithTags
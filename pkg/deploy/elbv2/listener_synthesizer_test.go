@@ -0,0 +1,119 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_listenerSynthesizer_synthesizeListenersOnLB(t *testing.T) {
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Name: "awesome-group"})
+	resLS := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+		LoadBalancerARN: coremodel.LiteralStringToken("lb-arn"),
+		Port:            443,
+		Protocol:        elbv2model.ProtocolHTTPS,
+	})
+	tests := []struct {
+		name    string
+		sdkLSs  []ListenerWithTags
+		wantErr error
+	}{
+		{
+			name: "listener on same port isn't created for stack",
+			sdkLSs: []ListenerWithTags{
+				{
+					Listener: &elbv2sdk.Listener{ListenerArn: awssdk.String("ls-arn"), Port: awssdk.Int64(443)},
+					Tags: map[string]string{
+						"owner": "another-team",
+					},
+				},
+			},
+			wantErr: errors.New("listener ls-arn on port 443 of existing LoadBalancer isn't created for stack, the port must be reserved for stack"),
+		},
+		{
+			name: "listener on same port is created for another stack",
+			sdkLSs: []ListenerWithTags{
+				{
+					Listener: &elbv2sdk.Listener{ListenerArn: awssdk.String("ls-arn"), Port: awssdk.Int64(443)},
+					Tags: map[string]string{
+						"elbv2.k8s.aws/cluster": "cluster-name",
+						"ingress.k8s.aws/stack": "another-group",
+					},
+				},
+			},
+			wantErr: errors.New("listener ls-arn on port 443 of existing LoadBalancer isn't created for stack, the port must be reserved for stack"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taggingManager := NewMockTaggingManager(ctrl)
+			taggingManager.EXPECT().ListListeners(gomock.Any(), "lb-arn").Return(tt.sdkLSs, nil)
+			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+			// lsManager is left unset, as listeners on existing LoadBalancer must not be modified.
+			s := NewListenerSynthesizer(nil, trackingProvider, taggingManager, nil, &log.NullLogger{}, stack)
+			_, err := s.synthesizeListenersOnLB(context.Background(), "lb-arn", []*elbv2model.Listener{resLS}, true)
+			assert.EqualError(t, err, tt.wantErr.Error())
+		})
+	}
+}
+
+func Test_isSDKListenerOwnedByStack(t *testing.T) {
+	stackTags := map[string]string{
+		"elbv2.k8s.aws/cluster": "cluster-name",
+		"ingress.k8s.aws/stack": "awesome-group",
+	}
+	tests := []struct {
+		name  string
+		sdkLS ListenerWithTags
+		want  bool
+	}{
+		{
+			name: "listener created for stack",
+			sdkLS: ListenerWithTags{
+				Listener: &elbv2sdk.Listener{ListenerArn: awssdk.String("arn-1")},
+				Tags: map[string]string{
+					"elbv2.k8s.aws/cluster":    "cluster-name",
+					"ingress.k8s.aws/stack":    "awesome-group",
+					"ingress.k8s.aws/resource": "80",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "listener created for another stack",
+			sdkLS: ListenerWithTags{
+				Listener: &elbv2sdk.Listener{ListenerArn: awssdk.String("arn-1")},
+				Tags: map[string]string{
+					"elbv2.k8s.aws/cluster":    "cluster-name",
+					"ingress.k8s.aws/stack":    "another-group",
+					"ingress.k8s.aws/resource": "80",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "listener without tags",
+			sdkLS: ListenerWithTags{
+				Listener: &elbv2sdk.Listener{ListenerArn: awssdk.String("arn-1")},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isSDKListenerOwnedByStack(tt.sdkLS, stackTags)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,8 +14,13 @@ import (
 const (
 	defaultWaitLSExistencePollInterval = 2 * time.Second
 	defaultWaitLSExistenceTimeout      = 20 * time.Second
+	defaultWaitCertActivePollInterval  = 2 * time.Second
+	defaultWaitCertActiveTimeout       = 20 * time.Second
 )
 
+// errCertificatesNotActive indicates certificates added to listener are not listed as its certificates yet.
+var errCertificatesNotActive = errors.New("certificates not active on listener")
+
 func buildSDKActions(modelActions []elbv2model.Action, featureGates config.FeatureGates) ([]*elbv2sdk.Action, error) {
 	var sdkActions []*elbv2sdk.Action
 	if len(modelActions) != 0 {
@@ -225,3 +230,7 @@ func isListenerNotFoundError(err error) bool {
 	}
 	return false
 }
+
+func isCertificatesNotActiveError(err error) bool {
+	return errors.Is(err, errCertificatesNotActive)
+}
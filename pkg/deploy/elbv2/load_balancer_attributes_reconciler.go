@@ -2,9 +2,13 @@ package elbv2
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
@@ -18,10 +22,11 @@ type LoadBalancerAttributeReconciler interface {
 }
 
 // NewDefaultLoadBalancerAttributeReconciler constructs new defaultLoadBalancerAttributeReconciler.
-func NewDefaultLoadBalancerAttributeReconciler(elbv2Client services.ELBV2, logger logr.Logger) *defaultLoadBalancerAttributeReconciler {
+func NewDefaultLoadBalancerAttributeReconciler(elbv2Client services.ELBV2, accessLogsConfigurator AccessLogsConfigurator, logger logr.Logger) *defaultLoadBalancerAttributeReconciler {
 	return &defaultLoadBalancerAttributeReconciler{
-		elbv2Client: elbv2Client,
-		logger:      logger,
+		elbv2Client:            elbv2Client,
+		accessLogsConfigurator: accessLogsConfigurator,
+		logger:                 logger,
 	}
 }
 
@@ -29,8 +34,9 @@ var _ LoadBalancerAttributeReconciler = &defaultLoadBalancerAttributeReconciler{
 
 // default implementation for LoadBalancerAttributeReconciler
 type defaultLoadBalancerAttributeReconciler struct {
-	elbv2Client services.ELBV2
-	logger      logr.Logger
+	elbv2Client            services.ELBV2
+	accessLogsConfigurator AccessLogsConfigurator
+	logger                 logr.Logger
 }
 
 func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context, resLB *elbv2model.LoadBalancer, sdkLB LoadBalancerWithTags) error {
@@ -42,6 +48,20 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 
 	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
 	if len(attributesToUpdate) > 0 {
+		// only buckets of logs being changed are hinted upon failures, as unchanged logs settings can't be the cause.
+		var changedLogsBuckets []string
+		for _, logsAttrKeys := range lbLogsS3AttributeKeysList {
+			logsBucket, logsEnabled := getEnabledLogsBucket(desiredAttrs, logsAttrKeys)
+			if !logsEnabled || !isLogsAttributesChanged(attributesToUpdate, logsAttrKeys) {
+				continue
+			}
+			changedLogsBuckets = append(changedLogsBuckets, fmt.Sprintf("%v S3 bucket %v", logsAttrKeys.logsType, logsBucket))
+			if r.accessLogsConfigurator != nil {
+				if err := r.accessLogsConfigurator.Configure(ctx, logsBucket, desiredAttrs[logsAttrKeys.prefix]); err != nil {
+					return err
+				}
+			}
+		}
 		req := &elbv2sdk.ModifyLoadBalancerAttributesInput{
 			LoadBalancerArn: sdkLB.LoadBalancer.LoadBalancerArn,
 			Attributes:      nil,
@@ -59,7 +79,16 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 			"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
 			"change", attributesToUpdate)
 		if _, err := r.elbv2Client.ModifyLoadBalancerAttributesWithContext(ctx, req); err != nil {
-			return err
+			switch len(changedLogsBuckets) {
+			case 0:
+				return err
+			case 1:
+				return errors.Wrapf(err, "failed to modify loadBalancer attributes, make sure %v exists in the same region "+
+					"and its bucket policy allows log delivery from Elastic Load Balancing", changedLogsBuckets[0])
+			default:
+				return errors.Wrapf(err, "failed to modify loadBalancer attributes, make sure %v exist in the same region "+
+					"and their bucket policies allow log delivery from Elastic Load Balancing", strings.Join(changedLogsBuckets, " and "))
+			}
 		}
 		r.logger.Info("modified loadBalancer attributes",
 			"stackID", resLB.Stack().StackID(),
@@ -69,6 +98,49 @@ func (r *defaultLoadBalancerAttributeReconciler) Reconcile(ctx context.Context,
 	return nil
 }
 
+// lbLogsS3AttributeKeys are the attribute keys that configure delivery of a type of load balancer logs to S3.
+type lbLogsS3AttributeKeys struct {
+	logsType string
+	enabled  string
+	bucket   string
+	prefix   string
+}
+
+// lbLogsS3AttributeKeysList contains the attribute keys for each type of load balancer logs delivered to S3.
+// both access logs and connection logs are delivered by Elastic Load Balancing under the same AWSLogs path.
+var lbLogsS3AttributeKeysList = []lbLogsS3AttributeKeys{
+	{
+		logsType: "access logs",
+		enabled:  LBAttrsAccessLogsS3Enabled,
+		bucket:   LBAttrsAccessLogsS3Bucket,
+		prefix:   LBAttrsAccessLogsS3Prefix,
+	},
+	{
+		logsType: "connection logs",
+		enabled:  LBAttrsConnectionLogsS3Enabled,
+		bucket:   LBAttrsConnectionLogsS3Bucket,
+		prefix:   LBAttrsConnectionLogsS3Prefix,
+	},
+}
+
+// getEnabledLogsBucket returns the S3 bucket for a type of logs if they are enabled.
+func getEnabledLogsBucket(lbAttributes map[string]string, logsAttrKeys lbLogsS3AttributeKeys) (string, bool) {
+	if lbAttributes[logsAttrKeys.enabled] != "true" {
+		return "", false
+	}
+	return lbAttributes[logsAttrKeys.bucket], true
+}
+
+// isLogsAttributesChanged checks whether any attributes of a type of logs are changed.
+func isLogsAttributesChanged(attributesToUpdate map[string]string, logsAttrKeys lbLogsS3AttributeKeys) bool {
+	for _, attrKey := range []string{logsAttrKeys.enabled, logsAttrKeys.bucket, logsAttrKeys.prefix} {
+		if _, ok := attributesToUpdate[attrKey]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *defaultLoadBalancerAttributeReconciler) getDesiredLoadBalancerAttributes(ctx context.Context, resLB *elbv2model.LoadBalancer) map[string]string {
 	lbAttributes := make(map[string]string, len(resLB.Spec.LoadBalancerAttributes))
 	for _, attr := range resLB.Spec.LoadBalancerAttributes {
@@ -27,9 +27,16 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 		err  error
 	}
 
+	type configureAccessLogsCall struct {
+		bucket string
+		prefix string
+		err    error
+	}
+
 	type fields struct {
 		describeLoadBalancerAttributesWithContextCalls []describeLoadBalancerAttributesWithContextCall
 		modifyLoadBalancerAttributesWithContextCalls   []modifyLoadBalancerAttributesWithContextCall
+		configureAccessLogsCalls                       []configureAccessLogsCall
 	}
 	type args struct {
 		sdkLB LoadBalancerWithTags
@@ -149,6 +156,368 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 				},
 			},
 		},
+		{
+			name: "access logs should be configured before enabled",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				configureAccessLogsCalls: []configureAccessLogsCall{
+					{
+						bucket: "my-bucket",
+						prefix: "my-prefix",
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.bucket"),
+									Value: awssdk.String("my-bucket"),
+								},
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("access_logs.s3.prefix"),
+									Value: awssdk.String("my-prefix"),
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "access_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "access_logs.s3.bucket",
+								Value: "my-bucket",
+							},
+							{
+								Key:   "access_logs.s3.prefix",
+								Value: "my-prefix",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "access logs configuration failed",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				configureAccessLogsCalls: []configureAccessLogsCall{
+					{
+						bucket: "my-bucket",
+						err:    errors.New("access logs S3 bucket my-bucket doesn't exist"),
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "access_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "access_logs.s3.bucket",
+								Value: "my-bucket",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("access logs S3 bucket my-bucket doesn't exist"),
+		},
+		{
+			name: "failed to enable access logs",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("false"),
+								},
+							},
+						},
+					},
+				},
+				configureAccessLogsCalls: []configureAccessLogsCall{
+					{
+						bucket: "my-bucket",
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.bucket"),
+									Value: awssdk.String("my-bucket"),
+								},
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("true"),
+								},
+							},
+						},
+						err: errors.New("InvalidConfigurationRequest: Access Denied for bucket: my-bucket"),
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "access_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "access_logs.s3.bucket",
+								Value: "my-bucket",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to modify loadBalancer attributes, make sure access logs S3 bucket my-bucket exists in the same region " +
+				"and its bucket policy allows log delivery from Elastic Load Balancing: InvalidConfigurationRequest: Access Denied for bucket: my-bucket"),
+		},
+		{
+			name: "connection logs should be configured before enabled, along with unchanged access logs",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("access_logs.s3.bucket"),
+									Value: awssdk.String("my-bucket"),
+								},
+								{
+									Key:   awssdk.String("connection_logs.s3.enabled"),
+									Value: awssdk.String("false"),
+								},
+								{
+									Key:   awssdk.String("client_keep_alive.seconds"),
+									Value: awssdk.String("3600"),
+								},
+							},
+						},
+					},
+				},
+				configureAccessLogsCalls: []configureAccessLogsCall{
+					{
+						bucket: "my-connection-logs-bucket",
+						prefix: "my-app",
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("client_keep_alive.seconds"),
+									Value: awssdk.String("600"),
+								},
+								{
+									Key:   awssdk.String("connection_logs.s3.bucket"),
+									Value: awssdk.String("my-connection-logs-bucket"),
+								},
+								{
+									Key:   awssdk.String("connection_logs.s3.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("connection_logs.s3.prefix"),
+									Value: awssdk.String("my-app"),
+								},
+							},
+						},
+						err: errors.New("InvalidConfigurationRequest: Access Denied for bucket: my-connection-logs-bucket"),
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "access_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "access_logs.s3.bucket",
+								Value: "my-bucket",
+							},
+							{
+								Key:   "connection_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "connection_logs.s3.bucket",
+								Value: "my-connection-logs-bucket",
+							},
+							{
+								Key:   "connection_logs.s3.prefix",
+								Value: "my-app",
+							},
+							{
+								Key:   "client_keep_alive.seconds",
+								Value: "600",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to modify loadBalancer attributes, make sure connection logs S3 bucket my-connection-logs-bucket exists in the same region " +
+				"and its bucket policy allows log delivery from Elastic Load Balancing: InvalidConfigurationRequest: Access Denied for bucket: my-connection-logs-bucket"),
+		},
+		{
+			name: "failed to modify attributes other than unchanged access logs",
+			fields: fields{
+				describeLoadBalancerAttributesWithContextCalls: []describeLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.DescribeLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+						},
+						resp: &elbv2sdk.DescribeLoadBalancerAttributesOutput{
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("access_logs.s3.enabled"),
+									Value: awssdk.String("true"),
+								},
+								{
+									Key:   awssdk.String("access_logs.s3.bucket"),
+									Value: awssdk.String("my-bucket"),
+								},
+								{
+									Key:   awssdk.String("idle_timeout.timeout_seconds"),
+									Value: awssdk.String("60"),
+								},
+							},
+						},
+					},
+				},
+				modifyLoadBalancerAttributesWithContextCalls: []modifyLoadBalancerAttributesWithContextCall{
+					{
+						req: &elbv2sdk.ModifyLoadBalancerAttributesInput{
+							LoadBalancerArn: awssdk.String("my-arn"),
+							Attributes: []*elbv2sdk.LoadBalancerAttribute{
+								{
+									Key:   awssdk.String("idle_timeout.timeout_seconds"),
+									Value: awssdk.String("120"),
+								},
+							},
+						},
+						err: errors.New("Throttling: Rate exceeded"),
+					},
+				},
+			},
+			args: args{
+				sdkLB: LoadBalancerWithTags{
+					LoadBalancer: &elbv2sdk.LoadBalancer{
+						LoadBalancerArn: awssdk.String("my-arn"),
+					},
+				},
+				resLB: &elbv2model.LoadBalancer{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "id-1"),
+					Spec: elbv2model.LoadBalancerSpec{
+						LoadBalancerAttributes: []elbv2model.LoadBalancerAttribute{
+							{
+								Key:   "access_logs.s3.enabled",
+								Value: "true",
+							},
+							{
+								Key:   "access_logs.s3.bucket",
+								Value: "my-bucket",
+							},
+							{
+								Key:   "idle_timeout.timeout_seconds",
+								Value: "120",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("Throttling: Rate exceeded"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -161,9 +530,14 @@ func Test_defaultLoadBalancerAttributeReconciler_updateSDKLoadBalancerWithAttrib
 			for _, call := range tt.fields.modifyLoadBalancerAttributesWithContextCalls {
 				elbv2Client.EXPECT().ModifyLoadBalancerAttributesWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
 			}
+			accessLogsConfigurator := NewMockAccessLogsConfigurator(ctrl)
+			for _, call := range tt.fields.configureAccessLogsCalls {
+				accessLogsConfigurator.EXPECT().Configure(gomock.Any(), call.bucket, call.prefix).Return(call.err)
+			}
 			r := &defaultLoadBalancerAttributeReconciler{
-				elbv2Client: elbv2Client,
-				logger:      &log.NullLogger{},
+				elbv2Client:            elbv2Client,
+				accessLogsConfigurator: accessLogsConfigurator,
+				logger:                 &log.NullLogger{},
 			}
 			err := r.Reconcile(context.Background(), tt.args.resLB, tt.args.sdkLB)
 			if tt.wantErr != nil {
@@ -24,12 +24,12 @@ type LoadBalancerManager interface {
 
 // NewDefaultLoadBalancerManager constructs new defaultLoadBalancerManager.
 func NewDefaultLoadBalancerManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
-	taggingManager TaggingManager, externalManagedTags []string, logger logr.Logger) *defaultLoadBalancerManager {
+	taggingManager TaggingManager, accessLogsConfigurator AccessLogsConfigurator, externalManagedTags []string, logger logr.Logger) *defaultLoadBalancerManager {
 	return &defaultLoadBalancerManager{
 		elbv2Client:          elbv2Client,
 		trackingProvider:     trackingProvider,
 		taggingManager:       taggingManager,
-		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, logger),
+		attributesReconciler: NewDefaultLoadBalancerAttributeReconciler(elbv2Client, accessLogsConfigurator, logger),
 		externalManagedTags:  externalManagedTags,
 		logger:               logger,
 	}
@@ -293,7 +293,8 @@ func buildSDKSubnetMapping(modelSubnetMapping elbv2model.SubnetMapping) *elbv2sd
 
 func buildResLoadBalancerStatus(sdkLB LoadBalancerWithTags) elbv2model.LoadBalancerStatus {
 	return elbv2model.LoadBalancerStatus{
-		LoadBalancerARN: awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
-		DNSName:         awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		LoadBalancerARN:       awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+		DNSName:               awssdk.StringValue(sdkLB.LoadBalancer.DNSName),
+		CanonicalHostedZoneID: awssdk.StringValue(sdkLB.LoadBalancer.CanonicalHostedZoneId),
 	}
 }
@@ -288,14 +288,16 @@ func Test_buildResLoadBalancerStatus(t *testing.T) {
 			args: args{
 				sdkLB: LoadBalancerWithTags{
 					LoadBalancer: &elbv2sdk.LoadBalancer{
-						LoadBalancerArn: awssdk.String("my-arn"),
-						DNSName:         awssdk.String("www.example.com"),
+						LoadBalancerArn:       awssdk.String("my-arn"),
+						DNSName:               awssdk.String("www.example.com"),
+						CanonicalHostedZoneId: awssdk.String("Z1H1FL5HABSF5"),
 					},
 				},
 			},
 			want: elbv2model.LoadBalancerStatus{
-				LoadBalancerARN: "my-arn",
-				DNSName:         "www.example.com",
+				LoadBalancerARN:       "my-arn",
+				DNSName:               "www.example.com",
+				CanonicalHostedZoneID: "Z1H1FL5HABSF5",
 			},
 		},
 	}
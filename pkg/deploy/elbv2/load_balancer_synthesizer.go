@@ -3,6 +3,7 @@ package elbv2
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
@@ -46,6 +47,15 @@ type loadBalancerSynthesizer struct {
 func (s *loadBalancerSynthesizer) Synthesize(ctx context.Context) error {
 	var resLBs []*elbv2model.LoadBalancer
 	s.stack.ListResources(&resLBs)
+	resLBs, resExistingLBs := partitionResExistingLoadBalancers(resLBs)
+	// existing LoadBalancers are managed outside of the controller, we only resolve their status.
+	for _, resLB := range resExistingLBs {
+		lbStatus, err := s.resolveExistingLoadBalancerStatus(ctx, resLB)
+		if err != nil {
+			return err
+		}
+		resLB.SetStatus(lbStatus)
+	}
 	sdkLBs, err := s.findSDKLoadBalancers(ctx)
 	if err != nil {
 		return err
@@ -108,6 +118,32 @@ func (s *loadBalancerSynthesizer) PostSynthesize(ctx context.Context) error {
 	return nil
 }
 
+// resolveExistingLoadBalancerStatus resolves the status of an existing LoadBalancer that's managed outside of the controller.
+func (s *loadBalancerSynthesizer) resolveExistingLoadBalancerStatus(ctx context.Context, resLB *elbv2model.LoadBalancer) (elbv2model.LoadBalancerStatus, error) {
+	lbARN := awssdk.StringValue(resLB.Spec.ExistingLoadBalancerARN)
+	req := &elbv2sdk.DescribeLoadBalancersInput{
+		LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+	}
+	sdkLBs, err := s.elbv2Client.DescribeLoadBalancersAsList(ctx, req)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2sdk.ErrCodeLoadBalancerNotFoundException {
+			return elbv2model.LoadBalancerStatus{}, errors.Errorf("existing loadBalancer not found: %v", lbARN)
+		}
+		return elbv2model.LoadBalancerStatus{}, err
+	}
+	if len(sdkLBs) == 0 {
+		return elbv2model.LoadBalancerStatus{}, errors.Errorf("existing loadBalancer not found: %v", lbARN)
+	}
+	if lbType := awssdk.StringValue(sdkLBs[0].Type); lbType != string(resLB.Spec.Type) {
+		return elbv2model.LoadBalancerStatus{}, errors.Errorf("existing loadBalancer %v must be of type %v, got %v", lbARN, resLB.Spec.Type, lbType)
+	}
+	return elbv2model.LoadBalancerStatus{
+		LoadBalancerARN:       lbARN,
+		DNSName:               awssdk.StringValue(sdkLBs[0].DNSName),
+		CanonicalHostedZoneID: awssdk.StringValue(sdkLBs[0].CanonicalHostedZoneId),
+	}, nil
+}
+
 // findSDKLoadBalancers will find all AWS LoadBalancer created for stack.
 func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]LoadBalancerWithTags, error) {
 	stackTags := s.trackingProvider.StackTags(s.stack)
@@ -117,6 +153,20 @@ func (s *loadBalancerSynthesizer) findSDKLoadBalancers(ctx context.Context) ([]L
 		tracking.TagsAsTagFilter(stackTagsLegacy))
 }
 
+// partitionResExistingLoadBalancers partitions LoadBalancer resources into ones managed by controller and existing ones managed outside of the controller.
+func partitionResExistingLoadBalancers(resLBs []*elbv2model.LoadBalancer) ([]*elbv2model.LoadBalancer, []*elbv2model.LoadBalancer) {
+	var resManagedLBs []*elbv2model.LoadBalancer
+	var resExistingLBs []*elbv2model.LoadBalancer
+	for _, resLB := range resLBs {
+		if resLB.Spec.ExistingLoadBalancerARN != nil {
+			resExistingLBs = append(resExistingLBs, resLB)
+		} else {
+			resManagedLBs = append(resManagedLBs, resLB)
+		}
+	}
+	return resManagedLBs, resExistingLBs
+}
+
 type resAndSDKLoadBalancerPair struct {
 	resLB *elbv2model.LoadBalancer
 	sdkLB LoadBalancerWithTags
@@ -1,10 +1,14 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"testing"
@@ -594,3 +598,85 @@ func Test_isSDKLoadBalancerRequiresReplacement(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadBalancerSynthesizer_resolveExistingLoadBalancerStatus(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/shared-alb/50dc6c495c0c9188"
+	type describeLoadBalancersAsListCall struct {
+		resp []*elbv2sdk.LoadBalancer
+		err  error
+	}
+	tests := []struct {
+		name                            string
+		describeLoadBalancersAsListCall describeLoadBalancersAsListCall
+		want                            elbv2model.LoadBalancerStatus
+		wantErr                         error
+	}{
+		{
+			name: "existing loadBalancer found",
+			describeLoadBalancersAsListCall: describeLoadBalancersAsListCall{
+				resp: []*elbv2sdk.LoadBalancer{
+					{
+						LoadBalancerArn:       awssdk.String(lbARN),
+						DNSName:               awssdk.String("shared-alb-1234567890.us-west-2.elb.amazonaws.com"),
+						CanonicalHostedZoneId: awssdk.String("Z1H1FL5HABSF5"),
+						Type:                  awssdk.String("application"),
+					},
+				},
+			},
+			want: elbv2model.LoadBalancerStatus{
+				LoadBalancerARN:       lbARN,
+				DNSName:               "shared-alb-1234567890.us-west-2.elb.amazonaws.com",
+				CanonicalHostedZoneID: "Z1H1FL5HABSF5",
+			},
+		},
+		{
+			name: "existing loadBalancer not found",
+			describeLoadBalancersAsListCall: describeLoadBalancersAsListCall{
+				err: awserr.New(elbv2sdk.ErrCodeLoadBalancerNotFoundException, "", nil),
+			},
+			wantErr: errors.New("existing loadBalancer not found: " + lbARN),
+		},
+		{
+			name: "existing loadBalancer isn't an application loadBalancer",
+			describeLoadBalancersAsListCall: describeLoadBalancersAsListCall{
+				resp: []*elbv2sdk.LoadBalancer{
+					{
+						LoadBalancerArn: awssdk.String(lbARN),
+						Type:            awssdk.String("network"),
+					},
+				},
+			},
+			wantErr: errors.New("existing loadBalancer " + lbARN + " must be of type application, got network"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeLoadBalancersAsList(gomock.Any(), &elbv2sdk.DescribeLoadBalancersInput{
+				LoadBalancerArns: awssdk.StringSlice([]string{lbARN}),
+			}).Return(tt.describeLoadBalancersAsListCall.resp, tt.describeLoadBalancersAsListCall.err)
+
+			stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+			resLB := &elbv2model.LoadBalancer{
+				ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::LoadBalancer", "LoadBalancer"),
+				Spec: elbv2model.LoadBalancerSpec{
+					ExistingLoadBalancerARN: awssdk.String(lbARN),
+					Type:                    elbv2model.LoadBalancerTypeApplication,
+				},
+			}
+			s := &loadBalancerSynthesizer{
+				elbv2Client: elbv2Client,
+				stack:       stack,
+			}
+			got, err := s.resolveExistingLoadBalancerStatus(context.Background(), resLB)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -11,6 +11,15 @@ import (
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
+const (
+	TGAttrsLoadBalancingAlgorithmType     = "load_balancing.algorithm.type"
+	TGAttrsLoadBalancingAnomalyMitigation = "load_balancing.algorithm.anomaly_mitigation"
+
+	LoadBalancingAlgorithmWeightedRandom = "weighted_random"
+	AnomalyMitigationOn                  = "on"
+	AnomalyMitigationOff                 = "off"
+)
+
 // reconciler for TargetGroup attributes
 type TargetGroupAttributesReconciler interface {
 	// Reconcile TargetGroup attributes
@@ -41,6 +50,7 @@ func (r *defaultTargetGroupAttributeReconciler) Reconcile(ctx context.Context, r
 	}
 
 	attributesToUpdate, _ := algorithm.DiffStringMap(desiredAttrs, currentAttrs)
+	attributesToUpdate = r.amendAnomalyMitigationAttributes(desiredAttrs, currentAttrs, attributesToUpdate)
 	if len(attributesToUpdate) > 0 {
 		req := &elbv2sdk.ModifyTargetGroupAttributesInput{
 			TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
@@ -69,6 +79,23 @@ func (r *defaultTargetGroupAttributeReconciler) Reconcile(ctx context.Context, r
 	return nil
 }
 
+// amendAnomalyMitigationAttributes amends attributesToUpdate, since ELBV2 validates anomaly mitigation against the load balancing algorithm.
+//   - anomaly mitigation is modified together with the load balancing algorithm, so that weighted_random is in effect when anomaly mitigation is turned on.
+//   - anomaly mitigation is turned off when load balancing algorithm is changed from weighted_random, unless it's explicitly desired.
+func (r *defaultTargetGroupAttributeReconciler) amendAnomalyMitigationAttributes(desiredAttrs map[string]string,
+	currentAttrs map[string]string, attributesToUpdate map[string]string) map[string]string {
+	desiredAlgorithm, algorithmDesired := desiredAttrs[TGAttrsLoadBalancingAlgorithmType]
+	if _, anomalyMitigationChanged := attributesToUpdate[TGAttrsLoadBalancingAnomalyMitigation]; anomalyMitigationChanged && algorithmDesired {
+		attributesToUpdate[TGAttrsLoadBalancingAlgorithmType] = desiredAlgorithm
+	}
+	_, anomalyMitigationDesired := desiredAttrs[TGAttrsLoadBalancingAnomalyMitigation]
+	if algorithmDesired && desiredAlgorithm != LoadBalancingAlgorithmWeightedRandom && !anomalyMitigationDesired &&
+		currentAttrs[TGAttrsLoadBalancingAnomalyMitigation] == AnomalyMitigationOn {
+		attributesToUpdate[TGAttrsLoadBalancingAnomalyMitigation] = AnomalyMitigationOff
+	}
+	return attributesToUpdate
+}
+
 func (r *defaultTargetGroupAttributeReconciler) getDesiredTargetGroupAttributes(ctx context.Context, resTG *elbv2model.TargetGroup) map[string]string {
 	tgAttributes := make(map[string]string, len(resTG.Spec.TargetGroupAttributes))
 	for _, attr := range resTG.Spec.TargetGroupAttributes {
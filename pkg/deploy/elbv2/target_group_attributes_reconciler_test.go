@@ -328,3 +328,92 @@ func Test_defaultTargetGroupAttributeReconciler_getCurrentTargetGroupAttributes(
 		})
 	}
 }
+
+func Test_defaultTargetGroupAttributeReconciler_amendAnomalyMitigationAttributes(t *testing.T) {
+	tests := []struct {
+		name               string
+		desiredAttrs       map[string]string
+		currentAttrs       map[string]string
+		attributesToUpdate map[string]string
+		want               map[string]string
+	}{
+		{
+			name: "anomaly mitigation turned on together with weighted_random",
+			desiredAttrs: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			currentAttrs: map[string]string{
+				"load_balancing.algorithm.type":               "round_robin",
+				"load_balancing.algorithm.anomaly_mitigation": "off",
+			},
+			attributesToUpdate: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			want: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+		},
+		{
+			name: "anomaly mitigation turned on with weighted_random already in effect",
+			desiredAttrs: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			currentAttrs: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "off",
+			},
+			attributesToUpdate: map[string]string{
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			want: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+		},
+		{
+			name: "load balancing algorithm changed from weighted_random with anomaly mitigation on",
+			desiredAttrs: map[string]string{
+				"load_balancing.algorithm.type": "round_robin",
+			},
+			currentAttrs: map[string]string{
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			attributesToUpdate: map[string]string{
+				"load_balancing.algorithm.type": "round_robin",
+			},
+			want: map[string]string{
+				"load_balancing.algorithm.type":               "round_robin",
+				"load_balancing.algorithm.anomaly_mitigation": "off",
+			},
+		},
+		{
+			name: "load balancing algorithm isn't desired",
+			desiredAttrs: map[string]string{
+				"slow_start.duration_seconds": "30",
+			},
+			currentAttrs: map[string]string{
+				"slow_start.duration_seconds":                 "0",
+				"load_balancing.algorithm.type":               "weighted_random",
+				"load_balancing.algorithm.anomaly_mitigation": "on",
+			},
+			attributesToUpdate: map[string]string{
+				"slow_start.duration_seconds": "30",
+			},
+			want: map[string]string{
+				"slow_start.duration_seconds": "30",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &defaultTargetGroupAttributeReconciler{}
+			got := r.amendAnomalyMitigationAttributes(tt.desiredAttrs, tt.currentAttrs, tt.attributesToUpdate)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
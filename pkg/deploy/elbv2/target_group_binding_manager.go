@@ -71,9 +71,10 @@ func (m *defaultTargetGroupBindingManager) Create(ctx context.Context, resTGB *e
 	stackLabels := m.trackingProvider.StackLabels(resTGB.Stack())
 	k8sTGB := &elbv2api.TargetGroupBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: resTGB.Spec.Template.Namespace,
-			Name:      resTGB.Spec.Template.Name,
-			Labels:    stackLabels,
+			Namespace:   resTGB.Spec.Template.Namespace,
+			Name:        resTGB.Spec.Template.Name,
+			Labels:      stackLabels,
+			Annotations: resTGB.Spec.Template.Annotations,
 		},
 		Spec: k8sTGBSpec,
 	}
@@ -96,12 +97,22 @@ func (m *defaultTargetGroupBindingManager) Update(ctx context.Context, resTGB *e
 	if err != nil {
 		return elbv2model.TargetGroupBindingResourceStatus{}, err
 	}
-	if equality.Semantic.DeepEqual(k8sTGB.Spec, k8sTGBSpec) {
+	annotationsChanged := !isAnnotationsSubset(resTGB.Spec.Template.Annotations, k8sTGB.Annotations)
+	if !annotationsChanged && equality.Semantic.DeepEqual(k8sTGB.Spec, k8sTGBSpec) {
 		return buildResTargetGroupBindingStatus(k8sTGB), nil
 	}
 
 	oldK8sTGB := k8sTGB.DeepCopy()
 	k8sTGB.Spec = k8sTGBSpec
+	// annotations in template are added to the TargetGroupBinding, while annotations set by others are left alone.
+	if annotationsChanged {
+		if k8sTGB.Annotations == nil {
+			k8sTGB.Annotations = make(map[string]string, len(resTGB.Spec.Template.Annotations))
+		}
+		for key, value := range resTGB.Spec.Template.Annotations {
+			k8sTGB.Annotations[key] = value
+		}
+	}
 	m.logger.Info("modifying targetGroupBinding",
 		"stackID", resTGB.Stack().StackID(),
 		"resourceID", resTGB.ID(),
@@ -187,6 +198,7 @@ func buildK8sTargetGroupBindingSpec(ctx context.Context, resTGB *elbv2model.Targ
 	}
 	k8sTGBSpec.NodeSelector = resTGB.Spec.Template.Spec.NodeSelector
 	k8sTGBSpec.IPAddressType = resTGB.Spec.Template.Spec.IPAddressType
+	k8sTGBSpec.ExternalTargets = resTGB.Spec.Template.Spec.ExternalTargets
 	return k8sTGBSpec, nil
 }
 
@@ -240,3 +252,13 @@ func buildResTargetGroupBindingStatus(k8sTGB *elbv2api.TargetGroupBinding) elbv2
 		},
 	}
 }
+
+// isAnnotationsSubset checks whether all annotations in desired exist in actual with the same value.
+func isAnnotationsSubset(desired map[string]string, actual map[string]string) bool {
+	for key, value := range desired {
+		if actualValue, exists := actual[key]; !exists || actualValue != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package elbv2
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultTargetGroupBindingManager_Update(t *testing.T) {
+	targetTypeIP := elbv2api.TargetTypeIP
+	tests := []struct {
+		name                string
+		templateAnnotations map[string]string
+		k8sTGBAnnotations   map[string]string
+		wantAnnotations     map[string]string
+	}{
+		{
+			name:                "annotations in template are added",
+			templateAnnotations: map[string]string{"elbv2.k8s.aws/canary-rollback-reason": "unhealthy"},
+			k8sTGBAnnotations:   map[string]string{"some-key": "some-value"},
+			wantAnnotations: map[string]string{
+				"elbv2.k8s.aws/canary-rollback-reason": "unhealthy",
+				"some-key":                             "some-value",
+			},
+		},
+		{
+			name:                "annotations not in template are left alone",
+			templateAnnotations: nil,
+			k8sTGBAnnotations:   map[string]string{"elbv2.k8s.aws/canary-rollback-reason": "unhealthy"},
+			wantAnnotations:     map[string]string{"elbv2.k8s.aws/canary-rollback-reason": "unhealthy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			k8sTGB := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-ns",
+					Name:        "my-tgb",
+					Annotations: tt.k8sTGBAnnotations,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: "my-tg-arn",
+					TargetType:     &targetTypeIP,
+				},
+				Status: elbv2api.TargetGroupBindingStatus{
+					ObservedGeneration: awssdk.Int64(1),
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, k8sTGB))
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "my-ns", Name: "my-ing"})
+			resTGB := elbv2model.NewTargetGroupBindingResource(stack, "my-tg", elbv2model.TargetGroupBindingResourceSpec{
+				Template: elbv2model.TargetGroupBindingTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "my-ns",
+						Name:        "my-tgb",
+						Annotations: tt.templateAnnotations,
+					},
+					Spec: elbv2model.TargetGroupBindingSpec{
+						TargetGroupARN: core.LiteralStringToken("my-tg-arn"),
+						TargetType:     &targetTypeIP,
+					},
+				},
+			})
+			m := NewDefaultTargetGroupBindingManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "my-cluster"), &log.NullLogger{})
+			_, err := m.Update(ctx, resTGB, k8sTGB)
+			assert.NoError(t, err)
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(k8sTGB), gotTGB))
+			assert.Equal(t, tt.wantAnnotations, gotTGB.Annotations)
+		})
+	}
+}
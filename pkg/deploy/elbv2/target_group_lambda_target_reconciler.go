@@ -0,0 +1,283 @@
+package elbv2
+
+import (
+	"context"
+	"encoding/json"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	lambdasdk "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+const (
+	lambdaInvokeFunctionAction      = "lambda:InvokeFunction"
+	lambdaInvokePrincipalELBV2      = "elasticloadbalancing.amazonaws.com"
+	lambdaPermissionStatementPrefix = "elbv2-"
+	lambdaPolicyConditionArnLike    = "ArnLike"
+	lambdaPolicyConditionSourceARN  = "AWS:SourceArn"
+)
+
+// reconciler for the Lambda function target of TargetGroups with lambda targetType.
+type TargetGroupLambdaTargetReconciler interface {
+	// Reconcile ensures the Lambda function is registered as the only target of TargetGroup,
+	// and that ELBV2 is permitted to invoke it.
+	Reconcile(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) error
+
+	// Cleanup revokes the permission granted to TargetGroup on Lambda functions.
+	// It should be invoked with Lambda functions registered to TargetGroup before it's deleted.
+	Cleanup(ctx context.Context, sdkTG TargetGroupWithTags, lambdaFunctionARNs []string) error
+
+	// ListLambdaTargets returns the Lambda functions registered to TargetGroup.
+	ListLambdaTargets(ctx context.Context, sdkTG TargetGroupWithTags) ([]string, error)
+}
+
+// NewDefaultTargetGroupLambdaTargetReconciler constructs new TargetGroupLambdaTargetReconciler.
+func NewDefaultTargetGroupLambdaTargetReconciler(elbv2Client services.ELBV2, lambdaClient services.Lambda, logger logr.Logger) *defaultTargetGroupLambdaTargetReconciler {
+	return &defaultTargetGroupLambdaTargetReconciler{
+		elbv2Client:  elbv2Client,
+		lambdaClient: lambdaClient,
+		logger:       logger,
+	}
+}
+
+var _ TargetGroupLambdaTargetReconciler = &defaultTargetGroupLambdaTargetReconciler{}
+
+// default implementation for TargetGroupLambdaTargetReconciler
+type defaultTargetGroupLambdaTargetReconciler struct {
+	elbv2Client  services.ELBV2
+	lambdaClient services.Lambda
+	logger       logr.Logger
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) Reconcile(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) error {
+	if resTG.Spec.TargetType != elbv2model.TargetTypeLambda || resTG.Spec.LambdaFunctionARN == nil {
+		return nil
+	}
+	desiredFunctionARN := awssdk.StringValue(resTG.Spec.LambdaFunctionARN)
+	currentFunctionARNs, err := r.ListLambdaTargets(ctx, sdkTG)
+	if err != nil {
+		return err
+	}
+
+	var staleFunctionARNs []string
+	desiredFunctionRegistered := false
+	for _, functionARN := range currentFunctionARNs {
+		if functionARN == desiredFunctionARN {
+			desiredFunctionRegistered = true
+		} else {
+			staleFunctionARNs = append(staleFunctionARNs, functionARN)
+		}
+	}
+	if len(staleFunctionARNs) > 0 {
+		if err := r.deregisterLambdaTargets(ctx, resTG, sdkTG, staleFunctionARNs); err != nil {
+			return err
+		}
+		if err := r.Cleanup(ctx, sdkTG, staleFunctionARNs); err != nil {
+			return err
+		}
+	}
+	if !desiredFunctionRegistered {
+		if err := r.addInvokePermission(ctx, sdkTG, desiredFunctionARN); err != nil {
+			return err
+		}
+		if err := r.registerLambdaTarget(ctx, resTG, sdkTG, desiredFunctionARN); err != nil {
+			// the permission is revoked so that it isn't leaked if the desired function changes before registration succeeds.
+			if cleanupErr := r.Cleanup(ctx, sdkTG, []string{desiredFunctionARN}); cleanupErr != nil {
+				r.logger.Error(cleanupErr, "failed to revoke lambda invoke permission of unregistered function",
+					"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+					"function", desiredFunctionARN)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) Cleanup(ctx context.Context, sdkTG TargetGroupWithTags, lambdaFunctionARNs []string) error {
+	for _, functionARN := range lambdaFunctionARNs {
+		req := &lambdasdk.RemovePermissionInput{
+			FunctionName: awssdk.String(functionARN),
+			StatementId:  awssdk.String(buildLambdaPermissionStatementID(sdkTG)),
+		}
+		r.logger.Info("removing lambda invoke permission",
+			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+			"function", functionARN)
+		if _, err := r.lambdaClient.RemovePermissionWithContext(ctx, req); err != nil && !isLambdaResourceNotFoundError(err) {
+			return errors.Wrapf(err, "failed to remove invoke permission on lambda function: %v", functionARN)
+		}
+		r.logger.Info("removed lambda invoke permission",
+			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+			"function", functionARN)
+	}
+	return nil
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) ListLambdaTargets(ctx context.Context, sdkTG TargetGroupWithTags) ([]string, error) {
+	req := &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+	}
+	resp, err := r.elbv2Client.DescribeTargetHealthWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	functionARNs := make([]string, 0, len(resp.TargetHealthDescriptions))
+	for _, targetHealth := range resp.TargetHealthDescriptions {
+		functionARNs = append(functionARNs, awssdk.StringValue(targetHealth.Target.Id))
+	}
+	return functionARNs, nil
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) addInvokePermission(ctx context.Context, sdkTG TargetGroupWithTags, functionARN string) error {
+	tgARN := awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)
+	statementID := buildLambdaPermissionStatementID(sdkTG)
+	req := &lambdasdk.AddPermissionInput{
+		FunctionName: awssdk.String(functionARN),
+		StatementId:  awssdk.String(statementID),
+		Action:       awssdk.String(lambdaInvokeFunctionAction),
+		Principal:    awssdk.String(lambdaInvokePrincipalELBV2),
+		SourceArn:    sdkTG.TargetGroup.TargetGroupArn,
+	}
+	r.logger.Info("adding lambda invoke permission",
+		"arn", tgARN,
+		"function", functionARN)
+	if _, err := r.lambdaClient.AddPermissionWithContext(ctx, req); err != nil {
+		if !isLambdaResourceConflictError(err) {
+			return errors.Wrapf(err, "failed to add invoke permission on lambda function: %v", functionARN)
+		}
+		// the statementID can be taken by the permission granted to a deleted TargetGroup with same name, which must be replaced.
+		sourceARN, exists, getErr := r.findInvokePermissionSourceARN(ctx, functionARN, statementID)
+		if getErr != nil {
+			return getErr
+		}
+		if !exists {
+			return errors.Wrapf(err, "failed to add invoke permission on lambda function: %v", functionARN)
+		}
+		if sourceARN != tgARN {
+			r.logger.Info("replacing lambda invoke permission of another targetGroup",
+				"arn", tgARN,
+				"function", functionARN,
+				"sourceARN", sourceARN)
+			removeReq := &lambdasdk.RemovePermissionInput{
+				FunctionName: awssdk.String(functionARN),
+				StatementId:  awssdk.String(statementID),
+			}
+			if _, err := r.lambdaClient.RemovePermissionWithContext(ctx, removeReq); err != nil && !isLambdaResourceNotFoundError(err) {
+				return errors.Wrapf(err, "failed to remove invoke permission on lambda function: %v", functionARN)
+			}
+			if _, err := r.lambdaClient.AddPermissionWithContext(ctx, req); err != nil {
+				return errors.Wrapf(err, "failed to add invoke permission on lambda function: %v", functionARN)
+			}
+		}
+	}
+	r.logger.Info("added lambda invoke permission",
+		"arn", tgARN,
+		"function", functionARN)
+	return nil
+}
+
+// findInvokePermissionSourceARN finds the source ARN that the invoke permission with statementID is granted to within function policy.
+// it returns false if there is no such permission.
+func (r *defaultTargetGroupLambdaTargetReconciler) findInvokePermissionSourceARN(ctx context.Context, functionARN string, statementID string) (string, bool, error) {
+	req := &lambdasdk.GetPolicyInput{
+		FunctionName: awssdk.String(functionARN),
+	}
+	resp, err := r.lambdaClient.GetPolicyWithContext(ctx, req)
+	if err != nil {
+		if isLambdaResourceNotFoundError(err) {
+			return "", false, nil
+		}
+		return "", false, errors.Wrapf(err, "failed to get policy of lambda function: %v", functionARN)
+	}
+	var policy lambdaFunctionPolicy
+	if err := json.Unmarshal([]byte(awssdk.StringValue(resp.Policy)), &policy); err != nil {
+		return "", false, errors.Wrapf(err, "failed to parse policy of lambda function: %v", functionARN)
+	}
+	for _, statement := range policy.Statement {
+		if statement.Sid != statementID {
+			continue
+		}
+		sourceARN, _ := statement.Condition[lambdaPolicyConditionArnLike][lambdaPolicyConditionSourceARN].(string)
+		return sourceARN, true, nil
+	}
+	return "", false, nil
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) registerLambdaTarget(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags, functionARN string) error {
+	req := &elbv2sdk.RegisterTargetsInput{
+		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+		Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+	}
+	r.logger.Info("registering lambda target",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		"function", functionARN)
+	if _, err := r.elbv2Client.RegisterTargetsWithContext(ctx, req); err != nil {
+		return err
+	}
+	r.logger.Info("registered lambda target",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		"function", functionARN)
+	return nil
+}
+
+func (r *defaultTargetGroupLambdaTargetReconciler) deregisterLambdaTargets(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags, functionARNs []string) error {
+	req := &elbv2sdk.DeregisterTargetsInput{
+		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
+	}
+	for _, functionARN := range functionARNs {
+		req.Targets = append(req.Targets, &elbv2sdk.TargetDescription{Id: awssdk.String(functionARN)})
+	}
+	r.logger.Info("deregistering lambda targets",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		"functions", functionARNs)
+	if _, err := r.elbv2Client.DeregisterTargetsWithContext(ctx, req); err != nil {
+		return err
+	}
+	r.logger.Info("deregistered lambda targets",
+		"stackID", resTG.Stack().StackID(),
+		"resourceID", resTG.ID(),
+		"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+		"functions", functionARNs)
+	return nil
+}
+
+// lambdaFunctionPolicy is the resource-based policy of Lambda function, with only the fields needed to find invoke permissions.
+type lambdaFunctionPolicy struct {
+	Statement []lambdaFunctionPolicyStatement `json:"Statement"`
+}
+
+type lambdaFunctionPolicyStatement struct {
+	Sid       string                            `json:"Sid"`
+	Condition map[string]map[string]interface{} `json:"Condition"`
+}
+
+// buildLambdaPermissionStatementID computes the statementID of invoke permission granted to TargetGroup.
+// TargetGroup names are unique per region & account, which makes the statementID unique within function policy.
+func buildLambdaPermissionStatementID(sdkTG TargetGroupWithTags) string {
+	return lambdaPermissionStatementPrefix + awssdk.StringValue(sdkTG.TargetGroup.TargetGroupName)
+}
+
+func isLambdaResourceConflictError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == lambdasdk.ErrCodeResourceConflictException
+	}
+	return false
+}
+
+func isLambdaResourceNotFoundError(err error) bool {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code() == lambdasdk.ErrCodeResourceNotFoundException
+	}
+	return false
+}
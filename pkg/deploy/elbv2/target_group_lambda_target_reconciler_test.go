@@ -0,0 +1,467 @@
+package elbv2
+
+import (
+	"context"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	lambdasdk "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	coremodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func Test_defaultTargetGroupLambdaTargetReconciler_Reconcile(t *testing.T) {
+	type describeTargetHealthWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetHealthInput
+		resp *elbv2sdk.DescribeTargetHealthOutput
+		err  error
+	}
+	type registerTargetsWithContextCall struct {
+		req  *elbv2sdk.RegisterTargetsInput
+		resp *elbv2sdk.RegisterTargetsOutput
+		err  error
+	}
+	type deregisterTargetsWithContextCall struct {
+		req  *elbv2sdk.DeregisterTargetsInput
+		resp *elbv2sdk.DeregisterTargetsOutput
+		err  error
+	}
+	type addPermissionWithContextCall struct {
+		req  *lambdasdk.AddPermissionInput
+		resp *lambdasdk.AddPermissionOutput
+		err  error
+	}
+	type removePermissionWithContextCall struct {
+		req  *lambdasdk.RemovePermissionInput
+		resp *lambdasdk.RemovePermissionOutput
+		err  error
+	}
+	type getPolicyWithContextCall struct {
+		req  *lambdasdk.GetPolicyInput
+		resp *lambdasdk.GetPolicyOutput
+		err  error
+	}
+	type fields struct {
+		describeTargetHealthWithContextCalls []describeTargetHealthWithContextCall
+		registerTargetsWithContextCalls      []registerTargetsWithContextCall
+		deregisterTargetsWithContextCalls    []deregisterTargetsWithContextCall
+		addPermissionWithContextCalls        []addPermissionWithContextCall
+		removePermissionWithContextCalls     []removePermissionWithContextCall
+		getPolicyWithContextCalls            []getPolicyWithContextCall
+	}
+	type args struct {
+		resTG *elbv2model.TargetGroup
+		sdkTG TargetGroupWithTags
+	}
+
+	stack := coremodel.NewDefaultStack(coremodel.StackID{Namespace: "namespace", Name: "name"})
+	functionARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	oldFunctionARN := "arn:aws:lambda:us-west-2:123456789012:function:my-old-function"
+	sdkTG := TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{
+			TargetGroupArn:  awssdk.String("my-arn"),
+			TargetGroupName: awssdk.String("k8s-my-tg"),
+			TargetType:      awssdk.String("lambda"),
+		},
+	}
+	lambdaResTG := &elbv2model.TargetGroup{
+		ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+		Spec: elbv2model.TargetGroupSpec{
+			Name:              "k8s-my-tg",
+			TargetType:        elbv2model.TargetTypeLambda,
+			LambdaFunctionARN: awssdk.String(functionARN),
+		},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "non-lambda targetGroup should be ignored",
+			args: args{
+				resTG: &elbv2model.TargetGroup{
+					ResourceMeta: coremodel.NewResourceMeta(stack, "AWS::ElasticLoadBalancingV2::TargetGroup", "id-1"),
+					Spec: elbv2model.TargetGroupSpec{
+						TargetType: elbv2model.TargetTypeIP,
+					},
+				},
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "lambda function should be registered with permission",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						resp: &lambdasdk.AddPermissionOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "existing permission should be tolerated",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						err: awserr.New(lambdasdk.ErrCodeResourceConflictException, "statement already exists", nil),
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+				getPolicyWithContextCalls: []getPolicyWithContextCall{
+					{
+						req: &lambdasdk.GetPolicyInput{FunctionName: awssdk.String(functionARN)},
+						resp: &lambdasdk.GetPolicyOutput{
+							Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Sid":"elbv2-k8s-my-tg","Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-west-2:123456789012:function:my-function","Condition":{"ArnLike":{"AWS:SourceArn":"my-arn"}}}]}`),
+						},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "existing permission of another targetGroup with same name should be replaced",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						err: awserr.New(lambdasdk.ErrCodeResourceConflictException, "statement already exists", nil),
+					},
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						resp: &lambdasdk.AddPermissionOutput{},
+					},
+				},
+				getPolicyWithContextCalls: []getPolicyWithContextCall{
+					{
+						req: &lambdasdk.GetPolicyInput{FunctionName: awssdk.String(functionARN)},
+						resp: &lambdasdk.GetPolicyOutput{
+							Policy: awssdk.String(`{"Version":"2012-10-17","Statement":[{"Sid":"elbv2-k8s-my-tg","Effect":"Allow","Principal":{"Service":"elasticloadbalancing.amazonaws.com"},"Action":"lambda:InvokeFunction","Resource":"arn:aws:lambda:us-west-2:123456789012:function:my-function","Condition":{"ArnLike":{"AWS:SourceArn":"my-old-arn"}}}]}`),
+						},
+					},
+				},
+				removePermissionWithContextCalls: []removePermissionWithContextCall{
+					{
+						req: &lambdasdk.RemovePermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+						},
+						resp: &lambdasdk.RemovePermissionOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "conflict without existing permission should fail",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						err: awserr.New(lambdasdk.ErrCodeResourceConflictException, "policy modified concurrently", nil),
+					},
+				},
+				getPolicyWithContextCalls: []getPolicyWithContextCall{
+					{
+						req: &lambdasdk.GetPolicyInput{FunctionName: awssdk.String(functionARN)},
+						err: awserr.New(lambdasdk.ErrCodeResourceNotFoundException, "policy not found", nil),
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+			wantErr: errors.New("failed to add invoke permission on lambda function: arn:aws:lambda:us-west-2:123456789012:function:my-function: ResourceConflictException: policy modified concurrently"),
+		},
+		{
+			name: "lambda function already registered",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{
+							TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+								{Target: &elbv2sdk.TargetDescription{Id: awssdk.String(functionARN)}},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "stale lambda function should be deregistered",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{
+							TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+								{Target: &elbv2sdk.TargetDescription{Id: awssdk.String(oldFunctionARN)}},
+							},
+						},
+					},
+				},
+				deregisterTargetsWithContextCalls: []deregisterTargetsWithContextCall{
+					{
+						req: &elbv2sdk.DeregisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(oldFunctionARN)}},
+						},
+						resp: &elbv2sdk.DeregisterTargetsOutput{},
+					},
+				},
+				removePermissionWithContextCalls: []removePermissionWithContextCall{
+					{
+						req: &lambdasdk.RemovePermissionInput{
+							FunctionName: awssdk.String(oldFunctionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+						},
+						resp: &lambdasdk.RemovePermissionOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						resp: &lambdasdk.AddPermissionOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+						},
+						resp: &elbv2sdk.RegisterTargetsOutput{},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+		},
+		{
+			name: "failed to add permission",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						err: errors.New("access denied"),
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+			wantErr: errors.New("failed to add invoke permission on lambda function: arn:aws:lambda:us-west-2:123456789012:function:my-function: access denied"),
+		},
+		{
+			name: "permission should be revoked when failed to register lambda function",
+			fields: fields{
+				describeTargetHealthWithContextCalls: []describeTargetHealthWithContextCall{
+					{
+						req:  &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")},
+						resp: &elbv2sdk.DescribeTargetHealthOutput{},
+					},
+				},
+				addPermissionWithContextCalls: []addPermissionWithContextCall{
+					{
+						req: &lambdasdk.AddPermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+							Action:       awssdk.String("lambda:InvokeFunction"),
+							Principal:    awssdk.String("elasticloadbalancing.amazonaws.com"),
+							SourceArn:    awssdk.String("my-arn"),
+						},
+						resp: &lambdasdk.AddPermissionOutput{},
+					},
+				},
+				registerTargetsWithContextCalls: []registerTargetsWithContextCall{
+					{
+						req: &elbv2sdk.RegisterTargetsInput{
+							TargetGroupArn: awssdk.String("my-arn"),
+							Targets:        []*elbv2sdk.TargetDescription{{Id: awssdk.String(functionARN)}},
+						},
+						err: errors.New("InvalidTarget: function not found"),
+					},
+				},
+				removePermissionWithContextCalls: []removePermissionWithContextCall{
+					{
+						req: &lambdasdk.RemovePermissionInput{
+							FunctionName: awssdk.String(functionARN),
+							StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+						},
+						resp: &lambdasdk.RemovePermissionOutput{},
+					},
+				},
+			},
+			args: args{
+				resTG: lambdaResTG,
+				sdkTG: sdkTG,
+			},
+			wantErr: errors.New("InvalidTarget: function not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetHealthWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.registerTargetsWithContextCalls {
+				elbv2Client.EXPECT().RegisterTargetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.deregisterTargetsWithContextCalls {
+				elbv2Client.EXPECT().DeregisterTargetsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			lambdaClient := services.NewMockLambda(ctrl)
+			for _, call := range tt.fields.addPermissionWithContextCalls {
+				lambdaClient.EXPECT().AddPermissionWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.removePermissionWithContextCalls {
+				lambdaClient.EXPECT().RemovePermissionWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.getPolicyWithContextCalls {
+				lambdaClient.EXPECT().GetPolicyWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+
+			r := &defaultTargetGroupLambdaTargetReconciler{
+				elbv2Client:  elbv2Client,
+				lambdaClient: lambdaClient,
+				logger:       &log.NullLogger{},
+			}
+			err := r.Reconcile(context.Background(), tt.args.resTG, tt.args.sdkTG)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -29,16 +29,17 @@ type TargetGroupManager interface {
 }
 
 // NewDefaultTargetGroupManager constructs new defaultTargetGroupManager.
-func NewDefaultTargetGroupManager(elbv2Client services.ELBV2, trackingProvider tracking.Provider,
+func NewDefaultTargetGroupManager(elbv2Client services.ELBV2, lambdaClient services.Lambda, trackingProvider tracking.Provider,
 	taggingManager TaggingManager, vpcID string, externalManagedTags []string, logger logr.Logger) *defaultTargetGroupManager {
 	return &defaultTargetGroupManager{
-		elbv2Client:          elbv2Client,
-		trackingProvider:     trackingProvider,
-		taggingManager:       taggingManager,
-		attributesReconciler: NewDefaultTargetGroupAttributesReconciler(elbv2Client, logger),
-		vpcID:                vpcID,
-		externalManagedTags:  externalManagedTags,
-		logger:               logger,
+		elbv2Client:            elbv2Client,
+		trackingProvider:       trackingProvider,
+		taggingManager:         taggingManager,
+		attributesReconciler:   NewDefaultTargetGroupAttributesReconciler(elbv2Client, logger),
+		lambdaTargetReconciler: NewDefaultTargetGroupLambdaTargetReconciler(elbv2Client, lambdaClient, logger),
+		vpcID:                  vpcID,
+		externalManagedTags:    externalManagedTags,
+		logger:                 logger,
 
 		waitTGDeletionPollInterval: defaultWaitTGDeletionPollInterval,
 		waitTGDeletionTimeout:      defaultWaitTGDeletionTimeout,
@@ -49,12 +50,13 @@ var _ TargetGroupManager = &defaultTargetGroupManager{}
 
 // default implementation for TargetGroupManager
 type defaultTargetGroupManager struct {
-	elbv2Client          services.ELBV2
-	trackingProvider     tracking.Provider
-	taggingManager       TaggingManager
-	attributesReconciler TargetGroupAttributesReconciler
-	vpcID                string
-	externalManagedTags  []string
+	elbv2Client            services.ELBV2
+	trackingProvider       tracking.Provider
+	taggingManager         TaggingManager
+	attributesReconciler   TargetGroupAttributesReconciler
+	lambdaTargetReconciler TargetGroupLambdaTargetReconciler
+	vpcID                  string
+	externalManagedTags    []string
 
 	logger logr.Logger
 
@@ -67,6 +69,9 @@ func (m *defaultTargetGroupManager) Create(ctx context.Context, resTG *elbv2mode
 	req.VpcId = awssdk.String(m.vpcID)
 	tgTags := m.trackingProvider.ResourceTags(resTG.Stack(), resTG, resTG.Spec.Tags)
 	req.Tags = convertTagsToSDKTags(tgTags)
+	if err := m.checkTargetGroupNameOwnership(ctx, resTG.Spec.Name, tgTags); err != nil {
+		return elbv2model.TargetGroupStatus{}, err
+	}
 
 	m.logger.Info("creating targetGroup",
 		"stackID", resTG.Stack().StackID(),
@@ -86,10 +91,48 @@ func (m *defaultTargetGroupManager) Create(ctx context.Context, resTG *elbv2mode
 	if err := m.attributesReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
 		return elbv2model.TargetGroupStatus{}, err
 	}
+	if err := m.lambdaTargetReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
+		return elbv2model.TargetGroupStatus{}, err
+	}
 
 	return buildResTargetGroupStatus(sdkTG), nil
 }
 
+// checkTargetGroupNameOwnership ensures an existing TargetGroup with the same name isn't owned by another resource.
+// CreateTargetGroup succeeds with the existing TargetGroup if its settings are identical, which would silently adopt it otherwise.
+func (m *defaultTargetGroupManager) checkTargetGroupNameOwnership(ctx context.Context, tgName string, desiredTags map[string]string) error {
+	req := &elbv2sdk.DescribeTargetGroupsInput{
+		Names: awssdk.StringSlice([]string{tgName}),
+	}
+	resp, err := m.elbv2Client.DescribeTargetGroupsWithContext(ctx, req)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2sdk.ErrCodeTargetGroupNotFoundException {
+			return nil
+		}
+		return err
+	}
+	for _, sdkTG := range resp.TargetGroups {
+		tagsReq := &elbv2sdk.DescribeTagsInput{
+			ResourceArns: []*string{sdkTG.TargetGroupArn},
+		}
+		tagsResp, err := m.elbv2Client.DescribeTagsWithContext(ctx, tagsReq)
+		if err != nil {
+			return err
+		}
+		var tags map[string]string
+		for _, tagDescription := range tagsResp.TagDescriptions {
+			tags = convertSDKTagsToTags(tagDescription.Tags)
+		}
+		for _, tagKey := range []string{m.trackingProvider.StackIDTagKey(), m.trackingProvider.ResourceIDTagKey()} {
+			if tags[tagKey] != desiredTags[tagKey] {
+				return errors.Errorf("targetGroup %v with name %v already exists and is owned by a different resource, %v: %q",
+					awssdk.StringValue(sdkTG.TargetGroupArn), tgName, tagKey, tags[tagKey])
+			}
+		}
+	}
+	return nil
+}
+
 func (m *defaultTargetGroupManager) Update(ctx context.Context, resTG *elbv2model.TargetGroup, sdkTG TargetGroupWithTags) (elbv2model.TargetGroupStatus, error) {
 	if err := m.updateSDKTargetGroupWithTags(ctx, resTG, sdkTG); err != nil {
 		return elbv2model.TargetGroupStatus{}, err
@@ -100,6 +143,9 @@ func (m *defaultTargetGroupManager) Update(ctx context.Context, resTG *elbv2mode
 	if err := m.attributesReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
 		return elbv2model.TargetGroupStatus{}, err
 	}
+	if err := m.lambdaTargetReconciler.Reconcile(ctx, resTG, sdkTG); err != nil {
+		return elbv2model.TargetGroupStatus{}, err
+	}
 
 	return buildResTargetGroupStatus(sdkTG), nil
 }
@@ -109,6 +155,18 @@ func (m *defaultTargetGroupManager) Delete(ctx context.Context, sdkTG TargetGrou
 		TargetGroupArn: sdkTG.TargetGroup.TargetGroupArn,
 	}
 
+	// invoke permissions are revoked before the targetGroup is deleted, so that they are retried along with the deletion upon failures,
+	// instead of being leaked once the targetGroup and its registered Lambda functions are gone.
+	if awssdk.StringValue(sdkTG.TargetGroup.TargetType) == string(elbv2model.TargetTypeLambda) {
+		lambdaFunctionARNs, err := m.lambdaTargetReconciler.ListLambdaTargets(ctx, sdkTG)
+		if err != nil {
+			return err
+		}
+		if err := m.lambdaTargetReconciler.Cleanup(ctx, sdkTG, lambdaFunctionARNs); err != nil {
+			return err
+		}
+	}
+
 	m.logger.Info("deleting targetGroup",
 		"arn", awssdk.StringValue(req.TargetGroupArn))
 	if err := runtime.RetryImmediateOnError(m.waitTGDeletionPollInterval, m.waitTGDeletionTimeout, isTargetGroupResourceInUseError, func() error {
@@ -119,7 +177,6 @@ func (m *defaultTargetGroupManager) Delete(ctx context.Context, sdkTG TargetGrou
 	}
 	m.logger.Info("deleted targetGroup",
 		"arn", awssdk.StringValue(req.TargetGroupArn))
-
 	return nil
 }
 
@@ -190,8 +247,10 @@ func buildSDKCreateTargetGroupInput(tgSpec elbv2model.TargetGroupSpec) *elbv2sdk
 	sdkObj := &elbv2sdk.CreateTargetGroupInput{}
 	sdkObj.Name = awssdk.String(tgSpec.Name)
 	sdkObj.TargetType = awssdk.String(string(tgSpec.TargetType))
-	sdkObj.Port = awssdk.Int64(tgSpec.Port)
-	sdkObj.Protocol = awssdk.String(string(tgSpec.Protocol))
+	if tgSpec.TargetType != elbv2model.TargetTypeLambda {
+		sdkObj.Port = awssdk.Int64(tgSpec.Port)
+		sdkObj.Protocol = awssdk.String(string(tgSpec.Protocol))
+	}
 	if tgSpec.IPAddressType != nil && *tgSpec.IPAddressType != elbv2model.TargetGroupIPAddressTypeIPv4 {
 		sdkObj.IpAddressType = (*string)(tgSpec.IPAddressType)
 	}
@@ -1,13 +1,19 @@
 package elbv2
 
 import (
+	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	lambdasdk "github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
 )
 
@@ -509,6 +515,20 @@ func Test_buildSDKCreateTargetGroupInput(t *testing.T) {
 				IpAddressType:              awssdk.String("ipv6"),
 			},
 		},
+		{
+			name: "lambda target type",
+			args: args{
+				tgSpec: elbv2model.TargetGroupSpec{
+					Name:              "my-tg",
+					TargetType:        elbv2model.TargetTypeLambda,
+					LambdaFunctionARN: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function"),
+				},
+			},
+			want: &elbv2sdk.CreateTargetGroupInput{
+				Name:       awssdk.String("my-tg"),
+				TargetType: awssdk.String("lambda"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -680,3 +700,252 @@ func Test_isTargetGroupResourceInUseError(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultTargetGroupManager_checkTargetGroupNameOwnership(t *testing.T) {
+	type describeTargetGroupsWithContextCall struct {
+		req  *elbv2sdk.DescribeTargetGroupsInput
+		resp *elbv2sdk.DescribeTargetGroupsOutput
+		err  error
+	}
+	type describeTagsWithContextCall struct {
+		req  *elbv2sdk.DescribeTagsInput
+		resp *elbv2sdk.DescribeTagsOutput
+		err  error
+	}
+	type fields struct {
+		describeTargetGroupsWithContextCalls []describeTargetGroupsWithContextCall
+		describeTagsWithContextCalls         []describeTagsWithContextCall
+	}
+	desiredTags := map[string]string{
+		"elbv2.k8s.aws/cluster":    "cluster-name",
+		"ingress.k8s.aws/stack":    "ns-1/ing-1",
+		"ingress.k8s.aws/resource": "ns-1/ing-1-svc-1:http",
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr error
+	}{
+		{
+			name: "targetGroup with name doesn't exist",
+			fields: fields{
+				describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"k8s-ns1-svc1-2c37289a00"}),
+						},
+						err: awserr.New(elbv2sdk.ErrCodeTargetGroupNotFoundException, "One or more target groups not found", nil),
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "targetGroup with name is owned by same resource",
+			fields: fields{
+				describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"k8s-ns1-svc1-2c37289a00"}),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupsOutput{
+							TargetGroups: []*elbv2sdk.TargetGroup{
+								{
+									TargetGroupArn: awssdk.String("my-tg"),
+								},
+							},
+						},
+					},
+				},
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("my-tg"),
+									Tags:        convertTagsToSDKTags(desiredTags),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "targetGroup with name is owned by different resource",
+			fields: fields{
+				describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"k8s-ns1-svc1-2c37289a00"}),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupsOutput{
+							TargetGroups: []*elbv2sdk.TargetGroup{
+								{
+									TargetGroupArn: awssdk.String("my-tg"),
+								},
+							},
+						},
+					},
+				},
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("my-tg"),
+									Tags: convertTagsToSDKTags(map[string]string{
+										"elbv2.k8s.aws/cluster":    "cluster-name",
+										"ingress.k8s.aws/stack":    "ns-2/ing-1",
+										"ingress.k8s.aws/resource": "ns-2/ing-1-svc-1:http",
+									}),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("targetGroup my-tg with name k8s-ns1-svc1-2c37289a00 already exists and is owned by a different resource, ingress.k8s.aws/stack: \"ns-2/ing-1\""),
+		},
+		{
+			name: "targetGroup with name isn't owned by controller",
+			fields: fields{
+				describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"k8s-ns1-svc1-2c37289a00"}),
+						},
+						resp: &elbv2sdk.DescribeTargetGroupsOutput{
+							TargetGroups: []*elbv2sdk.TargetGroup{
+								{
+									TargetGroupArn: awssdk.String("my-tg"),
+								},
+							},
+						},
+					},
+				},
+				describeTagsWithContextCalls: []describeTagsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTagsInput{
+							ResourceArns: awssdk.StringSlice([]string{"my-tg"}),
+						},
+						resp: &elbv2sdk.DescribeTagsOutput{
+							TagDescriptions: []*elbv2sdk.TagDescription{
+								{
+									ResourceArn: awssdk.String("my-tg"),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("targetGroup my-tg with name k8s-ns1-svc1-2c37289a00 already exists and is owned by a different resource, ingress.k8s.aws/stack: \"\""),
+		},
+		{
+			name: "describe targetGroups fails",
+			fields: fields{
+				describeTargetGroupsWithContextCalls: []describeTargetGroupsWithContextCall{
+					{
+						req: &elbv2sdk.DescribeTargetGroupsInput{
+							Names: awssdk.StringSlice([]string{"k8s-ns1-svc1-2c37289a00"}),
+						},
+						err: errors.New("some error"),
+					},
+				},
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.fields.describeTargetGroupsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTargetGroupsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			for _, call := range tt.fields.describeTagsWithContextCalls {
+				elbv2Client.EXPECT().DescribeTagsWithContext(gomock.Any(), call.req).Return(call.resp, call.err)
+			}
+			m := &defaultTargetGroupManager{
+				elbv2Client:      elbv2Client,
+				trackingProvider: tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+				logger:           &log.NullLogger{},
+			}
+			err := m.checkTargetGroupNameOwnership(context.Background(), "k8s-ns1-svc1-2c37289a00", desiredTags)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultTargetGroupManager_Delete(t *testing.T) {
+	functionARN := "arn:aws:lambda:us-west-2:123456789012:function:my-function"
+	lambdaSDKTG := TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{
+			TargetGroupArn:  awssdk.String("my-arn"),
+			TargetGroupName: awssdk.String("k8s-my-tg"),
+			TargetType:      awssdk.String("lambda"),
+		},
+	}
+	tests := []struct {
+		name                string
+		removePermissionErr error
+		wantDeletion        bool
+		wantErr             error
+	}{
+		{
+			name:         "invoke permission should be revoked before targetGroup is deleted",
+			wantDeletion: true,
+		},
+		{
+			name:                "targetGroup shouldn't be deleted when failed to revoke invoke permission",
+			removePermissionErr: errors.New("access denied"),
+			wantErr:             errors.New("failed to remove invoke permission on lambda function: arn:aws:lambda:us-west-2:123456789012:function:my-function: access denied"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			lambdaClient := services.NewMockLambda(ctrl)
+			calls := []*gomock.Call{
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{TargetGroupArn: awssdk.String("my-arn")}).
+					Return(&elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							{Target: &elbv2sdk.TargetDescription{Id: awssdk.String(functionARN)}},
+						},
+					}, nil),
+				lambdaClient.EXPECT().RemovePermissionWithContext(gomock.Any(), &lambdasdk.RemovePermissionInput{
+					FunctionName: awssdk.String(functionARN),
+					StatementId:  awssdk.String("elbv2-k8s-my-tg"),
+				}).Return(&lambdasdk.RemovePermissionOutput{}, tt.removePermissionErr),
+			}
+			if tt.wantDeletion {
+				calls = append(calls, elbv2Client.EXPECT().DeleteTargetGroupWithContext(gomock.Any(), &elbv2sdk.DeleteTargetGroupInput{TargetGroupArn: awssdk.String("my-arn")}).
+					Return(&elbv2sdk.DeleteTargetGroupOutput{}, nil))
+			}
+			gomock.InOrder(calls...)
+
+			m := NewDefaultTargetGroupManager(elbv2Client, lambdaClient, nil, nil, "vpc-id", nil, &log.NullLogger{})
+			err := m.Delete(context.Background(), lambdaSDKTG)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
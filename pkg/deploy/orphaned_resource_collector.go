@@ -0,0 +1,202 @@
+package deploy
+
+import (
+	"context"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ActiveStackIDsLoader loads the IDs of stacks whose owning Kubernetes resources still exist.
+type ActiveStackIDsLoader interface {
+	// LoadActiveStackIDs returns the string representation of active stackIDs.
+	LoadActiveStackIDs(ctx context.Context) (sets.String, error)
+}
+
+// OrphanedResourceCollector garbage collects AWS resources provisioned for stacks that no longer exist.
+type OrphanedResourceCollector interface {
+	// Collect deletes AWS resources tagged with this cluster whose stack is no longer active.
+	Collect(ctx context.Context) error
+}
+
+// NewDefaultOrphanedResourceCollector constructs new defaultOrphanedResourceCollector.
+func NewDefaultOrphanedResourceCollector(cloud aws.Cloud, k8sClient client.Client,
+	networkingSGManager networking.SecurityGroupManager, networkingSGReconciler networking.SecurityGroupReconciler,
+	stackIDsLoader ActiveStackIDsLoader, config config.ControllerConfig, tagPrefix string, dryRun bool, logger logr.Logger) *defaultOrphanedResourceCollector {
+
+	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
+	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
+	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), config.FeatureGates, logger)
+	accessLogsConfigurator := elbv2.NewDefaultAccessLogsConfigurator(cloud.S3(), cloud.Region(), cloud.Partition(), config.EnableAccessLogsBucketPolicy, logger)
+
+	return &defaultOrphanedResourceCollector{
+		k8sClient:           k8sClient,
+		stackIDsLoader:      stackIDsLoader,
+		trackingProvider:    trackingProvider,
+		ec2TaggingManager:   ec2TaggingManager,
+		ec2SGManager:        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), config.ExternalManagedTags, logger),
+		elbv2TaggingManager: elbv2TaggingManager,
+		elbv2LBManager:      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, accessLogsConfigurator, config.ExternalManagedTags, logger),
+		elbv2TGManager:      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), cloud.Lambda(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), config.ExternalManagedTags, logger),
+		dryRun:              dryRun,
+		logger:              logger,
+	}
+}
+
+var _ OrphanedResourceCollector = &defaultOrphanedResourceCollector{}
+
+// defaultOrphanedResourceCollector is the default implementation for OrphanedResourceCollector
+type defaultOrphanedResourceCollector struct {
+	k8sClient           client.Client
+	stackIDsLoader      ActiveStackIDsLoader
+	trackingProvider    tracking.Provider
+	ec2TaggingManager   ec2.TaggingManager
+	ec2SGManager        ec2.SecurityGroupManager
+	elbv2TaggingManager elbv2.TaggingManager
+	elbv2LBManager      elbv2.LoadBalancerManager
+	elbv2TGManager      elbv2.TargetGroupManager
+	dryRun              bool
+
+	logger logr.Logger
+}
+
+// orphanedResources contains AWS resources whose stack is no longer active.
+type orphanedResources struct {
+	loadBalancers  []elbv2.LoadBalancerWithTags
+	targetGroups   []elbv2.TargetGroupWithTags
+	securityGroups []networking.SecurityGroupInfo
+}
+
+func (c *defaultOrphanedResourceCollector) Collect(ctx context.Context) error {
+	tagFilter := tracking.TagsAsTagFilter(c.trackingProvider.ClusterTags())
+	tagFilter[c.trackingProvider.StackIDTagKey()] = nil
+
+	// AWS resources must be listed before active stacks are loaded,
+	// otherwise resources provisioned for newly created stacks in between will be considered as orphaned.
+	sdkLBs, err := c.elbv2TaggingManager.ListLoadBalancers(ctx, tagFilter)
+	if err != nil {
+		return err
+	}
+	sdkTGs, err := c.elbv2TaggingManager.ListTargetGroups(ctx, tagFilter)
+	if err != nil {
+		return err
+	}
+	sdkSGs, err := c.ec2TaggingManager.ListSecurityGroups(ctx, tagFilter)
+	if err != nil {
+		return err
+	}
+	activeStackIDs, err := c.stackIDsLoader.LoadActiveStackIDs(ctx)
+	if err != nil {
+		return err
+	}
+	tgARNsInUse, err := c.listTargetGroupARNsInUse(ctx)
+	if err != nil {
+		return err
+	}
+
+	orphaned := findOrphanedResources(c.trackingProvider.StackIDTagKey(), activeStackIDs, tgARNsInUse, sdkLBs, sdkTGs, sdkSGs)
+	if c.dryRun {
+		c.logOrphanedResources(orphaned)
+		return nil
+	}
+	return c.deleteOrphanedResources(ctx, orphaned)
+}
+
+// listTargetGroupARNsInUse returns ARNs of TargetGroups referenced by TargetGroupBindings.
+// such TargetGroups are left alone, so that TargetGroupBinding controller can deregister targets and revoke networking access first.
+func (c *defaultOrphanedResourceCollector) listTargetGroupARNsInUse(ctx context.Context) (sets.String, error) {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := c.k8sClient.List(ctx, tgbList); err != nil {
+		return nil, errors.Wrap(err, "failed to list targetGroupBindings")
+	}
+	tgARNs := sets.NewString()
+	for _, tgb := range tgbList.Items {
+		tgARNs.Insert(tgb.Spec.TargetGroupARN)
+	}
+	return tgARNs, nil
+}
+
+func (c *defaultOrphanedResourceCollector) logOrphanedResources(orphaned orphanedResources) {
+	for _, sdkLB := range orphaned.loadBalancers {
+		c.logger.Info("dry-run: would delete orphaned loadBalancer",
+			"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn),
+			"stackID", sdkLB.Tags[c.trackingProvider.StackIDTagKey()])
+	}
+	for _, sdkTG := range orphaned.targetGroups {
+		c.logger.Info("dry-run: would delete orphaned targetGroup",
+			"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn),
+			"stackID", sdkTG.Tags[c.trackingProvider.StackIDTagKey()])
+	}
+	for _, sdkSG := range orphaned.securityGroups {
+		c.logger.Info("dry-run: would delete orphaned securityGroup",
+			"securityGroupID", sdkSG.SecurityGroupID,
+			"stackID", sdkSG.Tags[c.trackingProvider.StackIDTagKey()])
+	}
+}
+
+// deleteOrphanedResources deletes orphaned resources in dependency order.
+// failure to delete a resource doesn't prevent other resources from being deleted, they'll be retried by next collection.
+func (c *defaultOrphanedResourceCollector) deleteOrphanedResources(ctx context.Context, orphaned orphanedResources) error {
+	var firstErr error
+	recordErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, sdkLB := range orphaned.loadBalancers {
+		if err := c.elbv2LBManager.Delete(ctx, sdkLB); err != nil {
+			c.logger.Error(err, "failed to delete orphaned loadBalancer",
+				"arn", awssdk.StringValue(sdkLB.LoadBalancer.LoadBalancerArn))
+			recordErr(err)
+		}
+	}
+	for _, sdkTG := range orphaned.targetGroups {
+		if err := c.elbv2TGManager.Delete(ctx, sdkTG); err != nil {
+			c.logger.Error(err, "failed to delete orphaned targetGroup",
+				"arn", awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn))
+			recordErr(err)
+		}
+	}
+	for _, sdkSG := range orphaned.securityGroups {
+		if err := c.ec2SGManager.Delete(ctx, sdkSG); err != nil {
+			c.logger.Error(err, "failed to delete orphaned securityGroup",
+				"securityGroupID", sdkSG.SecurityGroupID)
+			recordErr(err)
+		}
+	}
+	return firstErr
+}
+
+// findOrphanedResources finds resources whose stackID isn't within activeStackIDs.
+// TargetGroups within tgARNsInUse are never considered as orphaned.
+func findOrphanedResources(stackIDTagKey string, activeStackIDs sets.String, tgARNsInUse sets.String,
+	sdkLBs []elbv2.LoadBalancerWithTags, sdkTGs []elbv2.TargetGroupWithTags, sdkSGs []networking.SecurityGroupInfo) orphanedResources {
+	var orphaned orphanedResources
+	for _, sdkLB := range sdkLBs {
+		if !activeStackIDs.Has(sdkLB.Tags[stackIDTagKey]) {
+			orphaned.loadBalancers = append(orphaned.loadBalancers, sdkLB)
+		}
+	}
+	for _, sdkTG := range sdkTGs {
+		if activeStackIDs.Has(sdkTG.Tags[stackIDTagKey]) || tgARNsInUse.Has(awssdk.StringValue(sdkTG.TargetGroup.TargetGroupArn)) {
+			continue
+		}
+		orphaned.targetGroups = append(orphaned.targetGroups, sdkTG)
+	}
+	for _, sdkSG := range sdkSGs {
+		if !activeStackIDs.Has(sdkSG.Tags[stackIDTagKey]) {
+			orphaned.securityGroups = append(orphaned.securityGroups, sdkSG)
+		}
+	}
+	return orphaned
+}
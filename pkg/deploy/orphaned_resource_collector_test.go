@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+)
+
+func Test_findOrphanedResources(t *testing.T) {
+	activeLB := elbv2.LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-1")},
+		Tags:         map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-1"},
+	}
+	orphanedLB := elbv2.LoadBalancerWithTags{
+		LoadBalancer: &elbv2sdk.LoadBalancer{LoadBalancerArn: awssdk.String("lb-2")},
+		Tags:         map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-2"},
+	}
+	activeTG := elbv2.TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("tg-1")},
+		Tags:        map[string]string{"ingress.k8s.aws/stack": "awesome-group"},
+	}
+	orphanedTG := elbv2.TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("tg-2")},
+		Tags:        map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-2"},
+	}
+	inUseTG := elbv2.TargetGroupWithTags{
+		TargetGroup: &elbv2sdk.TargetGroup{TargetGroupArn: awssdk.String("tg-3")},
+		Tags:        map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-2"},
+	}
+	activeSG := networking.SecurityGroupInfo{
+		SecurityGroupID: "sg-1",
+		Tags:            map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-1"},
+	}
+	orphanedSG := networking.SecurityGroupInfo{
+		SecurityGroupID: "sg-2",
+		Tags:            map[string]string{"ingress.k8s.aws/stack": "awesome-ns/ing-2"},
+	}
+
+	type args struct {
+		activeStackIDs sets.String
+		tgARNsInUse    sets.String
+		sdkLBs         []elbv2.LoadBalancerWithTags
+		sdkTGs         []elbv2.TargetGroupWithTags
+		sdkSGs         []networking.SecurityGroupInfo
+	}
+	tests := []struct {
+		name string
+		args args
+		want orphanedResources
+	}{
+		{
+			name: "no resources",
+			args: args{
+				activeStackIDs: sets.NewString("awesome-ns/ing-1"),
+				tgARNsInUse:    sets.NewString(),
+			},
+			want: orphanedResources{},
+		},
+		{
+			name: "all resources are active",
+			args: args{
+				activeStackIDs: sets.NewString("awesome-ns/ing-1", "awesome-group"),
+				tgARNsInUse:    sets.NewString(),
+				sdkLBs:         []elbv2.LoadBalancerWithTags{activeLB},
+				sdkTGs:         []elbv2.TargetGroupWithTags{activeTG},
+				sdkSGs:         []networking.SecurityGroupInfo{activeSG},
+			},
+			want: orphanedResources{},
+		},
+		{
+			name: "orphaned resources are found",
+			args: args{
+				activeStackIDs: sets.NewString("awesome-ns/ing-1", "awesome-group"),
+				tgARNsInUse:    sets.NewString("tg-3"),
+				sdkLBs:         []elbv2.LoadBalancerWithTags{activeLB, orphanedLB},
+				sdkTGs:         []elbv2.TargetGroupWithTags{activeTG, orphanedTG, inUseTG},
+				sdkSGs:         []networking.SecurityGroupInfo{activeSG, orphanedSG},
+			},
+			want: orphanedResources{
+				loadBalancers:  []elbv2.LoadBalancerWithTags{orphanedLB},
+				targetGroups:   []elbv2.TargetGroupWithTags{orphanedTG},
+				securityGroups: []networking.SecurityGroupInfo{orphanedSG},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findOrphanedResources("ingress.k8s.aws/stack", tt.args.activeStackIDs, tt.args.tgARNsInUse,
+				tt.args.sdkLBs, tt.args.sdkTGs, tt.args.sdkSGs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
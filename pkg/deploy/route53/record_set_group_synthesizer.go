@@ -0,0 +1,268 @@
+package route53
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+)
+
+// NewRecordSetGroupSynthesizer constructs new recordSetGroupSynthesizer
+func NewRecordSetGroupSynthesizer(route53Client services.Route53, allowedHostedZoneIDs []string, clusterName string, logger logr.Logger, stack core.Stack) *recordSetGroupSynthesizer {
+	return &recordSetGroupSynthesizer{
+		route53Client:        route53Client,
+		allowedHostedZoneIDs: sets.NewString(allowedHostedZoneIDs...),
+		clusterName:          clusterName,
+		logger:               logger,
+		stack:                stack,
+	}
+}
+
+// recordSetGroupSynthesizer manages alias records of stack within hosted zones.
+// the ownership of each alias record is tracked by a TXT record of the same name, so that records created by others are never modified.
+// records are looked up by name, so that hosted zones are never listed as a whole.
+type recordSetGroupSynthesizer struct {
+	route53Client        services.Route53
+	allowedHostedZoneIDs sets.String
+	clusterName          string
+	logger               logr.Logger
+	stack                core.Stack
+}
+
+func (s *recordSetGroupSynthesizer) Synthesize(ctx context.Context) error {
+	var resGroups []*route53model.RecordSetGroup
+	s.stack.ListResources(&resGroups)
+	for _, resGroup := range resGroups {
+		if err := s.synthesizeRecordSetGroup(ctx, resGroup); err != nil {
+			return errors.Wrapf(err, "failed to synthesize records in hosted zone: %v", resGroup.Spec.HostedZoneID)
+		}
+	}
+	return nil
+}
+
+func (s *recordSetGroupSynthesizer) PostSynthesize(ctx context.Context) error {
+	// nothing to do here.
+	return nil
+}
+
+// recordsWithName are the records of a DNS name that this synthesizer cares about.
+type recordsWithName struct {
+	alias *route53sdk.ResourceRecordSet
+	txt   *route53sdk.ResourceRecordSet
+}
+
+func (s *recordSetGroupSynthesizer) synthesizeRecordSetGroup(ctx context.Context, resGroup *route53model.RecordSetGroup) error {
+	hostedZoneID := resGroup.Spec.HostedZoneID
+	desiredNames := sets.NewString()
+	for _, rawName := range resGroup.Spec.RecordNames {
+		desiredNames.Insert(normalizeRecordName(rawName))
+	}
+	staleNames := sets.NewString()
+	for _, rawName := range resGroup.Spec.StaleRecordNames {
+		staleNames.Insert(normalizeRecordName(rawName))
+	}
+	staleNames = staleNames.Difference(desiredNames)
+	if !s.allowedHostedZoneIDs.Has(hostedZoneID) {
+		if desiredNames.Len() != 0 {
+			return errors.Errorf("hosted zone %v isn't allowed for records of %v", hostedZoneID, s.stack.StackID())
+		}
+		s.logger.Info("skipping deletion of stale route53 records in hosted zone that isn't allowed",
+			"hostedZoneID", hostedZoneID,
+			"staleRecords", staleNames.List())
+		return nil
+	}
+
+	ownerValue := s.buildOwnerValue()
+	var changes []*route53sdk.Change
+	if desiredNames.Len() != 0 {
+		if resGroup.Spec.AliasTarget == nil {
+			return errors.New("[should never happen] alias target must be specified for records")
+		}
+		hostedZoneName, err := s.describeHostedZoneName(ctx, hostedZoneID)
+		if err != nil {
+			return err
+		}
+		aliasDNSName, err := resGroup.Spec.AliasTarget.DNSName.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		aliasHostedZoneID, err := resGroup.Spec.AliasTarget.HostedZoneID.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range desiredNames.List() {
+			if !strings.HasSuffix(name, "."+hostedZoneName) {
+				return errors.Errorf("record %v isn't within the domain %v of hosted zone", name, hostedZoneName)
+			}
+			records, err := s.describeRecordsWithName(ctx, hostedZoneID, name)
+			if err != nil {
+				return err
+			}
+			if !s.isOwnedRecords(records, ownerValue) && (records.alias != nil || records.txt != nil) {
+				return errors.Errorf("record %v already exists and isn't managed by this controller for %v", name, s.stack.StackID())
+			}
+			if records.alias != nil && isAliasTo(records.alias, aliasDNSName, aliasHostedZoneID) {
+				continue
+			}
+			changes = append(changes,
+				&route53sdk.Change{
+					Action:            awssdk.String(route53sdk.ChangeActionUpsert),
+					ResourceRecordSet: buildOwnerRecord(name, ownerValue),
+				},
+				&route53sdk.Change{
+					Action:            awssdk.String(route53sdk.ChangeActionUpsert),
+					ResourceRecordSet: buildAliasRecord(name, aliasDNSName, aliasHostedZoneID),
+				},
+			)
+		}
+	}
+	for _, name := range staleNames.List() {
+		records, err := s.describeRecordsWithName(ctx, hostedZoneID, name)
+		if err != nil {
+			return err
+		}
+		if !s.isOwnedRecords(records, ownerValue) {
+			continue
+		}
+		if records.alias != nil {
+			changes = append(changes, &route53sdk.Change{
+				Action:            awssdk.String(route53sdk.ChangeActionDelete),
+				ResourceRecordSet: records.alias,
+			})
+		}
+		changes = append(changes, &route53sdk.Change{
+			Action:            awssdk.String(route53sdk.ChangeActionDelete),
+			ResourceRecordSet: records.txt,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	req := &route53sdk.ChangeResourceRecordSetsInput{
+		HostedZoneId: awssdk.String(hostedZoneID),
+		ChangeBatch: &route53sdk.ChangeBatch{
+			Comment: awssdk.String(fmt.Sprintf("managed by aws-load-balancer-controller for %v", s.stack.StackID())),
+			Changes: changes,
+		},
+	}
+	s.logger.Info("changing route53 records",
+		"hostedZoneID", hostedZoneID,
+		"desiredRecords", desiredNames.List(),
+		"staleRecords", staleNames.List())
+	if _, err := s.route53Client.ChangeResourceRecordSetsWithContext(ctx, req); err != nil {
+		return err
+	}
+	s.logger.Info("changed route53 records",
+		"hostedZoneID", hostedZoneID)
+	return nil
+}
+
+// describeHostedZoneName returns the normalized domain name of hosted zone.
+func (s *recordSetGroupSynthesizer) describeHostedZoneName(ctx context.Context, hostedZoneID string) (string, error) {
+	resp, err := s.route53Client.GetHostedZoneWithContext(ctx, &route53sdk.GetHostedZoneInput{
+		Id: awssdk.String(hostedZoneID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.HostedZone == nil {
+		return "", errors.Errorf("hosted zone not found: %v", hostedZoneID)
+	}
+	return normalizeRecordName(awssdk.StringValue(resp.HostedZone.Name)), nil
+}
+
+// describeRecordsWithName looks up the alias and TXT records of name, by starting the listing of hosted zone at each of them.
+func (s *recordSetGroupSynthesizer) describeRecordsWithName(ctx context.Context, hostedZoneID string, name string) (recordsWithName, error) {
+	var records recordsWithName
+	for _, recordType := range []string{route53sdk.RRTypeA, route53sdk.RRTypeTxt} {
+		resp, err := s.route53Client.ListResourceRecordSetsWithContext(ctx, &route53sdk.ListResourceRecordSetsInput{
+			HostedZoneId:    awssdk.String(hostedZoneID),
+			StartRecordName: awssdk.String(name),
+			StartRecordType: awssdk.String(recordType),
+			MaxItems:        awssdk.String("1"),
+		})
+		if err != nil {
+			return recordsWithName{}, err
+		}
+		for _, sdkRecord := range resp.ResourceRecordSets {
+			if normalizeRecordName(awssdk.StringValue(sdkRecord.Name)) != name || awssdk.StringValue(sdkRecord.Type) != recordType {
+				continue
+			}
+			switch recordType {
+			case route53sdk.RRTypeA:
+				records.alias = sdkRecord
+			case route53sdk.RRTypeTxt:
+				records.txt = sdkRecord
+			}
+		}
+	}
+	return records, nil
+}
+
+// isOwnedRecords checks whether records are owned by stack, which is denoted by its TXT record.
+func (s *recordSetGroupSynthesizer) isOwnedRecords(records recordsWithName, ownerValue string) bool {
+	return records.txt != nil && isOwnerRecord(records.txt) && ownerRecordValue(records.txt) == ownerValue
+}
+
+// buildOwnerValue returns the value of TXT records that denotes records owned by stack.
+func (s *recordSetGroupSynthesizer) buildOwnerValue() string {
+	return fmt.Sprintf("%s,cluster=%s,stack=%s", ownerRecordHeritage, s.clusterName, s.stack.StackID())
+}
+
+const ownerRecordHeritage = "heritage=aws-load-balancer-controller"
+
+func isOwnerRecord(sdkRecord *route53sdk.ResourceRecordSet) bool {
+	return strings.HasPrefix(ownerRecordValue(sdkRecord), ownerRecordHeritage)
+}
+
+func ownerRecordValue(sdkRecord *route53sdk.ResourceRecordSet) string {
+	if len(sdkRecord.ResourceRecords) != 1 {
+		return ""
+	}
+	return strings.Trim(awssdk.StringValue(sdkRecord.ResourceRecords[0].Value), `"`)
+}
+
+func isAliasTo(sdkRecord *route53sdk.ResourceRecordSet, dnsName string, hostedZoneID string) bool {
+	if sdkRecord.AliasTarget == nil {
+		return false
+	}
+	return normalizeRecordName(awssdk.StringValue(sdkRecord.AliasTarget.DNSName)) == normalizeRecordName(dnsName) &&
+		awssdk.StringValue(sdkRecord.AliasTarget.HostedZoneId) == hostedZoneID
+}
+
+func buildOwnerRecord(name string, ownerValue string) *route53sdk.ResourceRecordSet {
+	return &route53sdk.ResourceRecordSet{
+		Name: awssdk.String(name),
+		Type: awssdk.String(route53sdk.RRTypeTxt),
+		TTL:  awssdk.Int64(300),
+		ResourceRecords: []*route53sdk.ResourceRecord{
+			{Value: awssdk.String(fmt.Sprintf("%q", ownerValue))},
+		},
+	}
+}
+
+func buildAliasRecord(name string, dnsName string, hostedZoneID string) *route53sdk.ResourceRecordSet {
+	return &route53sdk.ResourceRecordSet{
+		Name: awssdk.String(name),
+		Type: awssdk.String(route53sdk.RRTypeA),
+		AliasTarget: &route53sdk.AliasTarget{
+			DNSName:              awssdk.String(dnsName),
+			HostedZoneId:         awssdk.String(hostedZoneID),
+			EvaluateTargetHealth: awssdk.Bool(true),
+		},
+	}
+}
+
+// normalizeRecordName normalizes DNS name into lower case with trailing dot, as returned by Route 53.
+func normalizeRecordName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
@@ -0,0 +1,206 @@
+package route53
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	route53sdk "github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_recordSetGroupSynthesizer_synthesizeRecordSetGroup(t *testing.T) {
+	const ownerValue = `"heritage=aws-load-balancer-controller,cluster=cluster-name,stack=awesome-group"`
+	ownerRecord := func(name string, value string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name:            awssdk.String(name),
+			Type:            awssdk.String("TXT"),
+			TTL:             awssdk.Int64(300),
+			ResourceRecords: []*route53sdk.ResourceRecord{{Value: awssdk.String(value)}},
+		}
+	}
+	aliasRecord := func(name string, dnsName string) *route53sdk.ResourceRecordSet {
+		return &route53sdk.ResourceRecordSet{
+			Name: awssdk.String(name),
+			Type: awssdk.String("A"),
+			AliasTarget: &route53sdk.AliasTarget{
+				DNSName:              awssdk.String(dnsName),
+				HostedZoneId:         awssdk.String("Z35SXDOTRQ7X7K"),
+				EvaluateTargetHealth: awssdk.Bool(true),
+			},
+		}
+	}
+	aliasTarget := &route53model.AliasTarget{
+		DNSName:      core.LiteralStringToken("my-alb-1234.us-west-2.elb.amazonaws.com"),
+		HostedZoneID: core.LiteralStringToken("Z35SXDOTRQ7X7K"),
+	}
+	tests := []struct {
+		name                 string
+		spec                 route53model.RecordSetGroupSpec
+		allowedHostedZoneIDs []string
+		hostedZoneName       string
+		recordsByName        map[string][]*route53sdk.ResourceRecordSet
+		wantChanges          []*route53sdk.Change
+		wantErr              error
+	}{
+		{
+			name: "records absent",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID: "Z1",
+				RecordNames:  []string{"PR-1.preview.example.com"},
+				AliasTarget:  aliasTarget,
+			},
+			allowedHostedZoneIDs: []string{"Z1"},
+			hostedZoneName:       "preview.example.com.",
+			recordsByName: map[string][]*route53sdk.ResourceRecordSet{
+				"pr-1.preview.example.com.": {
+					ownerRecord("pr-2.preview.example.com.", ownerValue),
+				},
+			},
+			wantChanges: []*route53sdk.Change{
+				{Action: awssdk.String("UPSERT"), ResourceRecordSet: ownerRecord("pr-1.preview.example.com.", ownerValue)},
+				{Action: awssdk.String("UPSERT"), ResourceRecordSet: aliasRecord("pr-1.preview.example.com.", "my-alb-1234.us-west-2.elb.amazonaws.com")},
+			},
+		},
+		{
+			name: "records up to date",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID: "Z1",
+				RecordNames:  []string{"pr-1.preview.example.com"},
+				AliasTarget:  aliasTarget,
+			},
+			allowedHostedZoneIDs: []string{"Z1"},
+			hostedZoneName:       "example.com",
+			recordsByName: map[string][]*route53sdk.ResourceRecordSet{
+				"pr-1.preview.example.com.": {
+					aliasRecord("pr-1.preview.example.com.", "my-alb-1234.us-west-2.elb.amazonaws.com."),
+					ownerRecord("pr-1.preview.example.com.", ownerValue),
+				},
+			},
+		},
+		{
+			name: "stale records owned are deleted, while records of others are kept",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID:     "Z1",
+				StaleRecordNames: []string{"pr-1.preview.example.com", "pr-2.preview.example.com"},
+			},
+			allowedHostedZoneIDs: []string{"Z1"},
+			recordsByName: map[string][]*route53sdk.ResourceRecordSet{
+				"pr-1.preview.example.com.": {
+					aliasRecord("pr-1.preview.example.com.", "my-alb-1234.us-west-2.elb.amazonaws.com."),
+					ownerRecord("pr-1.preview.example.com.", ownerValue),
+				},
+				"pr-2.preview.example.com.": {
+					aliasRecord("pr-2.preview.example.com.", "other-alb-1234.us-west-2.elb.amazonaws.com."),
+					ownerRecord("pr-2.preview.example.com.", `"heritage=aws-load-balancer-controller,cluster=cluster-name,stack=other-group"`),
+				},
+			},
+			wantChanges: []*route53sdk.Change{
+				{Action: awssdk.String("DELETE"), ResourceRecordSet: aliasRecord("pr-1.preview.example.com.", "my-alb-1234.us-west-2.elb.amazonaws.com.")},
+				{Action: awssdk.String("DELETE"), ResourceRecordSet: ownerRecord("pr-1.preview.example.com.", ownerValue)},
+			},
+		},
+		{
+			name: "stale records in hosted zone that isn't allowed are kept",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID:     "Z1",
+				StaleRecordNames: []string{"pr-1.preview.example.com"},
+			},
+			allowedHostedZoneIDs: []string{"Z2"},
+		},
+		{
+			name: "records of others conflict",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID: "Z1",
+				RecordNames:  []string{"pr-2.preview.example.com"},
+				AliasTarget:  aliasTarget,
+			},
+			allowedHostedZoneIDs: []string{"Z1"},
+			hostedZoneName:       "preview.example.com.",
+			recordsByName: map[string][]*route53sdk.ResourceRecordSet{
+				"pr-2.preview.example.com.": {
+					aliasRecord("pr-2.preview.example.com.", "other-alb-1234.us-west-2.elb.amazonaws.com."),
+				},
+			},
+			wantErr: errors.New("record pr-2.preview.example.com. already exists and isn't managed by this controller for awesome-group"),
+		},
+		{
+			name: "hosted zone isn't allowed",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID: "Z1",
+				RecordNames:  []string{"pr-1.preview.example.com"},
+				AliasTarget:  aliasTarget,
+			},
+			allowedHostedZoneIDs: []string{"Z2"},
+			wantErr:              errors.New("hosted zone Z1 isn't allowed for records of awesome-group"),
+		},
+		{
+			name: "record isn't within domain of hosted zone",
+			spec: route53model.RecordSetGroupSpec{
+				HostedZoneID: "Z1",
+				RecordNames:  []string{"www.example.org"},
+				AliasTarget:  aliasTarget,
+			},
+			allowedHostedZoneIDs: []string{"Z1"},
+			hostedZoneName:       "example.com.",
+			wantErr:              errors.New("record www.example.org. isn't within the domain example.com. of hosted zone"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			route53Client := services.NewMockRoute53(ctrl)
+			if tt.hostedZoneName != "" {
+				route53Client.EXPECT().GetHostedZoneWithContext(gomock.Any(), &route53sdk.GetHostedZoneInput{
+					Id: awssdk.String("Z1"),
+				}).Return(&route53sdk.GetHostedZoneOutput{
+					HostedZone: &route53sdk.HostedZone{Id: awssdk.String("Z1"), Name: awssdk.String(tt.hostedZoneName)},
+				}, nil)
+			}
+			for name, records := range tt.recordsByName {
+				for _, recordType := range []string{"A", "TXT"} {
+					var matchedRecords []*route53sdk.ResourceRecordSet
+					for _, record := range records {
+						if awssdk.StringValue(record.Type) >= recordType {
+							matchedRecords = append(matchedRecords, record)
+							break
+						}
+					}
+					route53Client.EXPECT().ListResourceRecordSetsWithContext(gomock.Any(), &route53sdk.ListResourceRecordSetsInput{
+						HostedZoneId:    awssdk.String("Z1"),
+						StartRecordName: awssdk.String(name),
+						StartRecordType: awssdk.String(recordType),
+						MaxItems:        awssdk.String("1"),
+					}).Return(&route53sdk.ListResourceRecordSetsOutput{ResourceRecordSets: matchedRecords}, nil)
+				}
+			}
+			if len(tt.wantChanges) != 0 {
+				route53Client.EXPECT().ChangeResourceRecordSetsWithContext(gomock.Any(), &route53sdk.ChangeResourceRecordSetsInput{
+					HostedZoneId: awssdk.String("Z1"),
+					ChangeBatch: &route53sdk.ChangeBatch{
+						Comment: awssdk.String("managed by aws-load-balancer-controller for awesome-group"),
+						Changes: tt.wantChanges,
+					},
+				}).Return(&route53sdk.ChangeResourceRecordSetsOutput{}, nil)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			resGroup := route53model.NewRecordSetGroup(stack, tt.spec.HostedZoneID, tt.spec)
+			s := NewRecordSetGroupSynthesizer(route53Client, tt.allowedHostedZoneIDs, "cluster-name", &log.NullLogger{}, stack)
+			err := s.synthesizeRecordSetGroup(context.Background(), resGroup)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
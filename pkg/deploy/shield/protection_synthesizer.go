@@ -45,6 +45,10 @@ func (s *protectionSynthesizer) Synthesize(ctx context.Context) error {
 		if resLB.Spec.Type != elbv2model.LoadBalancerTypeApplication {
 			continue
 		}
+		// shield protection of existing LoadBalancer is managed outside of the controller.
+		if resLB.Spec.ExistingLoadBalancerARN != nil {
+			continue
+		}
 		lbARN, err := resLB.LoadBalancerARN().Resolve(ctx)
 		if err != nil {
 			return err
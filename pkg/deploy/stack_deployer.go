@@ -3,22 +3,27 @@ package deploy
 import (
 	"context"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/ec2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/route53"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/shield"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafregional"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/wafv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // StackDeployer will deploy a resource stack into AWS and K8S.
 type StackDeployer interface {
 	// Deploy a resource stack.
+	// a RequeueNeededAfter error is returned if the stack is deployed, but some of the steps must be completed by a later deployment.
 	Deploy(ctx context.Context, stack core.Stack) error
 }
 
@@ -30,6 +35,7 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 	trackingProvider := tracking.NewDefaultProvider(tagPrefix, config.ClusterName)
 	ec2TaggingManager := ec2.NewDefaultTaggingManager(cloud.EC2(), networkingSGManager, cloud.VpcID(), logger)
 	elbv2TaggingManager := elbv2.NewDefaultTaggingManager(cloud.ELBV2(), cloud.VpcID(), config.FeatureGates, logger)
+	accessLogsConfigurator := elbv2.NewDefaultAccessLogsConfigurator(cloud.S3(), cloud.Region(), cloud.Partition(), config.EnableAccessLogsBucketPolicy, logger)
 
 	return &defaultStackDeployer{
 		cloud:                               cloud,
@@ -39,15 +45,17 @@ func NewDefaultStackDeployer(cloud aws.Cloud, k8sClient client.Client,
 		ec2TaggingManager:                   ec2TaggingManager,
 		ec2SGManager:                        ec2.NewDefaultSecurityGroupManager(cloud.EC2(), trackingProvider, ec2TaggingManager, networkingSGReconciler, cloud.VpcID(), config.ExternalManagedTags, logger),
 		elbv2TaggingManager:                 elbv2TaggingManager,
-		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, logger),
-		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, config.FeatureGates, logger),
+		elbv2LBManager:                      elbv2.NewDefaultLoadBalancerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, accessLogsConfigurator, config.ExternalManagedTags, logger),
+		elbv2LSManager:                      elbv2.NewDefaultListenerManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, config.FeatureGates, config.ListenerCertificateRemovalGracePeriod, logger),
 		elbv2LRManager:                      elbv2.NewDefaultListenerRuleManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, config.ExternalManagedTags, config.FeatureGates, logger),
-		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), config.ExternalManagedTags, logger),
+		elbv2TGManager:                      elbv2.NewDefaultTargetGroupManager(cloud.ELBV2(), cloud.Lambda(), trackingProvider, elbv2TaggingManager, cloud.VpcID(), config.ExternalManagedTags, logger),
 		elbv2TGBManager:                     elbv2.NewDefaultTargetGroupBindingManager(k8sClient, trackingProvider, logger),
 		wafv2WebACLAssociationManager:       wafv2.NewDefaultWebACLAssociationManager(cloud.WAFv2(), logger),
 		wafRegionalWebACLAssociationManager: wafregional.NewDefaultWebACLAssociationManager(cloud.WAFRegional(), logger),
 		shieldProtectionManager:             shield.NewDefaultProtectionManager(cloud.Shield(), logger),
+		cloudWatchDashboardManager:          cloudwatch.NewDefaultDashboardManager(cloud.CloudWatch(), logger),
 		vpcID:                               cloud.VpcID(),
+		clusterName:                         config.ClusterName,
 		logger:                              logger,
 	}
 }
@@ -71,7 +79,9 @@ type defaultStackDeployer struct {
 	wafv2WebACLAssociationManager       wafv2.WebACLAssociationManager
 	wafRegionalWebACLAssociationManager wafregional.WebACLAssociationManager
 	shieldProtectionManager             shield.ProtectionManager
+	cloudWatchDashboardManager          cloudwatch.DashboardManager
 	vpcID                               string
+	clusterName                         string
 
 	logger logr.Logger
 }
@@ -87,7 +97,7 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) err
 		ec2.NewSecurityGroupSynthesizer(d.cloud.EC2(), d.trackingProvider, d.ec2TaggingManager, d.ec2SGManager, d.vpcID, d.logger, stack),
 		elbv2.NewTargetGroupSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2TGManager, d.logger, stack),
 		elbv2.NewLoadBalancerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LBManager, d.logger, stack),
-		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
+		elbv2.NewListenerSynthesizer(d.cloud.ELBV2(), d.trackingProvider, d.elbv2TaggingManager, d.elbv2LSManager, d.logger, stack),
 		elbv2.NewListenerRuleSynthesizer(d.cloud.ELBV2(), d.elbv2TaggingManager, d.elbv2LRManager, d.logger, stack),
 		elbv2.NewTargetGroupBindingSynthesizer(d.k8sClient, d.trackingProvider, d.elbv2TGBManager, d.logger, stack),
 	}
@@ -106,17 +116,31 @@ func (d *defaultStackDeployer) Deploy(ctx context.Context, stack core.Stack) err
 			synthesizers = append(synthesizers, shield.NewProtectionSynthesizer(d.shieldProtectionManager, d.logger, stack))
 		}
 	}
+	if d.addonsConfig.CloudWatchDashboardEnabled {
+		synthesizers = append(synthesizers, cloudwatch.NewDashboardSynthesizer(d.cloudWatchDashboardManager, d.cloud.Region(), d.clusterName, d.logger, stack))
+	}
+	if d.addonsConfig.Route53RecordsEnabled {
+		synthesizers = append(synthesizers, route53.NewRecordSetGroupSynthesizer(d.cloud.Route53(), d.addonsConfig.Route53AllowedHostedZoneIDs, d.clusterName, d.logger, stack))
+	}
 
+	// synthesizers requesting requeue are done for now, thus the deployment moves on and the requeue is requested at end.
+	var requeueNeededAfter *runtime.RequeueNeededAfter
 	for _, synthesizer := range synthesizers {
 		if err := synthesizer.Synthesize(ctx); err != nil {
-			return err
+			if !errors.As(err, &requeueNeededAfter) {
+				return err
+			}
 		}
 	}
 	for i := len(synthesizers) - 1; i >= 0; i-- {
 		if err := synthesizers[i].PostSynthesize(ctx); err != nil {
-			return err
+			if !errors.As(err, &requeueNeededAfter) {
+				return err
+			}
 		}
 	}
-
+	if requeueNeededAfter != nil {
+		return requeueNeededAfter
+	}
 	return nil
 }
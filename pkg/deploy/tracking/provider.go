@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/version"
 )
 
 //we use AWS tags and K8s labels to track resources we have created.
 //
 //For AWS resources created by this controller, the tagging strategy is as follows:
 //  * `elbv2.k8s.aws/cluster: cluster-name` will be applied on all AWS resources.
+//  * `elbv2.k8s.aws/controller-version: version` will be applied on all AWS resources, with the version of controller that last reconciled them.
 //  * `ingress.k8s.aws/stack: stack-id` will be applied on all AWS resources provisioned for Ingress resources:
 //    * For explicit IngressGroup, `stack-id` will be `groupName`
 //    * For implicit IngressGroup, `stack-id` will be `namespace/ingressName`
@@ -38,11 +40,23 @@ const clusterNameTagKey = "elbv2.k8s.aws/cluster"
 // Legacy AWS TagKey for cluster resources, which is used by AWSALBIngressController(v1.1.3+)
 const clusterNameTagKeyLegacy = "ingress.k8s.aws/cluster"
 
+// AWS TagKey for the version of controller that last reconciled resources.
+const controllerVersionTagKey = "elbv2.k8s.aws/controller-version"
+
 // an abstraction that generates metadata to track actual resources provisioned for stack.
 type Provider interface {
 	// ResourceIDTagKey provide the tagKey for resourceID.
 	ResourceIDTagKey() string
 
+	// StackIDTagKey provide the tagKey for stackID.
+	StackIDTagKey() string
+
+	// ControllerVersionTagKey provide the tagKey for the version of controller that last reconciled resources.
+	ControllerVersionTagKey() string
+
+	// ClusterTags provide the tags shared by all resources within cluster.
+	ClusterTags() map[string]string
+
 	// StackTags provide the tags for stack.
 	StackTags(stack core.Stack) map[string]string
 
@@ -65,8 +79,9 @@ type Provider interface {
 // NewDefaultProvider constructs defaultProvider
 func NewDefaultProvider(tagPrefix string, clusterName string) *defaultProvider {
 	return &defaultProvider{
-		tagPrefix:   tagPrefix,
-		clusterName: clusterName,
+		tagPrefix:         tagPrefix,
+		clusterName:       clusterName,
+		controllerVersion: version.GitVersion,
 	}
 }
 
@@ -76,17 +91,33 @@ var _ Provider = &defaultProvider{}
 type defaultProvider struct {
 	tagPrefix   string
 	clusterName string
+	// controllerVersion is stamped onto resources, it's empty for development builds.
+	controllerVersion string
 }
 
 func (p *defaultProvider) ResourceIDTagKey() string {
 	return p.prefixedTrackingKey("resource")
 }
 
+func (p *defaultProvider) StackIDTagKey() string {
+	return p.prefixedTrackingKey("stack")
+}
+
+func (p *defaultProvider) ControllerVersionTagKey() string {
+	return controllerVersionTagKey
+}
+
+func (p *defaultProvider) ClusterTags() map[string]string {
+	return map[string]string{
+		clusterNameTagKey: p.clusterName,
+	}
+}
+
 func (p *defaultProvider) StackTags(stack core.Stack) map[string]string {
 	stackID := stack.StackID()
 	return map[string]string{
-		clusterNameTagKey:              p.clusterName,
-		p.prefixedTrackingKey("stack"): stackID.String(),
+		clusterNameTagKey: p.clusterName,
+		p.StackIDTagKey(): stackID.String(),
 	}
 }
 
@@ -95,6 +126,9 @@ func (p *defaultProvider) ResourceTags(stack core.Stack, res core.Resource, addi
 	resourceIDTags := map[string]string{
 		p.ResourceIDTagKey(): res.ID(),
 	}
+	if p.controllerVersion != "" {
+		resourceIDTags[controllerVersionTagKey] = p.controllerVersion
+	}
 	return algorithm.MergeStringMap(stackTags, resourceIDTags, additionalTags)
 }
 
@@ -134,3 +168,17 @@ func (p *defaultProvider) LegacyTagKeys() []string {
 func (p *defaultProvider) prefixedTrackingKey(tag string) string {
 	return fmt.Sprintf("%v/%v", p.tagPrefix, tag)
 }
+
+// StackIDFromLabels decodes the stackID from k8s labels provided by StackLabels of a provider with tagPrefix.
+// returns false if labels don't track a stack for tagPrefix.
+func StackIDFromLabels(tagPrefix string, labels map[string]string) (core.StackID, bool) {
+	if name, ok := labels[fmt.Sprintf("%v/stack", tagPrefix)]; ok {
+		return core.StackID{Namespace: "", Name: name}, true
+	}
+	namespace, hasNamespace := labels[fmt.Sprintf("%v/stack-namespace", tagPrefix)]
+	name, hasName := labels[fmt.Sprintf("%v/stack-name", tagPrefix)]
+	if !hasNamespace || !hasName {
+		return core.StackID{}, false
+	}
+	return core.StackID{Namespace: namespace, Name: name}, true
+}
@@ -31,6 +31,37 @@ func Test_defaultProvider_ResourceIDTagKey(t *testing.T) {
 	}
 }
 
+func Test_defaultProvider_StackIDTagKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *defaultProvider
+		want     string
+	}{
+		{
+			name:     "stackTagKey for Ingress",
+			provider: NewDefaultProvider("ingress.k8s.aws", "cluster-name"),
+			want:     "ingress.k8s.aws/stack",
+		},
+		{
+			name:     "stackTagKey for Service",
+			provider: NewDefaultProvider("service.k8s.aws", "cluster-name"),
+			want:     "service.k8s.aws/stack",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.provider.StackIDTagKey()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultProvider_ClusterTags(t *testing.T) {
+	provider := NewDefaultProvider("ingress.k8s.aws", "cluster-name")
+	got := provider.ClusterTags()
+	assert.Equal(t, map[string]string{"elbv2.k8s.aws/cluster": "cluster-name"}, got)
+}
+
 func Test_defaultProvider_StackTags(t *testing.T) {
 	type args struct {
 		stack core.Stack
@@ -105,6 +136,24 @@ func Test_defaultProvider_ResourceTags(t *testing.T) {
 				"ingress.k8s.aws/resource": "fake-id",
 			},
 		},
+		{
+			name: "resourceTags with controller version",
+			provider: &defaultProvider{
+				tagPrefix:         "ingress.k8s.aws",
+				clusterName:       "cluster-name",
+				controllerVersion: "v2.3.0",
+			},
+			args: args{
+				stack: stack,
+				res:   fakeRes,
+			},
+			want: map[string]string{
+				"elbv2.k8s.aws/cluster":            "cluster-name",
+				"elbv2.k8s.aws/controller-version": "v2.3.0",
+				"ingress.k8s.aws/stack":            "namespace/ingressName",
+				"ingress.k8s.aws/resource":         "fake-id",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -159,6 +208,66 @@ func Test_defaultProvider_StackLabels(t *testing.T) {
 	}
 }
 
+func TestStackIDFromLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagPrefix string
+		labels    map[string]string
+		want      core.StackID
+		wantFound bool
+	}{
+		{
+			name:      "labels for explicit IngressGroup",
+			tagPrefix: "ingress.k8s.aws",
+			labels: map[string]string{
+				"ingress.k8s.aws/stack": "awesome-group",
+			},
+			want:      core.StackID{Namespace: "", Name: "awesome-group"},
+			wantFound: true,
+		},
+		{
+			name:      "labels for implicit IngressGroup",
+			tagPrefix: "ingress.k8s.aws",
+			labels: map[string]string{
+				"ingress.k8s.aws/stack-namespace": "namespace",
+				"ingress.k8s.aws/stack-name":      "ingressName",
+			},
+			want:      core.StackID{Namespace: "namespace", Name: "ingressName"},
+			wantFound: true,
+		},
+		{
+			name:      "labels for Service with Ingress tagPrefix",
+			tagPrefix: "ingress.k8s.aws",
+			labels: map[string]string{
+				"service.k8s.aws/stack-namespace": "namespace",
+				"service.k8s.aws/stack-name":      "serviceName",
+			},
+			wantFound: false,
+		},
+		{
+			name:      "incomplete labels",
+			tagPrefix: "service.k8s.aws",
+			labels: map[string]string{
+				"service.k8s.aws/stack-name": "serviceName",
+			},
+			wantFound: false,
+		},
+		{
+			name:      "no labels",
+			tagPrefix: "service.k8s.aws",
+			labels:    nil,
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := StackIDFromLabels(tt.tagPrefix, tt.labels)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_defaultProvider_StackTagsLegacy(t *testing.T) {
 	type args struct {
 		stack core.Stack
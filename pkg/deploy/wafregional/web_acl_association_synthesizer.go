@@ -39,6 +39,10 @@ func (s *webACLAssociationSynthesizer) Synthesize(ctx context.Context) error {
 		if resLB.Spec.Type != elbv2model.LoadBalancerTypeApplication {
 			continue
 		}
+		// wafRegional WebACL association of existing LoadBalancer is managed outside of the controller.
+		if resLB.Spec.ExistingLoadBalancerARN != nil {
+			continue
+		}
 		lbARN, err := resLB.LoadBalancerARN().Resolve(ctx)
 		if err != nil {
 			return err
@@ -0,0 +1,133 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations/schema"
+)
+
+// Test_annotationSchemaConsistentWithParsers cross-checks that the annotation values accepted by the schema,
+// which the Ingress webhook validates against, are exactly the values accepted when building the model.
+func Test_annotationSchemaConsistentWithParsers(t *testing.T) {
+	annotationParser := annotations.NewSuffixAnnotationParser(annotations.AnnotationPrefixIngress)
+	buildIngress := func(suffix string, rawValue string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "ing",
+				Annotations: map[string]string{
+					fmt.Sprintf("%v/%v", annotations.AnnotationPrefixIngress, suffix): rawValue,
+				},
+			},
+		}
+	}
+	parseTargetGroupAttributes := func(suffix string, rawValue string) error {
+		task := &defaultModelBuildTask{annotationParser: annotationParser}
+		ing := buildIngress(suffix, rawValue)
+		attributes, err := task.buildTargetGroupTypedAttributes(context.Background(), ing.Annotations)
+		if err != nil {
+			return err
+		}
+		return task.buildTargetGroupHealthAttributes(attributes, ing.Annotations)
+	}
+	parseLoadBalancerAttributes := func(suffix string, rawValue string) error {
+		task := &defaultModelBuildTask{annotationParser: annotationParser}
+		attributes, err := task.buildIngressLoadBalancerTypedAttributes(ClassifiedIngress{Ing: buildIngress(suffix, rawValue)})
+		if err != nil {
+			return err
+		}
+		return validateLoadBalancerAttributes(attributes)
+	}
+	parseAWSRequestConfig := func(suffix string, rawValue string) error {
+		b := NewDefaultAWSRequestConfigBuilder(annotationParser)
+		_, err := b.Build(context.Background(), Group{Members: []ClassifiedIngress{{Ing: buildIngress(suffix, rawValue)}}})
+		return err
+	}
+	parseGroupOrder := func(suffix string, rawValue string) error {
+		m := &defaultGroupLoader{annotationParser: annotationParser}
+		_, err := m.sortGroupMembers([]ClassifiedIngress{{Ing: buildIngress(suffix, rawValue)}})
+		return err
+	}
+
+	tests := []struct {
+		suffix      string
+		parse       func(suffix string, rawValue string) error
+		extraValues []string
+	}{
+		{
+			suffix:      annotations.IngressSuffixSlowStartSeconds,
+			parse:       parseTargetGroupAttributes,
+			extraValues: []string{"1", "29", "30"},
+		},
+		{
+			suffix: annotations.IngressSuffixStickinessDurationSeconds,
+			parse:  parseTargetGroupAttributes,
+		},
+		{
+			suffix:      annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsCount,
+			parse:       parseTargetGroupAttributes,
+			extraValues: []string{"off", "0", "1", "2147483647", "2147483648", "abc"},
+		},
+		{
+			suffix:      annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsPercentage,
+			parse:       parseTargetGroupAttributes,
+			extraValues: []string{"off", "0", "1", "100", "101", "abc"},
+		},
+		{
+			suffix:      annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsCount,
+			parse:       parseTargetGroupAttributes,
+			extraValues: []string{"2147483647"},
+		},
+		{
+			suffix:      annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsPercentage,
+			parse:       parseTargetGroupAttributes,
+			extraValues: []string{"off", "0", "1", "100", "101", "abc"},
+		},
+		{
+			suffix: annotations.IngressSuffixIdleTimeoutSeconds,
+			parse:  parseLoadBalancerAttributes,
+		},
+		{
+			suffix: annotations.IngressSuffixClientKeepAliveSeconds,
+			parse:  parseLoadBalancerAttributes,
+		},
+		{
+			suffix: annotations.IngressSuffixAWSAPIMaxRetries,
+			parse:  parseAWSRequestConfig,
+		},
+		{
+			suffix: annotations.IngressSuffixAWSAPITimeoutSeconds,
+			parse:  parseAWSRequestConfig,
+		},
+		{
+			suffix: annotations.IngressSuffixGroupOrder,
+			parse:  parseGroupOrder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.suffix, func(t *testing.T) {
+			field, ok := schema.FindIngressField(tt.suffix)
+			assert.True(t, ok)
+			rawValues := append([]string{"1h"}, tt.extraValues...)
+			if field.Minimum != nil {
+				rawValues = append(rawValues, strconv.FormatInt(*field.Minimum-1, 10), strconv.FormatInt(*field.Minimum, 10))
+			}
+			if field.Maximum != nil {
+				rawValues = append(rawValues, strconv.FormatInt(*field.Maximum, 10), strconv.FormatInt(*field.Maximum+1, 10))
+			}
+			for _, rawValue := range rawValues {
+				schemaErr := field.ValidateValue(rawValue)
+				parserErr := tt.parse(tt.suffix, rawValue)
+				assert.Equal(t, schemaErr == nil, parserErr == nil,
+					"value %v, schema error: %v, parser error: %v", rawValue, schemaErr, parserErr)
+			}
+		})
+	}
+}
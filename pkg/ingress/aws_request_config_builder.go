@@ -0,0 +1,108 @@
+package ingress
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+)
+
+const (
+	minAWSAPIMaxRetries     = 0
+	maxAWSAPIMaxRetries     = 100
+	minAWSAPITimeoutSeconds = 1
+	maxAWSAPITimeoutSeconds = 3600
+)
+
+// AWSRequestConfigBuilder builds the config of AWS API calls made when reconciling IngressGroup.
+type AWSRequestConfigBuilder interface {
+	Build(ctx context.Context, ingGroup Group) (aws.RequestConfig, error)
+}
+
+// NewDefaultAWSRequestConfigBuilder constructs new defaultAWSRequestConfigBuilder.
+func NewDefaultAWSRequestConfigBuilder(annotationParser annotations.Parser) *defaultAWSRequestConfigBuilder {
+	return &defaultAWSRequestConfigBuilder{
+		annotationParser: annotationParser,
+	}
+}
+
+var _ AWSRequestConfigBuilder = &defaultAWSRequestConfigBuilder{}
+
+// default implementation for AWSRequestConfigBuilder
+type defaultAWSRequestConfigBuilder struct {
+	annotationParser annotations.Parser
+}
+
+func (b *defaultAWSRequestConfigBuilder) Build(_ context.Context, ingGroup Group) (aws.RequestConfig, error) {
+	ingAnnotationsList := buildAWSRequestConfigIngressAnnotationsList(ingGroup)
+	maxRetries, err := b.buildExclusiveInt64Annotation(annotations.IngressSuffixAWSAPIMaxRetries, ingAnnotationsList)
+	if err != nil {
+		return aws.RequestConfig{}, err
+	}
+	timeoutSeconds, err := b.buildExclusiveInt64Annotation(annotations.IngressSuffixAWSAPITimeoutSeconds, ingAnnotationsList)
+	if err != nil {
+		return aws.RequestConfig{}, err
+	}
+
+	var requestConfig aws.RequestConfig
+	if maxRetries != nil {
+		if *maxRetries < minAWSAPIMaxRetries || *maxRetries > maxAWSAPIMaxRetries {
+			return aws.RequestConfig{}, errors.Errorf("%v must be within [%v, %v]: %v",
+				annotations.IngressSuffixAWSAPIMaxRetries, minAWSAPIMaxRetries, maxAWSAPIMaxRetries, *maxRetries)
+		}
+		retries := int(*maxRetries)
+		requestConfig.MaxRetries = &retries
+	}
+	if timeoutSeconds != nil {
+		if *timeoutSeconds < minAWSAPITimeoutSeconds || *timeoutSeconds > maxAWSAPITimeoutSeconds {
+			return aws.RequestConfig{}, errors.Errorf("%v must be within [%v, %v]: %v",
+				annotations.IngressSuffixAWSAPITimeoutSeconds, minAWSAPITimeoutSeconds, maxAWSAPITimeoutSeconds, *timeoutSeconds)
+		}
+		timeout := time.Duration(*timeoutSeconds) * time.Second
+		requestConfig.Timeout = &timeout
+	}
+	return requestConfig, nil
+}
+
+// buildExclusiveInt64Annotation returns the value of annotation specified by Ingresses, which must be consistent.
+// returns nil if none of the Ingresses specified it.
+func (b *defaultAWSRequestConfigBuilder) buildExclusiveInt64Annotation(annotation string, ingAnnotationsList []map[string]string) (*int64, error) {
+	explicitValues := sets.NewInt64()
+	for _, ingAnnotations := range ingAnnotationsList {
+		var rawValue int64
+		exists, err := b.annotationParser.ParseInt64Annotation(annotation, &rawValue, ingAnnotations)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			explicitValues.Insert(rawValue)
+		}
+	}
+	if len(explicitValues) == 0 {
+		return nil, nil
+	}
+	if len(explicitValues) > 1 {
+		return nil, errors.Errorf("conflicting %v: %v", annotation, explicitValues.List())
+	}
+	value, _ := explicitValues.PopAny()
+	return &value, nil
+}
+
+// buildAWSRequestConfigIngressAnnotationsList returns the annotations of Ingresses that configure AWS API calls of IngressGroup.
+// once IngressGroup has no members left, its AWS resources are cleaned up on behalf of the inactive members,
+// thus their annotations apply, so that the cleanup honors the same overrides as the deployment did.
+func buildAWSRequestConfigIngressAnnotationsList(ingGroup Group) []map[string]string {
+	var ingAnnotationsList []map[string]string
+	for _, member := range ingGroup.Members {
+		ingAnnotationsList = append(ingAnnotationsList, member.Ing.Annotations)
+	}
+	if len(ingGroup.Members) == 0 {
+		for _, inactiveMember := range ingGroup.InactiveMembers {
+			ingAnnotationsList = append(ingAnnotationsList, inactiveMember.Annotations)
+		}
+	}
+	return ingAnnotationsList
+}
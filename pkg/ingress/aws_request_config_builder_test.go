@@ -0,0 +1,190 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws"
+)
+
+func Test_defaultAWSRequestConfigBuilder_Build(t *testing.T) {
+	maxRetries0 := 0
+	maxRetries20 := 20
+	timeout5m := 5 * time.Minute
+	tests := []struct {
+		name                       string
+		membersAnnotations         []map[string]string
+		inactiveMembersAnnotations []map[string]string
+		want                       aws.RequestConfig
+		wantErr                    error
+	}{
+		{
+			name: "no annotations",
+			membersAnnotations: []map[string]string{
+				{},
+			},
+			want: aws.RequestConfig{},
+		},
+		{
+			name: "both annotations specified",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries":     "20",
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+			},
+			want: aws.RequestConfig{
+				MaxRetries: &maxRetries20,
+				Timeout:    &timeout5m,
+			},
+		},
+		{
+			name: "zero retries",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries": "0",
+				},
+			},
+			want: aws.RequestConfig{
+				MaxRetries: &maxRetries0,
+			},
+		},
+		{
+			name: "consistent annotations across members",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+				{},
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+			},
+			want: aws.RequestConfig{
+				Timeout: &timeout5m,
+			},
+		},
+		{
+			name: "conflicting annotations across members",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries": "20",
+				},
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries": "5",
+				},
+			},
+			wantErr: errors.New("conflicting aws-api-max-retries: [5 20]"),
+		},
+		{
+			name: "inactive members of IngressGroup being deleted",
+			inactiveMembersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries":     "20",
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+			},
+			want: aws.RequestConfig{
+				MaxRetries: &maxRetries20,
+				Timeout:    &timeout5m,
+			},
+		},
+		{
+			name: "inactive members are ignored while IngressGroup has members",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries": "0",
+				},
+			},
+			inactiveMembersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries":     "20",
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+			},
+			want: aws.RequestConfig{
+				MaxRetries: &maxRetries0,
+			},
+		},
+		{
+			name: "conflicting annotations across inactive members",
+			inactiveMembersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "300",
+				},
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "60",
+				},
+			},
+			wantErr: errors.New("conflicting aws-api-timeout-seconds: [60 300]"),
+		},
+		{
+			name: "negative retries",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-max-retries": "-1",
+				},
+			},
+			wantErr: errors.New("aws-api-max-retries must be within [0, 100]: -1"),
+		},
+		{
+			name: "non-positive timeout",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "0",
+				},
+			},
+			wantErr: errors.New("aws-api-timeout-seconds must be within [1, 3600]: 0"),
+		},
+		{
+			name: "invalid timeout",
+			membersAnnotations: []map[string]string{
+				{
+					"alb.ingress.kubernetes.io/aws-api-timeout-seconds": "5m",
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, alb.ingress.kubernetes.io/aws-api-timeout-seconds: 5m: strconv.ParseInt: parsing \"5m\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var members []ClassifiedIngress
+			for _, ingAnnotations := range tt.membersAnnotations {
+				members = append(members, ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   "awesome-ns",
+							Name:        "ing",
+							Annotations: ingAnnotations,
+						},
+					},
+				})
+			}
+			var inactiveMembers []*networking.Ingress
+			for _, ingAnnotations := range tt.inactiveMembersAnnotations {
+				inactiveMembers = append(inactiveMembers, &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "awesome-ns",
+						Name:        "deleted-ing",
+						Annotations: ingAnnotations,
+					},
+				})
+			}
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			b := NewDefaultAWSRequestConfigBuilder(annotationParser)
+			got, err := b.Build(context.Background(), Group{Members: members, InactiveMembers: inactiveMembers})
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -75,14 +75,25 @@ type TargetGroupTuple struct {
 	// the K8s service port
 	ServicePort *intstr.IntOrString `json:"servicePort"`
 
+	// The Amazon Resource Name (ARN) of the Lambda function.
+	// a TargetGroup with lambda targetType will be created with the Lambda function registered.
+	// +optional
+	LambdaFunctionARN *string `json:"lambdaFunctionARN,omitempty"`
+
 	// The weight.
 	// +optional
 	Weight *int64 `json:"weight,omitempty"`
 }
 
 func (t *TargetGroupTuple) validate() error {
-	if (t.TargetGroupARN != nil) == (t.ServiceName != nil) {
-		return errors.New("precisely one of targetGroupARN and serviceName can be specified")
+	specifiedTargets := 0
+	for _, target := range []*string{t.TargetGroupARN, t.ServiceName, t.LambdaFunctionARN} {
+		if target != nil {
+			specifiedTargets++
+		}
+	}
+	if specifiedTargets != 1 {
+		return errors.New("precisely one of targetGroupARN, serviceName and lambdaFunctionARN can be specified")
 	}
 
 	if t.ServiceName != nil && t.ServicePort == nil {
@@ -102,6 +113,64 @@ type TargetGroupStickinessConfig struct {
 	DurationSeconds *int64 `json:"durationSeconds,omitempty"`
 }
 
+// Information about the weight ramp for newly created target groups within a rule.
+type TargetGroupWeightRampConfig struct {
+	// The time period, in seconds, over which the weight of a newly created target group is increased
+	// from zero to its configured weight.
+	DurationSeconds int64 `json:"durationSeconds"`
+}
+
+func (c *TargetGroupWeightRampConfig) validate() error {
+	if c.DurationSeconds <= 0 {
+		return errors.New("durationSeconds must be greater than 0")
+	}
+	return nil
+}
+
+// Information about the automated rollback of canary target groups within a rule.
+type TargetGroupRollbackConfig struct {
+	// The name of the service backing the stable target groups, the other target groups created for ServiceName/ServicePort are canary target groups.
+	StableServiceName string `json:"stableServiceName"`
+
+	// The maximum percentage of requests to a canary target group that result in HTTP 5XX responses from targets.
+	// +optional
+	HTTPCode5XXPercentThreshold *float64 `json:"httpCode5XXPercentThreshold,omitempty"`
+
+	// The maximum number of unhealthy targets in a canary target group.
+	// +optional
+	UnhealthyHostCountThreshold *int64 `json:"unhealthyHostCountThreshold,omitempty"`
+
+	// The time period, in seconds, over which the metrics of canary target groups are evaluated.
+	// +optional
+	EvaluationPeriodSeconds *int64 `json:"evaluationPeriodSeconds,omitempty"`
+
+	// The minimum number of requests to a canary target group within the evaluation period before its HTTP 5XX rate is evaluated.
+	// +optional
+	MinRequestCount *int64 `json:"minRequestCount,omitempty"`
+}
+
+func (c *TargetGroupRollbackConfig) validate() error {
+	if c.StableServiceName == "" {
+		return errors.New("stableServiceName must be specified")
+	}
+	if c.HTTPCode5XXPercentThreshold == nil && c.UnhealthyHostCountThreshold == nil {
+		return errors.New("either httpCode5XXPercentThreshold or unhealthyHostCountThreshold must be specified")
+	}
+	if c.HTTPCode5XXPercentThreshold != nil && (*c.HTTPCode5XXPercentThreshold < 0 || *c.HTTPCode5XXPercentThreshold > 100) {
+		return errors.New("httpCode5XXPercentThreshold must be within [0, 100]")
+	}
+	if c.UnhealthyHostCountThreshold != nil && *c.UnhealthyHostCountThreshold < 0 {
+		return errors.New("unhealthyHostCountThreshold must be non-negative")
+	}
+	if c.EvaluationPeriodSeconds != nil && (*c.EvaluationPeriodSeconds <= 0 || *c.EvaluationPeriodSeconds%60 != 0) {
+		return errors.New("evaluationPeriodSeconds must be a positive multiple of 60")
+	}
+	if c.MinRequestCount != nil && *c.MinRequestCount < 0 {
+		return errors.New("minRequestCount must be non-negative")
+	}
+	return nil
+}
+
 // Information about a forward action.
 type ForwardActionConfig struct {
 	// One or more target groups.
@@ -111,6 +180,14 @@ type ForwardActionConfig struct {
 	// The target group stickiness for the rule.
 	// +optional
 	TargetGroupStickinessConfig *TargetGroupStickinessConfig `json:"targetGroupStickinessConfig,omitempty"`
+
+	// The weight ramp for newly created target groups in the rule.
+	// +optional
+	WeightRampConfig *TargetGroupWeightRampConfig `json:"weightRampConfig,omitempty"`
+
+	// The automated rollback of canary target groups in the rule.
+	// +optional
+	RollbackConfig *TargetGroupRollbackConfig `json:"rollbackConfig,omitempty"`
 }
 
 func (c *ForwardActionConfig) validate() error {
@@ -126,6 +203,31 @@ func (c *ForwardActionConfig) validate() error {
 			}
 		}
 	}
+	if c.WeightRampConfig != nil {
+		if len(c.TargetGroups) < 2 {
+			return errors.New("weightRampConfig requires multiple target groups")
+		}
+		if err := c.WeightRampConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid WeightRampConfig")
+		}
+	}
+	if c.RollbackConfig != nil {
+		if len(c.TargetGroups) < 2 {
+			return errors.New("rollbackConfig requires multiple target groups")
+		}
+		if err := c.RollbackConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid RollbackConfig")
+		}
+		hasStableTargetGroup := false
+		for _, t := range c.TargetGroups {
+			if t.ServiceName != nil && *t.ServiceName == c.RollbackConfig.StableServiceName {
+				hasStableTargetGroup = true
+			}
+		}
+		if !hasStableTargetGroup {
+			return errors.Errorf("rollbackConfig stableServiceName must be one of the target groups: %v", c.RollbackConfig.StableServiceName)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,120 @@
+package ingress
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	resourceTypeConfigMaps = "configmaps"
+)
+
+// DefaultAnnotationsRepo provides controller-wide default values of Ingress annotations from a ConfigMap,
+// which individual Ingresses can override with their own annotations.
+type DefaultAnnotationsRepo interface {
+	annotations.DefaultsProvider
+	manager.Runnable
+
+	// Informer returns the informer of ConfigMap, which can be used to watch for changes of default values.
+	Informer() cache.SharedIndexInformer
+
+	// WaitForCacheSync waits for the initial sync of default values.
+	WaitForCacheSync(ctx context.Context) error
+}
+
+// NewDefaultAnnotationsRepo constructs new defaultAnnotationsRepo.
+// the ConfigMap specified by cmKey contains default values keyed by annotation suffix, e.g. "ssl-policy" for "alb.ingress.kubernetes.io/ssl-policy".
+func NewDefaultAnnotationsRepo(getter cache.Getter, cmKey types.NamespacedName, logger logr.Logger) *defaultAnnotationsRepo {
+	lw := cache.NewListWatchFromClient(getter, resourceTypeConfigMaps, cmKey.Namespace, fields.OneTermEqualSelector("metadata.name", cmKey.Name))
+	informer := cache.NewSharedIndexInformer(lw, &corev1.ConfigMap{}, 0, cache.Indexers{})
+	return &defaultAnnotationsRepo{
+		cmKey:    cmKey,
+		informer: informer,
+		logger:   logger,
+	}
+}
+
+var _ DefaultAnnotationsRepo = &defaultAnnotationsRepo{}
+
+// default implementation for DefaultAnnotationsRepo, which keeps the ConfigMap in-sync with a dedicated informer,
+// so that only the single ConfigMap is cached instead of ConfigMaps across the cluster.
+type defaultAnnotationsRepo struct {
+	cmKey    types.NamespacedName
+	informer cache.SharedIndexInformer
+	logger   logr.Logger
+
+	// defaults are built once per resourceVersion of ConfigMap, since they are looked up for every annotation parsed.
+	defaultsMutex           sync.Mutex
+	defaultsResourceVersion string
+	defaults                map[string]string
+}
+
+func (r *defaultAnnotationsRepo) Defaults() map[string]string {
+	cm, err := r.getConfigMap()
+	if err != nil {
+		r.logger.Error(err, "failed to get default annotations", "configMap", r.cmKey)
+		return nil
+	}
+	if cm == nil {
+		return nil
+	}
+
+	r.defaultsMutex.Lock()
+	defer r.defaultsMutex.Unlock()
+	if r.defaults == nil || r.defaultsResourceVersion != cm.ResourceVersion {
+		r.defaults = r.buildDefaults(cm)
+		r.defaultsResourceVersion = cm.ResourceVersion
+	}
+	return r.defaults
+}
+
+func (r *defaultAnnotationsRepo) Informer() cache.SharedIndexInformer {
+	return r.informer
+}
+
+// Start will start the repo.
+func (r *defaultAnnotationsRepo) Start(ctx context.Context) error {
+	r.informer.Run(ctx.Done())
+	return nil
+}
+
+// WaitForCacheSync waits for the initial sync of default annotations, so that Ingresses are never reconciled without them.
+func (r *defaultAnnotationsRepo) WaitForCacheSync(ctx context.Context) error {
+	if !cache.WaitForCacheSync(ctx.Done(), r.informer.HasSynced) {
+		return errors.Errorf("failed to wait for default annotations sync: %v", r.cmKey)
+	}
+	return nil
+}
+
+func (r *defaultAnnotationsRepo) getConfigMap() (*corev1.ConfigMap, error) {
+	raw, exists, err := r.informer.GetStore().GetByKey(r.cmKey.String())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return raw.(*corev1.ConfigMap), nil
+}
+
+// buildDefaults builds default values by annotation suffix, annotations specific to each Ingress are ignored.
+func (r *defaultAnnotationsRepo) buildDefaults(cm *corev1.ConfigMap) map[string]string {
+	defaults := make(map[string]string, len(cm.Data))
+	for suffix, value := range cm.Data {
+		if !annotations.IsDefaultableIngressAnnotation(suffix) {
+			r.logger.Info("ignoring default annotation specific to each Ingress", "configMap", r.cmKey, "annotation", suffix)
+			continue
+		}
+		defaults[suffix] = value
+	}
+	return defaults
+}
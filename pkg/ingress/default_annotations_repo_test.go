@@ -0,0 +1,86 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultAnnotationsRepo_Defaults(t *testing.T) {
+	cmKey := types.NamespacedName{Namespace: "kube-system", Name: "alb-default-annotations"}
+	tests := []struct {
+		name        string
+		existingCM  *corev1.ConfigMap
+		updatedCM   *corev1.ConfigMap
+		want        map[string]string
+		wantUpdated map[string]string
+	}{
+		{
+			name: "configMap doesn't exist",
+			want: nil,
+		},
+		{
+			name: "configMap exists",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-default-annotations", ResourceVersion: "1"},
+				Data: map[string]string{
+					"ssl-policy":                   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+					"healthcheck-interval-seconds": "10",
+				},
+			},
+			want: map[string]string{
+				"ssl-policy":                   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+				"healthcheck-interval-seconds": "10",
+			},
+		},
+		{
+			name: "annotations specific to each Ingress are ignored",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-default-annotations", ResourceVersion: "1"},
+				Data: map[string]string{
+					"target-type":               "ip",
+					"group.name":                "default-group",
+					"group.order":               "10",
+					"actions.default-action":    `{"type":"fixed-response"}`,
+					"conditions.default-action": `[]`,
+					"profile.prod.actions.a":    `{"type":"fixed-response"}`,
+				},
+			},
+			want: map[string]string{
+				"target-type": "ip",
+			},
+		},
+		{
+			name: "configMap updated",
+			existingCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-default-annotations", ResourceVersion: "1"},
+				Data:       map[string]string{"target-type": "instance"},
+			},
+			updatedCM: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-default-annotations", ResourceVersion: "2"},
+				Data:       map[string]string{"target-type": "ip"},
+			},
+			want:        map[string]string{"target-type": "instance"},
+			wantUpdated: map[string]string{"target-type": "ip"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewDefaultAnnotationsRepo(nil, cmKey, &log.NullLogger{})
+			store := repo.Informer().GetStore()
+			if tt.existingCM != nil {
+				assert.NoError(t, store.Add(tt.existingCM))
+			}
+			assert.Equal(t, tt.want, repo.Defaults())
+
+			if tt.updatedCM != nil {
+				assert.NoError(t, store.Update(tt.updatedCM))
+				assert.Equal(t, tt.wantUpdated, repo.Defaults())
+			}
+		})
+	}
+}
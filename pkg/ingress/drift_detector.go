@@ -0,0 +1,176 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+)
+
+const (
+	driftKeyLoadBalancerAttributes = "load balancer attributes"
+)
+
+// DriftDetector detects out-of-band changes to the AWS resources of IngressGroup.
+type DriftDetector interface {
+	// Record takes a snapshot of the live state of LoadBalancer for IngressGroup, which is expected to match the desired state.
+	Record(ctx context.Context, groupID GroupID, lbARN string) error
+
+	// Detect compares the live state of LoadBalancer for IngressGroup against the last recorded snapshot,
+	// and returns descriptions of the drifted parts.
+	Detect(ctx context.Context, groupID GroupID) ([]string, error)
+
+	// Forget discards the recorded snapshot for IngressGroup.
+	Forget(groupID GroupID)
+}
+
+// NewDefaultDriftDetector constructs new defaultDriftDetector.
+func NewDefaultDriftDetector(elbv2Client services.ELBV2, logger logr.Logger) *defaultDriftDetector {
+	return &defaultDriftDetector{
+		elbv2Client:      elbv2Client,
+		logger:           logger,
+		snapshotsByGroup: make(map[GroupID]loadBalancerSnapshot),
+	}
+}
+
+var _ DriftDetector = &defaultDriftDetector{}
+
+// default implementation for DriftDetector, which compares hashes of listeners, rules and attributes.
+type defaultDriftDetector struct {
+	elbv2Client services.ELBV2
+	logger      logr.Logger
+
+	snapshotsByGroupMutex sync.Mutex
+	snapshotsByGroup      map[GroupID]loadBalancerSnapshot
+}
+
+// loadBalancerSnapshot contains the hashes of LoadBalancer's configurations.
+type loadBalancerSnapshot struct {
+	lbARN string
+	// hashes is keyed by the description of each part, e.g. "listener port 80"
+	hashes map[string]string
+}
+
+func (d *defaultDriftDetector) Record(ctx context.Context, groupID GroupID, lbARN string) error {
+	hashes, err := d.buildLoadBalancerHashes(ctx, lbARN)
+	if err != nil {
+		return err
+	}
+	d.snapshotsByGroupMutex.Lock()
+	defer d.snapshotsByGroupMutex.Unlock()
+	d.snapshotsByGroup[groupID] = loadBalancerSnapshot{
+		lbARN:  lbARN,
+		hashes: hashes,
+	}
+	return nil
+}
+
+func (d *defaultDriftDetector) Detect(ctx context.Context, groupID GroupID) ([]string, error) {
+	d.snapshotsByGroupMutex.Lock()
+	snapshot, exists := d.snapshotsByGroup[groupID]
+	d.snapshotsByGroupMutex.Unlock()
+	if !exists {
+		return nil, nil
+	}
+	hashes, err := d.buildLoadBalancerHashes(ctx, snapshot.lbARN)
+	if err != nil {
+		return nil, err
+	}
+	return computeDrifts(snapshot.hashes, hashes), nil
+}
+
+func (d *defaultDriftDetector) Forget(groupID GroupID) {
+	d.snapshotsByGroupMutex.Lock()
+	defer d.snapshotsByGroupMutex.Unlock()
+	delete(d.snapshotsByGroup, groupID)
+}
+
+// buildLoadBalancerHashes computes the hashes for listeners(with certificates and rules) and attributes of LoadBalancer.
+func (d *defaultDriftDetector) buildLoadBalancerHashes(ctx context.Context, lbARN string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	listeners, err := d.elbv2Client.DescribeListenersAsList(ctx, &elbv2sdk.DescribeListenersInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, listener := range listeners {
+		certificates, err := d.elbv2Client.DescribeListenerCertificatesAsList(ctx, &elbv2sdk.DescribeListenerCertificatesInput{
+			ListenerArn: listener.ListenerArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rules, err := d.elbv2Client.DescribeRulesAsList(ctx, &elbv2sdk.DescribeRulesInput{
+			ListenerArn: listener.ListenerArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(certificates, func(i, j int) bool {
+			return awssdk.StringValue(certificates[i].CertificateArn) < awssdk.StringValue(certificates[j].CertificateArn)
+		})
+		sort.Slice(rules, func(i, j int) bool {
+			return awssdk.StringValue(rules[i].Priority) < awssdk.StringValue(rules[j].Priority)
+		})
+		hash, err := computeHash(listener, certificates, rules)
+		if err != nil {
+			return nil, err
+		}
+		hashes[fmt.Sprintf("listener port %v", awssdk.Int64Value(listener.Port))] = hash
+	}
+
+	resp, err := d.elbv2Client.DescribeLoadBalancerAttributesWithContext(ctx, &elbv2sdk.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String(lbARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]string, len(resp.Attributes))
+	for _, attr := range resp.Attributes {
+		attributes[awssdk.StringValue(attr.Key)] = awssdk.StringValue(attr.Value)
+	}
+	hash, err := computeHash(attributes)
+	if err != nil {
+		return nil, err
+	}
+	hashes[driftKeyLoadBalancerAttributes] = hash
+	return hashes, nil
+}
+
+// computeDrifts returns sorted descriptions of the differences between recorded and live hashes.
+func computeDrifts(recordedHashes map[string]string, liveHashes map[string]string) []string {
+	var drifts []string
+	recordedKeys := sets.StringKeySet(recordedHashes)
+	liveKeys := sets.StringKeySet(liveHashes)
+	for _, key := range recordedKeys.Intersection(liveKeys).List() {
+		if recordedHashes[key] != liveHashes[key] {
+			drifts = append(drifts, fmt.Sprintf("%v modified", key))
+		}
+	}
+	for _, key := range recordedKeys.Difference(liveKeys).List() {
+		drifts = append(drifts, fmt.Sprintf("%v deleted", key))
+	}
+	for _, key := range liveKeys.Difference(recordedKeys).List() {
+		drifts = append(drifts, fmt.Sprintf("%v added", key))
+	}
+	return drifts
+}
+
+func computeHash(objs ...interface{}) (string, error) {
+	payload, err := json.Marshal(objs)
+	if err != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256(payload)
+	return hex.EncodeToString(checksum[:]), nil
+}
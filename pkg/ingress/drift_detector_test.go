@@ -0,0 +1,148 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_computeDrifts(t *testing.T) {
+	tests := []struct {
+		name           string
+		recordedHashes map[string]string
+		liveHashes     map[string]string
+		want           []string
+	}{
+		{
+			name: "no drift",
+			recordedHashes: map[string]string{
+				"listener port 80":         "hash-1",
+				"load balancer attributes": "hash-2",
+			},
+			liveHashes: map[string]string{
+				"listener port 80":         "hash-1",
+				"load balancer attributes": "hash-2",
+			},
+			want: nil,
+		},
+		{
+			name: "listeners modified, deleted and added",
+			recordedHashes: map[string]string{
+				"listener port 80":         "hash-1",
+				"listener port 443":        "hash-2",
+				"load balancer attributes": "hash-3",
+			},
+			liveHashes: map[string]string{
+				"listener port 443":        "hash-2-modified",
+				"listener port 8080":       "hash-4",
+				"load balancer attributes": "hash-3",
+			},
+			want: []string{
+				"listener port 443 modified",
+				"listener port 80 deleted",
+				"listener port 8080 added",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeDrifts(tt.recordedHashes, tt.liveHashes)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultDriftDetector_RecordAndDetect(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	listenerARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	groupID := GroupID(types.NamespacedName{Namespace: "awesome-ns", Name: "ing-1"})
+	buildRules := func(pathPattern string) []*elbv2sdk.Rule {
+		return []*elbv2sdk.Rule{
+			{
+				Priority: awssdk.String("1"),
+				Conditions: []*elbv2sdk.RuleCondition{
+					{
+						Field:  awssdk.String("path-pattern"),
+						Values: awssdk.StringSlice([]string{pathPattern}),
+					},
+				},
+			},
+			{
+				Priority:  awssdk.String("default"),
+				IsDefault: awssdk.Bool(true),
+			},
+		}
+	}
+	tests := []struct {
+		name          string
+		recordedRules []*elbv2sdk.Rule
+		liveRules     []*elbv2sdk.Rule
+		want          []string
+	}{
+		{
+			name:          "rules unchanged",
+			recordedRules: buildRules("/api"),
+			liveRules:     buildRules("/api"),
+			want:          nil,
+		},
+		{
+			name:          "rules modified out-of-band",
+			recordedRules: buildRules("/api"),
+			liveRules:     buildRules("/console-edit"),
+			want:          []string{"listener port 80 modified"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			elbv2Client.EXPECT().DescribeListenersAsList(gomock.Any(), &elbv2sdk.DescribeListenersInput{
+				LoadBalancerArn: awssdk.String(lbARN),
+			}).Return([]*elbv2sdk.Listener{
+				{
+					ListenerArn: awssdk.String(listenerARN),
+					Port:        awssdk.Int64(80),
+					Protocol:    awssdk.String("HTTP"),
+				},
+			}, nil).Times(2)
+			elbv2Client.EXPECT().DescribeListenerCertificatesAsList(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+			gomock.InOrder(
+				elbv2Client.EXPECT().DescribeRulesAsList(gomock.Any(), gomock.Any()).Return(tt.recordedRules, nil),
+				elbv2Client.EXPECT().DescribeRulesAsList(gomock.Any(), gomock.Any()).Return(tt.liveRules, nil),
+			)
+			elbv2Client.EXPECT().DescribeLoadBalancerAttributesWithContext(gomock.Any(), gomock.Any()).Return(&elbv2sdk.DescribeLoadBalancerAttributesOutput{
+				Attributes: []*elbv2sdk.LoadBalancerAttribute{
+					{
+						Key:   awssdk.String("idle_timeout.timeout_seconds"),
+						Value: awssdk.String("60"),
+					},
+				},
+			}, nil).Times(2)
+
+			d := NewDefaultDriftDetector(elbv2Client, &log.NullLogger{})
+			ctx := context.Background()
+			drifts, err := d.Detect(ctx, groupID)
+			assert.NoError(t, err)
+			assert.Nil(t, drifts)
+
+			assert.NoError(t, d.Record(ctx, groupID, lbARN))
+			drifts, err = d.Detect(ctx, groupID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, drifts)
+
+			d.Forget(groupID)
+			drifts, err = d.Detect(ctx, groupID)
+			assert.NoError(t, err)
+			assert.Nil(t, drifts)
+		})
+	}
+}
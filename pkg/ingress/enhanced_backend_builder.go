@@ -80,11 +80,12 @@ type EnhancedBackendBuilder interface {
 }
 
 // NewDefaultEnhancedBackendBuilder constructs new defaultEnhancedBackendBuilder.
-func NewDefaultEnhancedBackendBuilder(k8sClient client.Client, annotationParser annotations.Parser, authConfigBuilder AuthConfigBuilder) *defaultEnhancedBackendBuilder {
+func NewDefaultEnhancedBackendBuilder(k8sClient client.Client, annotationParser annotations.Parser, authConfigBuilder AuthConfigBuilder, profile string) *defaultEnhancedBackendBuilder {
 	return &defaultEnhancedBackendBuilder{
 		k8sClient:         k8sClient,
 		annotationParser:  annotationParser,
 		authConfigBuilder: authConfigBuilder,
+		profile:           profile,
 
 		tolerateNonExistentBackendService: defaultTolerateNonExistentBackendAction,
 		tolerateNonExistentBackendAction:  defaultTolerateNonExistentBackendService,
@@ -99,6 +100,9 @@ type defaultEnhancedBackendBuilder struct {
 	annotationParser  annotations.Parser
 	authConfigBuilder AuthConfigBuilder
 
+	// the active profile, actions and conditions annotations scoped to it take precedence over unscoped ones.
+	profile string
+
 	// whether to tolerate misconfiguration that used a non-existent backend service.
 	// when tolerate, If a single backend service is used and it's non-existent, a fixed 503 response will be used instead.
 	tolerateNonExistentBackendService bool
@@ -160,7 +164,7 @@ func (b *defaultEnhancedBackendBuilder) Build(ctx context.Context, ing *networki
 func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAnnotation map[string]string, svcName string) ([]RuleCondition, error) {
 	var conditions []RuleCondition
 	annotationKey := fmt.Sprintf("conditions.%v", svcName)
-	_, err := b.annotationParser.ParseJSONAnnotation(annotationKey, &conditions, ingAnnotation)
+	_, err := b.parseProfileJSONAnnotation(annotationKey, &conditions, ingAnnotation)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +180,7 @@ func (b *defaultEnhancedBackendBuilder) buildConditions(_ context.Context, ingAn
 func (b *defaultEnhancedBackendBuilder) buildActionViaAnnotation(ctx context.Context, ingAnnotation map[string]string, svcName string) (Action, error) {
 	action := Action{}
 	annotationKey := fmt.Sprintf("actions.%v", svcName)
-	exists, err := b.annotationParser.ParseJSONAnnotation(annotationKey, &action, ingAnnotation)
+	exists, err := b.parseProfileJSONAnnotation(annotationKey, &action, ingAnnotation)
 	if err != nil {
 		return Action{}, err
 	}
@@ -194,6 +198,19 @@ func (b *defaultEnhancedBackendBuilder) buildActionViaAnnotation(ctx context.Con
 	return action, nil
 }
 
+// parseProfileJSONAnnotation parses json annotation scoped to the active profile if exists, e.g. "profile.prod.actions.my-action".
+// otherwise, the unscoped annotation is parsed instead.
+func (b *defaultEnhancedBackendBuilder) parseProfileJSONAnnotation(annotationKey string, value interface{}, ingAnnotation map[string]string) (bool, error) {
+	if b.profile != "" {
+		profileAnnotationKey := fmt.Sprintf("%v%v.%v", annotations.IngressSuffixPrefixProfile, b.profile, annotationKey)
+		exists, err := b.annotationParser.ParseJSONAnnotation(profileAnnotationKey, value, ingAnnotation)
+		if exists || err != nil {
+			return exists, err
+		}
+	}
+	return b.annotationParser.ParseJSONAnnotation(annotationKey, value, ingAnnotation)
+}
+
 // buildActionViaServiceAndServicePort will build the backend Action that forward to specified Kubernetes Service.
 func (b *defaultEnhancedBackendBuilder) buildActionViaServiceAndServicePort(_ context.Context, svcName string, svcPort intstr.IntOrString) Action {
 	action := Action{
@@ -665,6 +665,7 @@ func Test_defaultEnhancedBackendBuilder_buildConditions(t *testing.T) {
 	type args struct {
 		ingAnnotation map[string]string
 		svcName       string
+		profile       string
 	}
 	tests := []struct {
 		name    string
@@ -976,12 +977,62 @@ func Test_defaultEnhancedBackendBuilder_buildConditions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "profile scoped condition takes precedence",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.rule-path1":              `[{"field":"host-header","hostHeaderConfig":{"values":["anno.example.com"]}}]`,
+					"alb.ingress.kubernetes.io/profile.dev.conditions.rule-path1":  `[{"field":"host-header","hostHeaderConfig":{"values":["dev.example.com"]}}]`,
+					"alb.ingress.kubernetes.io/profile.prod.conditions.rule-path1": `[{"field":"host-header","hostHeaderConfig":{"values":["prod.example.com"]}}]`,
+				},
+				svcName: "rule-path1",
+				profile: "dev",
+			},
+			want: []RuleCondition{
+				{
+					Field: RuleConditionFieldHostHeader,
+					HostHeaderConfig: &HostHeaderConditionConfig{
+						Values: []string{"dev.example.com"},
+					},
+				},
+			},
+		},
+		{
+			name: "unscoped condition used when profile scoped condition absent",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/conditions.rule-path1":              `[{"field":"host-header","hostHeaderConfig":{"values":["anno.example.com"]}}]`,
+					"alb.ingress.kubernetes.io/profile.prod.conditions.rule-path1": `[{"field":"host-header","hostHeaderConfig":{"values":["prod.example.com"]}}]`,
+				},
+				svcName: "rule-path1",
+				profile: "dev",
+			},
+			want: []RuleCondition{
+				{
+					Field: RuleConditionFieldHostHeader,
+					HostHeaderConfig: &HostHeaderConditionConfig{
+						Values: []string{"anno.example.com"},
+					},
+				},
+			},
+		},
+		{
+			name: "profile scoped condition ignored without active profile",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/profile.prod.conditions.rule-path1": `[{"field":"host-header","hostHeaderConfig":{"values":["prod.example.com"]}}]`,
+				},
+				svcName: "rule-path1",
+			},
+			want: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			b := &defaultEnhancedBackendBuilder{
 				annotationParser: annotationParser,
+				profile:          tt.args.profile,
 			}
 			got, err := b.buildConditions(context.Background(), tt.args.ingAnnotation, tt.args.svcName)
 			if tt.wantErr != nil {
@@ -998,6 +1049,7 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaAnnotation(t *testing.T) {
 	type args struct {
 		ingAnnotation map[string]string
 		svcName       string
+		profile       string
 	}
 
 	portHTTP := intstr.FromString("http")
@@ -1241,12 +1293,54 @@ func Test_defaultEnhancedBackendBuilder_buildActionViaAnnotation(t *testing.T) {
 			},
 			wantErr: errors.New("missing actions.non-exists configuration"),
 		},
+		{
+			name: "profile scoped action takes precedence",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/actions.response-503":              `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"503","messageBody":"503 error text"}}`,
+					"alb.ingress.kubernetes.io/profile.prod.actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"503","messageBody":"maintenance"}}`,
+				},
+				svcName: "response-503",
+				profile: "prod",
+			},
+			want: Action{
+				Type: ActionTypeFixedResponse,
+				FixedResponseConfig: &FixedResponseActionConfig{
+					ContentType: awssdk.String("text/plain"),
+					MessageBody: awssdk.String("maintenance"),
+					StatusCode:  "503",
+				},
+			},
+		},
+		{
+			name: "invalid profile scoped action",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/profile.prod.actions.response-503": `{"type":"fixed-response"}`,
+				},
+				svcName: "response-503",
+				profile: "prod",
+			},
+			wantErr: errors.New("missing FixedResponseConfig"),
+		},
+		{
+			name: "non-exists action for active profile",
+			args: args{
+				ingAnnotation: map[string]string{
+					"alb.ingress.kubernetes.io/profile.prod.actions.response-503": `{"type":"fixed-response","fixedResponseConfig":{"contentType":"text/plain","statusCode":"503","messageBody":"maintenance"}}`,
+				},
+				svcName: "response-503",
+				profile: "dev",
+			},
+			wantErr: errors.New("missing actions.response-503 configuration"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			b := &defaultEnhancedBackendBuilder{
 				annotationParser: annotationParser,
+				profile:          tt.args.profile,
 			}
 			got, err := b.buildActionViaAnnotation(context.Background(), tt.args.ingAnnotation, tt.args.svcName)
 			if tt.wantErr != nil {
@@ -0,0 +1,204 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// groupClaimLeaseNamePrefix is the name prefix of Leases that claim IngressGroups.
+	groupClaimLeaseNamePrefix = "ingress-group-claim-"
+	// annotationKeyGroupClaimIngressGroup records the IngressGroup claimed by the Lease for human inspection.
+	annotationKeyGroupClaimIngressGroup = "ingress.k8s.aws/ingress-group"
+)
+
+// GroupClaimer claims IngressGroups, so that a single controller instance mutates AWS resources of an IngressGroup at a time.
+// e.g. during a rolling upgrade, the previous controller instance finishes in-flight reconciles while the new one is already elected as leader.
+type GroupClaimer interface {
+	// Claim claims IngressGroup for this controller instance, or renews the existing claim.
+	// It returns the duration to wait if IngressGroup is claimed by another controller instance.
+	Claim(ctx context.Context, groupID GroupID) (time.Duration, error)
+
+	// Release releases the claim of IngressGroup, e.g. once the IngressGroup is deleted.
+	Release(ctx context.Context, groupID GroupID) error
+
+	// ReleaseAll releases all claims held by this controller instance, which should be invoked after reconciles are stopped.
+	ReleaseAll(ctx context.Context) error
+}
+
+// NewDefaultGroupClaimer constructs new defaultGroupClaimer.
+// IngressGroups are claimed with Leases in namespace, claims are disabled if claimDuration is zero.
+func NewDefaultGroupClaimer(k8sClient client.Client, apiReader client.Reader, namespace string, identity string,
+	claimDuration time.Duration, logger logr.Logger) *defaultGroupClaimer {
+	return &defaultGroupClaimer{
+		k8sClient:       k8sClient,
+		apiReader:       apiReader,
+		namespace:       namespace,
+		identity:        identity,
+		claimDuration:   claimDuration,
+		logger:          logger,
+		now:             time.Now,
+		claimedGroupIDs: make(map[GroupID]struct{}),
+	}
+}
+
+var _ GroupClaimer = &defaultGroupClaimer{}
+
+// default implementation for GroupClaimer, which claims each IngressGroup with a Lease.
+type defaultGroupClaimer struct {
+	k8sClient client.Client
+	// apiReader reads Leases directly from API server, so that claims from other controller instances are never stale,
+	// and claims can still be released after the manager stopped.
+	apiReader     client.Reader
+	namespace     string
+	identity      string
+	claimDuration time.Duration
+	logger        logr.Logger
+	now           func() time.Time
+
+	claimedGroupIDsMutex sync.Mutex
+	claimedGroupIDs      map[GroupID]struct{}
+}
+
+func (c *defaultGroupClaimer) Claim(ctx context.Context, groupID GroupID) (time.Duration, error) {
+	if c.claimDuration == 0 {
+		return 0, nil
+	}
+	now := c.now()
+	lease := &coordinationv1.Lease{}
+	err := c.apiReader.Get(ctx, c.buildLeaseKey(groupID), lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, errors.Wrapf(err, "failed to get claim of ingressGroup: %v", groupID)
+	}
+	if apierrors.IsNotFound(err) {
+		lease = c.buildLease(groupID, now)
+		if err := c.k8sClient.Create(ctx, lease); err != nil {
+			return 0, errors.Wrapf(err, "failed to claim ingressGroup: %v", groupID)
+		}
+		c.recordClaim(groupID)
+		return 0, nil
+	}
+
+	holder := awssdk.StringValue(lease.Spec.HolderIdentity)
+	if holder != "" && holder != c.identity {
+		if waitDuration := c.computeClaimExpiry(lease).Sub(now); waitDuration > 0 {
+			return waitDuration, nil
+		}
+		c.logger.Info("taking over expired claim of ingressGroup", "ingressGroup", groupID, "previousHolder", holder)
+	}
+	if holder != c.identity {
+		lease.Spec.HolderIdentity = awssdk.String(c.identity)
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+		lease.Spec.LeaseTransitions = awssdk.Int32(awssdk.Int32Value(lease.Spec.LeaseTransitions) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = awssdk.Int32(int32(c.claimDuration / time.Second))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	if err := c.k8sClient.Update(ctx, lease); err != nil {
+		return 0, errors.Wrapf(err, "failed to claim ingressGroup: %v", groupID)
+	}
+	c.recordClaim(groupID)
+	return 0, nil
+}
+
+func (c *defaultGroupClaimer) Release(ctx context.Context, groupID GroupID) error {
+	if c.claimDuration == 0 {
+		return nil
+	}
+	lease := &coordinationv1.Lease{}
+	if err := c.apiReader.Get(ctx, c.buildLeaseKey(groupID), lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			c.forgetClaim(groupID)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get claim of ingressGroup: %v", groupID)
+	}
+	if awssdk.StringValue(lease.Spec.HolderIdentity) != c.identity {
+		c.forgetClaim(groupID)
+		return nil
+	}
+	// the precondition guarantees claims taken over by other controller instances in the meantime are kept.
+	if err := c.k8sClient.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to release claim of ingressGroup: %v", groupID)
+	}
+	c.forgetClaim(groupID)
+	return nil
+}
+
+func (c *defaultGroupClaimer) ReleaseAll(ctx context.Context) error {
+	c.claimedGroupIDsMutex.Lock()
+	groupIDs := make([]GroupID, 0, len(c.claimedGroupIDs))
+	for groupID := range c.claimedGroupIDs {
+		groupIDs = append(groupIDs, groupID)
+	}
+	c.claimedGroupIDsMutex.Unlock()
+
+	var lastErr error
+	for _, groupID := range groupIDs {
+		if err := c.Release(ctx, groupID); err != nil {
+			c.logger.Error(err, "failed to release claim of ingressGroup", "ingressGroup", groupID)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (c *defaultGroupClaimer) recordClaim(groupID GroupID) {
+	c.claimedGroupIDsMutex.Lock()
+	defer c.claimedGroupIDsMutex.Unlock()
+	c.claimedGroupIDs[groupID] = struct{}{}
+}
+
+func (c *defaultGroupClaimer) forgetClaim(groupID GroupID) {
+	c.claimedGroupIDsMutex.Lock()
+	defer c.claimedGroupIDsMutex.Unlock()
+	delete(c.claimedGroupIDs, groupID)
+}
+
+func (c *defaultGroupClaimer) computeClaimExpiry(lease *coordinationv1.Lease) time.Time {
+	var renewTime time.Time
+	if lease.Spec.RenewTime != nil {
+		renewTime = lease.Spec.RenewTime.Time
+	}
+	return renewTime.Add(time.Duration(awssdk.Int32Value(lease.Spec.LeaseDurationSeconds)) * time.Second)
+}
+
+func (c *defaultGroupClaimer) buildLease(groupID GroupID, now time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      c.buildLeaseKey(groupID).Name,
+			Annotations: map[string]string{
+				annotationKeyGroupClaimIngressGroup: groupID.String(),
+			},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       awssdk.String(c.identity),
+			LeaseDurationSeconds: awssdk.Int32(int32(c.claimDuration / time.Second)),
+			AcquireTime:          &metav1.MicroTime{Time: now},
+			RenewTime:            &metav1.MicroTime{Time: now},
+			LeaseTransitions:     awssdk.Int32(0),
+		},
+	}
+}
+
+// buildLeaseKey returns the key of Lease for IngressGroup, which is named by hash of groupID,
+// since groupIDs of implicit IngressGroups contain "/" and might be too long for a name.
+func (c *defaultGroupClaimer) buildLeaseKey(groupID GroupID) types.NamespacedName {
+	groupIDHash := sha256.Sum256([]byte(groupID.String()))
+	return types.NamespacedName{
+		Namespace: c.namespace,
+		Name:      groupClaimLeaseNamePrefix + hex.EncodeToString(groupIDHash[:])[:16],
+	}
+}
@@ -0,0 +1,190 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultGroupClaimer_Claim(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	groupID := GroupID{Name: "awesome-group"}
+	leaseName := "ingress-group-claim-8eb081998b312d97"
+	buildLease := func(holder string, renewTime time.Time, leaseTransitions int32) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kube-system",
+				Name:      leaseName,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       awssdk.String(holder),
+				LeaseDurationSeconds: awssdk.Int32(120),
+				AcquireTime:          &metav1.MicroTime{Time: renewTime},
+				RenewTime:            &metav1.MicroTime{Time: renewTime},
+				LeaseTransitions:     awssdk.Int32(leaseTransitions),
+			},
+		}
+	}
+	tests := []struct {
+		name                 string
+		claimDuration        time.Duration
+		existingLease        *coordinationv1.Lease
+		wantWaitDuration     time.Duration
+		wantHolder           string
+		wantRenewTime        time.Time
+		wantLeaseTransitions int32
+		wantNoLease          bool
+	}{
+		{
+			name:          "claims disabled",
+			claimDuration: 0,
+			wantNoLease:   true,
+		},
+		{
+			name:                 "IngressGroup isn't claimed",
+			claimDuration:        2 * time.Minute,
+			wantHolder:           "me",
+			wantRenewTime:        now,
+			wantLeaseTransitions: 0,
+		},
+		{
+			name:                 "IngressGroup is claimed by me",
+			claimDuration:        2 * time.Minute,
+			existingLease:        buildLease("me", now.Add(-time.Minute), 1),
+			wantHolder:           "me",
+			wantRenewTime:        now,
+			wantLeaseTransitions: 1,
+		},
+		{
+			name:                 "IngressGroup is claimed by another controller instance",
+			claimDuration:        2 * time.Minute,
+			existingLease:        buildLease("another", now.Add(-time.Minute), 1),
+			wantWaitDuration:     time.Minute,
+			wantHolder:           "another",
+			wantRenewTime:        now.Add(-time.Minute),
+			wantLeaseTransitions: 1,
+		},
+		{
+			name:                 "IngressGroup is claimed by another controller instance, but claim expired",
+			claimDuration:        2 * time.Minute,
+			existingLease:        buildLease("another", now.Add(-3*time.Minute), 1),
+			wantHolder:           "me",
+			wantRenewTime:        now,
+			wantLeaseTransitions: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			if tt.existingLease != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingLease.DeepCopy()))
+			}
+
+			c := NewDefaultGroupClaimer(k8sClient, k8sClient, "kube-system", "me", tt.claimDuration, &log.NullLogger{})
+			c.now = func() time.Time { return now }
+			waitDuration, err := c.Claim(ctx, groupID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWaitDuration, waitDuration)
+
+			gotLease := &coordinationv1.Lease{}
+			err = k8sClient.Get(ctx, c.buildLeaseKey(groupID), gotLease)
+			if tt.wantNoLease {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, leaseName, gotLease.Name)
+			assert.Equal(t, tt.wantHolder, awssdk.StringValue(gotLease.Spec.HolderIdentity))
+			assert.True(t, tt.wantRenewTime.Equal(gotLease.Spec.RenewTime.Time))
+			assert.Equal(t, tt.wantLeaseTransitions, awssdk.Int32Value(gotLease.Spec.LeaseTransitions))
+			assert.Equal(t, int32(120), awssdk.Int32Value(gotLease.Spec.LeaseDurationSeconds))
+		})
+	}
+}
+
+func Test_defaultGroupClaimer_Release(t *testing.T) {
+	groupID := GroupID{Namespace: "awesome-ns", Name: "ing-1"}
+	tests := []struct {
+		name          string
+		existingOwner *string
+		wantLease     bool
+	}{
+		{
+			name:          "IngressGroup is claimed by me",
+			existingOwner: awssdk.String("me"),
+			wantLease:     false,
+		},
+		{
+			name:          "IngressGroup is claimed by another controller instance",
+			existingOwner: awssdk.String("another"),
+			wantLease:     true,
+		},
+		{
+			name:      "IngressGroup isn't claimed",
+			wantLease: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			c := NewDefaultGroupClaimer(k8sClient, k8sClient, "kube-system", "me", 2*time.Minute, &log.NullLogger{})
+			if tt.existingOwner != nil {
+				assert.NoError(t, k8sClient.Create(ctx, &coordinationv1.Lease{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "kube-system",
+						Name:      c.buildLeaseKey(groupID).Name,
+					},
+					Spec: coordinationv1.LeaseSpec{
+						HolderIdentity: tt.existingOwner,
+					},
+				}))
+			}
+
+			err := c.Release(ctx, groupID)
+			assert.NoError(t, err)
+			err = k8sClient.Get(ctx, c.buildLeaseKey(groupID), &coordinationv1.Lease{})
+			if tt.wantLease {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, apierrors.IsNotFound(err))
+			}
+		})
+	}
+}
+
+func Test_defaultGroupClaimer_ReleaseAll(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	ctx := context.Background()
+	c := NewDefaultGroupClaimer(k8sClient, k8sClient, "kube-system", "me", 2*time.Minute, &log.NullLogger{})
+	groupIDs := []GroupID{{Name: "awesome-group"}, {Namespace: "awesome-ns", Name: "ing-1"}}
+	for _, groupID := range groupIDs {
+		waitDuration, err := c.Claim(ctx, groupID)
+		assert.NoError(t, err)
+		assert.Zero(t, waitDuration)
+	}
+
+	assert.NoError(t, c.ReleaseAll(ctx))
+	leaseList := &coordinationv1.LeaseList{}
+	assert.NoError(t, k8sClient.List(ctx, leaseList, client.InNamespace("kube-system")))
+	assert.Empty(t, leaseList.Items)
+	assert.Empty(t, c.claimedGroupIDs)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -1339,6 +1340,7 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 		name              string
 		env               env
 		args              args
+		ingressSelector   string
 		wantClassifiedIng ClassifiedIngress
 		wantGroupID       *GroupID
 		wantErr           error
@@ -1552,6 +1554,60 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 			wantGroupID:       nil,
 			wantErr:           errors.New("invalid ingress group: groupName must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character"),
 		},
+		{
+			name: "ingress selected by label selector",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Labels: map[string]string{
+							"team": "payments",
+						},
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+			},
+			ingressSelector: "team=payments",
+			wantClassifiedIng: ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Labels: map[string]string{
+							"team": "payments",
+						},
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+				IngClassConfig: ClassConfiguration{},
+			},
+			wantGroupID: &ingImplicitGroupID,
+		},
+		{
+			name: "ingress not selected by label selector",
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ing-ns",
+						Name:      "ing-name",
+						Labels: map[string]string{
+							"team": "search",
+						},
+						Annotations: map[string]string{
+							"kubernetes.io/ingress.class": "alb",
+						},
+					},
+				},
+			},
+			ingressSelector:   "team=payments",
+			wantClassifiedIng: ClassifiedIngress{},
+			wantGroupID:       nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1579,6 +1635,11 @@ func Test_defaultGroupLoader_loadGroupIDIfAnyHelper(t *testing.T) {
 				classAnnotationMatcher:             classAnnotationMatcher,
 				manageIngressesWithoutIngressClass: false,
 			}
+			if tt.ingressSelector != "" {
+				ingressSelector, err := labels.Parse(tt.ingressSelector)
+				assert.NoError(t, err)
+				m.ingressSelector = ingressSelector
+			}
 			gotClassifiedIng, gotGroupID, err := m.loadGroupIDIfAnyHelper(context.Background(), tt.args.ing)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
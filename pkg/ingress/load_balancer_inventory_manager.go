@@ -0,0 +1,235 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxInventoryReadableNameLength is the maximum length of the readable part of LoadBalancerInventory name,
+	// which leaves room for the hash suffix within the 253 characters limit of object names.
+	maxInventoryReadableNameLength = 242
+)
+
+// LoadBalancerInventoryManager maintains the LoadBalancerInventories.
+type LoadBalancerInventoryManager interface {
+	// Update records the deployed LoadBalancer of IngressGroup into its inventory.
+	Update(ctx context.Context, ingGroup Group, stack core.Stack, lb *elbv2model.LoadBalancer) error
+
+	// Remove removes the inventory of IngressGroup.
+	Remove(ctx context.Context, groupID GroupID) error
+}
+
+// NewDefaultLoadBalancerInventoryManager constructs new defaultLoadBalancerInventoryManager.
+func NewDefaultLoadBalancerInventoryManager(k8sClient client.Client, trackingProvider tracking.Provider) *defaultLoadBalancerInventoryManager {
+	return &defaultLoadBalancerInventoryManager{
+		k8sClient:        k8sClient,
+		trackingProvider: trackingProvider,
+		now:              time.Now,
+	}
+}
+
+var _ LoadBalancerInventoryManager = &defaultLoadBalancerInventoryManager{}
+
+// default implementation for LoadBalancerInventoryManager, which keeps a LoadBalancerInventory per IngressGroup,
+// so that reconciles of different IngressGroups never contend on the same object.
+type defaultLoadBalancerInventoryManager struct {
+	k8sClient        client.Client
+	trackingProvider tracking.Provider
+	now              func() time.Time
+}
+
+func (m *defaultLoadBalancerInventoryManager) Update(ctx context.Context, ingGroup Group, stack core.Stack, lb *elbv2model.LoadBalancer) error {
+	entry, err := m.buildInventoryEntry(ctx, ingGroup, stack, lb)
+	if err != nil {
+		return err
+	}
+	inventoryName := buildLoadBalancerInventoryName(ingGroup.ID)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		inventory, err := m.getOrCreateInventory(ctx, inventoryName, m.trackingProvider.StackLabels(stack))
+		if err != nil {
+			return err
+		}
+		// LastUpdateTime only changes when the content of entry changes, so that steady reconciles are no-op.
+		if len(inventory.Status.LoadBalancers) == 1 {
+			entry.LastUpdateTime = inventory.Status.LoadBalancers[0].LastUpdateTime
+			if equality.Semantic.DeepEqual(inventory.Status.LoadBalancers[0], entry) {
+				return nil
+			}
+		}
+		entry.LastUpdateTime = metav1.NewTime(m.now())
+		inventory.Status.LoadBalancers = []elbv2api.LoadBalancerInventoryEntry{entry}
+		return m.k8sClient.Status().Update(ctx, inventory)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update loadBalancerInventory: %v", inventoryName)
+	}
+	return nil
+}
+
+func (m *defaultLoadBalancerInventoryManager) Remove(ctx context.Context, groupID GroupID) error {
+	inventoryName := buildLoadBalancerInventoryName(groupID)
+	inventory := &elbv2api.LoadBalancerInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: inventoryName,
+		},
+	}
+	if err := m.k8sClient.Delete(ctx, inventory); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete loadBalancerInventory: %v", inventoryName)
+	}
+	return nil
+}
+
+// getOrCreateInventory returns the inventory with name, and creates it when it doesn't exist yet.
+func (m *defaultLoadBalancerInventoryManager) getOrCreateInventory(ctx context.Context, name string, labels map[string]string) (*elbv2api.LoadBalancerInventory, error) {
+	inventory := &elbv2api.LoadBalancerInventory{}
+	err := m.k8sClient.Get(ctx, types.NamespacedName{Name: name}, inventory)
+	if err == nil {
+		return inventory, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	inventory = &elbv2api.LoadBalancerInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+	err = m.k8sClient.Create(ctx, inventory)
+	if err == nil {
+		return inventory, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	// the inventory has been created concurrently, re-read it.
+	inventory = &elbv2api.LoadBalancerInventory{}
+	if err := m.k8sClient.Get(ctx, types.NamespacedName{Name: name}, inventory); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+func (m *defaultLoadBalancerInventoryManager) buildInventoryEntry(ctx context.Context, ingGroup Group, stack core.Stack, lb *elbv2model.LoadBalancer) (elbv2api.LoadBalancerInventoryEntry, error) {
+	lbARN, err := lb.LoadBalancerARN().Resolve(ctx)
+	if err != nil {
+		return elbv2api.LoadBalancerInventoryEntry{}, err
+	}
+	lbDNS, err := lb.DNSName().Resolve(ctx)
+	if err != nil {
+		return elbv2api.LoadBalancerInventoryEntry{}, err
+	}
+	listeners, err := buildListenerInventories(ctx, stack)
+	if err != nil {
+		return elbv2api.LoadBalancerInventoryEntry{}, err
+	}
+	tgARNs, err := buildTargetGroupARNs(ctx, stack)
+	if err != nil {
+		return elbv2api.LoadBalancerInventoryEntry{}, err
+	}
+	ingresses := make([]elbv2api.IngressReference, 0, len(ingGroup.Members))
+	for _, member := range ingGroup.Members {
+		ingresses = append(ingresses, elbv2api.IngressReference{
+			Namespace: member.Ing.Namespace,
+			Name:      member.Ing.Name,
+		})
+	}
+	sort.Slice(ingresses, func(i, j int) bool {
+		if ingresses[i].Namespace != ingresses[j].Namespace {
+			return ingresses[i].Namespace < ingresses[j].Namespace
+		}
+		return ingresses[i].Name < ingresses[j].Name
+	})
+	return elbv2api.LoadBalancerInventoryEntry{
+		IngressGroup:    ingGroup.ID.String(),
+		Ingresses:       ingresses,
+		LoadBalancerARN: lbARN,
+		DNSName:         lbDNS,
+		Listeners:       listeners,
+		TargetGroupARNs: tgARNs,
+	}, nil
+}
+
+// buildLoadBalancerInventoryName returns the name of LoadBalancerInventory for IngressGroup.
+// It's the IngressGroup name for explicit groups, or `namespace.name` of the Ingress for implicit groups,
+// suffixed with a hash of groupID so that explicit and implicit groups never collide.
+func buildLoadBalancerInventoryName(groupID GroupID) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(groupID.String()))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	readableName := groupID.Name
+	if !groupID.IsExplicit() {
+		readableName = fmt.Sprintf("%s.%s", groupID.Namespace, groupID.Name)
+	}
+	if len(readableName) > maxInventoryReadableNameLength {
+		readableName = strings.TrimRight(readableName[:maxInventoryReadableNameLength], ".-")
+	}
+	return fmt.Sprintf("%s-%.10s", readableName, uuid)
+}
+
+// buildListenerInventories returns the deployed listeners in stack, sorted by port.
+func buildListenerInventories(ctx context.Context, stack core.Stack) ([]elbv2api.ListenerInventory, error) {
+	var resLSs []*elbv2model.Listener
+	if err := stack.ListResources(&resLSs); err != nil {
+		return nil, err
+	}
+	listeners := make([]elbv2api.ListenerInventory, 0, len(resLSs))
+	for _, resLS := range resLSs {
+		lsARN, err := resLS.ListenerARN().Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var certARNs []string
+		for _, cert := range resLS.Spec.Certificates {
+			certARNs = append(certARNs, awssdk.StringValue(cert.CertificateARN))
+		}
+		listeners = append(listeners, elbv2api.ListenerInventory{
+			Port:            resLS.Spec.Port,
+			Protocol:        string(resLS.Spec.Protocol),
+			ListenerARN:     lsARN,
+			CertificateARNs: certARNs,
+		})
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		return listeners[i].Port < listeners[j].Port
+	})
+	return listeners, nil
+}
+
+// buildTargetGroupARNs returns the sorted ARNs of deployed targetGroups in stack.
+func buildTargetGroupARNs(ctx context.Context, stack core.Stack) ([]string, error) {
+	var resTGs []*elbv2model.TargetGroup
+	if err := stack.ListResources(&resTGs); err != nil {
+		return nil, err
+	}
+	tgARNs := make([]string, 0, len(resTGs))
+	for _, resTG := range resTGs {
+		tgARN, err := resTG.TargetGroupARN().Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tgARNs = append(tgARNs, tgARN)
+	}
+	sort.Strings(tgARNs)
+	return tgARNs, nil
+}
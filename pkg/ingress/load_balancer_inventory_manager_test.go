@@ -0,0 +1,275 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	mock_client "sigs.k8s.io/aws-load-balancer-controller/mocks/controller-runtime/client"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultLoadBalancerInventoryManager_Update(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	earlier := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	ingGroup := Group{
+		ID: GroupID{Name: "awesome-group"},
+		Members: []ClassifiedIngress{
+			{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-2", Name: "ing-1"}}},
+			{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-1", Name: "ing-2"}}},
+		},
+	}
+	wantEntry := elbv2api.LoadBalancerInventoryEntry{
+		IngressGroup: "awesome-group",
+		Ingresses: []elbv2api.IngressReference{
+			{Namespace: "ns-1", Name: "ing-2"},
+			{Namespace: "ns-2", Name: "ing-1"},
+		},
+		LoadBalancerARN: "lb-arn",
+		DNSName:         "lb-dns",
+		Listeners: []elbv2api.ListenerInventory{
+			{
+				Port:        80,
+				Protocol:    "HTTP",
+				ListenerARN: "ls-80-arn",
+			},
+			{
+				Port:            443,
+				Protocol:        "HTTPS",
+				ListenerARN:     "ls-443-arn",
+				CertificateARNs: []string{"cert-arn-1", "cert-arn-2"},
+			},
+		},
+		TargetGroupARNs: []string{"tg-arn-1", "tg-arn-2"},
+	}
+	withLastUpdateTime := func(entry elbv2api.LoadBalancerInventoryEntry, lastUpdateTime time.Time) elbv2api.LoadBalancerInventoryEntry {
+		entry.LastUpdateTime = metav1.NewTime(lastUpdateTime)
+		return entry
+	}
+	staleEntry := withLastUpdateTime(wantEntry, earlier)
+	staleEntry.TargetGroupARNs = []string{"tg-arn-1"}
+	inventoryName := buildLoadBalancerInventoryName(ingGroup.ID)
+
+	tests := []struct {
+		name              string
+		existingInventory *elbv2api.LoadBalancerInventory
+		want              []elbv2api.LoadBalancerInventoryEntry
+	}{
+		{
+			name: "inventory doesn't exist",
+			want: []elbv2api.LoadBalancerInventoryEntry{
+				withLastUpdateTime(wantEntry, now),
+			},
+		},
+		{
+			name: "inventory contains up-to-date entry",
+			existingInventory: &elbv2api.LoadBalancerInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: inventoryName},
+				Status: elbv2api.LoadBalancerInventoryStatus{
+					LoadBalancers: []elbv2api.LoadBalancerInventoryEntry{
+						withLastUpdateTime(wantEntry, earlier),
+					},
+				},
+			},
+			want: []elbv2api.LoadBalancerInventoryEntry{
+				withLastUpdateTime(wantEntry, earlier),
+			},
+		},
+		{
+			name: "inventory contains stale entry",
+			existingInventory: &elbv2api.LoadBalancerInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: inventoryName},
+				Status: elbv2api.LoadBalancerInventoryStatus{
+					LoadBalancers: []elbv2api.LoadBalancerInventoryEntry{staleEntry},
+				},
+			},
+			want: []elbv2api.LoadBalancerInventoryEntry{
+				withLastUpdateTime(wantEntry, now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			if tt.existingInventory != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.existingInventory.DeepCopy()))
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			lb.SetStatus(elbv2model.LoadBalancerStatus{LoadBalancerARN: "lb-arn", DNSName: "lb-dns"})
+			ls443 := elbv2model.NewListener(stack, "443", elbv2model.ListenerSpec{
+				LoadBalancerARN: lb.LoadBalancerARN(),
+				Port:            443,
+				Protocol:        elbv2model.ProtocolHTTPS,
+				Certificates: []elbv2model.Certificate{
+					{CertificateARN: awssdk.String("cert-arn-1")},
+					{CertificateARN: awssdk.String("cert-arn-2")},
+				},
+			})
+			ls443.SetStatus(elbv2model.ListenerStatus{ListenerARN: "ls-443-arn"})
+			ls80 := elbv2model.NewListener(stack, "80", elbv2model.ListenerSpec{
+				LoadBalancerARN: lb.LoadBalancerARN(),
+				Port:            80,
+				Protocol:        elbv2model.ProtocolHTTP,
+			})
+			ls80.SetStatus(elbv2model.ListenerStatus{ListenerARN: "ls-80-arn"})
+			tg2 := elbv2model.NewTargetGroup(stack, "tg-2", elbv2model.TargetGroupSpec{})
+			tg2.SetStatus(elbv2model.TargetGroupStatus{TargetGroupARN: "tg-arn-2"})
+			tg1 := elbv2model.NewTargetGroup(stack, "tg-1", elbv2model.TargetGroupSpec{})
+			tg1.SetStatus(elbv2model.TargetGroupStatus{TargetGroupARN: "tg-arn-1"})
+
+			m := NewDefaultLoadBalancerInventoryManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"))
+			m.now = func() time.Time { return now }
+			err := m.Update(ctx, ingGroup, stack, lb)
+			assert.NoError(t, err)
+
+			gotInventory := &elbv2api.LoadBalancerInventory{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: inventoryName}, gotInventory))
+			got := gotInventory.Status.LoadBalancers
+			assert.True(t, cmp.Equal(tt.want, got), "diff", cmp.Diff(tt.want, got))
+			if tt.existingInventory == nil {
+				assert.Equal(t, map[string]string{"ingress.k8s.aws/stack": "awesome-group"}, gotInventory.Labels)
+			}
+		})
+	}
+}
+
+func Test_defaultLoadBalancerInventoryManager_Remove(t *testing.T) {
+	explicitGroupID := GroupID{Name: "awesome-group"}
+	implicitGroupID := GroupID{Namespace: "ns-2", Name: "ing-2"}
+	tests := []struct {
+		name    string
+		groupID GroupID
+		want    []string
+	}{
+		{
+			name:    "remove explicit IngressGroup",
+			groupID: explicitGroupID,
+			want:    []string{buildLoadBalancerInventoryName(implicitGroupID)},
+		},
+		{
+			name:    "remove implicit IngressGroup",
+			groupID: implicitGroupID,
+			want:    []string{buildLoadBalancerInventoryName(explicitGroupID)},
+		},
+		{
+			name:    "IngressGroup without inventory",
+			groupID: GroupID{Name: "another-group"},
+			want:    []string{buildLoadBalancerInventoryName(explicitGroupID), buildLoadBalancerInventoryName(implicitGroupID)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, groupID := range []GroupID{explicitGroupID, implicitGroupID} {
+				assert.NoError(t, k8sClient.Create(ctx, &elbv2api.LoadBalancerInventory{
+					ObjectMeta: metav1.ObjectMeta{Name: buildLoadBalancerInventoryName(groupID)},
+				}))
+			}
+
+			m := NewDefaultLoadBalancerInventoryManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"))
+			err := m.Remove(ctx, tt.groupID)
+			assert.NoError(t, err)
+
+			inventoryList := &elbv2api.LoadBalancerInventoryList{}
+			assert.NoError(t, k8sClient.List(ctx, inventoryList))
+			var got []string
+			for _, inventory := range inventoryList.Items {
+				got = append(got, inventory.Name)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultLoadBalancerInventoryManager_getOrCreateInventory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryKey := types.NamespacedName{Name: "awesome-group-0123456789"}
+	groupResource := schema.GroupResource{Group: "elbv2.k8s.aws", Resource: "loadbalancerinventories"}
+	concurrentInventory := &elbv2api.LoadBalancerInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: inventoryKey.Name, ResourceVersion: "1"},
+	}
+	k8sClient := mock_client.NewMockClient(ctrl)
+	gomock.InOrder(
+		k8sClient.EXPECT().Get(gomock.Any(), inventoryKey, gomock.Any()).Return(apierrors.NewNotFound(groupResource, inventoryKey.Name)),
+		k8sClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(apierrors.NewAlreadyExists(groupResource, inventoryKey.Name)),
+		k8sClient.EXPECT().Get(gomock.Any(), inventoryKey, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, key types.NamespacedName, obj *elbv2api.LoadBalancerInventory) error {
+				concurrentInventory.DeepCopyInto(obj)
+				return nil
+			}),
+	)
+
+	m := NewDefaultLoadBalancerInventoryManager(k8sClient, tracking.NewDefaultProvider("ingress.k8s.aws", "cluster-name"))
+	got, err := m.getOrCreateInventory(context.Background(), inventoryKey.Name, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, concurrentInventory, got)
+}
+
+func Test_buildLoadBalancerInventoryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		groupID GroupID
+		want    string
+	}{
+		{
+			name:    "explicit IngressGroup",
+			groupID: GroupID{Name: "awesome-group"},
+			want:    "awesome-group-" + hashOfGroupID(GroupID{Name: "awesome-group"}),
+		},
+		{
+			name:    "implicit IngressGroup",
+			groupID: GroupID{Namespace: "awesome-ns", Name: "ing-1"},
+			want:    "awesome-ns.ing-1-" + hashOfGroupID(GroupID{Namespace: "awesome-ns", Name: "ing-1"}),
+		},
+		{
+			name:    "long name is truncated",
+			groupID: GroupID{Namespace: "awesome-ns", Name: strings.Repeat("a", 230) + "." + strings.Repeat("b", 20)},
+			want:    "awesome-ns." + strings.Repeat("a", 230) + "-" + hashOfGroupID(GroupID{Namespace: "awesome-ns", Name: strings.Repeat("a", 230) + "." + strings.Repeat("b", 20)}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildLoadBalancerInventoryName(tt.groupID)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), 253)
+		})
+	}
+
+	t.Run("explicit and implicit IngressGroups don't collide", func(t *testing.T) {
+		explicitName := buildLoadBalancerInventoryName(GroupID{Name: "awesome-ns.ing-1"})
+		implicitName := buildLoadBalancerInventoryName(GroupID{Namespace: "awesome-ns", Name: "ing-1"})
+		assert.NotEqual(t, explicitName, implicitName)
+	})
+}
+
+func hashOfGroupID(groupID GroupID) string {
+	uuidHash := sha256.Sum256([]byte(groupID.String()))
+	return hex.EncodeToString(uuidHash[:])[:10]
+}
@@ -0,0 +1,312 @@
+package ingress
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the ConfigMap that publishes metrics dimensions of Ingress is named with this prefix followed by Ingress's name.
+	metricsDimensionsConfigMapNamePrefix = "aws-lbc-metrics-"
+	// MetricsDimensionsConfigMapDataKey is the ConfigMap data key for metrics dimensions of Ingress paths in JSON.
+	MetricsDimensionsConfigMapDataKey = "dimensions.json"
+	// labelKeyMetricsDimensionsIngress labels ConfigMaps that publish metrics dimensions with the name of their Ingress.
+	labelKeyMetricsDimensionsIngress = "ingress.k8s.aws/metrics-dimensions-of"
+)
+
+// PathMetricsDimensions are the CloudWatch metrics dimensions of traffic routed by an Ingress path.
+type PathMetricsDimensions struct {
+	Host string `json:"host,omitempty"`
+	Path string `json:"path,omitempty"`
+	// DefaultBackend is true for the default backend of Ingress, which has neither host nor path.
+	DefaultBackend bool `json:"defaultBackend,omitempty"`
+	// LoadBalancer is the value of LoadBalancer dimension, in format of app/<name>/<id>.
+	LoadBalancer string `json:"loadBalancer"`
+	// TargetGroups are the TargetGroups traffic is forwarded to, empty for paths with redirect or fixed-response actions.
+	TargetGroups []TargetGroupMetricsDimensions `json:"targetGroups,omitempty"`
+}
+
+// TargetGroupMetricsDimensions are the CloudWatch metrics dimensions of a TargetGroup that an Ingress path forwards to.
+type TargetGroupMetricsDimensions struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	ServicePort string `json:"servicePort,omitempty"`
+	Weight      *int64 `json:"weight,omitempty"`
+	// TargetGroup is the value of TargetGroup dimension, in format of targetgroup/<name>/<id>.
+	TargetGroup string `json:"targetGroup"`
+}
+
+// MetricsDimensionsPublisher publishes CloudWatch metrics dimensions of each Ingress path into a ConfigMap alongside the Ingress,
+// so that teams can template per-route dashboards and alarms.
+type MetricsDimensionsPublisher interface {
+	// Publish publishes metrics dimensions of member Ingresses of IngressGroup with the deployed LoadBalancer,
+	// and unpublishes them for inactive members.
+	Publish(ctx context.Context, ingGroup Group, stack core.Stack, lb *elbv2model.LoadBalancer) error
+}
+
+// NewDefaultMetricsDimensionsPublisher constructs new defaultMetricsDimensionsPublisher.
+func NewDefaultMetricsDimensionsPublisher(k8sClient client.Client, apiReader client.Reader, eventRecorder record.EventRecorder,
+	enhancedBackendBuilder EnhancedBackendBuilder) *defaultMetricsDimensionsPublisher {
+	return &defaultMetricsDimensionsPublisher{
+		k8sClient:              k8sClient,
+		apiReader:              apiReader,
+		eventRecorder:          eventRecorder,
+		enhancedBackendBuilder: enhancedBackendBuilder,
+		publishedPayloads:      make(map[types.NamespacedName]string),
+	}
+}
+
+var _ MetricsDimensionsPublisher = &defaultMetricsDimensionsPublisher{}
+
+// default implementation for MetricsDimensionsPublisher.
+// ConfigMaps are owned by their Ingress, thus garbage collected together with the Ingress.
+// ConfigMaps are created without reading them, and existing ones are read directly from API server before they are updated or deleted,
+// so that ConfigMaps across the cluster don't need to be cached.
+// existing ConfigMaps without the label of their Ingress aren't managed by the controller, thus left alone.
+type defaultMetricsDimensionsPublisher struct {
+	k8sClient              client.Client
+	apiReader              client.Reader
+	eventRecorder          record.EventRecorder
+	enhancedBackendBuilder EnhancedBackendBuilder
+
+	// publishedPayloads are the payloads published by Ingress, to skip writing unchanged ConfigMaps.
+	publishedPayloadsMutex sync.Mutex
+	publishedPayloads      map[types.NamespacedName]string
+}
+
+func (p *defaultMetricsDimensionsPublisher) Publish(ctx context.Context, ingGroup Group, stack core.Stack, lb *elbv2model.LoadBalancer) error {
+	for _, inactiveMember := range ingGroup.InactiveMembers {
+		if err := p.unpublish(ctx, inactiveMember); err != nil {
+			return err
+		}
+	}
+	if len(ingGroup.Members) == 0 || lb == nil {
+		return nil
+	}
+
+	lbARN, err := lb.LoadBalancerARN().Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	lbDimension, err := cloudwatchdeploy.BuildLoadBalancerDimension(lbARN)
+	if err != nil {
+		return err
+	}
+	var resTGs []*elbv2model.TargetGroup
+	stack.ListResources(&resTGs)
+	tgByResID := make(map[string]*elbv2model.TargetGroup, len(resTGs))
+	for _, tg := range resTGs {
+		tgByResID[tg.ID()] = tg
+	}
+	for _, member := range ingGroup.Members {
+		pathsDimensions, err := p.buildIngressMetricsDimensions(ctx, member.Ing, lbDimension, tgByResID)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(member.Ing))
+		}
+		if err := p.publish(ctx, member.Ing, pathsDimensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *defaultMetricsDimensionsPublisher) buildIngressMetricsDimensions(ctx context.Context, ing *networking.Ingress,
+	lbDimension string, tgByResID map[string]*elbv2model.TargetGroup) ([]PathMetricsDimensions, error) {
+	pathsDimensions := []PathMetricsDimensions{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			tgsDimensions, err := p.buildTargetGroupsMetricsDimensions(ctx, ing, path.Backend, tgByResID)
+			if err != nil {
+				return nil, err
+			}
+			pathsDimensions = append(pathsDimensions, PathMetricsDimensions{
+				Host:         rule.Host,
+				Path:         path.Path,
+				LoadBalancer: lbDimension,
+				TargetGroups: tgsDimensions,
+			})
+		}
+	}
+	if ing.Spec.DefaultBackend != nil {
+		tgsDimensions, err := p.buildTargetGroupsMetricsDimensions(ctx, ing, *ing.Spec.DefaultBackend, tgByResID)
+		if err != nil {
+			return nil, err
+		}
+		pathsDimensions = append(pathsDimensions, PathMetricsDimensions{
+			DefaultBackend: true,
+			LoadBalancer:   lbDimension,
+			TargetGroups:   tgsDimensions,
+		})
+	}
+	return pathsDimensions, nil
+}
+
+// buildTargetGroupsMetricsDimensions builds metrics dimensions of TargetGroups that backend forwards to.
+// TargetGroups of Lambda functions are omitted.
+func (p *defaultMetricsDimensionsPublisher) buildTargetGroupsMetricsDimensions(ctx context.Context, ing *networking.Ingress,
+	backend networking.IngressBackend, tgByResID map[string]*elbv2model.TargetGroup) ([]TargetGroupMetricsDimensions, error) {
+	enhancedBackend, err := p.enhancedBackendBuilder.Build(ctx, ing, backend,
+		WithLoadBackendServices(false, nil),
+		WithLoadAuthConfig(false))
+	if err != nil {
+		return nil, err
+	}
+	if enhancedBackend.Action.Type != ActionTypeForward || enhancedBackend.Action.ForwardConfig == nil {
+		return nil, nil
+	}
+	var tgsDimensions []TargetGroupMetricsDimensions
+	for _, tgt := range enhancedBackend.Action.ForwardConfig.TargetGroups {
+		tgDimensions := TargetGroupMetricsDimensions{
+			Weight: tgt.Weight,
+		}
+		var tgARN string
+		switch {
+		case tgt.TargetGroupARN != nil:
+			tgARN = awssdk.StringValue(tgt.TargetGroupARN)
+		case tgt.LambdaFunctionARN != nil:
+			continue
+		default:
+			svcKey := types.NamespacedName{Namespace: ing.Namespace, Name: awssdk.StringValue(tgt.ServiceName)}
+			tgResID := buildTargetGroupResourceID(k8s.NamespacedName(ing), svcKey, *tgt.ServicePort)
+			tg, exists := tgByResID[tgResID]
+			if !exists {
+				return nil, errors.Errorf("targetGroup not found for service %v:%v", svcKey.Name, tgt.ServicePort.String())
+			}
+			tgARN, err = tg.TargetGroupARN().Resolve(ctx)
+			if err != nil {
+				return nil, err
+			}
+			tgDimensions.ServiceName = svcKey.Name
+			tgDimensions.ServicePort = tgt.ServicePort.String()
+		}
+		tgDimensions.TargetGroup, err = cloudwatchdeploy.BuildTargetGroupDimension(tgARN)
+		if err != nil {
+			return nil, err
+		}
+		tgsDimensions = append(tgsDimensions, tgDimensions)
+	}
+	return tgsDimensions, nil
+}
+
+func (p *defaultMetricsDimensionsPublisher) publish(ctx context.Context, ing *networking.Ingress, pathsDimensions []PathMetricsDimensions) error {
+	payload, err := json.Marshal(pathsDimensions)
+	if err != nil {
+		return err
+	}
+	ingKey := k8s.NamespacedName(ing)
+	p.publishedPayloadsMutex.Lock()
+	publishedPayload, published := p.publishedPayloads[ingKey]
+	p.publishedPayloadsMutex.Unlock()
+	if published && publishedPayload == string(payload) {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ing.Namespace,
+			Name:      BuildMetricsDimensionsConfigMapName(ing.Name),
+			Labels: map[string]string{
+				labelKeyMetricsDimensionsIngress: buildMetricsDimensionsIngressLabelValue(ing.Name),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: networking.SchemeGroupVersion.String(),
+					Kind:       "Ingress",
+					Name:       ing.Name,
+					UID:        ing.UID,
+				},
+			},
+		},
+		Data: map[string]string{
+			MetricsDimensionsConfigMapDataKey: string(payload),
+		},
+	}
+	err = p.k8sClient.Create(ctx, cm)
+	if apierrors.IsAlreadyExists(err) {
+		existingCM := &corev1.ConfigMap{}
+		if err := p.apiReader.Get(ctx, k8s.NamespacedName(cm), existingCM); err != nil {
+			return errors.Wrapf(err, "failed to publish metrics dimensions of ingress: %v", ingKey)
+		}
+		if !isMetricsDimensionsConfigMapOf(existingCM, ing.Name) {
+			p.eventRecorder.Event(ing, corev1.EventTypeWarning, k8s.IngressEventReasonFailedPublishMetricsDimensions,
+				fmt.Sprintf("ConfigMap %v already exists and isn't managed by the controller", cm.Name))
+			return nil
+		}
+		cm.ResourceVersion = existingCM.ResourceVersion
+		err = p.k8sClient.Update(ctx, cm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to publish metrics dimensions of ingress: %v", ingKey)
+	}
+
+	p.publishedPayloadsMutex.Lock()
+	p.publishedPayloads[ingKey] = string(payload)
+	p.publishedPayloadsMutex.Unlock()
+	return nil
+}
+
+func (p *defaultMetricsDimensionsPublisher) unpublish(ctx context.Context, ing *networking.Ingress) error {
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Namespace: ing.Namespace, Name: BuildMetricsDimensionsConfigMapName(ing.Name)}
+	if err := p.apiReader.Get(ctx, cmKey, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to unpublish metrics dimensions of ingress: %v", k8s.NamespacedName(ing))
+		}
+	} else if isMetricsDimensionsConfigMapOf(cm, ing.Name) {
+		deleteOpts := client.Preconditions{UID: &cm.UID, ResourceVersion: &cm.ResourceVersion}
+		if err := p.k8sClient.Delete(ctx, cm, deleteOpts); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to unpublish metrics dimensions of ingress: %v", k8s.NamespacedName(ing))
+		}
+	}
+
+	p.publishedPayloadsMutex.Lock()
+	delete(p.publishedPayloads, k8s.NamespacedName(ing))
+	p.publishedPayloadsMutex.Unlock()
+	return nil
+}
+
+// BuildMetricsDimensionsConfigMapName returns the name of ConfigMap that publishes metrics dimensions of Ingress.
+// the name is suffixed by hash of Ingress's name instead if it would exceed the maximum length.
+func BuildMetricsDimensionsConfigMapName(ingName string) string {
+	name := metricsDimensionsConfigMapNamePrefix + ingName
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	ingNameHash := sha256.Sum256([]byte(ingName))
+	return metricsDimensionsConfigMapNamePrefix + hex.EncodeToString(ingNameHash[:])[:32]
+}
+
+// isMetricsDimensionsConfigMapOf returns whether cm is the ConfigMap that publishes metrics dimensions of Ingress with ingName.
+func isMetricsDimensionsConfigMapOf(cm *corev1.ConfigMap, ingName string) bool {
+	return cm.Labels[labelKeyMetricsDimensionsIngress] == buildMetricsDimensionsIngressLabelValue(ingName)
+}
+
+// buildMetricsDimensionsIngressLabelValue returns the label value for Ingress's name, which is hashed if it's not a valid label value.
+func buildMetricsDimensionsIngressLabelValue(ingName string) string {
+	if len(validation.IsValidLabelValue(ingName)) == 0 {
+		return ingName
+	}
+	ingNameHash := sha256.Sum256([]byte(ingName))
+	return hex.EncodeToString(ingNameHash[:])[:32]
+}
@@ -0,0 +1,214 @@
+package ingress
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultMetricsDimensionsPublisher_Publish(t *testing.T) {
+	ing := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "ing-1",
+			UID:       "ing-1-uid",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.fixed":    `{"type":"fixed-response","fixedResponseConfig":{"statusCode":"404"}}`,
+				"alb.ingress.kubernetes.io/actions.weighted": `{"type":"forward","forwardConfig":{"targetGroups":[{"serviceName":"svc-1","servicePort":"80","weight":80},{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/legacy-tg/1a2b3c4d5e6f7a8b","weight":20}]}}`,
+			},
+		},
+		Spec: networking.IngressSpec{
+			DefaultBackend: &networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "fixed",
+					Port: networking.ServiceBackendPort{Name: "use-annotation"},
+				},
+			},
+			Rules: []networking.IngressRule{
+				{
+					Host: "app.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/api",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "svc-1",
+											Port: networking.ServiceBackendPort{Number: 80},
+										},
+									},
+								},
+								{
+									Path: "/canary",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "weighted",
+											Port: networking.ServiceBackendPort{Name: "use-annotation"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	inactiveIng := &networking.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "ing-2"},
+	}
+	wantPayload := `[` +
+		`{"host":"app.example.com","path":"/api","loadBalancer":"app/k8s-awesome-a1b2c3/50dc6c495c0c9188",` +
+		`"targetGroups":[{"serviceName":"svc-1","servicePort":"80","targetGroup":"targetgroup/k8s-svc1-d4e5f6/73e2d6bc24d8a067"}]},` +
+		`{"host":"app.example.com","path":"/canary","loadBalancer":"app/k8s-awesome-a1b2c3/50dc6c495c0c9188",` +
+		`"targetGroups":[{"serviceName":"svc-1","servicePort":"80","weight":80,"targetGroup":"targetgroup/k8s-svc1-d4e5f6/73e2d6bc24d8a067"},` +
+		`{"weight":20,"targetGroup":"targetgroup/legacy-tg/1a2b3c4d5e6f7a8b"}]},` +
+		`{"defaultBackend":true,"loadBalancer":"app/k8s-awesome-a1b2c3/50dc6c495c0c9188"}` +
+		`]`
+
+	tests := []struct {
+		name        string
+		existingCMs []*corev1.ConfigMap
+		wantManaged bool
+		wantEvents  []string
+	}{
+		{
+			name:        "configMaps don't exist",
+			wantManaged: true,
+		},
+		{
+			name: "configMaps managed by the controller exist",
+			existingCMs: []*corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "aws-lbc-metrics-ing-1",
+						Labels:    map[string]string{"ingress.k8s.aws/metrics-dimensions-of": "ing-1"},
+					},
+					Data: map[string]string{MetricsDimensionsConfigMapDataKey: "[]"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "aws-lbc-metrics-ing-2",
+						Labels:    map[string]string{"ingress.k8s.aws/metrics-dimensions-of": "ing-2"},
+					},
+					Data: map[string]string{MetricsDimensionsConfigMapDataKey: "[]"},
+				},
+			},
+			wantManaged: true,
+		},
+		{
+			name: "configMaps not managed by the controller exist",
+			existingCMs: []*corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "aws-lbc-metrics-ing-1"},
+					Data:       map[string]string{MetricsDimensionsConfigMapDataKey: "[]"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "aws-lbc-metrics-ing-2"},
+					Data:       map[string]string{MetricsDimensionsConfigMapDataKey: "[]"},
+				},
+			},
+			wantManaged: false,
+			wantEvents: []string{
+				"Warning FailedPublishMetricsDimensions ConfigMap aws-lbc-metrics-ing-1 already exists and isn't managed by the controller",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, cm := range tt.existingCMs {
+				assert.NoError(t, k8sClient.Create(ctx, cm.DeepCopy()))
+			}
+
+			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
+			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, NewDefaultAuthConfigBuilder(annotationParser), "")
+			eventRecorder := record.NewFakeRecorder(10)
+			publisher := NewDefaultMetricsDimensionsPublisher(k8sClient, k8sClient, eventRecorder, enhancedBackendBuilder)
+
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			lb := elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			lb.SetStatus(elbv2model.LoadBalancerStatus{
+				LoadBalancerARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/k8s-awesome-a1b2c3/50dc6c495c0c9188",
+			})
+			tg := elbv2model.NewTargetGroup(stack, "awesome-ns/ing-1-svc-1:80", elbv2model.TargetGroupSpec{})
+			tg.SetStatus(elbv2model.TargetGroupStatus{
+				TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-svc1-d4e5f6/73e2d6bc24d8a067",
+			})
+			ingGroup := Group{
+				ID:              GroupID{Name: "awesome-group"},
+				Members:         []ClassifiedIngress{{Ing: ing}},
+				InactiveMembers: []*networking.Ingress{inactiveIng},
+			}
+			assert.NoError(t, publisher.Publish(ctx, ingGroup, stack, lb))
+
+			gotCM := &corev1.ConfigMap{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "aws-lbc-metrics-ing-1"}, gotCM))
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "aws-lbc-metrics-ing-2"}, &corev1.ConfigMap{})
+			if tt.wantManaged {
+				assert.Equal(t, wantPayload, gotCM.Data[MetricsDimensionsConfigMapDataKey])
+				assert.Equal(t, map[string]string{"ingress.k8s.aws/metrics-dimensions-of": "ing-1"}, gotCM.Labels)
+				assert.Equal(t, []metav1.OwnerReference{
+					{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Name: "ing-1", UID: "ing-1-uid"},
+				}, gotCM.OwnerReferences)
+				assert.True(t, apierrors.IsNotFound(err))
+			} else {
+				assert.Equal(t, "[]", gotCM.Data[MetricsDimensionsConfigMapDataKey])
+				assert.Empty(t, gotCM.OwnerReferences)
+				assert.NoError(t, err)
+			}
+
+			var gotEvents []string
+			for len(eventRecorder.Events) > 0 {
+				gotEvents = append(gotEvents, <-eventRecorder.Events)
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func TestBuildMetricsDimensionsConfigMapName(t *testing.T) {
+	longIngName := strings.Repeat("ingress-01", 25)
+	tests := []struct {
+		name    string
+		ingName string
+		want    string
+	}{
+		{
+			name:    "short ingress name",
+			ingName: "ing-1",
+			want:    "aws-lbc-metrics-ing-1",
+		},
+		{
+			name:    "long ingress name",
+			ingName: longIngName,
+			want:    "aws-lbc-metrics-2b9c1b3044b9b800a99a7d99ce1696c6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildMetricsDimensionsConfigMapName(tt.ingName)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	cloudwatchsdk "github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MetricsExpressionsHandlerPath is the path to serve metric math expressions of Ingress paths on the metrics server.
+	MetricsExpressionsHandlerPath = "/ingress-metrics-expressions"
+
+	defaultMetricsExpressionsMetric = "RequestCount"
+	defaultMetricsExpressionsStat   = "Sum"
+	defaultMetricsExpressionsPeriod = 60
+)
+
+// PathMetricsExpressions are the ready-to-use CloudWatch metric data queries for traffic routed by an Ingress path.
+type PathMetricsExpressions struct {
+	Host           string `json:"host,omitempty"`
+	Path           string `json:"path,omitempty"`
+	DefaultBackend bool   `json:"defaultBackend,omitempty"`
+	// MetricDataQueries can be used as-is for GetMetricData API, CloudWatch alarms or dashboards.
+	MetricDataQueries []*cloudwatchsdk.MetricDataQuery `json:"metricDataQueries"`
+}
+
+// NewMetricsExpressionsHandler constructs a read-only http.Handler that renders metric math expressions of each path of an Ingress in JSON,
+// from the metrics dimensions published by MetricsDimensionsPublisher.
+// it requires query parameters "namespace" and "ingress", and accepts optional "metric", "stat" and "period".
+// requests must carry a bearer token of a user that is authorized to get the ConfigMap of metrics dimensions,
+// since the metrics server itself isn't authenticated.
+func NewMetricsExpressionsHandler(apiReader client.Reader, tokenReviewer k8s.TokenReviewer, accessReviewer k8s.AccessReviewer, logger logr.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := req.URL.Query()
+		ingKey := types.NamespacedName{Namespace: query.Get("namespace"), Name: query.Get("ingress")}
+		if ingKey.Namespace == "" || ingKey.Name == "" {
+			http.Error(w, "query parameters namespace and ingress are required", http.StatusBadRequest)
+			return
+		}
+		cmKey := types.NamespacedName{Namespace: ingKey.Namespace, Name: BuildMetricsDimensionsConfigMapName(ingKey.Name)}
+		if code, err := authorizeMetricsExpressionsRequest(req, cmKey, tokenReviewer, accessReviewer); err != nil {
+			if code == http.StatusInternalServerError {
+				logger.Error(err, "failed to authorize request", "ingress", ingKey)
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		metricName := defaultMetricsExpressionsMetric
+		if rawMetricName := query.Get("metric"); rawMetricName != "" {
+			metricName = rawMetricName
+		}
+		stat := defaultMetricsExpressionsStat
+		if rawStat := query.Get("stat"); rawStat != "" {
+			stat = rawStat
+		}
+		period := int64(defaultMetricsExpressionsPeriod)
+		if rawPeriod := query.Get("period"); rawPeriod != "" {
+			parsedPeriod, err := strconv.ParseInt(rawPeriod, 10, 64)
+			if err != nil || parsedPeriod <= 0 {
+				http.Error(w, fmt.Sprintf("invalid period: %v", rawPeriod), http.StatusBadRequest)
+				return
+			}
+			period = parsedPeriod
+		}
+
+		cm := &corev1.ConfigMap{}
+		if err := apiReader.Get(req.Context(), cmKey, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				http.Error(w, fmt.Sprintf("metrics dimensions not published for ingress: %v", ingKey), http.StatusNotFound)
+				return
+			}
+			logger.Error(err, "failed to get metrics dimensions", "ingress", ingKey)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var pathsDimensions []PathMetricsDimensions
+		if err := json.Unmarshal([]byte(cm.Data[MetricsDimensionsConfigMapDataKey]), &pathsDimensions); err != nil {
+			logger.Error(err, "failed to decode metrics dimensions", "ingress", ingKey)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		pathsExpressions := make([]PathMetricsExpressions, 0, len(pathsDimensions))
+		for _, pathDimensions := range pathsDimensions {
+			pathsExpressions = append(pathsExpressions, buildPathMetricsExpressions(pathDimensions, metricName, stat, period))
+		}
+		payload, err := json.MarshalIndent(pathsExpressions, "", "  ")
+		if err != nil {
+			logger.Error(err, "failed to encode metrics expressions", "ingress", ingKey)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(payload)
+	})
+}
+
+// authorizeMetricsExpressionsRequest authorizes whether the user of req's bearer token can get the ConfigMap of metrics dimensions.
+// returns the http status code along with the error if req isn't authorized.
+func authorizeMetricsExpressionsRequest(req *http.Request, cmKey types.NamespacedName, tokenReviewer k8s.TokenReviewer, accessReviewer k8s.AccessReviewer) (int, error) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == req.Header.Get("Authorization") {
+		return http.StatusUnauthorized, errors.New("bearer token is required")
+	}
+	user, authenticated, err := tokenReviewer.Review(req.Context(), token)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !authenticated {
+		return http.StatusUnauthorized, errors.New("bearer token isn't authenticated")
+	}
+	allowed, _, err := accessReviewer.Review(req.Context(), user, authorizationv1.ResourceAttributes{
+		Namespace: cmKey.Namespace,
+		Verb:      "get",
+		Resource:  "configmaps",
+		Name:      cmKey.Name,
+	})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !allowed {
+		return http.StatusForbidden, errors.Errorf("user %v is not allowed to get configmap: %v", user.Username, cmKey)
+	}
+	return http.StatusOK, nil
+}
+
+// buildPathMetricsExpressions builds metric data queries for path.
+// paths that forward to a single TargetGroup get the TargetGroup's metric, paths that forward to multiple TargetGroups get
+// a SUM expression over metrics of each TargetGroup, and paths without TargetGroups get the LoadBalancer's metric.
+func buildPathMetricsExpressions(pathDimensions PathMetricsDimensions, metricName string, stat string, period int64) PathMetricsExpressions {
+	label := buildPathMetricsLabel(pathDimensions, metricName)
+	var queries []*cloudwatchsdk.MetricDataQuery
+	switch len(pathDimensions.TargetGroups) {
+	case 0:
+		queries = append(queries, &cloudwatchsdk.MetricDataQuery{
+			Id:    awssdk.String("m0"),
+			Label: awssdk.String(label),
+			MetricStat: buildMetricStat(metricName, stat, period, []*cloudwatchsdk.Dimension{
+				{Name: awssdk.String("LoadBalancer"), Value: awssdk.String(pathDimensions.LoadBalancer)},
+			}),
+			ReturnData: awssdk.Bool(true),
+		})
+	case 1:
+		queries = append(queries, &cloudwatchsdk.MetricDataQuery{
+			Id:         awssdk.String("m0"),
+			Label:      awssdk.String(label),
+			MetricStat: buildTargetGroupMetricStat(pathDimensions.LoadBalancer, pathDimensions.TargetGroups[0], metricName, stat, period),
+			ReturnData: awssdk.Bool(true),
+		})
+	default:
+		metricIDs := make([]string, 0, len(pathDimensions.TargetGroups))
+		for i, tgDimensions := range pathDimensions.TargetGroups {
+			metricID := fmt.Sprintf("m%d", i)
+			metricIDs = append(metricIDs, metricID)
+			queries = append(queries, &cloudwatchsdk.MetricDataQuery{
+				Id:         awssdk.String(metricID),
+				MetricStat: buildTargetGroupMetricStat(pathDimensions.LoadBalancer, tgDimensions, metricName, stat, period),
+				ReturnData: awssdk.Bool(false),
+			})
+		}
+		queries = append(queries, &cloudwatchsdk.MetricDataQuery{
+			Id:         awssdk.String("e0"),
+			Label:      awssdk.String(label),
+			Expression: awssdk.String(fmt.Sprintf("SUM([%s])", strings.Join(metricIDs, ","))),
+			ReturnData: awssdk.Bool(true),
+		})
+	}
+	return PathMetricsExpressions{
+		Host:              pathDimensions.Host,
+		Path:              pathDimensions.Path,
+		DefaultBackend:    pathDimensions.DefaultBackend,
+		MetricDataQueries: queries,
+	}
+}
+
+func buildTargetGroupMetricStat(lbDimension string, tgDimensions TargetGroupMetricsDimensions, metricName string, stat string, period int64) *cloudwatchsdk.MetricStat {
+	return buildMetricStat(metricName, stat, period, []*cloudwatchsdk.Dimension{
+		{Name: awssdk.String("TargetGroup"), Value: awssdk.String(tgDimensions.TargetGroup)},
+		{Name: awssdk.String("LoadBalancer"), Value: awssdk.String(lbDimension)},
+	})
+}
+
+func buildMetricStat(metricName string, stat string, period int64, dimensions []*cloudwatchsdk.Dimension) *cloudwatchsdk.MetricStat {
+	return &cloudwatchsdk.MetricStat{
+		Metric: &cloudwatchsdk.Metric{
+			Namespace:  awssdk.String(cloudwatchdeploy.NamespaceApplicationELB),
+			MetricName: awssdk.String(metricName),
+			Dimensions: dimensions,
+		},
+		Period: awssdk.Int64(period),
+		Stat:   awssdk.String(stat),
+	}
+}
+
+func buildPathMetricsLabel(pathDimensions PathMetricsDimensions, metricName string) string {
+	if pathDimensions.DefaultBackend {
+		return fmt.Sprintf("%s default backend", metricName)
+	}
+	return fmt.Sprintf("%s %s%s", metricName, pathDimensions.Host, pathDimensions.Path)
+}
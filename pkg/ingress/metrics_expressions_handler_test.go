@@ -0,0 +1,228 @@
+package ingress
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	cloudwatchsdk "github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNewMetricsExpressionsHandler(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "aws-lbc-metrics-ing-1"},
+		Data: map[string]string{
+			MetricsDimensionsConfigMapDataKey: `[` +
+				`{"host":"app.example.com","path":"/api","loadBalancer":"app/lb/1","targetGroups":[{"targetGroup":"targetgroup/tg-1/1"}]},` +
+				`{"host":"app.example.com","path":"/canary","loadBalancer":"app/lb/1","targetGroups":[{"targetGroup":"targetgroup/tg-1/1"},{"targetGroup":"targetgroup/tg-2/2"}]},` +
+				`{"defaultBackend":true,"loadBalancer":"app/lb/1"}` +
+				`]`,
+		},
+	}
+	tgMetricStat := func(tgDimension string, metricName string, stat string, period int64) *cloudwatchsdk.MetricStat {
+		return &cloudwatchsdk.MetricStat{
+			Metric: &cloudwatchsdk.Metric{
+				Namespace:  awssdk.String("AWS/ApplicationELB"),
+				MetricName: awssdk.String(metricName),
+				Dimensions: []*cloudwatchsdk.Dimension{
+					{Name: awssdk.String("TargetGroup"), Value: awssdk.String(tgDimension)},
+					{Name: awssdk.String("LoadBalancer"), Value: awssdk.String("app/lb/1")},
+				},
+			},
+			Period: awssdk.Int64(period),
+			Stat:   awssdk.String(stat),
+		}
+	}
+	user := authenticationv1.UserInfo{Username: "awesome-user"}
+	tests := []struct {
+		name            string
+		method          string
+		target          string
+		authorization   string
+		unauthenticated bool
+		forbidden       bool
+		wantStatus      int
+		wantBody        string
+		want            []PathMetricsExpressions
+	}{
+		{
+			name:       "default metric",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1",
+			wantStatus: http.StatusOK,
+			want: []PathMetricsExpressions{
+				{
+					Host: "app.example.com",
+					Path: "/api",
+					MetricDataQueries: []*cloudwatchsdk.MetricDataQuery{
+						{
+							Id:         awssdk.String("m0"),
+							Label:      awssdk.String("RequestCount app.example.com/api"),
+							MetricStat: tgMetricStat("targetgroup/tg-1/1", "RequestCount", "Sum", 60),
+							ReturnData: awssdk.Bool(true),
+						},
+					},
+				},
+				{
+					Host: "app.example.com",
+					Path: "/canary",
+					MetricDataQueries: []*cloudwatchsdk.MetricDataQuery{
+						{
+							Id:         awssdk.String("m0"),
+							MetricStat: tgMetricStat("targetgroup/tg-1/1", "RequestCount", "Sum", 60),
+							ReturnData: awssdk.Bool(false),
+						},
+						{
+							Id:         awssdk.String("m1"),
+							MetricStat: tgMetricStat("targetgroup/tg-2/2", "RequestCount", "Sum", 60),
+							ReturnData: awssdk.Bool(false),
+						},
+						{
+							Id:         awssdk.String("e0"),
+							Label:      awssdk.String("RequestCount app.example.com/canary"),
+							Expression: awssdk.String("SUM([m0,m1])"),
+							ReturnData: awssdk.Bool(true),
+						},
+					},
+				},
+				{
+					DefaultBackend: true,
+					MetricDataQueries: []*cloudwatchsdk.MetricDataQuery{
+						{
+							Id:    awssdk.String("m0"),
+							Label: awssdk.String("RequestCount default backend"),
+							MetricStat: &cloudwatchsdk.MetricStat{
+								Metric: &cloudwatchsdk.Metric{
+									Namespace:  awssdk.String("AWS/ApplicationELB"),
+									MetricName: awssdk.String("RequestCount"),
+									Dimensions: []*cloudwatchsdk.Dimension{
+										{Name: awssdk.String("LoadBalancer"), Value: awssdk.String("app/lb/1")},
+									},
+								},
+								Period: awssdk.Int64(60),
+								Stat:   awssdk.String("Sum"),
+							},
+							ReturnData: awssdk.Bool(true),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "custom metric",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1&metric=TargetResponseTime&stat=p99&period=300",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "ingress not specified",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "query parameters namespace and ingress are required\n",
+		},
+		{
+			name:       "invalid period",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1&period=-1",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "invalid period: -1\n",
+		},
+		{
+			name:       "metrics dimensions not published",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-2",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "metrics dimensions not published for ingress: awesome-ns/ing-2\n",
+		},
+		{
+			name:          "bearer token not specified",
+			method:        http.MethodGet,
+			target:        "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      "bearer token is required\n",
+		},
+		{
+			name:            "bearer token not authenticated",
+			method:          http.MethodGet,
+			target:          "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1",
+			unauthenticated: true,
+			wantStatus:      http.StatusUnauthorized,
+			wantBody:        "bearer token isn't authenticated\n",
+		},
+		{
+			name:       "user not allowed to get configMap",
+			method:     http.MethodGet,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1",
+			forbidden:  true,
+			wantStatus: http.StatusForbidden,
+			wantBody:   "user awesome-user is not allowed to get configmap: awesome-ns/aws-lbc-metrics-ing-1\n",
+		},
+		{
+			name:       "POST request",
+			method:     http.MethodPost,
+			target:     "/ingress-metrics-expressions?namespace=awesome-ns&ingress=ing-1",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "method not allowed\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(context.Background(), cm.DeepCopy()))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tokenReviewer := k8s.NewMockTokenReviewer(ctrl)
+			tokenReviewer.EXPECT().Review(gomock.Any(), "awesome-token").Return(user, !tt.unauthenticated, nil).AnyTimes()
+			accessReviewer := k8s.NewMockAccessReviewer(ctrl)
+			accessReviewer.EXPECT().Review(gomock.Any(), user, authorizationv1.ResourceAttributes{
+				Namespace: "awesome-ns",
+				Verb:      "get",
+				Resource:  "configmaps",
+				Name:      "aws-lbc-metrics-ing-1",
+			}).Return(!tt.forbidden, "", nil).AnyTimes()
+			accessReviewer.EXPECT().Review(gomock.Any(), user, gomock.Any()).Return(true, "", nil).AnyTimes()
+
+			handler := NewMetricsExpressionsHandler(k8sClient, tokenReviewer, accessReviewer, &log.NullLogger{})
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			authorization := tt.authorization
+			if authorization == "" {
+				authorization = "Bearer awesome-token"
+			}
+			req.Header.Set("Authorization", authorization)
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantStatus != http.StatusOK {
+				assert.Equal(t, tt.wantBody, recorder.Body.String())
+				return
+			}
+			var got []PathMetricsExpressions
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.Len(t, got, 3)
+				assert.Equal(t, tgMetricStat("targetgroup/tg-1/1", "TargetResponseTime", "p99", 300), got[0].MetricDataQueries[0].MetricStat)
+			}
+		})
+	}
+}
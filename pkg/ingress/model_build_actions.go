@@ -6,13 +6,35 @@ import (
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	"strings"
+	"time"
 	"unicode"
 )
 
+const (
+	// weightRampRequeueInterval is the maximum interval between weight updates while a weight ramp is in progress,
+	// which is also the length of each step of the ramp.
+	weightRampRequeueInterval = 30 * time.Second
+	// canaryAnalysisRequeueInterval is the interval between evaluations of canary target groups' metrics.
+	canaryAnalysisRequeueInterval = 60 * time.Second
+	// defaultCanaryEvaluationPeriod is the default time period over which canary target groups' metrics are evaluated.
+	defaultCanaryEvaluationPeriod = 5 * time.Minute
+	// defaultCanaryMinRequestCount is the default minimum number of requests to canary target groups within the evaluation period,
+	// below which their HTTP 5XX rate isn't evaluated.
+	defaultCanaryMinRequestCount = 100
+
+	// annotationKeyCanaryRollbackReason is the annotation on TargetGroupBinding of a rolled back canary target group.
+	// canary target group won't receive traffic again until this annotation is removed.
+	annotationKeyCanaryRollbackReason = "elbv2.k8s.aws/canary-rollback-reason"
+)
+
 func (t *defaultModelBuildTask) buildActions(ctx context.Context, protocol elbv2model.Protocol, ing ClassifiedIngress, backend EnhancedBackend) ([]elbv2model.Action, error) {
 	var actions []elbv2model.Action
 	if protocol == elbv2model.ProtocolHTTPS {
@@ -101,10 +123,20 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing Clas
 	}
 
 	var targetGroupTuples []elbv2model.TargetGroupTuple
+	rolledBack := false
+	rollbackCfg := actionCfg.ForwardConfig.RollbackConfig
+	canaryRolloutInProgress := rollbackCfg != nil && isCanaryRolloutInProgress(*actionCfg.ForwardConfig)
 	for _, tgt := range actionCfg.ForwardConfig.TargetGroups {
 		var tgARN core.StringToken
+		weight := tgt.Weight
 		if tgt.TargetGroupARN != nil {
 			tgARN = core.LiteralStringToken(*tgt.TargetGroupARN)
+		} else if tgt.LambdaFunctionARN != nil {
+			tg, err := t.buildLambdaTargetGroup(ctx, ing, awssdk.StringValue(tgt.LambdaFunctionARN))
+			if err != nil {
+				return elbv2model.Action{}, err
+			}
+			tgARN = tg.TargetGroupARN()
 		} else {
 			svcKey := types.NamespacedName{
 				Namespace: ing.Ing.Namespace,
@@ -116,12 +148,31 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing Clas
 				return elbv2model.Action{}, err
 			}
 			tgARN = tg.TargetGroupARN()
+			if actionCfg.ForwardConfig.WeightRampConfig != nil && weight != nil {
+				rampedWeight, err := t.buildRampedTargetGroupWeight(ctx, svc, tg, *weight, *actionCfg.ForwardConfig.WeightRampConfig)
+				if err != nil {
+					return elbv2model.Action{}, err
+				}
+				weight = awssdk.Int64(rampedWeight)
+			}
+			if rollbackCfg != nil && svcKey.Name != rollbackCfg.StableServiceName {
+				rollbackReason, err := t.buildCanaryRollbackReason(ctx, ing, svc, tg, *rollbackCfg, canaryRolloutInProgress)
+				if err != nil {
+					return elbv2model.Action{}, err
+				}
+				if rollbackReason != "" {
+					rolledBack = true
+				}
+			}
 		}
 		targetGroupTuples = append(targetGroupTuples, elbv2model.TargetGroupTuple{
 			TargetGroupARN: tgARN,
-			Weight:         tgt.Weight,
+			Weight:         weight,
 		})
 	}
+	if rolledBack {
+		targetGroupTuples = buildRolledBackTargetGroupTuples(targetGroupTuples, *actionCfg.ForwardConfig)
+	}
 	var stickinessCfg *elbv2model.TargetGroupStickinessConfig
 	if actionCfg.ForwardConfig.TargetGroupStickinessConfig != nil {
 		stickinessCfg = &elbv2model.TargetGroupStickinessConfig{
@@ -139,6 +190,181 @@ func (t *defaultModelBuildTask) buildForwardAction(ctx context.Context, ing Clas
 	}, nil
 }
 
+// buildRampedTargetGroupWeight computes the effective weight for target group while its weight ramp is in progress.
+// the age of target group is measured from the creation of its TargetGroupBinding, a target group without
+// TargetGroupBinding yet is considered to be just created.
+func (t *defaultModelBuildTask) buildRampedTargetGroupWeight(ctx context.Context, svc *corev1.Service, tg *elbv2model.TargetGroup,
+	weight int64, rampCfg TargetGroupWeightRampConfig) (int64, error) {
+	tgbKey := types.NamespacedName{Namespace: svc.Namespace, Name: tg.Spec.Name}
+	tgb := &elbv2api.TargetGroupBinding{}
+	var elapsed time.Duration
+	if err := t.k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, errors.Wrapf(err, "failed to get targetGroupBinding: %v", tgbKey)
+		}
+	} else {
+		elapsed = t.now.Sub(tgb.CreationTimestamp.Time)
+	}
+	rampDuration := time.Duration(rampCfg.DurationSeconds) * time.Second
+	rampedWeight := computeRampedWeight(weight, elapsed, rampDuration, weightRampRequeueInterval)
+	if rampedWeight < weight {
+		t.requeueAfterRamp(weightRampRequeueInterval - elapsed%weightRampRequeueInterval)
+	}
+	return rampedWeight, nil
+}
+
+// requeueAfterRamp records that the model must be rebuilt to continue an ongoing weight ramp at its next step.
+func (t *defaultModelBuildTask) requeueAfterRamp(untilNextStep time.Duration) {
+	requeueAfter := untilNextStep
+	if requeueAfter > weightRampRequeueInterval {
+		requeueAfter = weightRampRequeueInterval
+	}
+	t.requeueWithin(requeueAfter)
+}
+
+// buildCanaryRollbackReason returns the reason why canary target group is rolled back, or empty if it's not rolled back.
+// canary target group is rolled back once its metrics breach the thresholds, which is recorded on its TargetGroupBinding when deployed,
+// so that it won't receive traffic again after its metrics recovered due to receiving no traffic.
+// metrics are only evaluated while the canary rollout is in progress, i.e. both stable and canary target groups receive traffic.
+func (t *defaultModelBuildTask) buildCanaryRollbackReason(ctx context.Context, ing ClassifiedIngress, svc *corev1.Service, tg *elbv2model.TargetGroup,
+	rollbackCfg TargetGroupRollbackConfig, rolloutInProgress bool) (string, error) {
+	tgbKey := types.NamespacedName{Namespace: svc.Namespace, Name: tg.Spec.Name}
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := t.k8sClient.Get(ctx, tgbKey, tgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			if rolloutInProgress {
+				t.requeueWithin(canaryAnalysisRequeueInterval)
+			}
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get targetGroupBinding: %v", tgbKey)
+	}
+	if rollbackReason, exists := tgb.Annotations[annotationKeyCanaryRollbackReason]; exists {
+		t.recordCanaryRollback(tg, rollbackReason)
+		return rollbackReason, nil
+	}
+	if !rolloutInProgress {
+		return "", nil
+	}
+
+	t.requeueWithin(canaryAnalysisRequeueInterval)
+	evaluationPeriod := defaultCanaryEvaluationPeriod
+	if rollbackCfg.EvaluationPeriodSeconds != nil {
+		evaluationPeriod = time.Duration(*rollbackCfg.EvaluationPeriodSeconds) * time.Second
+	}
+	metrics, err := t.tgMetricsCollector.Collect(ctx, tgb.Spec.TargetGroupARN, evaluationPeriod)
+	if err != nil {
+		// failure to collect metrics shouldn't block the reconcile, the canary target group will be evaluated again later.
+		t.logger.Error(err, "failed to collect canary targetGroup metrics", "targetGroupBinding", tgbKey)
+		t.eventRecorder.Event(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonFailedCollectCanaryMetrics,
+			fmt.Sprintf("Failed to collect metrics of canary service %v due to %v", svc.Name, err))
+		return "", nil
+	}
+	rollbackReason := computeCanaryRollbackReason(metrics, rollbackCfg)
+	if rollbackReason == "" {
+		return "", nil
+	}
+
+	t.recordCanaryRollback(tg, rollbackReason)
+	t.eventRecorder.Event(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonCanaryRollback,
+		fmt.Sprintf("Rolled back canary service %v to stable service %v: %v", svc.Name, rollbackCfg.StableServiceName, rollbackReason))
+	return rollbackReason, nil
+}
+
+// recordCanaryRollback records the rollback of canary target group on the template of its TargetGroupBinding,
+// which is annotated by the deployer.
+func (t *defaultModelBuildTask) recordCanaryRollback(tg *elbv2model.TargetGroup, rollbackReason string) {
+	tgb, exists := t.tgbByResID[tg.ID()]
+	if !exists {
+		return
+	}
+	if tgb.Spec.Template.Annotations == nil {
+		tgb.Spec.Template.Annotations = make(map[string]string)
+	}
+	tgb.Spec.Template.Annotations[annotationKeyCanaryRollbackReason] = rollbackReason
+}
+
+// isCanaryRolloutInProgress checks whether both stable and canary target groups in forwardCfg receive traffic.
+func isCanaryRolloutInProgress(forwardCfg ForwardActionConfig) bool {
+	var stableWeight, canaryWeight int64
+	for _, tgt := range forwardCfg.TargetGroups {
+		if tgt.ServiceName == nil {
+			continue
+		}
+		if awssdk.StringValue(tgt.ServiceName) == forwardCfg.RollbackConfig.StableServiceName {
+			stableWeight += awssdk.Int64Value(tgt.Weight)
+		} else {
+			canaryWeight += awssdk.Int64Value(tgt.Weight)
+		}
+	}
+	return stableWeight > 0 && canaryWeight > 0
+}
+
+// requeueWithin records that the model must be rebuilt within duration.
+func (t *defaultModelBuildTask) requeueWithin(duration time.Duration) {
+	if t.requeueAfter == 0 || duration < t.requeueAfter {
+		t.requeueAfter = duration
+	}
+}
+
+// computeCanaryRollbackReason describes the threshold breached by metrics of canary target group, or empty if none breached.
+func computeCanaryRollbackReason(metrics cloudwatchdeploy.TargetGroupMetrics, rollbackCfg TargetGroupRollbackConfig) string {
+	minRequestCount := int64(defaultCanaryMinRequestCount)
+	if rollbackCfg.MinRequestCount != nil {
+		minRequestCount = *rollbackCfg.MinRequestCount
+	}
+	if rollbackCfg.HTTPCode5XXPercentThreshold != nil && metrics.RequestCount > 0 && metrics.RequestCount >= float64(minRequestCount) {
+		httpCode5XXPercent := metrics.HTTPCodeTarget5XXCount * 100 / metrics.RequestCount
+		if httpCode5XXPercent > *rollbackCfg.HTTPCode5XXPercentThreshold {
+			return fmt.Sprintf("HTTP 5XX rate %.2f%% exceeds threshold %v%%", httpCode5XXPercent, *rollbackCfg.HTTPCode5XXPercentThreshold)
+		}
+	}
+	if rollbackCfg.UnhealthyHostCountThreshold != nil && metrics.MaxUnHealthyHostCount > float64(*rollbackCfg.UnhealthyHostCountThreshold) {
+		return fmt.Sprintf("unhealthy host count %v exceeds threshold %v", metrics.MaxUnHealthyHostCount, *rollbackCfg.UnhealthyHostCountThreshold)
+	}
+	return ""
+}
+
+// buildRolledBackTargetGroupTuples zeroes the weights of canary target groups to route all traffic to the stable target groups.
+// stable target groups keep their weights, unless they are all zero, in which case they get weight 1 to receive traffic.
+// targetGroupTuples must be built from forwardCfg.TargetGroups in order.
+func buildRolledBackTargetGroupTuples(targetGroupTuples []elbv2model.TargetGroupTuple, forwardCfg ForwardActionConfig) []elbv2model.TargetGroupTuple {
+	var stableWeight int64
+	for i, tuple := range targetGroupTuples {
+		if awssdk.StringValue(forwardCfg.TargetGroups[i].ServiceName) == forwardCfg.RollbackConfig.StableServiceName {
+			stableWeight += awssdk.Int64Value(tuple.Weight)
+		}
+	}
+	rolledBackTuples := make([]elbv2model.TargetGroupTuple, 0, len(targetGroupTuples))
+	for i, tuple := range targetGroupTuples {
+		tgt := forwardCfg.TargetGroups[i]
+		if awssdk.StringValue(tgt.ServiceName) != forwardCfg.RollbackConfig.StableServiceName {
+			tuple.Weight = awssdk.Int64(0)
+		} else if stableWeight == 0 {
+			tuple.Weight = awssdk.Int64(1)
+		}
+		rolledBackTuples = append(rolledBackTuples, tuple)
+	}
+	return rolledBackTuples
+}
+
+// computeRampedWeight linearly scales weight in steps of stepDuration over rampDuration.
+// the ramp starts at the weight of its first step instead of 0, so that the first step already sends traffic.
+func computeRampedWeight(weight int64, elapsed time.Duration, rampDuration time.Duration, stepDuration time.Duration) int64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	stepEnd := (elapsed/stepDuration + 1) * stepDuration
+	if stepEnd >= rampDuration {
+		return weight
+	}
+	rampedWeight := int64(float64(weight) * float64(stepEnd) / float64(rampDuration))
+	if rampedWeight == 0 && weight > 0 {
+		return 1
+	}
+	return rampedWeight
+}
+
 func (t *defaultModelBuildTask) buildAuthenticateCognitoAction(_ context.Context, authCfg AuthConfig) (elbv2model.Action, error) {
 	if authCfg.IDPConfigCognito == nil {
 		return elbv2model.Action{}, errors.New("missing IDPConfigCognito")
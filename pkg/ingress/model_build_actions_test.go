@@ -3,15 +3,24 @@ package ingress
 import (
 	"context"
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"testing"
+	"time"
 )
 
 func Test_defaultModelBuildTask_buildAuthenticateOIDCAction(t *testing.T) {
@@ -284,3 +293,576 @@ func Test_defaultModelBuildTask_buildSSLRedirectAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildRampedTargetGroupWeight(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	type env struct {
+		tgbs []*elbv2api.TargetGroupBinding
+	}
+	type args struct {
+		weight  int64
+		rampCfg TargetGroupWeightRampConfig
+	}
+	tests := []struct {
+		name             string
+		env              env
+		args             args
+		want             int64
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name: "targetGroupBinding not exists yet",
+			args: args{
+				weight:  100,
+				rampCfg: TargetGroupWeightRampConfig{DurationSeconds: 300},
+			},
+			want:             10,
+			wantRequeueAfter: 30 * time.Second,
+		},
+		{
+			name: "ramp in progress",
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:         "my-ns",
+							Name:              "k8s-my-tg",
+							CreationTimestamp: metav1.NewTime(now.Add(-160 * time.Second)),
+						},
+					},
+				},
+			},
+			args: args{
+				weight:  80,
+				rampCfg: TargetGroupWeightRampConfig{DurationSeconds: 300},
+			},
+			want:             48,
+			wantRequeueAfter: 20 * time.Second,
+		},
+		{
+			name: "ramp at last step",
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:         "my-ns",
+							Name:              "k8s-my-tg",
+							CreationTimestamp: metav1.NewTime(now.Add(-290 * time.Second)),
+						},
+					},
+				},
+			},
+			args: args{
+				weight:  100,
+				rampCfg: TargetGroupWeightRampConfig{DurationSeconds: 300},
+			},
+			want:             100,
+			wantRequeueAfter: 0,
+		},
+		{
+			name: "ramp finished",
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:         "my-ns",
+							Name:              "k8s-my-tg",
+							CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+						},
+					},
+				},
+			},
+			args: args{
+				weight:  100,
+				rampCfg: TargetGroupWeightRampConfig{DurationSeconds: 300},
+			},
+			want:             100,
+			wantRequeueAfter: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, tgb := range tt.env.tgbs {
+				err := k8sClient.Create(context.Background(), tgb.DeepCopy())
+				assert.NoError(t, err)
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "my-ns", Name: "my-ing"})
+			tg := elbv2model.NewTargetGroup(stack, "my-tg", elbv2model.TargetGroupSpec{Name: "k8s-my-tg"})
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-svc"}}
+			task := &defaultModelBuildTask{
+				k8sClient: k8sClient,
+				now:       now,
+			}
+			got, err := task.buildRampedTargetGroupWeight(context.Background(), svc, tg, tt.args.weight, tt.args.rampCfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequeueAfter, task.requeueAfter)
+		})
+	}
+}
+
+func Test_computeRampedWeight(t *testing.T) {
+	type args struct {
+		weight       int64
+		elapsed      time.Duration
+		rampDuration time.Duration
+		stepDuration time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want int64
+	}{
+		{
+			name: "ramp starts at first step",
+			args: args{
+				weight:       100,
+				elapsed:      0,
+				rampDuration: 300 * time.Second,
+				stepDuration: 30 * time.Second,
+			},
+			want: 10,
+		},
+		{
+			name: "weight stays within step",
+			args: args{
+				weight:       100,
+				elapsed:      59 * time.Second,
+				rampDuration: 300 * time.Second,
+				stepDuration: 30 * time.Second,
+			},
+			want: 20,
+		},
+		{
+			name: "first step is at least 1 for small weight",
+			args: args{
+				weight:       1,
+				elapsed:      0,
+				rampDuration: time.Hour,
+				stepDuration: 30 * time.Second,
+			},
+			want: 1,
+		},
+		{
+			name: "zero weight stays zero",
+			args: args{
+				weight:       0,
+				elapsed:      0,
+				rampDuration: 300 * time.Second,
+				stepDuration: 30 * time.Second,
+			},
+			want: 0,
+		},
+		{
+			name: "last step reaches full weight",
+			args: args{
+				weight:       100,
+				elapsed:      270 * time.Second,
+				rampDuration: 300 * time.Second,
+				stepDuration: 30 * time.Second,
+			},
+			want: 100,
+		},
+		{
+			name: "ramp shorter than a step",
+			args: args{
+				weight:       100,
+				elapsed:      0,
+				rampDuration: 10 * time.Second,
+				stepDuration: 30 * time.Second,
+			},
+			want: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeRampedWeight(tt.args.weight, tt.args.elapsed, tt.args.rampDuration, tt.args.stepDuration)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildCanaryRollbackReason(t *testing.T) {
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/k8s-my-tg/73e2d6bc24d8a067"
+	rollbackCfg := TargetGroupRollbackConfig{
+		StableServiceName:           "stable-svc",
+		HTTPCode5XXPercentThreshold: awssdk.Float64(5),
+		EvaluationPeriodSeconds:     awssdk.Int64(120),
+	}
+	type collectCall struct {
+		metrics cloudwatchdeploy.TargetGroupMetrics
+		err     error
+	}
+	type env struct {
+		tgbs []*elbv2api.TargetGroupBinding
+	}
+	tests := []struct {
+		name                    string
+		env                     env
+		rolloutInProgress       bool
+		collectCalls            []collectCall
+		want                    string
+		wantTemplateAnnotations map[string]string
+		wantRequeueAfter        time.Duration
+		wantEvents              int
+	}{
+		{
+			name:              "targetGroupBinding not exists yet",
+			rolloutInProgress: true,
+			want:              "",
+			wantRequeueAfter:  60 * time.Second,
+		},
+		{
+			name:              "targetGroupBinding not exists yet and rollout not in progress",
+			rolloutInProgress: false,
+			want:              "",
+			wantRequeueAfter:  0,
+		},
+		{
+			name:              "canary is healthy",
+			rolloutInProgress: true,
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "k8s-my-tg"},
+						Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			collectCalls: []collectCall{
+				{
+					metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 1000, HTTPCodeTarget5XXCount: 10},
+				},
+			},
+			want:             "",
+			wantRequeueAfter: 60 * time.Second,
+		},
+		{
+			name:              "canary breached threshold",
+			rolloutInProgress: true,
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "k8s-my-tg"},
+						Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			collectCalls: []collectCall{
+				{
+					metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 1000, HTTPCodeTarget5XXCount: 100},
+				},
+			},
+			want: "HTTP 5XX rate 10.00% exceeds threshold 5%",
+			wantTemplateAnnotations: map[string]string{
+				"elbv2.k8s.aws/canary-rollback-reason": "HTTP 5XX rate 10.00% exceeds threshold 5%",
+			},
+			wantRequeueAfter: 60 * time.Second,
+			wantEvents:       1,
+		},
+		{
+			name:              "canary already rolled back",
+			rolloutInProgress: true,
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "k8s-my-tg",
+							Annotations: map[string]string{
+								"elbv2.k8s.aws/canary-rollback-reason": "unhealthy host count 3 exceeds threshold 1",
+							},
+						},
+						Spec: elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			want: "unhealthy host count 3 exceeds threshold 1",
+			wantTemplateAnnotations: map[string]string{
+				"elbv2.k8s.aws/canary-rollback-reason": "unhealthy host count 3 exceeds threshold 1",
+			},
+			wantRequeueAfter: 0,
+		},
+		{
+			name: "canary already rolled back and rollout not in progress",
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "my-ns",
+							Name:      "k8s-my-tg",
+							Annotations: map[string]string{
+								"elbv2.k8s.aws/canary-rollback-reason": "unhealthy host count 3 exceeds threshold 1",
+							},
+						},
+						Spec: elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			rolloutInProgress: false,
+			want:              "unhealthy host count 3 exceeds threshold 1",
+			wantTemplateAnnotations: map[string]string{
+				"elbv2.k8s.aws/canary-rollback-reason": "unhealthy host count 3 exceeds threshold 1",
+			},
+			wantRequeueAfter: 0,
+		},
+		{
+			name: "rollout not in progress",
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "k8s-my-tg"},
+						Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			rolloutInProgress: false,
+			want:              "",
+			wantRequeueAfter:  0,
+		},
+		{
+			name:              "failed to collect metrics",
+			rolloutInProgress: true,
+			env: env{
+				tgbs: []*elbv2api.TargetGroupBinding{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "k8s-my-tg"},
+						Spec:       elbv2api.TargetGroupBindingSpec{TargetGroupARN: tgARN},
+					},
+				},
+			},
+			collectCalls: []collectCall{
+				{
+					err: errors.New("access denied"),
+				},
+			},
+			want:             "",
+			wantRequeueAfter: 60 * time.Second,
+			wantEvents:       1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, tgb := range tt.env.tgbs {
+				err := k8sClient.Create(context.Background(), tgb.DeepCopy())
+				assert.NoError(t, err)
+			}
+			tgMetricsCollector := cloudwatchdeploy.NewMockTargetGroupMetricsCollector(ctrl)
+			for _, call := range tt.collectCalls {
+				tgMetricsCollector.EXPECT().Collect(gomock.Any(), tgARN, 2*time.Minute).Return(call.metrics, call.err)
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+
+			stack := core.NewDefaultStack(core.StackID{Namespace: "my-ns", Name: "my-ing"})
+			tg := elbv2model.NewTargetGroup(stack, "my-tg", elbv2model.TargetGroupSpec{Name: "k8s-my-tg"})
+			tgb := elbv2model.NewTargetGroupBindingResource(stack, tg.ID(), elbv2model.TargetGroupBindingResourceSpec{
+				Template: elbv2model.TargetGroupBindingTemplate{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "k8s-my-tg"},
+					Spec: elbv2model.TargetGroupBindingSpec{
+						TargetGroupARN: tg.TargetGroupARN(),
+					},
+				},
+			})
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "canary-svc"}}
+			ing := ClassifiedIngress{Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-ing"}}}
+			task := &defaultModelBuildTask{
+				k8sClient:          k8sClient,
+				eventRecorder:      eventRecorder,
+				tgMetricsCollector: tgMetricsCollector,
+				logger:             &log.NullLogger{},
+				tgbByResID:         map[string]*elbv2model.TargetGroupBindingResource{tg.ID(): tgb},
+			}
+			got, err := task.buildCanaryRollbackReason(context.Background(), ing, svc, tg, rollbackCfg, tt.rolloutInProgress)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequeueAfter, task.requeueAfter)
+			assert.Equal(t, tt.wantEvents, len(eventRecorder.Events))
+			assert.Equal(t, tt.wantTemplateAnnotations, tgb.Spec.Template.Annotations)
+			for _, wantTGB := range tt.env.tgbs {
+				gotTGB := &elbv2api.TargetGroupBinding{}
+				assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "my-ns", Name: "k8s-my-tg"}, gotTGB))
+				assert.Equal(t, wantTGB.Annotations, gotTGB.Annotations)
+			}
+		})
+	}
+}
+
+func Test_computeCanaryRollbackReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		metrics     cloudwatchdeploy.TargetGroupMetrics
+		rollbackCfg TargetGroupRollbackConfig
+		want        string
+	}{
+		{
+			name:    "5XX rate within threshold",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 200, HTTPCodeTarget5XXCount: 2},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(1),
+			},
+			want: "",
+		},
+		{
+			name:    "5XX rate exceeds threshold",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 200, HTTPCodeTarget5XXCount: 3},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(1),
+			},
+			want: "HTTP 5XX rate 1.50% exceeds threshold 1%",
+		},
+		{
+			name:    "5XX rate exceeds threshold with requests below default minimum",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 50, HTTPCodeTarget5XXCount: 10},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(1),
+			},
+			want: "",
+		},
+		{
+			name:    "5XX rate exceeds threshold with requests reaching configured minimum",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 50, HTTPCodeTarget5XXCount: 10},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(1),
+				MinRequestCount:             awssdk.Int64(50),
+			},
+			want: "HTTP 5XX rate 20.00% exceeds threshold 1%",
+		},
+		{
+			name:    "no requests",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(0),
+			},
+			want: "",
+		},
+		{
+			name:    "unhealthy host count exceeds threshold",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 200, MaxUnHealthyHostCount: 2},
+			rollbackCfg: TargetGroupRollbackConfig{
+				HTTPCode5XXPercentThreshold: awssdk.Float64(1),
+				UnhealthyHostCountThreshold: awssdk.Int64(1),
+			},
+			want: "unhealthy host count 2 exceeds threshold 1",
+		},
+		{
+			name:    "unhealthy host count within threshold",
+			metrics: cloudwatchdeploy.TargetGroupMetrics{RequestCount: 200, MaxUnHealthyHostCount: 1},
+			rollbackCfg: TargetGroupRollbackConfig{
+				UnhealthyHostCountThreshold: awssdk.Int64(1),
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeCanaryRollbackReason(tt.metrics, tt.rollbackCfg)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_isCanaryRolloutInProgress(t *testing.T) {
+	tests := []struct {
+		name       string
+		forwardCfg ForwardActionConfig
+		want       bool
+	}{
+		{
+			name: "both stable and canary receive traffic",
+			forwardCfg: ForwardActionConfig{
+				TargetGroups: []TargetGroupTuple{
+					{ServiceName: awssdk.String("stable-svc"), Weight: awssdk.Int64(80)},
+					{ServiceName: awssdk.String("canary-svc"), Weight: awssdk.Int64(20)},
+				},
+				RollbackConfig: &TargetGroupRollbackConfig{StableServiceName: "stable-svc"},
+			},
+			want: true,
+		},
+		{
+			name: "canary receives no traffic",
+			forwardCfg: ForwardActionConfig{
+				TargetGroups: []TargetGroupTuple{
+					{ServiceName: awssdk.String("stable-svc"), Weight: awssdk.Int64(100)},
+					{ServiceName: awssdk.String("canary-svc"), Weight: awssdk.Int64(0)},
+				},
+				RollbackConfig: &TargetGroupRollbackConfig{StableServiceName: "stable-svc"},
+			},
+			want: false,
+		},
+		{
+			name: "stable receives no traffic",
+			forwardCfg: ForwardActionConfig{
+				TargetGroups: []TargetGroupTuple{
+					{ServiceName: awssdk.String("stable-svc"), Weight: awssdk.Int64(0)},
+					{ServiceName: awssdk.String("canary-svc"), Weight: awssdk.Int64(100)},
+				},
+				RollbackConfig: &TargetGroupRollbackConfig{StableServiceName: "stable-svc"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isCanaryRolloutInProgress(tt.forwardCfg)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_buildRolledBackTargetGroupTuples(t *testing.T) {
+	forwardCfg := ForwardActionConfig{
+		TargetGroups: []TargetGroupTuple{
+			{ServiceName: awssdk.String("stable-svc"), Weight: awssdk.Int64(80)},
+			{ServiceName: awssdk.String("canary-svc"), Weight: awssdk.Int64(20)},
+		},
+		RollbackConfig: &TargetGroupRollbackConfig{
+			StableServiceName: "stable-svc",
+		},
+	}
+	tests := []struct {
+		name              string
+		targetGroupTuples []elbv2model.TargetGroupTuple
+		want              []elbv2model.TargetGroupTuple
+	}{
+		{
+			name: "stable keeps its weight",
+			targetGroupTuples: []elbv2model.TargetGroupTuple{
+				{TargetGroupARN: core.LiteralStringToken("stable-tg-arn"), Weight: awssdk.Int64(80)},
+				{TargetGroupARN: core.LiteralStringToken("canary-tg-arn"), Weight: awssdk.Int64(10)},
+			},
+			want: []elbv2model.TargetGroupTuple{
+				{TargetGroupARN: core.LiteralStringToken("stable-tg-arn"), Weight: awssdk.Int64(80)},
+				{TargetGroupARN: core.LiteralStringToken("canary-tg-arn"), Weight: awssdk.Int64(0)},
+			},
+		},
+		{
+			name: "stable receives traffic when its weight is zero",
+			targetGroupTuples: []elbv2model.TargetGroupTuple{
+				{TargetGroupARN: core.LiteralStringToken("stable-tg-arn"), Weight: awssdk.Int64(0)},
+				{TargetGroupARN: core.LiteralStringToken("canary-tg-arn"), Weight: awssdk.Int64(100)},
+			},
+			want: []elbv2model.TargetGroupTuple{
+				{TargetGroupARN: core.LiteralStringToken("stable-tg-arn"), Weight: awssdk.Int64(1)},
+				{TargetGroupARN: core.LiteralStringToken("canary-tg-arn"), Weight: awssdk.Int64(0)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildRolledBackTargetGroupTuples(tt.targetGroupTuples, forwardCfg)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
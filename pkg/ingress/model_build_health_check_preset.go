@@ -0,0 +1,74 @@
+package ingress
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+// healthCheckPreset is a set of health check defaults for the health endpoint of a framework.
+type healthCheckPreset struct {
+	// the health check port, which is traffic-port, a port number or a named port of the service.
+	port string
+	// the health check path.
+	path string
+	// the status codes that indicate healthy targets, which are HTTP codes or gRPC codes depending on protocolVersions.
+	successCodes string
+	// the backend protocol versions the preset applies to.
+	protocolVersions []elbv2model.ProtocolVersion
+}
+
+var healthCheckPresetProtocolVersionsHTTP = []elbv2model.ProtocolVersion{elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2}
+
+// healthCheckPresets are the built-in health check presets, keyed by the preset name.
+var healthCheckPresets = map[string]healthCheckPreset{
+	// Spring Boot Actuator health endpoint.
+	"spring-boot": {
+		port:             healthCheckPortTrafficPort,
+		path:             "/actuator/health",
+		successCodes:     "200",
+		protocolVersions: healthCheckPresetProtocolVersionsHTTP,
+	},
+	// gRPC health checking protocol, as probed by grpc-health-probe.
+	"grpc-health-probe": {
+		port:             healthCheckPortTrafficPort,
+		path:             "/grpc.health.v1.Health/Check",
+		successCodes:     "0",
+		protocolVersions: []elbv2model.ProtocolVersion{elbv2model.ProtocolVersionGRPC},
+	},
+	// Rails health check endpoint, generated since Rails 7.1.
+	"rails": {
+		port:             healthCheckPortTrafficPort,
+		path:             "/up",
+		successCodes:     "200",
+		protocolVersions: healthCheckPresetProtocolVersionsHTTP,
+	},
+	// django-health-check endpoint, with its suggested URL.
+	"django": {
+		port:             healthCheckPortTrafficPort,
+		path:             "/ht/",
+		successCodes:     "200",
+		protocolVersions: healthCheckPresetProtocolVersionsHTTP,
+	},
+}
+
+// buildTargetGroupHealthCheckPreset returns the health check preset selected via annotation, or nil if none is selected.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPreset(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) (*healthCheckPreset, error) {
+	rawPreset := ""
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPreset, &rawPreset, svcAndIngAnnotations); !exists {
+		return nil, nil
+	}
+	preset, ok := healthCheckPresets[rawPreset]
+	if !ok {
+		return nil, errors.Errorf("unknown healthCheckPreset: %v, must be within %v", rawPreset, sets.StringKeySet(healthCheckPresets).List())
+	}
+	for _, protocolVersion := range preset.protocolVersions {
+		if protocolVersion == tgProtocolVersion {
+			return &preset, nil
+		}
+	}
+	return nil, errors.Errorf("healthCheckPreset %v doesn't support backend protocol version %v", rawPreset, tgProtocolVersion)
+}
@@ -0,0 +1,97 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPreset(t *testing.T) {
+	type args struct {
+		svcAndIngAnnotations map[string]string
+		tgProtocolVersion    elbv2model.ProtocolVersion
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *healthCheckPreset
+		wantErr error
+	}{
+		{
+			name: "without annotation configured",
+			args: args{
+				svcAndIngAnnotations: nil,
+				tgProtocolVersion:    elbv2model.ProtocolVersionHTTP1,
+			},
+			want: nil,
+		},
+		{
+			name: "spring-boot preset",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-preset": "spring-boot",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: &healthCheckPreset{
+				port:             "traffic-port",
+				path:             "/actuator/health",
+				successCodes:     "200",
+				protocolVersions: []elbv2model.ProtocolVersion{elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2},
+			},
+		},
+		{
+			name: "grpc-health-probe preset",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-preset": "grpc-health-probe",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			},
+			want: &healthCheckPreset{
+				port:             "traffic-port",
+				path:             "/grpc.health.v1.Health/Check",
+				successCodes:     "0",
+				protocolVersions: []elbv2model.ProtocolVersion{elbv2model.ProtocolVersionGRPC},
+			},
+		},
+		{
+			name: "grpc-health-probe preset with HTTP1 backend",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-preset": "grpc-health-probe",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("healthCheckPreset grpc-health-probe doesn't support backend protocol version HTTP1"),
+		},
+		{
+			name: "unknown preset",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-preset": "flask",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("unknown healthCheckPreset: flask, must be within [django grpc-health-probe rails spring-boot]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupHealthCheckPreset(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
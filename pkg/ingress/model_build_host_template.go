@@ -0,0 +1,175 @@
+package ingress
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+)
+
+// AnnotationKeyRoute53Record is the annotation on Ingress recording the Route 53 alias record managed for its templated host
+// in "<hostedZoneID>/<recordName>" format, so that the record can be deleted after route53-hosted-zone-id or host-template annotation changes.
+const AnnotationKeyRoute53Record = "elbv2.k8s.aws/route53-record"
+
+// expandHostTemplates replaces members of IngressGroup with host-template annotation by copies whose routing is scoped to the templated host,
+// so that Ingresses like PR-preview environments from different namespaces can share an ALB without conflicting with each other:
+//  1. rules without host get the templated host.
+//  2. default backend turns into a rule of the templated host, instead of the default action of listeners.
+func (t *defaultModelBuildTask) expandHostTemplates(ctx context.Context) error {
+	members := make([]ClassifiedIngress, 0, len(t.ingGroup.Members))
+	for _, member := range t.ingGroup.Members {
+		host, exists, err := buildTemplatedHost(t.annotationParser, member.Ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(member.Ing))
+		}
+		if exists {
+			member = ClassifiedIngress{
+				Ing:            expandIngressHost(member.Ing, host),
+				IngClassConfig: member.IngClassConfig,
+			}
+		}
+		members = append(members, member)
+	}
+	t.ingGroup.Members = members
+	return nil
+}
+
+// buildTemplatedHost builds the host of Ingress from its host-template annotation.
+func buildTemplatedHost(annotationParser annotations.Parser, ing *networking.Ingress) (string, bool, error) {
+	rawHostTemplate := ""
+	if exists := annotationParser.ParseStringAnnotation(annotations.IngressSuffixHostTemplate, &rawHostTemplate, ing.Annotations); !exists {
+		return "", false, nil
+	}
+	host := strings.NewReplacer(
+		annotations.HostTemplatePlaceholderNamespace, ing.Namespace,
+		annotations.HostTemplatePlaceholderName, ing.Name,
+	).Replace(rawHostTemplate)
+	host = strings.ToLower(host)
+	if errs := validation.IsDNS1123Subdomain(host); len(errs) != 0 {
+		return "", false, errors.Errorf("host template %v expands to invalid host %v: %v", rawHostTemplate, host, strings.Join(errs, ", "))
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) > validation.DNS1123LabelMaxLength {
+			return "", false, errors.Errorf("host template %v expands to invalid host %v: labels must be no more than %v characters",
+				rawHostTemplate, host, validation.DNS1123LabelMaxLength)
+		}
+	}
+	return host, true, nil
+}
+
+// expandIngressHost returns a copy of Ingress whose routing is scoped to host.
+func expandIngressHost(ing *networking.Ingress, host string) *networking.Ingress {
+	expandedIng := ing.DeepCopy()
+	for i := range expandedIng.Spec.Rules {
+		if expandedIng.Spec.Rules[i].Host == "" {
+			expandedIng.Spec.Rules[i].Host = host
+		}
+	}
+	if expandedIng.Spec.DefaultBackend != nil {
+		expandedIng.Spec.Rules = append(expandedIng.Spec.Rules, networking.IngressRule{
+			Host: host,
+			IngressRuleValue: networking.IngressRuleValue{
+				HTTP: &networking.HTTPIngressRuleValue{
+					Paths: []networking.HTTPIngressPath{
+						{
+							Backend: *expandedIng.Spec.DefaultBackend,
+						},
+					},
+				},
+			},
+		})
+		expandedIng.Spec.DefaultBackend = nil
+	}
+	return expandedIng
+}
+
+// BuildIngressRoute53Record builds the Route 53 alias record of Ingress in "<hostedZoneID>/<recordName>" format,
+// or empty if Ingress doesn't request one via route53-hosted-zone-id annotation.
+func BuildIngressRoute53Record(annotationParser annotations.Parser, ing *networking.Ingress) (string, error) {
+	hostedZoneID := ""
+	if exists := annotationParser.ParseStringAnnotation(annotations.IngressSuffixRoute53HostedZoneID, &hostedZoneID, ing.Annotations); !exists {
+		return "", nil
+	}
+	host, exists, err := buildTemplatedHost(annotationParser, ing)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.Errorf("%v annotation requires %v annotation",
+			annotations.IngressSuffixRoute53HostedZoneID, annotations.IngressSuffixHostTemplate)
+	}
+	return hostedZoneID + "/" + host, nil
+}
+
+// parseRoute53Record parses the hostedZoneID and recordName from Route 53 alias record in "<hostedZoneID>/<recordName>" format.
+func parseRoute53Record(record string) (string, string, bool) {
+	parts := strings.SplitN(record, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// buildRoute53RecordSetGroups builds the alias records of templated hosts within hosted zones specified via route53-hosted-zone-id annotation.
+// records recorded on members by AnnotationKeyRoute53Record that are no longer desired, and records of inactive members are included as stale records,
+// so that they are deleted.
+func (t *defaultModelBuildTask) buildRoute53RecordSetGroups(_ context.Context, lb *elbv2model.LoadBalancer) error {
+	recordNamesByHostedZoneID := make(map[string]sets.String)
+	staleRecordNamesByHostedZoneID := make(map[string]sets.String)
+	addRecord := func(namesByHostedZoneID map[string]sets.String, record string) {
+		hostedZoneID, recordName, ok := parseRoute53Record(record)
+		if !ok {
+			return
+		}
+		for _, names := range []map[string]sets.String{recordNamesByHostedZoneID, staleRecordNamesByHostedZoneID} {
+			if _, exists := names[hostedZoneID]; !exists {
+				names[hostedZoneID] = sets.NewString()
+			}
+		}
+		namesByHostedZoneID[hostedZoneID].Insert(recordName)
+	}
+	for _, inactiveMember := range t.ingGroup.InactiveMembers {
+		addRecord(staleRecordNamesByHostedZoneID, inactiveMember.Annotations[AnnotationKeyRoute53Record])
+		// the annotations of inactive members are used as well, in case their records haven't been recorded yet.
+		if record, err := BuildIngressRoute53Record(t.annotationParser, inactiveMember); err == nil {
+			addRecord(staleRecordNamesByHostedZoneID, record)
+		}
+	}
+	for _, member := range t.ingGroup.Members {
+		record, err := BuildIngressRoute53Record(t.annotationParser, member.Ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(member.Ing))
+		}
+		addRecord(recordNamesByHostedZoneID, record)
+		if recordedRecord := member.Ing.Annotations[AnnotationKeyRoute53Record]; recordedRecord != record {
+			addRecord(staleRecordNamesByHostedZoneID, recordedRecord)
+		}
+	}
+
+	for _, hostedZoneID := range sets.StringKeySet(recordNamesByHostedZoneID).List() {
+		recordNames := recordNamesByHostedZoneID[hostedZoneID]
+		spec := route53model.RecordSetGroupSpec{
+			HostedZoneID:     hostedZoneID,
+			RecordNames:      recordNames.List(),
+			StaleRecordNames: staleRecordNamesByHostedZoneID[hostedZoneID].Difference(recordNames).List(),
+		}
+		if len(spec.RecordNames) != 0 {
+			if lb == nil {
+				return errors.New("[should never happen] load balancer must be specified for records")
+			}
+			spec.AliasTarget = &route53model.AliasTarget{
+				DNSName:      lb.DNSName(),
+				HostedZoneID: lb.CanonicalHostedZoneID(),
+			}
+		}
+		_ = route53model.NewRecordSetGroup(t.stack, hostedZoneID, spec)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	route53model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/route53"
+)
+
+func Test_defaultModelBuildTask_expandHostTemplates(t *testing.T) {
+	svcBackend := func(name string) networking.IngressBackend {
+		return networking.IngressBackend{
+			Service: &networking.IngressServiceBackend{
+				Name: name,
+				Port: networking.ServiceBackendPort{Number: 80},
+			},
+		}
+	}
+	defaultBackend := svcBackend("svc-default")
+	tests := []struct {
+		name    string
+		ing     *networking.Ingress
+		want    *networking.Ingress
+		wantErr error
+	}{
+		{
+			name: "host-template annotation absent",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "pr-1", Name: "ing"},
+				Spec: networking.IngressSpec{
+					DefaultBackend: &defaultBackend,
+				},
+			},
+			want: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "pr-1", Name: "ing"},
+				Spec: networking.IngressSpec{
+					DefaultBackend: &defaultBackend,
+				},
+			},
+		},
+		{
+			name: "rules without host and default backend are scoped to templated host",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "PR-1",
+					Name:      "ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/host-template": "{{namespace}}-{{name}}.preview.example.com",
+					},
+				},
+				Spec: networking.IngressSpec{
+					DefaultBackend: &defaultBackend,
+					Rules: []networking.IngressRule{
+						{
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{{Path: "/api", Backend: svcBackend("svc-api")}},
+								},
+							},
+						},
+						{
+							Host: "static.example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{{Path: "/", Backend: svcBackend("svc-static")}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "PR-1",
+					Name:      "ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/host-template": "{{namespace}}-{{name}}.preview.example.com",
+					},
+				},
+				Spec: networking.IngressSpec{
+					Rules: []networking.IngressRule{
+						{
+							Host: "pr-1-ing.preview.example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{{Path: "/api", Backend: svcBackend("svc-api")}},
+								},
+							},
+						},
+						{
+							Host: "static.example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{{Path: "/", Backend: svcBackend("svc-static")}},
+								},
+							},
+						},
+						{
+							Host: "pr-1-ing.preview.example.com",
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{{Backend: defaultBackend}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "host template expands to invalid host",
+			ing: &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "pr-1",
+					Name:      "ing",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/host-template": "{{namespace}}-this-label-is-way-too-long-to-be-a-valid-dns-label-of-any-hostname.example.com",
+					},
+				},
+			},
+			wantErr: errors.New("ingress: pr-1/ing: host template {{namespace}}-this-label-is-way-too-long-to-be-a-valid-dns-label-of-any-hostname.example.com expands to invalid host pr-1-this-label-is-way-too-long-to-be-a-valid-dns-label-of-any-hostname.example.com: labels must be no more than 63 characters"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalIng := tt.ing.DeepCopy()
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup: Group{
+					Members: []ClassifiedIngress{{Ing: tt.ing}},
+				},
+			}
+			err := task.expandHostTemplates(context.Background())
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, task.ingGroup.Members[0].Ing)
+			}
+			assert.Equal(t, originalIng, tt.ing)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildRoute53RecordSetGroups(t *testing.T) {
+	previewIng := func(namespace string, hostedZoneID string) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "ing",
+				Annotations: map[string]string{
+					"alb.ingress.kubernetes.io/host-template": "{{namespace}}.preview.example.com",
+				},
+			},
+		}
+		if hostedZoneID != "" {
+			ing.Annotations["alb.ingress.kubernetes.io/route53-hosted-zone-id"] = hostedZoneID
+		}
+		return ing
+	}
+	recordedPreviewIng := func(namespace string, hostedZoneID string, recordedRecord string) *networking.Ingress {
+		ing := previewIng(namespace, hostedZoneID)
+		ing.Annotations["elbv2.k8s.aws/route53-record"] = recordedRecord
+		return ing
+	}
+	tests := []struct {
+		name            string
+		members         []*networking.Ingress
+		inactiveMembers []*networking.Ingress
+		withLB          bool
+		want            []route53model.RecordSetGroupSpec
+		wantErr         error
+	}{
+		{
+			name:    "no hosted zone annotated",
+			members: []*networking.Ingress{previewIng("pr-1", "")},
+			withLB:  true,
+			want:    nil,
+		},
+		{
+			name:            "records grouped by hosted zone, with hosted zones of inactive members",
+			members:         []*networking.Ingress{previewIng("pr-2", "Z2"), previewIng("pr-1", "Z1"), previewIng("pr-3", "Z1")},
+			inactiveMembers: []*networking.Ingress{previewIng("pr-4", "Z1"), previewIng("pr-5", "Z3")},
+			withLB:          true,
+			want: []route53model.RecordSetGroupSpec{
+				{HostedZoneID: "Z1", RecordNames: []string{"pr-1.preview.example.com", "pr-3.preview.example.com"}, StaleRecordNames: []string{"pr-4.preview.example.com"}},
+				{HostedZoneID: "Z2", RecordNames: []string{"pr-2.preview.example.com"}, StaleRecordNames: []string{}},
+				{HostedZoneID: "Z3", RecordNames: []string{}, StaleRecordNames: []string{"pr-5.preview.example.com"}},
+			},
+		},
+		{
+			name:            "no members left",
+			inactiveMembers: []*networking.Ingress{previewIng("pr-1", "Z1")},
+			withLB:          false,
+			want: []route53model.RecordSetGroupSpec{
+				{HostedZoneID: "Z1", RecordNames: []string{}, StaleRecordNames: []string{"pr-1.preview.example.com"}},
+			},
+		},
+		{
+			name: "recorded records of members",
+			members: []*networking.Ingress{
+				recordedPreviewIng("pr-1", "Z1", "Z1/pr-1.preview.example.com"),
+				recordedPreviewIng("pr-2", "Z2", "Z1/pr-2.preview.example.com"),
+				recordedPreviewIng("pr-3", "", "Z1/pr-3.preview.example.com"),
+			},
+			inactiveMembers: []*networking.Ingress{recordedPreviewIng("pr-4", "", "Z3/pr-4.preview.example.com")},
+			withLB:          true,
+			want: []route53model.RecordSetGroupSpec{
+				{HostedZoneID: "Z1", RecordNames: []string{"pr-1.preview.example.com"}, StaleRecordNames: []string{"pr-2.preview.example.com", "pr-3.preview.example.com"}},
+				{HostedZoneID: "Z2", RecordNames: []string{"pr-2.preview.example.com"}, StaleRecordNames: []string{}},
+				{HostedZoneID: "Z3", RecordNames: []string{}, StaleRecordNames: []string{"pr-4.preview.example.com"}},
+			},
+		},
+		{
+			name: "hosted zone annotated without host template",
+			members: []*networking.Ingress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "pr-1",
+						Name:      "ing",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/route53-hosted-zone-id": "Z1",
+						},
+					},
+				},
+			},
+			withLB:  true,
+			wantErr: errors.New("ingress: pr-1/ing: route53-hosted-zone-id annotation requires host-template annotation"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			var lb *elbv2model.LoadBalancer
+			if tt.withLB {
+				lb = elbv2model.NewLoadBalancer(stack, "LoadBalancer", elbv2model.LoadBalancerSpec{})
+			}
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup: Group{
+					InactiveMembers: tt.inactiveMembers,
+				},
+				stack: stack,
+			}
+			for _, member := range tt.members {
+				task.ingGroup.Members = append(task.ingGroup.Members, ClassifiedIngress{Ing: member})
+			}
+			err := task.buildRoute53RecordSetGroups(context.Background(), lb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resGroups []*route53model.RecordSetGroup
+			assert.NoError(t, stack.ListResources(&resGroups))
+			var got []route53model.RecordSetGroupSpec
+			for _, resGroup := range resGroups {
+				spec := resGroup.Spec
+				if len(spec.RecordNames) != 0 {
+					assert.Equal(t, []core.Resource{lb}, spec.AliasTarget.DNSName.Dependencies())
+					assert.Equal(t, []core.Resource{lb}, spec.AliasTarget.HostedZoneID.Dependencies())
+				} else {
+					assert.Nil(t, spec.AliasTarget)
+				}
+				spec.AliasTarget = nil
+				got = append(got, spec)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
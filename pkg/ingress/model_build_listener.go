@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
@@ -106,7 +107,10 @@ type listenPortConfig struct {
 
 func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context.Context, ing *networking.Ingress) (map[int64]listenPortConfig, error) {
 	explicitTLSCertARNs := t.computeIngressExplicitTLSCertARNs(ctx, ing)
-	explicitSSLPolicy := t.computeIngressExplicitSSLPolicy(ctx, ing)
+	explicitSSLPolicy, explicitSSLPolicyByPort, err := t.computeIngressExplicitSSLPolicy(ctx, ing)
+	if err != nil {
+		return nil, err
+	}
 	inboundCIDRv4s, inboundCIDRV6s, err := t.computeIngressExplicitInboundCIDRs(ctx, ing)
 	if err != nil {
 		return nil, err
@@ -117,6 +121,13 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 		return nil, err
 	}
 
+	for port := range explicitSSLPolicyByPort {
+		if listenPorts[port] != elbv2model.ProtocolHTTPS {
+			return nil, errors.Errorf("invalid %v settings on Ingress: %v, port %v isn't an HTTPS listen port",
+				annotations.IngressSuffixSSLPolicy, k8s.NamespacedName(ing), port)
+		}
+	}
+
 	containsHTTPSPort := false
 	for _, protocol := range listenPorts {
 		if protocol == elbv2model.ProtocolHTTPS {
@@ -145,7 +156,11 @@ func (t *defaultModelBuildTask) computeIngressListenPortConfigByPort(ctx context
 			} else {
 				cfg.tlsCerts = explicitTLSCertARNs
 			}
-			cfg.sslPolicy = explicitSSLPolicy
+			if sslPolicy, ok := explicitSSLPolicyByPort[port]; ok {
+				cfg.sslPolicy = awssdk.String(sslPolicy)
+			} else {
+				cfg.sslPolicy = explicitSSLPolicy
+			}
 		}
 		listenPortConfigByPort[port] = cfg
 	}
@@ -228,10 +243,34 @@ func (t *defaultModelBuildTask) computeIngressExplicitInboundCIDRs(_ context.Con
 	return inboundCIDRv4s, inboundCIDRv6s, nil
 }
 
-func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Context, ing *networking.Ingress) *string {
+// computeIngressExplicitSSLPolicy computes the explicit SSL policy of HTTPS listen ports.
+// the ssl-policy annotation is either a single policy for all HTTPS listen ports, or a map from listen port to policy,
+// e.g. "443=ELBSecurityPolicy-TLS13-1-2-2021-06,8443=ELBSecurityPolicy-2016-08".
+func (t *defaultModelBuildTask) computeIngressExplicitSSLPolicy(_ context.Context, ing *networking.Ingress) (*string, map[int64]string, error) {
 	var rawSSLPolicy string
 	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSSLPolicy, &rawSSLPolicy, ing.Annotations); !exists {
-		return nil
+		return nil, nil, nil
+	}
+	if !strings.Contains(rawSSLPolicy, "=") {
+		return &rawSSLPolicy, nil, nil
+	}
+
+	var rawSSLPolicyByPort map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixSSLPolicy, &rawSSLPolicyByPort, ing.Annotations); err != nil {
+		return nil, nil, err
+	}
+	sslPolicyByPort := make(map[int64]string, len(rawSSLPolicyByPort))
+	for rawPort, sslPolicy := range rawSSLPolicyByPort {
+		port, err := strconv.ParseInt(rawPort, 10, 64)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid %v settings on Ingress: %v, failed to parse port: %v",
+				annotations.IngressSuffixSSLPolicy, k8s.NamespacedName(ing), rawPort)
+		}
+		if len(sslPolicy) == 0 {
+			return nil, nil, errors.Errorf("invalid %v settings on Ingress: %v, empty policy for port: %v",
+				annotations.IngressSuffixSSLPolicy, k8s.NamespacedName(ing), rawPort)
+		}
+		sslPolicyByPort[port] = sslPolicy
 	}
-	return &rawSSLPolicy
+	return nil, sslPolicyByPort, nil
 }
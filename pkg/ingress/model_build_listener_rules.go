@@ -2,17 +2,34 @@ package ingress
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
+const (
+	minPathOrder = 1
+	maxPathOrder = 1000
+
+	// the path pattern of ALB rule conditions can only have up to 128 characters.
+	maxPathPatternLength = 128
+)
+
+// pathPatternPattern matches characters supported by path pattern of ALB rule conditions, where "*" and "?" are wildcards.
+var pathPatternPattern = regexp.MustCompile(`^[A-Za-z0-9_\-.$/~"'@:+&*?]+$`)
+
 func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN core.StringToken, port int64, protocol elbv2model.Protocol, ingList []ClassifiedIngress) error {
 	if t.sslRedirectConfig != nil && protocol == elbv2model.ProtocolHTTP {
 		return nil
@@ -20,6 +37,11 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 
 	var rules []Rule
 	for _, ing := range ingList {
+		pathOrders, err := t.buildIngressPathOrders(ctx, ing.Ing)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+		}
+		var ingRules []ruleWithOrder
 		for _, rule := range ing.Ing.Spec.Rules {
 			if rule.HTTP == nil {
 				continue
@@ -43,13 +65,27 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 				if err != nil {
 					return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
 				}
-				rules = append(rules, Rule{
-					Conditions: conditions,
-					Actions:    actions,
-					Tags:       tags,
+				ingRules = append(ingRules, ruleWithOrder{
+					rule: Rule{
+						Conditions: conditions,
+						Actions:    actions,
+						Tags:       tags,
+					},
+					order: pathOrders[path.Path],
 				})
 			}
 		}
+		// rules are stable sorted so that paths of same order keep the order they're declared in Ingress.
+		sort.SliceStable(ingRules, func(i, j int) bool {
+			return ingRules[i].order < ingRules[j].order
+		})
+		ingRules, err = promoteHTTPRequestMethodRules(ingRules)
+		if err != nil {
+			return errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(ing.Ing))
+		}
+		for _, ingRule := range ingRules {
+			rules = append(rules, ingRule.rule)
+		}
 	}
 	optimizedRules, err := t.ruleOptimizer.Optimize(ctx, port, protocol, rules)
 	if err != nil {
@@ -72,6 +108,107 @@ func (t *defaultModelBuildTask) buildListenerRules(ctx context.Context, lsARN co
 	return nil
 }
 
+// ruleWithOrder is a Rule with the order of its path within Ingress.
+type ruleWithOrder struct {
+	rule  Rule
+	order int64
+}
+
+// promoteHTTPRequestMethodRules moves rules with http-request-method condition ahead of the rules of same order that only differ by
+// the http-request-method condition, so that the same host/path can be routed to different backends per HTTP request method,
+// e.g. GET to one service and POST/PUT to another, regardless of the order these paths are declared in Ingress.
+// otherwise, the rule without http-request-method condition would take all requests.
+func promoteHTTPRequestMethodRules(ingRules []ruleWithOrder) ([]ruleWithOrder, error) {
+	type ruleGroup struct {
+		methodRules []ruleWithOrder
+		otherRules  []ruleWithOrder
+	}
+	groupKeys := make([]string, 0, len(ingRules))
+	groupByKey := make(map[string]*ruleGroup)
+	for _, ingRule := range ingRules {
+		var nonMethodConditions []elbv2model.RuleCondition
+		hasMethodCondition := false
+		for _, condition := range ingRule.rule.Conditions {
+			if condition.Field == elbv2model.RuleConditionFieldHTTPRequestMethod {
+				hasMethodCondition = true
+				continue
+			}
+			nonMethodConditions = append(nonMethodConditions, condition)
+		}
+		rawNonMethodConditions, err := json.Marshal(nonMethodConditions)
+		if err != nil {
+			return nil, err
+		}
+		groupKey := fmt.Sprintf("%v/%s", ingRule.order, rawNonMethodConditions)
+		group, exists := groupByKey[groupKey]
+		if !exists {
+			group = &ruleGroup{}
+			groupByKey[groupKey] = group
+		}
+		if hasMethodCondition {
+			group.methodRules = append(group.methodRules, ingRule)
+		} else {
+			group.otherRules = append(group.otherRules, ingRule)
+		}
+		groupKeys = append(groupKeys, groupKey)
+	}
+
+	// only rules that need promotion are regrouped at the position of the group's first rule, other rules keep their position.
+	promotedRules := make([]ruleWithOrder, 0, len(ingRules))
+	emittedGroupKeys := sets.NewString()
+	for i, ingRule := range ingRules {
+		groupKey := groupKeys[i]
+		group := groupByKey[groupKey]
+		if len(group.methodRules) == 0 || len(group.otherRules) == 0 {
+			promotedRules = append(promotedRules, ingRule)
+			continue
+		}
+		if emittedGroupKeys.Has(groupKey) {
+			continue
+		}
+		emittedGroupKeys.Insert(groupKey)
+		promotedRules = append(promotedRules, group.methodRules...)
+		promotedRules = append(promotedRules, group.otherRules...)
+	}
+	return promotedRules, nil
+}
+
+// buildIngressPathOrders builds the explicit order of paths within Ingress via "path-order" annotation.
+// paths without explicit order get order value as 0, thus evaluated before paths with explicit order.
+func (t *defaultModelBuildTask) buildIngressPathOrders(_ context.Context, ing *networking.Ingress) (map[string]int64, error) {
+	var rawPathOrders map[string]string
+	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixPathOrder, &rawPathOrders, ing.Annotations); err != nil {
+		return nil, err
+	}
+	if len(rawPathOrders) == 0 {
+		return nil, nil
+	}
+	ingPaths := sets.NewString()
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			ingPaths.Insert(path.Path)
+		}
+	}
+	pathOrders := make(map[string]int64, len(rawPathOrders))
+	for path, rawOrder := range rawPathOrders {
+		if !ingPaths.Has(path) {
+			return nil, errors.Errorf("path-order references unknown path: %v", path)
+		}
+		order, err := strconv.ParseInt(rawOrder, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse order of path: %v", path)
+		}
+		if order < minPathOrder || order > maxPathOrder {
+			return nil, errors.Errorf("explicit path order must be within [%v:%v], path: %v, order: %v", minPathOrder, maxPathOrder, path, order)
+		}
+		pathOrders[path] = order
+	}
+	return pathOrders, nil
+}
+
 func (t *defaultModelBuildTask) buildRuleConditions(ctx context.Context, rule networking.IngressRule,
 	path networking.HTTPIngressPath, backend EnhancedBackend) ([]elbv2model.RuleCondition, error) {
 	var hosts []string
@@ -143,16 +280,40 @@ func (t *defaultModelBuildTask) buildPathPatterns(path string, pathType *network
 	if pathType != nil {
 		normalizedPathType = *pathType
 	}
+	var pathPatterns []string
+	var err error
 	switch normalizedPathType {
 	case networking.PathTypeImplementationSpecific:
-		return t.buildPathPatternsForImplementationSpecificPathType(path)
+		pathPatterns, err = t.buildPathPatternsForImplementationSpecificPathType(path)
 	case networking.PathTypeExact:
-		return t.buildPathPatternsForExactPathType(path)
+		pathPatterns, err = t.buildPathPatternsForExactPathType(path)
 	case networking.PathTypePrefix:
-		return t.buildPathPatternsForPrefixPathType(path)
+		pathPatterns, err = t.buildPathPatternsForPrefixPathType(path)
 	default:
 		return nil, errors.Errorf("unsupported pathType: %v", normalizedPathType)
 	}
+	if err != nil {
+		return nil, err
+	}
+	for _, pathPattern := range pathPatterns {
+		if err := validatePathPattern(pathPattern); err != nil {
+			return nil, err
+		}
+	}
+	return pathPatterns, nil
+}
+
+// validatePathPattern validates the path pattern generated from Ingress path is acceptable by ALB.
+// ALB only supports "*" and "?" as wildcards, thus paths with regular expressions like "/api/(v1|v2)" are rejected
+// instead of being matched literally.
+func validatePathPattern(pathPattern string) error {
+	if len(pathPattern) > maxPathPatternLength {
+		return errors.Errorf("path pattern shouldn't exceed %v characters: %v", maxPathPatternLength, pathPattern)
+	}
+	if !pathPatternPattern.MatchString(pathPattern) {
+		return errors.Errorf("path pattern contains unsupported characters, only A-Z, a-z, 0-9, _-.$/~\"'@:+& and wildcards *? are allowed: %v", pathPattern)
+	}
+	return nil
 }
 
 // buildPathPatternsForImplementationSpecificPathType will build path patterns for implementationSpecific pathType.
@@ -1,9 +1,14 @@
 package ingress
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"strings"
 	"testing"
 )
 
@@ -86,6 +91,30 @@ func Test_defaultModelBuildTask_buildPathPatterns(t *testing.T) {
 			},
 			want: []string{"/abc", "/abc/*"},
 		},
+		{
+			name: "regular expression with implementationSpecific pathType",
+			args: args{
+				path:     "/api/(v1|v2)/.*",
+				pathType: &pathTypeImplementationSpecific,
+			},
+			wantErr: errors.New("path pattern contains unsupported characters, only A-Z, a-z, 0-9, _-.$/~\"'@:+& and wildcards *? are allowed: /api/(v1|v2)/.*"),
+		},
+		{
+			name: "path with 127 characters with exact pathType",
+			args: args{
+				path:     "/" + strings.Repeat("a", 126),
+				pathType: &pathTypeExact,
+			},
+			want: []string{"/" + strings.Repeat("a", 126)},
+		},
+		{
+			name: "path with 127 characters with prefix pathType",
+			args: args{
+				path:     "/" + strings.Repeat("a", 126),
+				pathType: &pathTypePrefix,
+			},
+			wantErr: errors.New("path pattern shouldn't exceed 128 characters: /" + strings.Repeat("a", 126) + "/*"),
+		},
 		{
 			name: "/abc/ with unknown pathType",
 			args: args{
@@ -330,3 +359,143 @@ func Test_defaultModelBuildTask_buildPathPatternsForPrefixPathType(t *testing.T)
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildIngressPathOrders(t *testing.T) {
+	ingSpec := networking.IngressSpec{
+		Rules: []networking.IngressRule{
+			{
+				Host: "app.example.com",
+				IngressRuleValue: networking.IngressRuleValue{
+					HTTP: &networking.HTTPIngressRuleValue{
+						Paths: []networking.HTTPIngressPath{
+							{Path: "/"},
+							{Path: "/api"},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]int64
+		wantErr     error
+	}{
+		{
+			name:        "without annotation configured",
+			annotations: nil,
+			want:        nil,
+		},
+		{
+			name: "with path order",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/path-order": "/=1000,/api=10",
+			},
+			want: map[string]int64{
+				"/":    1000,
+				"/api": 10,
+			},
+		},
+		{
+			name: "with unknown path",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/path-order": "/admin=10",
+			},
+			wantErr: errors.New("path-order references unknown path: /admin"),
+		},
+		{
+			name: "with order out of range",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/path-order": "/=1001",
+			},
+			wantErr: errors.New("explicit path order must be within [1:1000], path: /, order: 1001"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "awesome-ns",
+					Name:        "ing-1",
+					Annotations: tt.annotations,
+				},
+				Spec: ingSpec,
+			}
+			got, err := task.buildIngressPathOrders(context.Background(), ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_promoteHTTPRequestMethodRules(t *testing.T) {
+	pathCondition := func(path string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field:             elbv2model.RuleConditionFieldPathPattern,
+			PathPatternConfig: &elbv2model.PathPatternConditionConfig{Values: []string{path}},
+		}
+	}
+	methodCondition := func(methods ...string) elbv2model.RuleCondition {
+		return elbv2model.RuleCondition{
+			Field:                   elbv2model.RuleConditionFieldHTTPRequestMethod,
+			HTTPRequestMethodConfig: &elbv2model.HTTPRequestMethodConditionConfig{Values: methods},
+		}
+	}
+	buildRule := func(tag string, order int64, conditions ...elbv2model.RuleCondition) ruleWithOrder {
+		return ruleWithOrder{
+			rule:  Rule{Conditions: conditions, Tags: map[string]string{"rule": tag}},
+			order: order,
+		}
+	}
+	ruleAPIDefault := buildRule("api-default", 0, pathCondition("/api"))
+	ruleAPIWrite := buildRule("api-write", 0, pathCondition("/api"), methodCondition("POST", "PUT"))
+	ruleAPIRead := buildRule("api-read", 0, pathCondition("/api"), methodCondition("GET"))
+	ruleStatic := buildRule("static", 0, pathCondition("/static"))
+	ruleAPIDefaultOrdered := buildRule("api-default-ordered", 1, pathCondition("/api"))
+	tests := []struct {
+		name     string
+		ingRules []ruleWithOrder
+		want     []ruleWithOrder
+	}{
+		{
+			name:     "no http-request-method conditions",
+			ingRules: []ruleWithOrder{ruleStatic, ruleAPIDefault},
+			want:     []ruleWithOrder{ruleStatic, ruleAPIDefault},
+		},
+		{
+			name:     "rules with http-request-method conditions are already ahead",
+			ingRules: []ruleWithOrder{ruleAPIRead, ruleAPIWrite, ruleAPIDefault},
+			want:     []ruleWithOrder{ruleAPIRead, ruleAPIWrite, ruleAPIDefault},
+		},
+		{
+			name:     "rules with http-request-method conditions are promoted",
+			ingRules: []ruleWithOrder{ruleAPIDefault, ruleStatic, ruleAPIWrite, ruleAPIRead},
+			want:     []ruleWithOrder{ruleAPIWrite, ruleAPIRead, ruleAPIDefault, ruleStatic},
+		},
+		{
+			name:     "rules of different order aren't regrouped",
+			ingRules: []ruleWithOrder{ruleAPIRead, ruleAPIDefaultOrdered},
+			want:     []ruleWithOrder{ruleAPIRead, ruleAPIDefaultOrdered},
+		},
+		{
+			name:     "no rules",
+			ingRules: nil,
+			want:     []ruleWithOrder{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promoteHTTPRequestMethodRules(tt.ingRules)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+)
+
+func Test_defaultModelBuildTask_computeIngressListenPortConfigByPort(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[int64]listenPortConfig
+		wantErr     error
+	}{
+		{
+			name: "single ssl-policy applies to all HTTPS listen ports",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTP": 80}, {"HTTPS": 443}, {"HTTPS": 8443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/cert-1",
+				"alb.ingress.kubernetes.io/ssl-policy":      "ELBSecurityPolicy-TLS13-1-2-2021-06",
+			},
+			want: map[int64]listenPortConfig{
+				80: {
+					protocol: elbv2model.ProtocolHTTP,
+				},
+				443: {
+					protocol:  elbv2model.ProtocolHTTPS,
+					sslPolicy: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					tlsCerts:  []string{"arn:aws:acm:us-west-2:123456789012:certificate/cert-1"},
+				},
+				8443: {
+					protocol:  elbv2model.ProtocolHTTPS,
+					sslPolicy: awssdk.String("ELBSecurityPolicy-TLS13-1-2-2021-06"),
+					tlsCerts:  []string{"arn:aws:acm:us-west-2:123456789012:certificate/cert-1"},
+				},
+			},
+		},
+		{
+			name: "ssl-policy by listen port",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTPS": 443}, {"HTTPS": 8443}, {"HTTPS": 9443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/cert-1",
+				"alb.ingress.kubernetes.io/ssl-policy":      "443=ELBSecurityPolicy-TLS13-1-3-2021-06, 8443=ELBSecurityPolicy-2016-08",
+			},
+			want: map[int64]listenPortConfig{
+				443: {
+					protocol:  elbv2model.ProtocolHTTPS,
+					sslPolicy: awssdk.String("ELBSecurityPolicy-TLS13-1-3-2021-06"),
+					tlsCerts:  []string{"arn:aws:acm:us-west-2:123456789012:certificate/cert-1"},
+				},
+				8443: {
+					protocol:  elbv2model.ProtocolHTTPS,
+					sslPolicy: awssdk.String("ELBSecurityPolicy-2016-08"),
+					tlsCerts:  []string{"arn:aws:acm:us-west-2:123456789012:certificate/cert-1"},
+				},
+				9443: {
+					protocol: elbv2model.ProtocolHTTPS,
+					tlsCerts: []string{"arn:aws:acm:us-west-2:123456789012:certificate/cert-1"},
+				},
+			},
+		},
+		{
+			name: "ssl-policy by listen port for non-HTTPS port",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/listen-ports":    `[{"HTTP": 80}, {"HTTPS": 443}]`,
+				"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/cert-1",
+				"alb.ingress.kubernetes.io/ssl-policy":      "80=ELBSecurityPolicy-2016-08",
+			},
+			wantErr: errors.New("invalid ssl-policy settings on Ingress: ns-1/ing-1, port 80 isn't an HTTPS listen port"),
+		},
+		{
+			name: "ssl-policy by listen port with invalid port",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/certificate-arn": "arn:aws:acm:us-west-2:123456789012:certificate/cert-1",
+				"alb.ingress.kubernetes.io/ssl-policy":      "https=ELBSecurityPolicy-2016-08",
+			},
+			wantErr: errors.New("invalid ssl-policy settings on Ingress: ns-1/ing-1, failed to parse port: https: strconv.ParseInt: parsing \"https\": invalid syntax"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			ing := &networking.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Name:        "ing-1",
+					Annotations: tt.annotations,
+				},
+			}
+			got, err := task.computeIngressListenPortConfigByPort(context.Background(), ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -27,18 +26,82 @@ import (
 const (
 	resourceIDLoadBalancer         = "LoadBalancer"
 	minimalAvailableIPAddressCount = int64(8)
+
+	// AnnotationKeyLoadBalancerARN is the annotation on Ingress recording the existing LoadBalancer its IngressGroup is bound to via load-balancer-arn annotation,
+	// so that listeners created on it can be deleted after load-balancer-arn annotation changes or is removed.
+	AnnotationKeyLoadBalancerARN = "elbv2.k8s.aws/load-balancer-arn"
 )
 
 func (t *defaultModelBuildTask) buildLoadBalancer(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) (*elbv2model.LoadBalancer, error) {
-	lbSpec, err := t.buildLoadBalancerSpec(ctx, listenPortConfigByPort)
+	existingLBARN, err := t.buildExistingLoadBalancerARN(ctx)
 	if err != nil {
 		return nil, err
 	}
+	var lbSpec elbv2model.LoadBalancerSpec
+	if len(existingLBARN) != 0 {
+		lbSpec = buildExistingLoadBalancerSpec(existingLBARN)
+	} else {
+		lbSpec, err = t.buildLoadBalancerSpec(ctx, listenPortConfigByPort)
+		if err != nil {
+			return nil, err
+		}
+	}
 	lb := elbv2model.NewLoadBalancer(t.stack, resourceIDLoadBalancer, lbSpec)
 	t.loadBalancer = lb
+	t.buildInactiveExistingLoadBalancers(ctx, existingLBARN)
 	return lb, nil
 }
 
+// buildExistingLoadBalancerARN returns the ARN of existing LoadBalancer that IngressGroup is bound to, or empty if there is none.
+func (t *defaultModelBuildTask) buildExistingLoadBalancerARN(_ context.Context) (string, error) {
+	explicitLBARNs := sets.String{}
+	for _, member := range t.ingGroup.Members {
+		rawLBARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancerARN, &rawLBARN, member.Ing.Annotations); !exists {
+			continue
+		}
+		explicitLBARNs.Insert(rawLBARN)
+	}
+	if len(explicitLBARNs) > 1 {
+		return "", errors.Errorf("conflicting load balancer arn: %v", explicitLBARNs.List())
+	}
+	lbARN, _ := explicitLBARNs.PopAny()
+	return lbARN, nil
+}
+
+// buildInactiveExistingLoadBalancers builds existing LoadBalancers that IngressGroup is no longer bound to,
+// so that listeners created on them for IngressGroup can be cleaned up. These are the ones recorded on members by AnnotationKeyLoadBalancerARN
+// other than activeLBARN, as well as the ones of inactive members.
+func (t *defaultModelBuildTask) buildInactiveExistingLoadBalancers(_ context.Context, activeLBARN string) {
+	inactiveLBARNs := sets.String{}
+	addInactiveLBARN := func(lbARN string) {
+		if lbARN != "" && lbARN != activeLBARN {
+			inactiveLBARNs.Insert(lbARN)
+		}
+	}
+	for _, member := range t.ingGroup.Members {
+		addInactiveLBARN(member.Ing.Annotations[AnnotationKeyLoadBalancerARN])
+	}
+	for _, inactiveMember := range t.ingGroup.InactiveMembers {
+		addInactiveLBARN(inactiveMember.Annotations[AnnotationKeyLoadBalancerARN])
+		// the annotations of inactive members are used as well, in case their LoadBalancers haven't been recorded yet.
+		rawLBARN := ""
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancerARN, &rawLBARN, inactiveMember.Annotations); exists {
+			addInactiveLBARN(rawLBARN)
+		}
+	}
+	for _, lbARN := range inactiveLBARNs.List() {
+		_ = elbv2model.NewLoadBalancer(t.stack, lbARN, buildExistingLoadBalancerSpec(lbARN))
+	}
+}
+
+func buildExistingLoadBalancerSpec(lbARN string) elbv2model.LoadBalancerSpec {
+	return elbv2model.LoadBalancerSpec{
+		ExistingLoadBalancerARN: awssdk.String(lbARN),
+		Type:                    elbv2model.LoadBalancerTypeApplication,
+	}
+}
+
 func (t *defaultModelBuildTask) buildLoadBalancerSpec(ctx context.Context, listenPortConfigByPort map[int64]listenPortConfig) (elbv2model.LoadBalancerSpec, error) {
 	scheme, err := t.buildLoadBalancerScheme(ctx)
 	if err != nil {
@@ -115,12 +178,12 @@ func (t *defaultModelBuildTask) buildLoadBalancerName(_ context.Context, scheme
 
 	if t.ingGroup.ID.IsExplicit() {
 		payload := invalidLoadBalancerNamePattern.ReplaceAllString(t.ingGroup.ID.Name, "")
-		return fmt.Sprintf("k8s-%.17s-%.10s", payload, uuid), nil
+		return buildResourceName(t.resourceNameTemplate, uuid, "", payload), nil
 	}
 
 	sanitizedNamespace := invalidLoadBalancerNamePattern.ReplaceAllString(t.ingGroup.ID.Namespace, "")
 	sanitizedName := invalidLoadBalancerNamePattern.ReplaceAllString(t.ingGroup.ID.Name, "")
-	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid), nil
+	return buildResourceName(t.resourceNameTemplate, uuid, sanitizedNamespace, sanitizedName), nil
 }
 
 func (t *defaultModelBuildTask) buildLoadBalancerScheme(_ context.Context) (elbv2model.LoadBalancerScheme, error) {
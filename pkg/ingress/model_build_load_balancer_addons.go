@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	cloudwatchmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/cloudwatch"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	shieldmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/shield"
 	wafregionalmodel "sigs.k8s.io/aws-load-balancer-controller/pkg/model/wafregional"
@@ -12,6 +13,11 @@ import (
 )
 
 func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbARN core.StringToken) error {
+	// addons of existing LoadBalancer are managed outside of the controller as well.
+	if t.loadBalancer.Spec.ExistingLoadBalancerARN != nil {
+		_, err := t.buildCloudWatchDashboard(ctx, lbARN)
+		return err
+	}
 	if _, err := t.buildWAFv2WebACLAssociation(ctx, lbARN); err != nil {
 		return err
 	}
@@ -21,6 +27,9 @@ func (t *defaultModelBuildTask) buildLoadBalancerAddOns(ctx context.Context, lbA
 	if _, err := t.buildShieldProtection(ctx, lbARN); err != nil {
 		return err
 	}
+	if _, err := t.buildCloudWatchDashboard(ctx, lbARN); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -102,3 +111,36 @@ func (t *defaultModelBuildTask) buildShieldProtection(_ context.Context, lbARN c
 	}
 	return nil, nil
 }
+
+func (t *defaultModelBuildTask) buildCloudWatchDashboard(_ context.Context, lbARN core.StringToken) (*cloudwatchmodel.Dashboard, error) {
+	explicitEnableDashboards := make(map[bool]struct{})
+	for _, member := range t.ingGroup.Members {
+		rawEnableDashboard := false
+		exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixCloudWatchDashboard, &rawEnableDashboard, member.Ing.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			explicitEnableDashboards[rawEnableDashboard] = struct{}{}
+		}
+	}
+	if len(explicitEnableDashboards) == 0 {
+		return nil, nil
+	}
+	if len(explicitEnableDashboards) > 1 {
+		return nil, errors.New("conflicting enable CloudWatch dashboard")
+	}
+	if _, enableDashboard := explicitEnableDashboards[true]; enableDashboard {
+		tgResIDs := sets.StringKeySet(t.tgByResID).List()
+		tgARNs := make([]core.StringToken, 0, len(tgResIDs))
+		for _, tgResID := range tgResIDs {
+			tgARNs = append(tgARNs, t.tgByResID[tgResID].TargetGroupARN())
+		}
+		dashboard := cloudwatchmodel.NewDashboard(t.stack, resourceIDLoadBalancer, cloudwatchmodel.DashboardSpec{
+			LoadBalancerARN: lbARN,
+			TargetGroupARNs: tgARNs,
+		})
+		return dashboard, nil
+	}
+	return nil, nil
+}
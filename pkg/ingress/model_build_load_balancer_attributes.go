@@ -1,9 +1,22 @@
 package ingress
 
 import (
+	"strconv"
+
 	"github.com/pkg/errors"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
+)
+
+const (
+	lbAttrsIdleTimeoutSeconds     = "idle_timeout.timeout_seconds"
+	lbAttrsClientKeepAliveSeconds = "client_keep_alive.seconds"
+
+	minIdleTimeoutSeconds     = 1
+	maxIdleTimeoutSeconds     = 4000
+	minClientKeepAliveSeconds = 60
+	maxClientKeepAliveSeconds = 604800
 )
 
 // buildIngressGroupLoadBalancerAttributes builds the LB attributes for a group of Ingresses.
@@ -28,7 +41,10 @@ func (t *defaultModelBuildTask) buildIngressGroupLoadBalancerAttributes(ingList
 		if err != nil {
 			return nil, err
 		}
-		return algorithm.MergeStringMap(ingClassAttributes, ingGroupAttributes), nil
+		ingGroupAttributes = algorithm.MergeStringMap(ingClassAttributes, ingGroupAttributes)
+	}
+	if err := validateLoadBalancerAttributes(ingGroupAttributes); err != nil {
+		return nil, err
 	}
 	return ingGroupAttributes, nil
 }
@@ -40,9 +56,41 @@ func (t *defaultModelBuildTask) buildIngressLoadBalancerAttributes(ing Classifie
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixLoadBalancerAttributes, &annotationAttributes, ing.Ing.Annotations); err != nil {
 		return nil, err
 	}
+	typedAttributes, err := t.buildIngressLoadBalancerTypedAttributes(ing)
+	if err != nil {
+		return nil, err
+	}
+	if len(typedAttributes) != 0 && annotationAttributes == nil {
+		annotationAttributes = make(map[string]string, len(typedAttributes))
+	}
+	for attrKey, attrValue := range typedAttributes {
+		if rawValue, exists := annotationAttributes[attrKey]; exists && rawValue != attrValue {
+			return nil, errors.Errorf("conflicting values for load balancer attribute %v: %v from typed annotation, %v from %v annotation",
+				attrKey, attrValue, rawValue, annotations.IngressSuffixLoadBalancerAttributes)
+		}
+		annotationAttributes[attrKey] = attrValue
+	}
 	return annotationAttributes, nil
 }
 
+// buildIngressLoadBalancerTypedAttributes builds the LB attributes from first-class annotations on a single Ingress.
+func (t *defaultModelBuildTask) buildIngressLoadBalancerTypedAttributes(ing ClassifiedIngress) (map[string]string, error) {
+	attributes := make(map[string]string)
+	var idleTimeoutSeconds int64
+	if exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixIdleTimeoutSeconds, &idleTimeoutSeconds, ing.Ing.Annotations); err != nil {
+		return nil, err
+	} else if exists {
+		attributes[lbAttrsIdleTimeoutSeconds] = strconv.FormatInt(idleTimeoutSeconds, 10)
+	}
+	var clientKeepAliveSeconds int64
+	if exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixClientKeepAliveSeconds, &clientKeepAliveSeconds, ing.Ing.Annotations); err != nil {
+		return nil, err
+	} else if exists {
+		attributes[lbAttrsClientKeepAliveSeconds] = strconv.FormatInt(clientKeepAliveSeconds, 10)
+	}
+	return attributes, nil
+}
+
 // buildIngressClassLoadBalancerAttributes builds the LB attributes for an IngressClass.
 func (t *defaultModelBuildTask) buildIngressClassLoadBalancerAttributes(ingClassConfig ClassConfiguration) (map[string]string, error) {
 	if ingClassConfig.IngClassParams == nil || len(ingClassConfig.IngClassParams.Spec.LoadBalancerAttributes) == 0 {
@@ -54,3 +102,39 @@ func (t *defaultModelBuildTask) buildIngressClassLoadBalancerAttributes(ingClass
 	}
 	return ingClassAttributes, nil
 }
+
+// validateLoadBalancerAttributes validates the LB attributes that ELBV2 would reject, regardless of whether they are specified via annotation or IngressClass.
+func validateLoadBalancerAttributes(attributes map[string]string) error {
+	if rawValue, exists := attributes[lbAttrsIdleTimeoutSeconds]; exists {
+		if err := validateInt64AttributeInRange(rawValue, minIdleTimeoutSeconds, maxIdleTimeoutSeconds); err != nil {
+			return errors.Wrapf(err, "invalid load balancer attribute %v", lbAttrsIdleTimeoutSeconds)
+		}
+	}
+	if rawValue, exists := attributes[lbAttrsClientKeepAliveSeconds]; exists {
+		if err := validateInt64AttributeInRange(rawValue, minClientKeepAliveSeconds, maxClientKeepAliveSeconds); err != nil {
+			return errors.Wrapf(err, "invalid load balancer attribute %v", lbAttrsClientKeepAliveSeconds)
+		}
+	}
+	if rawValue, exists := attributes[elbv2deploy.LBAttrsConnectionLogsS3Enabled]; exists {
+		// only the literal values are accepted, as they're compared as-is when configuring the logs bucket.
+		if rawValue != "true" && rawValue != "false" {
+			return errors.Errorf("invalid load balancer attribute %v: must be true or false, got %v", elbv2deploy.LBAttrsConnectionLogsS3Enabled, rawValue)
+		}
+		if rawValue == "true" && attributes[elbv2deploy.LBAttrsConnectionLogsS3Bucket] == "" {
+			return errors.Errorf("load balancer attribute %v must be specified when connection logs are enabled", elbv2deploy.LBAttrsConnectionLogsS3Bucket)
+		}
+	}
+	return nil
+}
+
+// validateInt64AttributeInRange validates the raw attribute value is an integer within [min, max].
+func validateInt64AttributeInRange(rawValue string, min int64, max int64) error {
+	value, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		return errors.Errorf("must be an integer, got %v", rawValue)
+	}
+	if value < min || value > max {
+		return errors.Errorf("must be within [%v, %v], got %v", min, max, value)
+	}
+	return nil
+}
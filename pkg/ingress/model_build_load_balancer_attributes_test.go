@@ -303,6 +303,40 @@ func Test_defaultModelBuildTask_buildIngressGroupLoadBalancerAttributes(t *testi
 				"deletion_protection.enabled":  "true",
 			},
 		},
+		{
+			name: "out of range attributes from IngressClass",
+			args: args{
+				ingList: []ClassifiedIngress{
+					{
+						Ing: &networking.Ingress{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: "awesome-ns",
+								Name:      "awesome-ing",
+								Annotations: map[string]string{
+									"alb.ingress.kubernetes.io/client-keep-alive-seconds": "3600",
+								},
+							},
+						},
+						IngClassConfig: ClassConfiguration{
+							IngClassParams: &elbv2api.IngressClassParams{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "awesome-class",
+								},
+								Spec: elbv2api.IngressClassParamsSpec{
+									LoadBalancerAttributes: []elbv2api.Attribute{
+										{
+											Key:   "idle_timeout.timeout_seconds",
+											Value: "4001",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid load balancer attribute idle_timeout.timeout_seconds: must be within [1, 4000], got 4001"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -351,6 +385,64 @@ func Test_defaultModelBuildTask_buildIngressLoadBalancerAttributes(t *testing.T)
 				"access_logs.s3.enabled":       "true",
 			},
 		},
+		{
+			name: "typed annotation attributes from Ingress",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "awesome-ing",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/load-balancer-attributes":  "idle_timeout.timeout_seconds=120, access_logs.s3.enabled=true",
+								"alb.ingress.kubernetes.io/idle-timeout-seconds":      "120",
+								"alb.ingress.kubernetes.io/client-keep-alive-seconds": "3600",
+							},
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"idle_timeout.timeout_seconds": "120",
+				"client_keep_alive.seconds":    "3600",
+				"access_logs.s3.enabled":       "true",
+			},
+		},
+		{
+			name: "typed annotation attributes conflict with load-balancer-attributes",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "awesome-ing",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/load-balancer-attributes": "idle_timeout.timeout_seconds=60",
+								"alb.ingress.kubernetes.io/idle-timeout-seconds":     "120",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("conflicting values for load balancer attribute idle_timeout.timeout_seconds: 120 from typed annotation, 60 from load-balancer-attributes annotation"),
+		},
+		{
+			name: "invalid typed annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "awesome-ing",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/client-keep-alive-seconds": "1h",
+							},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("failed to parse int64 annotation, alb.ingress.kubernetes.io/client-keep-alive-seconds: 1h: strconv.ParseInt: parsing \"1h\": invalid syntax"),
+		},
 		{
 			name: "empty attributes from Ingress",
 			args: args{
@@ -457,3 +549,100 @@ func Test_defaultModelBuildTask_buildIngressClassLoadBalancerAttributes(t *testi
 		})
 	}
 }
+
+func Test_validateLoadBalancerAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		wantErr    error
+	}{
+		{
+			name: "valid attributes",
+			attributes: map[string]string{
+				"idle_timeout.timeout_seconds": "4000",
+				"client_keep_alive.seconds":    "60",
+				"connection_logs.s3.enabled":   "true",
+				"connection_logs.s3.bucket":    "my-bucket",
+				"deletion_protection.enabled":  "true",
+			},
+		},
+		{
+			name:       "nil attributes",
+			attributes: nil,
+		},
+		{
+			name: "idle timeout isn't an integer",
+			attributes: map[string]string{
+				"idle_timeout.timeout_seconds": "1m",
+			},
+			wantErr: errors.New("invalid load balancer attribute idle_timeout.timeout_seconds: must be an integer, got 1m"),
+		},
+		{
+			name: "idle timeout below range",
+			attributes: map[string]string{
+				"idle_timeout.timeout_seconds": "0",
+			},
+			wantErr: errors.New("invalid load balancer attribute idle_timeout.timeout_seconds: must be within [1, 4000], got 0"),
+		},
+		{
+			name: "client keep alive below range",
+			attributes: map[string]string{
+				"client_keep_alive.seconds": "59",
+			},
+			wantErr: errors.New("invalid load balancer attribute client_keep_alive.seconds: must be within [60, 604800], got 59"),
+		},
+		{
+			name: "client keep alive above range",
+			attributes: map[string]string{
+				"client_keep_alive.seconds": "604801",
+			},
+			wantErr: errors.New("invalid load balancer attribute client_keep_alive.seconds: must be within [60, 604800], got 604801"),
+		},
+		{
+			name: "connection logs enabled isn't a boolean",
+			attributes: map[string]string{
+				"connection_logs.s3.enabled": "yes",
+			},
+			wantErr: errors.New("invalid load balancer attribute connection_logs.s3.enabled: must be true or false, got yes"),
+		},
+		{
+			name: "connection logs enabled isn't lowercase",
+			attributes: map[string]string{
+				"connection_logs.s3.enabled": "True",
+				"connection_logs.s3.bucket":  "awesome-bucket",
+			},
+			wantErr: errors.New("invalid load balancer attribute connection_logs.s3.enabled: must be true or false, got True"),
+		},
+		{
+			name: "connection logs enabled is numeric",
+			attributes: map[string]string{
+				"connection_logs.s3.enabled": "1",
+				"connection_logs.s3.bucket":  "awesome-bucket",
+			},
+			wantErr: errors.New("invalid load balancer attribute connection_logs.s3.enabled: must be true or false, got 1"),
+		},
+		{
+			name: "connection logs enabled without bucket",
+			attributes: map[string]string{
+				"connection_logs.s3.enabled": "true",
+			},
+			wantErr: errors.New("load balancer attribute connection_logs.s3.bucket must be specified when connection logs are enabled"),
+		},
+		{
+			name: "connection logs disabled without bucket",
+			attributes: map[string]string{
+				"connection_logs.s3.enabled": "false",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLoadBalancerAttributes(tt.attributes)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
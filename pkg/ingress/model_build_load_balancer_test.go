@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
 )
 
@@ -656,8 +657,9 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &defaultModelBuildTask{
-				ingGroup:         tt.fields.ingGroup,
-				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				ingGroup:             tt.fields.ingGroup,
+				annotationParser:     annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				resourceNameTemplate: "k8s-{namespace}-{name}-{hash}",
 			}
 			got, err := task.buildLoadBalancerName(context.Background(), tt.fields.scheme)
 			if err != nil {
@@ -668,3 +670,123 @@ func Test_defaultModelBuildTask_buildLoadBalancerName(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildLoadBalancer_existingLoadBalancer(t *testing.T) {
+	lbARN1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/shared-alb-1/50dc6c495c0c9188"
+	lbARN2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/shared-alb-2/50dc6c495c0c9188"
+	ingWithLBARN := func(name string, lbARN string) *networking.Ingress {
+		return &networking.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+				Annotations: map[string]string{
+					"alb.ingress.kubernetes.io/group.name":        "awesome-group",
+					"alb.ingress.kubernetes.io/load-balancer-arn": lbARN,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		ingGroup    Group
+		wantLBSpecs map[string]elbv2.LoadBalancerSpec
+		wantErr     error
+	}{
+		{
+			name: "existing load balancer",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{Ing: ingWithLBARN("ing-1", lbARN1)},
+					{Ing: ingWithLBARN("ing-2", lbARN1)},
+				},
+			},
+			wantLBSpecs: map[string]elbv2.LoadBalancerSpec{
+				"LoadBalancer": {
+					ExistingLoadBalancerARN: awssdk.String(lbARN1),
+					Type:                    elbv2.LoadBalancerTypeApplication,
+				},
+			},
+		},
+		{
+			name: "existing load balancer changed",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{Ing: ingWithLBARN("ing-1", lbARN2)},
+				},
+				InactiveMembers: []*networking.Ingress{
+					ingWithLBARN("ing-2", lbARN1),
+					ingWithLBARN("ing-3", lbARN2),
+				},
+			},
+			wantLBSpecs: map[string]elbv2.LoadBalancerSpec{
+				"LoadBalancer": {
+					ExistingLoadBalancerARN: awssdk.String(lbARN2),
+					Type:                    elbv2.LoadBalancerTypeApplication,
+				},
+				lbARN1: {
+					ExistingLoadBalancerARN: awssdk.String(lbARN1),
+					Type:                    elbv2.LoadBalancerTypeApplication,
+				},
+			},
+		},
+		{
+			name: "existing load balancer recorded on members changed",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{Ing: func() *networking.Ingress {
+						ing := ingWithLBARN("ing-1", lbARN2)
+						ing.Annotations["elbv2.k8s.aws/load-balancer-arn"] = lbARN1
+						return ing
+					}()},
+				},
+			},
+			wantLBSpecs: map[string]elbv2.LoadBalancerSpec{
+				"LoadBalancer": {
+					ExistingLoadBalancerARN: awssdk.String(lbARN2),
+					Type:                    elbv2.LoadBalancerTypeApplication,
+				},
+				lbARN1: {
+					ExistingLoadBalancerARN: awssdk.String(lbARN1),
+					Type:                    elbv2.LoadBalancerTypeApplication,
+				},
+			},
+		},
+		{
+			name: "conflicting existing load balancer",
+			ingGroup: Group{
+				ID: GroupID{Name: "awesome-group"},
+				Members: []ClassifiedIngress{
+					{Ing: ingWithLBARN("ing-1", lbARN1)},
+					{Ing: ingWithLBARN("ing-2", lbARN2)},
+				},
+			},
+			wantErr: errors.New("conflicting load balancer arn: [" + lbARN1 + " " + lbARN2 + "]"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID(tt.ingGroup.ID))
+			task := &defaultModelBuildTask{
+				ingGroup:         tt.ingGroup,
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				stack:            stack,
+			}
+			_, err := task.buildLoadBalancer(context.Background(), nil)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var resLBs []*elbv2.LoadBalancer
+			stack.ListResources(&resLBs)
+			gotLBSpecs := make(map[string]elbv2.LoadBalancerSpec, len(resLBs))
+			for _, resLB := range resLBs {
+				gotLBSpecs[resLB.ID()] = resLB.Spec
+			}
+			assert.Equal(t, tt.wantLBSpecs, gotLBSpecs)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package ingress
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// the name of ALB and TargetGroup can only have up to 32 characters.
+	maxResourceNameLength = 32
+	// the length of uuid within generated names.
+	resourceNameUUIDLength = 10
+
+	resourceNamePlaceholderNamespace = "{namespace}"
+	resourceNamePlaceholderName      = "{name}"
+	resourceNamePlaceholderHash      = "{hash}"
+)
+
+var resourceNamePlaceholderPattern = regexp.MustCompile(`\{[a-z]+\}`)
+
+// buildResourceName generates the name of ALB or TargetGroup from template like "k8s-{namespace}-{name}-{hash}".
+// an empty namespace or name is dropped from template together with a neighbouring hyphen, e.g. for ALBs of explicit IngressGroups.
+// namespace and name are truncated to evenly share the characters left by the rest of template, with any remainder given to the last one,
+// so names generated with the default template are the same as "k8s-%.8s-%.8s-%.10s" and "k8s-%.17s-%.10s".
+// the uuid is hashed from untruncated identities, thus long namespaces or names truncated into the same value still result in different names.
+func buildResourceName(template string, uuid string, namespace string, name string) string {
+	if len(uuid) > resourceNameUUIDLength {
+		uuid = uuid[:resourceNameUUIDLength]
+	}
+	values := map[string]string{
+		resourceNamePlaceholderNamespace: namespace,
+		resourceNamePlaceholderName:      name,
+		resourceNamePlaceholderHash:      uuid,
+	}
+	for _, placeholder := range []string{resourceNamePlaceholderNamespace, resourceNamePlaceholderName} {
+		if len(values[placeholder]) == 0 {
+			template = dropResourceNamePlaceholder(template, placeholder)
+		}
+	}
+
+	var truncatedPlaceholders []string
+	available := maxResourceNameLength - len(resourceNamePlaceholderPattern.ReplaceAllString(template, ""))
+	for _, placeholder := range resourceNamePlaceholderPattern.FindAllString(template, -1) {
+		if placeholder == resourceNamePlaceholderHash {
+			available -= len(uuid)
+			continue
+		}
+		truncatedPlaceholders = append(truncatedPlaceholders, placeholder)
+	}
+	for i, placeholder := range truncatedPlaceholders {
+		limit := available / len(truncatedPlaceholders)
+		if i == len(truncatedPlaceholders)-1 {
+			limit += available % len(truncatedPlaceholders)
+		}
+		if len(values[placeholder]) > limit {
+			values[placeholder] = values[placeholder][:limit]
+		}
+	}
+	return resourceNamePlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return values[placeholder]
+	})
+}
+
+// dropResourceNamePlaceholder removes placeholder from template together with a neighbouring hyphen.
+func dropResourceNamePlaceholder(template string, placeholder string) string {
+	for _, pattern := range []string{"-" + placeholder, placeholder + "-", placeholder} {
+		if strings.Contains(template, pattern) {
+			return strings.Replace(template, pattern, "", 1)
+		}
+	}
+	return template
+}
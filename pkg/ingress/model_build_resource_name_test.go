@@ -0,0 +1,107 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildResourceName(t *testing.T) {
+	uuid := "2c37289a00d0e9d4eb9b4b2e55a8f4a5c1cbcd3e0d1a5d6e4a7f5c5c6b1e0f0a"
+	type args struct {
+		template  string
+		namespace string
+		name      string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default template with namespace and name",
+			args: args{
+				template:  "k8s-{namespace}-{name}-{hash}",
+				namespace: "awesomenamespace",
+				name:      "awesomename",
+			},
+			want: "k8s-awesomen-awesomen-2c37289a00",
+		},
+		{
+			name: "default template with group name",
+			args: args{
+				template: "k8s-{namespace}-{name}-{hash}",
+				name:     "awesomegroupnamethatislong",
+			},
+			want: "k8s-awesomegroupnamet-2c37289a00",
+		},
+		{
+			name: "default template with short namespace and name",
+			args: args{
+				template:  "k8s-{namespace}-{name}-{hash}",
+				namespace: "ns1",
+				name:      "name1",
+			},
+			want: "k8s-ns1-name1-2c37289a00",
+		},
+		{
+			name: "longer prefix in template with namespace and name",
+			args: args{
+				template:  "prod-eks-{namespace}-{name}-{hash}",
+				namespace: "awesomenamespace",
+				name:      "awesomename",
+			},
+			want: "prod-eks-aweso-awesom-2c37289a00",
+		},
+		{
+			name: "longer prefix in template with group name",
+			args: args{
+				template: "prod-eks-{namespace}-{name}-{hash}",
+				name:     "awesomegroupnamethatislong",
+			},
+			want: "prod-eks-awesomegroup-2c37289a00",
+		},
+		{
+			name: "name before namespace",
+			args: args{
+				template:  "{name}-{namespace}-{hash}",
+				namespace: "awesomenamespace",
+				name:      "awesomename",
+			},
+			want: "awesomenam-awesomenam-2c37289a00",
+		},
+		{
+			name: "name before namespace with group name",
+			args: args{
+				template: "{name}-{namespace}-{hash}",
+				name:     "awesomegroupnamethatislong",
+			},
+			want: "awesomegroupnamethati-2c37289a00",
+		},
+		{
+			name: "hash before name without namespace",
+			args: args{
+				template:  "eks{hash}-{name}",
+				namespace: "awesomenamespace",
+				name:      "awesomenamethatislong",
+			},
+			want: "eks2c37289a00-awesomenamethatisl",
+		},
+		{
+			name: "hash only",
+			args: args{
+				template:  "k8s-{hash}",
+				namespace: "awesomenamespace",
+				name:      "awesomename",
+			},
+			want: "k8s-2c37289a00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildResourceName(tt.args.template, uuid, tt.args.namespace, tt.args.name)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), maxResourceNameLength)
+		})
+	}
+}
@@ -5,28 +5,83 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"net"
 	"regexp"
 	"strconv"
+	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/algorithm"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	healthCheckPortTrafficPort = "traffic-port"
+	healthCheckPortTrafficPort      = "traffic-port"
+	lambdaFunctionARNResourcePrefix = "function:"
+
+	// healthCheckSourceTargetGroup relies on the TargetGroup's health check to withdraw unhealthy targets.
+	healthCheckSourceTargetGroup = "target-group"
+	// healthCheckSourceReadiness relies on pod readiness to withdraw unhealthy targets,
+	// while the TargetGroup's health check is relaxed to only verify target reachability.
+	healthCheckSourceReadiness = "readiness"
+
+	// permissive matchers used by readiness health check source, which accepts any non-5xx response
+	// so that backends requiring authentication are still considered healthy by the load balancer.
+	permissiveHealthCheckMatcherHTTPCode = "200-499"
+	permissiveHealthCheckMatcherGRPCCode = "0-99"
+	// the ranges of status codes supported by ELBV2 health check matchers.
+	healthCheckMatcherHTTPCodeMin = 200
+	healthCheckMatcherHTTPCodeMax = 499
+	healthCheckMatcherGRPCCodeMin = 0
+	healthCheckMatcherGRPCCodeMax = 99
+	// permissiveHealthCheckUnhealthyThresholdCount is the maximum unhealthy threshold count allowed by ELBV2.
+	permissiveHealthCheckUnhealthyThresholdCount = 10
+
+	tgAttrsSlowStartDurationSeconds           = "slow_start.duration_seconds"
+	tgAttrsStickinessEnabled                  = "stickiness.enabled"
+	tgAttrsStickinessType                     = "stickiness.type"
+	tgAttrsStickinessLBCookieDurationSeconds  = "stickiness.lb_cookie.duration_seconds"
+	tgAttrsStickinessAppCookieDurationSeconds = "stickiness.app_cookie.duration_seconds"
+	tgAttrsStickinessAppCookieName            = "stickiness.app_cookie.cookie_name"
+
+	tgAttrsDNSFailoverMinimumHealthyTargetsCount                = "target_group_health.dns_failover.minimum_healthy_targets.count"
+	tgAttrsDNSFailoverMinimumHealthyTargetsPercentage           = "target_group_health.dns_failover.minimum_healthy_targets.percentage"
+	tgAttrsUnhealthyStateRoutingMinimumHealthyTargetsCount      = "target_group_health.unhealthy_state_routing.minimum_healthy_targets.count"
+	tgAttrsUnhealthyStateRoutingMinimumHealthyTargetsPercentage = "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage"
+
+	stickinessTypeLBCookie                         = "lb_cookie"
+	stickinessTypeAppCookie                        = "app_cookie"
+	loadBalancingAlgorithmRoundRobin               = "round_robin"
+	loadBalancingAlgorithmLeastOutstandingRequests = "least_outstanding_requests"
+	minimumHealthyTargetsOff                       = "off"
+
+	minSlowStartSeconds          = 30
+	maxSlowStartSeconds          = 900
+	minStickinessDurationSeconds = 1
+	maxStickinessDurationSeconds = 604800
+	minMinimumHealthyTargetsPct  = 1
+	maxMinimumHealthyTargetsPct  = 100
 )
 
 func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
 	ing ClassifiedIngress, svc *corev1.Service, port intstr.IntOrString) (*elbv2model.TargetGroup, error) {
-	tgResID := t.buildTargetGroupResourceID(k8s.NamespacedName(ing.Ing), k8s.NamespacedName(svc), port)
+	tgResID := buildTargetGroupResourceID(k8s.NamespacedName(ing.Ing), k8s.NamespacedName(svc), port)
 	if tg, exists := t.tgByResID[tgResID]; exists {
 		return tg, nil
 	}
@@ -42,19 +97,25 @@ func (t *defaultModelBuildTask) buildTargetGroup(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	externalTargets, err := t.buildTargetGroupBindingExternalTargets(ctx, ing, svc, tgSpec.TargetType)
+	if err != nil {
+		return nil, err
+	}
 	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
 	t.tgByResID[tgResID] = tg
-	_ = t.buildTargetGroupBinding(ctx, tg, svc, port, svcPort, nodeSelector)
+	t.tgbByResID[tgResID] = t.buildTargetGroupBinding(ctx, tg, svc, port, svcPort, nodeSelector, externalTargets)
 	return tg, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, svcPort corev1.ServicePort, nodeSelector *metav1.LabelSelector) *elbv2model.TargetGroupBindingResource {
-	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, svcPort, nodeSelector)
+func (t *defaultModelBuildTask) buildTargetGroupBinding(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, svcPort corev1.ServicePort,
+	nodeSelector *metav1.LabelSelector, externalTargets []elbv2api.ExternalTarget) *elbv2model.TargetGroupBindingResource {
+	tgbSpec := t.buildTargetGroupBindingSpec(ctx, tg, svc, port, svcPort, nodeSelector, externalTargets)
 	tgb := elbv2model.NewTargetGroupBindingResource(t.stack, tg.ID(), tgbSpec)
 	return tgb
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, svcPort corev1.ServicePort, nodeSelector *metav1.LabelSelector) elbv2model.TargetGroupBindingResourceSpec {
+func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context, tg *elbv2model.TargetGroup, svc *corev1.Service, port intstr.IntOrString, svcPort corev1.ServicePort,
+	nodeSelector *metav1.LabelSelector, externalTargets []elbv2api.ExternalTarget) elbv2model.TargetGroupBindingResourceSpec {
 	targetType := elbv2api.TargetType(tg.Spec.TargetType)
 	targetPort := svcPort.TargetPort
 	if targetType == elbv2api.TargetTypeInstance {
@@ -74,9 +135,10 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingSpec(ctx context.Context,
 					Name: svc.Name,
 					Port: port,
 				},
-				Networking:    tgbNetworking,
-				NodeSelector:  nodeSelector,
-				IPAddressType: (*elbv2api.TargetGroupIPAddressType)(tg.Spec.IPAddressType),
+				Networking:      tgbNetworking,
+				NodeSelector:    nodeSelector,
+				IPAddressType:   (*elbv2api.TargetGroupIPAddressType)(tg.Spec.IPAddressType),
+				ExternalTargets: externalTargets,
 			},
 		},
 	}
@@ -152,6 +214,14 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
 	}
+	buildNameForTargetType := func(targetType elbv2model.TargetType) string {
+		tgPort := t.buildTargetGroupPort(ctx, targetType, svcPort)
+		return t.buildTargetGroupName(ctx, k8s.NamespacedName(ing.Ing), svc, port, tgPort, targetType, tgProtocol, tgProtocolVersion)
+	}
+	targetType, err = t.buildTargetGroupTargetTypeForFargateBackend(ctx, ing, svc, targetType, buildNameForTargetType)
+	if err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
 	healthCheckConfig, err := t.buildTargetGroupHealthCheckConfig(ctx, svc, svcAndIngAnnotations, targetType, tgProtocol, tgProtocolVersion)
 	if err != nil {
 		return elbv2model.TargetGroupSpec{}, err
@@ -170,6 +240,9 @@ func (t *defaultModelBuildTask) buildTargetGroupSpec(ctx context.Context,
 	}
 	tgPort := t.buildTargetGroupPort(ctx, targetType, svcPort)
 	name := t.buildTargetGroupName(ctx, k8s.NamespacedName(ing.Ing), svc, port, tgPort, targetType, tgProtocol, tgProtocolVersion)
+	if err := t.buildTargetGroupHealthCheckConfigForRollout(ctx, svc, name, &healthCheckConfig); err != nil {
+		return elbv2model.TargetGroupSpec{}, err
+	}
 	return elbv2model.TargetGroupSpec{
 		Name:                  name,
 		TargetType:            targetType,
@@ -204,7 +277,7 @@ func (t *defaultModelBuildTask) buildTargetGroupName(_ context.Context,
 
 	sanitizedNamespace := invalidTargetGroupNamePattern.ReplaceAllString(svc.Namespace, "")
 	sanitizedName := invalidTargetGroupNamePattern.ReplaceAllString(svc.Name, "")
-	return fmt.Sprintf("k8s-%.8s-%.8s-%.10s", sanitizedNamespace, sanitizedName, uuid)
+	return buildResourceName(t.resourceNameTemplate, uuid, sanitizedNamespace, sanitizedName)
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupTargetType(_ context.Context, svcAndIngAnnotations map[string]string) (elbv2model.TargetType, error) {
@@ -215,11 +288,139 @@ func (t *defaultModelBuildTask) buildTargetGroupTargetType(_ context.Context, sv
 		return elbv2model.TargetTypeInstance, nil
 	case string(elbv2model.TargetTypeIP):
 		return elbv2model.TargetTypeIP, nil
+	case string(elbv2model.TargetTypeLambda):
+		return "", errors.New("lambda targetType is only supported via lambdaFunctionARN in forward action")
 	default:
 		return "", errors.Errorf("unknown targetType: %v", rawTargetType)
 	}
 }
 
+// buildTargetGroupTargetTypeForFargateBackend detects instance targetType for backends whose pods all run on Fargate,
+// which cannot be registered as instance targets. A warning event is emitted on the Ingress,
+// and ip targetType is used instead if Fargate targetType fallback is enabled.
+// the decision is only made when the TargetGroup is created, afterwards the targetType of its existing TargetGroupBinding is kept,
+// so that the TargetGroup isn't replaced while pods move between Fargate and EC2 nodes.
+func (t *defaultModelBuildTask) buildTargetGroupTargetTypeForFargateBackend(ctx context.Context, ing ClassifiedIngress, svc *corev1.Service,
+	targetType elbv2model.TargetType, buildNameForTargetType func(targetType elbv2model.TargetType) string) (elbv2model.TargetType, error) {
+	if targetType != elbv2model.TargetTypeInstance {
+		return targetType, nil
+	}
+	candidateTargetTypes := []elbv2model.TargetType{elbv2model.TargetTypeInstance}
+	if t.enableFargateTargetTypeFallback {
+		candidateTargetTypes = append(candidateTargetTypes, elbv2model.TargetTypeIP)
+	}
+	for _, candidateTargetType := range candidateTargetTypes {
+		tgbKey := types.NamespacedName{Namespace: svc.Namespace, Name: buildNameForTargetType(candidateTargetType)}
+		if err := t.k8sClient.Get(ctx, tgbKey, &elbv2api.TargetGroupBinding{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		return candidateTargetType, nil
+	}
+
+	runsOnFargate, err := t.isBackendRunningOnFargate(ctx, svc)
+	if err != nil {
+		return "", err
+	}
+	if !runsOnFargate {
+		return targetType, nil
+	}
+	svcKey := k8s.NamespacedName(svc)
+	if !t.enableFargateTargetTypeFallback {
+		t.eventRecorder.Event(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonFargateTargetType,
+			fmt.Sprintf("Pods of service %v run on Fargate, which cannot be registered as instance targets, use ip target type instead", svcKey))
+		return targetType, nil
+	}
+	t.eventRecorder.Event(ing.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonFargateTargetType,
+		fmt.Sprintf("Pods of service %v run on Fargate, which cannot be registered as instance targets, falling back to ip target type", svcKey))
+	return elbv2model.TargetTypeIP, nil
+}
+
+// isBackendRunningOnFargate returns whether all endpoints of service are pods on Fargate nodes.
+// it returns false if service has no endpoints yet, so that targetType doesn't flip while pods are being scheduled.
+func (t *defaultModelBuildTask) isBackendRunningOnFargate(ctx context.Context, svc *corev1.Service) (bool, error) {
+	var nodeNames sets.String
+	var allEndpointsOnNodes bool
+	var err error
+	if t.enableEndpointSlices {
+		nodeNames, allEndpointsOnNodes, err = t.listBackendNodeNamesFromSlices(ctx, svc)
+	} else {
+		nodeNames, allEndpointsOnNodes, err = t.listBackendNodeNames(ctx, svc)
+	}
+	if err != nil {
+		return false, err
+	}
+	if !allEndpointsOnNodes || nodeNames.Len() == 0 {
+		return false, nil
+	}
+	for _, nodeName := range nodeNames.List() {
+		node := &corev1.Node{}
+		if err := t.k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if !k8s.IsFargateNode(node) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// listBackendNodeNames returns the names of nodes that endpoints of service run on from its Endpoints,
+// along with whether all endpoints run on nodes.
+func (t *defaultModelBuildTask) listBackendNodeNames(ctx context.Context, svc *corev1.Service) (sets.String, bool, error) {
+	eps := &corev1.Endpoints{}
+	if err := t.k8sClient.Get(ctx, k8s.NamespacedName(svc), eps); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	nodeNames := sets.NewString()
+	for _, subset := range eps.Subsets {
+		for _, addresses := range [][]corev1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+			for _, address := range addresses {
+				if address.NodeName == nil {
+					// endpoints without node aren't pods, e.g. manually managed endpoints.
+					return nil, false, nil
+				}
+				nodeNames.Insert(*address.NodeName)
+			}
+		}
+	}
+	return nodeNames, true, nil
+}
+
+// listBackendNodeNamesFromSlices returns the names of nodes that endpoints of service run on from its EndpointSlices,
+// along with whether all endpoints run on nodes.
+func (t *defaultModelBuildTask) listBackendNodeNamesFromSlices(ctx context.Context, svc *corev1.Service) (sets.String, bool, error) {
+	epSliceList := &discv1.EndpointSliceList{}
+	if err := t.k8sClient.List(ctx, epSliceList, client.InNamespace(svc.Namespace),
+		client.MatchingLabels{discv1.LabelServiceName: svc.Name}); err != nil {
+		return nil, false, err
+	}
+	nodeNames := sets.NewString()
+	for _, epSlice := range epSliceList.Items {
+		for _, endpoint := range epSlice.Endpoints {
+			// nodeName is only populated with EndpointSliceNodeName feature gate, otherwise the node is reported via topology.
+			nodeName, ok := endpoint.Topology[corev1.LabelHostname]
+			if endpoint.NodeName != nil {
+				nodeName, ok = *endpoint.NodeName, true
+			}
+			if !ok {
+				// endpoints without node aren't pods, e.g. endpoints of custom EndpointSlices.
+				return nil, false, nil
+			}
+			nodeNames.Insert(nodeName)
+		}
+	}
+	return nodeNames, true, nil
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupIPAddressType(_ context.Context, svc *corev1.Service) (elbv2model.TargetGroupIPAddressType, error) {
 	var ipv6Configured bool
 	for _, ipFamily := range svc.Spec.IPFamilies {
@@ -229,7 +430,8 @@ func (t *defaultModelBuildTask) buildTargetGroupIPAddressType(_ context.Context,
 		}
 	}
 	if ipv6Configured {
-		if *t.loadBalancer.Spec.IPAddressType != elbv2model.IPAddressTypeDualStack {
+		// the IP address type of existing LoadBalancer is unknown, and will be validated by ELBV2 instead.
+		if t.loadBalancer.Spec.ExistingLoadBalancerARN == nil && *t.loadBalancer.Spec.IPAddressType != elbv2model.IPAddressTypeDualStack {
 			return "", errors.New("unsupported IPv6 configuration, lb not dual-stack")
 		}
 		return elbv2model.TargetGroupIPAddressTypeIPv6, nil
@@ -282,7 +484,11 @@ func (t *defaultModelBuildTask) buildTargetGroupProtocolVersion(_ context.Contex
 }
 
 func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType, tgProtocol elbv2model.Protocol, tgProtocolVersion elbv2model.ProtocolVersion) (elbv2model.TargetGroupHealthCheckConfig, error) {
-	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, svc, svcAndIngAnnotations, targetType)
+	healthCheckPreset, err := t.buildTargetGroupHealthCheckPreset(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
+	healthCheckPort, err := t.buildTargetGroupHealthCheckPort(ctx, svc, svcAndIngAnnotations, targetType, healthCheckPreset)
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
@@ -290,8 +496,15 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
-	healthCheckPath := t.buildTargetGroupHealthCheckPath(ctx, svcAndIngAnnotations, tgProtocolVersion)
-	healthCheckMatcher := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion)
+	healthCheckSource, err := t.buildTargetGroupHealthCheckSource(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
+	healthCheckPath := t.buildTargetGroupHealthCheckPath(ctx, svcAndIngAnnotations, tgProtocolVersion, healthCheckPreset)
+	healthCheckMatcher, err := t.buildTargetGroupHealthCheckMatcher(ctx, svcAndIngAnnotations, tgProtocolVersion, healthCheckPreset)
+	if err != nil {
+		return elbv2model.TargetGroupHealthCheckConfig{}, err
+	}
 	healthCheckIntervalSeconds, err := t.buildTargetGroupHealthCheckIntervalSeconds(ctx, svcAndIngAnnotations)
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
@@ -308,6 +521,10 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	if err != nil {
 		return elbv2model.TargetGroupHealthCheckConfig{}, err
 	}
+	if healthCheckSource == healthCheckSourceReadiness {
+		healthCheckMatcher = buildPermissiveHealthCheckMatcher(tgProtocolVersion)
+		healthCheckUnhealthyThresholdCount = permissiveHealthCheckUnhealthyThresholdCount
+	}
 	return elbv2model.TargetGroupHealthCheckConfig{
 		Port:                    &healthCheckPort,
 		Protocol:                &healthCheckProtocol,
@@ -320,11 +537,35 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfig(ctx context.Co
 	}, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType) (intstr.IntOrString, error) {
-	rawHealthCheckPort := ""
-	if exist := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPort, &rawHealthCheckPort, svcAndIngAnnotations); !exist {
-		return intstr.FromString(healthCheckPortTrafficPort), nil
+// buildTargetGroupHealthCheckConfigForRollout relaxes the health check of targetGroup while its TargetGroupBinding
+// rolls out many targets, as requested by the TargetGroupBinding controller via annotation.
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckConfigForRollout(ctx context.Context, svc *corev1.Service, tgName string,
+	healthCheckConfig *elbv2model.TargetGroupHealthCheckConfig) error {
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := t.k8sClient.Get(ctx, types.NamespacedName{Namespace: svc.Namespace, Name: tgName}, tgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !targetgroupbinding.IsHealthCheckRelaxed(tgb) {
+		return nil
 	}
+	relaxed := targetgroupbinding.BuildRelaxedHealthCheckThresholds(targetgroupbinding.HealthCheckThresholds{
+		UnhealthyThresholdCount: awssdk.Int64Value(healthCheckConfig.UnhealthyThresholdCount),
+		IntervalSeconds:         awssdk.Int64Value(healthCheckConfig.IntervalSeconds),
+	})
+	healthCheckConfig.UnhealthyThresholdCount = awssdk.Int64(relaxed.UnhealthyThresholdCount)
+	healthCheckConfig.IntervalSeconds = awssdk.Int64(relaxed.IntervalSeconds)
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPort(_ context.Context, svc *corev1.Service, svcAndIngAnnotations map[string]string, targetType elbv2model.TargetType, preset *healthCheckPreset) (intstr.IntOrString, error) {
+	rawHealthCheckPort := healthCheckPortTrafficPort
+	if preset != nil {
+		rawHealthCheckPort = preset.port
+	}
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPort, &rawHealthCheckPort, svcAndIngAnnotations)
 	if rawHealthCheckPort == healthCheckPortTrafficPort {
 		return intstr.FromString(healthCheckPortTrafficPort), nil
 	}
@@ -359,7 +600,7 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckProtocol(_ context.Co
 	}
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) string {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion, preset *healthCheckPreset) string {
 	var rawHealthCheckPath string
 	switch tgProtocolVersion {
 	case elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2:
@@ -367,11 +608,14 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckPath(_ context.Contex
 	case elbv2model.ProtocolVersionGRPC:
 		rawHealthCheckPath = t.defaultHealthCheckPathGRPC
 	}
+	if preset != nil {
+		rawHealthCheckPath = preset.path
+	}
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckPath, &rawHealthCheckPath, svcAndIngAnnotations)
 	return rawHealthCheckPath
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion) elbv2model.HealthCheckMatcher {
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Context, svcAndIngAnnotations map[string]string, tgProtocolVersion elbv2model.ProtocolVersion, preset *healthCheckPreset) (elbv2model.HealthCheckMatcher, error) {
 	var rawHealthCheckMatcherHTTPCode string
 	switch tgProtocolVersion {
 	case elbv2model.ProtocolVersionHTTP1, elbv2model.ProtocolVersionHTTP2:
@@ -379,15 +623,103 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckMatcher(_ context.Con
 	case elbv2model.ProtocolVersionGRPC:
 		rawHealthCheckMatcherHTTPCode = t.defaultHealthCheckMatcherGRPCCode
 	}
+	if preset != nil {
+		rawHealthCheckMatcherHTTPCode = preset.successCodes
+	}
 
 	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawHealthCheckMatcherHTTPCode, svcAndIngAnnotations)
+	var rawHealthCheckMatcherGRPCCode string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGRPCSuccessCodes, &rawHealthCheckMatcherGRPCCode, svcAndIngAnnotations); exists {
+		if tgProtocolVersion != elbv2model.ProtocolVersionGRPC {
+			return elbv2model.HealthCheckMatcher{}, errors.Errorf("grpcSuccessCodes is only supported for backend protocolVersion %v, got %v",
+				elbv2model.ProtocolVersionGRPC, tgProtocolVersion)
+		}
+		rawHealthCheckMatcherHTTPCode = rawHealthCheckMatcherGRPCCode
+	}
 	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC {
+		if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherHTTPCode, healthCheckMatcherGRPCCodeMin, healthCheckMatcherGRPCCodeMax); err != nil {
+			return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid gRPC successCodes for backend protocolVersion %v", tgProtocolVersion)
+		}
 		return elbv2model.HealthCheckMatcher{
 			GRPCCode: &rawHealthCheckMatcherHTTPCode,
-		}
+		}, nil
+	}
+	if err := validateHealthCheckMatcherCodes(rawHealthCheckMatcherHTTPCode, healthCheckMatcherHTTPCodeMin, healthCheckMatcherHTTPCodeMax); err != nil {
+		return elbv2model.HealthCheckMatcher{}, errors.Wrapf(err, "invalid HTTP successCodes for backend protocolVersion %v", tgProtocolVersion)
 	}
 	return elbv2model.HealthCheckMatcher{
 		HTTPCode: &rawHealthCheckMatcherHTTPCode,
+	}, nil
+}
+
+// validateHealthCheckMatcherCodes validates status codes of health check matcher, which are comma separated values or ranges like "200,202" or "200-399".
+// all codes must be within [min, max].
+func validateHealthCheckMatcherCodes(rawCodes string, min int64, max int64) error {
+	if len(rawCodes) == 0 {
+		return errors.New("codes must not be empty")
+	}
+	parseCode := func(rawCode string) (int64, error) {
+		code, err := strconv.ParseInt(rawCode, 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("code %q is not an integer", rawCode)
+		}
+		if code < min || code > max {
+			return 0, errors.Errorf("code %v must be within %v-%v", code, min, max)
+		}
+		return code, nil
+	}
+	for _, rawEntry := range strings.Split(rawCodes, ",") {
+		rawFrom, rawTo := rawEntry, rawEntry
+		if idx := strings.Index(rawEntry, "-"); idx != -1 {
+			rawFrom, rawTo = rawEntry[:idx], rawEntry[idx+1:]
+		}
+		from, err := parseCode(rawFrom)
+		if err != nil {
+			return err
+		}
+		to, err := parseCode(rawTo)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			return errors.Errorf("range %v is reversed", rawEntry)
+		}
+	}
+	return nil
+}
+
+func (t *defaultModelBuildTask) buildTargetGroupHealthCheckSource(_ context.Context, svcAndIngAnnotations map[string]string) (string, error) {
+	rawHealthCheckSource := healthCheckSourceTargetGroup
+	_ = t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixHealthCheckSource, &rawHealthCheckSource, svcAndIngAnnotations)
+	switch rawHealthCheckSource {
+	case healthCheckSourceTargetGroup:
+		return healthCheckSourceTargetGroup, nil
+	case healthCheckSourceReadiness:
+		if t.loadBalancer != nil && t.loadBalancer.Spec.Scheme != nil && *t.loadBalancer.Spec.Scheme != elbv2model.LoadBalancerSchemeInternal {
+			return "", errors.Errorf("healthCheckSource %v is only supported for internal load balancers", healthCheckSourceReadiness)
+		}
+		var rawSuccessCodes string
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixSuccessCodes, &rawSuccessCodes, svcAndIngAnnotations); exists {
+			return "", errors.Errorf("successCodes cannot be specified when healthCheckSource is %v", healthCheckSourceReadiness)
+		}
+		if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGRPCSuccessCodes, &rawSuccessCodes, svcAndIngAnnotations); exists {
+			return "", errors.Errorf("grpcSuccessCodes cannot be specified when healthCheckSource is %v", healthCheckSourceReadiness)
+		}
+		return healthCheckSourceReadiness, nil
+	default:
+		return "", errors.Errorf("unknown healthCheckSource: %v", rawHealthCheckSource)
+	}
+}
+
+// buildPermissiveHealthCheckMatcher builds a matcher that only verifies targets are reachable and responding.
+func buildPermissiveHealthCheckMatcher(tgProtocolVersion elbv2model.ProtocolVersion) elbv2model.HealthCheckMatcher {
+	if tgProtocolVersion == elbv2model.ProtocolVersionGRPC {
+		return elbv2model.HealthCheckMatcher{
+			GRPCCode: awssdk.String(permissiveHealthCheckMatcherGRPCCode),
+		}
+	}
+	return elbv2model.HealthCheckMatcher{
+		HTTPCode: awssdk.String(permissiveHealthCheckMatcherHTTPCode),
 	}
 }
 
@@ -427,11 +759,28 @@ func (t *defaultModelBuildTask) buildTargetGroupHealthCheckUnhealthyThresholdCou
 	return rawHealthCheckUnhealthyThresholdCount, nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, svcAndIngAnnotations map[string]string) ([]elbv2model.TargetGroupAttribute, error) {
+func (t *defaultModelBuildTask) buildTargetGroupAttributes(ctx context.Context, svcAndIngAnnotations map[string]string) ([]elbv2model.TargetGroupAttribute, error) {
 	var rawAttributes map[string]string
 	if _, err := t.annotationParser.ParseStringMapAnnotation(annotations.IngressSuffixTargetGroupAttributes, &rawAttributes, svcAndIngAnnotations); err != nil {
 		return nil, err
 	}
+	typedAttributes, err := t.buildTargetGroupTypedAttributes(ctx, svcAndIngAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	if len(typedAttributes) != 0 && rawAttributes == nil {
+		rawAttributes = make(map[string]string, len(typedAttributes))
+	}
+	for attrKey, attrValue := range typedAttributes {
+		if rawValue, exists := rawAttributes[attrKey]; exists && rawValue != attrValue {
+			return nil, errors.Errorf("conflicting values for target group attribute %v: %v from typed annotation, %v from %v annotation",
+				attrKey, attrValue, rawValue, annotations.IngressSuffixTargetGroupAttributes)
+		}
+		rawAttributes[attrKey] = attrValue
+	}
+	if err := validateTargetGroupAttributes(rawAttributes); err != nil {
+		return nil, err
+	}
 	attributes := make([]elbv2model.TargetGroupAttribute, 0, len(rawAttributes))
 	for attrKey, attrValue := range rawAttributes {
 		attributes = append(attributes, elbv2model.TargetGroupAttribute{
@@ -442,6 +791,148 @@ func (t *defaultModelBuildTask) buildTargetGroupAttributes(_ context.Context, sv
 	return attributes, nil
 }
 
+// buildTargetGroupTypedAttributes builds the target group attributes from first-class annotations.
+func (t *defaultModelBuildTask) buildTargetGroupTypedAttributes(_ context.Context, svcAndIngAnnotations map[string]string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	var slowStartSeconds int64
+	if exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixSlowStartSeconds, &slowStartSeconds, svcAndIngAnnotations); err != nil {
+		return nil, err
+	} else if exists {
+		if slowStartSeconds != 0 && (slowStartSeconds < minSlowStartSeconds || slowStartSeconds > maxSlowStartSeconds) {
+			return nil, errors.Errorf("%v annotation must be 0 or within [%v, %v], got %v", annotations.IngressSuffixSlowStartSeconds, minSlowStartSeconds, maxSlowStartSeconds, slowStartSeconds)
+		}
+		attributes[tgAttrsSlowStartDurationSeconds] = strconv.FormatInt(slowStartSeconds, 10)
+	}
+
+	var stickinessEnabled bool
+	if exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixStickinessEnabled, &stickinessEnabled, svcAndIngAnnotations); err != nil {
+		return nil, err
+	} else if exists {
+		attributes[tgAttrsStickinessEnabled] = strconv.FormatBool(stickinessEnabled)
+	}
+	stickinessType := stickinessTypeLBCookie
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixStickinessType, &stickinessType, svcAndIngAnnotations); exists {
+		if stickinessType != stickinessTypeLBCookie && stickinessType != stickinessTypeAppCookie {
+			return nil, errors.Errorf("unknown %v annotation: %v", annotations.IngressSuffixStickinessType, stickinessType)
+		}
+		attributes[tgAttrsStickinessType] = stickinessType
+	}
+	var stickinessDurationSeconds int64
+	if exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixStickinessDurationSeconds, &stickinessDurationSeconds, svcAndIngAnnotations); err != nil {
+		return nil, err
+	} else if exists {
+		if stickinessDurationSeconds < minStickinessDurationSeconds || stickinessDurationSeconds > maxStickinessDurationSeconds {
+			return nil, errors.Errorf("%v annotation must be within [%v, %v], got %v", annotations.IngressSuffixStickinessDurationSeconds, minStickinessDurationSeconds, maxStickinessDurationSeconds, stickinessDurationSeconds)
+		}
+		if stickinessType == stickinessTypeAppCookie {
+			attributes[tgAttrsStickinessAppCookieDurationSeconds] = strconv.FormatInt(stickinessDurationSeconds, 10)
+		} else {
+			attributes[tgAttrsStickinessLBCookieDurationSeconds] = strconv.FormatInt(stickinessDurationSeconds, 10)
+		}
+	}
+	var stickinessCookieName string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixStickinessCookieName, &stickinessCookieName, svcAndIngAnnotations); exists {
+		if stickinessType != stickinessTypeAppCookie {
+			return nil, errors.Errorf("%v annotation can only be specified when %v is %v",
+				annotations.IngressSuffixStickinessCookieName, annotations.IngressSuffixStickinessType, stickinessTypeAppCookie)
+		}
+		attributes[tgAttrsStickinessAppCookieName] = stickinessCookieName
+	}
+
+	var loadBalancingAlgorithm string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixLoadBalancingAlgorithm, &loadBalancingAlgorithm, svcAndIngAnnotations); exists {
+		if loadBalancingAlgorithm != loadBalancingAlgorithmRoundRobin && loadBalancingAlgorithm != loadBalancingAlgorithmLeastOutstandingRequests &&
+			loadBalancingAlgorithm != elbv2deploy.LoadBalancingAlgorithmWeightedRandom {
+			return nil, errors.Errorf("unknown %v annotation: %v", annotations.IngressSuffixLoadBalancingAlgorithm, loadBalancingAlgorithm)
+		}
+		attributes[elbv2deploy.TGAttrsLoadBalancingAlgorithmType] = loadBalancingAlgorithm
+	}
+	var anomalyMitigationEnabled bool
+	if exists, err := t.annotationParser.ParseBoolAnnotation(annotations.IngressSuffixAnomalyMitigationEnabled, &anomalyMitigationEnabled, svcAndIngAnnotations); err != nil {
+		return nil, err
+	} else if exists {
+		attributes[elbv2deploy.TGAttrsLoadBalancingAnomalyMitigation] = elbv2deploy.AnomalyMitigationOff
+		if anomalyMitigationEnabled {
+			attributes[elbv2deploy.TGAttrsLoadBalancingAnomalyMitigation] = elbv2deploy.AnomalyMitigationOn
+		}
+	}
+
+	if err := t.buildTargetGroupHealthAttributes(attributes, svcAndIngAnnotations); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// buildTargetGroupHealthAttributes builds the target group health requirements from first-class annotations.
+func (t *defaultModelBuildTask) buildTargetGroupHealthAttributes(attributes map[string]string, svcAndIngAnnotations map[string]string) error {
+	var dnsFailoverCount string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsCount, &dnsFailoverCount, svcAndIngAnnotations); exists {
+		if err := validateMinimumHealthyTargets(dnsFailoverCount, 1, math.MaxInt32); err != nil {
+			return errors.Wrapf(err, "invalid %v annotation", annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsCount)
+		}
+		attributes[tgAttrsDNSFailoverMinimumHealthyTargetsCount] = dnsFailoverCount
+	}
+	var dnsFailoverPercentage string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsPercentage, &dnsFailoverPercentage, svcAndIngAnnotations); exists {
+		if err := validateMinimumHealthyTargets(dnsFailoverPercentage, minMinimumHealthyTargetsPct, maxMinimumHealthyTargetsPct); err != nil {
+			return errors.Wrapf(err, "invalid %v annotation", annotations.IngressSuffixDNSFailoverMinimumHealthyTargetsPercentage)
+		}
+		attributes[tgAttrsDNSFailoverMinimumHealthyTargetsPercentage] = dnsFailoverPercentage
+	}
+	var unhealthyStateRoutingCount int64
+	if exists, err := t.annotationParser.ParseInt64Annotation(annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsCount, &unhealthyStateRoutingCount, svcAndIngAnnotations); err != nil {
+		return err
+	} else if exists {
+		if unhealthyStateRoutingCount < 1 {
+			return errors.Errorf("%v annotation must be at least 1, got %v", annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsCount, unhealthyStateRoutingCount)
+		}
+		attributes[tgAttrsUnhealthyStateRoutingMinimumHealthyTargetsCount] = strconv.FormatInt(unhealthyStateRoutingCount, 10)
+	}
+	var unhealthyStateRoutingPercentage string
+	if exists := t.annotationParser.ParseStringAnnotation(annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsPercentage, &unhealthyStateRoutingPercentage, svcAndIngAnnotations); exists {
+		if err := validateMinimumHealthyTargets(unhealthyStateRoutingPercentage, minMinimumHealthyTargetsPct, maxMinimumHealthyTargetsPct); err != nil {
+			return errors.Wrapf(err, "invalid %v annotation", annotations.IngressSuffixUnhealthyStateRoutingMinimumHealthyTargetsPercentage)
+		}
+		attributes[tgAttrsUnhealthyStateRoutingMinimumHealthyTargetsPercentage] = unhealthyStateRoutingPercentage
+	}
+	return nil
+}
+
+// validateMinimumHealthyTargets validates a minimum healthy targets requirement, which is either "off" or an integer within [min, max].
+func validateMinimumHealthyTargets(rawValue string, min int64, max int64) error {
+	if rawValue == minimumHealthyTargetsOff {
+		return nil
+	}
+	value, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		return errors.Errorf("must be %v or an integer, got %v", minimumHealthyTargetsOff, rawValue)
+	}
+	if value < min || value > max {
+		return errors.Errorf("must be %v or within [%v, %v], got %v", minimumHealthyTargetsOff, min, max, value)
+	}
+	return nil
+}
+
+// validateTargetGroupAttributes validates combinations of target group attributes that ELBV2 would reject.
+func validateTargetGroupAttributes(attributes map[string]string) error {
+	slowStartSeconds, slowStartConfigured := attributes[tgAttrsSlowStartDurationSeconds]
+	if slowStartConfigured && slowStartSeconds != "0" && attributes[elbv2deploy.TGAttrsLoadBalancingAlgorithmType] == loadBalancingAlgorithmLeastOutstandingRequests {
+		return errors.Errorf("slow start cannot be enabled with %v load balancing algorithm", loadBalancingAlgorithmLeastOutstandingRequests)
+	}
+	if attributes[elbv2deploy.TGAttrsLoadBalancingAnomalyMitigation] == elbv2deploy.AnomalyMitigationOn {
+		if attributes[elbv2deploy.TGAttrsLoadBalancingAlgorithmType] != elbv2deploy.LoadBalancingAlgorithmWeightedRandom {
+			return errors.Errorf("anomaly mitigation can only be enabled with %v load balancing algorithm", elbv2deploy.LoadBalancingAlgorithmWeightedRandom)
+		}
+		if slowStartConfigured && slowStartSeconds != "0" {
+			return errors.New("slow start cannot be enabled with anomaly mitigation")
+		}
+	}
+	if attributes[tgAttrsStickinessEnabled] == "true" && attributes[tgAttrsStickinessType] == stickinessTypeAppCookie && attributes[tgAttrsStickinessAppCookieName] == "" {
+		return errors.Errorf("target group attribute %v must be specified when %v is %v", tgAttrsStickinessAppCookieName, tgAttrsStickinessType, stickinessTypeAppCookie)
+	}
+	return nil
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, ing ClassifiedIngress, svc *corev1.Service) (map[string]string, error) {
 	ingSvcTags, err := t.buildIngressBackendResourceTags(ing, svc)
 	if err != nil {
@@ -450,10 +941,60 @@ func (t *defaultModelBuildTask) buildTargetGroupTags(_ context.Context, ing Clas
 	return algorithm.MergeStringMap(t.defaultTags, ingSvcTags), nil
 }
 
-func (t *defaultModelBuildTask) buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString) string {
+// buildTargetGroupResourceID returns the resource ID of TargetGroup for service port referenced by Ingress.
+func buildTargetGroupResourceID(ingKey types.NamespacedName, svcKey types.NamespacedName, port intstr.IntOrString) string {
 	return fmt.Sprintf("%s/%s-%s:%s", ingKey.Namespace, ingKey.Name, svcKey.Name, port.String())
 }
 
+// buildLambdaTargetGroup builds a TargetGroup with lambda targetType, with the Lambda function as its only target.
+// lambda TargetGroups don't have a TargetGroupBinding, the Lambda function is registered during deployment.
+func (t *defaultModelBuildTask) buildLambdaTargetGroup(ctx context.Context, ing ClassifiedIngress, lambdaFunctionARN string) (*elbv2model.TargetGroup, error) {
+	parsedARN, err := arn.Parse(lambdaFunctionARN)
+	if err != nil || !strings.HasPrefix(parsedARN.Resource, lambdaFunctionARNResourcePrefix) {
+		return nil, errors.Errorf("invalid lambdaFunctionARN: %v", lambdaFunctionARN)
+	}
+	functionName := strings.TrimPrefix(parsedARN.Resource, lambdaFunctionARNResourcePrefix)
+	ingKey := k8s.NamespacedName(ing.Ing)
+	tgResID := fmt.Sprintf("%s/%s-lambda:%s", ingKey.Namespace, ingKey.Name, functionName)
+	if tg, exists := t.tgByResID[tgResID]; exists {
+		return tg, nil
+	}
+	tgAttributes, err := t.buildTargetGroupAttributes(ctx, ing.Ing.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	ingTags, err := t.buildIngressResourceTags(ing)
+	if err != nil {
+		return nil, err
+	}
+	tgSpec := elbv2model.TargetGroupSpec{
+		Name:                  t.buildLambdaTargetGroupName(ctx, ingKey, functionName, lambdaFunctionARN),
+		TargetType:            elbv2model.TargetTypeLambda,
+		TargetGroupAttributes: tgAttributes,
+		Tags:                  algorithm.MergeStringMap(t.defaultTags, ingTags),
+		LambdaFunctionARN:     &lambdaFunctionARN,
+	}
+	tg := elbv2model.NewTargetGroup(t.stack, tgResID, tgSpec)
+	t.tgByResID[tgResID] = tg
+	return tg, nil
+}
+
+// buildLambdaTargetGroupName will calculate the name of TargetGroup with lambda targetType.
+func (t *defaultModelBuildTask) buildLambdaTargetGroupName(_ context.Context, ingKey types.NamespacedName, functionName string, lambdaFunctionARN string) string {
+	uuidHash := sha256.New()
+	_, _ = uuidHash.Write([]byte(t.clusterName))
+	_, _ = uuidHash.Write([]byte(t.ingGroup.ID.String()))
+	_, _ = uuidHash.Write([]byte(ingKey.Namespace))
+	_, _ = uuidHash.Write([]byte(ingKey.Name))
+	_, _ = uuidHash.Write([]byte(lambdaFunctionARN))
+	_, _ = uuidHash.Write([]byte(elbv2model.TargetTypeLambda))
+	uuid := hex.EncodeToString(uuidHash.Sum(nil))
+
+	sanitizedNamespace := invalidTargetGroupNamePattern.ReplaceAllString(ingKey.Namespace, "")
+	sanitizedName := invalidTargetGroupNamePattern.ReplaceAllString(functionName, "")
+	return buildResourceName(t.resourceNameTemplate, uuid, sanitizedNamespace, sanitizedName)
+}
+
 func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Context, ing ClassifiedIngress, svc *corev1.Service, targetType elbv2model.TargetType) (*metav1.LabelSelector, error) {
 	if targetType != elbv2model.TargetTypeInstance {
 		return nil, nil
@@ -471,3 +1012,34 @@ func (t *defaultModelBuildTask) buildTargetGroupBindingNodeSelector(_ context.Co
 		MatchLabels: targetNodeLabels,
 	}, nil
 }
+
+// buildTargetGroupBindingExternalTargets builds the static targets outside the cluster, which are specified in format of ip:port.
+func (t *defaultModelBuildTask) buildTargetGroupBindingExternalTargets(_ context.Context, ing ClassifiedIngress, svc *corev1.Service, targetType elbv2model.TargetType) ([]elbv2api.ExternalTarget, error) {
+	var rawExternalTargets []string
+	svcAndIngAnnotations := algorithm.MergeStringMap(svc.Annotations, ing.Ing.Annotations)
+	if exists := t.annotationParser.ParseStringSliceAnnotation(annotations.IngressSuffixExternalTargets, &rawExternalTargets, svcAndIngAnnotations); !exists {
+		return nil, nil
+	}
+	if targetType != elbv2model.TargetTypeIP {
+		return nil, errors.Errorf("externalTargets is only supported for ip targetType, got %v", targetType)
+	}
+	externalTargets := make([]elbv2api.ExternalTarget, 0, len(rawExternalTargets))
+	for _, rawExternalTarget := range rawExternalTargets {
+		rawIP, rawPort, err := net.SplitHostPort(rawExternalTarget)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse externalTarget: %v", rawExternalTarget)
+		}
+		if net.ParseIP(rawIP) == nil {
+			return nil, errors.Errorf("invalid IP in externalTarget: %v", rawExternalTarget)
+		}
+		port, err := strconv.ParseInt(rawPort, 10, 64)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, errors.Errorf("invalid port in externalTarget: %v", rawExternalTarget)
+		}
+		externalTargets = append(externalTargets, elbv2api.ExternalTarget{
+			IP:   rawIP,
+			Port: port,
+		})
+	}
+	return externalTargets, nil
+}
@@ -2,17 +2,25 @@ package ingress
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1beta1"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
 	elbv2model "sigs.k8s.io/aws-load-balancer-controller/pkg/model/elbv2"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
 )
 
@@ -129,7 +137,9 @@ func Test_defaultModelBuildTask_buildTargetGroupName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task := &defaultModelBuildTask{}
+			task := &defaultModelBuildTask{
+				resourceNameTemplate: "k8s-{namespace}-{name}-{hash}",
+			}
 			got := task.buildTargetGroupName(context.Background(), tt.args.ingKey, tt.args.svc, tt.args.port, tt.args.tgPort, tt.args.targetType, tt.args.tgProtocol, tt.args.tgProtocolVersion)
 			assert.Equal(t, tt.want, got)
 		})
@@ -374,6 +384,103 @@ func Test_defaultModelBuildTask_buildTargetGroupTags(t *testing.T) {
 	}
 }
 
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPort(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+					NodePort:   32768,
+				},
+				{
+					Name:       "management",
+					Port:       8081,
+					TargetPort: intstr.FromInt(8081),
+					NodePort:   32769,
+				},
+			},
+		},
+	}
+	type args struct {
+		svcAndIngAnnotations map[string]string
+		targetType           elbv2model.TargetType
+		preset               *healthCheckPreset
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    intstr.IntOrString
+		wantErr error
+	}{
+		{
+			name: "without annotation configured",
+			args: args{
+				svcAndIngAnnotations: nil,
+				targetType:           elbv2model.TargetTypeIP,
+			},
+			want: intstr.FromString("traffic-port"),
+		},
+		{
+			name: "with preset",
+			args: args{
+				svcAndIngAnnotations: nil,
+				targetType:           elbv2model.TargetTypeIP,
+				preset:               &healthCheckPreset{port: "traffic-port"},
+			},
+			want: intstr.FromString("traffic-port"),
+		},
+		{
+			name: "with preset of named port, instance target type",
+			args: args{
+				svcAndIngAnnotations: nil,
+				targetType:           elbv2model.TargetTypeInstance,
+				preset:               &healthCheckPreset{port: "management"},
+			},
+			want: intstr.FromInt(32769),
+		},
+		{
+			name: "with preset and annotation configured",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-port": "management",
+				},
+				targetType: elbv2model.TargetTypeIP,
+				preset:     &healthCheckPreset{port: "traffic-port"},
+			},
+			want: intstr.FromInt(8081),
+		},
+		{
+			name: "with preset of unknown named port",
+			args: args{
+				svcAndIngAnnotations: nil,
+				targetType:           elbv2model.TargetTypeIP,
+				preset:               &healthCheckPreset{port: "metrics"},
+			},
+			wantErr: errors.New("failed to resolve healthCheckPort: unable to find port metrics on service awesome-ns/awesome-svc"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupHealthCheckPort(context.Background(), svc, tt.args.svcAndIngAnnotations, tt.args.targetType, tt.args.preset)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 	type fields struct {
 		defaultHealthCheckPathHTTP string
@@ -382,6 +489,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 	type args struct {
 		svcAndIngAnnotations map[string]string
 		tgProtocolVersion    elbv2model.ProtocolVersion
+		preset               *healthCheckPreset
 	}
 	tests := []struct {
 		name   string
@@ -467,6 +575,34 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 			},
 			want: "/package.service/method",
 		},
+		{
+			name: "HTTP1, with preset",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svcAndIngAnnotations: nil,
+				tgProtocolVersion:    elbv2model.ProtocolVersionHTTP1,
+				preset:               &healthCheckPreset{path: "/actuator/health"},
+			},
+			want: "/actuator/health",
+		},
+		{
+			name: "HTTP1, with preset and annotation configured",
+			fields: fields{
+				defaultHealthCheckPathHTTP: "/",
+				defaultHealthCheckPathGRPC: "/AWS.ALB/healthcheck",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-path": "/actuator/health/liveness",
+				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+				preset:            &healthCheckPreset{path: "/actuator/health"},
+			},
+			want: "/actuator/health/liveness",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -475,7 +611,7 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckPath(t *testing.T) {
 				defaultHealthCheckPathHTTP: tt.fields.defaultHealthCheckPathHTTP,
 				defaultHealthCheckPathGRPC: tt.fields.defaultHealthCheckPathGRPC,
 			}
-			got := task.buildTargetGroupHealthCheckPath(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
+			got := task.buildTargetGroupHealthCheckPath(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion, tt.args.preset)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -489,12 +625,14 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 	type args struct {
 		svcAndIngAnnotations map[string]string
 		tgProtocolVersion    elbv2model.ProtocolVersion
+		preset               *healthCheckPreset
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   elbv2model.HealthCheckMatcher
+		name    string
+		fields  fields
+		args    args
+		want    elbv2model.HealthCheckMatcher
+		wantErr error
 	}{
 		{
 			name: "HTTP1, without annotation configured",
@@ -586,143 +724,122 @@ func Test_defaultModelBuildTask_buildTargetGroupHealthCheckMatcher(t *testing.T)
 				GRPCCode: awssdk.String("0"),
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			task := &defaultModelBuildTask{
-				annotationParser:                  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
-				defaultHealthCheckMatcherHTTPCode: tt.fields.defaultHealthCheckMatcherHTTPCode,
-				defaultHealthCheckMatcherGRPCCode: tt.fields.defaultHealthCheckMatcherGRPCCode,
-			}
-			got := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T) {
-	type args struct {
-		ing        ClassifiedIngress
-		svc        *corev1.Service
-		targetType elbv2model.TargetType
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    *metav1.LabelSelector
-		wantErr error
-	}{
 		{
-			name: "no annotation",
+			name: "GRPC, with preset",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
 			args: args{
-				ing: ClassifiedIngress{
-					Ing: &networking.Ingress{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{},
-						},
-					},
-				},
-				svc: &corev1.Service{},
+				svcAndIngAnnotations: nil,
+				tgProtocolVersion:    elbv2model.ProtocolVersionGRPC,
+				preset:               &healthCheckPreset{successCodes: "0"},
+			},
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0"),
 			},
-			want: nil,
 		},
 		{
-			name: "ingress has annotation",
+			name: "HTTP1, with preset and annotation configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
 			args: args{
-				ing: ClassifiedIngress{
-					Ing: &networking.Ingress{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
-							},
-						},
-					},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-299",
 				},
-				svc:        &corev1.Service{},
-				targetType: elbv2model.TargetTypeInstance,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+				preset:            &healthCheckPreset{successCodes: "200"},
 			},
-			want: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"key1":            "value1",
-					"node.label/key2": "value.2",
-				},
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200-299"),
 			},
 		},
 		{
-			name: "service annotation overrides ingress",
+			name: "GRPC, with grpc-success-codes annotation configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
 			args: args{
-				ing: ClassifiedIngress{
-					Ing: &networking.Ingress{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
-							},
-						},
-					},
-				},
-				svc: &corev1.Service{
-					ObjectMeta: metav1.ObjectMeta{
-						Annotations: map[string]string{
-							"alb.ingress.kubernetes.io/target-node-labels": "service/key1=value1.service, service.node.label/key2=value.2.service",
-						},
-					},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes":      "0",
+					"alb.ingress.kubernetes.io/grpc-success-codes": "0-12",
 				},
-				targetType: elbv2model.TargetTypeInstance,
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
 			},
-			want: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"service/key1":            "value1.service",
-					"service.node.label/key2": "value.2.service",
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0-12"),
+			},
+		},
+		{
+			name: "HTTP1, with multiple values and ranges configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-399,401",
 				},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200-399,401"),
 			},
 		},
 		{
-			name: "target type ip",
+			name: "HTTP1, with grpc-success-codes annotation configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
 			args: args{
-				ing: ClassifiedIngress{
-					Ing: &networking.Ingress{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
-							},
-						},
-					},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/grpc-success-codes": "0",
 				},
-				svc: &corev1.Service{
-					ObjectMeta: metav1.ObjectMeta{
-						Annotations: map[string]string{
-							"alb.ingress.kubernetes.io/target-node-labels": "service/key1=value1.service, service.node.label/key2=value.2.service",
-						},
-					},
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			},
+			wantErr: errors.New("grpcSuccessCodes is only supported for backend protocolVersion GRPC, got HTTP1"),
+		},
+		{
+			name: "HTTP2, with success codes out of range",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200-599",
 				},
-				targetType: elbv2model.TargetTypeIP,
+				tgProtocolVersion: elbv2model.ProtocolVersionHTTP2,
 			},
-			want: nil,
+			wantErr: errors.New("invalid HTTP successCodes for backend protocolVersion HTTP2: code 599 must be within 200-499"),
 		},
 		{
-			name: "annotation parse error",
+			name: "GRPC, with HTTP success codes configured",
+			fields: fields{
+				defaultHealthCheckMatcherHTTPCode: "200",
+				defaultHealthCheckMatcherGRPCCode: "12",
+			},
 			args: args{
-				ing: ClassifiedIngress{
-					Ing: &networking.Ingress{
-						ObjectMeta: metav1.ObjectMeta{
-							Annotations: map[string]string{
-								"alb.ingress.kubernetes.io/target-node-labels": "key1",
-							},
-						},
-					},
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/success-codes": "200",
 				},
-				svc:        &corev1.Service{},
-				targetType: elbv2model.TargetTypeInstance,
+				tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
 			},
-			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/target-node-labels: key1"),
+			wantErr: errors.New("invalid gRPC successCodes for backend protocolVersion GRPC: code 200 must be within 0-99"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			task := &defaultModelBuildTask{
-				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				annotationParser:                  annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				defaultHealthCheckMatcherHTTPCode: tt.fields.defaultHealthCheckMatcherHTTPCode,
+				defaultHealthCheckMatcherGRPCCode: tt.fields.defaultHealthCheckMatcherGRPCCode,
 			}
-			got, err := task.buildTargetGroupBindingNodeSelector(context.Background(), tt.args.ing, tt.args.svc, tt.args.targetType)
+			got, err := task.buildTargetGroupHealthCheckMatcher(context.Background(), tt.args.svcAndIngAnnotations, tt.args.tgProtocolVersion, tt.args.preset)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -732,3 +849,1182 @@ func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T
 		})
 	}
 }
+
+func Test_validateHealthCheckMatcherCodes(t *testing.T) {
+	type args struct {
+		rawCodes string
+		min      int64
+		max      int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "single value",
+			args: args{rawCodes: "200", min: 200, max: 499},
+		},
+		{
+			name: "multiple values",
+			args: args{rawCodes: "200,202", min: 200, max: 499},
+		},
+		{
+			name: "range of values",
+			args: args{rawCodes: "200-399", min: 200, max: 499},
+		},
+		{
+			name: "gRPC range of values",
+			args: args{rawCodes: "0-99", min: 0, max: 99},
+		},
+		{
+			name:    "empty",
+			args:    args{rawCodes: "", min: 200, max: 499},
+			wantErr: errors.New("codes must not be empty"),
+		},
+		{
+			name:    "not an integer",
+			args:    args{rawCodes: "2xx", min: 200, max: 499},
+			wantErr: errors.New("code \"2xx\" is not an integer"),
+		},
+		{
+			name:    "incomplete range",
+			args:    args{rawCodes: "200-", min: 200, max: 499},
+			wantErr: errors.New("code \"\" is not an integer"),
+		},
+		{
+			name:    "below minimum",
+			args:    args{rawCodes: "100,200", min: 200, max: 499},
+			wantErr: errors.New("code 100 must be within 200-499"),
+		},
+		{
+			name:    "reversed range",
+			args:    args{rawCodes: "399-200", min: 200, max: 499},
+			wantErr: errors.New("range 399-200 is reversed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHealthCheckMatcherCodes(tt.args.rawCodes, tt.args.min, tt.args.max)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckSource(t *testing.T) {
+	schemeInternal := elbv2model.LoadBalancerSchemeInternal
+	schemeInternetFacing := elbv2model.LoadBalancerSchemeInternetFacing
+	type args struct {
+		scheme               *elbv2model.LoadBalancerScheme
+		svcAndIngAnnotations map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "without annotation configured",
+			args: args{
+				scheme:               &schemeInternal,
+				svcAndIngAnnotations: nil,
+			},
+			want: "target-group",
+		},
+		{
+			name: "readiness for internal load balancer",
+			args: args{
+				scheme: &schemeInternal,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-source": "readiness",
+				},
+			},
+			want: "readiness",
+		},
+		{
+			name: "readiness for internet-facing load balancer",
+			args: args{
+				scheme: &schemeInternetFacing,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-source": "readiness",
+				},
+			},
+			wantErr: errors.New("healthCheckSource readiness is only supported for internal load balancers"),
+		},
+		{
+			name: "readiness with success-codes",
+			args: args{
+				scheme: &schemeInternal,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-source": "readiness",
+					"alb.ingress.kubernetes.io/success-codes":      "200",
+				},
+			},
+			wantErr: errors.New("successCodes cannot be specified when healthCheckSource is readiness"),
+		},
+		{
+			name: "readiness with grpc-success-codes",
+			args: args{
+				scheme: &schemeInternal,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-source": "readiness",
+					"alb.ingress.kubernetes.io/grpc-success-codes": "0",
+				},
+			},
+			wantErr: errors.New("grpcSuccessCodes cannot be specified when healthCheckSource is readiness"),
+		},
+		{
+			name: "unknown value",
+			args: args{
+				scheme: &schemeInternal,
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/healthcheck-source": "pod",
+				},
+			},
+			wantErr: errors.New("unknown healthCheckSource: pod"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				loadBalancer: &elbv2model.LoadBalancer{
+					Spec: elbv2model.LoadBalancerSpec{
+						Scheme: tt.args.scheme,
+					},
+				},
+			}
+			got, err := task.buildTargetGroupHealthCheckSource(context.Background(), tt.args.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupAttributes(t *testing.T) {
+	type args struct {
+		svcAndIngAnnotations map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []elbv2model.TargetGroupAttribute
+		wantErr error
+	}{
+		{
+			name: "without annotation configured",
+			args: args{
+				svcAndIngAnnotations: nil,
+			},
+			want: []elbv2model.TargetGroupAttribute{},
+		},
+		{
+			name: "raw attributes only",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-attributes": "deregistration_delay.timeout_seconds=30",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "deregistration_delay.timeout_seconds", Value: "30"},
+			},
+		},
+		{
+			name: "typed attributes merged with raw attributes",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-attributes":     "deregistration_delay.timeout_seconds=30,stickiness.enabled=true",
+					"alb.ingress.kubernetes.io/slow-start-seconds":          "60",
+					"alb.ingress.kubernetes.io/stickiness-enabled":          "true",
+					"alb.ingress.kubernetes.io/stickiness-duration-seconds": "3600",
+					"alb.ingress.kubernetes.io/load-balancing-algorithm":    "round_robin",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "deregistration_delay.timeout_seconds", Value: "30"},
+				{Key: "stickiness.enabled", Value: "true"},
+				{Key: "slow_start.duration_seconds", Value: "60"},
+				{Key: "stickiness.lb_cookie.duration_seconds", Value: "3600"},
+				{Key: "load_balancing.algorithm.type", Value: "round_robin"},
+			},
+		},
+		{
+			name: "app_cookie stickiness",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/stickiness-enabled":          "true",
+					"alb.ingress.kubernetes.io/stickiness-type":             "app_cookie",
+					"alb.ingress.kubernetes.io/stickiness-cookie-name":      "MYSESSION",
+					"alb.ingress.kubernetes.io/stickiness-duration-seconds": "3600",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "stickiness.enabled", Value: "true"},
+				{Key: "stickiness.type", Value: "app_cookie"},
+				{Key: "stickiness.app_cookie.cookie_name", Value: "MYSESSION"},
+				{Key: "stickiness.app_cookie.duration_seconds", Value: "3600"},
+			},
+		},
+		{
+			name: "typed attribute conflicts with raw attribute",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-attributes": "slow_start.duration_seconds=30",
+					"alb.ingress.kubernetes.io/slow-start-seconds":      "60",
+				},
+			},
+			wantErr: errors.New("conflicting values for target group attribute slow_start.duration_seconds: 60 from typed annotation, 30 from target-group-attributes annotation"),
+		},
+		{
+			name: "slow start out of range",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/slow-start-seconds": "10",
+				},
+			},
+			wantErr: errors.New("slow-start-seconds annotation must be 0 or within [30, 900], got 10"),
+		},
+		{
+			name: "slow start with least_outstanding_requests",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/slow-start-seconds":       "60",
+					"alb.ingress.kubernetes.io/load-balancing-algorithm": "least_outstanding_requests",
+				},
+			},
+			wantErr: errors.New("slow start cannot be enabled with least_outstanding_requests load balancing algorithm"),
+		},
+		{
+			name: "slow start disabled with least_outstanding_requests",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/slow-start-seconds":       "0",
+					"alb.ingress.kubernetes.io/load-balancing-algorithm": "least_outstanding_requests",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "slow_start.duration_seconds", Value: "0"},
+				{Key: "load_balancing.algorithm.type", Value: "least_outstanding_requests"},
+			},
+		},
+		{
+			name: "slow start from raw attribute with least_outstanding_requests",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/target-group-attributes":  "slow_start.duration_seconds=30",
+					"alb.ingress.kubernetes.io/load-balancing-algorithm": "least_outstanding_requests",
+				},
+			},
+			wantErr: errors.New("slow start cannot be enabled with least_outstanding_requests load balancing algorithm"),
+		},
+		{
+			name: "unknown stickiness type",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/stickiness-type": "source_ip",
+				},
+			},
+			wantErr: errors.New("unknown stickiness-type annotation: source_ip"),
+		},
+		{
+			name: "cookie name with lb_cookie stickiness",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/stickiness-cookie-name": "MYSESSION",
+				},
+			},
+			wantErr: errors.New("stickiness-cookie-name annotation can only be specified when stickiness-type is app_cookie"),
+		},
+		{
+			name: "app_cookie stickiness without cookie name",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/stickiness-enabled": "true",
+					"alb.ingress.kubernetes.io/stickiness-type":    "app_cookie",
+				},
+			},
+			wantErr: errors.New("target group attribute stickiness.app_cookie.cookie_name must be specified when stickiness.type is app_cookie"),
+		},
+		{
+			name: "stickiness duration out of range",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/stickiness-duration-seconds": "0",
+				},
+			},
+			wantErr: errors.New("stickiness-duration-seconds annotation must be within [1, 604800], got 0"),
+		},
+		{
+			name: "unknown load balancing algorithm",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/load-balancing-algorithm": "random",
+				},
+			},
+			wantErr: errors.New("unknown load-balancing-algorithm annotation: random"),
+		},
+		{
+			name: "anomaly mitigation with weighted_random load balancing algorithm",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/load-balancing-algorithm":   "weighted_random",
+					"alb.ingress.kubernetes.io/anomaly-mitigation-enabled": "true",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "load_balancing.algorithm.type", Value: "weighted_random"},
+				{Key: "load_balancing.algorithm.anomaly_mitigation", Value: "on"},
+			},
+		},
+		{
+			name: "anomaly mitigation disabled",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/anomaly-mitigation-enabled": "false",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "load_balancing.algorithm.anomaly_mitigation", Value: "off"},
+			},
+		},
+		{
+			name: "anomaly mitigation with round_robin load balancing algorithm",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/load-balancing-algorithm":   "round_robin",
+					"alb.ingress.kubernetes.io/anomaly-mitigation-enabled": "true",
+				},
+			},
+			wantErr: errors.New("anomaly mitigation can only be enabled with weighted_random load balancing algorithm"),
+		},
+		{
+			name: "anomaly mitigation with slow start",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/load-balancing-algorithm":   "weighted_random",
+					"alb.ingress.kubernetes.io/anomaly-mitigation-enabled": "true",
+					"alb.ingress.kubernetes.io/slow-start-seconds":         "30",
+				},
+			},
+			wantErr: errors.New("slow start cannot be enabled with anomaly mitigation"),
+		},
+		{
+			name: "target group health requirements",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/dns-failover-minimum-healthy-targets-count":                 "2",
+					"alb.ingress.kubernetes.io/dns-failover-minimum-healthy-targets-percentage":            "off",
+					"alb.ingress.kubernetes.io/unhealthy-state-routing-minimum-healthy-targets-count":      "1",
+					"alb.ingress.kubernetes.io/unhealthy-state-routing-minimum-healthy-targets-percentage": "50",
+				},
+			},
+			want: []elbv2model.TargetGroupAttribute{
+				{Key: "target_group_health.dns_failover.minimum_healthy_targets.count", Value: "2"},
+				{Key: "target_group_health.dns_failover.minimum_healthy_targets.percentage", Value: "off"},
+				{Key: "target_group_health.unhealthy_state_routing.minimum_healthy_targets.count", Value: "1"},
+				{Key: "target_group_health.unhealthy_state_routing.minimum_healthy_targets.percentage", Value: "50"},
+			},
+		},
+		{
+			name: "invalid dns failover minimum healthy targets count",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/dns-failover-minimum-healthy-targets-count": "none",
+				},
+			},
+			wantErr: errors.New("invalid dns-failover-minimum-healthy-targets-count annotation: must be off or an integer, got none"),
+		},
+		{
+			name: "invalid unhealthy state routing minimum healthy targets percentage",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/unhealthy-state-routing-minimum-healthy-targets-percentage": "101",
+				},
+			},
+			wantErr: errors.New("invalid unhealthy-state-routing-minimum-healthy-targets-percentage annotation: must be off or within [1, 100], got 101"),
+		},
+		{
+			name: "invalid unhealthy state routing minimum healthy targets count",
+			args: args{
+				svcAndIngAnnotations: map[string]string{
+					"alb.ingress.kubernetes.io/unhealthy-state-routing-minimum-healthy-targets-count": "0",
+				},
+			},
+			wantErr: errors.New("unhealthy-state-routing-minimum-healthy-targets-count annotation must be at least 1, got 0"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupAttributes(context.Background(), tt.args.svcAndIngAnnotations)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_buildPermissiveHealthCheckMatcher(t *testing.T) {
+	tests := []struct {
+		name              string
+		tgProtocolVersion elbv2model.ProtocolVersion
+		want              elbv2model.HealthCheckMatcher
+	}{
+		{
+			name:              "HTTP1",
+			tgProtocolVersion: elbv2model.ProtocolVersionHTTP1,
+			want: elbv2model.HealthCheckMatcher{
+				HTTPCode: awssdk.String("200-499"),
+			},
+		},
+		{
+			name:              "GRPC",
+			tgProtocolVersion: elbv2model.ProtocolVersionGRPC,
+			want: elbv2model.HealthCheckMatcher{
+				GRPCCode: awssdk.String("0-99"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPermissiveHealthCheckMatcher(tt.tgProtocolVersion)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingNodeSelector(t *testing.T) {
+	type args struct {
+		ing        ClassifiedIngress
+		svc        *corev1.Service
+		targetType elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *metav1.LabelSelector
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{},
+						},
+					},
+				},
+				svc: &corev1.Service{},
+			},
+			want: nil,
+		},
+		{
+			name: "ingress has annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			want: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"key1":            "value1",
+					"node.label/key2": "value.2",
+				},
+			},
+		},
+		{
+			name: "service annotation overrides ingress",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
+							},
+						},
+					},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-node-labels": "service/key1=value1.service, service.node.label/key2=value.2.service",
+						},
+					},
+				},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			want: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"service/key1":            "value1.service",
+					"service.node.label/key2": "value.2.service",
+				},
+			},
+		},
+		{
+			name: "target type ip",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-node-labels": "key1=value1, node.label/key2=value.2",
+							},
+						},
+					},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/target-node-labels": "service/key1=value1.service, service.node.label/key2=value.2.service",
+						},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: nil,
+		},
+		{
+			name: "annotation parse error",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-node-labels": "key1",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			wantErr: errors.New("failed to parse stringMap annotation, alb.ingress.kubernetes.io/target-node-labels: key1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupBindingNodeSelector(context.Background(), tt.args.ing, tt.args.svc, tt.args.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildLambdaTargetGroup(t *testing.T) {
+	type args struct {
+		ing               ClassifiedIngress
+		lambdaFunctionARN string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantResID string
+		want      elbv2model.TargetGroupSpec
+		wantErr   error
+	}{
+		{
+			name: "lambda function",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "ing-1",
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/target-group-attributes": "lambda.multi_value_headers.enabled=true",
+								"alb.ingress.kubernetes.io/tags":                    "k1=v1",
+							},
+						},
+					},
+				},
+				lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:my-function",
+			},
+			wantResID: "awesome-ns/ing-1-lambda:my-function",
+			want: elbv2model.TargetGroupSpec{
+				Name:       "k8s-awesomen-myfuncti-b2d0e56ad0",
+				TargetType: elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{
+					{
+						Key:   "lambda.multi_value_headers.enabled",
+						Value: "true",
+					},
+				},
+				Tags:              map[string]string{"k1": "v1"},
+				LambdaFunctionARN: awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function"),
+			},
+		},
+		{
+			name: "lambda function with alias",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "ing-1",
+						},
+					},
+				},
+				lambdaFunctionARN: "arn:aws:lambda:us-west-2:123456789012:function:my-function:live",
+			},
+			wantResID: "awesome-ns/ing-1-lambda:my-function:live",
+			want: elbv2model.TargetGroupSpec{
+				Name:                  "k8s-awesomen-myfuncti-e38a1d674d",
+				TargetType:            elbv2model.TargetTypeLambda,
+				TargetGroupAttributes: []elbv2model.TargetGroupAttribute{},
+				Tags:                  map[string]string{},
+				LambdaFunctionARN:     awssdk.String("arn:aws:lambda:us-west-2:123456789012:function:my-function:live"),
+			},
+		},
+		{
+			name: "invalid lambda function ARN",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "awesome-ns",
+							Name:      "ing-1",
+						},
+					},
+				},
+				lambdaFunctionARN: "arn:aws:s3:::my-bucket",
+			},
+			wantErr: errors.New("invalid lambdaFunctionARN: arn:aws:s3:::my-bucket"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := core.NewDefaultStack(core.StackID{Name: "awesome-group"})
+			task := &defaultModelBuildTask{
+				clusterName:          "cluster-name",
+				ingGroup:             Group{ID: GroupID{Name: "awesome-group"}},
+				annotationParser:     annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				stack:                stack,
+				tgByResID:            make(map[string]*elbv2model.TargetGroup),
+				resourceNameTemplate: "k8s-{namespace}-{name}-{hash}",
+			}
+			got, err := task.buildLambdaTargetGroup(context.Background(), tt.args.ing, tt.args.lambdaFunctionARN)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantResID, got.ID())
+				assert.Equal(t, tt.want, got.Spec)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupBindingExternalTargets(t *testing.T) {
+	type args struct {
+		ing        ClassifiedIngress
+		svc        *corev1.Service
+		targetType elbv2model.TargetType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []elbv2api.ExternalTarget
+		wantErr error
+	}{
+		{
+			name: "no annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: nil,
+		},
+		{
+			name: "service has annotation",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{},
+				},
+				svc: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/external-targets": "10.100.0.1:8080, [2600:1f14:f8c:2701::1]:443",
+						},
+					},
+				},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			want: []elbv2api.ExternalTarget{
+				{IP: "10.100.0.1", Port: 8080},
+				{IP: "2600:1f14:f8c:2701::1", Port: 443},
+			},
+		},
+		{
+			name: "target type instance",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/external-targets": "10.100.0.1:8080",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeInstance,
+			},
+			wantErr: errors.New("externalTargets is only supported for ip targetType, got instance"),
+		},
+		{
+			name: "invalid IP",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/external-targets": "vm.example.com:8080",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("invalid IP in externalTarget: vm.example.com:8080"),
+		},
+		{
+			name: "invalid port",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/external-targets": "10.100.0.1:70000",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("invalid port in externalTarget: 10.100.0.1:70000"),
+		},
+		{
+			name: "missing port",
+			args: args{
+				ing: ClassifiedIngress{
+					Ing: &networking.Ingress{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"alb.ingress.kubernetes.io/external-targets": "10.100.0.1",
+							},
+						},
+					},
+				},
+				svc:        &corev1.Service{},
+				targetType: elbv2model.TargetTypeIP,
+			},
+			wantErr: errors.New("failed to parse externalTarget: 10.100.0.1: address 10.100.0.1: missing port in address"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &defaultModelBuildTask{
+				annotationParser: annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+			}
+			got, err := task.buildTargetGroupBindingExternalTargets(context.Background(), tt.args.ing, tt.args.svc, tt.args.targetType)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupTargetTypeForFargateBackend(t *testing.T) {
+	fargateNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fargate-ip-192-168-138-30.us-west-2.compute.internal",
+			Labels: map[string]string{
+				"eks.amazonaws.com/compute-type": "fargate",
+			},
+		},
+	}
+	ec2Node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ip-192-168-1-1.us-west-2.compute.internal",
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+	}
+	buildEndpoints := func(nodeNames ...string) *corev1.Endpoints {
+		var addresses []corev1.EndpointAddress
+		for i, nodeName := range nodeNames {
+			addresses = append(addresses, corev1.EndpointAddress{
+				IP:       fmt.Sprintf("192.168.0.%d", i+1),
+				NodeName: awssdk.String(nodeName),
+			})
+		}
+		return &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "awesome-svc",
+			},
+			Subsets: []corev1.EndpointSubset{
+				{
+					Addresses: addresses,
+				},
+			},
+		}
+	}
+	buildEndpointSlice := func(nodeNames ...string) *discv1.EndpointSlice {
+		var endpoints []discv1.Endpoint
+		for i, nodeName := range nodeNames {
+			endpoints = append(endpoints, discv1.Endpoint{
+				Addresses: []string{fmt.Sprintf("192.168.0.%d", i+1)},
+				Topology:  map[string]string{corev1.LabelHostname: nodeName},
+			})
+		}
+		return &discv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      "awesome-svc-abcde",
+				Labels:    map[string]string{discv1.LabelServiceName: "awesome-svc"},
+			},
+			AddressType: discv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+		}
+	}
+	buildNameForTargetType := func(targetType elbv2model.TargetType) string {
+		return "k8s-awesome-tg-" + string(targetType)
+	}
+	buildTGB := func(targetType elbv2model.TargetType) *elbv2api.TargetGroupBinding {
+		return &elbv2api.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      buildNameForTargetType(targetType),
+			},
+		}
+	}
+	type env struct {
+		nodes          []*corev1.Node
+		endpoints      []*corev1.Endpoints
+		endpointSlices []*discv1.EndpointSlice
+		tgbs           []*elbv2api.TargetGroupBinding
+	}
+	type fields struct {
+		enableFargateTargetTypeFallback bool
+		enableEndpointSlices            bool
+	}
+	tests := []struct {
+		name       string
+		env        env
+		fields     fields
+		targetType elbv2model.TargetType
+		want       elbv2model.TargetType
+		wantEvents int
+	}{
+		{
+			name: "ip targetType is kept",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeIP,
+			want:       elbv2model.TargetTypeIP,
+		},
+		{
+			name: "instance targetType without endpoints is kept",
+			env: env{
+				nodes: []*corev1.Node{fargateNode},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+		},
+		{
+			name: "instance targetType with pods on EC2 and Fargate is kept",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode, ec2Node},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name, ec2Node.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+		},
+		{
+			name: "instance targetType with pods on Fargate, fallback disabled",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: false,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+			wantEvents: 1,
+		},
+		{
+			name: "instance targetType with pods on Fargate, fallback enabled",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeIP,
+			wantEvents: 1,
+		},
+		{
+			name: "instance targetType with pods on Fargate, fallback enabled, existing ip targetGroup is kept",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+				tgbs:      []*elbv2api.TargetGroupBinding{buildTGB(elbv2model.TargetTypeIP)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeIP,
+		},
+		{
+			name: "instance targetType with pods moved to EC2, fallback enabled, existing ip targetGroup is kept",
+			env: env{
+				nodes:     []*corev1.Node{ec2Node},
+				endpoints: []*corev1.Endpoints{buildEndpoints(ec2Node.Name)},
+				tgbs:      []*elbv2api.TargetGroupBinding{buildTGB(elbv2model.TargetTypeIP)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeIP,
+		},
+		{
+			name: "instance targetType with pods moved to Fargate, fallback enabled, existing instance targetGroup is kept",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+				tgbs:      []*elbv2api.TargetGroupBinding{buildTGB(elbv2model.TargetTypeInstance)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+		},
+		{
+			name: "instance targetType with pods on Fargate, fallback disabled, existing ip targetGroup is ignored",
+			env: env{
+				nodes:     []*corev1.Node{fargateNode},
+				endpoints: []*corev1.Endpoints{buildEndpoints(fargateNode.Name)},
+				tgbs:      []*elbv2api.TargetGroupBinding{buildTGB(elbv2model.TargetTypeIP)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: false,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+			wantEvents: 1,
+		},
+		{
+			name: "instance targetType with pods on Fargate from endpointSlices, fallback enabled",
+			env: env{
+				nodes:          []*corev1.Node{fargateNode},
+				endpointSlices: []*discv1.EndpointSlice{buildEndpointSlice(fargateNode.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+				enableEndpointSlices:            true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeIP,
+			wantEvents: 1,
+		},
+		{
+			name: "instance targetType with pods on EC2 and Fargate from endpointSlices is kept",
+			env: env{
+				nodes:          []*corev1.Node{fargateNode, ec2Node},
+				endpointSlices: []*discv1.EndpointSlice{buildEndpointSlice(fargateNode.Name, ec2Node.Name)},
+			},
+			fields: fields{
+				enableFargateTargetTypeFallback: true,
+				enableEndpointSlices:            true,
+			},
+			targetType: elbv2model.TargetTypeInstance,
+			want:       elbv2model.TargetTypeInstance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, node := range tt.env.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+			for _, eps := range tt.env.endpoints {
+				assert.NoError(t, k8sClient.Create(ctx, eps.DeepCopy()))
+			}
+			for _, epSlice := range tt.env.endpointSlices {
+				assert.NoError(t, k8sClient.Create(ctx, epSlice.DeepCopy()))
+			}
+			for _, tgb := range tt.env.tgbs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				k8sClient:                       k8sClient,
+				eventRecorder:                   eventRecorder,
+				enableFargateTargetTypeFallback: tt.fields.enableFargateTargetTypeFallback,
+				enableEndpointSlices:            tt.fields.enableEndpointSlices,
+			}
+			ing := ClassifiedIngress{
+				Ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "awesome-ns",
+						Name:      "awesome-ing",
+					},
+				},
+			}
+			got, err := task.buildTargetGroupTargetTypeForFargateBackend(ctx, ing, svc, tt.targetType, buildNameForTargetType)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantEvents, len(eventRecorder.Events))
+		})
+	}
+}
+
+func Test_defaultModelBuildTask_buildTargetGroupHealthCheckConfigForRollout(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "awesome-svc",
+		},
+	}
+	tgName := "k8s-awesomen-awesomes-5e8a0c1a1c"
+	buildTGB := func(annotations map[string]string) *elbv2api.TargetGroupBinding {
+		return &elbv2api.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        tgName,
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name                        string
+		tgbs                        []*elbv2api.TargetGroupBinding
+		unhealthyThresholdCount     int64
+		intervalSeconds             int64
+		wantUnhealthyThresholdCount int64
+		wantIntervalSeconds         int64
+	}{
+		{
+			name:                        "targetGroupBinding doesn't exist yet",
+			unhealthyThresholdCount:     2,
+			intervalSeconds:             15,
+			wantUnhealthyThresholdCount: 2,
+			wantIntervalSeconds:         15,
+		},
+		{
+			name:                        "health check isn't relaxed",
+			tgbs:                        []*elbv2api.TargetGroupBinding{buildTGB(nil)},
+			unhealthyThresholdCount:     2,
+			intervalSeconds:             15,
+			wantUnhealthyThresholdCount: 2,
+			wantIntervalSeconds:         15,
+		},
+		{
+			name: "health check is relaxed",
+			tgbs: []*elbv2api.TargetGroupBinding{buildTGB(map[string]string{
+				"elbv2.k8s.aws/health-check-relaxed": "true",
+			})},
+			unhealthyThresholdCount:     2,
+			intervalSeconds:             15,
+			wantUnhealthyThresholdCount: 10,
+			wantIntervalSeconds:         30,
+		},
+		{
+			name: "health check is relaxed, lenient thresholds are kept",
+			tgbs: []*elbv2api.TargetGroupBinding{buildTGB(map[string]string{
+				"elbv2.k8s.aws/health-check-relaxed": "true",
+			})},
+			unhealthyThresholdCount:     10,
+			intervalSeconds:             60,
+			wantUnhealthyThresholdCount: 10,
+			wantIntervalSeconds:         60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			for _, tgb := range tt.tgbs {
+				assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			}
+			task := &defaultModelBuildTask{
+				k8sClient: k8sClient,
+			}
+			healthCheckConfig := elbv2model.TargetGroupHealthCheckConfig{
+				UnhealthyThresholdCount: awssdk.Int64(tt.unhealthyThresholdCount),
+				IntervalSeconds:         awssdk.Int64(tt.intervalSeconds),
+			}
+			err := task.buildTargetGroupHealthCheckConfigForRollout(ctx, svc, tgName, &healthCheckConfig)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnhealthyThresholdCount, awssdk.Int64Value(healthCheckConfig.UnhealthyThresholdCount))
+			assert.Equal(t, tt.wantIntervalSeconds, awssdk.Int64Value(healthCheckConfig.IntervalSeconds))
+		})
+	}
+}
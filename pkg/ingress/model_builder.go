@@ -2,6 +2,7 @@ package ingress
 
 import (
 	"context"
+	"fmt"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/go-logr/logr"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	cloudwatchdeploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/cloudwatch"
 	elbv2deploy "sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/elbv2"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
@@ -21,6 +23,7 @@ import (
 	networkingpkg "sigs.k8s.io/aws-load-balancer-controller/pkg/networking"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"strconv"
+	"time"
 )
 
 const (
@@ -30,7 +33,8 @@ const (
 // ModelBuilder is responsible for build mode stack for a IngressGroup.
 type ModelBuilder interface {
 	// build mode stack for a IngressGroup.
-	Build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, error)
+	// a non-zero requeueAfter indicates the model is time-dependent and must be rebuilt after that duration.
+	Build(ctx context.Context, ingGroup Group) (stack core.Stack, lb *elbv2model.LoadBalancer, requeueAfter time.Duration, err error)
 }
 
 // NewDefaultModelBuilder constructs new defaultModelBuilder.
@@ -38,9 +42,10 @@ func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventR
 	ec2Client services.EC2, acmClient services.ACM,
 	annotationParser annotations.Parser, subnetsResolver networkingpkg.SubnetsResolver,
 	authConfigBuilder AuthConfigBuilder, enhancedBackendBuilder EnhancedBackendBuilder,
-	trackingProvider tracking.Provider, elbv2TaggingManager elbv2deploy.TaggingManager,
+	trackingProvider tracking.Provider, elbv2TaggingManager elbv2deploy.TaggingManager, tgMetricsCollector cloudwatchdeploy.TargetGroupMetricsCollector,
 	vpcID string, clusterName string, defaultTags map[string]string, externalManagedTags []string, defaultSSLPolicy string,
-	backendSGProvider networkingpkg.BackendSGProvider, enableBackendSG bool, disableRestrictedSGRules bool, logger logr.Logger) *defaultModelBuilder {
+	backendSGProvider networkingpkg.BackendSGProvider, enableBackendSG bool, disableRestrictedSGRules bool, enableFargateTargetTypeFallback bool,
+	enableEndpointSlices bool, resourceNameTemplate string, logger logr.Logger) *defaultModelBuilder {
 	certDiscovery := NewACMCertDiscovery(acmClient, logger)
 	ruleOptimizer := NewDefaultRuleOptimizer(logger)
 	return &defaultModelBuilder{
@@ -58,12 +63,17 @@ func NewDefaultModelBuilder(k8sClient client.Client, eventRecorder record.EventR
 		ruleOptimizer:            ruleOptimizer,
 		trackingProvider:         trackingProvider,
 		elbv2TaggingManager:      elbv2TaggingManager,
+		tgMetricsCollector:       tgMetricsCollector,
 		defaultTags:              defaultTags,
 		externalManagedTags:      sets.NewString(externalManagedTags...),
 		defaultSSLPolicy:         defaultSSLPolicy,
 		enableBackendSG:          enableBackendSG,
 		disableRestrictedSGRules: disableRestrictedSGRules,
 		logger:                   logger,
+
+		enableFargateTargetTypeFallback: enableFargateTargetTypeFallback,
+		enableEndpointSlices:            enableEndpointSlices,
+		resourceNameTemplate:            resourceNameTemplate,
 	}
 }
 
@@ -87,17 +97,22 @@ type defaultModelBuilder struct {
 	ruleOptimizer            RuleOptimizer
 	trackingProvider         tracking.Provider
 	elbv2TaggingManager      elbv2deploy.TaggingManager
+	tgMetricsCollector       cloudwatchdeploy.TargetGroupMetricsCollector
 	defaultTags              map[string]string
 	externalManagedTags      sets.String
 	defaultSSLPolicy         string
 	enableBackendSG          bool
 	disableRestrictedSGRules bool
 
+	enableFargateTargetTypeFallback bool
+	enableEndpointSlices            bool
+	resourceNameTemplate            string
+
 	logger logr.Logger
 }
 
 // build mode stack for a IngressGroup.
-func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, error) {
+func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.Stack, *elbv2model.LoadBalancer, time.Duration, error) {
 	stack := core.NewDefaultStack(core.StackID(ingGroup.ID))
 	task := &defaultModelBuildTask{
 		k8sClient:                b.k8sClient,
@@ -113,13 +128,19 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 		ruleOptimizer:            b.ruleOptimizer,
 		trackingProvider:         b.trackingProvider,
 		elbv2TaggingManager:      b.elbv2TaggingManager,
+		tgMetricsCollector:       b.tgMetricsCollector,
 		backendSGProvider:        b.backendSGProvider,
 		logger:                   b.logger,
 		enableBackendSG:          b.enableBackendSG,
 		disableRestrictedSGRules: b.disableRestrictedSGRules,
 
+		enableFargateTargetTypeFallback: b.enableFargateTargetTypeFallback,
+		enableEndpointSlices:            b.enableEndpointSlices,
+		resourceNameTemplate:            b.resourceNameTemplate,
+
 		ingGroup: ingGroup,
 		stack:    stack,
+		now:      time.Now(),
 
 		defaultTags:                               b.defaultTags,
 		externalManagedTags:                       b.externalManagedTags,
@@ -140,12 +161,13 @@ func (b *defaultModelBuilder) Build(ctx context.Context, ingGroup Group) (core.S
 
 		loadBalancer:    nil,
 		tgByResID:       make(map[string]*elbv2model.TargetGroup),
+		tgbByResID:      make(map[string]*elbv2model.TargetGroupBindingResource),
 		backendServices: make(map[types.NamespacedName]*corev1.Service),
 	}
 	if err := task.run(ctx); err != nil {
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
-	return task.stack, task.loadBalancer, nil
+	return task.stack, task.loadBalancer, task.requeueAfter, nil
 }
 
 // the default model build task
@@ -164,15 +186,21 @@ type defaultModelBuildTask struct {
 	ruleOptimizer          RuleOptimizer
 	trackingProvider       tracking.Provider
 	elbv2TaggingManager    elbv2deploy.TaggingManager
+	tgMetricsCollector     cloudwatchdeploy.TargetGroupMetricsCollector
 	logger                 logr.Logger
 
 	ingGroup                 Group
 	sslRedirectConfig        *SSLRedirectConfig
 	stack                    core.Stack
+	now                      time.Time
 	backendSGIDToken         core.StringToken
 	enableBackendSG          bool
 	disableRestrictedSGRules bool
 
+	enableFargateTargetTypeFallback bool
+	enableEndpointSlices            bool
+	resourceNameTemplate            string
+
 	defaultTags                               map[string]string
 	externalManagedTags                       sets.String
 	defaultIPAddressType                      elbv2model.IPAddressType
@@ -192,7 +220,9 @@ type defaultModelBuildTask struct {
 
 	loadBalancer    *elbv2model.LoadBalancer
 	tgByResID       map[string]*elbv2model.TargetGroup
+	tgbByResID      map[string]*elbv2model.TargetGroupBindingResource
 	backendServices map[types.NamespacedName]*corev1.Service
+	requeueAfter    time.Duration
 }
 
 func (t *defaultModelBuildTask) run(ctx context.Context) error {
@@ -208,7 +238,11 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 		}
 	}
 	if len(t.ingGroup.Members) == 0 {
-		return nil
+		t.buildInactiveExistingLoadBalancers(ctx, "")
+		return t.buildRoute53RecordSetGroups(ctx, nil)
+	}
+	if err := t.expandHostTemplates(ctx); err != nil {
+		return err
 	}
 
 	ingListByPort := make(map[int64][]ClassifiedIngress)
@@ -263,6 +297,9 @@ func (t *defaultModelBuildTask) run(ctx context.Context) error {
 	if err := t.buildLoadBalancerAddOns(ctx, lb.LoadBalancerARN()); err != nil {
 		return err
 	}
+	if err := t.buildRoute53RecordSetGroups(ctx, lb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -347,9 +384,18 @@ func (t *defaultModelBuildTask) buildSSLRedirectConfig(ctx context.Context, list
 		if err != nil {
 			return nil, errors.Wrapf(err, "ingress: %v", k8s.NamespacedName(member.Ing))
 		}
-		if exists {
-			explicitSSLRedirectPorts.Insert(rawSSLRedirectPort)
+		if !exists {
+			continue
+		}
+		if listenPortConfig, ok := listenPortConfigByPort[rawSSLRedirectPort]; (!ok || listenPortConfig.protocol != elbv2model.ProtocolHTTPS) &&
+			annotations.IsTranslatedAnnotation(t.annotationParser, annotations.IngressSuffixSSLRedirect, member.Ing.Annotations) {
+			// ssl-redirect translated from annotations of other Ingress controllers assumes an HTTPS:443 listener,
+			// it's ignored instead of failing the whole IngressGroup when the Ingress doesn't listen on it.
+			t.eventRecorder.Event(member.Ing, corev1.EventTypeWarning, k8s.IngressEventReasonIgnoredCompatibilityAnnotation,
+				fmt.Sprintf("Ignored SSL redirect translated from compatibility annotations, HTTPS listener does not exist for port: %v", rawSSLRedirectPort))
+			continue
 		}
+		explicitSSLRedirectPorts.Insert(rawSSLRedirectPort)
 	}
 
 	if len(explicitSSLRedirectPorts) == 0 {
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy"
@@ -3846,6 +3847,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 			ctx := context.Background()
 			k8sSchema := runtime.NewScheme()
 			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 			for _, svc := range tt.env.svcs {
 				assert.NoError(t, k8sClient.Create(ctx, svc.DeepCopy()))
@@ -3865,7 +3867,7 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 			certDiscovery := NewMockCertDiscovery(ctrl)
 			annotationParser := annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io")
 			authConfigBuilder := NewDefaultAuthConfigBuilder(annotationParser)
-			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder)
+			enhancedBackendBuilder := NewDefaultEnhancedBackendBuilder(k8sClient, annotationParser, authConfigBuilder, "")
 			ruleOptimizer := NewDefaultRuleOptimizer(&log.NullLogger{})
 			trackingProvider := tracking.NewDefaultProvider("ingress.k8s.aws", clusterName)
 			stackMarshaller := deploy.NewDefaultStackMarshaller()
@@ -3897,10 +3899,11 @@ func Test_defaultModelBuilder_Build(t *testing.T) {
 				enableBackendSG:        tt.fields.enableBackendSG,
 				logger:                 &log.NullLogger{},
 
-				defaultSSLPolicy: "ELBSecurityPolicy-2016-08",
+				defaultSSLPolicy:     "ELBSecurityPolicy-2016-08",
+				resourceNameTemplate: "k8s-{namespace}-{name}-{hash}",
 			}
 
-			gotStack, _, err := b.Build(context.Background(), tt.args.ingGroup)
+			gotStack, _, _, err := b.Build(context.Background(), tt.args.ingGroup)
 			if tt.wantErr != nil {
 				assert.EqualError(t, err, tt.wantErr.Error())
 			} else {
@@ -4526,3 +4529,81 @@ func Test_defaultModelBuildTask_buildSSLRedirectConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultModelBuildTask_buildSSLRedirectConfig_compatibilityAnnotations(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ingAnnotations         map[string]string
+		listenPortConfigByPort map[int64]listenPortConfig
+		want                   *SSLRedirectConfig
+		wantEvents             int
+		wantErr                error
+	}{
+		{
+			name: "translated ssl-redirect with HTTPS:443 listener",
+			ingAnnotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80:  {protocol: elbv2model.ProtocolHTTP},
+				443: {protocol: elbv2model.ProtocolHTTPS},
+			},
+			want: &SSLRedirectConfig{
+				SSLPort:    443,
+				StatusCode: "HTTP_301",
+			},
+		},
+		{
+			name: "translated ssl-redirect without HTTPS:443 listener is ignored",
+			ingAnnotations: map[string]string{
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80: {protocol: elbv2model.ProtocolHTTP},
+			},
+			want:       nil,
+			wantEvents: 1,
+		},
+		{
+			name: "native ssl-redirect without HTTPS listener is rejected",
+			ingAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-redirect":         "443",
+				"nginx.ingress.kubernetes.io/force-ssl-redirect": "true",
+			},
+			listenPortConfigByPort: map[int64]listenPortConfig{
+				80: {protocol: elbv2model.ProtocolHTTP},
+			},
+			wantErr: errors.New("listener does not exist for SSLRedirect port: 443"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotationParser := annotations.NewCompatibilityAnnotationParser(annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"), "alb.ingress.kubernetes.io")
+			eventRecorder := record.NewFakeRecorder(10)
+			task := &defaultModelBuildTask{
+				annotationParser: annotationParser,
+				eventRecorder:    eventRecorder,
+				ingGroup: Group{
+					ID: GroupID{Namespace: "ns-1", Name: "ing-1"},
+					Members: []ClassifiedIngress{
+						{
+							Ing: &networking.Ingress{ObjectMeta: metav1.ObjectMeta{
+								Namespace:   "ns-1",
+								Name:        "ing-1",
+								Annotations: tt.ingAnnotations,
+							}},
+						},
+					},
+				},
+			}
+			got, err := task.buildSSLRedirectConfig(context.Background(), tt.listenPortConfigByPort)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Len(t, eventRecorder.Events, tt.wantEvents)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"context"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AccessReviewer reviews whether users are authorized to perform actions via SubjectAccessReview.
+type AccessReviewer interface {
+	// Review returns whether user is authorized to perform the action described by resourceAttributes,
+	// along with the reason from the authorizer if any.
+	Review(ctx context.Context, user authenticationv1.UserInfo, resourceAttributes authorizationv1.ResourceAttributes) (bool, string, error)
+}
+
+// NewDefaultAccessReviewer constructs new defaultAccessReviewer.
+func NewDefaultAccessReviewer(k8sClient client.Client) *defaultAccessReviewer {
+	return &defaultAccessReviewer{
+		k8sClient: k8sClient,
+	}
+}
+
+var _ AccessReviewer = &defaultAccessReviewer{}
+
+// default implementation for AccessReviewer.
+type defaultAccessReviewer struct {
+	k8sClient client.Client
+}
+
+func (r *defaultAccessReviewer) Review(ctx context.Context, user authenticationv1.UserInfo, resourceAttributes authorizationv1.ResourceAttributes) (bool, string, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &resourceAttributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+	if err := r.k8sClient.Create(ctx, sar); err != nil {
+		return false, "", err
+	}
+	return sar.Status.Allowed, sar.Status.Reason, nil
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/k8s (interfaces: AccessReviewer)
+
+// Package k8s is a generated GoMock package.
+package k8s
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/authentication/v1"
+	v10 "k8s.io/api/authorization/v1"
+)
+
+// MockAccessReviewer is a mock of AccessReviewer interface.
+type MockAccessReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessReviewerMockRecorder
+}
+
+// MockAccessReviewerMockRecorder is the mock recorder for MockAccessReviewer.
+type MockAccessReviewerMockRecorder struct {
+	mock *MockAccessReviewer
+}
+
+// NewMockAccessReviewer creates a new mock instance.
+func NewMockAccessReviewer(ctrl *gomock.Controller) *MockAccessReviewer {
+	mock := &MockAccessReviewer{ctrl: ctrl}
+	mock.recorder = &MockAccessReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessReviewer) EXPECT() *MockAccessReviewerMockRecorder {
+	return m.recorder
+}
+
+// Review mocks base method.
+func (m *MockAccessReviewer) Review(arg0 context.Context, arg1 v1.UserInfo, arg2 v10.ResourceAttributes) (bool, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockAccessReviewerMockRecorder) Review(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockAccessReviewer)(nil).Review), arg0, arg1, arg2)
+}
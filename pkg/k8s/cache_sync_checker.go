@@ -0,0 +1,28 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// CacheSyncWaiter waits for informers to be synced, which is implemented by controller-runtime's cache.
+type CacheSyncWaiter interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// NewCacheSyncChecker constructs a health checker that reports whether informers of cache are synced.
+// the check fails if informers are not synced within timeout, so that probes don't hang on a cache that is still starting.
+func NewCacheSyncChecker(cache CacheSyncWaiter, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(ctx) {
+			return errors.New("informers not synced")
+		}
+		return nil
+	}
+}
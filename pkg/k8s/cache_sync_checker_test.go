@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCacheSyncWaiter struct {
+	synced bool
+}
+
+func (w *fakeCacheSyncWaiter) WaitForCacheSync(ctx context.Context) bool {
+	if w.synced {
+		return true
+	}
+	<-ctx.Done()
+	return false
+}
+
+func Test_NewCacheSyncChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		synced  bool
+		wantErr string
+	}{
+		{
+			name:   "informers synced",
+			synced: true,
+		},
+		{
+			name:    "informers not synced within timeout",
+			synced:  false,
+			wantErr: "informers not synced",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewCacheSyncChecker(&fakeCacheSyncWaiter{synced: tt.synced}, 10*time.Millisecond)
+			err := checker(httptest.NewRequest("GET", "/readyz", nil))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
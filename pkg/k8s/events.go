@@ -2,14 +2,22 @@ package k8s
 
 const (
 	// Ingress events
-	IngressEventReasonConflictingIngressClass = "ConflictingIngressClass"
-	IngressEventReasonFailedLoadGroupID       = "FailedLoadGroupID"
-	IngressEventReasonFailedAddFinalizer      = "FailedAddFinalizer"
-	IngressEventReasonFailedRemoveFinalizer   = "FailedRemoveFinalizer"
-	IngressEventReasonFailedUpdateStatus      = "FailedUpdateStatus"
-	IngressEventReasonFailedBuildModel        = "FailedBuildModel"
-	IngressEventReasonFailedDeployModel       = "FailedDeployModel"
-	IngressEventReasonSuccessfullyReconciled  = "SuccessfullyReconciled"
+	IngressEventReasonConflictingIngressClass        = "ConflictingIngressClass"
+	IngressEventReasonFailedLoadGroupID              = "FailedLoadGroupID"
+	IngressEventReasonFailedAddFinalizer             = "FailedAddFinalizer"
+	IngressEventReasonFailedRemoveFinalizer          = "FailedRemoveFinalizer"
+	IngressEventReasonFailedUpdateStatus             = "FailedUpdateStatus"
+	IngressEventReasonFailedUpdateInventory          = "FailedUpdateInventory"
+	IngressEventReasonFailedBuildModel               = "FailedBuildModel"
+	IngressEventReasonUnknownAnnotations             = "UnknownAnnotations"
+	IngressEventReasonDriftDetected                  = "DriftDetected"
+	IngressEventReasonFargateTargetType              = "FargateTargetType"
+	IngressEventReasonCanaryRollback                 = "CanaryRollback"
+	IngressEventReasonFailedCollectCanaryMetrics     = "FailedCollectCanaryMetrics"
+	IngressEventReasonIgnoredCompatibilityAnnotation = "IgnoredCompatibilityAnnotation"
+	IngressEventReasonFailedPublishMetricsDimensions = "FailedPublishMetricsDimensions"
+	IngressEventReasonFailedDeployModel              = "FailedDeployModel"
+	IngressEventReasonSuccessfullyReconciled         = "SuccessfullyReconciled"
 
 	// Service events
 	ServiceEventReasonFailedAddFinalizer     = "FailedAddFinalizer"
@@ -27,4 +35,8 @@ const (
 	TargetGroupBindingEventReasonFailedCleanup          = "FailedCleanup"
 	TargetGroupBindingEventReasonBackendNotFound        = "BackendNotFound"
 	TargetGroupBindingEventReasonSuccessfullyReconciled = "SuccessfullyReconciled"
+	TargetGroupBindingEventReasonHealthCheckRelaxed     = "HealthCheckRelaxed"
+	TargetGroupBindingEventReasonHealthCheckRestored    = "HealthCheckRestored"
+	TargetGroupBindingEventReasonTargetPortChanged      = "TargetPortChanged"
+	TargetGroupBindingEventReasonCustomEndpointsIgnored = "CustomEndpointsIgnored"
 )
@@ -9,6 +9,9 @@ import (
 
 const (
 	toBeDeletedByCATaint = "ToBeDeletedByClusterAutoscaler"
+
+	labelEKSComputeType   = "eks.amazonaws.com/compute-type"
+	eksComputeTypeFargate = "fargate"
 )
 
 var awsInstanceIDRegex = regexp.MustCompile("^i-[^/]*$")
@@ -33,6 +36,11 @@ func IsNodeSuitableAsTrafficProxy(node *corev1.Node) bool {
 	return IsNodeReady(node)
 }
 
+// IsFargateNode returns whether node is an EKS Fargate node, which isn't backed by an EC2 instance.
+func IsFargateNode(node *corev1.Node) bool {
+	return node.Labels[labelEKSComputeType] == eksComputeTypeFargate
+}
+
 // GetNodeCondition will get pointer to Node's existing condition.
 // returns nil if no matching condition found.
 func GetNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
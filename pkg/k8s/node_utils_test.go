@@ -131,6 +131,49 @@ func TestIsNodeSuitableForTraffic(t *testing.T) {
 	}
 }
 
+func TestIsFargateNode(t *testing.T) {
+	type args struct {
+		node *corev1.Node
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "node by EKS Fargate",
+			args: args{
+				node: &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fargate-ip-192-168-138-30.us-west-2.compute.internal",
+						Labels: map[string]string{
+							"eks.amazonaws.com/compute-type": "fargate",
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "node by EC2 instance",
+			args: args{
+				node: &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-node-name",
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsFargateNode(tt.args.node)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestGetNodeCondition(t *testing.T) {
 	type args struct {
 		node          *corev1.Node
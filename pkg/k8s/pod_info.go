@@ -2,6 +2,8 @@ package k8s
 
 import (
 	"encoding/json"
+	"time"
+
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -10,6 +12,29 @@ import (
 
 const (
 	annotationKeyPodENIInfo = "vpc.amazonaws.com/pod-eni"
+
+	// AnnotationKeyCalicoPodIP is the annotation set by Calico CNI with pod's IP in CIDR format.
+	AnnotationKeyCalicoPodIP = "cni.projectcalico.org/podIP"
+	// AnnotationKeyMultusNetworkStatus is the annotation set by Multus CNI with the status of pod's networks.
+	AnnotationKeyMultusNetworkStatus = "k8s.v1.cni.cncf.io/network-status"
+	// AnnotationKeyTargetNetwork is the annotation on pod that selects the network whose IP will be registered into target groups.
+	AnnotationKeyTargetNetwork = "elbv2.k8s.aws/target-network"
+	// AnnotationKeyDeregistrationRequestedAt is the annotation on pod that records when its eviction requested deregistration of its targets.
+	AnnotationKeyDeregistrationRequestedAt = "elbv2.k8s.aws/deregistration-requested-at"
+
+	// DeregistrationRequestGracePeriod is the duration beyond the drain timeout that an eviction is expected to take effect in.
+	// deregistration requests of pods that aren't terminating by then are considered rejected, e.g. by PodDisruptionBudgets,
+	// so that targets of these pods are registered again.
+	DeregistrationRequestGracePeriod = 1 * time.Minute
+)
+
+var (
+	// podNetworkAnnotationKeys is the pod annotations we keep in PodInfo to resolve pod IPs from CNI plugins.
+	podNetworkAnnotationKeys = []string{
+		AnnotationKeyCalicoPodIP,
+		AnnotationKeyMultusNetworkStatus,
+		AnnotationKeyTargetNetwork,
+	}
 )
 
 // PodInfo contains simplified pod information we cares about.
@@ -25,6 +50,12 @@ type PodInfo struct {
 	PodIP          string
 
 	ENIInfos []PodENIInfo
+	// NetworkAnnotations contains the subset of pod annotations that describes pod networks set by CNI plugins.
+	NetworkAnnotations map[string]string
+	// DeregistrationRequestedAt is the time the eviction of pod requested deregistration of its targets, zero if not requested.
+	DeregistrationRequestedAt time.Time
+	// Terminating is whether pod is being deleted.
+	Terminating bool
 }
 
 // PodENIInfo is a json convertible structure that stores the Branch ENI details that can be
@@ -49,6 +80,34 @@ func (i *PodInfo) HasAnyOfReadinessGates(conditionTypes []corev1.PodConditionTyp
 	return false
 }
 
+// IsDeregistrationRequested returns whether the deregistration of podInfo's targets is requested at now.
+// deregistration requests expire after drainTimeout and DeregistrationRequestGracePeriod, unless pod is terminating.
+func (i *PodInfo) IsDeregistrationRequested(now time.Time, drainTimeout time.Duration) bool {
+	if i.DeregistrationRequestedAt.IsZero() {
+		return false
+	}
+	return i.Terminating || now.Before(DeregistrationRequestExpiry(i.DeregistrationRequestedAt, drainTimeout))
+}
+
+// DeregistrationRequestExpiry returns the time the deregistration request at requestedAt expires if pod isn't terminating.
+func DeregistrationRequestExpiry(requestedAt time.Time, drainTimeout time.Duration) time.Time {
+	return requestedAt.Add(drainTimeout + DeregistrationRequestGracePeriod)
+}
+
+// GetDeregistrationRequestedAt returns the time the eviction of pod requested deregistration of its targets.
+// returns zero time if not requested or the annotation is malformed.
+func GetDeregistrationRequestedAt(pod *corev1.Pod) time.Time {
+	rawRequestedAt, ok := pod.Annotations[AnnotationKeyDeregistrationRequestedAt]
+	if !ok {
+		return time.Time{}
+	}
+	requestedAt, err := time.Parse(time.RFC3339, rawRequestedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return requestedAt
+}
+
 // IsContainersReady returns whether podInfo is ContainersReady.
 func (i *PodInfo) IsContainersReady() bool {
 	containersReadyCond, exists := i.GetPodCondition(corev1.ContainersReady)
@@ -81,8 +140,8 @@ func (i *PodInfo) LookupContainerPort(port intstr.IntOrString) (int64, error) {
 	return 0, errors.Errorf("unable to find port %s on pod %s", port.String(), i.Key)
 }
 
-// buildPodInfo will construct PodInfo for given pod.
-func buildPodInfo(pod *corev1.Pod) PodInfo {
+// BuildPodInfo will construct PodInfo for given pod.
+func BuildPodInfo(pod *corev1.Pod) PodInfo {
 	podKey := NamespacedName(pod)
 
 	var podENIInfos []PodENIInfo
@@ -105,8 +164,28 @@ func buildPodInfo(pod *corev1.Pod) PodInfo {
 		NodeName:       pod.Spec.NodeName,
 		PodIP:          pod.Status.PodIP,
 
-		ENIInfos: podENIInfos,
+		ENIInfos:           podENIInfos,
+		NetworkAnnotations: BuildPodNetworkAnnotations(pod),
+
+		DeregistrationRequestedAt: GetDeregistrationRequestedAt(pod),
+		Terminating:               !pod.DeletionTimestamp.IsZero(),
+	}
+}
+
+// BuildPodNetworkAnnotations will extract the annotations describing pod networks for given pod if any.
+func BuildPodNetworkAnnotations(pod *corev1.Pod) map[string]string {
+	var networkAnnotations map[string]string
+	for _, key := range podNetworkAnnotationKeys {
+		value, ok := pod.Annotations[key]
+		if !ok {
+			continue
+		}
+		if networkAnnotations == nil {
+			networkAnnotations = make(map[string]string)
+		}
+		networkAnnotations[key] = value
 	}
+	return networkAnnotations
 }
 
 // buildPodENIInfo will construct PodENIInfo for given pod if any.
@@ -114,6 +114,6 @@ func podInfoConversionFunc(obj interface{}) (interface{}, error) {
 	if !ok {
 		return nil, errors.New("expect pod object")
 	}
-	podInfo := buildPodInfo(pod)
+	podInfo := BuildPodInfo(pod)
 	return &podInfo, nil
 }
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"testing"
+	"time"
 )
 
 func TestPodInfo_HasAnyOfReadinessGates(t *testing.T) {
@@ -96,6 +97,47 @@ func TestPodInfo_HasAnyOfReadinessGates(t *testing.T) {
 	}
 }
 
+func TestPodInfo_IsDeregistrationRequested(t *testing.T) {
+	now := time.Date(2021, 10, 1, 0, 10, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		pod  PodInfo
+		want bool
+	}{
+		{
+			name: "deregistration not requested",
+			pod:  PodInfo{},
+			want: false,
+		},
+		{
+			name: "deregistration requested within drain timeout",
+			pod:  PodInfo{DeregistrationRequestedAt: now.Add(-2 * time.Minute)},
+			want: true,
+		},
+		{
+			name: "deregistration requested within grace period after drain timeout",
+			pod:  PodInfo{DeregistrationRequestedAt: now.Add(-5*time.Minute - 30*time.Second)},
+			want: true,
+		},
+		{
+			name: "deregistration request expired as eviction was rejected",
+			pod:  PodInfo{DeregistrationRequestedAt: now.Add(-10 * time.Minute)},
+			want: false,
+		},
+		{
+			name: "deregistration request of terminating pod never expires",
+			pod:  PodInfo{DeregistrationRequestedAt: now.Add(-10 * time.Minute), Terminating: true},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pod.IsDeregistrationRequested(now, 5*time.Minute)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestPodInfo_IsContainersReady(t *testing.T) {
 	tests := []struct {
 		name string
@@ -308,7 +350,7 @@ func TestPodInfo_LookupContainerPort(t *testing.T) {
 	}
 }
 
-func Test_buildPodInfo(t *testing.T) {
+func Test_BuildPodInfo(t *testing.T) {
 	type args struct {
 		pod *corev1.Pod
 	}
@@ -518,10 +560,34 @@ func Test_buildPodInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pod with deregistration requested",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "my-ns",
+						Name:      "pod-1",
+						UID:       "pod-uuid",
+						Annotations: map[string]string{
+							"elbv2.k8s.aws/deregistration-requested-at": "2021-10-01T00:00:00Z",
+						},
+					},
+					Status: corev1.PodStatus{
+						PodIP: "192.168.1.1",
+					},
+				},
+			},
+			want: PodInfo{
+				Key:                       types.NamespacedName{Namespace: "my-ns", Name: "pod-1"},
+				UID:                       "pod-uuid",
+				PodIP:                     "192.168.1.1",
+				DeregistrationRequestedAt: time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildPodInfo(tt.args.pod)
+			got := BuildPodInfo(tt.args.pod)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -592,3 +658,54 @@ func Test_buildPodENIInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_BuildPodNetworkAnnotations(t *testing.T) {
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "network annotations exists",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"cni.projectcalico.org/podIP":       "192.168.1.1/32",
+							"k8s.v1.cni.cncf.io/network-status": `[{"name":"ns-1/macvlan","interface":"net1","ips":["10.1.1.1"]}]`,
+							"elbv2.k8s.aws/target-network":      "ns-1/macvlan",
+							"some-other-annotation":             "value",
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				"cni.projectcalico.org/podIP":       "192.168.1.1/32",
+				"k8s.v1.cni.cncf.io/network-status": `[{"name":"ns-1/macvlan","interface":"net1","ips":["10.1.1.1"]}]`,
+				"elbv2.k8s.aws/target-network":      "ns-1/macvlan",
+			},
+		},
+		{
+			name: "network annotations didn't exist",
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"some-other-annotation": "value",
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildPodNetworkAnnotations(tt.args.pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
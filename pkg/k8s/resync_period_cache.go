@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// NewResyncPeriodCacheBuilder constructs a cache builder that resyncs informers of specified kinds with their own resync periods,
+// informers of other kinds are resynced with the resync period from cache options.
+// resync is disabled for a kind if its resync period is zero.
+// caches for each resync period are built by newCache, which defaults to cache.New if it's nil.
+func NewResyncPeriodCacheBuilder(resyncPeriodByKind map[string]time.Duration, newCache cache.NewCacheFunc) cache.NewCacheFunc {
+	if newCache == nil {
+		newCache = cache.New
+	}
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		if opts.Scheme == nil {
+			return nil, errors.New("scheme must be specified")
+		}
+		knownKinds := sets.NewString()
+		for gvk := range opts.Scheme.AllKnownTypes() {
+			knownKinds.Insert(gvk.Kind)
+		}
+		defaultCache, err := newCache(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		cacheByKind := make(map[string]cache.Cache, len(resyncPeriodByKind))
+		for kind, resyncPeriod := range resyncPeriodByKind {
+			if !knownKinds.Has(kind) {
+				return nil, errors.Errorf("unknown kind %v", kind)
+			}
+			kindOpts := opts
+			kindOpts.Resync = &resyncPeriod
+			kindCache, err := newCache(config, kindOpts)
+			if err != nil {
+				return nil, err
+			}
+			cacheByKind[kind] = kindCache
+		}
+		return &resyncPeriodCache{
+			defaultCache: defaultCache,
+			cacheByKind:  cacheByKind,
+			scheme:       opts.Scheme,
+		}, nil
+	}
+}
+
+var _ cache.Cache = &resyncPeriodCache{}
+
+// resyncPeriodCache dispatches objects to caches by their kind.
+type resyncPeriodCache struct {
+	defaultCache cache.Cache
+	cacheByKind  map[string]cache.Cache
+	scheme       *runtime.Scheme
+}
+
+func (c *resyncPeriodCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	kindCache, err := c.cacheForObject(obj)
+	if err != nil {
+		return err
+	}
+	return kindCache.Get(ctx, key, obj)
+}
+
+func (c *resyncPeriodCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	kindCache, err := c.cacheForObject(list)
+	if err != nil {
+		return err
+	}
+	return kindCache.List(ctx, list, opts...)
+}
+
+func (c *resyncPeriodCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	kindCache, err := c.cacheForObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return kindCache.GetInformer(ctx, obj)
+}
+
+func (c *resyncPeriodCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	return c.cacheForKind(gvk.Kind).GetInformerForKind(ctx, gvk)
+}
+
+func (c *resyncPeriodCache) Start(ctx context.Context) error {
+	caches := c.allCaches()
+	errCh := make(chan error, len(caches))
+	for _, kindCache := range caches {
+		go func(kindCache cache.Cache) {
+			errCh <- kindCache.Start(ctx)
+		}(kindCache)
+	}
+	for range caches {
+		if err := <-errCh; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *resyncPeriodCache) WaitForCacheSync(ctx context.Context) bool {
+	synced := true
+	for _, kindCache := range c.allCaches() {
+		if !kindCache.WaitForCacheSync(ctx) {
+			synced = false
+		}
+	}
+	return synced
+}
+
+func (c *resyncPeriodCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	kindCache, err := c.cacheForObject(obj)
+	if err != nil {
+		return err
+	}
+	return kindCache.IndexField(ctx, obj, field, extractValue)
+}
+
+func (c *resyncPeriodCache) cacheForObject(obj runtime.Object) (cache.Cache, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	// list objects are cached by the kind of their items.
+	if _, isList := obj.(client.ObjectList); isList {
+		return c.cacheForKind(strings.TrimSuffix(gvk.Kind, "List")), nil
+	}
+	return c.cacheForKind(gvk.Kind), nil
+}
+
+func (c *resyncPeriodCache) cacheForKind(kind string) cache.Cache {
+	if kindCache, ok := c.cacheByKind[kind]; ok {
+		return kindCache
+	}
+	return c.defaultCache
+}
+
+func (c *resyncPeriodCache) allCaches() []cache.Cache {
+	caches := make([]cache.Cache, 0, len(c.cacheByKind)+1)
+	caches = append(caches, c.defaultCache)
+	for _, kindCache := range c.cacheByKind {
+		caches = append(caches, kindCache)
+	}
+	return caches
+}
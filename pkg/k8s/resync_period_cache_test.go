@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+type namedCache struct {
+	cache.Cache
+	name string
+}
+
+func Test_resyncPeriodCache_cacheForObject(t *testing.T) {
+	defaultCache := &namedCache{name: "default"}
+	ingressCache := &namedCache{name: "ingress"}
+	endpointsCache := &namedCache{name: "endpoints"}
+	c := &resyncPeriodCache{
+		defaultCache: defaultCache,
+		cacheByKind: map[string]cache.Cache{
+			"Ingress":   ingressCache,
+			"Endpoints": endpointsCache,
+		},
+		scheme: clientgoscheme.Scheme,
+	}
+	tests := []struct {
+		name string
+		obj  runtime.Object
+		want cache.Cache
+	}{
+		{
+			name: "object of kind with resync period",
+			obj:  &networking.Ingress{},
+			want: ingressCache,
+		},
+		{
+			name: "list of kind with resync period",
+			obj:  &corev1.EndpointsList{},
+			want: endpointsCache,
+		},
+		{
+			name: "object of kind without resync period",
+			obj:  &corev1.Service{},
+			want: defaultCache,
+		},
+		{
+			name: "list of kind without resync period",
+			obj:  &corev1.ServiceList{},
+			want: defaultCache,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.cacheForObject(tt.obj)
+			assert.NoError(t, err)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"context"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TokenReviewer authenticates bearer tokens via TokenReview.
+type TokenReviewer interface {
+	// Review returns the user that token belongs to, and whether token is authenticated.
+	Review(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error)
+}
+
+// NewDefaultTokenReviewer constructs new defaultTokenReviewer.
+func NewDefaultTokenReviewer(k8sClient client.Client) *defaultTokenReviewer {
+	return &defaultTokenReviewer{
+		k8sClient: k8sClient,
+	}
+}
+
+var _ TokenReviewer = &defaultTokenReviewer{}
+
+// default implementation for TokenReviewer.
+type defaultTokenReviewer struct {
+	k8sClient client.Client
+}
+
+func (r *defaultTokenReviewer) Review(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := r.k8sClient.Create(ctx, tokenReview); err != nil {
+		return authenticationv1.UserInfo{}, false, err
+	}
+	return tokenReview.Status.User, tokenReview.Status.Authenticated, nil
+}
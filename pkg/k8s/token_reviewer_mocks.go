@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/k8s (interfaces: TokenReviewer)
+
+// Package k8s is a generated GoMock package.
+package k8s
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/authentication/v1"
+)
+
+// MockTokenReviewer is a mock of TokenReviewer interface.
+type MockTokenReviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenReviewerMockRecorder
+}
+
+// MockTokenReviewerMockRecorder is the mock recorder for MockTokenReviewer.
+type MockTokenReviewerMockRecorder struct {
+	mock *MockTokenReviewer
+}
+
+// NewMockTokenReviewer creates a new mock instance.
+func NewMockTokenReviewer(ctrl *gomock.Controller) *MockTokenReviewer {
+	mock := &MockTokenReviewer{ctrl: ctrl}
+	mock.recorder = &MockTokenReviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenReviewer) EXPECT() *MockTokenReviewerMockRecorder {
+	return m.recorder
+}
+
+// Review mocks base method.
+func (m *MockTokenReviewer) Review(arg0 context.Context, arg1 string) (v1.UserInfo, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", arg0, arg1)
+	ret0, _ := ret[0].(v1.UserInfo)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockTokenReviewerMockRecorder) Review(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockTokenReviewer)(nil).Review), arg0, arg1)
+}
@@ -0,0 +1,44 @@
+package cloudwatch
+
+import "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+
+// Dashboard represents a CloudWatch dashboard for LoadBalancer and its TargetGroups.
+type Dashboard struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of Dashboard
+	Spec DashboardSpec `json:"spec"`
+}
+
+// NewDashboard constructs new Dashboard resource.
+func NewDashboard(stack core.Stack, id string, spec DashboardSpec) *Dashboard {
+	d := &Dashboard{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::CloudWatch::Dashboard", id),
+		Spec:         spec,
+	}
+	stack.AddResource(d)
+	d.registerDependencies(stack)
+	return d
+}
+
+// register dependencies for Dashboard.
+func (d *Dashboard) registerDependencies(stack core.Stack) {
+	for _, dep := range d.Spec.LoadBalancerARN.Dependencies() {
+		stack.AddDependency(dep, d)
+	}
+	for _, tgARN := range d.Spec.TargetGroupARNs {
+		for _, dep := range tgARN.Dependencies() {
+			stack.AddDependency(dep, d)
+		}
+	}
+}
+
+// DashboardSpec defines the desired state of Dashboard.
+type DashboardSpec struct {
+	// The LoadBalancer to display metrics for.
+	LoadBalancerARN core.StringToken `json:"loadBalancerARN"`
+
+	// The TargetGroups to display metrics for.
+	// +optional
+	TargetGroupARNs []core.StringToken `json:"targetGroupARNs,omitempty"`
+}
@@ -55,6 +55,7 @@ type TargetType string
 const (
 	TargetTypeInstance TargetType = "instance"
 	TargetTypeIP       TargetType = "ip"
+	TargetTypeLambda   TargetType = "lambda"
 )
 
 type TargetGroupIPAddressType string
@@ -126,9 +127,11 @@ type TargetGroupSpec struct {
 	TargetType TargetType `json:"targetType"`
 
 	// The port on which the targets receive traffic.
+	// Not applicable for lambda target type.
 	Port int64 `json:"port"`
 
 	// The protocol to use for routing traffic to the targets.
+	// Not applicable for lambda target type.
 	Protocol Protocol `json:"protocol"`
 
 	// The target group protocol version.
@@ -150,6 +153,10 @@ type TargetGroupSpec struct {
 	// The tags.
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// [Lambda target type] The Amazon Resource Name (ARN) of the Lambda function registered as the only target.
+	// +optional
+	LambdaFunctionARN *string `json:"lambdaFunctionARN,omitempty"`
 }
 
 // TargetGroupStatus defines the observed state of TargetGroup
@@ -103,6 +103,10 @@ type TargetGroupBindingSpec struct {
 	// ipAddressType specifies whether the target group is of type IPv4 or IPv6. If unspecified, it will be automatically inferred.
 	// +optional
 	IPAddressType *elbv2api.TargetGroupIPAddressType `json:"ipAddressType,omitempty"`
+
+	// externalTargets is a list of static targets outside the cluster that will be registered into TargetGroup.
+	// +optional
+	ExternalTargets []elbv2api.ExternalTarget `json:"externalTargets,omitempty"`
 }
 
 // Template for TargetGroupBinding Custom Resource.
@@ -0,0 +1,62 @@
+package route53
+
+import "sigs.k8s.io/aws-load-balancer-controller/pkg/model/core"
+
+// RecordSetGroup represents the DNS records managed for a stack within a Route 53 hosted zone.
+type RecordSetGroup struct {
+	core.ResourceMeta `json:"-"`
+
+	// desired state of RecordSetGroup
+	Spec RecordSetGroupSpec `json:"spec"`
+}
+
+// NewRecordSetGroup constructs new RecordSetGroup resource.
+func NewRecordSetGroup(stack core.Stack, id string, spec RecordSetGroupSpec) *RecordSetGroup {
+	g := &RecordSetGroup{
+		ResourceMeta: core.NewResourceMeta(stack, "AWS::Route53::RecordSetGroup", id),
+		Spec:         spec,
+	}
+	stack.AddResource(g)
+	g.registerDependencies(stack)
+	return g
+}
+
+// register dependencies for RecordSetGroup.
+func (g *RecordSetGroup) registerDependencies(stack core.Stack) {
+	if g.Spec.AliasTarget == nil {
+		return
+	}
+	for _, dep := range g.Spec.AliasTarget.DNSName.Dependencies() {
+		stack.AddDependency(dep, g)
+	}
+	for _, dep := range g.Spec.AliasTarget.HostedZoneID.Dependencies() {
+		stack.AddDependency(dep, g)
+	}
+}
+
+// AliasTarget is the AWS resource that alias records route traffic to.
+type AliasTarget struct {
+	// The DNS name of AWS resource.
+	DNSName core.StringToken `json:"dnsName"`
+
+	// The ID of the hosted zone associated with AWS resource.
+	HostedZoneID core.StringToken `json:"hostedZoneID"`
+}
+
+// RecordSetGroupSpec defines the desired state of RecordSetGroup.
+type RecordSetGroupSpec struct {
+	// The ID of hosted zone.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// The names of alias records routing traffic to AliasTarget.
+	// +optional
+	RecordNames []string `json:"recordNames,omitempty"`
+
+	// The names of alias records previously managed for the stack, which will be deleted unless desired.
+	// +optional
+	StaleRecordNames []string `json:"staleRecordNames,omitempty"`
+
+	// The AWS resource that alias records route traffic to, required if RecordNames isn't empty.
+	// +optional
+	AliasTarget *AliasTarget `json:"aliasTarget,omitempty"`
+}
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"context"
+	"time"
+)
+
+// NewGracefulShutdownContext returns a context that carries the values of ctx, but is only cancelled after gracePeriod once ctx is done.
+// Reconciles run with contexts from the manager, which are cancelled as soon as the controller is asked to shutdown,
+// so that in-flight AWS API calls would be aborted halfway, e.g. leaving listeners created without their rules.
+// The returned cancel func must be called to release resources once the reconcile completes.
+func NewGracefulShutdownContext(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	if gracePeriod <= 0 {
+		return context.WithCancel(ctx)
+	}
+	gracefulCtx, cancel := context.WithCancel(valueOnlyContext{Context: ctx})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-gracefulCtx.Done():
+			return
+		}
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-gracefulCtx.Done():
+		}
+	}()
+	return gracefulCtx, cancel
+}
+
+// valueOnlyContext is a context that carries the values of its parent, but never gets cancelled with it.
+type valueOnlyContext struct {
+	context.Context
+}
+
+func (valueOnlyContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (valueOnlyContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valueOnlyContext) Err() error {
+	return nil
+}
@@ -0,0 +1,60 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey struct{}
+
+func TestNewGracefulShutdownContext(t *testing.T) {
+	tests := []struct {
+		name            string
+		gracePeriod     time.Duration
+		wantCancelDelay time.Duration
+	}{
+		{
+			name:            "cancelled after grace period once parent is done",
+			gracePeriod:     50 * time.Millisecond,
+			wantCancelDelay: 50 * time.Millisecond,
+		},
+		{
+			name:            "cancelled with parent when grace period is zero",
+			gracePeriod:     0,
+			wantCancelDelay: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentCtx, parentCancel := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "value"))
+			ctx, cancel := NewGracefulShutdownContext(parentCtx, tt.gracePeriod)
+			defer cancel()
+			assert.Equal(t, "value", ctx.Value(testContextKey{}))
+
+			parentCancelTime := time.Now()
+			parentCancel()
+			select {
+			case <-ctx.Done():
+			case <-time.After(tt.wantCancelDelay + time.Second):
+				t.Fatal("context isn't cancelled")
+			}
+			assert.GreaterOrEqual(t, int64(time.Since(parentCancelTime)), int64(tt.wantCancelDelay))
+			assert.Equal(t, context.Canceled, ctx.Err())
+		})
+	}
+}
+
+func TestNewGracefulShutdownContext_cancel(t *testing.T) {
+	parentCtx, parentCancel := context.WithCancel(context.Background())
+	defer parentCancel()
+	ctx, cancel := NewGracefulShutdownContext(parentCtx, time.Hour)
+	assert.NoError(t, ctx.Err())
+
+	cancel()
+	<-ctx.Done()
+	assert.Equal(t, context.Canceled, ctx.Err())
+	assert.NoError(t, parentCtx.Err())
+}
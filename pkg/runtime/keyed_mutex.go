@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// KeyedMutex provides mutual exclusion per key, so that operations on different keys can proceed concurrently.
+type KeyedMutex struct {
+	mutex sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mutex    sync.Mutex
+	refCount int
+}
+
+// NewKeyedMutex constructs new KeyedMutex.
+func NewKeyedMutex() *KeyedMutex {
+	return &KeyedMutex{
+		locks: make(map[string]*keyedLock),
+	}
+}
+
+// Lock acquires the lock for key.
+func (m *KeyedMutex) Lock(key string) {
+	m.mutex.Lock()
+	lock, exists := m.locks[key]
+	if !exists {
+		lock = &keyedLock{}
+		m.locks[key] = lock
+	}
+	lock.refCount++
+	m.mutex.Unlock()
+
+	lock.mutex.Lock()
+}
+
+// Unlock releases the lock for key.
+func (m *KeyedMutex) Unlock(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	lock, exists := m.locks[key]
+	if !exists {
+		panic("unlock of unlocked key: " + key)
+	}
+	lock.refCount--
+	if lock.refCount == 0 {
+		delete(m.locks, key)
+	}
+	lock.mutex.Unlock()
+}
+
+// LockAll acquires the locks for all keys, and returns a function to release them.
+// keys are locked in sorted order to avoid deadlocks between concurrent callers.
+func (m *KeyedMutex) LockAll(keys []string) func() {
+	sortedKeys := sets.NewString(keys...).List()
+	for _, key := range sortedKeys {
+		m.Lock(key)
+	}
+	return func() {
+		for i := len(sortedKeys) - 1; i >= 0; i-- {
+			m.Unlock(sortedKeys[i])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutex_LockAll(t *testing.T) {
+	m := NewKeyedMutex()
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	active := make(map[string]int)
+	maxActive := make(map[string]int)
+	keySets := [][]string{
+		{"ns/ing-1", "ns/ing-2"},
+		{"ns/ing-2", "ns/ing-1"},
+		{"ns/ing-2", "ns/ing-3"},
+		{"ns/ing-3"},
+	}
+	for i := 0; i < 20; i++ {
+		for _, keys := range keySets {
+			wg.Add(1)
+			go func(keys []string) {
+				defer wg.Done()
+				unlock := m.LockAll(keys)
+				defer unlock()
+				mutex.Lock()
+				for _, key := range keys {
+					active[key]++
+					if active[key] > maxActive[key] {
+						maxActive[key] = active[key]
+					}
+				}
+				mutex.Unlock()
+				time.Sleep(time.Millisecond)
+				mutex.Lock()
+				for _, key := range keys {
+					active[key]--
+				}
+				mutex.Unlock()
+			}(keys)
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, map[string]int{"ns/ing-1": 1, "ns/ing-2": 1, "ns/ing-3": 1}, maxActive)
+	assert.Empty(t, m.locks)
+}
+
+func TestKeyedMutex_independentKeys(t *testing.T) {
+	m := NewKeyedMutex()
+	m.Lock("ns/ing-1")
+	done := make(chan struct{})
+	go func() {
+		m.Lock("ns/ing-2")
+		m.Unlock("ns/ing-2")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on independent key is blocked")
+	}
+	m.Unlock("ns/ing-1")
+}
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	pprofServerShutdownTimeout = 5 * time.Second
+)
+
+// NewPprofServer constructs a manager.Runnable that serves pprof profiles on bindAddress.
+// It runs regardless of leader election, so that profiles can be collected from standby replicas as well.
+func NewPprofServer(bindAddress string, logger logr.Logger) manager.Runnable {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return &pprofServer{
+		server: &http.Server{Addr: bindAddress, Handler: mux},
+		logger: logger,
+	}
+}
+
+var _ manager.Runnable = &pprofServer{}
+var _ manager.LeaderElectionRunnable = &pprofServer{}
+
+type pprofServer struct {
+	server *http.Server
+	logger logr.Logger
+}
+
+func (s *pprofServer) Start(ctx context.Context) error {
+	errChan := make(chan error, 1)
+	go func() {
+		s.logger.Info("starting pprof server", "address", s.server.Addr)
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- errors.Wrap(err, "failed to serve pprof")
+		}
+		close(errChan)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), pprofServerShutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx)
+}
+
+func (s *pprofServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,44 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_pprofServer_Start(t *testing.T) {
+	tests := []struct {
+		name        string
+		bindAddress string
+		wantErr     bool
+	}{
+		{
+			name:        "server stops when context is done",
+			bindAddress: "127.0.0.1:0",
+		},
+		{
+			name:        "server fails to listen",
+			bindAddress: "127.0.0.1:-1",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if !tt.wantErr {
+				cancel()
+			} else {
+				defer cancel()
+			}
+			server := NewPprofServer(tt.bindAddress, &log.NullLogger{})
+			err := server.Start(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
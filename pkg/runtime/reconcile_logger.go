@@ -0,0 +1,34 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// reconcileIDContextKey is the context key for reconcileID.
+type reconcileIDContextKey struct{}
+
+// NewReconcileContext annotates logger with an unique reconcileID and keysAndValues that identifies the object being reconciled.
+// The annotated logger and reconcileID are stored into returned context, so that logs and AWS API calls made during the same reconcile can be correlated.
+func NewReconcileContext(ctx context.Context, logger logr.Logger, keysAndValues ...interface{}) (context.Context, logr.Logger) {
+	reconcileID := string(uuid.NewUUID())
+	reconcileLogger := logger.WithValues("reconcileID", reconcileID).WithValues(keysAndValues...)
+	ctx = context.WithValue(ctx, reconcileIDContextKey{}, reconcileID)
+	return logr.NewContext(ctx, reconcileLogger), reconcileLogger
+}
+
+// ReconcileIDFromContext returns the reconcileID stored in context by NewReconcileContext, or empty string if there is none.
+func ReconcileIDFromContext(ctx context.Context) string {
+	reconcileID, _ := ctx.Value(reconcileIDContextKey{}).(string)
+	return reconcileID
+}
+
+// LoggerFromContext returns the logger stored in context, or fallback if there is none.
+func LoggerFromContext(ctx context.Context, fallback logr.Logger) logr.Logger {
+	if logger := logr.FromContext(ctx); logger != nil {
+		return logger
+	}
+	return fallback
+}
@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// recordingLogger records values it's annotated with.
+type recordingLogger struct {
+	log.NullLogger
+	keysAndValues []interface{}
+}
+
+func (l *recordingLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &recordingLogger{keysAndValues: append(append([]interface{}{}, l.keysAndValues...), keysAndValues...)}
+}
+
+func TestNewReconcileContext(t *testing.T) {
+	ctx, logger := NewReconcileContext(context.Background(), &recordingLogger{}, "ingressGroup", "awesome-ns/ing-1")
+	keysAndValues := logger.(*recordingLogger).keysAndValues
+	assert.Len(t, keysAndValues, 4)
+	assert.Equal(t, "reconcileID", keysAndValues[0])
+	assert.NotEmpty(t, keysAndValues[1])
+	assert.Equal(t, []interface{}{"ingressGroup", "awesome-ns/ing-1"}, keysAndValues[2:])
+	assert.Equal(t, logger, LoggerFromContext(ctx, &log.NullLogger{}))
+	assert.Equal(t, keysAndValues[1], ReconcileIDFromContext(ctx))
+
+	_, anotherLogger := NewReconcileContext(context.Background(), &recordingLogger{}, "ingressGroup", "awesome-ns/ing-1")
+	assert.NotEqual(t, keysAndValues[1], anotherLogger.(*recordingLogger).keysAndValues[1])
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	contextLogger := &recordingLogger{keysAndValues: []interface{}{"reconcileID", "id-1"}}
+	fallbackLogger := &recordingLogger{}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want logr.Logger
+	}{
+		{
+			name: "context with logger",
+			ctx:  logr.NewContext(context.Background(), contextLogger),
+			want: contextLogger,
+		},
+		{
+			name: "context without logger",
+			ctx:  context.Background(),
+			want: fallbackLogger,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LoggerFromContext(tt.ctx, fallbackLogger)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconcileIDFromContext(t *testing.T) {
+	assert.Equal(t, "", ReconcileIDFromContext(context.Background()))
+}
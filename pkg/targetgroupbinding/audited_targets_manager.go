@@ -0,0 +1,59 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"time"
+
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+)
+
+// NewAuditedTargetsManager constructs new auditedTargetsManager.
+func NewAuditedTargetsManager(targetsManager TargetsManager, auditor RegistrationAuditor, logger logr.Logger) *auditedTargetsManager {
+	return &auditedTargetsManager{
+		TargetsManager: targetsManager,
+		auditor:        auditor,
+		logger:         logger,
+		clock:          time.Now,
+	}
+}
+
+var _ TargetsManager = &auditedTargetsManager{}
+
+// auditedTargetsManager records successful target registration and deregistration batches of the wrapped TargetsManager.
+// auditing is best-effort, failures to record audit trail are logged without failing the registration.
+type auditedTargetsManager struct {
+	TargetsManager
+	auditor RegistrationAuditor
+	logger  logr.Logger
+	clock   func() time.Time
+}
+
+func (m *auditedTargetsManager) RegisterTargets(ctx context.Context, tgARN string, targets []elbv2sdk.TargetDescription) error {
+	if err := m.TargetsManager.RegisterTargets(ctx, tgARN, targets); err != nil {
+		return err
+	}
+	m.record(ctx, tgARN, RegistrationOperationRegister, len(targets))
+	return nil
+}
+
+func (m *auditedTargetsManager) DeregisterTargets(ctx context.Context, tgARN string, targets []elbv2sdk.TargetDescription) error {
+	if err := m.TargetsManager.DeregisterTargets(ctx, tgARN, targets); err != nil {
+		return err
+	}
+	m.record(ctx, tgARN, RegistrationOperationDeregister, len(targets))
+	return nil
+}
+
+func (m *auditedTargetsManager) record(ctx context.Context, tgARN string, operation RegistrationOperation, targetCount int) {
+	batch := RegistrationBatch{
+		Time:        m.clock().UTC(),
+		Operation:   operation,
+		TargetCount: targetCount,
+		ReconcileID: runtime.ReconcileIDFromContext(ctx),
+	}
+	if err := m.auditor.Record(ctx, tgARN, batch); err != nil {
+		runtime.LoggerFromContext(ctx, m.logger).Error(err, "failed to record target registration audit trail", "arn", tgARN)
+	}
+}
@@ -0,0 +1,117 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_auditedTargetsManager_RegisterTargets(t *testing.T) {
+	now := time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC)
+	targets := []elbv2sdk.TargetDescription{
+		{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+		{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+	}
+	tests := []struct {
+		name        string
+		registerErr error
+		recordErr   error
+		wantRecord  bool
+		wantErr     error
+	}{
+		{
+			name:       "registration recorded",
+			wantRecord: true,
+		},
+		{
+			name:        "failed registration not recorded",
+			registerErr: errors.New("some error"),
+			wantErr:     errors.New("some error"),
+		},
+		{
+			name:       "failure to record doesn't fail registration",
+			recordErr:  errors.New("some audit error"),
+			wantRecord: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			targetsManager := NewMockTargetsManager(ctrl)
+			targetsManager.EXPECT().RegisterTargets(gomock.Any(), "my-tg", targets).Return(tt.registerErr)
+			auditor := NewMockRegistrationAuditor(ctrl)
+			if tt.wantRecord {
+				auditor.EXPECT().Record(gomock.Any(), "my-tg", RegistrationBatch{
+					Time:        now,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 2,
+				}).Return(tt.recordErr)
+			}
+			m := NewAuditedTargetsManager(targetsManager, auditor, &log.NullLogger{})
+			m.clock = func() time.Time { return now }
+			err := m.RegisterTargets(context.Background(), "my-tg", targets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_auditedTargetsManager_DeregisterTargets(t *testing.T) {
+	now := time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC)
+	targets := []elbv2sdk.TargetDescription{
+		{Id: awssdk.String("i-0123456789abcdef0"), Port: awssdk.Int64(30080)},
+	}
+	tests := []struct {
+		name          string
+		deregisterErr error
+		wantRecord    bool
+		wantErr       error
+	}{
+		{
+			name:       "deregistration recorded",
+			wantRecord: true,
+		},
+		{
+			name:          "failed deregistration not recorded",
+			deregisterErr: errors.New("some error"),
+			wantErr:       errors.New("some error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			targetsManager := NewMockTargetsManager(ctrl)
+			targetsManager.EXPECT().DeregisterTargets(gomock.Any(), "my-tg", targets).Return(tt.deregisterErr)
+			auditor := NewMockRegistrationAuditor(ctrl)
+			if tt.wantRecord {
+				auditor.EXPECT().Record(gomock.Any(), "my-tg", RegistrationBatch{
+					Time:        now,
+					Operation:   RegistrationOperationDeregister,
+					TargetCount: 1,
+				}).Return(nil)
+			}
+			m := NewAuditedTargetsManager(targetsManager, auditor, &log.NullLogger{})
+			m.clock = func() time.Time { return now }
+			err := m.DeregisterTargets(context.Background(), "my-tg", targets)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/deploy/tracking"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationKeyHealthCheckRelaxed is the annotation on TargetGroupBinding managed by Ingress that requests
+	// the Ingress model to relax the health check of its targetGroup.
+	AnnotationKeyHealthCheckRelaxed = "elbv2.k8s.aws/health-check-relaxed"
+	// annotationKeyOriginalHealthCheck records the health check configured on targetGroup before it's relaxed.
+	annotationKeyOriginalHealthCheck = "elbv2.k8s.aws/original-health-check"
+
+	// tag prefixes of the labels that Ingress and Service reconcile set on the TargetGroupBindings they manage.
+	ingressTagPrefix = "ingress.k8s.aws"
+	serviceTagPrefix = "service.k8s.aws"
+
+	relaxedUnhealthyThresholdCount    = 10
+	relaxedHealthCheckIntervalSeconds = 30
+)
+
+// HealthCheckAdjuster relaxes the health check of targetGroups during mass rollouts, and restores it afterwards.
+type HealthCheckAdjuster interface {
+	// Adjust relaxes or restores the health check of targetGroup for tgb based on the number of targets pending registration.
+	// returns whether the health check is relaxed after adjustment.
+	Adjust(ctx context.Context, tgb *elbv2api.TargetGroupBinding, pendingTargetCount int) (bool, error)
+}
+
+// NewDefaultHealthCheckAdjuster constructs new defaultHealthCheckAdjuster.
+func NewDefaultHealthCheckAdjuster(k8sClient client.Client, elbv2Client services.ELBV2, rolloutThreshold int,
+	eventRecorder record.EventRecorder, logger logr.Logger) *defaultHealthCheckAdjuster {
+	return &defaultHealthCheckAdjuster{
+		k8sClient:        k8sClient,
+		elbv2Client:      elbv2Client,
+		rolloutThreshold: rolloutThreshold,
+		eventRecorder:    eventRecorder,
+		logger:           logger,
+	}
+}
+
+var _ HealthCheckAdjuster = &defaultHealthCheckAdjuster{}
+
+// defaultHealthCheckAdjuster relaxes the health check once the number of targets pending registration reaches rolloutThreshold,
+// and restores it once no target is pending registration.
+//   - for TargetGroupBinding managed by Ingress, the health check is owned by the Ingress model,
+//     so relaxation is requested via annotation and applied by Ingress reconcile.
+//   - for TargetGroupBinding managed by Service, the health check isn't relaxed,
+//     since the Service model doesn't consider TargetGroupBindings and would revert it.
+//   - for standalone TargetGroupBinding, the targetGroup is modified directly, and the original health check is recorded
+//     on TargetGroupBinding, so that it's restored even if controller restarts during rollout.
+type defaultHealthCheckAdjuster struct {
+	k8sClient        client.Client
+	elbv2Client      services.ELBV2
+	rolloutThreshold int
+	eventRecorder    record.EventRecorder
+	logger           logr.Logger
+}
+
+// HealthCheckThresholds is the part of targetGroup health check that is relaxed during rollouts.
+type HealthCheckThresholds struct {
+	UnhealthyThresholdCount int64 `json:"unhealthyThresholdCount"`
+	IntervalSeconds         int64 `json:"intervalSeconds"`
+}
+
+func (a *defaultHealthCheckAdjuster) Adjust(ctx context.Context, tgb *elbv2api.TargetGroupBinding, pendingTargetCount int) (bool, error) {
+	if _, managedByIngress := tracking.StackIDFromLabels(ingressTagPrefix, tgb.Labels); managedByIngress {
+		return a.adjustViaModel(ctx, tgb, pendingTargetCount)
+	}
+	if _, managedByService := tracking.StackIDFromLabels(serviceTagPrefix, tgb.Labels); managedByService {
+		return false, nil
+	}
+	return a.adjustTargetGroup(ctx, tgb, pendingTargetCount)
+}
+
+// adjustViaModel requests the Ingress model to relax or restore the health check of targetGroup for tgb.
+func (a *defaultHealthCheckAdjuster) adjustViaModel(ctx context.Context, tgb *elbv2api.TargetGroupBinding, pendingTargetCount int) (bool, error) {
+	if !IsHealthCheckRelaxed(tgb) {
+		if pendingTargetCount < a.rolloutThreshold {
+			return false, nil
+		}
+		if err := a.updateAnnotation(ctx, tgb, AnnotationKeyHealthCheckRelaxed, awssdk.String("true")); err != nil {
+			return false, err
+		}
+		a.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonHealthCheckRelaxed,
+			fmt.Sprintf("Relaxing health check during rollout of %v targets", pendingTargetCount))
+		return true, nil
+	}
+	if pendingTargetCount > 0 {
+		return true, nil
+	}
+	if err := a.updateAnnotation(ctx, tgb, AnnotationKeyHealthCheckRelaxed, nil); err != nil {
+		return true, err
+	}
+	a.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonHealthCheckRestored,
+		"Restoring health check after rollout")
+	return false, nil
+}
+
+// adjustTargetGroup relaxes or restores the health check of targetGroup for tgb by modifying targetGroup directly.
+func (a *defaultHealthCheckAdjuster) adjustTargetGroup(ctx context.Context, tgb *elbv2api.TargetGroupBinding, pendingTargetCount int) (bool, error) {
+	rawOriginal, relaxed := tgb.Annotations[annotationKeyOriginalHealthCheck]
+	if !relaxed {
+		if pendingTargetCount < a.rolloutThreshold {
+			return false, nil
+		}
+		if err := a.relax(ctx, tgb, pendingTargetCount); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if pendingTargetCount > 0 {
+		return true, nil
+	}
+	var original HealthCheckThresholds
+	if err := json.Unmarshal([]byte(rawOriginal), &original); err != nil {
+		return true, errors.Wrapf(err, "failed to parse annotation %v", annotationKeyOriginalHealthCheck)
+	}
+	if err := a.restore(ctx, tgb, original); err != nil {
+		return true, err
+	}
+	return false, nil
+}
+
+func (a *defaultHealthCheckAdjuster) relax(ctx context.Context, tgb *elbv2api.TargetGroupBinding, pendingTargetCount int) error {
+	current, err := a.describeHealthCheckThresholds(ctx, tgb.Spec.TargetGroupARN)
+	if err != nil {
+		return err
+	}
+	// the original health check is recorded before modifying targetGroup, so that it can always be restored.
+	rawOriginal, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	if err := a.updateAnnotation(ctx, tgb, annotationKeyOriginalHealthCheck, awssdk.String(string(rawOriginal))); err != nil {
+		return err
+	}
+	relaxed := BuildRelaxedHealthCheckThresholds(current)
+	if relaxed == current {
+		return nil
+	}
+	if err := a.modifyHealthCheckThresholds(ctx, tgb.Spec.TargetGroupARN, relaxed); err != nil {
+		return err
+	}
+	a.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonHealthCheckRelaxed,
+		fmt.Sprintf("Relaxed health check during rollout of %v targets", pendingTargetCount))
+	return nil
+}
+
+func (a *defaultHealthCheckAdjuster) restore(ctx context.Context, tgb *elbv2api.TargetGroupBinding, original HealthCheckThresholds) error {
+	current, err := a.describeHealthCheckThresholds(ctx, tgb.Spec.TargetGroupARN)
+	if err != nil {
+		return err
+	}
+	// the health check is only restored if it's not changed since relaxed, e.g. by the owner of targetGroup.
+	if current == BuildRelaxedHealthCheckThresholds(original) && current != original {
+		if err := a.modifyHealthCheckThresholds(ctx, tgb.Spec.TargetGroupARN, original); err != nil {
+			return err
+		}
+		a.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonHealthCheckRestored,
+			"Restored health check after rollout")
+	} else if current != original {
+		a.logger.Info("skipped restoring targetGroup healthCheck that changed during rollout",
+			"arn", tgb.Spec.TargetGroupARN)
+	}
+	return a.updateAnnotation(ctx, tgb, annotationKeyOriginalHealthCheck, nil)
+}
+
+func (a *defaultHealthCheckAdjuster) describeHealthCheckThresholds(ctx context.Context, tgARN string) (HealthCheckThresholds, error) {
+	resp, err := a.elbv2Client.DescribeTargetGroupsWithContext(ctx, &elbv2sdk.DescribeTargetGroupsInput{
+		TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+	})
+	if err != nil {
+		return HealthCheckThresholds{}, err
+	}
+	if len(resp.TargetGroups) == 0 {
+		return HealthCheckThresholds{}, errors.Errorf("targetGroup not found: %v", tgARN)
+	}
+	sdkTG := resp.TargetGroups[0]
+	return HealthCheckThresholds{
+		UnhealthyThresholdCount: awssdk.Int64Value(sdkTG.UnhealthyThresholdCount),
+		IntervalSeconds:         awssdk.Int64Value(sdkTG.HealthCheckIntervalSeconds),
+	}, nil
+}
+
+func (a *defaultHealthCheckAdjuster) modifyHealthCheckThresholds(ctx context.Context, tgARN string, thresholds HealthCheckThresholds) error {
+	req := &elbv2sdk.ModifyTargetGroupInput{
+		TargetGroupArn:             awssdk.String(tgARN),
+		UnhealthyThresholdCount:    awssdk.Int64(thresholds.UnhealthyThresholdCount),
+		HealthCheckIntervalSeconds: awssdk.Int64(thresholds.IntervalSeconds),
+	}
+	a.logger.Info("modifying targetGroup healthCheck thresholds",
+		"arn", tgARN,
+		"unhealthyThresholdCount", thresholds.UnhealthyThresholdCount,
+		"intervalSeconds", thresholds.IntervalSeconds)
+	if _, err := a.elbv2Client.ModifyTargetGroupWithContext(ctx, req); err != nil {
+		return err
+	}
+	a.logger.Info("modified targetGroup healthCheck thresholds",
+		"arn", tgARN)
+	return nil
+}
+
+// updateAnnotation sets the annotation with key on tgb, or removes it if value is nil.
+func (a *defaultHealthCheckAdjuster) updateAnnotation(ctx context.Context, tgb *elbv2api.TargetGroupBinding, key string, value *string) error {
+	// patch a copy of tgb to not overwrite the status computed by current reconcile.
+	tgbOld := tgb.DeepCopy()
+	tgbNew := tgb.DeepCopy()
+	if value != nil {
+		if tgbNew.Annotations == nil {
+			tgbNew.Annotations = make(map[string]string)
+		}
+		tgbNew.Annotations[key] = *value
+	} else {
+		delete(tgbNew.Annotations, key)
+	}
+	if err := a.k8sClient.Patch(ctx, tgbNew, client.MergeFrom(tgbOld)); err != nil {
+		return errors.Wrapf(err, "failed to update targetGroupBinding annotation: %v", k8s.NamespacedName(tgb))
+	}
+	tgb.Annotations = tgbNew.Annotations
+	return nil
+}
+
+// IsHealthCheckRelaxed returns whether the Ingress model is requested to relax the health check of targetGroup for tgb.
+func IsHealthCheckRelaxed(tgb *elbv2api.TargetGroupBinding) bool {
+	return tgb.Annotations[AnnotationKeyHealthCheckRelaxed] == "true"
+}
+
+// BuildRelaxedHealthCheckThresholds builds the health check thresholds used during rollouts.
+// thresholds are only raised, so that health checks already more lenient than relaxed values are kept as is.
+func BuildRelaxedHealthCheckThresholds(original HealthCheckThresholds) HealthCheckThresholds {
+	relaxed := original
+	if relaxed.UnhealthyThresholdCount < relaxedUnhealthyThresholdCount {
+		relaxed.UnhealthyThresholdCount = relaxedUnhealthyThresholdCount
+	}
+	if relaxed.IntervalSeconds < relaxedHealthCheckIntervalSeconds {
+		relaxed.IntervalSeconds = relaxedHealthCheckIntervalSeconds
+	}
+	return relaxed
+}
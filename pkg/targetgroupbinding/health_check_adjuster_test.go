@@ -0,0 +1,366 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultHealthCheckAdjuster_Adjust(t *testing.T) {
+	tgARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067"
+	type describeTargetGroupsCall struct {
+		resp *elbv2sdk.DescribeTargetGroupsOutput
+		err  error
+	}
+	type modifyTargetGroupCall struct {
+		req *elbv2sdk.ModifyTargetGroupInput
+		err error
+	}
+	type args struct {
+		labels             map[string]string
+		annotations        map[string]string
+		pendingTargetCount int
+	}
+	tests := []struct {
+		name                     string
+		describeTargetGroupsCall *describeTargetGroupsCall
+		modifyTargetGroupCall    *modifyTargetGroupCall
+		args                     args
+		want                     bool
+		wantAnnotations          map[string]string
+		wantErr                  error
+	}{
+		{
+			name: "pending targets below threshold",
+			args: args{
+				pendingTargetCount: 9,
+			},
+			want: false,
+		},
+		{
+			name: "pending targets reach threshold",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2sdk.TargetGroup{
+						{
+							TargetGroupArn:             awssdk.String(tgARN),
+							UnhealthyThresholdCount:    awssdk.Int64(2),
+							HealthCheckIntervalSeconds: awssdk.Int64(15),
+						},
+					},
+				},
+			},
+			modifyTargetGroupCall: &modifyTargetGroupCall{
+				req: &elbv2sdk.ModifyTargetGroupInput{
+					TargetGroupArn:             awssdk.String(tgARN),
+					UnhealthyThresholdCount:    awssdk.Int64(10),
+					HealthCheckIntervalSeconds: awssdk.Int64(30),
+				},
+			},
+			args: args{
+				pendingTargetCount: 10,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+			},
+		},
+		{
+			name: "pending targets reach threshold, health check already lenient",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2sdk.TargetGroup{
+						{
+							TargetGroupArn:             awssdk.String(tgARN),
+							UnhealthyThresholdCount:    awssdk.Int64(10),
+							HealthCheckIntervalSeconds: awssdk.Int64(60),
+						},
+					},
+				},
+			},
+			args: args{
+				pendingTargetCount: 10,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":10,"intervalSeconds":60}`,
+			},
+		},
+		{
+			name: "pending targets reach threshold, failed to modify targetGroup",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2sdk.TargetGroup{
+						{
+							TargetGroupArn:             awssdk.String(tgARN),
+							UnhealthyThresholdCount:    awssdk.Int64(2),
+							HealthCheckIntervalSeconds: awssdk.Int64(15),
+						},
+					},
+				},
+			},
+			modifyTargetGroupCall: &modifyTargetGroupCall{
+				req: &elbv2sdk.ModifyTargetGroupInput{
+					TargetGroupArn:             awssdk.String(tgARN),
+					UnhealthyThresholdCount:    awssdk.Int64(10),
+					HealthCheckIntervalSeconds: awssdk.Int64(30),
+				},
+				err: errors.New("some error"),
+			},
+			args: args{
+				pendingTargetCount: 10,
+			},
+			want: false,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+			},
+			wantErr: errors.New("some error"),
+		},
+		{
+			name: "relaxed, targets still pending",
+			args: args{
+				annotations: map[string]string{
+					annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+				},
+				pendingTargetCount: 1,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+			},
+		},
+		{
+			name: "relaxed, rollout completed",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2sdk.TargetGroup{
+						{
+							TargetGroupArn:             awssdk.String(tgARN),
+							UnhealthyThresholdCount:    awssdk.Int64(10),
+							HealthCheckIntervalSeconds: awssdk.Int64(30),
+						},
+					},
+				},
+			},
+			modifyTargetGroupCall: &modifyTargetGroupCall{
+				req: &elbv2sdk.ModifyTargetGroupInput{
+					TargetGroupArn:             awssdk.String(tgARN),
+					UnhealthyThresholdCount:    awssdk.Int64(2),
+					HealthCheckIntervalSeconds: awssdk.Int64(15),
+				},
+			},
+			args: args{
+				annotations: map[string]string{
+					annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+				},
+				pendingTargetCount: 0,
+			},
+			want: false,
+		},
+		{
+			name: "relaxed, rollout completed, health check changed during rollout",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2sdk.TargetGroup{
+						{
+							TargetGroupArn:             awssdk.String(tgARN),
+							UnhealthyThresholdCount:    awssdk.Int64(3),
+							HealthCheckIntervalSeconds: awssdk.Int64(15),
+						},
+					},
+				},
+			},
+			args: args{
+				annotations: map[string]string{
+					annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+				},
+				pendingTargetCount: 0,
+			},
+			want: false,
+		},
+		{
+			name: "relaxed, malformed annotation",
+			args: args{
+				annotations: map[string]string{
+					annotationKeyOriginalHealthCheck: `{`,
+				},
+				pendingTargetCount: 0,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{`,
+			},
+			wantErr: errors.New("failed to parse annotation elbv2.k8s.aws/original-health-check: unexpected end of JSON input"),
+		},
+		{
+			name: "managed by Ingress, pending targets below threshold",
+			args: args{
+				labels: map[string]string{
+					"ingress.k8s.aws/stack": "awesome-group",
+				},
+				pendingTargetCount: 9,
+			},
+			want: false,
+		},
+		{
+			name: "managed by Ingress, pending targets reach threshold",
+			args: args{
+				labels: map[string]string{
+					"ingress.k8s.aws/stack-namespace": "default",
+					"ingress.k8s.aws/stack-name":      "my-ing",
+				},
+				pendingTargetCount: 10,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				AnnotationKeyHealthCheckRelaxed: "true",
+			},
+		},
+		{
+			name: "managed by Ingress, relaxed, targets still pending",
+			args: args{
+				labels: map[string]string{
+					"ingress.k8s.aws/stack": "awesome-group",
+				},
+				annotations: map[string]string{
+					AnnotationKeyHealthCheckRelaxed: "true",
+				},
+				pendingTargetCount: 1,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				AnnotationKeyHealthCheckRelaxed: "true",
+			},
+		},
+		{
+			name: "managed by Ingress, relaxed, rollout completed",
+			args: args{
+				labels: map[string]string{
+					"ingress.k8s.aws/stack": "awesome-group",
+				},
+				annotations: map[string]string{
+					AnnotationKeyHealthCheckRelaxed: "true",
+				},
+				pendingTargetCount: 0,
+			},
+			want: false,
+		},
+		{
+			name: "managed by Service, pending targets reach threshold",
+			args: args{
+				labels: map[string]string{
+					"service.k8s.aws/stack-namespace": "default",
+					"service.k8s.aws/stack-name":      "my-svc",
+				},
+				pendingTargetCount: 10,
+			},
+			want: false,
+		},
+		{
+			name: "relaxed, targetGroup not found",
+			describeTargetGroupsCall: &describeTargetGroupsCall{
+				resp: &elbv2sdk.DescribeTargetGroupsOutput{},
+			},
+			args: args{
+				annotations: map[string]string{
+					annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+				},
+				pendingTargetCount: 0,
+			},
+			want: true,
+			wantAnnotations: map[string]string{
+				annotationKeyOriginalHealthCheck: `{"unhealthyThresholdCount":2,"intervalSeconds":15}`,
+			},
+			wantErr: errors.New("targetGroup not found: " + tgARN),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			if tt.describeTargetGroupsCall != nil {
+				elbv2Client.EXPECT().DescribeTargetGroupsWithContext(gomock.Any(), &elbv2sdk.DescribeTargetGroupsInput{
+					TargetGroupArns: awssdk.StringSlice([]string{tgARN}),
+				}).Return(tt.describeTargetGroupsCall.resp, tt.describeTargetGroupsCall.err)
+			}
+			if tt.modifyTargetGroupCall != nil {
+				elbv2Client.EXPECT().ModifyTargetGroupWithContext(gomock.Any(), tt.modifyTargetGroupCall.req).Return(&elbv2sdk.ModifyTargetGroupOutput{}, tt.modifyTargetGroupCall.err)
+			}
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "my-tgb",
+					Labels:      tt.args.labels,
+					Annotations: tt.args.annotations,
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					TargetGroupARN: tgARN,
+				},
+			}
+			ctx := context.Background()
+			assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+
+			a := NewDefaultHealthCheckAdjuster(k8sClient, elbv2Client, 10, record.NewFakeRecorder(10), &log.NullLogger{})
+			got, err := a.Adjust(ctx, tgb, tt.args.pendingTargetCount)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			persistedTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), persistedTGB))
+			assert.Equal(t, len(tt.wantAnnotations), len(persistedTGB.Annotations))
+			for key, value := range tt.wantAnnotations {
+				assert.Equal(t, value, persistedTGB.Annotations[key])
+			}
+		})
+	}
+}
+
+func Test_BuildRelaxedHealthCheckThresholds(t *testing.T) {
+	tests := []struct {
+		name     string
+		original HealthCheckThresholds
+		want     HealthCheckThresholds
+	}{
+		{
+			name:     "thresholds are raised",
+			original: HealthCheckThresholds{UnhealthyThresholdCount: 2, IntervalSeconds: 15},
+			want:     HealthCheckThresholds{UnhealthyThresholdCount: 10, IntervalSeconds: 30},
+		},
+		{
+			name:     "lenient thresholds are kept",
+			original: HealthCheckThresholds{UnhealthyThresholdCount: 10, IntervalSeconds: 60},
+			want:     HealthCheckThresholds{UnhealthyThresholdCount: 10, IntervalSeconds: 60},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildRelaxedHealthCheckThresholds(tt.original)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package targetgroupbinding
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MultiClusterManager tracks targets registered by this cluster into TargetGroups that are shared with other clusters.
+type MultiClusterManager interface {
+	// ListOwnedTargetIDs returns the unique IDs of targets registered by this cluster for TargetGroupBinding.
+	ListOwnedTargetIDs(tgb *elbv2api.TargetGroupBinding) sets.String
+
+	// UpdateOwnedTargetIDs persists the unique IDs of targets registered by this cluster for TargetGroupBinding.
+	UpdateOwnedTargetIDs(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetIDs sets.String) error
+}
+
+// NewDefaultMultiClusterManager constructs new defaultMultiClusterManager.
+func NewDefaultMultiClusterManager(k8sClient client.Client, logger logr.Logger) *defaultMultiClusterManager {
+	return &defaultMultiClusterManager{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+var _ MultiClusterManager = &defaultMultiClusterManager{}
+
+// defaultMultiClusterManager tracks targets registered by this cluster in the status of TargetGroupBinding.
+// the status is persisted as soon as tracked targets change, before targets are registered or deregistered.
+// it's patched with optimistic lock, so that tracked targets read from a stale cache are never persisted.
+type defaultMultiClusterManager struct {
+	k8sClient client.Client
+	logger    logr.Logger
+}
+
+func (m *defaultMultiClusterManager) ListOwnedTargetIDs(tgb *elbv2api.TargetGroupBinding) sets.String {
+	return sets.NewString(tgb.Status.OwnedTargets...)
+}
+
+func (m *defaultMultiClusterManager) UpdateOwnedTargetIDs(ctx context.Context, tgb *elbv2api.TargetGroupBinding, targetIDs sets.String) error {
+	if m.ListOwnedTargetIDs(tgb).Equal(targetIDs) {
+		return nil
+	}
+	tgbOld := tgb.DeepCopy()
+	tgb.Status.OwnedTargets = targetIDs.List()
+	if err := m.k8sClient.Status().Patch(ctx, tgb, client.MergeFromWithOptions(tgbOld, client.MergeFromWithOptimisticLock{})); err != nil {
+		tgb.Status.OwnedTargets = tgbOld.Status.OwnedTargets
+		return errors.Wrap(err, "failed to update owned targets of targetGroupBinding")
+	}
+	m.logger.V(1).Info("updated owned targets", "tgb", k8s.NamespacedName(tgb), "ownedTargets", tgb.Status.OwnedTargets)
+	return nil
+}
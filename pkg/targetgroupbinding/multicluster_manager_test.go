@@ -0,0 +1,100 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_defaultMultiClusterManager_UpdateOwnedTargetIDs(t *testing.T) {
+	tests := []struct {
+		name             string
+		ownedTargets     []string
+		targetIDs        sets.String
+		wantOwnedTargets []string
+	}{
+		{
+			name:             "no targets tracked yet",
+			targetIDs:        sets.NewString("192.168.1.2:8080", "192.168.1.1:8080"),
+			wantOwnedTargets: []string{"192.168.1.1:8080", "192.168.1.2:8080"},
+		},
+		{
+			name:             "targets already tracked",
+			ownedTargets:     []string{"192.168.1.1:8080"},
+			targetIDs:        sets.NewString("192.168.1.3:8080"),
+			wantOwnedTargets: []string{"192.168.1.3:8080"},
+		},
+		{
+			name:             "tracked targets unchanged",
+			ownedTargets:     []string{"192.168.1.1:8080"},
+			targetIDs:        sets.NewString("192.168.1.1:8080"),
+			wantOwnedTargets: []string{"192.168.1.1:8080"},
+		},
+		{
+			name:         "no targets",
+			ownedTargets: []string{"192.168.1.1:8080"},
+			targetIDs:    sets.NewString(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "tgb-1",
+				},
+				Status: elbv2api.TargetGroupBindingStatus{
+					OwnedTargets: tt.ownedTargets,
+				},
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+			m := NewDefaultMultiClusterManager(k8sClient, &log.NullLogger{})
+			err := m.UpdateOwnedTargetIDs(ctx, tgb, tt.targetIDs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.targetIDs, m.ListOwnedTargetIDs(tgb))
+
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), gotTGB))
+			assert.Equal(t, tt.wantOwnedTargets, gotTGB.Status.OwnedTargets)
+		})
+	}
+}
+
+func Test_defaultMultiClusterManager_UpdateOwnedTargetIDs_staleTargetGroupBinding(t *testing.T) {
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "tgb-1",
+		},
+	}
+	assert.NoError(t, k8sClient.Create(ctx, tgb))
+	staleTGB := tgb.DeepCopy()
+	m := NewDefaultMultiClusterManager(k8sClient, &log.NullLogger{})
+	assert.NoError(t, m.UpdateOwnedTargetIDs(ctx, tgb, sets.NewString("192.168.1.1:8080")))
+
+	// targets tracked on a stale TargetGroupBinding are never persisted.
+	err := m.UpdateOwnedTargetIDs(ctx, staleTGB, sets.NewString("192.168.1.2:8080"))
+	assert.True(t, apierrors.IsConflict(errors.Cause(err)))
+	assert.Equal(t, sets.NewString(), m.ListOwnedTargetIDs(staleTGB))
+}
@@ -0,0 +1,250 @@
+package targetgroupbinding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/runtime"
+)
+
+// RegistrationOperation is the operation performed on a batch of targets.
+type RegistrationOperation string
+
+const (
+	RegistrationOperationRegister   RegistrationOperation = "register"
+	RegistrationOperationDeregister RegistrationOperation = "deregister"
+)
+
+const (
+	// RegistrationAuditBufferSize is the number of registration batches buffered for asynchronous auditing.
+	RegistrationAuditBufferSize = 1000
+
+	// timeout to persist the buffered registration batches when controller stops.
+	registrationAuditFlushTimeout = 10 * time.Second
+)
+
+// RegistrationBatch is a batch of targets registered into or deregistered from TargetGroup.
+type RegistrationBatch struct {
+	// Time when the batch completed.
+	Time time.Time `json:"time"`
+	// Operation performed on the batch.
+	Operation RegistrationOperation `json:"operation"`
+	// TargetCount is the number of targets in the batch.
+	TargetCount int `json:"targetCount"`
+	// ReconcileID of the reconcile that initiated the batch, if any.
+	ReconcileID string `json:"reconcileID,omitempty"`
+}
+
+// RegistrationAuditTrail is the audit trail of most recent registration batches for TargetGroup.
+type RegistrationAuditTrail struct {
+	TargetGroupARN string `json:"targetGroupARN"`
+	// Batches in chronological order.
+	Batches []RegistrationBatch `json:"batches"`
+}
+
+// RegistrationAuditor persists audit trail of target registration batches.
+type RegistrationAuditor interface {
+	// Record appends batches into the audit trail for TargetGroup.
+	Record(ctx context.Context, tgARN string, batches ...RegistrationBatch) error
+}
+
+// NewS3RegistrationAuditor constructs new s3RegistrationAuditor.
+func NewS3RegistrationAuditor(s3Client services.S3, bucket string, prefix string, historySize int, logger logr.Logger) *s3RegistrationAuditor {
+	return &s3RegistrationAuditor{
+		s3Client:    s3Client,
+		bucket:      bucket,
+		prefix:      prefix,
+		historySize: historySize,
+		trailMutex:  runtime.NewKeyedMutex(),
+		logger:      logger,
+	}
+}
+
+var _ RegistrationAuditor = &s3RegistrationAuditor{}
+
+// s3RegistrationAuditor persists audit trail of each TargetGroup as a JSON object in S3 bucket.
+// the object key is "<prefix>/targetgroup/<tgName>/<tgID>.json", and only the most recent historySize batches are kept.
+type s3RegistrationAuditor struct {
+	s3Client    services.S3
+	bucket      string
+	prefix      string
+	historySize int
+	// trailMutex serializes read-modify-write of audit trail per TargetGroup.
+	trailMutex *runtime.KeyedMutex
+	logger     logr.Logger
+}
+
+func (a *s3RegistrationAuditor) Record(ctx context.Context, tgARN string, batches ...RegistrationBatch) error {
+	objectKey, err := a.buildAuditTrailObjectKey(tgARN)
+	if err != nil {
+		return err
+	}
+	a.trailMutex.Lock(tgARN)
+	defer a.trailMutex.Unlock(tgARN)
+
+	trail, err := a.loadAuditTrail(ctx, objectKey)
+	if err != nil {
+		return err
+	}
+	trail.TargetGroupARN = tgARN
+	trail.Batches = appendRegistrationBatches(trail.Batches, batches, a.historySize)
+	payload, err := json.Marshal(trail)
+	if err != nil {
+		return err
+	}
+	req := &s3sdk.PutObjectInput{
+		Bucket:      awssdk.String(a.bucket),
+		Key:         awssdk.String(objectKey),
+		Body:        bytes.NewReader(payload),
+		ContentType: awssdk.String("application/json"),
+	}
+	if _, err := a.s3Client.PutObjectWithContext(ctx, req); err != nil {
+		return errors.Wrapf(err, "failed to persist target registration audit trail to S3 bucket %v", a.bucket)
+	}
+	return nil
+}
+
+// loadAuditTrail loads the persisted audit trail, an empty audit trail is returned if there is none.
+func (a *s3RegistrationAuditor) loadAuditTrail(ctx context.Context, objectKey string) (RegistrationAuditTrail, error) {
+	req := &s3sdk.GetObjectInput{
+		Bucket: awssdk.String(a.bucket),
+		Key:    awssdk.String(objectKey),
+	}
+	resp, err := a.s3Client.GetObjectWithContext(ctx, req)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3sdk.ErrCodeNoSuchKey {
+			return RegistrationAuditTrail{}, nil
+		}
+		return RegistrationAuditTrail{}, errors.Wrapf(err, "failed to load target registration audit trail from S3 bucket %v", a.bucket)
+	}
+	defer resp.Body.Close()
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RegistrationAuditTrail{}, err
+	}
+	var trail RegistrationAuditTrail
+	if err := json.Unmarshal(payload, &trail); err != nil {
+		// a corrupted audit trail shouldn't block auditing forever, start over instead.
+		a.logger.Info("discarding malformed target registration audit trail", "bucket", a.bucket, "key", objectKey, "error", err.Error())
+		return RegistrationAuditTrail{}, nil
+	}
+	return trail, nil
+}
+
+func (a *s3RegistrationAuditor) buildAuditTrailObjectKey(tgARN string) (string, error) {
+	parsedARN, err := arn.Parse(tgARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse targetGroup ARN %v", tgARN)
+	}
+	return path.Join(a.prefix, parsedARN.Resource+".json"), nil
+}
+
+// appendRegistrationBatches appends newBatches to batches, and keeps only the most recent historySize batches.
+func appendRegistrationBatches(batches []RegistrationBatch, newBatches []RegistrationBatch, historySize int) []RegistrationBatch {
+	batches = append(batches, newBatches...)
+	if len(batches) > historySize {
+		batches = batches[len(batches)-historySize:]
+	}
+	return batches
+}
+
+// NewBufferedRegistrationAuditor constructs new bufferedRegistrationAuditor.
+func NewBufferedRegistrationAuditor(auditor RegistrationAuditor, bufferSize int, logger logr.Logger) *bufferedRegistrationAuditor {
+	return &bufferedRegistrationAuditor{
+		auditor:      auditor,
+		pendingChan:  make(chan pendingRegistrationBatch, bufferSize),
+		flushTimeout: registrationAuditFlushTimeout,
+		logger:       logger,
+	}
+}
+
+var _ RegistrationAuditor = &bufferedRegistrationAuditor{}
+
+// bufferedRegistrationAuditor buffers registration batches, and persists them with the wrapped RegistrationAuditor asynchronously,
+// so that target registration is never blocked by the audit trail storage.
+// batches are dropped if the buffer is full, and batches of the same TargetGroup buffered meanwhile are persisted together.
+type bufferedRegistrationAuditor struct {
+	auditor      RegistrationAuditor
+	pendingChan  chan pendingRegistrationBatch
+	flushTimeout time.Duration
+	logger       logr.Logger
+}
+
+// pendingRegistrationBatch is a registration batch of TargetGroup pending to be persisted.
+type pendingRegistrationBatch struct {
+	tgARN string
+	batch RegistrationBatch
+}
+
+func (a *bufferedRegistrationAuditor) Record(_ context.Context, tgARN string, batches ...RegistrationBatch) error {
+	for _, batch := range batches {
+		select {
+		case a.pendingChan <- pendingRegistrationBatch{tgARN: tgARN, batch: batch}:
+		default:
+			return errors.New("target registration audit buffer is full, dropping batch")
+		}
+	}
+	return nil
+}
+
+// Start persists buffered registration batches until ctx is done, and flushes remaining batches afterwards.
+func (a *bufferedRegistrationAuditor) Start(ctx context.Context) error {
+	for {
+		select {
+		case pending := <-a.pendingChan:
+			a.persist(ctx, a.collectPendingBatches(pending))
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), a.flushTimeout)
+			defer cancel()
+			a.persist(flushCtx, a.collectPendingBatches())
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection ensures batches recorded by every replica are persisted.
+func (a *bufferedRegistrationAuditor) NeedLeaderElection() bool {
+	return false
+}
+
+// collectPendingBatches collects the buffered registration batches without blocking, after the already received ones.
+func (a *bufferedRegistrationAuditor) collectPendingBatches(received ...pendingRegistrationBatch) []pendingRegistrationBatch {
+	pendingBatches := received
+	for {
+		select {
+		case pending := <-a.pendingChan:
+			pendingBatches = append(pendingBatches, pending)
+		default:
+			return pendingBatches
+		}
+	}
+}
+
+// persist persists pendingBatches with one record per TargetGroup, in the order they are received.
+func (a *bufferedRegistrationAuditor) persist(ctx context.Context, pendingBatches []pendingRegistrationBatch) {
+	var tgARNs []string
+	batchesByTGARN := make(map[string][]RegistrationBatch)
+	for _, pending := range pendingBatches {
+		if _, exists := batchesByTGARN[pending.tgARN]; !exists {
+			tgARNs = append(tgARNs, pending.tgARN)
+		}
+		batchesByTGARN[pending.tgARN] = append(batchesByTGARN[pending.tgARN], pending.batch)
+	}
+	for _, tgARN := range tgARNs {
+		if err := a.auditor.Record(ctx, tgARN, batchesByTGARN[tgARN]...); err != nil {
+			a.logger.Error(err, "failed to record target registration audit trail", "arn", tgARN)
+		}
+	}
+}
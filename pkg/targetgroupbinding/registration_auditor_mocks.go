@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding (interfaces: RegistrationAuditor)
+
+// Package targetgroupbinding is a generated GoMock package.
+package targetgroupbinding
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRegistrationAuditor is a mock of RegistrationAuditor interface.
+type MockRegistrationAuditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrationAuditorMockRecorder
+}
+
+// MockRegistrationAuditorMockRecorder is the mock recorder for MockRegistrationAuditor.
+type MockRegistrationAuditorMockRecorder struct {
+	mock *MockRegistrationAuditor
+}
+
+// NewMockRegistrationAuditor creates a new mock instance.
+func NewMockRegistrationAuditor(ctrl *gomock.Controller) *MockRegistrationAuditor {
+	mock := &MockRegistrationAuditor{ctrl: ctrl}
+	mock.recorder = &MockRegistrationAuditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistrationAuditor) EXPECT() *MockRegistrationAuditorMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRegistrationAuditor) Record(arg0 context.Context, arg1 string, arg2 ...RegistrationBatch) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Record", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRegistrationAuditorMockRecorder) Record(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRegistrationAuditor)(nil).Record), varargs...)
+}
@@ -0,0 +1,247 @@
+package targetgroupbinding
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	s3sdk "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_s3RegistrationAuditor_Record(t *testing.T) {
+	batchTime := time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC)
+	type getObjectCall struct {
+		body string
+		err  error
+	}
+	type putObjectCall struct {
+		body string
+		err  error
+	}
+	type fields struct {
+		historySize    int
+		getObjectCalls []getObjectCall
+		putObjectCalls []putObjectCall
+	}
+	type args struct {
+		tgARN string
+		batch RegistrationBatch
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "first batch of targetGroup",
+			fields: fields{
+				historySize: 2,
+				getObjectCalls: []getObjectCall{
+					{err: awserr.New(s3sdk.ErrCodeNoSuchKey, "The specified key does not exist.", nil)},
+				},
+				putObjectCalls: []putObjectCall{
+					{body: `{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067","batches":[{"time":"2021-10-01T08:00:00Z","operation":"register","targetCount":3,"reconcileID":"reconcile-1"}]}`},
+				},
+			},
+			args: args{
+				tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 3,
+					ReconcileID: "reconcile-1",
+				},
+			},
+		},
+		{
+			name: "oldest batch discarded when exceeds history size",
+			fields: fields{
+				historySize: 2,
+				getObjectCalls: []getObjectCall{
+					{body: `{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067","batches":[{"time":"2021-09-30T08:00:00Z","operation":"register","targetCount":1},{"time":"2021-09-30T09:00:00Z","operation":"deregister","targetCount":2}]}`},
+				},
+				putObjectCalls: []putObjectCall{
+					{body: `{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067","batches":[{"time":"2021-09-30T09:00:00Z","operation":"deregister","targetCount":2},{"time":"2021-10-01T08:00:00Z","operation":"register","targetCount":3}]}`},
+				},
+			},
+			args: args{
+				tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 3,
+				},
+			},
+		},
+		{
+			name: "malformed audit trail is discarded",
+			fields: fields{
+				historySize: 2,
+				getObjectCalls: []getObjectCall{
+					{body: `not-json`},
+				},
+				putObjectCalls: []putObjectCall{
+					{body: `{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067","batches":[{"time":"2021-10-01T08:00:00Z","operation":"deregister","targetCount":1}]}`},
+				},
+			},
+			args: args{
+				tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationDeregister,
+					TargetCount: 1,
+				},
+			},
+		},
+		{
+			name: "failed to load audit trail",
+			fields: fields{
+				historySize: 2,
+				getObjectCalls: []getObjectCall{
+					{err: awserr.New("AccessDenied", "Access Denied", nil)},
+				},
+			},
+			args: args{
+				tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 3,
+				},
+			},
+			wantErr: errors.New("failed to load target registration audit trail from S3 bucket my-bucket: AccessDenied: Access Denied"),
+		},
+		{
+			name: "failed to persist audit trail",
+			fields: fields{
+				historySize: 2,
+				getObjectCalls: []getObjectCall{
+					{err: awserr.New(s3sdk.ErrCodeNoSuchKey, "The specified key does not exist.", nil)},
+				},
+				putObjectCalls: []putObjectCall{
+					{
+						body: `{"targetGroupARN":"arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067","batches":[{"time":"2021-10-01T08:00:00Z","operation":"register","targetCount":3}]}`,
+						err:  awserr.New("AccessDenied", "Access Denied", nil),
+					},
+				},
+			},
+			args: args{
+				tgARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/73e2d6bc24d8a067",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 3,
+				},
+			},
+			wantErr: errors.New("failed to persist target registration audit trail to S3 bucket my-bucket: AccessDenied: Access Denied"),
+		},
+		{
+			name: "invalid targetGroup ARN",
+			fields: fields{
+				historySize: 2,
+			},
+			args: args{
+				tgARN: "my-tg",
+				batch: RegistrationBatch{
+					Time:        batchTime,
+					Operation:   RegistrationOperationRegister,
+					TargetCount: 3,
+				},
+			},
+			wantErr: errors.New("failed to parse targetGroup ARN my-tg: arn: invalid prefix"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s3Client := services.NewMockS3(ctrl)
+			for _, call := range tt.fields.getObjectCalls {
+				var resp *s3sdk.GetObjectOutput
+				if call.err == nil {
+					resp = &s3sdk.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader([]byte(call.body)))}
+				}
+				s3Client.EXPECT().GetObjectWithContext(gomock.Any(), &s3sdk.GetObjectInput{
+					Bucket: awssdk.String("my-bucket"),
+					Key:    awssdk.String("audit/targetgroup/my-tg/73e2d6bc24d8a067.json"),
+				}).Return(resp, call.err)
+			}
+			for _, call := range tt.fields.putObjectCalls {
+				call := call
+				s3Client.EXPECT().PutObjectWithContext(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *s3sdk.PutObjectInput, _ ...request.Option) (*s3sdk.PutObjectOutput, error) {
+						assert.Equal(t, "my-bucket", awssdk.StringValue(req.Bucket))
+						assert.Equal(t, "audit/targetgroup/my-tg/73e2d6bc24d8a067.json", awssdk.StringValue(req.Key))
+						body, err := ioutil.ReadAll(req.Body)
+						assert.NoError(t, err)
+						assert.JSONEq(t, call.body, string(body))
+						return &s3sdk.PutObjectOutput{}, call.err
+					})
+			}
+
+			a := NewS3RegistrationAuditor(s3Client, "my-bucket", "audit", tt.fields.historySize, &log.NullLogger{})
+			err := a.Record(context.Background(), tt.args.tgARN, tt.args.batch)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_bufferedRegistrationAuditor_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	batch := RegistrationBatch{
+		Time:        time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC),
+		Operation:   RegistrationOperationRegister,
+		TargetCount: 2,
+	}
+	a := NewBufferedRegistrationAuditor(NewMockRegistrationAuditor(ctrl), 2, &log.NullLogger{})
+	assert.NoError(t, a.Record(context.Background(), "tg-1", batch))
+	assert.NoError(t, a.Record(context.Background(), "tg-2", batch))
+	assert.EqualError(t, a.Record(context.Background(), "tg-1", batch), "target registration audit buffer is full, dropping batch")
+}
+
+func Test_bufferedRegistrationAuditor_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildBatch := func(operation RegistrationOperation, targetCount int) RegistrationBatch {
+		return RegistrationBatch{
+			Time:        time.Date(2021, 10, 1, 8, 0, 0, 0, time.UTC),
+			Operation:   operation,
+			TargetCount: targetCount,
+		}
+	}
+	auditor := NewMockRegistrationAuditor(ctrl)
+	gomock.InOrder(
+		auditor.EXPECT().Record(gomock.Any(), "tg-1",
+			buildBatch(RegistrationOperationRegister, 1), buildBatch(RegistrationOperationDeregister, 3)).Return(nil),
+		auditor.EXPECT().Record(gomock.Any(), "tg-2",
+			buildBatch(RegistrationOperationRegister, 2)).Return(errors.New("some audit error")),
+	)
+	a := NewBufferedRegistrationAuditor(auditor, 10, &log.NullLogger{})
+	assert.NoError(t, a.Record(context.Background(), "tg-1", buildBatch(RegistrationOperationRegister, 1)))
+	assert.NoError(t, a.Record(context.Background(), "tg-2", buildBatch(RegistrationOperationRegister, 2)))
+	assert.NoError(t, a.Record(context.Background(), "tg-1", buildBatch(RegistrationOperationDeregister, 3)))
+
+	// buffered batches are flushed once stopped.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, a.Start(ctx))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"inet.af/netaddr"
+	"strings"
 	"time"
 
 	"k8s.io/client-go/tools/record"
@@ -39,28 +40,40 @@ type ResourceManager interface {
 
 // NewDefaultResourceManager constructs new defaultResourceManager.
 func NewDefaultResourceManager(k8sClient client.Client, elbv2Client services.ELBV2, ec2Client services.EC2,
-	podInfoRepo k8s.PodInfoRepo, sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
-	vpcID string, clusterName string, eventRecorder record.EventRecorder, logger logr.Logger, useEndpointSlices bool, disabledRestrictedSGRulesFlag bool, vpcInfoProvider networking.VPCInfoProvider) *defaultResourceManager {
-	targetsManager := NewCachedTargetsManager(elbv2Client, logger)
-	endpointResolver := backend.NewDefaultEndpointResolver(k8sClient, podInfoRepo, logger)
+	endpointResolver backend.EndpointResolver, sgManager networking.SecurityGroupManager, sgReconciler networking.SecurityGroupReconciler,
+	vpcID string, clusterName string, eventRecorder record.EventRecorder, logger logr.Logger, useEndpointSlices bool, disabledRestrictedSGRulesFlag bool, vpcInfoProvider networking.VPCInfoProvider,
+	registrationAuditor RegistrationAuditor, healthCheckAdjuster HealthCheckAdjuster, targetsSyncTracker TargetsSyncTracker,
+	targetDrainTimeout time.Duration, customEndpointSliceManagers []string, enableCustomEndpointSliceExternalIPs bool) *defaultResourceManager {
+	var targetsManager TargetsManager = NewCachedTargetsManager(elbv2Client, logger)
+	if registrationAuditor != nil {
+		targetsManager = NewAuditedTargetsManager(targetsManager, registrationAuditor, logger)
+	}
 
 	nodeInfoProvider := networking.NewDefaultNodeInfoProvider(ec2Client, logger)
 	podENIResolver := networking.NewDefaultPodENIInfoResolver(k8sClient, ec2Client, nodeInfoProvider, vpcID, logger)
 	nodeENIResolver := networking.NewDefaultNodeENIInfoResolver(nodeInfoProvider, logger)
 
 	networkingManager := NewDefaultNetworkingManager(k8sClient, podENIResolver, nodeENIResolver, sgManager, sgReconciler, vpcID, clusterName, logger, disabledRestrictedSGRulesFlag)
+	multiClusterManager := NewDefaultMultiClusterManager(k8sClient, logger)
 	return &defaultResourceManager{
-		k8sClient:         k8sClient,
-		targetsManager:    targetsManager,
-		endpointResolver:  endpointResolver,
-		networkingManager: networkingManager,
-		eventRecorder:     eventRecorder,
-		logger:            logger,
-		vpcID:             vpcID,
-		vpcInfoProvider:   vpcInfoProvider,
-
+		k8sClient:           k8sClient,
+		targetsManager:      targetsManager,
+		endpointResolver:    endpointResolver,
+		networkingManager:   networkingManager,
+		multiClusterManager: multiClusterManager,
+		eventRecorder:       eventRecorder,
+		logger:              logger,
+		vpcID:               vpcID,
+		vpcInfoProvider:     vpcInfoProvider,
+
+		healthCheckAdjuster:         healthCheckAdjuster,
+		targetsSyncTracker:          targetsSyncTracker,
 		targetHealthRequeueDuration: defaultTargetHealthRequeueDuration,
 		enableEndpointSlices:        useEndpointSlices,
+		targetDrainTimeout:          targetDrainTimeout,
+
+		customEndpointSliceManagers:          customEndpointSliceManagers,
+		enableCustomEndpointSliceExternalIPs: enableCustomEndpointSliceExternalIPs,
 	}
 }
 
@@ -68,17 +81,29 @@ var _ ResourceManager = &defaultResourceManager{}
 
 // default implementation for ResourceManager.
 type defaultResourceManager struct {
-	k8sClient         client.Client
-	targetsManager    TargetsManager
-	endpointResolver  backend.EndpointResolver
-	networkingManager NetworkingManager
-	eventRecorder     record.EventRecorder
-	logger            logr.Logger
-	vpcInfoProvider   networking.VPCInfoProvider
-	vpcID             string
-
+	k8sClient           client.Client
+	targetsManager      TargetsManager
+	endpointResolver    backend.EndpointResolver
+	networkingManager   NetworkingManager
+	multiClusterManager MultiClusterManager
+	eventRecorder       record.EventRecorder
+	logger              logr.Logger
+	vpcInfoProvider     networking.VPCInfoProvider
+	vpcID               string
+
+	// healthCheckAdjuster is nil if adaptive health check is disabled.
+	healthCheckAdjuster HealthCheckAdjuster
+	// targetsSyncTracker is nil if skipping targets reconcile for unchanged backends is disabled.
+	targetsSyncTracker          TargetsSyncTracker
 	targetHealthRequeueDuration time.Duration
 	enableEndpointSlices        bool
+	// targetDrainTimeout is the duration deregistration requests from pod evictions are honored for, unless the pod is terminating.
+	targetDrainTimeout time.Duration
+
+	// customEndpointSliceManagers are the managers whose EndpointSlices are honored even if their endpoints aren't backed by pods.
+	customEndpointSliceManagers []string
+	// enableCustomEndpointSliceExternalIPs allows endpoints of custom EndpointSlices outside the VPC to be registered.
+	enableCustomEndpointSliceExternalIPs bool
 }
 
 func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
@@ -92,6 +117,9 @@ func (m *defaultResourceManager) Reconcile(ctx context.Context, tgb *elbv2api.Ta
 }
 
 func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
+	if m.targetsSyncTracker != nil {
+		m.targetsSyncTracker.Forget(k8s.NamespacedName(tgb))
+	}
 	if err := m.cleanupTargets(ctx, tgb); err != nil {
 		return err
 	}
@@ -103,11 +131,27 @@ func (m *defaultResourceManager) Cleanup(ctx context.Context, tgb *elbv2api.Targ
 
 func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
+	var backendVersion string
+	if m.targetsSyncTracker != nil {
+		var err error
+		backendVersion, err = m.targetsSyncTracker.BackendVersion(ctx, svcKey)
+		if err != nil {
+			return err
+		}
+		// targets synced with unchanged backend are left alone, which avoids ELBV2 API calls in steady state.
+		if m.targetsSyncTracker.IsSynced(tgb, backendVersion) {
+			m.logger.V(1).Info("skipping targets reconcile for unchanged backend", "tgb", k8s.NamespacedName(tgb), "backendVersion", backendVersion)
+			return nil
+		}
+	}
 
 	targetHealthCondType := BuildTargetHealthPodConditionType(tgb)
 	resolveOpts := []backend.EndpointResolveOption{
 		backend.WithPodReadinessGate(targetHealthCondType),
 	}
+	if len(m.customEndpointSliceManagers) > 0 {
+		resolveOpts = append(resolveOpts, backend.WithCustomEndpointSliceManagers(m.customEndpointSliceManagers...))
+	}
 	var endpoints []backend.PodEndpoint
 	var containsPotentialReadyEndpoints bool
 	var err error
@@ -125,6 +169,29 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		}
 		return err
 	}
+	// pods whose eviction requested deregistration are excluded, so that their targets are drained before they terminate.
+	endpoints, deregistrationRequestExpiry := filterDeregistrationRequestedPodEndpoints(endpoints, time.Now(), m.targetDrainTimeout)
+	// endpoints of custom EndpointSlices that aren't backed by pods are registered like external targets.
+	endpoints, customEndpoints := partitionPodEndpointsByPodBacking(endpoints)
+	if len(customEndpoints) > 0 && !m.enableCustomEndpointSliceExternalIPs {
+		vpcCIDRs, err := m.fetchVPCCIDRs(ctx)
+		if err != nil {
+			return err
+		}
+		var externalEndpoints []backend.PodEndpoint
+		customEndpoints, externalEndpoints, err = partitionPodEndpointsByCIDRs(customEndpoints, vpcCIDRs)
+		if err != nil {
+			return err
+		}
+		if len(externalEndpoints) > 0 {
+			externalIPs := sets.NewString()
+			for _, endpoint := range externalEndpoints {
+				externalIPs.Insert(endpoint.IP)
+			}
+			m.eventRecorder.Event(tgb, corev1.EventTypeWarning, k8s.TargetGroupBindingEventReasonCustomEndpointsIgnored,
+				fmt.Sprintf("ignored endpoints of custom EndpointSlices outside the VPC: %v", strings.Join(externalIPs.List(), ",")))
+		}
+	}
 
 	tgARN := tgb.Spec.TargetGroupARN
 	targets, err := m.targetsManager.ListTargets(ctx, tgARN)
@@ -132,7 +199,25 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return err
 	}
 	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(endpoints, notDrainingTargets)
+	// external targets are registered alongside pod endpoints, but they don't participate in networking setup or readiness gates.
+	desiredEndpoints := append(append(buildExternalTargetEndpoints(tgb), customEndpoints...), endpoints...)
+	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchPodEndpointWithTargets(desiredEndpoints, notDrainingTargets)
+	desiredTargetIDs := sets.NewString()
+	for _, endpoint := range desiredEndpoints {
+		desiredTargetIDs.Insert(fmt.Sprintf("%v:%v", endpoint.IP, endpoint.Port))
+	}
+	unmatchedTargets, drainingTargets, err = m.filterOwnedTargets(ctx, tgb, desiredTargetIDs, unmatchedTargets, drainingTargets)
+	if err != nil {
+		return err
+	}
+	unmatchedEndpointPortsByID := make(map[string]sets.Int64, len(unmatchedEndpoints))
+	for _, endpoint := range unmatchedEndpoints {
+		if _, ok := unmatchedEndpointPortsByID[endpoint.IP]; !ok {
+			unmatchedEndpointPortsByID[endpoint.IP] = sets.NewInt64()
+		}
+		unmatchedEndpointPortsByID[endpoint.IP].Insert(endpoint.Port)
+	}
+	m.recordTargetPortChange(tgb, unmatchedTargets, unmatchedEndpointPortsByID)
 
 	if err := m.networkingManager.ReconcileForPodEndpoints(ctx, tgb, endpoints); err != nil {
 		return err
@@ -147,6 +232,15 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 			return err
 		}
 	}
+	matchedTargets := make([]TargetInfo, 0, len(matchedEndpointAndTargets))
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		matchedTargets = append(matchedTargets, endpointAndTarget.target)
+	}
+	tgb.Status.Targets = buildTargetsStatus(matchedTargets, len(unmatchedEndpoints), drainingTargets, unmatchedTargets)
+	healthCheckRelaxed, err := m.adjustHealthCheck(ctx, tgb)
+	if err != nil {
+		return err
+	}
 
 	anyPodNeedFurtherProbe, err := m.updateTargetHealthPodCondition(ctx, targetHealthCondType, matchedEndpointAndTargets, unmatchedEndpoints)
 	if err != nil {
@@ -164,7 +258,19 @@ func (m *defaultResourceManager) reconcileWithIPTargetType(ctx context.Context,
 		return runtime.NewRequeueNeeded("monitor potential ready endpoints")
 	}
 
-	_ = drainingTargets
+	if tgb.Status.Targets.Draining > 0 {
+		return runtime.NewRequeueNeededAfter("monitor draining targets", m.targetHealthRequeueDuration)
+	}
+	if healthCheckRelaxed {
+		return runtime.NewRequeueNeededAfter("monitor rollout", m.targetHealthRequeueDuration)
+	}
+	// targets of pods whose eviction got rejected are registered again once their deregistration requests expire.
+	if !deregistrationRequestExpiry.IsZero() {
+		return runtime.NewRequeueNeededAfter("monitor deregistration requests", time.Until(deregistrationRequestExpiry))
+	}
+	if m.targetsSyncTracker != nil {
+		m.targetsSyncTracker.MarkSynced(tgb, backendVersion)
+	}
 	return nil
 }
 
@@ -190,7 +296,23 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 		return err
 	}
 	notDrainingTargets, drainingTargets := partitionTargetsByDrainingStatus(targets)
-	_, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	matchedEndpointAndTargets, unmatchedEndpoints, unmatchedTargets := matchNodePortEndpointWithTargets(endpoints, notDrainingTargets)
+	desiredTargetIDs := sets.NewString()
+	for _, endpoint := range endpoints {
+		desiredTargetIDs.Insert(fmt.Sprintf("%v:%v", endpoint.InstanceID, endpoint.Port))
+	}
+	unmatchedTargets, drainingTargets, err = m.filterOwnedTargets(ctx, tgb, desiredTargetIDs, unmatchedTargets, drainingTargets)
+	if err != nil {
+		return err
+	}
+	unmatchedEndpointPortsByID := make(map[string]sets.Int64, len(unmatchedEndpoints))
+	for _, endpoint := range unmatchedEndpoints {
+		if _, ok := unmatchedEndpointPortsByID[endpoint.InstanceID]; !ok {
+			unmatchedEndpointPortsByID[endpoint.InstanceID] = sets.NewInt64()
+		}
+		unmatchedEndpointPortsByID[endpoint.InstanceID].Insert(endpoint.Port)
+	}
+	m.recordTargetPortChange(tgb, unmatchedTargets, unmatchedEndpointPortsByID)
 
 	if err := m.networkingManager.ReconcileForNodePortEndpoints(ctx, tgb, endpoints); err != nil {
 		return err
@@ -205,10 +327,68 @@ func (m *defaultResourceManager) reconcileWithInstanceTargetType(ctx context.Con
 			return err
 		}
 	}
-	_ = drainingTargets
+	matchedTargets := make([]TargetInfo, 0, len(matchedEndpointAndTargets))
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		matchedTargets = append(matchedTargets, endpointAndTarget.target)
+	}
+	tgb.Status.Targets = buildTargetsStatus(matchedTargets, len(unmatchedEndpoints), drainingTargets, unmatchedTargets)
+	healthCheckRelaxed, err := m.adjustHealthCheck(ctx, tgb)
+	if err != nil {
+		return err
+	}
+	if tgb.Status.Targets.Draining > 0 {
+		return runtime.NewRequeueNeededAfter("monitor draining targets", m.targetHealthRequeueDuration)
+	}
+	if healthCheckRelaxed {
+		return runtime.NewRequeueNeededAfter("monitor rollout", m.targetHealthRequeueDuration)
+	}
 	return nil
 }
 
+// recordTargetPortChange emits an event if targets are going to be re-registered with different ports,
+// which happens when the nodePort or targetPort of the Service changes.
+func (m *defaultResourceManager) recordTargetPortChange(tgb *elbv2api.TargetGroupBinding, unmatchedTargets []TargetInfo, unmatchedEndpointPortsByID map[string]sets.Int64) {
+	oldPorts, newPorts := detectTargetPortChange(unmatchedTargets, unmatchedEndpointPortsByID)
+	if len(oldPorts) == 0 {
+		return
+	}
+	m.logger.Info("re-registering targets with changed ports",
+		"tgb", k8s.NamespacedName(tgb), "oldPorts", oldPorts, "newPorts", newPorts)
+	m.eventRecorder.Event(tgb, corev1.EventTypeNormal, k8s.TargetGroupBindingEventReasonTargetPortChanged,
+		fmt.Sprintf("Re-registering targets from port %v to port %v", oldPorts, newPorts))
+}
+
+// filterOwnedTargets returns the unmatchedTargets and drainingTargets registered by this cluster if TargetGroup is shared with other clusters,
+// so that targets registered by other clusters are never deregistered.
+// desired targets are tracked before they're registered, and deregistered targets are tracked until they're removed from TargetGroup.
+func (m *defaultResourceManager) filterOwnedTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding, desiredTargetIDs sets.String,
+	unmatchedTargets []TargetInfo, drainingTargets []TargetInfo) ([]TargetInfo, []TargetInfo, error) {
+	if !tgb.Spec.MultiClusterTargetGroup {
+		return unmatchedTargets, drainingTargets, nil
+	}
+	ownedTargetIDs := m.multiClusterManager.ListOwnedTargetIDs(tgb)
+	ownedUnmatchedTargets := filterTargetsByUniqueIDs(unmatchedTargets, ownedTargetIDs)
+	ownedDrainingTargets := filterTargetsByUniqueIDs(drainingTargets, ownedTargetIDs)
+
+	newOwnedTargetIDs := sets.NewString(desiredTargetIDs.UnsortedList()...)
+	for _, target := range append(ownedUnmatchedTargets, ownedDrainingTargets...) {
+		newOwnedTargetIDs.Insert(UniqueIDForTargetDescription(target.Target))
+	}
+	if err := m.multiClusterManager.UpdateOwnedTargetIDs(ctx, tgb, newOwnedTargetIDs); err != nil {
+		return nil, nil, err
+	}
+	return ownedUnmatchedTargets, ownedDrainingTargets, nil
+}
+
+// adjustHealthCheck relaxes the health check of targetGroup during mass rollouts if adaptive health check is enabled.
+// returns whether the health check is relaxed.
+func (m *defaultResourceManager) adjustHealthCheck(ctx context.Context, tgb *elbv2api.TargetGroupBinding) (bool, error) {
+	if m.healthCheckAdjuster == nil {
+		return false, nil
+	}
+	return m.healthCheckAdjuster.Adjust(ctx, tgb, int(tgb.Status.Targets.PendingRegistration))
+}
+
 func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	targets, err := m.targetsManager.ListTargets(ctx, tgb.Spec.TargetGroupARN)
 	if err != nil {
@@ -219,6 +399,9 @@ func (m *defaultResourceManager) cleanupTargets(ctx context.Context, tgb *elbv2a
 		}
 		return err
 	}
+	if tgb.Spec.MultiClusterTargetGroup {
+		targets = filterTargetsByUniqueIDs(targets, m.multiClusterManager.ListOwnedTargetIDs(tgb))
+	}
 	if err := m.deregisterTargets(ctx, tgb.Spec.TargetGroupARN, targets); err != nil {
 		if isELBV2TargetGroupNotFoundError(err) {
 			return nil
@@ -273,7 +456,13 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 	if !pod.HasAnyOfReadinessGates([]corev1.PodConditionType{targetHealthCondType}) {
 		return false, nil
 	}
+	return patchTargetHealthPodCondition(ctx, m.k8sClient, pod, targetHealth, targetHealthCondType)
+}
 
+// patchTargetHealthPodCondition patches pod's targetHealth condition of targetHealthCondType to reflect targetHealth.
+// returns whether further probe is needed or not.
+func patchTargetHealthPodCondition(ctx context.Context, k8sClient client.Client, pod k8s.PodInfo,
+	targetHealth *elbv2sdk.TargetHealth, targetHealthCondType corev1.PodConditionType) (bool, error) {
 	targetHealthCondStatus := corev1.ConditionUnknown
 	var reason, message string
 	if targetHealth != nil {
@@ -318,7 +507,7 @@ func (m *defaultResourceManager) updateTargetHealthPodConditionForPod(ctx contex
 			UID:       pod.UID,
 		},
 	}
-	if err := m.k8sClient.Status().Patch(ctx, k8sPod, patch); err != nil {
+	if err := k8sClient.Status().Patch(ctx, k8sPod, patch); err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, nil
 		}
@@ -337,14 +526,7 @@ func (m *defaultResourceManager) deregisterTargets(ctx context.Context, tgARN st
 }
 
 func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN string, endpoints []backend.PodEndpoint) error {
-	vpcInfo, err := m.vpcInfoProvider.FetchVPCInfo(ctx, m.vpcID)
-	if err != nil {
-		return err
-	}
-	var vpcRawCIDRs []string
-	vpcRawCIDRs = append(vpcRawCIDRs, vpcInfo.AssociatedIPv4CIDRs()...)
-	vpcRawCIDRs = append(vpcRawCIDRs, vpcInfo.AssociatedIPv6CIDRs()...)
-	vpcCIDRs, err := networking.ParseCIDRs(vpcRawCIDRs)
+	vpcCIDRs, err := m.fetchVPCCIDRs(ctx)
 	if err != nil {
 		return err
 	}
@@ -367,6 +549,18 @@ func (m *defaultResourceManager) registerPodEndpoints(ctx context.Context, tgARN
 	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
+// fetchVPCCIDRs returns the IPv4 and IPv6 CIDRs associated with the VPC.
+func (m *defaultResourceManager) fetchVPCCIDRs(ctx context.Context) ([]netaddr.IPPrefix, error) {
+	vpcInfo, err := m.vpcInfoProvider.FetchVPCInfo(ctx, m.vpcID)
+	if err != nil {
+		return nil, err
+	}
+	var vpcRawCIDRs []string
+	vpcRawCIDRs = append(vpcRawCIDRs, vpcInfo.AssociatedIPv4CIDRs()...)
+	vpcRawCIDRs = append(vpcRawCIDRs, vpcInfo.AssociatedIPv6CIDRs()...)
+	return networking.ParseCIDRs(vpcRawCIDRs)
+}
+
 func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context, tgARN string, endpoints []backend.NodePortEndpoint) error {
 	sdkTargets := make([]elbv2sdk.TargetDescription, 0, len(endpoints))
 	for _, endpoint := range endpoints {
@@ -378,6 +572,29 @@ func (m *defaultResourceManager) registerNodePortEndpoints(ctx context.Context,
 	return m.targetsManager.RegisterTargets(ctx, tgARN, sdkTargets)
 }
 
+// buildExternalTargetEndpoints builds the endpoints for static targets outside the cluster.
+func buildExternalTargetEndpoints(tgb *elbv2api.TargetGroupBinding) []backend.PodEndpoint {
+	endpoints := make([]backend.PodEndpoint, 0, len(tgb.Spec.ExternalTargets))
+	for _, externalTarget := range tgb.Spec.ExternalTargets {
+		endpoints = append(endpoints, backend.PodEndpoint{
+			IP:   externalTarget.IP,
+			Port: externalTarget.Port,
+		})
+	}
+	return endpoints
+}
+
+// filterTargetsByUniqueIDs returns targets whose unique ID is within targetIDs.
+func filterTargetsByUniqueIDs(targets []TargetInfo, targetIDs sets.String) []TargetInfo {
+	var filteredTargets []TargetInfo
+	for _, target := range targets {
+		if targetIDs.Has(UniqueIDForTargetDescription(target.Target)) {
+			filteredTargets = append(filteredTargets, target)
+		}
+	}
+	return filteredTargets
+}
+
 type podEndpointAndTargetPair struct {
 	endpoint backend.PodEndpoint
 	target   TargetInfo
@@ -396,6 +613,26 @@ func partitionTargetsByDrainingStatus(targets []TargetInfo) ([]TargetInfo, []Tar
 	return notDrainingTargets, drainingTargets
 }
 
+// buildTargetsStatus summarizes the registration state of targets for TargetGroupBinding status.
+// matchedTargets are targets matching desired endpoints, unmatchedEndpointCount is the number of endpoints just registered,
+// drainingTargets are targets already draining, and deregisteredTargets are targets just deregistered.
+func buildTargetsStatus(matchedTargets []TargetInfo, unmatchedEndpointCount int, drainingTargets []TargetInfo, deregisteredTargets []TargetInfo) *elbv2api.TargetsStatus {
+	status := &elbv2api.TargetsStatus{
+		Registered:          int32(len(matchedTargets)),
+		PendingRegistration: int32(unmatchedEndpointCount),
+		Draining:            int32(len(drainingTargets) + len(deregisteredTargets)),
+	}
+	for _, target := range matchedTargets {
+		if target.IsHealthy() {
+			status.Healthy++
+		}
+		if target.IsInitial() {
+			status.PendingRegistration++
+		}
+	}
+	return status
+}
+
 func containsTargetsInInitialState(matchedEndpointAndTargets []podEndpointAndTargetPair) bool {
 	for _, endpointAndTarget := range matchedEndpointAndTargets {
 		if endpointAndTarget.target.IsInitial() {
@@ -518,3 +755,77 @@ func isELBV2TargetGroupARNInvalidError(err error) bool {
 	}
 	return false
 }
+
+// filterDeregistrationRequestedPodEndpoints returns the endpoints whose pod didn't request deregistration of its targets at now,
+// along with the earliest time a deregistration request of pods that aren't terminating expires, zero if there is none.
+func filterDeregistrationRequestedPodEndpoints(endpoints []backend.PodEndpoint, now time.Time, drainTimeout time.Duration) ([]backend.PodEndpoint, time.Time) {
+	filteredEndpoints := make([]backend.PodEndpoint, 0, len(endpoints))
+	var earliestExpiry time.Time
+	for _, endpoint := range endpoints {
+		if !endpoint.Pod.IsDeregistrationRequested(now, drainTimeout) {
+			filteredEndpoints = append(filteredEndpoints, endpoint)
+			continue
+		}
+		if endpoint.Pod.Terminating {
+			continue
+		}
+		expiry := k8s.DeregistrationRequestExpiry(endpoint.Pod.DeregistrationRequestedAt, drainTimeout)
+		if earliestExpiry.IsZero() || expiry.Before(earliestExpiry) {
+			earliestExpiry = expiry
+		}
+	}
+	return filteredEndpoints, earliestExpiry
+}
+
+// partitionPodEndpointsByPodBacking partitions endpoints into endpoints backed by pods and the ones that aren't.
+func partitionPodEndpointsByPodBacking(endpoints []backend.PodEndpoint) ([]backend.PodEndpoint, []backend.PodEndpoint) {
+	var podBackedEndpoints []backend.PodEndpoint
+	var otherEndpoints []backend.PodEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.IsPodBacked() {
+			podBackedEndpoints = append(podBackedEndpoints, endpoint)
+		} else {
+			otherEndpoints = append(otherEndpoints, endpoint)
+		}
+	}
+	return podBackedEndpoints, otherEndpoints
+}
+
+// partitionPodEndpointsByCIDRs partitions endpoints into endpoints within cidrs and the ones that aren't.
+func partitionPodEndpointsByCIDRs(endpoints []backend.PodEndpoint, cidrs []netaddr.IPPrefix) ([]backend.PodEndpoint, []backend.PodEndpoint, error) {
+	var endpointsWithinCIDRs []backend.PodEndpoint
+	var otherEndpoints []backend.PodEndpoint
+	for _, endpoint := range endpoints {
+		ip, err := netaddr.ParseIP(endpoint.IP)
+		if err != nil {
+			return nil, nil, err
+		}
+		if networking.IsIPWithinCIDRs(ip, cidrs) {
+			endpointsWithinCIDRs = append(endpointsWithinCIDRs, endpoint)
+		} else {
+			otherEndpoints = append(otherEndpoints, endpoint)
+		}
+	}
+	return endpointsWithinCIDRs, otherEndpoints, nil
+}
+
+// detectTargetPortChange detects ports of targets that are going to be re-registered with different ports.
+// a target's port is considered changed if its id is going to be registered without its current port.
+// returns the sorted old ports and new ports, which are empty if no port changed.
+func detectTargetPortChange(unmatchedTargets []TargetInfo, unmatchedEndpointPortsByID map[string]sets.Int64) ([]int64, []int64) {
+	oldPorts := sets.NewInt64()
+	newPorts := sets.NewInt64()
+	for _, target := range unmatchedTargets {
+		endpointPorts, ok := unmatchedEndpointPortsByID[awssdk.StringValue(target.Target.Id)]
+		if !ok {
+			continue
+		}
+		targetPort := awssdk.Int64Value(target.Target.Port)
+		if endpointPorts.Has(targetPort) {
+			continue
+		}
+		oldPorts.Insert(targetPort)
+		newPorts.Insert(endpointPorts.List()...)
+	}
+	return oldPorts.List(), newPorts.List()
+}
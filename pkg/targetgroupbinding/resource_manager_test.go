@@ -3,17 +3,23 @@ package targetgroupbinding
 import (
 	"context"
 	"testing"
+	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"inet.af/netaddr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/equality"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -459,6 +465,316 @@ func Test_containsTargetsInInitialState(t *testing.T) {
 	}
 }
 
+func Test_filterDeregistrationRequestedPodEndpoints(t *testing.T) {
+	now := time.Date(2021, 10, 1, 0, 10, 0, 0, time.UTC)
+	endpoint1 := backend.PodEndpoint{
+		IP:   "192.168.1.1",
+		Port: 8080,
+		Pod:  k8s.PodInfo{Key: types.NamespacedName{Namespace: "default", Name: "pod-1"}},
+	}
+	endpoint2 := backend.PodEndpoint{
+		IP:   "192.168.1.2",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key:                       types.NamespacedName{Namespace: "default", Name: "pod-2"},
+			DeregistrationRequestedAt: now.Add(-2 * time.Minute),
+		},
+	}
+	endpoint3 := backend.PodEndpoint{
+		IP:   "192.168.1.3",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key:                       types.NamespacedName{Namespace: "default", Name: "pod-3"},
+			DeregistrationRequestedAt: now.Add(-10 * time.Minute),
+		},
+	}
+	endpoint4 := backend.PodEndpoint{
+		IP:   "192.168.1.4",
+		Port: 8080,
+		Pod: k8s.PodInfo{
+			Key:                       types.NamespacedName{Namespace: "default", Name: "pod-4"},
+			DeregistrationRequestedAt: now.Add(-10 * time.Minute),
+			Terminating:               true,
+		},
+	}
+	tests := []struct {
+		name       string
+		endpoints  []backend.PodEndpoint
+		want       []backend.PodEndpoint
+		wantExpiry time.Time
+	}{
+		{
+			name:      "no pod requested deregistration",
+			endpoints: []backend.PodEndpoint{endpoint1},
+			want:      []backend.PodEndpoint{endpoint1},
+		},
+		{
+			name:       "some pod requested deregistration",
+			endpoints:  []backend.PodEndpoint{endpoint1, endpoint2},
+			want:       []backend.PodEndpoint{endpoint1},
+			wantExpiry: now.Add(4 * time.Minute),
+		},
+		{
+			name:      "deregistration request of pod whose eviction got rejected expired",
+			endpoints: []backend.PodEndpoint{endpoint1, endpoint3},
+			want:      []backend.PodEndpoint{endpoint1, endpoint3},
+		},
+		{
+			name:      "deregistration request of terminating pod",
+			endpoints: []backend.PodEndpoint{endpoint1, endpoint4},
+			want:      []backend.PodEndpoint{endpoint1},
+		},
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+			want:      []backend.PodEndpoint{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotExpiry := filterDeregistrationRequestedPodEndpoints(tt.endpoints, now, 5*time.Minute)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantExpiry, gotExpiry)
+		})
+	}
+}
+
+func Test_partitionPodEndpointsByPodBacking(t *testing.T) {
+	podEndpoint := backend.PodEndpoint{
+		IP:   "192.168.1.1",
+		Port: 8080,
+		Pod:  k8s.PodInfo{Key: types.NamespacedName{Namespace: "default", Name: "pod-1"}},
+	}
+	customEndpoint := backend.PodEndpoint{
+		IP:   "10.100.0.1",
+		Port: 8080,
+	}
+	tests := []struct {
+		name                   string
+		endpoints              []backend.PodEndpoint
+		wantPodBackedEndpoints []backend.PodEndpoint
+		wantOtherEndpoints     []backend.PodEndpoint
+	}{
+		{
+			name:                   "only endpoints backed by pods",
+			endpoints:              []backend.PodEndpoint{podEndpoint},
+			wantPodBackedEndpoints: []backend.PodEndpoint{podEndpoint},
+		},
+		{
+			name:                   "endpoints backed by pods and endpoints from custom EndpointSlices",
+			endpoints:              []backend.PodEndpoint{customEndpoint, podEndpoint},
+			wantPodBackedEndpoints: []backend.PodEndpoint{podEndpoint},
+			wantOtherEndpoints:     []backend.PodEndpoint{customEndpoint},
+		},
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPodBackedEndpoints, gotOtherEndpoints := partitionPodEndpointsByPodBacking(tt.endpoints)
+			assert.Equal(t, tt.wantPodBackedEndpoints, gotPodBackedEndpoints)
+			assert.Equal(t, tt.wantOtherEndpoints, gotOtherEndpoints)
+		})
+	}
+}
+
+func Test_partitionPodEndpointsByCIDRs(t *testing.T) {
+	inVPCEndpoint := backend.PodEndpoint{
+		IP:   "192.168.1.1",
+		Port: 8080,
+	}
+	outOfVPCEndpoint := backend.PodEndpoint{
+		IP:   "10.100.0.1",
+		Port: 8080,
+	}
+	cidrs := []netaddr.IPPrefix{netaddr.MustParseIPPrefix("192.168.0.0/16")}
+	tests := []struct {
+		name                     string
+		endpoints                []backend.PodEndpoint
+		wantEndpointsWithinCIDRs []backend.PodEndpoint
+		wantOtherEndpoints       []backend.PodEndpoint
+		wantErr                  error
+	}{
+		{
+			name:                     "endpoints within and outside cidrs",
+			endpoints:                []backend.PodEndpoint{outOfVPCEndpoint, inVPCEndpoint},
+			wantEndpointsWithinCIDRs: []backend.PodEndpoint{inVPCEndpoint},
+			wantOtherEndpoints:       []backend.PodEndpoint{outOfVPCEndpoint},
+		},
+		{
+			name:      "no endpoints",
+			endpoints: nil,
+		},
+		{
+			name:      "invalid endpoint IP",
+			endpoints: []backend.PodEndpoint{{IP: "invalid", Port: 8080}},
+			wantErr:   errors.New(`ParseIP("invalid"): unable to parse IP`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEndpointsWithinCIDRs, gotOtherEndpoints, err := partitionPodEndpointsByCIDRs(tt.endpoints, cidrs)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEndpointsWithinCIDRs, gotEndpointsWithinCIDRs)
+				assert.Equal(t, tt.wantOtherEndpoints, gotOtherEndpoints)
+			}
+		})
+	}
+}
+
+func Test_detectTargetPortChange(t *testing.T) {
+	type args struct {
+		unmatchedTargets           []TargetInfo
+		unmatchedEndpointPortsByID map[string]sets.Int64
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantOldPorts []int64
+		wantNewPorts []int64
+	}{
+		{
+			name: "nodePort changed",
+			args: args{
+				unmatchedTargets: []TargetInfo{
+					{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-abcdefg1"), Port: awssdk.Int64(30080)}},
+					{Target: elbv2sdk.TargetDescription{Id: awssdk.String("i-abcdefg2"), Port: awssdk.Int64(30080)}},
+				},
+				unmatchedEndpointPortsByID: map[string]sets.Int64{
+					"i-abcdefg1": sets.NewInt64(30081),
+					"i-abcdefg2": sets.NewInt64(30081),
+				},
+			},
+			wantOldPorts: []int64{30080},
+			wantNewPorts: []int64{30081},
+		},
+		{
+			name: "targets removed without port change",
+			args: args{
+				unmatchedTargets: []TargetInfo{
+					{Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)}},
+				},
+				unmatchedEndpointPortsByID: map[string]sets.Int64{
+					"192.168.1.2": sets.NewInt64(8080),
+				},
+			},
+			wantOldPorts: []int64{},
+			wantNewPorts: []int64{},
+		},
+		{
+			name: "target still registered with one of its ports",
+			args: args{
+				unmatchedTargets: []TargetInfo{
+					{Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)}},
+				},
+				unmatchedEndpointPortsByID: map[string]sets.Int64{
+					"192.168.1.1": sets.NewInt64(8080, 9090),
+				},
+			},
+			wantOldPorts: []int64{},
+			wantNewPorts: []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOldPorts, gotNewPorts := detectTargetPortChange(tt.args.unmatchedTargets, tt.args.unmatchedEndpointPortsByID)
+			assert.Equal(t, tt.wantOldPorts, gotOldPorts)
+			assert.Equal(t, tt.wantNewPorts, gotNewPorts)
+		})
+	}
+}
+
+func Test_buildTargetsStatus(t *testing.T) {
+	healthyTarget := TargetInfo{
+		Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+		TargetHealth: &elbv2sdk.TargetHealth{
+			State: awssdk.String(elbv2sdk.TargetHealthStateEnumHealthy),
+		},
+	}
+	initialTarget := TargetInfo{
+		Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+		TargetHealth: &elbv2sdk.TargetHealth{
+			State:  awssdk.String(elbv2sdk.TargetHealthStateEnumInitial),
+			Reason: awssdk.String(elbv2sdk.TargetHealthReasonEnumElbRegistrationInProgress),
+		},
+	}
+	unhealthyTarget := TargetInfo{
+		Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.3"), Port: awssdk.Int64(8080)},
+		TargetHealth: &elbv2sdk.TargetHealth{
+			State: awssdk.String(elbv2sdk.TargetHealthStateEnumUnhealthy),
+		},
+	}
+	drainingTarget := TargetInfo{
+		Target: elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.4"), Port: awssdk.Int64(8080)},
+		TargetHealth: &elbv2sdk.TargetHealth{
+			State: awssdk.String(elbv2sdk.TargetHealthStateEnumDraining),
+		},
+	}
+	type args struct {
+		matchedTargets         []TargetInfo
+		unmatchedEndpointCount int
+		drainingTargets        []TargetInfo
+		deregisteredTargets    []TargetInfo
+	}
+	tests := []struct {
+		name string
+		args args
+		want *elbv2api.TargetsStatus
+	}{
+		{
+			name: "no targets",
+			args: args{},
+			want: &elbv2api.TargetsStatus{},
+		},
+		{
+			name: "all targets healthy",
+			args: args{
+				matchedTargets: []TargetInfo{healthyTarget},
+			},
+			want: &elbv2api.TargetsStatus{
+				Registered: 1,
+				Healthy:    1,
+			},
+		},
+		{
+			name: "targets pending registration",
+			args: args{
+				matchedTargets:         []TargetInfo{healthyTarget, initialTarget, unhealthyTarget},
+				unmatchedEndpointCount: 2,
+			},
+			want: &elbv2api.TargetsStatus{
+				Registered:          3,
+				Healthy:             1,
+				PendingRegistration: 3,
+			},
+		},
+		{
+			name: "targets draining",
+			args: args{
+				matchedTargets:      []TargetInfo{healthyTarget},
+				drainingTargets:     []TargetInfo{drainingTarget},
+				deregisteredTargets: []TargetInfo{unhealthyTarget},
+			},
+			want: &elbv2api.TargetsStatus{
+				Registered: 1,
+				Healthy:    1,
+				Draining:   2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildTargetsStatus(tt.args.matchedTargets, tt.args.unmatchedEndpointCount, tt.args.drainingTargets, tt.args.deregisteredTargets)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_buildPodConditionPatch(t *testing.T) {
 	type args struct {
 		pod       k8s.PodInfo
@@ -501,3 +817,109 @@ func Test_buildPodConditionPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultResourceManager_filterOwnedTargets(t *testing.T) {
+	buildTarget := func(ip string, state string) TargetInfo {
+		return TargetInfo{
+			Target: elbv2sdk.TargetDescription{
+				Id:   awssdk.String(ip),
+				Port: awssdk.Int64(8080),
+			},
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State: awssdk.String(state),
+			},
+		}
+	}
+	ownedTarget := buildTarget("192.168.1.1", elbv2sdk.TargetHealthStateEnumHealthy)
+	foreignTarget := buildTarget("10.0.1.1", elbv2sdk.TargetHealthStateEnumHealthy)
+	ownedDrainingTarget := buildTarget("192.168.1.2", elbv2sdk.TargetHealthStateEnumDraining)
+	foreignDrainingTarget := buildTarget("10.0.1.2", elbv2sdk.TargetHealthStateEnumDraining)
+
+	type args struct {
+		multiClusterTargetGroup bool
+		desiredTargetIDs        sets.String
+		unmatchedTargets        []TargetInfo
+		drainingTargets         []TargetInfo
+	}
+	tests := []struct {
+		name                 string
+		ownedTargetIDs       sets.String
+		args                 args
+		wantUnmatchedTargets []TargetInfo
+		wantDrainingTargets  []TargetInfo
+		wantOwnedTargetIDs   sets.String
+	}{
+		{
+			name: "targetGroup isn't shared with other clusters",
+			args: args{
+				multiClusterTargetGroup: false,
+				desiredTargetIDs:        sets.NewString("192.168.1.3:8080"),
+				unmatchedTargets:        []TargetInfo{ownedTarget, foreignTarget},
+				drainingTargets:         []TargetInfo{ownedDrainingTarget, foreignDrainingTarget},
+			},
+			wantUnmatchedTargets: []TargetInfo{ownedTarget, foreignTarget},
+			wantDrainingTargets:  []TargetInfo{ownedDrainingTarget, foreignDrainingTarget},
+			wantOwnedTargetIDs:   sets.NewString(),
+		},
+		{
+			name:           "targetGroup is shared with other clusters",
+			ownedTargetIDs: sets.NewString("192.168.1.1:8080", "192.168.1.2:8080", "192.168.1.4:8080"),
+			args: args{
+				multiClusterTargetGroup: true,
+				desiredTargetIDs:        sets.NewString("192.168.1.3:8080"),
+				unmatchedTargets:        []TargetInfo{ownedTarget, foreignTarget},
+				drainingTargets:         []TargetInfo{ownedDrainingTarget, foreignDrainingTarget},
+			},
+			wantUnmatchedTargets: []TargetInfo{ownedTarget},
+			wantDrainingTargets:  []TargetInfo{ownedDrainingTarget},
+			wantOwnedTargetIDs:   sets.NewString("192.168.1.1:8080", "192.168.1.2:8080", "192.168.1.3:8080"),
+		},
+		{
+			name: "targetGroup is shared with other clusters and no targets are owned",
+			args: args{
+				multiClusterTargetGroup: true,
+				desiredTargetIDs:        sets.NewString("192.168.1.3:8080"),
+				unmatchedTargets:        []TargetInfo{foreignTarget},
+				drainingTargets:         []TargetInfo{foreignDrainingTarget},
+			},
+			wantUnmatchedTargets: nil,
+			wantDrainingTargets:  nil,
+			wantOwnedTargetIDs:   sets.NewString("192.168.1.3:8080"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			multiClusterManager := NewDefaultMultiClusterManager(k8sClient, &log.NullLogger{})
+			tgb := &elbv2api.TargetGroupBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "awesome-ns",
+					Name:      "tgb-1",
+				},
+				Spec: elbv2api.TargetGroupBindingSpec{
+					MultiClusterTargetGroup: tt.args.multiClusterTargetGroup,
+				},
+			}
+			if tt.ownedTargetIDs != nil {
+				tgb.Status.OwnedTargets = tt.ownedTargetIDs.List()
+			}
+			assert.NoError(t, k8sClient.Create(ctx, tgb))
+			m := &defaultResourceManager{
+				multiClusterManager: multiClusterManager,
+			}
+			gotUnmatchedTargets, gotDrainingTargets, err := m.filterOwnedTargets(ctx, tgb, tt.args.desiredTargetIDs,
+				tt.args.unmatchedTargets, tt.args.drainingTargets)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnmatchedTargets, gotUnmatchedTargets)
+			assert.Equal(t, tt.wantDrainingTargets, gotDrainingTargets)
+			assert.Equal(t, tt.wantOwnedTargetIDs, multiClusterManager.ListOwnedTargetIDs(tgb))
+			gotTGB := &elbv2api.TargetGroupBinding{}
+			assert.NoError(t, k8sClient.Get(ctx, k8s.NamespacedName(tgb), gotTGB))
+			assert.Equal(t, tt.wantOwnedTargetIDs, sets.NewString(gotTGB.Status.OwnedTargets...))
+		})
+	}
+}
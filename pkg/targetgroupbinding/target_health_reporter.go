@@ -0,0 +1,232 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	metricSubsystemTargetGroupBinding = "targetgroupbinding"
+
+	metricPodTargetHealth = "pod_target_health"
+	metricTargets         = "targets"
+)
+
+const (
+	labelNamespace          = "namespace"
+	labelPod                = "pod"
+	labelPodReady           = "pod_ready"
+	labelTargetGroupBinding = "target_group_binding"
+	labelTargetGroup        = "target_group"
+	labelState              = "state"
+	labelReason             = "reason"
+)
+
+// NewTargetHealthReporter constructs new targetHealthReporter, and registers its metrics to registerer.
+func NewTargetHealthReporter(k8sClient client.Client, elbv2Client services.ELBV2, endpointResolver backend.EndpointResolver,
+	enableEndpointSlices bool, interval time.Duration, registerer prometheus.Registerer, logger logr.Logger) (*targetHealthReporter, error) {
+	podTargetHealth := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricPodTargetHealth,
+		Help:      "Target health of pods registered as targets, the value is always 1",
+	}, []string{labelNamespace, labelPod, labelPodReady, labelTargetGroupBinding, labelTargetGroup, labelState, labelReason})
+	targets := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricSubsystemTargetGroupBinding,
+		Name:      metricTargets,
+		Help:      "Number of targets in target groups by target health",
+	}, []string{labelNamespace, labelTargetGroupBinding, labelTargetGroup, labelState, labelReason})
+	if err := registerer.Register(podTargetHealth); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(targets); err != nil {
+		return nil, err
+	}
+	return &targetHealthReporter{
+		k8sClient:            k8sClient,
+		elbv2Client:          elbv2Client,
+		endpointResolver:     endpointResolver,
+		enableEndpointSlices: enableEndpointSlices,
+		interval:             interval,
+		podTargetHealth:      podTargetHealth,
+		targets:              targets,
+		logger:               logger,
+	}, nil
+}
+
+var _ manager.Runnable = &targetHealthReporter{}
+var _ manager.LeaderElectionRunnable = &targetHealthReporter{}
+
+// targetHealthReporter periodically reports target health of TargetGroupBindings as Prometheus metrics,
+// and as pod conditions for pods registered as `ip` targets, regardless of whether pods have the targetHealth readiness gate.
+// unlike the readiness gate, which stops probing once pods turn ready, it keeps pods that fail health checks later on observable.
+type targetHealthReporter struct {
+	k8sClient            client.Client
+	elbv2Client          services.ELBV2
+	endpointResolver     backend.EndpointResolver
+	enableEndpointSlices bool
+	interval             time.Duration
+
+	podTargetHealth *prometheus.GaugeVec
+	targets         *prometheus.GaugeVec
+
+	logger logr.Logger
+}
+
+func (r *targetHealthReporter) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.report(ctx); err != nil {
+			r.logger.Error(err, "failed to report target health")
+		}
+	}, r.interval)
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader reports target health, thus metrics are only exposed by the leader.
+func (r *targetHealthReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// targetHealthSample is a sample of targetHealthReporter metrics.
+type targetHealthSample struct {
+	labels prometheus.Labels
+	value  float64
+}
+
+func (r *targetHealthReporter) report(ctx context.Context) error {
+	tgbList := &elbv2api.TargetGroupBindingList{}
+	if err := r.k8sClient.List(ctx, tgbList); err != nil {
+		return errors.Wrap(err, "failed to list targetGroupBindings")
+	}
+	var podTargetHealthSamples, targetsSamples []targetHealthSample
+	for i := range tgbList.Items {
+		tgb := &tgbList.Items[i]
+		if !tgb.DeletionTimestamp.IsZero() {
+			continue
+		}
+		podSamples, tgSamples, err := r.reportTargetGroupBinding(ctx, tgb)
+		if err != nil {
+			// metrics of failed TargetGroupBindings are absent until next report, rather than being stale.
+			r.logger.Error(err, "failed to report target health", "tgb", k8s.NamespacedName(tgb))
+			continue
+		}
+		podTargetHealthSamples = append(podTargetHealthSamples, podSamples...)
+		targetsSamples = append(targetsSamples, tgSamples...)
+	}
+
+	r.podTargetHealth.Reset()
+	for _, sample := range podTargetHealthSamples {
+		r.podTargetHealth.With(sample.labels).Set(sample.value)
+	}
+	r.targets.Reset()
+	for _, sample := range targetsSamples {
+		r.targets.With(sample.labels).Set(sample.value)
+	}
+	return nil
+}
+
+// reportTargetGroupBinding patches pod conditions for targets of tgb, and returns samples of podTargetHealth and targets metrics.
+func (r *targetHealthReporter) reportTargetGroupBinding(ctx context.Context, tgb *elbv2api.TargetGroupBinding) ([]targetHealthSample, []targetHealthSample, error) {
+	tgARN := tgb.Spec.TargetGroupARN
+	resp, err := r.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgARN),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	targets := make([]TargetInfo, 0, len(resp.TargetHealthDescriptions))
+	for _, elem := range resp.TargetHealthDescriptions {
+		targets = append(targets, TargetInfo{
+			Target:       *elem.Target,
+			TargetHealth: elem.TargetHealth,
+		})
+	}
+
+	targetsCountByHealth := make(map[[2]string]int)
+	for _, target := range targets {
+		state, reason := buildTargetHealthStateAndReason(target.TargetHealth)
+		targetsCountByHealth[[2]string{state, reason}]++
+	}
+	var targetsSamples []targetHealthSample
+	for health, count := range targetsCountByHealth {
+		targetsSamples = append(targetsSamples, targetHealthSample{
+			labels: prometheus.Labels{
+				labelNamespace:          tgb.Namespace,
+				labelTargetGroupBinding: tgb.Name,
+				labelTargetGroup:        tgARN,
+				labelState:              health[0],
+				labelReason:             health[1],
+			},
+			value: float64(count),
+		})
+	}
+
+	if tgb.Spec.TargetType == nil || *tgb.Spec.TargetType != elbv2api.TargetTypeIP {
+		return nil, targetsSamples, nil
+	}
+	svcKey := buildServiceReferenceKey(tgb, tgb.Spec.ServiceRef)
+	resolveOpts := []backend.EndpointResolveOption{
+		backend.WithPodReadinessGate(BuildTargetHealthPodConditionType(tgb)),
+	}
+	var endpoints []backend.PodEndpoint
+	if r.enableEndpointSlices {
+		endpoints, _, err = r.endpointResolver.ResolvePodEndpointsFromSlices(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
+	} else {
+		endpoints, _, err = r.endpointResolver.ResolvePodEndpoints(ctx, svcKey, tgb.Spec.ServiceRef.Port, resolveOpts...)
+	}
+	if err != nil {
+		if errors.Is(err, backend.ErrNotFound) {
+			return nil, targetsSamples, nil
+		}
+		return nil, nil, err
+	}
+	endpoints, _ = partitionPodEndpointsByPodBacking(endpoints)
+	matchedEndpointAndTargets, _, _ := matchPodEndpointWithTargets(endpoints, targets)
+
+	targetHealthReportCondType := BuildTargetHealthReportPodConditionType(tgb)
+	podTargetHealthSamples := make([]targetHealthSample, 0, len(matchedEndpointAndTargets))
+	for _, endpointAndTarget := range matchedEndpointAndTargets {
+		pod := endpointAndTarget.endpoint.Pod
+		targetHealth := endpointAndTarget.target.TargetHealth
+		if _, err := patchTargetHealthPodCondition(ctx, r.k8sClient, pod, targetHealth, targetHealthReportCondType); err != nil {
+			return nil, nil, err
+		}
+		podReadyCond, _ := pod.GetPodCondition(corev1.PodReady)
+		state, reason := buildTargetHealthStateAndReason(targetHealth)
+		podTargetHealthSamples = append(podTargetHealthSamples, targetHealthSample{
+			labels: prometheus.Labels{
+				labelNamespace:          pod.Key.Namespace,
+				labelPod:                pod.Key.Name,
+				labelPodReady:           strconv.FormatBool(podReadyCond.Status == corev1.ConditionTrue),
+				labelTargetGroupBinding: tgb.Name,
+				labelTargetGroup:        tgARN,
+				labelState:              state,
+				labelReason:             reason,
+			},
+			value: 1,
+		})
+	}
+	return podTargetHealthSamples, targetsSamples, nil
+}
+
+// buildTargetHealthStateAndReason returns the state and reason code of targetHealth, state is "unavailable" if targetHealth is absent.
+func buildTargetHealthStateAndReason(targetHealth *elbv2sdk.TargetHealth) (string, string) {
+	if targetHealth == nil {
+		return elbv2sdk.TargetHealthStateEnumUnavailable, ""
+	}
+	return awssdk.StringValue(targetHealth.State), awssdk.StringValue(targetHealth.Reason)
+}
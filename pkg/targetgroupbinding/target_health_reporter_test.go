@@ -0,0 +1,172 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// staticEndpointResolver resolves the same podEndpoints for every Service.
+type staticEndpointResolver struct {
+	backend.EndpointResolver
+	podEndpoints []backend.PodEndpoint
+}
+
+func (r *staticEndpointResolver) ResolvePodEndpoints(_ context.Context, _ types.NamespacedName, _ intstr.IntOrString,
+	_ ...backend.EndpointResolveOption) ([]backend.PodEndpoint, bool, error) {
+	return r.podEndpoints, false, nil
+}
+
+func Test_targetHealthReporter_report(t *testing.T) {
+	targetTypeIP := elbv2api.TargetTypeIP
+	targetTypeInstance := elbv2api.TargetTypeInstance
+	tgbs := []*elbv2api.TargetGroupBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-ip"},
+			Spec: elbv2api.TargetGroupBindingSpec{
+				TargetGroupARN: "tg-ip-arn",
+				TargetType:     &targetTypeIP,
+				ServiceRef:     elbv2api.ServiceReference{Name: "svc", Port: intstr.FromInt(80)},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "tgb-instance"},
+			Spec: elbv2api.TargetGroupBindingSpec{
+				TargetGroupARN: "tg-instance-arn",
+				TargetType:     &targetTypeInstance,
+				ServiceRef:     elbv2api.ServiceReference{Name: "svc", Port: intstr.FromInt(80)},
+			},
+		},
+	}
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "pod-1", UID: "pod-1-uuid"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "pod-2", UID: "pod-2-uuid"}},
+	}
+	podEndpoints := []backend.PodEndpoint{
+		{
+			IP:   "192.168.1.1",
+			Port: 8080,
+			Pod: k8s.PodInfo{
+				Key:        types.NamespacedName{Namespace: "awesome-ns", Name: "pod-1"},
+				UID:        "pod-1-uuid",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		},
+		{
+			IP:   "192.168.1.2",
+			Port: 8080,
+			Pod: k8s.PodInfo{
+				Key:        types.NamespacedName{Namespace: "awesome-ns", Name: "pod-2"},
+				UID:        "pod-2-uuid",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	elbv2Client := services.NewMockELBV2(ctrl)
+	elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String("tg-ip-arn"),
+	}).Return(&elbv2sdk.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+			{
+				Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.1"), Port: awssdk.Int64(8080)},
+				TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String("healthy")},
+			},
+			{
+				Target: &elbv2sdk.TargetDescription{Id: awssdk.String("192.168.1.2"), Port: awssdk.Int64(8080)},
+				TargetHealth: &elbv2sdk.TargetHealth{
+					State:       awssdk.String("unhealthy"),
+					Reason:      awssdk.String("Target.ResponseCodeMismatch"),
+					Description: awssdk.String("Health checks failed with these codes: [502]"),
+				},
+			},
+		},
+	}, nil)
+	elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String("tg-instance-arn"),
+	}).Return(&elbv2sdk.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+			{
+				Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-1"), Port: awssdk.Int64(30080)},
+				TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String("healthy")},
+			},
+			{
+				Target:       &elbv2sdk.TargetDescription{Id: awssdk.String("i-2"), Port: awssdk.Int64(30080)},
+				TargetHealth: &elbv2sdk.TargetHealth{State: awssdk.String("healthy")},
+			},
+		},
+	}, nil)
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	elbv2api.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+	ctx := context.Background()
+	for _, tgb := range tgbs {
+		assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+	}
+	for _, pod := range pods {
+		assert.NoError(t, k8sClient.Create(ctx, pod.DeepCopy()))
+	}
+
+	registry := prometheus.NewRegistry()
+	r, err := NewTargetHealthReporter(k8sClient, elbv2Client, &staticEndpointResolver{podEndpoints: podEndpoints}, false, 0, registry, &log.NullLogger{})
+	assert.NoError(t, err)
+	assert.NoError(t, r.report(ctx))
+
+	wantMetrics := `
+# HELP targetgroupbinding_pod_target_health Target health of pods registered as targets, the value is always 1
+# TYPE targetgroupbinding_pod_target_health gauge
+targetgroupbinding_pod_target_health{namespace="awesome-ns",pod="pod-1",pod_ready="true",reason="",state="healthy",target_group="tg-ip-arn",target_group_binding="tgb-ip"} 1
+targetgroupbinding_pod_target_health{namespace="awesome-ns",pod="pod-2",pod_ready="true",reason="Target.ResponseCodeMismatch",state="unhealthy",target_group="tg-ip-arn",target_group_binding="tgb-ip"} 1
+# HELP targetgroupbinding_targets Number of targets in target groups by target health
+# TYPE targetgroupbinding_targets gauge
+targetgroupbinding_targets{namespace="awesome-ns",reason="",state="healthy",target_group="tg-instance-arn",target_group_binding="tgb-instance"} 2
+targetgroupbinding_targets{namespace="awesome-ns",reason="",state="healthy",target_group="tg-ip-arn",target_group_binding="tgb-ip"} 1
+targetgroupbinding_targets{namespace="awesome-ns",reason="Target.ResponseCodeMismatch",state="unhealthy",target_group="tg-ip-arn",target_group_binding="tgb-ip"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(wantMetrics)))
+
+	wantConditions := map[string]corev1.PodCondition{
+		"pod-1": {
+			Type:   "target-health-report.elbv2.k8s.aws/tgb-ip",
+			Status: corev1.ConditionTrue,
+		},
+		"pod-2": {
+			Type:    "target-health-report.elbv2.k8s.aws/tgb-ip",
+			Status:  corev1.ConditionFalse,
+			Reason:  "Target.ResponseCodeMismatch",
+			Message: "Health checks failed with these codes: [502]",
+		},
+	}
+	for podName, wantCondition := range wantConditions {
+		pod := &corev1.Pod{}
+		assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: podName}, pod))
+		if assert.Len(t, pod.Status.Conditions, 1) {
+			gotCondition := pod.Status.Conditions[0]
+			gotCondition.LastTransitionTime = metav1.Time{}
+			assert.Equal(t, wantCondition, gotCondition)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TargetsSyncTracker tracks the backend versions that targets of TargetGroupBindings are synced with,
+// so that reconciling targets can be skipped while the backend is unchanged.
+type TargetsSyncTracker interface {
+	// BackendVersion returns the version of backend Service, which changes whenever the Service or its Endpoints change.
+	// returns empty version if the backend isn't found.
+	BackendVersion(ctx context.Context, svcKey types.NamespacedName) (string, error)
+
+	// IsSynced checks whether targets of tgb are synced with backendVersion, and have been verified within the verify interval.
+	IsSynced(tgb *elbv2api.TargetGroupBinding, backendVersion string) bool
+
+	// MarkSynced records that targets of tgb are synced with backendVersion.
+	MarkSynced(tgb *elbv2api.TargetGroupBinding, backendVersion string)
+
+	// Forget forgets the sync state of tgb, so that its targets will be reconciled next time.
+	Forget(tgbKey types.NamespacedName)
+}
+
+// NewDefaultTargetsSyncTracker constructs new defaultTargetsSyncTracker.
+func NewDefaultTargetsSyncTracker(k8sClient client.Client, useEndpointSlices bool, verifyInterval time.Duration) *defaultTargetsSyncTracker {
+	return &defaultTargetsSyncTracker{
+		k8sClient:         k8sClient,
+		useEndpointSlices: useEndpointSlices,
+		verifyInterval:    verifyInterval,
+		now:               time.Now,
+		syncStateByTGB:    make(map[types.NamespacedName]targetsSyncState),
+	}
+}
+
+var _ TargetsSyncTracker = &defaultTargetsSyncTracker{}
+
+// defaultTargetsSyncTracker tracks sync states in memory, thus targets are always reconciled once after controller restarts.
+// the backend version is built from resourceVersions of Service and its Endpoints or EndpointSlices,
+// targets are still verified periodically to recover from changes out of band, e.g. targets deregistered by other parties.
+type defaultTargetsSyncTracker struct {
+	k8sClient         client.Client
+	useEndpointSlices bool
+	verifyInterval    time.Duration
+	now               func() time.Time
+
+	syncStateByTGBMutex sync.Mutex
+	syncStateByTGB      map[types.NamespacedName]targetsSyncState
+}
+
+// targetsSyncState is the state of the last successful targets sync for TargetGroupBinding.
+type targetsSyncState struct {
+	tgbUID         types.UID
+	tgbGeneration  int64
+	backendVersion string
+	syncTime       time.Time
+}
+
+func (t *defaultTargetsSyncTracker) BackendVersion(ctx context.Context, svcKey types.NamespacedName) (string, error) {
+	svc := &corev1.Service{}
+	if err := t.k8sClient.Get(ctx, svcKey, svc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	var endpointsVersion string
+	if t.useEndpointSlices {
+		epSliceList := &discv1.EndpointSliceList{}
+		if err := t.k8sClient.List(ctx, epSliceList,
+			client.InNamespace(svcKey.Namespace),
+			client.MatchingLabels{discv1.LabelServiceName: svcKey.Name}); err != nil {
+			return "", err
+		}
+		if len(epSliceList.Items) == 0 {
+			return "", nil
+		}
+		epSliceVersions := make([]string, 0, len(epSliceList.Items))
+		for _, epSlice := range epSliceList.Items {
+			epSliceVersions = append(epSliceVersions, fmt.Sprintf("%v=%v", epSlice.Name, epSlice.ResourceVersion))
+		}
+		sort.Strings(epSliceVersions)
+		endpointsVersion = strings.Join(epSliceVersions, ",")
+	} else {
+		eps := &corev1.Endpoints{}
+		if err := t.k8sClient.Get(ctx, svcKey, eps); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		endpointsVersion = eps.ResourceVersion
+	}
+	return fmt.Sprintf("service=%v;endpoints=%v", svc.ResourceVersion, endpointsVersion), nil
+}
+
+func (t *defaultTargetsSyncTracker) IsSynced(tgb *elbv2api.TargetGroupBinding, backendVersion string) bool {
+	if backendVersion == "" {
+		return false
+	}
+	t.syncStateByTGBMutex.Lock()
+	defer t.syncStateByTGBMutex.Unlock()
+	syncState, exists := t.syncStateByTGB[k8s.NamespacedName(tgb)]
+	if !exists {
+		return false
+	}
+	return syncState.tgbUID == tgb.UID &&
+		syncState.tgbGeneration == tgb.Generation &&
+		syncState.backendVersion == backendVersion &&
+		t.now().Sub(syncState.syncTime) < t.verifyInterval
+}
+
+func (t *defaultTargetsSyncTracker) MarkSynced(tgb *elbv2api.TargetGroupBinding, backendVersion string) {
+	if backendVersion == "" {
+		return
+	}
+	t.syncStateByTGBMutex.Lock()
+	defer t.syncStateByTGBMutex.Unlock()
+	t.syncStateByTGB[k8s.NamespacedName(tgb)] = targetsSyncState{
+		tgbUID:         tgb.UID,
+		tgbGeneration:  tgb.Generation,
+		backendVersion: backendVersion,
+		syncTime:       t.now(),
+	}
+}
+
+func (t *defaultTargetsSyncTracker) Forget(tgbKey types.NamespacedName) {
+	t.syncStateByTGBMutex.Lock()
+	defer t.syncStateByTGBMutex.Unlock()
+	delete(t.syncStateByTGB, tgbKey)
+}
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding (interfaces: TargetsSyncTracker)
+
+// Package targetgroupbinding is a generated GoMock package.
+package targetgroupbinding
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "k8s.io/apimachinery/pkg/types"
+	v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+)
+
+// MockTargetsSyncTracker is a mock of TargetsSyncTracker interface.
+type MockTargetsSyncTracker struct {
+	ctrl     *gomock.Controller
+	recorder *MockTargetsSyncTrackerMockRecorder
+}
+
+// MockTargetsSyncTrackerMockRecorder is the mock recorder for MockTargetsSyncTracker.
+type MockTargetsSyncTrackerMockRecorder struct {
+	mock *MockTargetsSyncTracker
+}
+
+// NewMockTargetsSyncTracker creates a new mock instance.
+func NewMockTargetsSyncTracker(ctrl *gomock.Controller) *MockTargetsSyncTracker {
+	mock := &MockTargetsSyncTracker{ctrl: ctrl}
+	mock.recorder = &MockTargetsSyncTrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTargetsSyncTracker) EXPECT() *MockTargetsSyncTrackerMockRecorder {
+	return m.recorder
+}
+
+// BackendVersion mocks base method.
+func (m *MockTargetsSyncTracker) BackendVersion(arg0 context.Context, arg1 types.NamespacedName) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackendVersion", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackendVersion indicates an expected call of BackendVersion.
+func (mr *MockTargetsSyncTrackerMockRecorder) BackendVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackendVersion", reflect.TypeOf((*MockTargetsSyncTracker)(nil).BackendVersion), arg0, arg1)
+}
+
+// Forget mocks base method.
+func (m *MockTargetsSyncTracker) Forget(arg0 types.NamespacedName) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Forget", arg0)
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockTargetsSyncTrackerMockRecorder) Forget(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockTargetsSyncTracker)(nil).Forget), arg0)
+}
+
+// IsSynced mocks base method.
+func (m *MockTargetsSyncTracker) IsSynced(arg0 *v1beta1.TargetGroupBinding, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSynced", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSynced indicates an expected call of IsSynced.
+func (mr *MockTargetsSyncTrackerMockRecorder) IsSynced(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockTargetsSyncTracker)(nil).IsSynced), arg0, arg1)
+}
+
+// MarkSynced mocks base method.
+func (m *MockTargetsSyncTracker) MarkSynced(arg0 *v1beta1.TargetGroupBinding, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkSynced", arg0, arg1)
+}
+
+// MarkSynced indicates an expected call of MarkSynced.
+func (mr *MockTargetsSyncTrackerMockRecorder) MarkSynced(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSynced", reflect.TypeOf((*MockTargetsSyncTracker)(nil).MarkSynced), arg0, arg1)
+}
@@ -0,0 +1,211 @@
+package targetgroupbinding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discv1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_defaultTargetsSyncTracker_BackendVersion(t *testing.T) {
+	svcKey := types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-svc"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+	}
+	eps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "awesome-ns", Name: "awesome-svc"},
+	}
+	buildEndpointSlice := func(name string) *discv1.EndpointSlice {
+		return &discv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "awesome-ns",
+				Name:      name,
+				Labels:    map[string]string{discv1.LabelServiceName: "awesome-svc"},
+			},
+			AddressType: discv1.AddressTypeIPv4,
+		}
+	}
+	tests := []struct {
+		name              string
+		useEndpointSlices bool
+		existingObjects   []client.Object
+		wantVersion       bool
+	}{
+		{
+			name:            "service and endpoints exist",
+			existingObjects: []client.Object{svc, eps},
+			wantVersion:     true,
+		},
+		{
+			name:        "service doesn't exist",
+			wantVersion: false,
+		},
+		{
+			name:            "endpoints doesn't exist",
+			existingObjects: []client.Object{svc},
+			wantVersion:     false,
+		},
+		{
+			name:              "service and endpointSlices exist",
+			useEndpointSlices: true,
+			existingObjects:   []client.Object{svc, buildEndpointSlice("awesome-svc-abcde"), buildEndpointSlice("awesome-svc-fghij")},
+			wantVersion:       true,
+		},
+		{
+			name:              "endpointSlices doesn't exist",
+			useEndpointSlices: true,
+			existingObjects:   []client.Object{svc, eps},
+			wantVersion:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			ctx := context.Background()
+			for _, obj := range tt.existingObjects {
+				assert.NoError(t, k8sClient.Create(ctx, obj.DeepCopyObject().(client.Object)))
+			}
+
+			tracker := NewDefaultTargetsSyncTracker(k8sClient, tt.useEndpointSlices, time.Hour)
+			version, err := tracker.BackendVersion(ctx, svcKey)
+			assert.NoError(t, err)
+			if !tt.wantVersion {
+				assert.Empty(t, version)
+				return
+			}
+			assert.NotEmpty(t, version)
+
+			// the version is stable while backend is unchanged, and changes once endpoints are updated.
+			versionAgain, err := tracker.BackendVersion(ctx, svcKey)
+			assert.NoError(t, err)
+			assert.Equal(t, version, versionAgain)
+			if tt.useEndpointSlices {
+				assert.NoError(t, k8sClient.Create(ctx, buildEndpointSlice("awesome-svc-klmno")))
+			} else {
+				gotEps := &corev1.Endpoints{}
+				assert.NoError(t, k8sClient.Get(ctx, svcKey, gotEps))
+				gotEps.Subsets = []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "192.168.1.1"}}}}
+				assert.NoError(t, k8sClient.Update(ctx, gotEps))
+			}
+			versionUpdated, err := tracker.BackendVersion(ctx, svcKey)
+			assert.NoError(t, err)
+			assert.NotEqual(t, version, versionUpdated)
+		})
+	}
+}
+
+func Test_defaultTargetsSyncTracker_IsSynced(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "awesome-ns",
+			Name:       "awesome-tgb",
+			UID:        "tgb-uid",
+			Generation: 1,
+		},
+	}
+	tgbWithNewGeneration := tgb.DeepCopy()
+	tgbWithNewGeneration.Generation = 2
+	recreatedTGB := tgb.DeepCopy()
+	recreatedTGB.UID = "another-tgb-uid"
+
+	tests := []struct {
+		name           string
+		syncedVersion  string
+		syncedAgo      time.Duration
+		forget         bool
+		tgb            *elbv2api.TargetGroupBinding
+		backendVersion string
+		want           bool
+	}{
+		{
+			name:           "synced with same backend version",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Minute,
+			tgb:            tgb,
+			backendVersion: "v1",
+			want:           true,
+		},
+		{
+			name:           "synced with another backend version",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Minute,
+			tgb:            tgb,
+			backendVersion: "v2",
+			want:           false,
+		},
+		{
+			name:           "synced beyond verify interval",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Hour,
+			tgb:            tgb,
+			backendVersion: "v1",
+			want:           false,
+		},
+		{
+			name:           "tgb spec changed since synced",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Minute,
+			tgb:            tgbWithNewGeneration,
+			backendVersion: "v1",
+			want:           false,
+		},
+		{
+			name:           "tgb recreated since synced",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Minute,
+			tgb:            recreatedTGB,
+			backendVersion: "v1",
+			want:           false,
+		},
+		{
+			name:           "sync state forgotten",
+			syncedVersion:  "v1",
+			syncedAgo:      time.Minute,
+			forget:         true,
+			tgb:            tgb,
+			backendVersion: "v1",
+			want:           false,
+		},
+		{
+			name:           "never synced",
+			tgb:            tgb,
+			backendVersion: "v1",
+			want:           false,
+		},
+		{
+			name:           "backend not found",
+			syncedVersion:  "",
+			syncedAgo:      time.Minute,
+			tgb:            tgb,
+			backendVersion: "",
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewDefaultTargetsSyncTracker(nil, false, 10*time.Minute)
+			tracker.now = func() time.Time { return now.Add(-tt.syncedAgo) }
+			tracker.MarkSynced(tgb, tt.syncedVersion)
+			if tt.forget {
+				tracker.Forget(types.NamespacedName{Namespace: "awesome-ns", Name: "awesome-tgb"})
+			}
+
+			tracker.now = func() time.Time { return now }
+			got := tracker.IsSynced(tt.tgb, tt.backendVersion)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
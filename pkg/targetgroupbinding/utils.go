@@ -2,6 +2,8 @@ package targetgroupbinding
 
 import (
 	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
@@ -13,6 +15,8 @@ const (
 	TargetHealthPodConditionTypePrefix = "target-health.elbv2.k8s.aws"
 	// Legacy Prefix for TargetHealth pod condition type(used by AWS ALB Ingress Controller)
 	TargetHealthPodConditionTypePrefixLegacy = "target-health.alb.ingress.k8s.aws"
+	// Prefix for TargetHealth pod condition type reported periodically, which never gates pod readiness.
+	TargetHealthReportPodConditionTypePrefix = "target-health-report.elbv2.k8s.aws"
 
 	// Index Key for "ServiceReference" index.
 	IndexKeyServiceRefName = "spec.serviceRef.name"
@@ -23,6 +27,25 @@ func BuildTargetHealthPodConditionType(tgb *elbv2api.TargetGroupBinding) corev1.
 	return corev1.PodConditionType(fmt.Sprintf("%s/%s", TargetHealthPodConditionTypePrefix, tgb.Name))
 }
 
+// BuildTargetHealthReportPodConditionType constructs the condition type for TargetHealth pod condition reported periodically.
+func BuildTargetHealthReportPodConditionType(tgb *elbv2api.TargetGroupBinding) corev1.PodConditionType {
+	return corev1.PodConditionType(fmt.Sprintf("%s/%s", TargetHealthReportPodConditionTypePrefix, tgb.Name))
+}
+
+// FindTargetGroupBindingNamesForPod returns the names of TargetGroupBindings that registers pod as targets,
+// which are denoted by the targetHealth readiness gates of pod.
+func FindTargetGroupBindingNamesForPod(pod *corev1.Pod) []string {
+	var tgbNames []string
+	prefix := TargetHealthPodConditionTypePrefix + "/"
+	for _, readinessGate := range pod.Spec.ReadinessGates {
+		conditionType := string(readinessGate.ConditionType)
+		if strings.HasPrefix(conditionType, prefix) {
+			tgbNames = append(tgbNames, strings.TrimPrefix(conditionType, prefix))
+		}
+	}
+	return tgbNames
+}
+
 // IndexFuncServiceRefName is IndexFunc for "ServiceReference" index.
 func IndexFuncServiceRefName(obj client.Object) []string {
 	tgb := obj.(*elbv2api.TargetGroupBinding)
@@ -0,0 +1,42 @@
+package targetgroupbinding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFindTargetGroupBindingNamesForPod(t *testing.T) {
+	tests := []struct {
+		name           string
+		readinessGates []corev1.PodReadinessGate
+		want           []string
+	}{
+		{
+			name: "pod with targetHealth readiness gates",
+			readinessGates: []corev1.PodReadinessGate{
+				{ConditionType: "target-health.elbv2.k8s.aws/tgb-1"},
+				{ConditionType: "other.k8s.aws/cond-1"},
+				{ConditionType: "target-health.elbv2.k8s.aws/tgb-2"},
+			},
+			want: []string{"tgb-1", "tgb-2"},
+		},
+		{
+			name:           "pod without readiness gates",
+			readinessGates: nil,
+			want:           nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					ReadinessGates: tt.readinessGates,
+				},
+			}
+			got := FindTargetGroupBindingNamesForPod(pod)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,6 +5,8 @@
 ~/go/bin/mockgen -package=services -destination=./pkg/aws/services/elbv2_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services ELBV2
 ~/go/bin/mockgen -package=services -destination=./pkg/aws/services/ec2_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services EC2
 ~/go/bin/mockgen -package=services -destination=./pkg/aws/services/shield_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services Shield
+~/go/bin/mockgen -package=services -destination=./pkg/aws/services/lambda_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services Lambda
+~/go/bin/mockgen -package=services -destination=./pkg/aws/services/route53_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services Route53
 ~/go/bin/mockgen -package=webhook -destination=./pkg/webhook/mutator_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/webhook Mutator
 ~/go/bin/mockgen -package=webhook -destination=./pkg/webhook/validator_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/webhook Validator
 ~/go/bin/mockgen -package=k8s -destination=./pkg/k8s/finalizer_mocks.go sigs.k8s.io/aws-load-balancer-controller/pkg/k8s FinalizerManager
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/targetgroupbinding"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	apiPathValidatePodEviction = "/validate-v1-pod-eviction"
+
+	// evictions are retried by clients like kubectl drain when rejected with TooManyRequests.
+	podEvictionRetryAfterSeconds = 10
+)
+
+// NewPodEvictionValidator returns a validator that blocks evictions of pods until their targets are drained from TargetGroups.
+// podReader should read pods from API server directly, so that pods don't need to be cached.
+// endpointResolver should be the same as the targetGroupBinding controller's, so that targets of pod are found by the IPs they're registered with.
+func NewPodEvictionValidator(k8sClient client.Client, podReader client.Reader, elbv2Client services.ELBV2, endpointResolver backend.EndpointResolver,
+	drainTimeout time.Duration, logger logr.Logger) *podEvictionValidator {
+	return &podEvictionValidator{
+		k8sClient:        k8sClient,
+		podReader:        podReader,
+		elbv2Client:      elbv2Client,
+		endpointResolver: endpointResolver,
+		drainTimeout:     drainTimeout,
+		logger:           logger,
+	}
+}
+
+var _ admission.Handler = &podEvictionValidator{}
+
+// podEvictionValidator handles the eviction subresource of pods.
+// Upon the first eviction, pod is annotated with the deregistration request time, which makes the targetGroupBinding controller deregister its targets,
+// evictions are rejected as TooManyRequests until its targets are drained or the drain timeout is exceeded.
+// the validator doesn't change targets itself, as evictions can still be rejected after it by PodDisruptionBudgets,
+// deregistration requests of pods that aren't terminating expire instead, so that their targets are registered again.
+type podEvictionValidator struct {
+	k8sClient        client.Client
+	podReader        client.Reader
+	elbv2Client      services.ELBV2
+	endpointResolver backend.EndpointResolver
+	drainTimeout     time.Duration
+	logger           logr.Logger
+}
+
+func (v *podEvictionValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if v.drainTimeout <= 0 || req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+	pod := &corev1.Pod{}
+	if err := v.podReader.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !pod.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+	tgbNames := targetgroupbinding.FindTargetGroupBindingNamesForPod(pod)
+	if len(tgbNames) == 0 {
+		return admission.Allowed("")
+	}
+
+	dryRun := awssdk.BoolValue(req.DryRun)
+	requestedAt, err := v.ensureDeregistrationRequested(ctx, pod, dryRun)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if time.Since(requestedAt) >= v.drainTimeout {
+		v.logger.Info("allowing eviction after drain timeout", "pod", k8s.NamespacedName(pod))
+		return admission.Allowed("drain timeout exceeded")
+	}
+
+	// targets are registered with IPs resolved by the endpoint resolver, which may differ from the pod IP with CNI-specific IP sources.
+	podIPs, err := v.endpointResolver.ResolvePodIPs(ctx, pod)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	var undrainedTGBNames []string
+	for _, tgbName := range tgbNames {
+		drained, err := v.isTargetsDrained(ctx, pod, podIPs, tgbName)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !drained {
+			undrainedTGBNames = append(undrainedTGBNames, tgbName)
+		}
+	}
+	if len(undrainedTGBNames) != 0 {
+		message := fmt.Sprintf("waiting for targets of pod to be drained from targetGroupBindings: %v", strings.Join(undrainedTGBNames, ","))
+		statusErr := apierrors.NewTooManyRequests(message, podEvictionRetryAfterSeconds)
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &statusErr.ErrStatus,
+			},
+		}
+	}
+	return admission.Allowed("")
+}
+
+// ensureDeregistrationRequested annotates pod with the time deregistration of its targets is requested if not annotated yet,
+// or if the previous request expired as the eviction got rejected after this validator.
+// returns the time deregistration is requested.
+func (v *podEvictionValidator) ensureDeregistrationRequested(ctx context.Context, pod *corev1.Pod, dryRun bool) (time.Time, error) {
+	now := time.Now()
+	if requestedAt := k8s.GetDeregistrationRequestedAt(pod); !requestedAt.IsZero() &&
+		now.Before(k8s.DeregistrationRequestExpiry(requestedAt, v.drainTimeout)) {
+		return requestedAt, nil
+	}
+	requestedAt := now
+	if dryRun {
+		return requestedAt, nil
+	}
+	oldPod := pod.DeepCopy()
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[k8s.AnnotationKeyDeregistrationRequestedAt] = requestedAt.UTC().Format(time.RFC3339)
+	if err := v.k8sClient.Patch(ctx, pod, client.MergeFrom(oldPod)); err != nil {
+		return time.Time{}, err
+	}
+	return requestedAt, nil
+}
+
+// isTargetsDrained checks whether targets of pod with podIPs are drained from the TargetGroup of TargetGroupBinding.
+// targets are deregistered by the targetGroupBinding controller once pod is annotated with the deregistration request.
+func (v *podEvictionValidator) isTargetsDrained(ctx context.Context, pod *corev1.Pod, podIPs []string, tgbName string) (bool, error) {
+	tgb := &elbv2api.TargetGroupBinding{}
+	if err := v.k8sClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: tgbName}, tgb); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if tgb.Spec.TargetType == nil || *tgb.Spec.TargetType != elbv2api.TargetTypeIP {
+		return true, nil
+	}
+	resp, err := v.elbv2Client.DescribeTargetHealthWithContext(ctx, &elbv2sdk.DescribeTargetHealthInput{
+		TargetGroupArn: awssdk.String(tgb.Spec.TargetGroupARN),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	podIPSet := sets.NewString(podIPs...)
+	for _, targetHealthDesc := range resp.TargetHealthDescriptions {
+		if targetHealthDesc.Target == nil || !podIPSet.Has(awssdk.StringValue(targetHealthDesc.Target.Id)) {
+			continue
+		}
+		if targetHealthDesc.TargetHealth != nil && awssdk.StringValue(targetHealthDesc.TargetHealth.State) == elbv2sdk.TargetHealthStateEnumUnused {
+			continue
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// +kubebuilder:webhook:path=/validate-v1-pod-eviction,mutating=false,failurePolicy=ignore,groups="",resources=pods/eviction,verbs=create,versions=v1,name=vpodeviction.elbv2.k8s.aws,sideEffects=NoneOnDryRun,webhookVersions=v1,admissionReviewVersions=v1beta1
+// +kubebuilder:rbac:groups="",resources=pods,verbs=patch
+
+func (v *podEvictionValidator) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(apiPathValidatePodEviction, &admission.Webhook{Handler: v})
+}
@@ -0,0 +1,291 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	elbv2sdk "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/backend"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func Test_podEvictionValidator_Handle(t *testing.T) {
+	targetTypeIP := elbv2api.TargetTypeIP
+	tgb := &elbv2api.TargetGroupBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "awesome-ns",
+			Name:      "tgb-1",
+		},
+		Spec: elbv2api.TargetGroupBindingSpec{
+			TargetGroupARN: "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-1/1234567890abcdef",
+			TargetType:     &targetTypeIP,
+		},
+	}
+	buildPod := func(annotations map[string]string, readinessGates []corev1.PodReadinessGate) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "awesome-ns",
+				Name:        "pod-1",
+				Annotations: annotations,
+			},
+			Spec: corev1.PodSpec{
+				ReadinessGates: readinessGates,
+			},
+			Status: corev1.PodStatus{
+				PodIP: "192.168.1.1",
+			},
+		}
+	}
+	multusPodAnnotations := func(annotations map[string]string) map[string]string {
+		multusAnnotations := map[string]string{
+			"k8s.v1.cni.cncf.io/network-status": `[{"name":"cbr0","interface":"eth0","ips":["192.168.1.1"],"default":true},{"name":"awesome-ns/macvlan","interface":"net1","ips":["10.1.1.1"]}]`,
+			"elbv2.k8s.aws/target-network":      "awesome-ns/macvlan",
+		}
+		for key, value := range annotations {
+			multusAnnotations[key] = value
+		}
+		return multusAnnotations
+	}
+	tgbReadinessGates := []corev1.PodReadinessGate{
+		{ConditionType: "target-health.elbv2.k8s.aws/tgb-1"},
+	}
+	buildTargetHealthDescription := func(ip string, state string) *elbv2sdk.TargetHealthDescription {
+		return &elbv2sdk.TargetHealthDescription{
+			Target: &elbv2sdk.TargetDescription{
+				Id:   awssdk.String(ip),
+				Port: awssdk.Int64(8080),
+			},
+			TargetHealth: &elbv2sdk.TargetHealth{
+				State: awssdk.String(state),
+			},
+		}
+	}
+
+	type describeTargetHealthCall struct {
+		resp *elbv2sdk.DescribeTargetHealthOutput
+	}
+	tests := []struct {
+		name                      string
+		pod                       *corev1.Pod
+		endpointResolverName      string
+		drainTimeout              time.Duration
+		describeTargetHealthCalls []describeTargetHealthCall
+		wantAllowed               bool
+		wantCode                  int32
+		wantAnnotated             bool
+		wantRequestRenewed        bool
+	}{
+		{
+			name:         "drain timeout is disabled",
+			pod:          buildPod(nil, tgbReadinessGates),
+			drainTimeout: 0,
+			wantAllowed:  true,
+		},
+		{
+			name:         "pod without targetHealth readiness gates",
+			pod:          buildPod(nil, []corev1.PodReadinessGate{{ConditionType: "other.k8s.aws/cond-1"}}),
+			drainTimeout: 5 * time.Minute,
+			wantAllowed:  true,
+		},
+		{
+			name: "pod is terminating",
+			pod: func() *corev1.Pod {
+				pod := buildPod(nil, tgbReadinessGates)
+				pod.Finalizers = []string{"example.com/finalizer"}
+				pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return pod
+			}(),
+			drainTimeout: 5 * time.Minute,
+			wantAllowed:  true,
+		},
+		{
+			name:         "first eviction requests deregistration of targets",
+			pod:          buildPod(nil, tgbReadinessGates),
+			drainTimeout: 5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("192.168.1.1", elbv2sdk.TargetHealthStateEnumHealthy),
+							buildTargetHealthDescription("192.168.1.2", elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+			wantAllowed:        false,
+			wantCode:           http.StatusTooManyRequests,
+			wantAnnotated:      true,
+			wantRequestRenewed: true,
+		},
+		{
+			name: "eviction after previous eviction got rejected requests deregistration again",
+			pod: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+			}, tgbReadinessGates),
+			drainTimeout: 5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("192.168.1.1", elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+			wantAllowed:        false,
+			wantCode:           http.StatusTooManyRequests,
+			wantAnnotated:      true,
+			wantRequestRenewed: true,
+		},
+		{
+			name: "targets are draining",
+			pod: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().UTC().Format(time.RFC3339),
+			}, tgbReadinessGates),
+			drainTimeout: 5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("192.168.1.1", elbv2sdk.TargetHealthStateEnumDraining),
+						},
+					},
+				},
+			},
+			wantAllowed:   false,
+			wantCode:      http.StatusTooManyRequests,
+			wantAnnotated: true,
+		},
+		{
+			name: "targets are drained",
+			pod: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().UTC().Format(time.RFC3339),
+			}, tgbReadinessGates),
+			drainTimeout: 5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("192.168.1.2", elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+			wantAllowed:   true,
+			wantAnnotated: true,
+		},
+		{
+			name: "targets registered with IP of multus target network are draining",
+			pod: buildPod(multusPodAnnotations(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().UTC().Format(time.RFC3339),
+			}), tgbReadinessGates),
+			endpointResolverName: backend.EndpointResolverMultus,
+			drainTimeout:         5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("10.1.1.1", elbv2sdk.TargetHealthStateEnumDraining),
+						},
+					},
+				},
+			},
+			wantAllowed:   false,
+			wantCode:      http.StatusTooManyRequests,
+			wantAnnotated: true,
+		},
+		{
+			name: "targets registered with IP of multus target network are drained",
+			pod: buildPod(multusPodAnnotations(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().UTC().Format(time.RFC3339),
+			}), tgbReadinessGates),
+			endpointResolverName: backend.EndpointResolverMultus,
+			drainTimeout:         5 * time.Minute,
+			describeTargetHealthCalls: []describeTargetHealthCall{
+				{
+					resp: &elbv2sdk.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2sdk.TargetHealthDescription{
+							buildTargetHealthDescription("10.1.1.2", elbv2sdk.TargetHealthStateEnumHealthy),
+						},
+					},
+				},
+			},
+			wantAllowed:   true,
+			wantAnnotated: true,
+		},
+		{
+			name: "drain timeout exceeded",
+			pod: buildPod(map[string]string{
+				"elbv2.k8s.aws/deregistration-requested-at": time.Now().Add(-5*time.Minute - 30*time.Second).UTC().Format(time.RFC3339),
+			}, tgbReadinessGates),
+			drainTimeout:  5 * time.Minute,
+			wantAllowed:   true,
+			wantAnnotated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			elbv2api.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			assert.NoError(t, k8sClient.Create(ctx, tgb.DeepCopy()))
+			assert.NoError(t, k8sClient.Create(ctx, tt.pod.DeepCopy()))
+
+			elbv2Client := services.NewMockELBV2(ctrl)
+			for _, call := range tt.describeTargetHealthCalls {
+				elbv2Client.EXPECT().DescribeTargetHealthWithContext(gomock.Any(), gomock.Any()).Return(call.resp, nil)
+			}
+
+			endpointResolverName := tt.endpointResolverName
+			if endpointResolverName == "" {
+				endpointResolverName = backend.EndpointResolverEndpoints
+			}
+			endpointResolver, err := backend.NewDefaultEndpointResolverRegistry().Build(endpointResolverName, k8sClient, nil, &log.NullLogger{})
+			assert.NoError(t, err)
+
+			v := NewPodEvictionValidator(k8sClient, k8sClient, elbv2Client, endpointResolver, tt.drainTimeout, &log.NullLogger{})
+			resp := v.Handle(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation:   admissionv1.Create,
+					Namespace:   "awesome-ns",
+					Name:        "pod-1",
+					SubResource: "eviction",
+				},
+			})
+			assert.Equal(t, tt.wantAllowed, resp.Allowed)
+			if !tt.wantAllowed {
+				assert.Equal(t, tt.wantCode, resp.Result.Code)
+			}
+
+			gotPod := &corev1.Pod{}
+			assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "awesome-ns", Name: "pod-1"}, gotPod))
+			_, annotated := gotPod.Annotations["elbv2.k8s.aws/deregistration-requested-at"]
+			assert.Equal(t, tt.wantAnnotated, annotated)
+			if tt.wantRequestRenewed {
+				requestedAt := k8s.GetDeregistrationRequestedAt(gotPod)
+				assert.True(t, time.Since(requestedAt) < time.Minute)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"net"
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/aws/services"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
@@ -48,6 +49,9 @@ func (v *targetGroupBindingValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkExternalTargets(tgb); err != nil {
+		return err
+	}
 	if err := v.checkExistingTargetGroups(tgb); err != nil {
 		return err
 	}
@@ -69,6 +73,9 @@ func (v *targetGroupBindingValidator) ValidateUpdate(ctx context.Context, obj ru
 	if err := v.checkNodeSelector(tgb); err != nil {
 		return err
 	}
+	if err := v.checkExternalTargets(tgb); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -136,6 +143,22 @@ func (v *targetGroupBindingValidator) checkNodeSelector(tgb *elbv2api.TargetGrou
 	return nil
 }
 
+// checkExternalTargets ensures that ExternalTargets is only set when TargetType is ip, and are valid IP addresses
+func (v *targetGroupBindingValidator) checkExternalTargets(tgb *elbv2api.TargetGroupBinding) error {
+	if len(tgb.Spec.ExternalTargets) == 0 {
+		return nil
+	}
+	if *tgb.Spec.TargetType != elbv2api.TargetTypeIP {
+		return errors.Errorf("TargetGroupBinding cannot set ExternalTargets when TargetType is %v", *tgb.Spec.TargetType)
+	}
+	for _, externalTarget := range tgb.Spec.ExternalTargets {
+		if net.ParseIP(externalTarget.IP) == nil {
+			return errors.Errorf("TargetGroupBinding has invalid ExternalTarget IP: %v", externalTarget.IP)
+		}
+	}
+	return nil
+}
+
 // checkTargetGroupIPAddressType ensures IP address type matches with that on the AWS target group
 func (v *targetGroupBindingValidator) checkTargetGroupIPAddressType(ctx context.Context, tgb *elbv2api.TargetGroupBinding) error {
 	targetGroupIPAddressType, err := v.getTargetGroupIPAddressTypeFromAWS(ctx, tgb.Spec.TargetGroupARN)
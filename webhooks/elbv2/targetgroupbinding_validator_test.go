@@ -904,3 +904,84 @@ func Test_targetGroupBindingValidator_checkExistingTargetGroups(t *testing.T) {
 		})
 	}
 }
+
+func Test_targetGroupBindingValidator_checkExternalTargets(t *testing.T) {
+	type args struct {
+		tgb *elbv2api.TargetGroupBinding
+	}
+	instanceTargetType := elbv2api.TargetTypeInstance
+	ipTargetType := elbv2api.TargetTypeIP
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "[ok] externalTargets is empty",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[ok] targetType is ip, externalTargets is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+						ExternalTargets: []elbv2api.ExternalTarget{
+							{IP: "192.168.1.1", Port: 8080},
+							{IP: "2600:1f14:f8c:2701::1", Port: 8080},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "[err] targetType is instance, externalTargets is set",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &instanceTargetType,
+						ExternalTargets: []elbv2api.ExternalTarget{
+							{IP: "192.168.1.1", Port: 8080},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding cannot set ExternalTargets when TargetType is instance"),
+		},
+		{
+			name: "[err] externalTargets has invalid IP",
+			args: args{
+				tgb: &elbv2api.TargetGroupBinding{
+					Spec: elbv2api.TargetGroupBindingSpec{
+						TargetType: &ipTargetType,
+						ExternalTargets: []elbv2api.ExternalTarget{
+							{IP: "my-vm.example.com", Port: 8080},
+						},
+					},
+				},
+			},
+			wantErr: errors.New("TargetGroupBinding has invalid ExternalTarget IP: my-vm.example.com"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &targetGroupBindingValidator{
+				logger: &log.NullLogger{},
+			}
+			err := v.checkExternalTargets(tt.args.tgb)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,15 +2,22 @@ package networking
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations/schema"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/config"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,6 +28,15 @@ const (
 	apiPathValidateNetworkingIngress = "/validate-networking-v1-ingress"
 )
 
+const (
+	// virtual resource authorized via SubjectAccessReview for Ingresses referencing explicit IngressGroups.
+	ingressGroupAccessResource = "ingressgroups"
+	// verb to join an explicit IngressGroup.
+	ingressGroupAccessVerbJoin = "join"
+	// verb to set the order of Ingress within an explicit IngressGroup.
+	ingressGroupAccessVerbOrder = "order"
+)
+
 // NewIngressValidator returns a validator for Ingress API.
 func NewIngressValidator(client client.Client, ingConfig config.IngressConfig, logger logr.Logger) *ingressValidator {
 	return &ingressValidator{
@@ -29,6 +45,9 @@ func NewIngressValidator(client client.Client, ingConfig config.IngressConfig, l
 		classLoader:                   ingress.NewDefaultClassLoader(client),
 		disableIngressClassAnnotation: ingConfig.DisableIngressClassAnnotation,
 		disableIngressGroupAnnotation: ingConfig.DisableIngressGroupNameAnnotation,
+		strictIngressAnnotations:      ingConfig.StrictIngressAnnotations,
+		enableGroupAccessReview:       ingConfig.EnableGroupAccessReview,
+		accessReviewer:                k8s.NewDefaultAccessReviewer(client),
 		logger:                        logger,
 	}
 }
@@ -41,6 +60,9 @@ type ingressValidator struct {
 	classLoader                   ingress.ClassLoader
 	disableIngressClassAnnotation bool
 	disableIngressGroupAnnotation bool
+	strictIngressAnnotations      bool
+	enableGroupAccessReview       bool
+	accessReviewer                k8s.AccessReviewer
 	logger                        logr.Logger
 }
 
@@ -59,6 +81,12 @@ func (v *ingressValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 	if err := v.checkIngressClassUsage(ctx, ing, nil); err != nil {
 		return err
 	}
+	if err := v.checkIngressGroupAccess(ctx, ing, nil); err != nil {
+		return err
+	}
+	if err := v.checkUnknownAnnotations(ing); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -74,6 +102,12 @@ func (v *ingressValidator) ValidateUpdate(ctx context.Context, obj runtime.Objec
 	if err := v.checkIngressClassUsage(ctx, ing, oldIng); err != nil {
 		return err
 	}
+	if err := v.checkIngressGroupAccess(ctx, ing, oldIng); err != nil {
+		return err
+	}
+	if err := v.checkUnknownAnnotations(ing); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -164,6 +198,82 @@ func (v *ingressValidator) checkIngressClassUsage(ctx context.Context, ing *netw
 	return nil
 }
 
+// checkIngressGroupAccess checks whether the user is authorized to join an explicit IngressGroup, or to set the order within it.
+// Ingresses of an IngressGroup share an ALB and their rules take precedence over each other by order,
+// so that referencing IngressGroups owned by others must be authorized via SubjectAccessReview once enabled.
+func (v *ingressValidator) checkIngressGroupAccess(ctx context.Context, ing *networking.Ingress, oldIng *networking.Ingress) error {
+	if !v.enableGroupAccessReview {
+		return nil
+	}
+	admissionReq := webhook.ContextGetAdmissionRequest(ctx)
+	if admissionReq == nil {
+		return nil
+	}
+	newGroupName := ""
+	if exists := v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &newGroupName, ing.Annotations); !exists {
+		return nil
+	}
+	newGroupOrder := ""
+	newGroupOrderExists := v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupOrder, &newGroupOrder, ing.Annotations)
+	oldGroupName := ""
+	oldGroupOrder := ""
+	oldGroupOrderExists := false
+	if oldIng != nil {
+		v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupName, &oldGroupName, oldIng.Annotations)
+		oldGroupOrderExists = v.annotationParser.ParseStringAnnotation(annotations.IngressSuffixGroupOrder, &oldGroupOrder, oldIng.Annotations)
+	}
+
+	isGroupJoined := newGroupName != oldGroupName
+	if isGroupJoined {
+		if err := v.reviewIngressGroupAccess(ctx, admissionReq.UserInfo, ingressGroupAccessVerbJoin, newGroupName); err != nil {
+			return err
+		}
+	}
+	if newGroupOrderExists && (isGroupJoined || !oldGroupOrderExists || newGroupOrder != oldGroupOrder) {
+		if err := v.reviewIngressGroupAccess(ctx, admissionReq.UserInfo, ingressGroupAccessVerbOrder, newGroupName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reviewIngressGroupAccess reviews whether user is authorized to perform verb on the IngressGroup.
+func (v *ingressValidator) reviewIngressGroupAccess(ctx context.Context, user authenticationv1.UserInfo, verb string, groupName string) error {
+	allowed, reason, err := v.accessReviewer.Review(ctx, user, authorizationv1.ResourceAttributes{
+		Verb:     verb,
+		Group:    elbv2api.GroupVersion.Group,
+		Resource: ingressGroupAccessResource,
+		Name:     groupName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to review access to IngressGroup %v", groupName)
+	}
+	if allowed {
+		return nil
+	}
+	message := fmt.Sprintf("user %q is not allowed to %v IngressGroup %q, which requires permission to %q the %q resource named %q in the %q API group",
+		user.Username, verb, groupName, verb, ingressGroupAccessResource, groupName, elbv2api.GroupVersion.Group)
+	if reason != "" {
+		message = fmt.Sprintf("%v: %v", message, reason)
+	}
+	return errors.New(message)
+}
+
+// checkUnknownAnnotations checks the usage of annotations with "alb.ingress.kubernetes.io" prefix.
+// annotations unknown to this controller or with invalid values are rejected once strict mode is enabled,
+// so that typos in annotations won't be silently ignored.
+func (v *ingressValidator) checkUnknownAnnotations(ing *networking.Ingress) error {
+	if !v.strictIngressAnnotations {
+		return nil
+	}
+	unknownAnnotations := schema.FindUnknownIngressAnnotations(ing.Annotations)
+	if len(unknownAnnotations) != 0 {
+		return errors.Errorf("unknown annotations are forbidden: %s", strings.Join(unknownAnnotations, ","))
+	}
+	return schema.ValidateIngressAnnotations(ing.Annotations)
+}
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:webhook:path=/validate-networking-v1-ingress,mutating=false,failurePolicy=fail,groups=networking.k8s.io,resources=ingresses,verbs=create;update,versions=v1,name=vingress.elbv2.k8s.aws,sideEffects=None,matchPolicy=Equivalent,webhookVersions=v1,admissionReviewVersions=v1beta1
 
 func (v *ingressValidator) SetupWithManager(mgr ctrl.Manager) {
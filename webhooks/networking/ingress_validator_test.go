@@ -6,6 +6,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,8 +17,11 @@ import (
 	elbv2api "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/annotations"
 	"sigs.k8s.io/aws-load-balancer-controller/pkg/ingress"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/k8s"
+	"sigs.k8s.io/aws-load-balancer-controller/pkg/webhook"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"testing"
 )
 
@@ -785,3 +791,338 @@ func Test_ingressValidator_checkIngressClassUsage(t *testing.T) {
 		})
 	}
 }
+
+func Test_ingressValidator_checkIngressGroupAccess(t *testing.T) {
+	type reviewCall struct {
+		resourceAttributes authorizationv1.ResourceAttributes
+		allowed            bool
+		reason             string
+	}
+	type fields struct {
+		enableGroupAccessReview bool
+	}
+	type args struct {
+		ing    *networking.Ingress
+		oldIng *networking.Ingress
+	}
+	user := authenticationv1.UserInfo{
+		Username: "alice",
+		Groups:   []string{"team-a"},
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		reviewCalls []reviewCall
+		wantErr     error
+	}{
+		{
+			name: "ingress creation with group.name annotation - when access review disabled",
+			fields: fields{
+				enableGroupAccessReview: false,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "awesome-group",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress creation without group.name annotation",
+			fields: fields{
+				enableGroupAccessReview: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.order": "10",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress creation with group.name and group.order annotation - when allowed",
+			fields: fields{
+				enableGroupAccessReview: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name":  "awesome-group",
+							"alb.ingress.kubernetes.io/group.order": "10",
+						},
+					},
+				},
+			},
+			reviewCalls: []reviewCall{
+				{
+					resourceAttributes: authorizationv1.ResourceAttributes{
+						Verb:     "join",
+						Group:    "elbv2.k8s.aws",
+						Resource: "ingressgroups",
+						Name:     "awesome-group",
+					},
+					allowed: true,
+				},
+				{
+					resourceAttributes: authorizationv1.ResourceAttributes{
+						Verb:     "order",
+						Group:    "elbv2.k8s.aws",
+						Resource: "ingressgroups",
+						Name:     "awesome-group",
+					},
+					allowed: true,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress creation with group.name annotation - when denied",
+			fields: fields{
+				enableGroupAccessReview: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name": "awesome-group",
+						},
+					},
+				},
+			},
+			reviewCalls: []reviewCall{
+				{
+					resourceAttributes: authorizationv1.ResourceAttributes{
+						Verb:     "join",
+						Group:    "elbv2.k8s.aws",
+						Resource: "ingressgroups",
+						Name:     "awesome-group",
+					},
+					allowed: false,
+				},
+			},
+			wantErr: errors.New(`user "alice" is not allowed to join IngressGroup "awesome-group", which requires permission to "join" the "ingressgroups" resource named "awesome-group" in the "elbv2.k8s.aws" API group`),
+		},
+		{
+			name: "ingress update with unchanged group.name and group.order annotation",
+			fields: fields{
+				enableGroupAccessReview: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name":  "awesome-group",
+							"alb.ingress.kubernetes.io/group.order": "10",
+						},
+					},
+				},
+				oldIng: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name":  "awesome-group",
+							"alb.ingress.kubernetes.io/group.order": "10",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress update with changed group.order annotation - when denied with reason",
+			fields: fields{
+				enableGroupAccessReview: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name":  "awesome-group",
+							"alb.ingress.kubernetes.io/group.order": "1",
+						},
+					},
+				},
+				oldIng: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/group.name":  "awesome-group",
+							"alb.ingress.kubernetes.io/group.order": "10",
+						},
+					},
+				},
+			},
+			reviewCalls: []reviewCall{
+				{
+					resourceAttributes: authorizationv1.ResourceAttributes{
+						Verb:     "order",
+						Group:    "elbv2.k8s.aws",
+						Resource: "ingressgroups",
+						Name:     "awesome-group",
+					},
+					allowed: false,
+					reason:  "no RBAC policy matched",
+				},
+			},
+			wantErr: errors.New(`user "alice" is not allowed to order IngressGroup "awesome-group", which requires permission to "order" the "ingressgroups" resource named "awesome-group" in the "elbv2.k8s.aws" API group: no RBAC policy matched`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accessReviewer := k8s.NewMockAccessReviewer(ctrl)
+			for _, call := range tt.reviewCalls {
+				accessReviewer.EXPECT().Review(gomock.Any(), user, call.resourceAttributes).Return(call.allowed, call.reason, nil)
+			}
+			v := &ingressValidator{
+				annotationParser:        annotations.NewSuffixAnnotationParser("alb.ingress.kubernetes.io"),
+				enableGroupAccessReview: tt.fields.enableGroupAccessReview,
+				accessReviewer:          accessReviewer,
+				logger:                  &log.NullLogger{},
+			}
+			ctx := webhook.ContextWithAdmissionRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: user,
+				},
+			})
+			err := v.checkIngressGroupAccess(ctx, tt.args.ing, tt.args.oldIng)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ingressValidator_checkUnknownAnnotations(t *testing.T) {
+	type fields struct {
+		strictIngressAnnotations bool
+	}
+	type args struct {
+		ing *networking.Ingress
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ingress with unknown annotations - when strict mode disabled",
+			fields: fields{
+				strictIngressAnnotations: false,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/helathcheck-path": "/healthz",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with known annotations - when strict mode enabled",
+			fields: fields{
+				strictIngressAnnotations: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/healthcheck-path": "/healthz",
+							"alb.ingress.kubernetes.io/actions.blue":     "{}",
+							"kubernetes.io/ingress.class":                "alb",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ingress with unknown annotations - when strict mode enabled",
+			fields: fields{
+				strictIngressAnnotations: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/helathcheck-path": "/healthz",
+							"alb.ingress.kubernetes.io/scheme":           "internal",
+							"alb.ingress.kubernetes.io/sheme":            "internal",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("unknown annotations are forbidden: alb.ingress.kubernetes.io/helathcheck-path,alb.ingress.kubernetes.io/sheme"),
+		},
+		{
+			name: "ingress with invalid annotation value - when strict mode enabled",
+			fields: fields{
+				strictIngressAnnotations: true,
+			},
+			args: args{
+				ing: &networking.Ingress{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns-1",
+						Name:      "ing-1",
+						Annotations: map[string]string{
+							"alb.ingress.kubernetes.io/scheme": "public",
+						},
+					},
+				},
+			},
+			wantErr: errors.New("invalid annotation alb.ingress.kubernetes.io/scheme: value must be within [internal, internet-facing], got public"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ingressValidator{
+				strictIngressAnnotations: tt.fields.strictIngressAnnotations,
+				logger:                   &log.NullLogger{},
+			}
+			err := v.checkUnknownAnnotations(tt.args.ing)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}